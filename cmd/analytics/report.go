@@ -0,0 +1,55 @@
+package main
+
+import (
+	"github.com/adinandradrs/cezbek-engine/internal/cdi"
+	_ "github.com/adinandradrs/cezbek-engine/internal/docs"
+	"github.com/adinandradrs/cezbek-engine/internal/handler"
+	"github.com/adinandradrs/cezbek-engine/internal/handler/middleware"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/cors"
+	swagger "github.com/swaggo/fiber-swagger"
+)
+
+func main() {
+	//load Contexts and Dependency Injection (CDI)
+	c := cdi.NewContainer("app_cezbek_analytics")
+	env := c.LoadEnv()
+	infra := c.LoadInfra()
+	redis := c.LoadRedis()
+	ucase := c.RegisterAnalyticsUsecase(redis)
+
+	//app starting
+	api := fiber.New()
+
+	//middleware config
+	api.Use(cors.New())
+	jwtAuthenticator := middleware.NewJwtAuthenticator(&middleware.JwtAuthenticator{
+		Logger:      c.Logger,
+		CiamPartner: infra.CiamPartner,
+		Cacher:      redis,
+	})
+	jwtAuthPartnerFilter := jwtAuthenticator.PartnerFilter()
+	adminAuthenticator := middleware.NewAdminAuthenticator(&middleware.AdminAuthenticator{
+		Logger: c.Logger,
+		ApiKey: env.BackOfficeKey,
+	})
+
+	//swagger
+	api.Get(env.ContextPath+"/swagger/*", swagger.WrapHandler)
+	handler.DefaultHandler(api, env.ContextPath)
+
+	//APIs
+	reports := api.Group("/api/v1/analytics").Use(c.HttpLogger)
+	handler.AnalyticsHandler(reports, handler.Analytics{
+		ReportProvider: ucase.ReportProvider,
+		AdminFilter:    adminAuthenticator.AdminFilter(),
+	})
+
+	partnerReports := api.Group("/api/partner/v1/analytics").Use(c.HttpLogger)
+	handler.PartnerAnalyticsHandler(partnerReports, handler.PartnerAnalytics{
+		ReportProvider: ucase.ReportProvider,
+		PartnerFilter:  jwtAuthPartnerFilter,
+	})
+
+	_ = api.Listen(env.HttpPort)
+}
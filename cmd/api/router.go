@@ -54,10 +54,18 @@ func main() {
 	})
 	jwtAuthClientFilter := jwtAuthenticator.ClientFilter()
 	jwtAuthPartnerFilter := jwtAuthenticator.PartnerFilter()
+	adminAuthenticator := middleware.NewAdminAuthenticator(&middleware.AdminAuthenticator{
+		Logger: c.Logger,
+		ApiKey: env.BackOfficeKey,
+	})
+	adminAuthFilter := adminAuthenticator.AdminFilter()
 
 	//swagger
 	api.Get(env.ContextPath+"/swagger/*", swagger.WrapHandler)
 	handler.DefaultHandler(api, env.ContextPath)
+	if infra.Jwks != "" {
+		handler.JwksHandler(api, env.ContextPath, infra.Jwks)
+	}
 
 	//APIs
 	authorization := api.Group("/api/v1/authorization").Use(c.HttpLogger)
@@ -65,11 +73,55 @@ func main() {
 		PartnerOnboardProvider: ucase.PartnerOnboardProvider,
 		ClientOnboardProvider:  ucase.ClientOnboardProvider,
 		ClientFilter:           preAuthClientFilter,
+		JwtClientFilter:        jwtAuthClientFilter,
+		JwtPartnerFilter:       jwtAuthPartnerFilter,
 	})
 
 	partners := api.Group("/api/v1/partners").Use(c.HttpLogger)
 	handler.PartnerManagementHandler(partners, handler.PartnerManagement{
 		PartnerManager: ucase.PartnerManager,
+		AdminFilter:    adminAuthFilter,
+	})
+
+	templates := api.Group("/api/v1/templates").Use(c.HttpLogger)
+	handler.TemplateHandler(templates, handler.Template{
+		TemplateProvider: ucase.TemplateProvider,
+		AdminFilter:      adminAuthFilter,
+	})
+
+	notifications := api.Group("/api/v1/notifications").Use(c.HttpLogger)
+	handler.NotificationHandler(notifications, handler.Notification{
+		LogProvider: ucase.NotificationLogProvider,
+		AdminFilter: adminAuthFilter,
+	})
+
+	unsubscribe := api.Group("/api/v1/unsubscribe").Use(c.HttpLogger)
+	handler.UnsubscribeHandler(unsubscribe, handler.Unsubscribe{
+		PreferenceProvider: ucase.PreferenceProvider,
+	})
+
+	invoices := api.Group("/api/v1/invoices").Use(c.HttpLogger)
+	handler.InvoiceHandler(invoices, handler.Invoice{
+		InvoiceManager: ucase.InvoiceManager,
+		AdminFilter:    adminAuthFilter,
+	})
+
+	settlements := api.Group("/api/v1/settlements").Use(c.HttpLogger)
+	handler.SettlementHandler(settlements, handler.Settlement{
+		SettlementManager: ucase.SettlementManager,
+		AdminFilter:       adminAuthFilter,
+	})
+
+	ledgers := api.Group("/api/v1/ledgers").Use(c.HttpLogger)
+	handler.LedgerHandler(ledgers, handler.Ledger{
+		LedgerManager: ucase.LedgerManager,
+		AdminFilter:   adminAuthFilter,
+	})
+
+	deposits := api.Group("/api/v1/deposits").Use(c.HttpLogger)
+	handler.DepositHandler(deposits, handler.Deposit{
+		DepositManager: ucase.DepositManager,
+		AdminFilter:    adminAuthFilter,
 	})
 
 	cashbacks := api.Group("/api/v1/cashbacks").Use(c.HttpLogger)
@@ -84,6 +136,36 @@ func main() {
 		PartnerFilter:       jwtAuthPartnerFilter,
 	})
 
+	partnerOfficers := api.Group("/api/partner/v1/officers")
+	handler.PartnerOfficerHandler(partnerOfficers, handler.PartnerOfficer{
+		OfficerProvider: ucase.PartnerOfficerProvider,
+		PartnerFilter:   jwtAuthPartnerFilter,
+	})
+
+	partnerSuppressions := api.Group("/api/partner/v1/suppressions")
+	handler.PartnerSuppressionHandler(partnerSuppressions, handler.PartnerSuppression{
+		SuppressionProvider: ucase.PartnerSuppressionProvider,
+		PartnerFilter:       jwtAuthPartnerFilter,
+	})
+
+	partnerInvoices := api.Group("/api/partner/v1/invoices")
+	handler.PartnerInvoiceHandler(partnerInvoices, handler.PartnerInvoice{
+		InvoiceProvider: ucase.PartnerInvoiceProvider,
+		PartnerFilter:   jwtAuthPartnerFilter,
+	})
+
+	partnerDeposit := api.Group("/api/partner/v1/deposit")
+	handler.PartnerDepositHandler(partnerDeposit, handler.PartnerDeposit{
+		DepositProvider: ucase.PartnerDepositProvider,
+		PartnerFilter:   jwtAuthPartnerFilter,
+	})
+
+	partnerDashboard := api.Group("/api/partner/v1/dashboard")
+	handler.PartnerDashboardHandler(partnerDashboard, handler.PartnerDashboard{
+		ReportProvider: ucase.PartnerReportProvider,
+		PartnerFilter:  jwtAuthPartnerFilter,
+	})
+
 	_ = api.Listen(env.HttpPort)
 }
 
@@ -127,6 +209,13 @@ func onStartupLoadParameterCache(p management.ParamManager, logger *zap.Logger)
 		}
 	}()
 
+	go func() {
+		ex := p.CacheNotificationChannels()
+		if ex != nil {
+			logger.Panic("failed to load notification channels")
+		}
+	}()
+
 	go func() {
 		ex := p.CacheWallets()
 		if ex != nil {
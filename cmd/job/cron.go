@@ -1,12 +1,17 @@
 package main
 
 import (
+	"context"
 	"github.com/adinandradrs/cezbek-engine/internal/cdi"
 	"github.com/go-co-op/gocron"
 	rv8 "github.com/go-redis/redis/v8"
 	"github.com/go-redsync/redsync/v4"
 	"github.com/go-redsync/redsync/v4/redis/goredis/v8"
 	"go.uber.org/zap"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 	"time"
 )
 
@@ -27,10 +32,28 @@ func main() {
 			c.LoadRedis()),
 		Redsync: redsync.New(pool),
 	}
+	if len(os.Args) > 4 && os.Args[2] == "snapshot" {
+		r.rebuildSnapshot(os.Args[3], os.Args[4])
+		return
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	var wg sync.WaitGroup
 	r.onStartupJobExpireTier()
-	r.onStartupJobSendInvoiceEmail()
-	r.onStartupJobSendOtpEmail()
-	job.StartBlocking()
+	r.onStartupJobBilling()
+	r.onStartupJobSnapshot()
+	r.onStartupConsumer(ctx, &wg, "send_invoice_email", r.JobTransactionWatcher.ConsumeInvoiceEmail)
+	r.onStartupConsumer(ctx, &wg, "send_otp_email", r.JobOnboardWatcher.ConsumeOtpEmail)
+	r.onStartupConsumer(ctx, &wg, "email_feedback", r.JobFeedbackWatcher.ConsumeEmailFeedback)
+	r.onStartupConsumer(ctx, &wg, "transaction_export", r.JobExportWatcher.ConsumeTransactionExport)
+	r.onStartupConsumer(ctx, &wg, "cashback_reconciliation", r.JobReconciliationWatcher.ConsumeCashbackReconciliation)
+	job.StartAsync()
+
+	<-ctx.Done()
+	c.Logger.Info("cezbek cron job is shutting down, waiting for in-flight messages...")
+	job.Stop()
+	wg.Wait()
+	c.Logger.Info("cezbek cron job is stopped")
 }
 
 type runner struct {
@@ -57,36 +80,60 @@ func (r *runner) onStartupJobExpireTier() {
 	}
 }
 
-func (r *runner) onStartupJobSendInvoiceEmail() {
-	_, err := r.Every(r.Viper.GetString("schedule.send_invoice_email")).Do(func() {
-		r.Logger.Info("send_invoice_email running...")
-		mtx := r.NewMutex("send_invoice_email")
+func (r *runner) onStartupJobBilling() {
+	_, err := r.Cron(r.Viper.GetString("schedule.billing")).Do(func() {
+		r.Logger.Info("billing running...")
+		mtx := r.NewMutex("billing")
 		if err := mtx.Lock(); err != nil {
-			r.Logger.Error("send_invoice_email lock", zap.Error(err))
+			r.Logger.Error("billing lock", zap.Error(err))
 		}
-		_ = r.JobTransactionWatcher.SendInvoiceEmail()
+		r.JobBillingWatcher.Bill()
 		if ok, err := mtx.Unlock(); !ok || err != nil {
-			r.Logger.Error("send_invoice_email unlock", zap.Error(err))
+			r.Logger.Error("billing unlock", zap.Error(err))
 		}
 	})
 	if err != nil {
-		r.Logger.Panic("cezbek cron job is failing to run [JobTransactionWatcher.SendInvoiceEmail]")
+		r.Logger.Panic("cezbek cron job is failing to run [JobBillingWatcher.Bill]")
 	}
 }
 
-func (r *runner) onStartupJobSendOtpEmail() {
-	_, err := r.Every(r.Viper.GetString("schedule.send_otp_email")).Do(func() {
-		r.Logger.Info("send_otp_email running...")
-		mtx := r.NewMutex("send_otp_email")
+func (r *runner) onStartupJobSnapshot() {
+	_, err := r.Cron(r.Viper.GetString("schedule.snapshot")).Do(func() {
+		r.Logger.Info("snapshot running...")
+		mtx := r.NewMutex("snapshot")
 		if err := mtx.Lock(); err != nil {
-			r.Logger.Error("send_otp_email lock", zap.Error(err))
+			r.Logger.Error("snapshot lock", zap.Error(err))
 		}
-		_ = r.JobOnboardWatcher.SendOtpEmail()
+		r.JobSnapshotWatcher.Build()
 		if ok, err := mtx.Unlock(); !ok || err != nil {
-			r.Logger.Error("send_otp_email unlock", zap.Error(err))
+			r.Logger.Error("snapshot unlock", zap.Error(err))
 		}
 	})
 	if err != nil {
-		r.Logger.Panic("cezbek cron job is failing to run [JobOnboardWatcher.SendOtpEmail]")
+		r.Logger.Panic("cezbek cron job is failing to run [JobSnapshotWatcher.Build]")
+	}
+}
+
+func (r *runner) rebuildSnapshot(start string, end string) {
+	r.Logger.Info("snapshot rebuild running...", zap.String("start", start), zap.String("end", end))
+	mtx := r.NewMutex("snapshot")
+	if err := mtx.Lock(); err != nil {
+		r.Logger.Error("snapshot lock", zap.Error(err))
 	}
+	bx := r.JobSnapshotWatcher.Rebuild(start, end)
+	if ok, err := mtx.Unlock(); !ok || err != nil {
+		r.Logger.Error("snapshot unlock", zap.Error(err))
+	}
+	if bx != nil {
+		r.Logger.Fatal("snapshot rebuild is failed", zap.String("code", bx.ErrorCode))
+	}
+}
+
+func (r *runner) onStartupConsumer(ctx context.Context, wg *sync.WaitGroup, name string, consume func(ctx context.Context)) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		r.Logger.Info(name + " consumer running...")
+		consume(ctx)
+	}()
 }
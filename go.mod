@@ -16,6 +16,8 @@ require (
 	github.com/georgysavva/scany v1.1.0
 	github.com/go-co-op/gocron v1.18.0
 	github.com/go-redis/redis v6.15.9+incompatible
+	github.com/go-redis/redis/v8 v8.11.5
+	github.com/go-redsync/redsync/v4 v4.7.1
 	github.com/goccy/go-json v0.9.7
 	github.com/gofiber/fiber/v2 v2.40.1
 	github.com/gojek/heimdall/v7 v7.0.2
@@ -27,6 +29,7 @@ require (
 	github.com/stretchr/testify v1.8.1
 	github.com/swaggo/fiber-swagger v1.3.0
 	github.com/swaggo/swag v1.8.1
+	golang.org/x/crypto v0.1.0
 )
 
 require (
@@ -53,8 +56,6 @@ require (
 	github.com/go-openapi/swag v0.19.15 // indirect
 	github.com/go-playground/locales v0.14.0 // indirect
 	github.com/go-playground/universal-translator v0.18.0 // indirect
-	github.com/go-redis/redis/v8 v8.11.5 // indirect
-	github.com/go-redsync/redsync/v4 v4.7.1 // indirect
 	github.com/gogo/protobuf v1.3.2 // indirect
 	github.com/gojek/valkyrie v0.0.0-20180215180059-6aee720afcdf // indirect
 	github.com/golang/groupcache v0.0.0-20210331224755-41bb18bfe9da // indirect
@@ -121,7 +122,6 @@ require (
 	go.opencensus.io v0.23.0 // indirect
 	go.uber.org/atomic v1.9.0 // indirect
 	go.uber.org/multierr v1.8.0 // indirect
-	golang.org/x/crypto v0.1.0 // indirect
 	golang.org/x/net v0.4.0 // indirect
 	golang.org/x/oauth2 v0.0.0-20221014153046-6fdb5e3db783 // indirect
 	golang.org/x/sync v0.1.0 // indirect
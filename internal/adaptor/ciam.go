@@ -20,6 +20,9 @@ type CiamWatcher interface {
 	JwtInfo(t string) (map[string]interface{}, *model.TechnicalError)
 	OnboardPartner(m model.CiamOnboardPartnerRequest) (*model.CiamUserResponse, *model.TechnicalError)
 	Authenticate(m model.CiamAuthenticationRequest) (*model.CiamAuthenticationResponse, *model.TechnicalError)
+	Refresh(m model.CiamRefreshRequest) (*model.CiamAuthenticationResponse, *model.TechnicalError)
+	SignOut(accessToken string) *model.TechnicalError
+	RevokeUser(username string) *model.TechnicalError
 }
 
 type (
@@ -139,3 +142,45 @@ func (c *Cognito) Authenticate(m model.CiamAuthenticationRequest) (*model.CiamAu
 		ExpiresIn:    *out.AuthenticationResult.ExpiresIn,
 	}, nil
 }
+
+func (c *Cognito) Refresh(m model.CiamRefreshRequest) (*model.CiamAuthenticationResponse, *model.TechnicalError) {
+	inp := &cognito.InitiateAuthInput{
+		AuthFlow: aws.String(cognito.AuthFlowTypeRefreshTokenAuth),
+		AuthParameters: map[string]*string{
+			"REFRESH_TOKEN": aws.String(m.RefreshToken),
+			"SECRET_HASH":   aws.String(c.secretHash(m.Username)),
+		},
+		ClientId: aws.String(c.ClientId),
+	}
+	out, err := c.Provider.InitiateAuth(inp)
+	if err != nil {
+		return nil, apps.Exception("failed to refresh user token", err, zap.String("username", m.Username), c.Logger)
+	}
+	return &model.CiamAuthenticationResponse{
+		AccessToken:  *out.AuthenticationResult.AccessToken,
+		Token:        *out.AuthenticationResult.IdToken,
+		RefreshToken: m.RefreshToken,
+		ExpiresIn:    *out.AuthenticationResult.ExpiresIn,
+	}, nil
+}
+
+func (c *Cognito) SignOut(accessToken string) *model.TechnicalError {
+	_, err := c.Provider.GlobalSignOut(&cognito.GlobalSignOutInput{
+		AccessToken: aws.String(accessToken),
+	})
+	if err != nil {
+		return apps.Exception("failed to sign out user", err, zap.Bool("token", accessToken != ""), c.Logger)
+	}
+	return nil
+}
+
+func (c *Cognito) RevokeUser(username string) *model.TechnicalError {
+	_, err := c.Provider.AdminUserGlobalSignOut(&cognito.AdminUserGlobalSignOutInput{
+		UserPoolId: aws.String(c.UserPool),
+		Username:   aws.String(username),
+	})
+	if err != nil {
+		return apps.Exception("failed to revoke user sessions", err, zap.String("username", username), c.Logger)
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+package adaptor
+
+import (
+	"crypto/rsa"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/internal/repository"
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+	"github.com/lestrrat-go/jwx/jwk"
+	"go.uber.org/zap"
+	"strings"
+	"time"
+)
+
+type (
+	LocalCiam struct {
+		Dao        repository.CiamUserPersister
+		PrivateKey *rsa.PrivateKey
+		KeyId      string
+		Issuer     string
+		ClientId   string
+		JWK        string
+		TokenTTL   time.Duration
+		RefreshTTL time.Duration
+		Logger     *zap.Logger
+		verifier   *Cognito
+	}
+)
+
+func NewLocalCiam(l LocalCiam) CiamWatcher {
+	l.verifier = &Cognito{JWK: l.JWK, Logger: l.Logger}
+	return &l
+}
+
+func NewJwks(key *rsa.PublicKey, kid string) (string, error) {
+	k, err := jwk.New(key)
+	if err != nil {
+		return "", err
+	}
+	_ = k.Set(jwk.KeyIDKey, kid)
+	_ = k.Set(jwk.AlgorithmKey, jwt.SigningMethodRS256.Alg())
+	_ = k.Set(jwk.KeyUsageKey, "sig")
+	set := jwk.NewSet()
+	set.Add(k)
+	b, err := json.Marshal(set)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func (l *LocalCiam) sign(claims jwt.MapClaims, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims["iss"] = l.Issuer
+	claims["jti"] = uuid.NewString()
+	claims["iat"] = now.Unix()
+	claims["exp"] = now.Add(ttl).Unix()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = l.KeyId
+	return token.SignedString(l.PrivateKey)
+}
+
+func (l *LocalCiam) issue(u *model.CiamUser) (*model.CiamAuthenticationResponse, error) {
+	idToken, err := l.sign(jwt.MapClaims{
+		"sub":              u.SubId.String,
+		"aud":              l.ClientId,
+		"token_use":        "id",
+		"cognito:username": u.Username.String,
+		"email":            u.Email.String,
+		"name":             u.Name.String,
+		"picture":          u.Picture.String,
+		"phone_number":     u.PhoneNumber.String,
+	}, l.TokenTTL)
+	if err != nil {
+		return nil, err
+	}
+	accessToken, err := l.sign(jwt.MapClaims{
+		"sub":       u.SubId.String,
+		"client_id": l.ClientId,
+		"token_use": "access",
+		"username":  u.Username.String,
+	}, l.TokenTTL)
+	if err != nil {
+		return nil, err
+	}
+	return &model.CiamAuthenticationResponse{
+		AccessToken: accessToken,
+		Token:       idToken,
+		ExpiresIn:   int64(l.TokenTTL.Seconds()),
+	}, nil
+}
+
+func (l *LocalCiam) claims(t string, use string) (jwt.MapClaims, error) {
+	token, err := l.verifier.parseToken(t)
+	if err != nil {
+		return nil, err
+	}
+	claims, result := token.Claims.(jwt.MapClaims)
+	if !result || !token.Valid {
+		return nil, fmt.Errorf("failed to claim JWT")
+	}
+	if claims["token_use"] != use {
+		return nil, fmt.Errorf("unexpected token use: %v", claims["token_use"])
+	}
+	return claims, nil
+}
+
+func (l *LocalCiam) JwtInfo(t string) (map[string]interface{}, *model.TechnicalError) {
+	claims, ex := l.verifier.JwtInfo(t)
+	if ex != nil {
+		return nil, ex
+	}
+	if claims["token_use"] != "id" {
+		return nil, apps.Exception("bad JWT claim", fmt.Errorf("unexpected token use: %v", claims["token_use"]), zap.Bool("token", t != ""), l.Logger)
+	}
+	return claims, nil
+}
+
+func (l *LocalCiam) OnboardPartner(m model.CiamOnboardPartnerRequest) (*model.CiamUserResponse, *model.TechnicalError) {
+	h, ex := apps.HashPassword(m.Password, l.Logger)
+	if ex != nil {
+		return nil, ex
+	}
+	sub := uuid.NewString()
+	ex = l.Dao.Add(model.CiamUser{
+		SubId:       sql.NullString{String: sub, Valid: true},
+		Username:    sql.NullString{String: m.Username, Valid: true},
+		Name:        sql.NullString{String: m.Name, Valid: true},
+		Email:       sql.NullString{String: m.Email, Valid: true},
+		PhoneNumber: sql.NullString{String: "+" + m.PhoneNumber, Valid: true},
+		Picture:     sql.NullString{String: m.Picture, Valid: true},
+		Password:    sql.NullString{String: h, Valid: true},
+	})
+	if ex != nil {
+		return nil, ex
+	}
+	return &model.CiamUserResponse{
+		TransactionResponse: apps.Transaction(m.PhoneNumber),
+		SubId:               sub,
+	}, nil
+}
+
+func (l *LocalCiam) Authenticate(m model.CiamAuthenticationRequest) (*model.CiamAuthenticationResponse, *model.TechnicalError) {
+	u, ex := l.Dao.FindActiveByUsername(m.Username)
+	if ex != nil {
+		return nil, ex
+	}
+	if !apps.MatchPassword(u.Password.String, m.Secret) {
+		return nil, apps.Exception("failed to authenticate user", fmt.Errorf("incorrect username or password"),
+			zap.String("username", m.Username), l.Logger)
+	}
+	res, err := l.issue(u)
+	if err != nil {
+		return nil, apps.Exception("failed to issue user token", err, zap.String("username", m.Username), l.Logger)
+	}
+	res.RefreshToken, err = l.sign(jwt.MapClaims{
+		"sub":       u.SubId.String,
+		"token_use": "refresh",
+		"username":  u.Username.String,
+		"ver":       u.TokenVersion,
+	}, l.RefreshTTL)
+	if err != nil {
+		return nil, apps.Exception("failed to issue user refresh token", err, zap.String("username", m.Username), l.Logger)
+	}
+	return res, nil
+}
+
+func (l *LocalCiam) Refresh(m model.CiamRefreshRequest) (*model.CiamAuthenticationResponse, *model.TechnicalError) {
+	claims, err := l.claims(m.RefreshToken, "refresh")
+	if err != nil {
+		return nil, apps.Exception("failed to refresh user token", err, zap.String("username", m.Username), l.Logger)
+	}
+	uname, _ := claims["username"].(string)
+	if !strings.EqualFold(uname, m.Username) {
+		return nil, apps.Exception("failed to refresh user token", fmt.Errorf("refresh token does not belong to user"),
+			zap.String("username", m.Username), l.Logger)
+	}
+	u, ex := l.Dao.FindActiveByUsername(m.Username)
+	if ex != nil {
+		return nil, ex
+	}
+	if ver, _ := claims["ver"].(float64); int64(ver) != u.TokenVersion {
+		return nil, apps.Exception("failed to refresh user token", fmt.Errorf("refresh token has been revoked"),
+			zap.String("username", m.Username), l.Logger)
+	}
+	res, err := l.issue(u)
+	if err != nil {
+		return nil, apps.Exception("failed to issue user token", err, zap.String("username", m.Username), l.Logger)
+	}
+	res.RefreshToken = m.RefreshToken
+	return res, nil
+}
+
+func (l *LocalCiam) SignOut(accessToken string) *model.TechnicalError {
+	claims, err := l.claims(accessToken, "access")
+	if err != nil {
+		return apps.Exception("failed to sign out user", err, zap.Bool("token", accessToken != ""), l.Logger)
+	}
+	uname, _ := claims["username"].(string)
+	return l.Dao.RevokeTokens(uname)
+}
+
+func (l *LocalCiam) RevokeUser(username string) *model.TechnicalError {
+	return l.Dao.RevokeTokens(username)
+}
@@ -0,0 +1,171 @@
+package adaptor
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"database/sql"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/mock/repository"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func newTestLocalCiam(t *testing.T, dao *repository.MockCiamUserPersister) CiamWatcher {
+	logger, _ := apps.NewLog(false)
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+	jwks, err := NewJwks(&key.PublicKey, "kid-001")
+	assert.Nil(t, err)
+	return NewLocalCiam(LocalCiam{
+		Dao:        dao,
+		PrivateKey: key,
+		KeyId:      "kid-001",
+		Issuer:     "https://kezbek.local",
+		ClientId:   "cezbek-api",
+		JWK:        jwks,
+		TokenTTL:   time.Minute,
+		RefreshTTL: time.Hour,
+		Logger:     logger,
+	})
+}
+
+func TestLocalCiam_Authenticate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	dao := repository.NewMockCiamUserPersister(ctrl)
+	svc := newTestLocalCiam(t, dao)
+	h, _ := apps.HashPassword("s3cr3t!", logger)
+	user := &model.CiamUser{
+		SubId:        sql.NullString{String: "sub-001", Valid: true},
+		Username:     sql.NullString{String: "CORP_A", Valid: true},
+		Email:        sql.NullString{String: "someone@email.net", Valid: true},
+		Password:     sql.NullString{String: h, Valid: true},
+		TokenVersion: 1,
+	}
+	t.Run("should success and issue verifiable tokens", func(t *testing.T) {
+		dao.EXPECT().FindActiveByUsername("CORP_A").Return(user, nil)
+		v, ex := svc.Authenticate(model.CiamAuthenticationRequest{Username: "CORP_A", Secret: "s3cr3t!"})
+		assert.Nil(t, ex)
+		assert.Equal(t, int64(60), v.ExpiresIn)
+		claims, ex := svc.JwtInfo(v.Token)
+		assert.Nil(t, ex)
+		assert.Equal(t, "CORP_A", claims["cognito:username"])
+		assert.Equal(t, "someone@email.net", claims["email"])
+		assert.NotEmpty(t, claims["jti"])
+		_, ex = svc.JwtInfo(v.RefreshToken)
+		assert.NotNil(t, ex)
+		_, ex = svc.JwtInfo(v.AccessToken)
+		assert.NotNil(t, ex)
+	})
+	t.Run("should return exception on incorrect password", func(t *testing.T) {
+		dao.EXPECT().FindActiveByUsername("CORP_A").Return(user, nil)
+		v, ex := svc.Authenticate(model.CiamAuthenticationRequest{Username: "CORP_A", Secret: "wrong"})
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+	t.Run("should return exception on unknown user", func(t *testing.T) {
+		dao.EXPECT().FindActiveByUsername("CORP_B").Return(nil, &model.TechnicalError{Exception: "no rows in result set"})
+		v, ex := svc.Authenticate(model.CiamAuthenticationRequest{Username: "CORP_B", Secret: "s3cr3t!"})
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
+
+func TestLocalCiam_Refresh(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	dao := repository.NewMockCiamUserPersister(ctrl)
+	svc := newTestLocalCiam(t, dao)
+	h, _ := apps.HashPassword("s3cr3t!", logger)
+	user := &model.CiamUser{
+		SubId:        sql.NullString{String: "sub-001", Valid: true},
+		Username:     sql.NullString{String: "CORP_A", Valid: true},
+		Password:     sql.NullString{String: h, Valid: true},
+		TokenVersion: 1,
+	}
+	dao.EXPECT().FindActiveByUsername("CORP_A").Return(user, nil)
+	auth, _ := svc.Authenticate(model.CiamAuthenticationRequest{Username: "CORP_A", Secret: "s3cr3t!"})
+	t.Run("should success", func(t *testing.T) {
+		dao.EXPECT().FindActiveByUsername("CORP_A").Return(user, nil)
+		v, ex := svc.Refresh(model.CiamRefreshRequest{Username: "CORP_A", RefreshToken: auth.RefreshToken})
+		assert.Nil(t, ex)
+		assert.Equal(t, auth.RefreshToken, v.RefreshToken)
+		assert.NotEmpty(t, v.Token)
+	})
+	t.Run("should return exception on revoked refresh token", func(t *testing.T) {
+		revoked := *user
+		revoked.TokenVersion = 2
+		dao.EXPECT().FindActiveByUsername("CORP_A").Return(&revoked, nil)
+		v, ex := svc.Refresh(model.CiamRefreshRequest{Username: "CORP_A", RefreshToken: auth.RefreshToken})
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+	t.Run("should return exception on other user refresh token", func(t *testing.T) {
+		v, ex := svc.Refresh(model.CiamRefreshRequest{Username: "CORP_B", RefreshToken: auth.RefreshToken})
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+	t.Run("should return exception on id token as refresh token", func(t *testing.T) {
+		v, ex := svc.Refresh(model.CiamRefreshRequest{Username: "CORP_A", RefreshToken: auth.Token})
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
+
+func TestLocalCiam_SignOut(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	dao := repository.NewMockCiamUserPersister(ctrl)
+	svc := newTestLocalCiam(t, dao)
+	h, _ := apps.HashPassword("s3cr3t!", logger)
+	dao.EXPECT().FindActiveByUsername("CORP_A").Return(&model.CiamUser{
+		Username: sql.NullString{String: "CORP_A", Valid: true},
+		Password: sql.NullString{String: h, Valid: true},
+	}, nil)
+	auth, _ := svc.Authenticate(model.CiamAuthenticationRequest{Username: "CORP_A", Secret: "s3cr3t!"})
+	t.Run("should success", func(t *testing.T) {
+		dao.EXPECT().RevokeTokens("CORP_A").Return(nil)
+		ex := svc.SignOut(auth.AccessToken)
+		assert.Nil(t, ex)
+	})
+	t.Run("should return exception on id token", func(t *testing.T) {
+		ex := svc.SignOut(auth.Token)
+		assert.NotNil(t, ex)
+	})
+}
+
+func TestLocalCiam_OnboardPartner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	dao := repository.NewMockCiamUserPersister(ctrl)
+	svc := newTestLocalCiam(t, dao)
+	inp := model.CiamOnboardPartnerRequest{
+		Username:    "CORP_A",
+		Name:        "PT. Corporate A",
+		PhoneNumber: "6281123456789",
+		Email:       "someone@email.net",
+		Password:    "s3cr3t!",
+	}
+	t.Run("should success", func(t *testing.T) {
+		dao.EXPECT().Add(gomock.Any()).DoAndReturn(func(u model.CiamUser) *model.TechnicalError {
+			assert.Equal(t, "+6281123456789", u.PhoneNumber.String)
+			assert.True(t, apps.MatchPassword(u.Password.String, "s3cr3t!"))
+			return nil
+		})
+		v, ex := svc.OnboardPartner(inp)
+		assert.Nil(t, ex)
+		assert.NotEmpty(t, v.SubId)
+	})
+	t.Run("should return exception on failed to add user", func(t *testing.T) {
+		dao.EXPECT().Add(gomock.Any()).Return(&model.TechnicalError{Exception: "something went wrong"})
+		v, ex := svc.OnboardPartner(inp)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
@@ -0,0 +1,71 @@
+package adaptor
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type Messaging struct {
+	Host   string
+	ApiKey string
+	Sender string
+	Logger *zap.Logger
+	Rest
+}
+
+type MessagingAdapter interface {
+	Send(m model.SendMessageRequest) (*model.TransactionResponse, *model.TechnicalError)
+}
+
+func NewMessaging(m Messaging) MessagingAdapter {
+	return &m
+}
+
+func (m *Messaging) Send(inp model.SendMessageRequest) (*model.TransactionResponse, *model.TechnicalError) {
+	payload := new(bytes.Buffer)
+	err := json.NewEncoder(payload).Encode(model.MessagingGatewayRequest{
+		Channel: strings.ToLower(inp.Channel),
+		From:    m.Sender,
+		To:      inp.Destination,
+		Text:    inp.Content,
+	})
+	if err != nil {
+		return nil, apps.Exception("failed to build messaging payload", err, zap.String("destination", inp.Destination), m.Logger)
+	}
+	req, err := http.NewRequest(fiber.MethodPost, m.Host+"/v1/messages", payload)
+	if err != nil {
+		return nil, apps.Exception("failed to create messaging request", err, zap.String("destination", inp.Destination), m.Logger)
+	}
+	req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	req.Header.Add(fiber.HeaderAccept, fiber.MIMEApplicationJSON)
+	req.Header.Add(apps.HeaderApiKey, m.ApiKey)
+	resp, err := m.Client().Do(req)
+	if err != nil {
+		return nil, apps.Exception("failed to send message", err, zap.String("destination", inp.Destination), m.Logger)
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			m.Logger.Error("failed to close the body stream on messaging adapter", zap.Error(err))
+		}
+	}(resp.Body)
+	var v model.MessagingGatewayResponse
+	_ = json.NewDecoder(resp.Body).Decode(&v)
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, apps.Exception("message is rejected by the gateway", errors.New(resp.Status+" "+v.Error),
+			zap.String("destination", inp.Destination), m.Logger)
+	}
+	return &model.TransactionResponse{
+		TransactionId:        v.MessageId,
+		TransactionTimestamp: time.Now().Unix(),
+	}, nil
+}
@@ -0,0 +1,62 @@
+package adaptor
+
+import (
+	"encoding/json"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMessaging_Send(t *testing.T) {
+	logger, _ := apps.NewLog(false)
+	inp := model.SendMessageRequest{
+		Channel:     apps.NotificationChannelWhatsapp,
+		Destination: "628118770510",
+		Content:     "Your cashback is on the way",
+	}
+	t.Run("should success", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/v1/messages", r.URL.Path)
+			assert.Equal(t, "secret", r.Header.Get(apps.HeaderApiKey))
+			req := model.MessagingGatewayRequest{}
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			assert.Equal(t, "whatsapp", req.Channel)
+			assert.Equal(t, "KEZBEK", req.From)
+			assert.Equal(t, inp.Destination, req.To)
+			w.Header().Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			_ = json.NewEncoder(w).Encode(model.MessagingGatewayResponse{MessageId: "MSG-001", Status: "QUEUED"})
+		}))
+		defer srv.Close()
+		adapter := NewMessaging(Messaging{
+			Host:   srv.URL,
+			ApiKey: "secret",
+			Sender: "KEZBEK",
+			Logger: logger,
+			Rest:   Rest{Timeout: time.Second},
+		})
+		v, ex := adapter.Send(inp)
+		assert.Nil(t, ex)
+		assert.Equal(t, "MSG-001", v.TransactionId)
+	})
+
+	t.Run("should return exception on rejected message", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(model.MessagingGatewayResponse{Error: "invalid msisdn"})
+		}))
+		defer srv.Close()
+		adapter := NewMessaging(Messaging{
+			Host:   srv.URL,
+			Logger: logger,
+			Rest:   Rest{Timeout: time.Second},
+		})
+		v, ex := adapter.Send(inp)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
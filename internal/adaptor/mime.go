@@ -0,0 +1,102 @@
+package adaptor
+
+import (
+	"bytes"
+	"encoding/base64"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	"time"
+)
+
+func mimeMessage(from string, id string, m model.SendEmailRequest) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString("From: " + from + "\r\n")
+	b.WriteString("To: " + m.Destination + "\r\n")
+	b.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", m.Subject) + "\r\n")
+	b.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("Message-Id: " + id + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+
+	var body bytes.Buffer
+	alt := multipart.NewWriter(&body)
+	if m.Text != "" {
+		if err := quotedPart(alt, "text/plain; charset=utf-8", m.Text); err != nil {
+			return nil, err
+		}
+	}
+	if err := quotedPart(alt, "text/html; charset=utf-8", m.Content); err != nil {
+		return nil, err
+	}
+	if err := alt.Close(); err != nil {
+		return nil, err
+	}
+	if len(m.Attachments) == 0 {
+		b.WriteString("Content-Type: multipart/alternative; boundary=" + alt.Boundary() + "\r\n\r\n")
+		b.Write(body.Bytes())
+		return b.Bytes(), nil
+	}
+
+	var mixed bytes.Buffer
+	mw := multipart.NewWriter(&mixed)
+	w, err := mw.CreatePart(textproto.MIMEHeader{
+		"Content-Type": {"multipart/alternative; boundary=" + alt.Boundary()},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err = w.Write(body.Bytes()); err != nil {
+		return nil, err
+	}
+	for _, a := range m.Attachments {
+		ct := a.ContentType
+		if ct == "" {
+			ct = "application/octet-stream"
+		}
+		w, err = mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {mime.FormatMediaType(ct, map[string]string{"name": a.Filename})},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": a.Filename})},
+			"Content-Transfer-Encoding": {"base64"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if _, err = w.Write(base64Lines(a.Content)); err != nil {
+			return nil, err
+		}
+	}
+	if err = mw.Close(); err != nil {
+		return nil, err
+	}
+	b.WriteString("Content-Type: multipart/mixed; boundary=" + mw.Boundary() + "\r\n\r\n")
+	b.Write(mixed.Bytes())
+	return b.Bytes(), nil
+}
+
+func quotedPart(mw *multipart.Writer, ct string, content string) error {
+	w, err := mw.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {ct},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return err
+	}
+	qw := quotedprintable.NewWriter(w)
+	if _, err = qw.Write([]byte(content)); err != nil {
+		return err
+	}
+	return qw.Close()
+}
+
+func base64Lines(content []byte) []byte {
+	enc := base64.StdEncoding.EncodeToString(content)
+	var b bytes.Buffer
+	for len(enc) > 76 {
+		b.WriteString(enc[:76] + "\r\n")
+		enc = enc[76:]
+	}
+	b.WriteString(enc + "\r\n")
+	return b.Bytes()
+}
@@ -3,18 +3,24 @@ package adaptor
 import (
 	"github.com/adinandradrs/cezbek-engine/internal/apps"
 	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"go.uber.org/zap"
+	"time"
 )
 
 type S3Bucket struct {
-	Bucket   string
-	Uploader *s3manager.Uploader
-	Logger   *zap.Logger
+	Bucket     string
+	Uploader   *s3manager.Uploader
+	Downloader *s3manager.Downloader
+	Logger     *zap.Logger
 }
 
 type S3Watcher interface {
 	Upload(req *model.S3UploadRequest) (*s3manager.UploadOutput, *model.TechnicalError)
+	Download(path string) ([]byte, *model.TechnicalError)
+	Presign(path string, ttl time.Duration) (*string, *model.TechnicalError)
 }
 
 func NewS3(b S3Bucket) S3Watcher {
@@ -35,3 +41,27 @@ func (b *S3Bucket) Upload(req *model.S3UploadRequest) (*s3manager.UploadOutput,
 	b.Logger.Info("s3 response", zap.Any("", v))
 	return v, nil
 }
+
+func (b *S3Bucket) Download(path string) ([]byte, *model.TechnicalError) {
+	buf := aws.NewWriteAtBuffer([]byte{})
+	_, err := b.Downloader.Download(buf, &s3.GetObjectInput{
+		Bucket: &b.Bucket,
+		Key:    &path,
+	})
+	if err != nil {
+		return nil, apps.Exception("failed to download file", err, zap.String("path", path), b.Logger)
+	}
+	return buf.Bytes(), nil
+}
+
+func (b *S3Bucket) Presign(path string, ttl time.Duration) (*string, *model.TechnicalError) {
+	req, _ := b.Downloader.S3.GetObjectRequest(&s3.GetObjectInput{
+		Bucket: &b.Bucket,
+		Key:    &path,
+	})
+	v, err := req.Presign(ttl)
+	if err != nil {
+		return nil, apps.Exception("failed to presign file", err, zap.String("path", path), b.Logger)
+	}
+	return &v, nil
+}
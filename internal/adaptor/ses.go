@@ -5,7 +5,9 @@ import (
 	"github.com/adinandradrs/cezbek-engine/internal/model"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/sesv2"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
+	"time"
 )
 
 type SES struct {
@@ -23,33 +25,60 @@ func NewSES(s SES) SESAdapter {
 }
 
 func (s *SES) SendEmail(m model.SendEmailRequest) (*model.TransactionResponse, *model.TechnicalError) {
-	utf8 := "utf-8"
+	content, err := s.content(m)
+	if err != nil {
+		return nil, apps.Exception("failed to build email",
+			err, zap.String("destination", m.Destination), s.Logger)
+	}
 	out, err := s.SES.SendEmail(&sesv2.SendEmailInput{
 		Destination: &sesv2.Destination{
 			ToAddresses: []*string{
 				aws.String(m.Destination),
 			},
 		},
-		Content: &sesv2.EmailContent{
-			Simple: &sesv2.Message{
-				Body: &sesv2.Body{
-					Html: &sesv2.Content{
-						Charset: aws.String(utf8),
-						Data:    aws.String(m.Content),
-					},
-				},
-				Subject: &sesv2.Content{
-					Charset: aws.String(utf8),
-					Data:    aws.String(m.Subject),
-				},
-			},
-		},
+		Content:          content,
 		FromEmailAddress: s.Sender,
 	})
 	if err != nil {
 		return nil, apps.Exception("failed to send email",
 			err, zap.String("destination", m.Destination), s.Logger)
 	}
-	trx := apps.Transaction(*out.MessageId)
-	return &trx, nil
+	return &model.TransactionResponse{
+		TransactionId:        aws.StringValue(out.MessageId),
+		TransactionTimestamp: time.Now().Unix(),
+	}, nil
+}
+
+func (s *SES) content(m model.SendEmailRequest) (*sesv2.EmailContent, error) {
+	if len(m.Attachments) > 0 {
+		raw, err := mimeMessage(aws.StringValue(s.Sender), "<"+uuid.New().String()+"@amazonses.com>", m)
+		if err != nil {
+			return nil, err
+		}
+		return &sesv2.EmailContent{
+			Raw: &sesv2.RawMessage{Data: raw},
+		}, nil
+	}
+	utf8 := "utf-8"
+	body := &sesv2.Body{
+		Html: &sesv2.Content{
+			Charset: aws.String(utf8),
+			Data:    aws.String(m.Content),
+		},
+	}
+	if m.Text != "" {
+		body.Text = &sesv2.Content{
+			Charset: aws.String(utf8),
+			Data:    aws.String(m.Text),
+		}
+	}
+	return &sesv2.EmailContent{
+		Simple: &sesv2.Message{
+			Body: body,
+			Subject: &sesv2.Content{
+				Charset: aws.String(utf8),
+				Data:    aws.String(m.Subject),
+			},
+		},
+	}, nil
 }
@@ -0,0 +1,163 @@
+package adaptor
+
+import (
+	"crypto/tls"
+	"errors"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultSmtpIdle = time.Minute
+const defaultSmtpTimeout = 30 * time.Second
+
+type SMTP struct {
+	Host        string
+	Port        int
+	Username    string
+	Password    string
+	Sender      string
+	StartTLS    bool
+	Timeout     time.Duration
+	IdleTimeout time.Duration
+	Logger      *zap.Logger
+	session     *smtpSession
+}
+
+type smtpSession struct {
+	mtx      sync.Mutex
+	client   *smtp.Client
+	conn     net.Conn
+	lastUsed time.Time
+}
+
+func NewSMTP(s SMTP) SESAdapter {
+	if s.IdleTimeout <= 0 {
+		s.IdleTimeout = defaultSmtpIdle
+	}
+	if s.Timeout <= 0 {
+		s.Timeout = defaultSmtpTimeout
+	}
+	s.session = &smtpSession{}
+	return &s
+}
+
+func (s *SMTP) dial() (*smtp.Client, net.Conn, error) {
+	addr := net.JoinHostPort(s.Host, strconv.Itoa(s.Port))
+	conn, err := net.DialTimeout("tcp", addr, s.Timeout)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = conn.SetDeadline(time.Now().Add(s.Timeout)); err != nil {
+		_ = conn.Close()
+		return nil, nil, err
+	}
+	c, err := smtp.NewClient(conn, s.Host)
+	if err != nil {
+		_ = conn.Close()
+		return nil, nil, err
+	}
+	if s.StartTLS {
+		if ok, _ := c.Extension("STARTTLS"); !ok {
+			_ = c.Close()
+			return nil, nil, errors.New("smtp server does not support STARTTLS")
+		}
+		if err = c.StartTLS(&tls.Config{ServerName: s.Host}); err != nil {
+			_ = c.Close()
+			return nil, nil, err
+		}
+	}
+	if s.Username != "" {
+		if err = c.Auth(smtp.PlainAuth("", s.Username, s.Password, s.Host)); err != nil {
+			_ = c.Close()
+			return nil, nil, err
+		}
+	}
+	return c, conn, nil
+}
+
+func (s *SMTP) client() (*smtp.Client, error) {
+	if c := s.session.client; c != nil {
+		if time.Since(s.session.lastUsed) < s.IdleTimeout &&
+			s.session.conn.SetDeadline(time.Now().Add(s.Timeout)) == nil && c.Reset() == nil {
+			return c, nil
+		}
+		s.close()
+	}
+	c, conn, err := s.dial()
+	if err != nil {
+		return nil, err
+	}
+	s.session.client = c
+	s.session.conn = conn
+	return c, nil
+}
+
+func (s *SMTP) close() {
+	if s.session.client != nil {
+		_ = s.session.client.Quit()
+		s.session.client = nil
+		s.session.conn = nil
+	}
+}
+
+func (s *SMTP) envelope() string {
+	if a, err := mail.ParseAddress(s.Sender); err == nil {
+		return a.Address
+	}
+	return s.Sender
+}
+
+func (s *SMTP) deliver(c *smtp.Client, to string, msg []byte) error {
+	if err := c.Mail(s.envelope()); err != nil {
+		return err
+	}
+	if err := c.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err = w.Write(msg); err != nil {
+		_ = w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+func (s *SMTP) SendEmail(m model.SendEmailRequest) (*model.TransactionResponse, *model.TechnicalError) {
+	domain := s.Host
+	if i := strings.LastIndex(s.envelope(), "@"); i >= 0 {
+		domain = s.envelope()[i+1:]
+	}
+	id := "<" + uuid.New().String() + "@" + domain + ">"
+	msg, err := mimeMessage(s.Sender, id, m)
+	if err != nil {
+		return nil, apps.Exception("failed to build email", err, zap.String("destination", m.Destination), s.Logger)
+	}
+
+	s.session.mtx.Lock()
+	defer s.session.mtx.Unlock()
+	c, err := s.client()
+	if err == nil {
+		err = s.deliver(c, m.Destination, msg)
+	}
+	if err != nil {
+		s.close()
+		return nil, apps.Exception("failed to send email", err, zap.String("destination", m.Destination), s.Logger)
+	}
+	s.session.lastUsed = time.Now()
+	return &model.TransactionResponse{
+		TransactionId:        id,
+		TransactionTimestamp: time.Now().Unix(),
+	}, nil
+}
@@ -0,0 +1,191 @@
+package adaptor
+
+import (
+	"bufio"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeSmtp struct {
+	listener net.Listener
+	mtx      sync.Mutex
+	conns    int
+	messages []string
+	reject   string
+}
+
+func newFakeSmtp(t *testing.T) *fakeSmtp {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	f := &fakeSmtp{listener: l}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			f.mtx.Lock()
+			f.conns++
+			f.mtx.Unlock()
+			go f.serve(conn)
+		}
+	}()
+	return f
+}
+
+func (f *fakeSmtp) port() int {
+	return f.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (f *fakeSmtp) serve(conn net.Conn) {
+	defer conn.Close()
+	r, w := bufio.NewReader(conn), bufio.NewWriter(conn)
+	reply := func(s string) {
+		_, _ = w.WriteString(s + "\r\n")
+		_ = w.Flush()
+	}
+	reply("220 localhost ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"):
+			reply("250 localhost")
+		case strings.HasPrefix(cmd, "RCPT") && f.reject != "" && strings.Contains(cmd, strings.ToUpper(f.reject)):
+			reply("550 mailbox unavailable")
+		case strings.HasPrefix(cmd, "DATA"):
+			reply("354 go ahead")
+			var b strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil || l == ".\r\n" {
+					break
+				}
+				b.WriteString(l)
+			}
+			f.mtx.Lock()
+			f.messages = append(f.messages, b.String())
+			f.mtx.Unlock()
+			reply("250 queued")
+		case strings.HasPrefix(cmd, "QUIT"):
+			reply("221 bye")
+			return
+		default:
+			reply("250 ok")
+		}
+	}
+}
+
+func TestSMTP_SendEmail(t *testing.T) {
+	logger, _ := apps.NewLog(false)
+	srv := newFakeSmtp(t)
+	defer srv.listener.Close()
+	adapter := NewSMTP(SMTP{
+		Host:    "127.0.0.1",
+		Port:    srv.port(),
+		Sender:  "Kezbek <noreply@kezbek.id>",
+		Timeout: time.Second,
+		Logger:  logger,
+	})
+	t.Run("should success and reuse the connection", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			v, ex := adapter.SendEmail(model.SendEmailRequest{
+				Destination: "someone@email.net",
+				Subject:     "Invoice " + strconv.Itoa(i),
+				Content:     "<p>Your cashback</p>",
+				Text:        "Your cashback",
+			})
+			assert.Nil(t, ex)
+			assert.True(t, strings.HasSuffix(v.TransactionId, "@kezbek.id>"))
+		}
+		assert.Equal(t, 1, srv.conns)
+		assert.Len(t, srv.messages, 2)
+	})
+
+	t.Run("should return exception on rejected recipient", func(t *testing.T) {
+		srv.reject = "nobody@email.net"
+		v, ex := adapter.SendEmail(model.SendEmailRequest{
+			Destination: "nobody@email.net",
+			Subject:     "Invoice",
+			Content:     "<p>Your cashback</p>",
+		})
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
+
+func TestSMTP_SendEmailTimeout(t *testing.T) {
+	logger, _ := apps.NewLog(false)
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer l.Close()
+	go func() {
+		conn, err := l.Accept()
+		if err == nil {
+			defer conn.Close()
+			time.Sleep(time.Second)
+		}
+	}()
+	adapter := NewSMTP(SMTP{
+		Host:    "127.0.0.1",
+		Port:    l.Addr().(*net.TCPAddr).Port,
+		Sender:  "noreply@kezbek.id",
+		Timeout: 100 * time.Millisecond,
+		Logger:  logger,
+	})
+	t.Run("should return exception on stalled server", func(t *testing.T) {
+		start := time.Now()
+		v, ex := adapter.SendEmail(model.SendEmailRequest{
+			Destination: "someone@email.net",
+			Subject:     "Invoice",
+			Content:     "<p>Your cashback</p>",
+		})
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+}
+
+func TestMimeMessage(t *testing.T) {
+	raw, err := mimeMessage("noreply@kezbek.id", "<id@kezbek.id>", model.SendEmailRequest{
+		Destination: "someone@email.net",
+		Subject:     "Kwitansi Cashback",
+		Content:     "<p>Your cashback</p>",
+		Text:        "Your cashback",
+		Attachments: []model.EmailAttachment{{
+			Filename:    "receipt.pdf",
+			ContentType: "application/pdf",
+			Content:     []byte("%PDF-1.4"),
+		}},
+	})
+	assert.Nil(t, err)
+	msg, err := mail.ReadMessage(strings.NewReader(string(raw)))
+	assert.Nil(t, err)
+	assert.Equal(t, "<id@kezbek.id>", msg.Header.Get("Message-Id"))
+	mt, params, _ := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	assert.Equal(t, "multipart/mixed", mt)
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	alt, err := mr.NextPart()
+	assert.Nil(t, err)
+	mt, _, _ = mime.ParseMediaType(alt.Header.Get("Content-Type"))
+	assert.Equal(t, "multipart/alternative", mt)
+	att, err := mr.NextPart()
+	assert.Nil(t, err)
+	assert.Equal(t, "receipt.pdf", att.FileName())
+	b, _ := io.ReadAll(att)
+	assert.Contains(t, string(b), "JVBERi0xLjQ=")
+}
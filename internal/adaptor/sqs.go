@@ -1,8 +1,10 @@
 package adaptor
 
 import (
+	"fmt"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/sqs"
+	"strconv"
 )
 
 type SQS struct {
@@ -10,8 +12,9 @@ type SQS struct {
 }
 
 type SQSAdapter interface {
-	GetMessages(q string) *sqs.Message
-	DeleteMessages(q string, h string) *sqs.DeleteMessageOutput
+	ReceiveMessages(q string, max int64, wait int64, visibility int64) ([]*sqs.Message, error)
+	ChangeVisibility(q string, h string, visibility int64) error
+	DeleteMessageBatch(q string, hs []string) error
 	SendMessage(q string, msg string) error
 }
 
@@ -19,24 +22,49 @@ func NewSQS(c SQS) SQSAdapter {
 	return &c
 }
 
-func (s *SQS) GetMessages(t string) *sqs.Message {
-	msgs, _ := s.SQS.ReceiveMessage(&sqs.ReceiveMessageInput{
-		QueueUrl:            &t,
-		MaxNumberOfMessages: aws.Int64(1),
+func (s *SQS) ReceiveMessages(q string, max int64, wait int64, visibility int64) ([]*sqs.Message, error) {
+	msgs, err := s.SQS.ReceiveMessage(&sqs.ReceiveMessageInput{
+		QueueUrl:            &q,
+		MaxNumberOfMessages: aws.Int64(max),
+		WaitTimeSeconds:     aws.Int64(wait),
+		VisibilityTimeout:   aws.Int64(visibility),
+		AttributeNames:      []*string{aws.String(sqs.MessageSystemAttributeNameApproximateReceiveCount)},
 	})
-	if len(msgs.Messages) > 0 {
-		return msgs.Messages[0]
+	if err != nil {
+		return nil, err
 	}
+	return msgs.Messages, nil
+}
 
-	return nil
+func (s *SQS) ChangeVisibility(q string, h string, visibility int64) error {
+	_, err := s.SQS.ChangeMessageVisibility(&sqs.ChangeMessageVisibilityInput{
+		QueueUrl:          &q,
+		ReceiptHandle:     &h,
+		VisibilityTimeout: aws.Int64(visibility),
+	})
+	return err
 }
 
-func (s *SQS) DeleteMessages(q string, h string) *sqs.DeleteMessageOutput {
-	msgs, _ := s.SQS.DeleteMessage(&sqs.DeleteMessageInput{
-		QueueUrl:      &q,
-		ReceiptHandle: &h,
+func (s *SQS) DeleteMessageBatch(q string, hs []string) error {
+	entries := make([]*sqs.DeleteMessageBatchRequestEntry, len(hs))
+	for i := range hs {
+		entries[i] = &sqs.DeleteMessageBatchRequestEntry{
+			Id:            aws.String(strconv.Itoa(i)),
+			ReceiptHandle: aws.String(hs[i]),
+		}
+	}
+	out, err := s.SQS.DeleteMessageBatch(&sqs.DeleteMessageBatchInput{
+		QueueUrl: &q,
+		Entries:  entries,
 	})
-	return msgs
+	if err != nil {
+		return err
+	}
+	if len(out.Failed) > 0 {
+		return fmt.Errorf("failed to delete %d of %d messages: %s", len(out.Failed), len(hs),
+			aws.StringValue(out.Failed[0].Message))
+	}
+	return nil
 }
 
 func (s *SQS) SendMessage(q string, msg string) error {
@@ -23,6 +23,8 @@ const ErrMsgBadPayload = "Please check again the given input"
 const ErrCodeBadPayload = "9008"
 const ErrMsgInvalidChannel = "The given channel is invalid, try another channel"
 const ErrCodeInvalidChannel = "9009"
+const ErrMsgForbidden = "You are not allowed to access this resource"
+const ErrCodeForbidden = "9010"
 
 const ErrCodeBussPartnerExists = "BR-01"
 const ErrMsgBussPartnerExists = "The given partner data is exists on system"
@@ -38,6 +40,32 @@ const ErrCodeBussRewardFailed = "BR-06"
 const ErrMsgBussRewardFailed = "Failed to add reward"
 const ErrCodeBussNoCashback = "BR-07"
 const ErrMsgBussNoCashback = "No cashback available for the given value"
+const ErrCodeBussPartnerLocked = "BR-08"
+const ErrMsgBussPartnerLocked = "Too many failed OTP attempts, the account is temporarily locked"
+const ErrCodeBussOTPThrottled = "BR-09"
+const ErrMsgBussOTPThrottled = "OTP has been requested too often, please wait before requesting another one"
+const ErrCodeBussOfficerExists = "BR-10"
+const ErrMsgBussOfficerExists = "The given officer email is already registered"
+const ErrCodeBussOfficerSelfRemoval = "BR-11"
+const ErrMsgBussOfficerSelfRemoval = "Officer is not allowed to remove their own account"
+const ErrCodeBussTemplateInvalid = "BR-12"
+const ErrMsgBussTemplateInvalid = "Notification template is invalid or could not be rendered"
+const ErrCodeBussUnsubscribeInvalid = "BR-13"
+const ErrMsgBussUnsubscribeInvalid = "The unsubscribe link is invalid"
+const ErrCodeBussPeriodInvalid = "BR-14"
+const ErrMsgBussPeriodInvalid = "The report period is invalid, the start date should be before the end date within a year"
+const ErrCodeBussInvoicePaid = "BR-15"
+const ErrMsgBussInvoicePaid = "The invoice has been paid"
+const ErrCodeBussSettlementInvalid = "BR-16"
+const ErrMsgBussSettlementInvalid = "The settlement file is invalid, it should be a CSV or JSON with the reference and amount of each line"
+const ErrCodeBussExceptionResolved = "BR-17"
+const ErrMsgBussExceptionResolved = "The settlement exception has been resolved"
+const ErrCodeBussJournalReversed = "BR-18"
+const ErrMsgBussJournalReversed = "The journal has been reversed or is a reversal itself"
+const ErrCodeBussDepositInsufficient = "BR-19"
+const ErrMsgBussDepositInsufficient = "The partner deposit is insufficient for the cashback and fee"
+const ErrCodeBussTopUpInvalid = "BR-20"
+const ErrMsgBussTopUpInvalid = "The top up amount should be more than zero"
 
 const HeaderClientTrxId = "x-client-trxid"
 const HeaderClientChannel = "x-client-channel"
@@ -54,9 +82,64 @@ const HeaderSessionEmail = "x-session-email"
 const HeaderSessionMsisdn = "x-session-msisdn"
 const HeaderSessionFullname = "x-session-fullname"
 const HeaderSessionRole = "x-session-role"
+const HeaderSessionTokenId = "x-session-token-id"
+const HeaderSessionTokenExpiry = "x-session-token-exp"
+const HeaderSessionLocale = "x-session-locale"
 
 const HeaderApiKey = "x-api-key"
 
+const LocaleIndonesian = "id"
+const LocaleEnglish = "en"
+
+const RoleOfficerViewer = "viewer"
+const RoleOfficerFinance = "finance"
+const RoleOfficerAdmin = "admin"
+
+const NotificationChannelEmail = "EMAIL"
+const NotificationChannelSms = "SMS"
+const NotificationChannelWhatsapp = "WHATSAPP"
+const NotificationSent = "SENT"
+const NotificationFailed = "FAILED"
+const NotificationDead = "DEAD"
+const NotificationResent = "RESENT"
+const NotificationSuppressed = "SUPPRESSED"
+const NotificationCategoryTransactional = "TRANSACTIONAL"
+const NotificationCategoryTier = "TIER"
+const NotificationCategoryMarketing = "MARKETING"
+const NotificationEventInvoice = "INVOICE"
+const NotificationEventTierUpgraded = "TIER_UPGRADED"
+const NotificationEventTierDowngraded = "TIER_DOWNGRADED"
+const SuppressionBounce = "BOUNCE"
+const SuppressionComplaint = "COMPLAINT"
+const NotificationEventBilling = "BILLING_INVOICE"
+const InvoiceUnpaid = "UNPAID"
+const InvoicePaid = "PAID"
+const InvoiceOverdue = "OVERDUE"
+const SettlementMissingCashback = "MISSING_CASHBACK"
+const SettlementMissingSettlement = "MISSING_SETTLEMENT"
+const SettlementDuplicate = "DUPLICATE"
+const SettlementAmountMismatch = "AMOUNT_MISMATCH"
+const SettlementOpen = "OPEN"
+const SettlementResolved = "RESOLVED"
+const LedgerPartnerReceivable = "PARTNER_RECEIVABLE"
+const LedgerProviderPrefund = "PROVIDER_PREFUND"
+const LedgerProviderFee = "PROVIDER_FEE"
+const LedgerCustomerPayable = "CUSTOMER_CASHBACK_PAYABLE"
+const LedgerJournalCashback = "CASHBACK"
+const LedgerJournalReversal = "REVERSAL"
+const LedgerJournalSettlement = "SETTLEMENT"
+const NotificationEventDepositLow = "DEPOSIT_LOW_BALANCE"
+const DepositTopUp = "TOPUP"
+const DepositHold = "HOLD"
+const DepositCapture = "CAPTURE"
+const DepositRelease = "RELEASE"
+const NotificationEventTransactionExport = "TRANSACTION_EXPORT"
+const ExportCSV = "CSV"
+const ExportXLSX = "XLSX"
+
+const TransactionDisbursed = "DISBURSED"
+const TransactionFailed = "FAILED"
+
 const ChannelB2BClient = "B2BCLIENT"
 const ChannelEBizKezbek = "EBIZKEZBEK"
 const H2HJosvo = "JOSVOH2H"
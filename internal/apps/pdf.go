@@ -0,0 +1,110 @@
+package apps
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+)
+
+type PDF struct {
+	content bytes.Buffer
+	image   []byte
+	imageW  int
+	imageH  int
+}
+
+const (
+	PdfWidth  = 595.28
+	PdfHeight = 841.89
+)
+
+func NewPDF() *PDF {
+	return &PDF{}
+}
+
+func pdfNumber(f float64) string {
+	return strconv.FormatFloat(f, 'f', 2, 64)
+}
+
+func pdfText(s string) string {
+	var b strings.Builder
+	b.WriteByte('(')
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 32:
+			b.WriteByte(' ')
+		case r > 255:
+			b.WriteByte('?')
+		default:
+			b.WriteByte(byte(r))
+		}
+	}
+	b.WriteByte(')')
+	return b.String()
+}
+
+func (p *PDF) Text(x float64, y float64, size float64, bold bool, s string) {
+	font := "/F1"
+	if bold {
+		font = "/F2"
+	}
+	p.content.WriteString("BT " + font + " " + pdfNumber(size) + " Tf " + pdfNumber(x) + " " + pdfNumber(y) +
+		" Td " + pdfText(s) + " Tj ET\n")
+}
+
+func (p *PDF) Line(x1 float64, y1 float64, x2 float64, y2 float64) {
+	p.content.WriteString("0.5 w " + pdfNumber(x1) + " " + pdfNumber(y1) + " m " + pdfNumber(x2) + " " +
+		pdfNumber(y2) + " l S\n")
+}
+
+func (p *PDF) Image(jpeg []byte, w int, h int, x float64, y float64, dw float64, dh float64) {
+	p.image, p.imageW, p.imageH = jpeg, w, h
+	p.content.WriteString("q " + pdfNumber(dw) + " 0 0 " + pdfNumber(dh) + " " + pdfNumber(x) + " " +
+		pdfNumber(y) + " cm /Im1 Do Q\n")
+}
+
+func (p *PDF) Bytes() []byte {
+	var b bytes.Buffer
+	offsets := []int{}
+	object := func(body string, stream []byte) {
+		offsets = append(offsets, b.Len())
+		b.WriteString(strconv.Itoa(len(offsets)) + " 0 obj\n" + body)
+		if stream != nil {
+			b.WriteString("\nstream\n")
+			b.Write(stream)
+			b.WriteString("\nendstream")
+		}
+		b.WriteString("\nendobj\n")
+	}
+	resources := "/Font << /F1 4 0 R /F2 5 0 R >>"
+	if p.image != nil {
+		resources += " /XObject << /Im1 7 0 R >>"
+	}
+
+	b.WriteString("%PDF-1.4\n")
+	object("<< /Type /Catalog /Pages 2 0 R >>", nil)
+	object("<< /Type /Pages /Kids [3 0 R] /Count 1 >>", nil)
+	object("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 "+pdfNumber(PdfWidth)+" "+pdfNumber(PdfHeight)+
+		"] /Resources << "+resources+" >> /Contents 6 0 R >>", nil)
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>", nil)
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>", nil)
+	object("<< /Length "+strconv.Itoa(p.content.Len())+" >>", p.content.Bytes())
+	if p.image != nil {
+		object("<< /Type /XObject /Subtype /Image /Width "+strconv.Itoa(p.imageW)+" /Height "+
+			strconv.Itoa(p.imageH)+" /ColorSpace /DeviceRGB /BitsPerComponent 8 /Filter /DCTDecode /Length "+
+			strconv.Itoa(len(p.image))+" >>", p.image)
+	}
+
+	xref := b.Len()
+	b.WriteString("xref\n0 " + strconv.Itoa(len(offsets)+1) + "\n0000000000 65535 f \n")
+	for _, o := range offsets {
+		off := strconv.Itoa(o)
+		b.WriteString(strings.Repeat("0", 10-len(off)) + off + " 00000 n \n")
+	}
+	b.WriteString("trailer\n<< /Size " + strconv.Itoa(len(offsets)+1) + " /Root 1 0 R >>\nstartxref\n" +
+		strconv.Itoa(xref) + "\n%%EOF\n")
+	return b.Bytes()
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/adinandradrs/cezbek-engine/internal/model"
 	"github.com/sethvargo/go-password/password"
 	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
 	"io"
 	"math/big"
 )
@@ -44,6 +45,18 @@ func RandomPassword(len int, d int, sym int, logger *zap.Logger) (res string, e
 	return res, e
 }
 
+func HashPassword(p string, logger *zap.Logger) (string, *model.TechnicalError) {
+	h, err := bcrypt.GenerateFromPassword([]byte(p), 12)
+	if err != nil {
+		return "", Exception("failed to hash password", err, zap.Int("length", len(p)), logger)
+	}
+	return string(h), nil
+}
+
+func MatchPassword(h string, p string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(h), []byte(p)) == nil
+}
+
 func Hash(key string) string {
 	md := md5.New() //#nosec
 	md.Write([]byte(key))
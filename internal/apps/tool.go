@@ -5,6 +5,7 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
+	"strings"
 	"time"
 )
 
@@ -29,6 +30,25 @@ func Transaction(identifier string) model.TransactionResponse {
 	}
 }
 
+func TokenTTL(exp int64, fallback time.Duration) time.Duration {
+	ttl := time.Until(time.Unix(exp, 0))
+	if ttl <= 0 {
+		return fallback
+	}
+	return ttl
+}
+
+func NormalizeLocale(l string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(l)), "_", "-")
+}
+
+func LocaleKey(name string, locale string) string {
+	if locale == "" {
+		return name
+	}
+	return name + ":" + locale
+}
+
 func StringExists(key string, strs []string) bool {
 	for _, v := range strs {
 		if v == key {
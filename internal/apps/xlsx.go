@@ -0,0 +1,117 @@
+package apps
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"github.com/shopspring/decimal"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type XLSX struct {
+	zip   *zip.Writer
+	sheet *bufio.Writer
+	rows  int
+	err   error
+}
+
+const xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+	`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+	`</Types>`
+
+const xlsxRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+const xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+	`</Relationships>`
+
+func NewXLSX(w io.Writer, name string) *XLSX {
+	x := &XLSX{zip: zip.NewWriter(w)}
+	var sheetName strings.Builder
+	_ = xml.EscapeText(&sheetName, []byte(name))
+	parts := []struct{ name, body string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRels},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/workbook.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+			`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="` + sheetName.String() + `" sheetId="1" r:id="rId1"/></sheets></workbook>`},
+	}
+	for _, p := range parts {
+		f, err := x.zip.Create(p.name)
+		if err != nil {
+			x.err = err
+			return x
+		}
+		if _, err = io.WriteString(f, p.body); err != nil {
+			x.err = err
+			return x
+		}
+	}
+	f, err := x.zip.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		x.err = err
+		return x
+	}
+	x.sheet = bufio.NewWriter(f)
+	_, x.err = x.sheet.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	return x
+}
+
+func (x *XLSX) Row(cells ...interface{}) error {
+	if x.err != nil {
+		return x.err
+	}
+	x.rows++
+	var b strings.Builder
+	b.WriteString(`<row r="` + strconv.Itoa(x.rows) + `">`)
+	for _, c := range cells {
+		var v string
+		switch n := c.(type) {
+		case int:
+			v = strconv.Itoa(n)
+		case int64:
+			v = strconv.FormatInt(n, 10)
+		case float64:
+			v = strconv.FormatFloat(n, 'f', -1, 64)
+		case decimal.Decimal:
+			v = n.String()
+		}
+		if v != "" {
+			b.WriteString(`<c><v>` + v + `</v></c>`)
+			continue
+		}
+		b.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`)
+		_ = xml.EscapeText(&b, []byte(fmt.Sprint(c)))
+		b.WriteString(`</t></is></c>`)
+	}
+	b.WriteString(`</row>`)
+	_, x.err = x.sheet.WriteString(b.String())
+	return x.err
+}
+
+func (x *XLSX) Close() error {
+	if x.err != nil {
+		return x.err
+	}
+	if _, err := x.sheet.WriteString(`</sheetData></worksheet>`); err != nil {
+		return err
+	}
+	if err := x.sheet.Flush(); err != nil {
+		return err
+	}
+	return x.zip.Close()
+}
@@ -0,0 +1,27 @@
+package cdi
+
+import (
+	"github.com/adinandradrs/cezbek-engine/internal/storage"
+	"github.com/adinandradrs/cezbek-engine/internal/usecase/analytics"
+)
+
+type AnalyticsUsecase struct {
+	analytics.ReportProvider
+}
+
+func (c *Container) report(dao Dao, cacher storage.Cacher) analytics.ReportProvider {
+	return analytics.NewReport(analytics.Report{
+		Dao:             dao.AnalyticsPersister,
+		Cacher:          cacher,
+		TTL:             c.Viper.GetDuration("ttl.analytics"),
+		DefaultProvider: c.Viper.GetString("h2h.default_provider"),
+		Logger:          c.Logger,
+	})
+}
+
+func (c *Container) RegisterAnalyticsUsecase(cacher storage.Cacher) AnalyticsUsecase {
+	dao := c.registerRepository()
+	return AnalyticsUsecase{
+		ReportProvider: c.report(dao, cacher),
+	}
+}
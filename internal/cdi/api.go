@@ -2,9 +2,11 @@ package cdi
 
 import (
 	"github.com/adinandradrs/cezbek-engine/internal/storage"
+	"github.com/adinandradrs/cezbek-engine/internal/usecase/analytics"
 	"github.com/adinandradrs/cezbek-engine/internal/usecase/client"
 	"github.com/adinandradrs/cezbek-engine/internal/usecase/h2h"
 	"github.com/adinandradrs/cezbek-engine/internal/usecase/management"
+	"github.com/adinandradrs/cezbek-engine/internal/usecase/notification"
 	"github.com/adinandradrs/cezbek-engine/internal/usecase/partner"
 	"github.com/adinandradrs/cezbek-engine/internal/usecase/workflow"
 )
@@ -14,27 +16,39 @@ type APIUsecase struct {
 	management.ParamManager
 	management.H2HManager
 	management.WorkflowManager
+	management.InvoiceManager
+	management.SettlementManager
+	management.LedgerManager
+	management.DepositManager
 	workflow.CashbackProvider
 	PartnerOnboardProvider     partner.OnboardProvider
 	PartnerTransactionProvider partner.TransactionProvider
+	PartnerOfficerProvider     partner.OfficerProvider
+	PartnerSuppressionProvider partner.SuppressionProvider
+	PartnerInvoiceProvider     partner.InvoiceProvider
+	PartnerDepositProvider     partner.DepositProvider
+	PartnerReportProvider      analytics.ReportProvider
 	ClientOnboardProvider      client.OnboardProvider
 	ClientTransactionProvider  client.TransactionProvider
+	TemplateProvider           notification.TemplateProvider
+	NotificationLogProvider    notification.LogProvider
+	PreferenceProvider         notification.PreferenceProvider
 	H2HFactory                 h2h.Factory
 }
 
 func (c *Container) RegisterAPIUsecase(infra Infra, cacher storage.Cacher) APIUsecase {
+	c.Viper.SetDefault("otp.max_attempts", 3)
+	c.Viper.SetDefault("otp.max_lockout_attempts", 5)
+	c.Viper.SetDefault("ttl.otp_lockout", "30m")
+	c.Viper.SetDefault("ttl.otp_cooldown", "30s")
 	dao := c.registerRepository()
 	cdn := c.Viper.GetString("aws.cdn_base")
 	path := c.Viper.GetString("aws.s3.path")
 	otpTtl := c.Viper.GetDuration("ttl.otp")
 	qNotificationEmailOtp := c.Viper.GetString("aws.sqs.topic.notification_email_otp")
 	qNotificationEmailInvoice := c.Viper.GetString("aws.sqs.topic.notification_email_invoice")
-	tierProvider := workflow.NewTier(workflow.Tier{
-		Dao:            dao.TierPersister,
-		Logger:         c.Logger,
-		Cacher:         cacher,
-		ExpiryDuration: c.Viper.GetDuration("wfreward.expiry_duration"),
-	})
+	qTransactionExport := c.Viper.GetString("aws.sqs.topic.transaction_export")
+	qCashbackReconciliation := c.Viper.GetString("aws.sqs.topic.cashback_reconciliation")
 	h2hFactory := h2h.NewFactory(h2h.Factory{
 		Cacher: cacher,
 		Gopaid: h2h.Gopaid{GopaidAdapter: infra.GopaidAdapter},
@@ -51,12 +65,54 @@ func (c *Container) RegisterAPIUsecase(infra Infra, cacher storage.Cacher) APIUs
 		Logger: c.Logger,
 		Dao:    dao.WorkflowPersister,
 	})
+	templateProvider := notification.NewTemplate(notification.Template{
+		Dao:           dao.ParamPersister,
+		Cacher:        cacher,
+		DefaultLocale: c.Viper.GetString("notification.default_locale"),
+		Logger:        c.Logger,
+	})
+	preferenceProvider := notification.NewPreference(notification.Preference{
+		Dao:            dao.PreferencePersister,
+		UnsubscribeUrl: c.Viper.GetString("notification.unsubscribe.url"),
+		Secret:         c.secret("notification.unsubscribe.secret"),
+		Logger:         c.Logger,
+	})
+	eventProvider := notification.NewEvent(notification.Event{
+		SqsAdapter:         infra.SQSAdapter,
+		TemplateProvider:   templateProvider,
+		PreferenceProvider: preferenceProvider,
+		ChannelProvider: notification.NewChannel(notification.Channel{
+			Cacher:  cacher,
+			Default: c.Viper.GetString("notification.default_channel"),
+			Logger:  c.Logger,
+		}),
+		Queue:  &qNotificationEmailInvoice,
+		Logger: c.Logger,
+	})
+	tierProvider := workflow.NewTier(workflow.Tier{
+		Dao:            dao.TierPersister,
+		Logger:         c.Logger,
+		Cacher:         cacher,
+		EventProvider:  eventProvider,
+		ExpiryDuration: c.Viper.GetDuration("wfreward.expiry_duration"),
+	})
+	receiptProvider := workflow.NewReceipt(workflow.Receipt{
+		S3Watcher:  infra.S3Watcher,
+		PartnerDao: dao.PartnerPersister,
+		CDN:        &cdn,
+		PathS3:     &path,
+		Secret:     c.secret("receipt.secret"),
+		Logger:     c.Logger,
+	})
 	return APIUsecase{
 		PartnerManager: management.NewPartner(management.Partner{
 			Dao:         dao.PartnerPersister,
+			OfficerDao:  dao.OfficerPersister,
 			CiamWatcher: infra.CiamPartner,
 			S3Watcher:   infra.S3Watcher,
 			PathS3:      &path,
+			Cacher:      cacher,
+			AuthTTL:     c.Viper.GetDuration("ttl.client_auth"),
 			Logger:      c.Logger,
 		}),
 		ParamManager: management.NewParameter(management.Parameter{
@@ -68,13 +124,27 @@ func (c *Container) RegisterAPIUsecase(infra Infra, cacher storage.Cacher) APIUs
 			Dao:                       dao.PartnerPersister,
 			Cacher:                    cacher,
 			SqsAdapter:                infra.SQSAdapter,
+			TemplateProvider:          templateProvider,
 			AuthTTL:                   c.Viper.GetDuration("ttl.client_auth"),
 			CDN:                       &cdn,
 			OtpTTL:                    otpTtl,
+			OtpLockTTL:                c.Viper.GetDuration("ttl.otp_lockout"),
+			OtpCooldown:               c.Viper.GetDuration("ttl.otp_cooldown"),
+			OtpMaxAttempts:            c.Viper.GetInt64("otp.max_attempts"),
+			OtpMaxLockAttempts:        c.Viper.GetInt64("otp.max_lockout_attempts"),
+			AuditDao:                  dao.AuditPersister,
 			CiamWatcher:               infra.CiamPartner,
 			QueueNotificationEmailOtp: &qNotificationEmailOtp,
 			Logger:                    c.Logger,
 		}),
+		PartnerOfficerProvider: partner.NewOfficer(partner.Officer{
+			Dao:                           dao.OfficerPersister,
+			Cacher:                        cacher,
+			SqsAdapter:                    infra.SQSAdapter,
+			TemplateProvider:              templateProvider,
+			QueueNotificationEmailOnboard: &qNotificationEmailOtp,
+			Logger:                        c.Logger,
+		}),
 		ClientOnboardProvider: client.NewOnboard(client.Onboard{
 			Dao:         dao.PartnerPersister,
 			Cacher:      cacher,
@@ -82,10 +152,7 @@ func (c *Container) RegisterAPIUsecase(infra Infra, cacher storage.Cacher) APIUs
 			CiamWatcher: infra.CiamPartner,
 			Logger:      c.Logger,
 		}),
-		CashbackProvider: workflow.NewCashback(workflow.Cashback{
-			Logger: c.Logger,
-			Dao:    dao.WorkflowPersister,
-		}),
+		CashbackProvider: cashbackProvider,
 		H2HManager: management.NewH2H(management.H2H{
 			Logger: c.Logger,
 			Dao:    dao.H2HPersister,
@@ -96,21 +163,65 @@ func (c *Container) RegisterAPIUsecase(infra Infra, cacher storage.Cacher) APIUs
 			Dao:    dao.WorkflowPersister,
 			Cacher: cacher,
 		}),
+		InvoiceManager: management.NewInvoice(management.Invoice{
+			Dao:    dao.InvoicePersister,
+			Logger: c.Logger,
+		}),
+		SettlementManager: management.NewSettlement(management.Settlement{
+			Dao:    dao.SettlementPersister,
+			Logger: c.Logger,
+		}),
+		LedgerManager: management.NewLedger(management.Ledger{
+			Dao:    dao.LedgerPersister,
+			Logger: c.Logger,
+		}),
+		DepositManager: management.NewDeposit(management.Deposit{
+			Dao:        dao.DepositPersister,
+			PartnerDao: dao.PartnerPersister,
+			Logger:     c.Logger,
+		}),
 		ClientTransactionProvider: client.NewTransaction(client.Transaction{
-			TransactionDao:                dao.TransactionPersister,
-			CashbackDao:                   dao.CashbackPersister,
-			TierDao:                       dao.TierPersister,
-			CashbackProvider:              cashbackProvider,
-			QueueNotificationEmailInvoice: &qNotificationEmailInvoice,
-			TierProvider:                  tierProvider,
-			SqsAdapter:                    infra.SQSAdapter,
-			Factory:                       h2hFactory,
-			Logger:                        c.Logger,
-			Cacher:                        cacher,
+			TransactionDao:      dao.TransactionPersister,
+			CashbackDao:         dao.CashbackPersister,
+			DepositDao:          dao.DepositPersister,
+			TierDao:             dao.TierPersister,
+			CashbackProvider:    cashbackProvider,
+			ReceiptProvider:     receiptProvider,
+			TierProvider:        tierProvider,
+			EventProvider:       eventProvider,
+			SqsAdapter:          infra.SQSAdapter,
+			QueueReconciliation: &qCashbackReconciliation,
+			Factory:             h2hFactory,
+			Logger:              c.Logger,
+			Cacher:              cacher,
+		}),
+		TemplateProvider:   templateProvider,
+		PreferenceProvider: preferenceProvider,
+		NotificationLogProvider: notification.NewLog(notification.Log{
+			Dao:        dao.NotificationPersister,
+			SqsAdapter: infra.SQSAdapter,
+			Logger:     c.Logger,
 		}),
 		PartnerTransactionProvider: partner.NewTransaction(partner.Transaction{
-			Dao:    dao.TransactionPersister,
+			Dao:             dao.TransactionPersister,
+			ReceiptProvider: receiptProvider,
+			SqsAdapter:      infra.SQSAdapter,
+			QueueExport:     &qTransactionExport,
+			Logger:          c.Logger,
+		}),
+		PartnerSuppressionProvider: partner.NewSuppression(partner.Suppression{
+			Dao:    dao.SuppressionPersister,
+			Logger: c.Logger,
+		}),
+		PartnerInvoiceProvider: partner.NewInvoice(partner.Invoice{
+			Dao:    dao.InvoicePersister,
+			CDN:    &cdn,
+			Logger: c.Logger,
+		}),
+		PartnerDepositProvider: partner.NewDeposit(partner.Deposit{
+			Dao:    dao.DepositPersister,
 			Logger: c.Logger,
 		}),
+		PartnerReportProvider: c.report(dao, cacher),
 	}
 }
@@ -1,7 +1,11 @@
 package cdi
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
 	"encoding/json"
+	"encoding/pem"
 	"github.com/adinandradrs/cezbek-engine/internal/adaptor"
 	"github.com/adinandradrs/cezbek-engine/internal/apps"
 	"github.com/adinandradrs/cezbek-engine/internal/repository"
@@ -26,18 +30,22 @@ type (
 		HttpLogger fiber.Handler
 		Viper      *viper.Viper
 		app        string
+		pool       *storage.PgPool
 	}
 
 	Env struct {
-		ContextPath string
-		HttpPort    string
+		ContextPath   string
+		HttpPort      string
+		BackOfficeKey string
 	}
 
 	Infra struct {
 		adaptor.S3Watcher
 		adaptor.SQSAdapter
 		adaptor.SESAdapter
+		adaptor.MessagingAdapter
 		CiamPartner adaptor.CiamWatcher
+		Jwks        string
 		adaptor.XenitAdapter
 		adaptor.GopaidAdapter
 		adaptor.MiddletransAdapter
@@ -53,6 +61,17 @@ type (
 		repository.WorkflowPersister
 		repository.CashbackPersister
 		repository.TierPersister
+		repository.AuditPersister
+		repository.OfficerPersister
+		repository.NotificationPersister
+		repository.PreferencePersister
+		repository.SuppressionPersister
+		repository.AnalyticsPersister
+		repository.InvoicePersister
+		repository.SettlementPersister
+		repository.LedgerPersister
+		repository.DepositPersister
+		repository.SnapshotPersister
 	}
 )
 
@@ -87,8 +106,11 @@ func NewContainer(app string) Container {
 }
 
 func (c *Container) loadPool() *storage.PgPool {
+	if c.pool != nil {
+		return c.pool
+	}
 	opts := c.Viper.GetString("db_options")
-	return storage.NewPgPool(&storage.PgOptions{
+	c.pool = storage.NewPgPool(&storage.PgOptions{
 		Host:    c.Viper.GetString("db.host"),
 		Port:    c.Viper.GetString("db.port"),
 		User:    c.Viper.GetString("db.username"),
@@ -97,18 +119,30 @@ func (c *Container) loadPool() *storage.PgPool {
 		Options: &opts,
 		Logger:  c.Logger,
 	})
+	return c.pool
 }
 
 func (c *Container) registerRepository() Dao {
 	p := c.loadPool()
 	return Dao{
-		PartnerPersister:     repository.NewPartner(repository.Partner{Logger: c.Logger, Pool: p.Pool}),
-		ParamPersister:       repository.NewParameter(repository.Parameter{Logger: c.Logger, Pool: p.Pool}),
-		H2HPersister:         repository.NewH2H(repository.H2H{Logger: c.Logger, Pool: p.Pool}),
-		TransactionPersister: repository.NewTransaction(repository.Transaction{Logger: c.Logger, Pool: p.Pool}),
-		WorkflowPersister:    repository.NewWorkflow(repository.Workflow{Logger: c.Logger, Pool: p.Pool}),
-		CashbackPersister:    repository.NewCashback(repository.Cashback{Logger: c.Logger, Pool: p.Pool}),
-		TierPersister:        repository.NewTier(repository.Tier{Logger: c.Logger, Pool: p.Pool}),
+		PartnerPersister:      repository.NewPartner(repository.Partner{Logger: c.Logger, Pool: p.Pool}),
+		ParamPersister:        repository.NewParameter(repository.Parameter{Logger: c.Logger, Pool: p.Pool}),
+		H2HPersister:          repository.NewH2H(repository.H2H{Logger: c.Logger, Pool: p.Pool}),
+		TransactionPersister:  repository.NewTransaction(repository.Transaction{Logger: c.Logger, Pool: p.Pool}),
+		WorkflowPersister:     repository.NewWorkflow(repository.Workflow{Logger: c.Logger, Pool: p.Pool}),
+		CashbackPersister:     repository.NewCashback(repository.Cashback{Logger: c.Logger, Pool: p.Pool}),
+		TierPersister:         repository.NewTier(repository.Tier{Logger: c.Logger, Pool: p.Pool}),
+		AuditPersister:        repository.NewAudit(repository.Audit{Logger: c.Logger, Pool: p.Pool}),
+		OfficerPersister:      repository.NewOfficer(repository.Officer{Logger: c.Logger, Pool: p.Pool}),
+		NotificationPersister: repository.NewNotification(repository.Notification{Logger: c.Logger, Pool: p.Pool}),
+		PreferencePersister:   repository.NewPreference(repository.Preference{Logger: c.Logger, Pool: p.Pool}),
+		SuppressionPersister:  repository.NewSuppression(repository.Suppression{Logger: c.Logger, Pool: p.Pool}),
+		AnalyticsPersister:    repository.NewAnalytics(repository.Analytics{Logger: c.Logger, Pool: p.Pool}),
+		InvoicePersister:      repository.NewInvoice(repository.Invoice{Logger: c.Logger, Pool: p.Pool}),
+		SettlementPersister:   repository.NewSettlement(repository.Settlement{Logger: c.Logger, Pool: p.Pool}),
+		LedgerPersister:       repository.NewLedger(repository.Ledger{Logger: c.Logger, Pool: p.Pool}),
+		DepositPersister:      repository.NewDeposit(repository.Deposit{Logger: c.Logger, Pool: p.Pool}),
+		SnapshotPersister:     repository.NewSnapshot(repository.Snapshot{Logger: c.Logger, Pool: p.Pool}),
 	}
 }
 
@@ -122,28 +156,26 @@ func (c *Container) LoadInfra() Infra {
 		Credentials: credentials.NewStaticCredentials(kid, skey, ""),
 	})
 
-	return Infra{
+	infra := Infra{
 		S3Watcher: adaptor.NewS3(adaptor.S3Bucket{
-			Bucket:   c.Viper.GetString("aws.s3.bucket"),
-			Uploader: s3manager.NewUploader(sjkt),
-			Logger:   c.Logger,
+			Bucket:     c.Viper.GetString("aws.s3.bucket"),
+			Uploader:   s3manager.NewUploader(sjkt),
+			Downloader: s3manager.NewDownloader(sjkt),
+			Logger:     c.Logger,
 		}),
 		SQSAdapter: adaptor.NewSQS(adaptor.SQS{
 			SQS: sqs.New(sjkt),
 		}),
-		SESAdapter: adaptor.NewSES(adaptor.SES{
-			SES:    sesv2.New(sjkt),
-			Sender: &sender,
+		MessagingAdapter: adaptor.NewMessaging(adaptor.Messaging{
 			Logger: c.Logger,
-		}),
-		CiamPartner: adaptor.NewCognito(adaptor.Cognito{
-			Provider: c.LoadCognito(c.Viper.GetString("aws.region.sgp")),
-			ClientId: c.Viper.GetString("aws.ciam.partner.clientid"),
-			UserPool: c.Viper.GetString("aws.ciam.partner.poolid"),
-			Scrt:     c.Viper.GetString("aws.ciam.partner.secret"),
-			Region:   c.Viper.GetString("aws.ciam.region"),
-			JWK:      jwk,
-			Logger:   c.Logger,
+			Host:   c.Viper.GetString("messaging.host"),
+			ApiKey: c.Viper.GetString("messaging.apikey"),
+			Sender: c.Viper.GetString("messaging.sender"),
+			Rest: adaptor.Rest{
+				Timeout:           c.Viper.GetDuration("messaging.timeout"),
+				BackoffInterval:   c.Viper.GetDuration("messaging.backoff"),
+				MaxJitterInterval: c.Viper.GetDuration("messaging.jitter"),
+			},
 		}),
 		LinksajaAdapter: adaptor.NewLinksaja(adaptor.Linksaja{
 			Logger:   c.Logger,
@@ -197,6 +229,84 @@ func (c *Container) LoadInfra() Infra {
 			},
 		}),
 	}
+	if c.Viper.GetString("email.provider") == "smtp" {
+		infra.SESAdapter = c.LoadSMTP()
+	} else {
+		infra.SESAdapter = adaptor.NewSES(adaptor.SES{
+			SES:    sesv2.New(sjkt),
+			Sender: &sender,
+			Logger: c.Logger,
+		})
+	}
+	if c.Viper.GetString("ciam.provider") == "local" {
+		infra.CiamPartner, infra.Jwks = c.LoadLocalCiam()
+	} else {
+		infra.CiamPartner = adaptor.NewCognito(adaptor.Cognito{
+			Provider: c.LoadCognito(c.Viper.GetString("aws.region.sgp")),
+			ClientId: c.Viper.GetString("aws.ciam.partner.clientid"),
+			UserPool: c.Viper.GetString("aws.ciam.partner.poolid"),
+			Scrt:     c.Viper.GetString("aws.ciam.partner.secret"),
+			Region:   c.Viper.GetString("aws.ciam.region"),
+			JWK:      jwk,
+			Logger:   c.Logger,
+		})
+	}
+	return infra
+}
+
+func (c *Container) loadSigningKey() *rsa.PrivateKey {
+	block, _ := pem.Decode([]byte(c.Viper.GetString("ciam.local.private_key")))
+	if block == nil {
+		c.Logger.Warn("no CIAM local signing key configured, issued tokens will not survive a restart")
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			c.Logger.Panic("failed to generate CIAM local signing key", zap.Error(err))
+		}
+		return key
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key
+	}
+	pk, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	key, ok := pk.(*rsa.PrivateKey)
+	if err != nil || !ok {
+		c.Logger.Panic("failed to parse CIAM local signing key", zap.Error(err))
+	}
+	return key
+}
+
+func (c *Container) LoadLocalCiam() (adaptor.CiamWatcher, string) {
+	key := c.loadSigningKey()
+	kid := c.Viper.GetString("ciam.local.kid")
+	jwks, err := adaptor.NewJwks(&key.PublicKey, kid)
+	if err != nil {
+		c.Logger.Panic("failed to build CIAM local JWKS", zap.Error(err))
+	}
+	return adaptor.NewLocalCiam(adaptor.LocalCiam{
+		Dao:        repository.NewCiamUser(repository.CiamUser{Logger: c.Logger, Pool: c.loadPool().Pool}),
+		PrivateKey: key,
+		KeyId:      kid,
+		Issuer:     c.Viper.GetString("ciam.local.issuer"),
+		ClientId:   c.Viper.GetString("ciam.local.clientid"),
+		JWK:        jwks,
+		TokenTTL:   c.Viper.GetDuration("ciam.local.token_ttl"),
+		RefreshTTL: c.Viper.GetDuration("ciam.local.refresh_ttl"),
+		Logger:     c.Logger,
+	}), jwks
+}
+
+func (c *Container) LoadSMTP() adaptor.SESAdapter {
+	return adaptor.NewSMTP(adaptor.SMTP{
+		Host:        c.Viper.GetString("smtp.host"),
+		Port:        c.Viper.GetInt("smtp.port"),
+		Username:    c.Viper.GetString("smtp.username"),
+		Password:    c.Viper.GetString("smtp.password"),
+		Sender:      c.Viper.GetString("smtp.sender"),
+		StartTLS:    c.Viper.GetBool("smtp.starttls"),
+		Timeout:     c.Viper.GetDuration("smtp.timeout"),
+		IdleTimeout: c.Viper.GetDuration("smtp.idle_timeout"),
+		Logger:      c.Logger,
+	})
 }
 
 func (c *Container) LoadCognito(region string) *cognito.CognitoIdentityProvider {
@@ -230,7 +340,16 @@ func (c *Container) LoadRedis() (rds storage.Cacher) {
 
 func (c *Container) LoadEnv() Env {
 	return Env{
-		ContextPath: c.Viper.GetString("base_path"),
-		HttpPort:    c.Viper.GetString("app_port"),
+		ContextPath:   c.Viper.GetString("base_path"),
+		HttpPort:      c.Viper.GetString("app_port"),
+		BackOfficeKey: c.Viper.GetString("backoffice.apikey"),
+	}
+}
+
+func (c *Container) secret(k string) string {
+	v := c.Viper.GetString(k)
+	if v == "" {
+		c.Logger.Panic("secret is not configured", zap.String("key", k))
 	}
+	return v
 }
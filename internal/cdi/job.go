@@ -4,37 +4,138 @@ import (
 	"github.com/adinandradrs/cezbek-engine/internal/storage"
 	"github.com/adinandradrs/cezbek-engine/internal/usecase/h2h"
 	"github.com/adinandradrs/cezbek-engine/internal/usecase/job"
+	"github.com/adinandradrs/cezbek-engine/internal/usecase/notification"
+	"github.com/shopspring/decimal"
 )
 
 type JobUsecase struct {
-	JobOnboardWatcher     job.OnboardWatcher
-	JobTransactionWatcher job.TransactionWatcher
-	JobTierWatcher        job.TierWatcher
-	H2HFactory            h2h.Factory
+	JobOnboardWatcher        job.OnboardWatcher
+	JobTransactionWatcher    job.TransactionWatcher
+	JobTierWatcher           job.TierWatcher
+	JobFeedbackWatcher       job.FeedbackWatcher
+	JobBillingWatcher        job.BillingWatcher
+	JobExportWatcher         job.ExportWatcher
+	JobSnapshotWatcher       job.SnapshotWatcher
+	JobReconciliationWatcher job.ReconciliationWatcher
+	H2HFactory               h2h.Factory
+}
+
+func (c *Container) consumer(infra Infra, q string, dlq string) job.QueueConsumer {
+	return job.NewConsumer(job.Consumer{
+		SqsAdapter:        infra.SQSAdapter,
+		Queue:             q,
+		DeadLetterQueue:   dlq,
+		MaxAttempts:       c.Viper.GetInt64("aws.sqs.consumer.max_attempts"),
+		MaxMessages:       c.Viper.GetInt64("aws.sqs.consumer.max_messages"),
+		WaitSeconds:       c.Viper.GetInt64("aws.sqs.consumer.wait_seconds"),
+		VisibilityTimeout: c.Viper.GetInt64("aws.sqs.consumer.visibility_timeout"),
+		Workers:           c.Viper.GetInt("aws.sqs.consumer.workers"),
+		Logger:            c.Logger,
+	})
 }
 
 func (c *Container) RegisterJobUsecase(infra Infra, cacher storage.Cacher) JobUsecase {
+	c.Viper.SetDefault("schedule.snapshot", "0 * * * *")
+	c.Viper.SetDefault("snapshot.lookback", "72h")
 	dao := c.registerRepository()
 	qNotificationEmailOtp := c.Viper.GetString("aws.sqs.topic.notification_email_otp")
 	qNotificationEmailTrx := c.Viper.GetString("aws.sqs.topic.notification_email_invoice")
 	expired := c.Viper.GetDuration("wfreward.expiry_duration")
+	cdn := c.Viper.GetString("aws.cdn_base")
+	path := c.Viper.GetString("aws.s3.path")
+	notifier := job.Notifier{
+		SesAdapter:       infra.SESAdapter,
+		MessagingAdapter: infra.MessagingAdapter,
+		Dao:              dao.NotificationPersister,
+		SuppressionDao:   dao.SuppressionPersister,
+		Logger:           c.Logger,
+	}
+	templateProvider := notification.NewTemplate(notification.Template{
+		Dao:           dao.ParamPersister,
+		Cacher:        cacher,
+		DefaultLocale: c.Viper.GetString("notification.default_locale"),
+		Logger:        c.Logger,
+	})
+	eventProvider := notification.NewEvent(notification.Event{
+		SqsAdapter:       infra.SQSAdapter,
+		TemplateProvider: templateProvider,
+		PreferenceProvider: notification.NewPreference(notification.Preference{
+			Dao:            dao.PreferencePersister,
+			UnsubscribeUrl: c.Viper.GetString("notification.unsubscribe.url"),
+			Secret:         c.secret("notification.unsubscribe.secret"),
+			Logger:         c.Logger,
+		}),
+		ChannelProvider: notification.NewChannel(notification.Channel{
+			Cacher:  cacher,
+			Default: c.Viper.GetString("notification.default_channel"),
+			Logger:  c.Logger,
+		}),
+		Queue:  &qNotificationEmailTrx,
+		Logger: c.Logger,
+	})
 	return JobUsecase{
 		JobOnboardWatcher: job.NewOnboard(job.Onboard{
-			Logger:                    c.Logger,
-			QueueNotificationEmailOtp: &qNotificationEmailOtp,
-			SqsAdapter:                infra.SQSAdapter,
-			SesAdapter:                infra.SESAdapter,
+			Logger: c.Logger,
+			Consumer: c.consumer(infra, qNotificationEmailOtp,
+				c.Viper.GetString("aws.sqs.topic.notification_email_otp_dlq")),
+			Notifier: notifier,
 		}),
 		JobTransactionWatcher: job.NewTransaction(job.Transaction{
-			Logger:                    c.Logger,
-			QueueNotificationEmailTrx: &qNotificationEmailTrx,
-			SqsAdapter:                infra.SQSAdapter,
-			SesAdapter:                infra.SESAdapter,
+			Logger: c.Logger,
+			Consumer: c.consumer(infra, qNotificationEmailTrx,
+				c.Viper.GetString("aws.sqs.topic.notification_email_invoice_dlq")),
+			Notifier: notifier,
 		}),
 		JobTierWatcher: job.NewTier(job.Tier{
-			Logger:  c.Logger,
-			Dao:     dao.TierPersister,
-			Expired: &expired,
+			Logger:        c.Logger,
+			Dao:           dao.TierPersister,
+			Cacher:        cacher,
+			EventProvider: eventProvider,
+			Expired:       &expired,
+		}),
+		JobFeedbackWatcher: job.NewFeedback(job.Feedback{
+			Logger: c.Logger,
+			Dao:    dao.SuppressionPersister,
+			Consumer: c.consumer(infra, c.Viper.GetString("aws.sqs.topic.email_feedback"),
+				c.Viper.GetString("aws.sqs.topic.email_feedback_dlq")),
+		}),
+		JobBillingWatcher: job.NewBilling(job.Billing{
+			Logger:           c.Logger,
+			Dao:              dao.InvoicePersister,
+			S3Watcher:        infra.S3Watcher,
+			SqsAdapter:       infra.SQSAdapter,
+			TemplateProvider: templateProvider,
+			Queue:            &qNotificationEmailTrx,
+			ServiceFee:       decimal.NewFromFloat(c.Viper.GetFloat64("billing.service_fee")),
+			Tax:              decimal.NewFromFloat(c.Viper.GetFloat64("billing.tax")),
+			DueDays:          c.Viper.GetInt("billing.due_days"),
+			CDN:              &cdn,
+			PathS3:           &path,
+			Secret:           c.secret("billing.secret"),
+		}),
+		JobExportWatcher: job.NewExport(job.Export{
+			Logger:           c.Logger,
+			Dao:              dao.TransactionPersister,
+			S3Watcher:        infra.S3Watcher,
+			SqsAdapter:       infra.SQSAdapter,
+			TemplateProvider: templateProvider,
+			Consumer: c.consumer(infra, c.Viper.GetString("aws.sqs.topic.transaction_export"),
+				c.Viper.GetString("aws.sqs.topic.transaction_export_dlq")),
+			Queue:   &qNotificationEmailTrx,
+			PathS3:  &path,
+			LinkTTL: c.Viper.GetDuration("ttl.export_link"),
+		}),
+		JobSnapshotWatcher: job.NewSnapshot(job.Snapshot{
+			Logger:   c.Logger,
+			Dao:      dao.SnapshotPersister,
+			Lookback: c.Viper.GetDuration("snapshot.lookback"),
+		}),
+		JobReconciliationWatcher: job.NewReconciliation(job.Reconciliation{
+			Logger:      c.Logger,
+			DepositDao:  dao.DepositPersister,
+			CashbackDao: dao.CashbackPersister,
+			Consumer: c.consumer(infra, c.Viper.GetString("aws.sqs.topic.cashback_reconciliation"),
+				c.Viper.GetString("aws.sqs.topic.cashback_reconciliation_dlq")),
 		}),
 		H2HFactory: h2h.NewFactory(h2h.Factory{
 			Cacher: cacher,
@@ -25,16 +25,16 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/partner/v1/transactions": {
+        "/partner/v1/analytics/cashbacks": {
             "get": {
-                "description": "API to search transaction by partner",
+                "description": "API to report the cashback volume by day, wallet and provider of the partner",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Transaction Partner APIs"
+                    "Analytics Partner APIs"
                 ],
-                "summary": "API Transaction Search",
+                "summary": "API Partner Cashback Volume Report",
                 "parameters": [
                     {
                         "type": "string",
@@ -84,37 +84,18 @@ const docTemplate = `{
                         "name": "x-client-timestamp",
                         "in": "header"
                     },
-                    {
-                        "type": "integer",
-                        "example": 5,
-                        "name": "limit",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "enum": [
-                            "ASC",
-                            "DESC"
-                        ],
-                        "type": "string",
-                        "name": "sort",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "name": "sort_by",
+                        "default": "2023-01-01",
+                        "description": "Start Date",
+                        "name": "start_date",
                         "in": "query"
                     },
-                    {
-                        "type": "integer",
-                        "example": 0,
-                        "name": "start",
-                        "in": "query",
-                        "required": true
-                    },
                     {
                         "type": "string",
-                        "name": "text_search",
+                        "default": "2023-01-31",
+                        "description": "End Date",
+                        "name": "end_date",
                         "in": "query"
                     }
                 ],
@@ -122,7 +103,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.PartnerTransactionSearchResponse"
+                            "$ref": "#/definitions/model.CashbackVolumeResponse"
                         }
                     },
                     "400": {
@@ -158,16 +139,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/partner/v1/transactions/{id}": {
+        "/partner/v1/analytics/customers": {
             "get": {
-                "description": "API to view detail transaction by partner",
+                "description": "API to report the unique customers and the average basket of the partner",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Transaction Partner APIs"
+                    "Analytics Partner APIs"
                 ],
-                "summary": "API Transaction Detail",
+                "summary": "API Partner Customer Summary Report",
                 "parameters": [
                     {
                         "type": "string",
@@ -218,18 +199,25 @@ const docTemplate = `{
                         "in": "header"
                     },
                     {
-                        "type": "integer",
-                        "description": "Transaction ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "default": "2023-01-01",
+                        "description": "Start Date",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "2023-01-31",
+                        "description": "End Date",
+                        "name": "end_date",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.PartnerTransactionProjection"
+                            "$ref": "#/definitions/model.CustomerSummaryResponse"
                         }
                     },
                     "400": {
@@ -265,46 +253,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/ping": {
+        "/partner/v1/analytics/tiers": {
             "get": {
-                "description": "Ping the status of server, should be respond fastly.",
-                "consumes": [
-                    "*/*"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Default APIs"
-                ],
-                "summary": "Show the status of server.",
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    },
-                    "401": {
-                        "description": "Unauthorized"
-                    },
-                    "403": {
-                        "description": "Forbidden"
-                    },
-                    "500": {
-                        "description": "Internal Server Error"
-                    }
-                }
-            }
-        },
-        "/v1/authorization/b2b": {
-            "post": {
-                "description": "API to authorize B2B officer account",
+                "description": "API to report the customers on each current tier of the partner",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Authorization APIs"
+                    "Analytics Partner APIs"
                 ],
-                "summary": "API B2B Authorization",
+                "summary": "API Partner Tier Distribution Report",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Your Token to Access",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
                     {
                         "enum": [
                             "EBIZKEZBEK",
@@ -344,32 +311,23 @@ const docTemplate = `{
                         "description": "Client Original Timestamp in UNIX format (EPOCH)",
                         "name": "x-client-timestamp",
                         "in": "header"
-                    },
-                    {
-                        "description": "B2B Officer Authentication Payload",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.OfficerAuthenticationRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.OfficerAuthenticationResponse"
+                            "$ref": "#/definitions/model.TierDistributionResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/model.Meta"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/model.Meta"
                         }
@@ -379,32 +337,32 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/model.Meta"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
                     }
                 }
             }
         },
-        "/v1/authorization/client": {
-            "post": {
-                "description": "API to authorize client's signature and code",
+        "/partner/v1/dashboard": {
+            "get": {
+                "description": "API to compare the KPI of the partner on the period with the previous period of the same length",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Authorization APIs"
+                    "Dashboard Partner APIs"
                 ],
-                "summary": "API Client Authorization",
+                "summary": "API Partner Dashboard",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Client signature using HMAC SHA256, signature formula is \u003cb\u003eHEX(HMAC(SHA256(UPPER(HTTP-METHOD):UPPER(CODE):UNIX-EPOCH:UPPER(API-KEY))))\u003c/b\u003e",
-                        "name": "x-client-signature",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Client API Key",
-                        "name": "x-api-key",
+                        "default": "Bearer",
+                        "description": "Your Token to Access",
+                        "name": "Authorization",
                         "in": "header",
                         "required": true
                     },
@@ -446,24 +404,28 @@ const docTemplate = `{
                         "type": "string",
                         "description": "Client Original Timestamp in UNIX format (EPOCH)",
                         "name": "x-client-timestamp",
-                        "in": "header",
-                        "required": true
+                        "in": "header"
                     },
                     {
-                        "description": "Client Authentication Payload",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.ClientAuthenticationRequest"
-                        }
+                        "type": "string",
+                        "default": "2023-01-01",
+                        "description": "Start Date",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "2023-01-31",
+                        "description": "End Date",
+                        "name": "end_date",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.ClientAuthenticationResponse"
+                            "$ref": "#/definitions/model.DashboardResponse"
                         }
                     },
                     "400": {
@@ -478,26 +440,46 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.Meta"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/model.Meta"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
                     }
                 }
             }
         },
-        "/v1/authorization/otp": {
-            "post": {
-                "description": "API to validate B2B officer account OTP",
+        "/partner/v1/deposit": {
+            "get": {
+                "description": "API to get the deposit balance of the partner, the balance is low once the available balance goes below its threshold",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Authorization APIs"
+                    "Deposit Partner APIs"
                 ],
-                "summary": "API B2B OTP Validation",
+                "summary": "API Deposit Balance",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Your Token to Access",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
                     {
                         "enum": [
                             "EBIZKEZBEK",
@@ -537,39 +519,29 @@ const docTemplate = `{
                         "description": "Client Original Timestamp in UNIX format (EPOCH)",
                         "name": "x-client-timestamp",
                         "in": "header"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Client Transaction ID",
-                        "name": "x-client-trxid",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "description": "B2B Officer Authentication Payload",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.OfficerValidationRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.OfficerValidationResponse"
+                            "$ref": "#/definitions/model.DepositResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/model.Meta"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/model.Meta"
                         }
@@ -579,20 +551,26 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/model.Meta"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
                     }
                 }
             }
         },
-        "/v1/cashbacks": {
-            "post": {
-                "description": "API to apply cashback on client's transaction",
+        "/partner/v1/invoices": {
+            "get": {
+                "description": "API to search the monthly invoices of the partner with their payment status, an unpaid invoice after its due date is overdue",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Client Cashback APIs"
+                    "Invoice Partner APIs"
                 ],
-                "summary": "API Apply Cashback",
+                "summary": "API Invoice Search",
                 "parameters": [
                     {
                         "type": "string",
@@ -643,20 +621,44 @@ const docTemplate = `{
                         "in": "header"
                     },
                     {
-                        "description": "Transaction Payload",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.TransactionRequest"
-                        }
+                        "type": "integer",
+                        "example": 5,
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "ASC",
+                            "DESC"
+                        ],
+                        "type": "string",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 0,
+                        "name": "start",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "text_search",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.TransactionResponse"
+                            "$ref": "#/definitions/model.InvoiceSearchResponse"
                         }
                     },
                     "400": {
@@ -692,16 +694,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/cashbacks/{msisdn}": {
+        "/partner/v1/officers": {
             "get": {
-                "description": "API to retrieve tier information",
+                "description": "API to list active officers of the partner, only for admin role",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Client Cashback APIs"
+                    "Officer Partner APIs"
                 ],
-                "summary": "API Tier Information",
+                "summary": "API Officer List",
                 "parameters": [
                     {
                         "type": "string",
@@ -713,8 +715,7 @@ const docTemplate = `{
                     },
                     {
                         "enum": [
-                            "EBIZKEZBEK",
-                            "B2BCLIENT"
+                            "EBIZKEZBEK"
                         ],
                         "type": "string",
                         "description": "Client Channel",
@@ -750,20 +751,110 @@ const docTemplate = `{
                         "description": "Client Original Timestamp in UNIX format (EPOCH)",
                         "name": "x-client-timestamp",
                         "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.OfficerProjection"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "API to invite a new officer to the partner portal with a partner-scoped role, only for admin role",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Officer Partner APIs"
+                ],
+                "summary": "API Officer Invite",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Your Token to Access",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
                     },
                     {
+                        "enum": [
+                            "EBIZKEZBEK"
+                        ],
                         "type": "string",
-                        "description": "Customer MSISDN",
-                        "name": "msisdn",
-                        "in": "path",
+                        "description": "Client Channel",
+                        "name": "x-client-channel",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "android 10",
+                        "description": "Client OS or Browser Agent",
+                        "name": "x-client-os",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Device ID",
+                        "name": "x-client-device",
+                        "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "1.0.0",
+                        "description": "Client Platform Version",
+                        "name": "x-client-version",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Original Timestamp in UNIX format (EPOCH)",
+                        "name": "x-client-timestamp",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Officer Invitation Payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.AddOfficerRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.TransactionTierResponse"
+                            "$ref": "#/definitions/model.TransactionResponse"
                         }
                     },
                     "400": {
@@ -789,38 +880,32 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/model.Meta"
                         }
-                    },
-                    "503": {
-                        "description": "Service Unavailable",
-                        "schema": {
-                            "$ref": "#/definitions/model.Meta"
-                        }
                     }
                 }
             }
         },
-        "/v1/partners": {
-            "post": {
-                "description": "API to register a new B2B Partner data as user and client",
+        "/partner/v1/officers/{id}": {
+            "delete": {
+                "description": "API to remove an officer from the partner portal and end the officer session, only for admin role",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Partner Management APIs"
+                    "Officer Partner APIs"
                 ],
-                "summary": "API Add Partner",
+                "summary": "API Officer Remove",
                 "parameters": [
                     {
                         "type": "string",
                         "default": "Bearer",
                         "description": "Your Token to Access",
                         "name": "Authorization",
-                        "in": "header"
+                        "in": "header",
+                        "required": true
                     },
                     {
                         "enum": [
-                            "EBIZKEZBEK",
-                            "B2BCLIENT"
+                            "EBIZKEZBEK"
                         ],
                         "type": "string",
                         "description": "Client Channel",
@@ -857,67 +942,152 @@ const docTemplate = `{
                         "name": "x-client-timestamp",
                         "in": "header"
                     },
+                    {
+                        "type": "integer",
+                        "description": "Officer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TransactionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    }
+                }
+            }
+        },
+        "/partner/v1/suppressions": {
+            "get": {
+                "description": "API to search the suppressed customer emails by partner, an email is suppressed on a hard bounce or a complaint",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suppression Partner APIs"
+                ],
+                "summary": "API Suppression Search",
+                "parameters": [
                     {
                         "type": "string",
-                        "default": "PT. Lajada Piranti Commerce",
-                        "description": "Partner Corporate",
-                        "name": "partner",
-                        "in": "formData",
+                        "default": "Bearer",
+                        "description": "Your Token to Access",
+                        "name": "Authorization",
+                        "in": "header",
                         "required": true
                     },
                     {
+                        "enum": [
+                            "EBIZKEZBEK",
+                            "B2BCLIENT"
+                        ],
                         "type": "string",
-                        "default": "LAJADA",
-                        "description": "Partner Code",
-                        "name": "code",
-                        "in": "formData",
+                        "description": "Client Channel",
+                        "name": "x-client-channel",
+                        "in": "header",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "default": "kezbek.support@lajada.net",
-                        "description": "Partner Email",
-                        "name": "email",
-                        "in": "formData",
+                        "default": "android 10",
+                        "description": "Client OS or Browser Agent",
+                        "name": "x-client-os",
+                        "in": "header",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "default": "628123456789",
-                        "description": "MSISDN",
-                        "name": "msisdn",
-                        "in": "formData",
+                        "description": "Client Device ID",
+                        "name": "x-client-device",
+                        "in": "header",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "default": "John Doe",
-                        "description": "Partner Officer",
-                        "name": "officer",
-                        "in": "formData",
+                        "default": "1.0.0",
+                        "description": "Client Platform Version",
+                        "name": "x-client-version",
+                        "in": "header",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "default": "Bintaro Exchange Mall Blok A1",
-                        "description": "Office Address",
-                        "name": "address",
-                        "in": "formData",
+                        "description": "Client Original Timestamp in UNIX format (EPOCH)",
+                        "name": "x-client-timestamp",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 5,
+                        "name": "limit",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "type": "file",
-                        "description": "Logo",
-                        "name": "logo",
-                        "in": "formData",
+                        "enum": [
+                            "ASC",
+                            "DESC"
+                        ],
+                        "type": "string",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 0,
+                        "name": "start",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "text_search",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.TransactionResponse"
+                            "$ref": "#/definitions/model.SuppressionSearchResponse"
                         }
                     },
                     "400": {
@@ -952,182 +1122,4775 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "model.ClientAuthenticationRequest": {
-            "type": "object",
-            "required": [
-                "code"
-            ],
-            "properties": {
-                "code": {
-                    "type": "string",
-                    "example": "LAJADA"
-                }
-            }
         },
-        "model.ClientAuthenticationResponse": {
-            "type": "object",
-            "properties": {
-                "access_token": {
+        "/partner/v1/transactions": {
+            "get": {
+                "description": "API to search transaction by partner, sort_by is one of ID, DATE, WALLET_CODE, TRANSACTION, AMOUNT (same as TRANSACTION), CASHBACK or REWARD",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transaction Partner APIs"
+                ],
+                "summary": "API Transaction Search",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Your Token to Access",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "EBIZKEZBEK",
+                            "B2BCLIENT"
+                        ],
+                        "type": "string",
+                        "description": "Client Channel",
+                        "name": "x-client-channel",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "android 10",
+                        "description": "Client OS or Browser Agent",
+                        "name": "x-client-os",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Device ID",
+                        "name": "x-client-device",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "1.0.0",
+                        "description": "Client Platform Version",
+                        "name": "x-client-version",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Original Timestamp in UNIX format (EPOCH)",
+                        "name": "x-client-timestamp",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2023-01-31",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "XENIT",
+                        "name": "h2h_code",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 5,
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "25000",
+                        "name": "max_cashback",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "500000",
+                        "name": "max_transaction",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "500",
+                        "name": "min_cashback",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "10000",
+                        "name": "min_transaction",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "name": "skip_count",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ASC",
+                            "DESC"
+                        ],
+                        "type": "string",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 0,
+                        "name": "start",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "2023-01-01",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "DISBURSED",
+                            "FAILED"
+                        ],
+                        "type": "string",
+                        "default": "DISBURSED",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "text_search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "LSAJA",
+                        "name": "wallet_code",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PartnerTransactionSearchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    }
+                }
+            }
+        },
+        "/partner/v1/transactions/export": {
+            "post": {
+                "description": "API to export the transactions matching the search filters into a CSV or XLSX file, the file is generated asynchronously and its time-limited download link is emailed to the officer",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transaction Partner APIs"
+                ],
+                "summary": "API Transaction Export",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Your Token to Access",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "EBIZKEZBEK",
+                            "B2BCLIENT"
+                        ],
+                        "type": "string",
+                        "description": "Client Channel",
+                        "name": "x-client-channel",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "android 10",
+                        "description": "Client OS or Browser Agent",
+                        "name": "x-client-os",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Device ID",
+                        "name": "x-client-device",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "1.0.0",
+                        "description": "Client Platform Version",
+                        "name": "x-client-version",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Original Timestamp in UNIX format (EPOCH)",
+                        "name": "x-client-timestamp",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Export Payload",
+                        "name": "Payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.TransactionExportRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TransactionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    }
+                }
+            }
+        },
+        "/partner/v1/transactions/{id}": {
+            "get": {
+                "description": "API to view detail transaction by partner",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transaction Partner APIs"
+                ],
+                "summary": "API Transaction Detail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Your Token to Access",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "EBIZKEZBEK",
+                            "B2BCLIENT"
+                        ],
+                        "type": "string",
+                        "description": "Client Channel",
+                        "name": "x-client-channel",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "android 10",
+                        "description": "Client OS or Browser Agent",
+                        "name": "x-client-os",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Device ID",
+                        "name": "x-client-device",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "1.0.0",
+                        "description": "Client Platform Version",
+                        "name": "x-client-version",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Original Timestamp in UNIX format (EPOCH)",
+                        "name": "x-client-timestamp",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PartnerTransactionProjection"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    }
+                }
+            }
+        },
+        "/partner/v1/transactions/{id}/receipt": {
+            "get": {
+                "description": "API to download the PDF cashback receipt of a transaction by partner",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Transaction Partner APIs"
+                ],
+                "summary": "API Transaction Receipt",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Your Token to Access",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "EBIZKEZBEK",
+                            "B2BCLIENT"
+                        ],
+                        "type": "string",
+                        "description": "Client Channel",
+                        "name": "x-client-channel",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "android 10",
+                        "description": "Client OS or Browser Agent",
+                        "name": "x-client-os",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Device ID",
+                        "name": "x-client-device",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "1.0.0",
+                        "description": "Client Platform Version",
+                        "name": "x-client-version",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Original Timestamp in UNIX format (EPOCH)",
+                        "name": "x-client-timestamp",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    }
+                }
+            }
+        },
+        "/ping": {
+            "get": {
+                "description": "Ping the status of server, should be respond fastly.",
+                "consumes": [
+                    "*/*"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Default APIs"
+                ],
+                "summary": "Show the status of server.",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/v1/analytics/cashbacks": {
+            "get": {
+                "description": "API to report the cashback volume by day, wallet and provider of all or a partner",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics APIs"
+                ],
+                "summary": "API Cashback Volume Report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Back Office API Key",
+                        "name": "x-api-key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "EBIZKEZBEK",
+                            "B2BCLIENT"
+                        ],
+                        "type": "string",
+                        "description": "Client Channel",
+                        "name": "x-client-channel",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "android 10",
+                        "description": "Client OS or Browser Agent",
+                        "name": "x-client-os",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Device ID",
+                        "name": "x-client-device",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "1.0.0",
+                        "description": "Client Platform Version",
+                        "name": "x-client-version",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Original Timestamp in UNIX format (EPOCH)",
+                        "name": "x-client-timestamp",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2023-01-31",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "name": "partner_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2023-01-01",
+                        "name": "start_date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.CashbackVolumeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/analytics/customers": {
+            "get": {
+                "description": "API to report the unique customers and the average basket of all or a partner",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics APIs"
+                ],
+                "summary": "API Customer Summary Report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Back Office API Key",
+                        "name": "x-api-key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "EBIZKEZBEK",
+                            "B2BCLIENT"
+                        ],
+                        "type": "string",
+                        "description": "Client Channel",
+                        "name": "x-client-channel",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "android 10",
+                        "description": "Client OS or Browser Agent",
+                        "name": "x-client-os",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Device ID",
+                        "name": "x-client-device",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "1.0.0",
+                        "description": "Client Platform Version",
+                        "name": "x-client-version",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Original Timestamp in UNIX format (EPOCH)",
+                        "name": "x-client-timestamp",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2023-01-31",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "name": "partner_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2023-01-01",
+                        "name": "start_date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.CustomerSummaryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/analytics/providers": {
+            "get": {
+                "description": "API to report the fee paid by wallet and provider against the most expensive and the default provider",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics APIs"
+                ],
+                "summary": "API Provider Savings Report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Back Office API Key",
+                        "name": "x-api-key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "EBIZKEZBEK",
+                            "B2BCLIENT"
+                        ],
+                        "type": "string",
+                        "description": "Client Channel",
+                        "name": "x-client-channel",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "android 10",
+                        "description": "Client OS or Browser Agent",
+                        "name": "x-client-os",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Device ID",
+                        "name": "x-client-device",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "1.0.0",
+                        "description": "Client Platform Version",
+                        "name": "x-client-version",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Original Timestamp in UNIX format (EPOCH)",
+                        "name": "x-client-timestamp",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2023-01-31",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "name": "partner_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2023-01-01",
+                        "name": "start_date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ProviderSavingsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/analytics/tiers": {
+            "get": {
+                "description": "API to report the customers on each current tier of all or a partner",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics APIs"
+                ],
+                "summary": "API Tier Distribution Report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Back Office API Key",
+                        "name": "x-api-key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "EBIZKEZBEK",
+                            "B2BCLIENT"
+                        ],
+                        "type": "string",
+                        "description": "Client Channel",
+                        "name": "x-client-channel",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "android 10",
+                        "description": "Client OS or Browser Agent",
+                        "name": "x-client-os",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Device ID",
+                        "name": "x-client-device",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "1.0.0",
+                        "description": "Client Platform Version",
+                        "name": "x-client-version",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Original Timestamp in UNIX format (EPOCH)",
+                        "name": "x-client-timestamp",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Partner ID",
+                        "name": "partner_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TierDistributionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/authorization/b2b": {
+            "post": {
+                "description": "API to authorize B2B officer account",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authorization APIs"
+                ],
+                "summary": "API B2B Authorization",
+                "parameters": [
+                    {
+                        "enum": [
+                            "EBIZKEZBEK",
+                            "B2BCLIENT"
+                        ],
+                        "type": "string",
+                        "description": "Client Channel",
+                        "name": "x-client-channel",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "android 10",
+                        "description": "Client OS or Browser Agent",
+                        "name": "x-client-os",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Device ID",
+                        "name": "x-client-device",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "1.0.0",
+                        "description": "Client Platform Version",
+                        "name": "x-client-version",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Original Timestamp in UNIX format (EPOCH)",
+                        "name": "x-client-timestamp",
+                        "in": "header"
+                    },
+                    {
+                        "description": "B2B Officer Authentication Payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.OfficerAuthenticationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.OfficerAuthenticationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/authorization/b2b/logout": {
+            "post": {
+                "description": "API to end the B2B officer session and revoke the current token",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authorization APIs"
+                ],
+                "summary": "API B2B Logout",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "EBIZKEZBEK"
+                        ],
+                        "type": "string",
+                        "description": "Client Channel",
+                        "name": "x-client-channel",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "android 10",
+                        "description": "Client OS or Browser Agent",
+                        "name": "x-client-os",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Device ID",
+                        "name": "x-client-device",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "1.0.0",
+                        "description": "Client Platform Version",
+                        "name": "x-client-version",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Original Timestamp in UNIX format (EPOCH)",
+                        "name": "x-client-timestamp",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/authorization/client": {
+            "post": {
+                "description": "API to authorize client's signature and code",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authorization APIs"
+                ],
+                "summary": "API Client Authorization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Client signature using HMAC SHA256, signature formula is \u003cb\u003eHEX(HMAC(SHA256(UPPER(HTTP-METHOD):UPPER(CODE):UNIX-EPOCH:UPPER(API-KEY))))\u003c/b\u003e",
+                        "name": "x-client-signature",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client API Key",
+                        "name": "x-api-key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "EBIZKEZBEK",
+                            "B2BCLIENT"
+                        ],
+                        "type": "string",
+                        "description": "Client Channel",
+                        "name": "x-client-channel",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "android 10",
+                        "description": "Client OS or Browser Agent",
+                        "name": "x-client-os",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Device ID",
+                        "name": "x-client-device",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "1.0.0",
+                        "description": "Client Platform Version",
+                        "name": "x-client-version",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Original Timestamp in UNIX format (EPOCH)",
+                        "name": "x-client-timestamp",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Client Authentication Payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ClientAuthenticationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ClientAuthenticationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/authorization/client/logout": {
+            "post": {
+                "description": "API to end the client session and revoke the current token",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authorization APIs"
+                ],
+                "summary": "API Client Logout",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "B2BCLIENT"
+                        ],
+                        "type": "string",
+                        "description": "Client Channel",
+                        "name": "x-client-channel",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "android 10",
+                        "description": "Client OS or Browser Agent",
+                        "name": "x-client-os",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Device ID",
+                        "name": "x-client-device",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "1.0.0",
+                        "description": "Client Platform Version",
+                        "name": "x-client-version",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Original Timestamp in UNIX format (EPOCH)",
+                        "name": "x-client-timestamp",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/authorization/otp": {
+            "post": {
+                "description": "API to validate B2B officer account OTP",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authorization APIs"
+                ],
+                "summary": "API B2B OTP Validation",
+                "parameters": [
+                    {
+                        "enum": [
+                            "EBIZKEZBEK",
+                            "B2BCLIENT"
+                        ],
+                        "type": "string",
+                        "description": "Client Channel",
+                        "name": "x-client-channel",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "android 10",
+                        "description": "Client OS or Browser Agent",
+                        "name": "x-client-os",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Device ID",
+                        "name": "x-client-device",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "1.0.0",
+                        "description": "Client Platform Version",
+                        "name": "x-client-version",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Original Timestamp in UNIX format (EPOCH)",
+                        "name": "x-client-timestamp",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Transaction ID",
+                        "name": "x-client-trxid",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "B2B Officer Authentication Payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.OfficerValidationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.OfficerValidationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/authorization/refresh": {
+            "post": {
+                "description": "API to issue a new token by using the CIAM refresh token and extend the active session",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authorization APIs"
+                ],
+                "summary": "API Session Refresh",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Refresh token from the last authorization",
+                        "name": "x-client-refresh-token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "EBIZKEZBEK",
+                            "B2BCLIENT"
+                        ],
+                        "type": "string",
+                        "description": "Client Channel",
+                        "name": "x-client-channel",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "android 10",
+                        "description": "Client OS or Browser Agent",
+                        "name": "x-client-os",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Device ID",
+                        "name": "x-client-device",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "1.0.0",
+                        "description": "Client Platform Version",
+                        "name": "x-client-version",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Original Timestamp in UNIX format (EPOCH)",
+                        "name": "x-client-timestamp",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Partner code for B2BCLIENT or officer email for EBIZKEZBEK",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.SessionRefreshRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SessionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/cashbacks": {
+            "post": {
+                "description": "API to apply cashback on client's transaction, the cashback and the provider fee are held from the deposit of a prepaid partner",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Client Cashback APIs"
+                ],
+                "summary": "API Apply Cashback",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Your Token to Access",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "EBIZKEZBEK",
+                            "B2BCLIENT"
+                        ],
+                        "type": "string",
+                        "description": "Client Channel",
+                        "name": "x-client-channel",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "android 10",
+                        "description": "Client OS or Browser Agent",
+                        "name": "x-client-os",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Device ID",
+                        "name": "x-client-device",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "1.0.0",
+                        "description": "Client Platform Version",
+                        "name": "x-client-version",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Original Timestamp in UNIX format (EPOCH)",
+                        "name": "x-client-timestamp",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Transaction Payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.TransactionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TransactionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/cashbacks/{msisdn}": {
+            "get": {
+                "description": "API to retrieve tier information",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Client Cashback APIs"
+                ],
+                "summary": "API Tier Information",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Your Token to Access",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "EBIZKEZBEK",
+                            "B2BCLIENT"
+                        ],
+                        "type": "string",
+                        "description": "Client Channel",
+                        "name": "x-client-channel",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "android 10",
+                        "description": "Client OS or Browser Agent",
+                        "name": "x-client-os",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Device ID",
+                        "name": "x-client-device",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "1.0.0",
+                        "description": "Client Platform Version",
+                        "name": "x-client-version",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Original Timestamp in UNIX format (EPOCH)",
+                        "name": "x-client-timestamp",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Customer MSISDN",
+                        "name": "msisdn",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TransactionTierResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/deposits/{code}": {
+            "get": {
+                "description": "API to get the deposit balance of a prepaid partner, the available balance excludes the amount held by the cashback in progress",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Deposit Management APIs"
+                ],
+                "summary": "API Deposit Detail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Back Office API Key",
+                        "name": "x-api-key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "EBIZKEZBEK",
+                            "B2BCLIENT"
+                        ],
+                        "type": "string",
+                        "description": "Client Channel",
+                        "name": "x-client-channel",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "android 10",
+                        "description": "Client OS or Browser Agent",
+                        "name": "x-client-os",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Device ID",
+                        "name": "x-client-device",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "1.0.0",
+                        "description": "Client Platform Version",
+                        "name": "x-client-version",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Original Timestamp in UNIX format (EPOCH)",
+                        "name": "x-client-timestamp",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "default": "LAJADA",
+                        "description": "Partner Code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.DepositResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/deposits/{code}/topup": {
+            "post": {
+                "description": "API to record the fund transferred by a partner into its deposit, the partner becomes prepaid from its first top up and the low balance threshold is kept when it is not given",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Deposit Management APIs"
+                ],
+                "summary": "API Deposit Top Up",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Back Office API Key",
+                        "name": "x-api-key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "EBIZKEZBEK",
+                            "B2BCLIENT"
+                        ],
+                        "type": "string",
+                        "description": "Client Channel",
+                        "name": "x-client-channel",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "android 10",
+                        "description": "Client OS or Browser Agent",
+                        "name": "x-client-os",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Device ID",
+                        "name": "x-client-device",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "1.0.0",
+                        "description": "Client Platform Version",
+                        "name": "x-client-version",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Original Timestamp in UNIX format (EPOCH)",
+                        "name": "x-client-timestamp",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "default": "LAJADA",
+                        "description": "Partner Code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Top Up Payload",
+                        "name": "Payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.DepositTopUpRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TransactionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/invoices/{id}/payment": {
+            "put": {
+                "description": "API to mark a partner invoice as paid by the reference of the partner payment",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Invoice Management APIs"
+                ],
+                "summary": "API Invoice Payment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Back Office API Key",
+                        "name": "x-api-key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "EBIZKEZBEK",
+                            "B2BCLIENT"
+                        ],
+                        "type": "string",
+                        "description": "Client Channel",
+                        "name": "x-client-channel",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "android 10",
+                        "description": "Client OS or Browser Agent",
+                        "name": "x-client-os",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Device ID",
+                        "name": "x-client-device",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "1.0.0",
+                        "description": "Client Platform Version",
+                        "name": "x-client-version",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Original Timestamp in UNIX format (EPOCH)",
+                        "name": "x-client-timestamp",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Invoice ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payment Payload",
+                        "name": "Payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.InvoicePaymentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TransactionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/ledgers/accounts/{type}/{code}/balance": {
+            "get": {
+                "description": "API to get the balance of a ledger account within a period, the balance is on the debit side for the receivable and prefund accounts and on the credit side for the fee and payable accounts",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ledger Management APIs"
+                ],
+                "summary": "API Ledger Balance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Back Office API Key",
+                        "name": "x-api-key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "EBIZKEZBEK",
+                            "B2BCLIENT"
+                        ],
+                        "type": "string",
+                        "description": "Client Channel",
+                        "name": "x-client-channel",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "android 10",
+                        "description": "Client OS or Browser Agent",
+                        "name": "x-client-os",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Device ID",
+                        "name": "x-client-device",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "1.0.0",
+                        "description": "Client Platform Version",
+                        "name": "x-client-version",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Original Timestamp in UNIX format (EPOCH)",
+                        "name": "x-client-timestamp",
+                        "in": "header"
+                    },
+                    {
+                        "enum": [
+                            "PARTNER_RECEIVABLE",
+                            "PROVIDER_PREFUND",
+                            "PROVIDER_FEE",
+                            "CUSTOMER_CASHBACK_PAYABLE"
+                        ],
+                        "type": "string",
+                        "description": "Account Type",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "LSAJA",
+                        "description": "Account Code (partner code, H2H provider code or customer MSISDN)",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "2023-01-01",
+                        "description": "Start Date",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "2023-01-31",
+                        "description": "End Date",
+                        "name": "end_date",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.LedgerBalanceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/ledgers/accounts/{type}/{code}/statement": {
+            "get": {
+                "description": "API to list the entries of a ledger account within a period in the posting order along with their running balance",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ledger Management APIs"
+                ],
+                "summary": "API Ledger Statement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Back Office API Key",
+                        "name": "x-api-key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "EBIZKEZBEK",
+                            "B2BCLIENT"
+                        ],
+                        "type": "string",
+                        "description": "Client Channel",
+                        "name": "x-client-channel",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "android 10",
+                        "description": "Client OS or Browser Agent",
+                        "name": "x-client-os",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Device ID",
+                        "name": "x-client-device",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "1.0.0",
+                        "description": "Client Platform Version",
+                        "name": "x-client-version",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Original Timestamp in UNIX format (EPOCH)",
+                        "name": "x-client-timestamp",
+                        "in": "header"
+                    },
+                    {
+                        "enum": [
+                            "PARTNER_RECEIVABLE",
+                            "PROVIDER_PREFUND",
+                            "PROVIDER_FEE",
+                            "CUSTOMER_CASHBACK_PAYABLE"
+                        ],
+                        "type": "string",
+                        "description": "Account Type",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "LSAJA",
+                        "description": "Account Code (partner code, H2H provider code or customer MSISDN)",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "2023-01-31",
+                        "name": "end_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 5,
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "ASC",
+                            "DESC"
+                        ],
+                        "type": "string",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 0,
+                        "name": "start",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "2023-01-01",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "text_search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.LedgerStatementResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/ledgers/journals/{id}/reversal": {
+            "post": {
+                "description": "API to reverse a posted journal by posting its mirror entries, a journal is reversed only once",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ledger Management APIs"
+                ],
+                "summary": "API Reverse Journal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Back Office API Key",
+                        "name": "x-api-key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "EBIZKEZBEK",
+                            "B2BCLIENT"
+                        ],
+                        "type": "string",
+                        "description": "Client Channel",
+                        "name": "x-client-channel",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "android 10",
+                        "description": "Client OS or Browser Agent",
+                        "name": "x-client-os",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Device ID",
+                        "name": "x-client-device",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "1.0.0",
+                        "description": "Client Platform Version",
+                        "name": "x-client-version",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Original Timestamp in UNIX format (EPOCH)",
+                        "name": "x-client-timestamp",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Journal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reversal Payload",
+                        "name": "Payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.LedgerReversalRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TransactionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/notifications": {
+            "get": {
+                "description": "API to search notification delivery logs, filtered by status and destination or subject",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notification Management APIs"
+                ],
+                "summary": "API Notification Log Search",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Back Office API Key",
+                        "name": "x-api-key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 5,
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "ASC",
+                            "DESC"
+                        ],
+                        "type": "string",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 0,
+                        "name": "start",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "SENT",
+                            "FAILED",
+                            "DEAD",
+                            "RESENT",
+                            "SUPPRESSED"
+                        ],
+                        "type": "string",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "text_search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.NotificationSearchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/notifications/resend": {
+            "post": {
+                "description": "API to resend failed or dead notifications by their ids or by status",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notification Management APIs"
+                ],
+                "summary": "API Notification Bulk Resend",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Back Office API Key",
+                        "name": "x-api-key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Notification Resend Payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.NotificationResendRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.NotificationResendResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/notifications/{id}/resend": {
+            "post": {
+                "description": "API to resend a single failed or dead notification",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notification Management APIs"
+                ],
+                "summary": "API Notification Resend",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Back Office API Key",
+                        "name": "x-api-key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Notification Log ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.NotificationResendResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/partners": {
+            "post": {
+                "description": "API to register a new B2B Partner data as user and client",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Partner Management APIs"
+                ],
+                "summary": "API Add Partner",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Back Office API Key",
+                        "name": "x-api-key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "EBIZKEZBEK",
+                            "B2BCLIENT"
+                        ],
+                        "type": "string",
+                        "description": "Client Channel",
+                        "name": "x-client-channel",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "android 10",
+                        "description": "Client OS or Browser Agent",
+                        "name": "x-client-os",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Device ID",
+                        "name": "x-client-device",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "1.0.0",
+                        "description": "Client Platform Version",
+                        "name": "x-client-version",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Original Timestamp in UNIX format (EPOCH)",
+                        "name": "x-client-timestamp",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "default": "PT. Lajada Piranti Commerce",
+                        "description": "Partner Corporate",
+                        "name": "partner",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "LAJADA",
+                        "description": "Partner Code",
+                        "name": "code",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "kezbek.support@lajada.net",
+                        "description": "Partner Email",
+                        "name": "email",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "628123456789",
+                        "description": "MSISDN",
+                        "name": "msisdn",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "John Doe",
+                        "description": "Partner Officer",
+                        "name": "officer",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Bintaro Exchange Mall Blok A1",
+                        "description": "Office Address",
+                        "name": "address",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "en"
+                        ],
+                        "type": "string",
+                        "description": "Notification Locale",
+                        "name": "locale",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Logo",
+                        "name": "logo",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TransactionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/partners/{code}/sessions": {
+            "delete": {
+                "description": "API to force revoke all active client and officer sessions of a B2B Partner",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Partner Management APIs"
+                ],
+                "summary": "API Revoke Partner Sessions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Back Office API Key",
+                        "name": "x-api-key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "EBIZKEZBEK",
+                            "B2BCLIENT"
+                        ],
+                        "type": "string",
+                        "description": "Client Channel",
+                        "name": "x-client-channel",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "android 10",
+                        "description": "Client OS or Browser Agent",
+                        "name": "x-client-os",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Device ID",
+                        "name": "x-client-device",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "1.0.0",
+                        "description": "Client Platform Version",
+                        "name": "x-client-version",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Original Timestamp in UNIX format (EPOCH)",
+                        "name": "x-client-timestamp",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "default": "LAJADA",
+                        "description": "Partner Code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TransactionResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/settlements": {
+            "get": {
+                "description": "API to search the reconciliation runs with their count of unresolved exceptions",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settlement Management APIs"
+                ],
+                "summary": "API Search Settlement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Back Office API Key",
+                        "name": "x-api-key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "EBIZKEZBEK",
+                            "B2BCLIENT"
+                        ],
+                        "type": "string",
+                        "description": "Client Channel",
+                        "name": "x-client-channel",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "android 10",
+                        "description": "Client OS or Browser Agent",
+                        "name": "x-client-os",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Device ID",
+                        "name": "x-client-device",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "1.0.0",
+                        "description": "Client Platform Version",
+                        "name": "x-client-version",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Original Timestamp in UNIX format (EPOCH)",
+                        "name": "x-client-timestamp",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 5,
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "ASC",
+                            "DESC"
+                        ],
+                        "type": "string",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 0,
+                        "name": "start",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "text_search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SettlementSearchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "API to reconcile a provider settlement file in CSV or JSON against the disbursed cashbacks, the lines are matched by the Kezbek or the provider reference",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "tags": [
+                    "Settlement Management APIs"
+                ],
+                "summary": "API Reconcile Settlement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Back Office API Key",
+                        "name": "x-api-key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "EBIZKEZBEK",
+                            "B2BCLIENT"
+                        ],
+                        "type": "string",
+                        "description": "Client Channel",
+                        "name": "x-client-channel",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "android 10",
+                        "description": "Client OS or Browser Agent",
+                        "name": "x-client-os",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Device ID",
+                        "name": "x-client-device",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "1.0.0",
+                        "description": "Client Platform Version",
+                        "name": "x-client-version",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Original Timestamp in UNIX format (EPOCH)",
+                        "name": "x-client-timestamp",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "default": "LSAJAH2H",
+                        "description": "H2H Provider Code",
+                        "name": "h2h_code",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "2023-01-01",
+                        "description": "Settlement Date",
+                        "name": "settlement_date",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Settlement File",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SettlementReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/settlements/exceptions/{id}/resolution": {
+            "put": {
+                "description": "API to resolve a settlement exception by hand with the notes of how it has been settled",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settlement Management APIs"
+                ],
+                "summary": "API Resolve Settlement Exception",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Back Office API Key",
+                        "name": "x-api-key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "EBIZKEZBEK",
+                            "B2BCLIENT"
+                        ],
+                        "type": "string",
+                        "description": "Client Channel",
+                        "name": "x-client-channel",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "android 10",
+                        "description": "Client OS or Browser Agent",
+                        "name": "x-client-os",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Device ID",
+                        "name": "x-client-device",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "1.0.0",
+                        "description": "Client Platform Version",
+                        "name": "x-client-version",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Original Timestamp in UNIX format (EPOCH)",
+                        "name": "x-client-timestamp",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Settlement Exception ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Resolution Payload",
+                        "name": "Payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.SettlementResolveRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TransactionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/settlements/{id}": {
+            "get": {
+                "description": "API to get the report of a reconciliation run along with its exceptions",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settlement Management APIs"
+                ],
+                "summary": "API Settlement Report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Back Office API Key",
+                        "name": "x-api-key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "EBIZKEZBEK",
+                            "B2BCLIENT"
+                        ],
+                        "type": "string",
+                        "description": "Client Channel",
+                        "name": "x-client-channel",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "android 10",
+                        "description": "Client OS or Browser Agent",
+                        "name": "x-client-os",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Device ID",
+                        "name": "x-client-device",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "1.0.0",
+                        "description": "Client Platform Version",
+                        "name": "x-client-version",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client Original Timestamp in UNIX format (EPOCH)",
+                        "name": "x-client-timestamp",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Settlement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SettlementReportResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/templates/preview": {
+            "post": {
+                "description": "API to render a notification template with sample data, a draft content could be given to validate it before saved",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Template Management APIs"
+                ],
+                "summary": "API Template Preview",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Back Office API Key",
+                        "name": "x-api-key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Template Preview Payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.TemplatePreviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TemplatePreviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/unsubscribe": {
+            "get": {
+                "description": "API to confirm the notification category of the signed token of the unsubscribe link before the customer opts out, the customer is not opted out yet",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notification Preference APIs"
+                ],
+                "summary": "API Notification Unsubscribe Confirmation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unsubscribe Token",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.UnsubscribeConfirmationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "API to opt-out a customer from a notification category by the signed token of the unsubscribe link, it is called once the customer confirmed or by one-click unsubscribe",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notification Preference APIs"
+                ],
+                "summary": "API Notification Unsubscribe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unsubscribe Token",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.UnsubscribeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Meta"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "model.AddOfficerRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "fullname",
+                "role"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "jane.doe@lajada.id"
+                },
+                "fullname": {
+                    "type": "string",
+                    "example": "Jane Doe"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "viewer",
+                        "finance",
+                        "admin"
+                    ],
+                    "example": "finance"
+                }
+            }
+        },
+        "model.CashbackVolumeProjection": {
+            "type": "object",
+            "properties": {
+                "cashback": {
+                    "type": "number",
+                    "example": 300000
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2023-01-01"
+                },
+                "provider": {
+                    "type": "string",
+                    "example": "XENIT"
+                },
+                "reward": {
+                    "type": "number",
+                    "example": 26000
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "transaction": {
+                    "type": "number",
+                    "example": 30000000
+                },
+                "wallet_code": {
+                    "type": "string",
+                    "example": "LSAJA"
+                }
+            }
+        },
+        "model.CashbackVolumeResponse": {
+            "type": "object",
+            "properties": {
+                "end_date": {
+                    "type": "string",
+                    "example": "2023-01-31"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2023-01-01"
+                },
+                "volumes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CashbackVolumeProjection"
+                    }
+                }
+            }
+        },
+        "model.ClientAuthenticationRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "LAJADA"
+                }
+            }
+        },
+        "model.ClientAuthenticationResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string",
+                    "example": "**secret**"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "company": {
+                    "type": "string"
+                },
+                "expired": {
+                    "type": "integer",
+                    "example": 11234823643
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "locale": {
+                    "type": "string"
+                },
+                "refresh_token": {
+                    "type": "string",
+                    "example": "**secret**"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "**secret**"
+                }
+            }
+        },
+        "model.CustomerSummaryResponse": {
+            "type": "object",
+            "properties": {
+                "average_basket": {
+                    "type": "number",
+                    "example": 250000
+                },
+                "average_qty": {
+                    "type": "number",
+                    "example": 2.4
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "2023-01-31"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2023-01-01"
+                },
+                "transaction": {
+                    "type": "number",
+                    "example": 120000000
+                },
+                "transactions": {
+                    "type": "integer",
+                    "example": 480
+                },
+                "unique_customers": {
+                    "type": "integer",
+                    "example": 215
+                }
+            }
+        },
+        "model.DashboardResponse": {
+            "type": "object",
+            "properties": {
+                "cashback": {
+                    "$ref": "#/definitions/model.KpiMetric"
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "2023-01-31"
+                },
+                "prev_end_date": {
+                    "type": "string",
+                    "example": "2022-12-31"
+                },
+                "prev_start_date": {
+                    "type": "string",
+                    "example": "2022-12-01"
+                },
+                "returning_customers": {
+                    "$ref": "#/definitions/model.KpiMetric"
+                },
+                "reward": {
+                    "$ref": "#/definitions/model.KpiMetric"
+                },
+                "spend": {
+                    "$ref": "#/definitions/model.KpiMetric"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2023-01-01"
+                },
+                "tiers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.DashboardTier"
+                    }
+                },
+                "top_wallets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.DashboardWallet"
+                    }
+                },
+                "transactions": {
+                    "$ref": "#/definitions/model.KpiMetric"
+                },
+                "unique_customers": {
+                    "$ref": "#/definitions/model.KpiMetric"
+                }
+            }
+        },
+        "model.DashboardTier": {
+            "type": "object",
+            "properties": {
+                "tier": {
+                    "type": "string",
+                    "example": "GOLD"
+                },
+                "transactions": {
+                    "$ref": "#/definitions/model.KpiMetric"
+                }
+            }
+        },
+        "model.DashboardWallet": {
+            "type": "object",
+            "properties": {
+                "spend": {
+                    "$ref": "#/definitions/model.KpiMetric"
+                },
+                "transactions": {
+                    "$ref": "#/definitions/model.KpiMetric"
+                },
+                "wallet_code": {
+                    "type": "string",
+                    "example": "LSAJA"
+                }
+            }
+        },
+        "model.DepositResponse": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "number",
+                    "example": 4984000
+                },
+                "balance": {
+                    "type": "number",
+                    "example": 5000000
+                },
+                "code": {
+                    "type": "string",
+                    "example": "LAJADA"
+                },
+                "held": {
+                    "type": "number",
+                    "example": 16000
+                },
+                "low_balance": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "threshold": {
+                    "type": "number",
+                    "example": 1000000
+                }
+            }
+        },
+        "model.DepositTopUpRequest": {
+            "type": "object",
+            "required": [
+                "reference"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 5000000
+                },
+                "reference": {
+                    "type": "string",
+                    "example": "TRF/2023/01/0001"
+                },
+                "threshold": {
+                    "type": "number",
+                    "example": 1000000
+                }
+            }
+        },
+        "model.InvoicePaymentRequest": {
+            "type": "object",
+            "required": [
+                "reference"
+            ],
+            "properties": {
+                "reference": {
+                    "type": "string",
+                    "example": "TRF/2023/02/0001"
+                }
+            }
+        },
+        "model.InvoiceProjection": {
+            "type": "object",
+            "properties": {
+                "cashback": {
+                    "type": "number",
+                    "example": 1200000
+                },
+                "due_date": {
+                    "type": "integer",
+                    "example": 1676419200
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "invoice_no": {
+                    "type": "string",
+                    "example": "INV/LAJADA/202301"
+                },
+                "issued_date": {
+                    "type": "integer",
+                    "example": 1675209600
+                },
+                "paid_date": {
+                    "type": "integer",
+                    "example": 1676332800
+                },
+                "payment_reference": {
+                    "type": "string",
+                    "example": "TRF/2023/02/0001"
+                },
+                "period": {
+                    "type": "string",
+                    "example": "2023-01"
+                },
+                "provider_fee": {
+                    "type": "number",
+                    "example": 480000
+                },
+                "reward": {
+                    "type": "number",
+                    "example": 260000
+                },
+                "service_fee": {
+                    "type": "number",
+                    "example": 73000
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "UNPAID",
+                        "PAID",
+                        "OVERDUE"
+                    ],
+                    "example": "UNPAID"
+                },
+                "tax": {
+                    "type": "number",
+                    "example": 60830
+                },
+                "total": {
+                    "type": "number",
+                    "example": 2073830
+                },
+                "transactions": {
+                    "type": "integer",
+                    "example": 480
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://cdn.kezbek.id/invoice/1/INV-LAJADA-202301-8f2d0a6c1b9e4d57.pdf"
+                }
+            }
+        },
+        "model.InvoiceSearchResponse": {
+            "type": "object",
+            "properties": {
+                "invoices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.InvoiceProjection"
+                    }
+                },
+                "number": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "sort": {
+                    "type": "string",
+                    "example": "ASC"
+                },
+                "sort_by": {
+                    "type": "string",
+                    "example": "id"
+                },
+                "total_elements": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "total_pages": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "model.KpiMetric": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "number",
+                    "example": 120
+                },
+                "growth": {
+                    "type": "number",
+                    "example": 20
+                },
+                "previous": {
+                    "type": "number",
+                    "example": 100
+                }
+            }
+        },
+        "model.LedgerBalanceResponse": {
+            "type": "object",
+            "properties": {
+                "account_code": {
+                    "type": "string",
+                    "example": "LSAJA"
+                },
+                "account_type": {
+                    "type": "string",
+                    "example": "PARTNER_RECEIVABLE"
+                },
+                "closing": {
+                    "type": "number",
+                    "example": 16000
+                },
+                "credit": {
+                    "type": "number",
+                    "example": 0
+                },
+                "debit": {
+                    "type": "number",
+                    "example": 16000
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "2023-01-31"
+                },
+                "opening": {
+                    "type": "number",
+                    "example": 0
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2023-01-01"
+                }
+            }
+        },
+        "model.LedgerEntryProjection": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "number",
+                    "example": 16000
+                },
+                "credit": {
+                    "type": "number",
+                    "example": 0
+                },
+                "debit": {
+                    "type": "number",
+                    "example": 16000
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Cashback disbursed by LSAJAH2H"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "journal_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "CASHBACK",
+                        "REVERSAL",
+                        "SETTLEMENT"
+                    ],
+                    "example": "CASHBACK"
+                },
+                "posted_date": {
+                    "type": "integer",
+                    "example": 1672617600
+                },
+                "reference": {
+                    "type": "string",
+                    "example": "C00223010112000062811"
+                }
+            }
+        },
+        "model.LedgerReversalRequest": {
+            "type": "object",
+            "required": [
+                "description"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Cashback was disbursed twice"
+                }
+            }
+        },
+        "model.LedgerStatementResponse": {
+            "type": "object",
+            "properties": {
+                "account_code": {
+                    "type": "string",
+                    "example": "LSAJA"
+                },
+                "account_type": {
+                    "type": "string",
+                    "example": "PARTNER_RECEIVABLE"
+                },
+                "closing": {
+                    "type": "number",
+                    "example": 16000
+                },
+                "credit": {
+                    "type": "number",
+                    "example": 0
+                },
+                "debit": {
+                    "type": "number",
+                    "example": 16000
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "2023-01-31"
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.LedgerEntryProjection"
+                    }
+                },
+                "number": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "opening": {
+                    "type": "number",
+                    "example": 0
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "sort": {
+                    "type": "string",
+                    "example": "ASC"
+                },
+                "sort_by": {
+                    "type": "string",
+                    "example": "id"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2023-01-01"
+                },
+                "total_elements": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "total_pages": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "model.Meta": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.NotificationLogProjection": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "channel": {
+                    "type": "string",
+                    "example": "EMAIL"
+                },
+                "destination": {
+                    "type": "string",
+                    "example": "john.doe@email.net"
+                },
+                "error": {
+                    "type": "string",
+                    "example": "MessageRejected: Email address is not verified"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "last_attempt": {
+                    "type": "integer",
+                    "example": 1672531200
+                },
+                "provider_message_id": {
+                    "type": "string",
+                    "example": "0100018a-2b3c"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "FAILED"
+                },
+                "subject": {
+                    "type": "string",
+                    "example": "Your cashback invoice"
+                }
+            }
+        },
+        "model.NotificationResendRequest": {
+            "type": "object",
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "FAILED",
+                        "DEAD"
+                    ],
+                    "example": "DEAD"
+                }
+            }
+        },
+        "model.NotificationResendResponse": {
+            "type": "object",
+            "properties": {
+                "total": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "transaction_id": {
+                    "type": "string",
+                    "example": "TRX0012345678"
+                },
+                "transaction_timestamp": {
+                    "type": "integer",
+                    "example": 11285736234
+                }
+            }
+        },
+        "model.NotificationSearchResponse": {
+            "type": "object",
+            "properties": {
+                "notifications": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.NotificationLogProjection"
+                    }
+                },
+                "number": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "sort": {
+                    "type": "string",
+                    "example": "ASC"
+                },
+                "sort_by": {
+                    "type": "string",
+                    "example": "id"
+                },
+                "total_elements": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "total_pages": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "model.OfficerAuthenticationRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "john.doe@lajada.id"
+                }
+            }
+        },
+        "model.OfficerAuthenticationResponse": {
+            "type": "object",
+            "properties": {
+                "remaining_seconds": {
+                    "type": "number"
+                },
+                "transaction_id": {
+                    "type": "string",
+                    "example": "TRX0012345678"
+                },
+                "transaction_timestamp": {
+                    "type": "integer",
+                    "example": 11285736234
+                }
+            }
+        },
+        "model.OfficerProjection": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "jane.doe@lajada.id"
+                },
+                "fullname": {
+                    "type": "string",
+                    "example": "Jane Doe"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "role": {
+                    "type": "string",
+                    "example": "finance"
+                }
+            }
+        },
+        "model.OfficerValidationRequest": {
+            "type": "object",
+            "required": [
+                "otp"
+            ],
+            "properties": {
+                "otp": {
+                    "type": "string",
+                    "example": "123456"
+                }
+            }
+        },
+        "model.OfficerValidationResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
                     "type": "string",
                     "example": "**secret**"
                 },
                 "code": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "CORPCODE_A"
                 },
                 "company": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "Kezbek Indonesia Ltd"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "john.doe@email.net"
+                },
+                "expired": {
+                    "type": "integer",
+                    "example": 11234823643
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "locale": {
+                    "type": "string",
+                    "example": "id"
+                },
+                "msisdn": {
+                    "type": "string",
+                    "example": "628118770510"
+                },
+                "refresh_token": {
+                    "type": "string",
+                    "example": "**secret**"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "admin"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "**secret**"
+                },
+                "url_logo": {
+                    "type": "string",
+                    "example": "https://cdn-something.com/bucket/file.png"
+                }
+            }
+        },
+        "model.PartnerTransactionProjection": {
+            "type": "object",
+            "properties": {
+                "cashback": {
+                    "type": "number",
+                    "example": 2500
+                },
+                "email": {
+                    "type": "string",
+                    "example": "john.doe@email.net"
+                },
+                "h2h_code": {
+                    "type": "string",
+                    "example": "XENIT"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "kezbek_ref_code": {
+                    "type": "string",
+                    "example": "TRX0012345678"
+                },
+                "msisdn": {
+                    "type": "string",
+                    "example": "628118770510"
+                },
+                "qty": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "reward": {
+                    "type": "number",
+                    "example": 13000
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "DISBURSED",
+                        "FAILED"
+                    ],
+                    "example": "DISBURSED"
+                },
+                "transaction": {
+                    "type": "number",
+                    "example": 250000
+                },
+                "transaction_date": {
+                    "type": "integer",
+                    "example": 1672531200
+                },
+                "wallet_code": {
+                    "type": "string",
+                    "example": "LSAJA"
+                }
+            }
+        },
+        "model.PartnerTransactionSearchResponse": {
+            "type": "object",
+            "properties": {
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJrIjoiMTAiLCJpIjoxMH0"
+                },
+                "number": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "sort": {
+                    "type": "string",
+                    "example": "ASC"
+                },
+                "sort_by": {
+                    "type": "string",
+                    "example": "id"
+                },
+                "total_elements": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "total_pages": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "transactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PartnerTransactionProjection"
+                    }
+                }
+            }
+        },
+        "model.ProviderSavingsProjection": {
+            "type": "object",
+            "properties": {
+                "default_fee": {
+                    "type": "number",
+                    "example": 90000
+                },
+                "default_savings": {
+                    "type": "number",
+                    "example": 30000
+                },
+                "failovers": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "fee_paid": {
+                    "type": "number",
+                    "example": 60000
+                },
+                "highest_fee": {
+                    "type": "number",
+                    "example": 108000
+                },
+                "provider": {
+                    "type": "string",
+                    "example": "XENIT"
+                },
+                "savings": {
+                    "type": "number",
+                    "example": 48000
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "wallet_code": {
+                    "type": "string",
+                    "example": "LSAJA"
+                }
+            }
+        },
+        "model.ProviderSavingsResponse": {
+            "type": "object",
+            "properties": {
+                "default_provider": {
+                    "type": "string",
+                    "example": "XENIT"
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "2023-01-31"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2023-01-01"
+                },
+                "wallets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ProviderSavingsWallet"
+                    }
+                }
+            }
+        },
+        "model.ProviderSavingsWallet": {
+            "type": "object",
+            "properties": {
+                "default_fee": {
+                    "type": "number",
+                    "example": 90000
+                },
+                "default_savings": {
+                    "type": "number",
+                    "example": 30000
+                },
+                "failovers": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "fee_paid": {
+                    "type": "number",
+                    "example": 60000
+                },
+                "highest_fee": {
+                    "type": "number",
+                    "example": 108000
+                },
+                "providers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ProviderSavingsProjection"
+                    }
+                },
+                "savings": {
+                    "type": "number",
+                    "example": 48000
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "wallet_code": {
+                    "type": "string",
+                    "example": "LSAJA"
+                }
+            }
+        },
+        "model.SessionRefreshRequest": {
+            "type": "object",
+            "required": [
+                "username"
+            ],
+            "properties": {
+                "username": {
+                    "type": "string",
+                    "example": "LAJADA"
+                }
+            }
+        },
+        "model.SessionResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string",
+                    "example": "**secret**"
+                },
+                "expired": {
+                    "type": "integer",
+                    "example": 11234823643
+                },
+                "refresh_token": {
+                    "type": "string",
+                    "example": "**secret**"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "**secret**"
+                }
+            }
+        },
+        "model.SettlementExceptionProjection": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 15000
+                },
+                "expected": {
+                    "type": "number",
+                    "example": 16000
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "kezbek_ref_code": {
+                    "type": "string",
+                    "example": "C00223010112000062811"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Reward was settled on the next statement"
+                },
+                "provider_ref_code": {
+                    "type": "string",
+                    "example": "trx-001"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "OPEN",
+                        "RESOLVED"
+                    ],
+                    "example": "OPEN"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "MISSING_CASHBACK",
+                        "MISSING_SETTLEMENT",
+                        "DUPLICATE",
+                        "AMOUNT_MISMATCH"
+                    ],
+                    "example": "AMOUNT_MISMATCH"
+                }
+            }
+        },
+        "model.SettlementProjection": {
+            "type": "object",
+            "properties": {
+                "filename": {
+                    "type": "string",
+                    "example": "LSAJAH2H-20230101.csv"
+                },
+                "flagged": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "h2h_code": {
+                    "type": "string",
+                    "example": "LSAJAH2H"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "expired": {
+                "imported_date": {
                     "type": "integer",
-                    "example": 11234823643
+                    "example": 1672617600
                 },
-                "id": {
-                    "type": "integer"
+                "lines": {
+                    "type": "integer",
+                    "example": 120
                 },
-                "refresh_token": {
-                    "type": "string",
-                    "example": "**secret**"
+                "matched": {
+                    "type": "integer",
+                    "example": 118
                 },
-                "token": {
+                "settlement_date": {
                     "type": "string",
-                    "example": "**secret**"
-                }
-            }
-        },
-        "model.Meta": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "string"
+                    "example": "2023-01-01"
                 },
-                "message": {
-                    "type": "string"
+                "unresolved": {
+                    "type": "integer",
+                    "example": 1
                 }
             }
         },
-        "model.OfficerAuthenticationRequest": {
+        "model.SettlementReportResponse": {
             "type": "object",
             "properties": {
-                "email": {
+                "exceptions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SettlementExceptionProjection"
+                    }
+                },
+                "filename": {
                     "type": "string",
-                    "example": "john.doe@lajada.id"
-                }
-            }
-        },
-        "model.OfficerAuthenticationResponse": {
-            "type": "object",
-            "properties": {
-                "remaining_seconds": {
-                    "type": "number"
+                    "example": "LSAJAH2H-20230101.csv"
                 },
-                "transaction_id": {
+                "flagged": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "h2h_code": {
                     "type": "string",
-                    "example": "TRX0012345678"
+                    "example": "LSAJAH2H"
                 },
-                "transaction_timestamp": {
+                "id": {
                     "type": "integer",
-                    "example": 11285736234
+                    "example": 1
+                },
+                "imported_date": {
+                    "type": "integer",
+                    "example": 1672617600
+                },
+                "lines": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "matched": {
+                    "type": "integer",
+                    "example": 118
+                },
+                "settlement_date": {
+                    "type": "string",
+                    "example": "2023-01-01"
+                },
+                "unresolved": {
+                    "type": "integer",
+                    "example": 1
                 }
             }
         },
-        "model.OfficerValidationRequest": {
+        "model.SettlementResolveRequest": {
             "type": "object",
             "required": [
-                "otp"
+                "notes"
             ],
             "properties": {
-                "otp": {
+                "notes": {
                     "type": "string",
-                    "example": "123456"
+                    "example": "Reward was settled on the next statement"
                 }
             }
         },
-        "model.OfficerValidationResponse": {
+        "model.SettlementSearchResponse": {
             "type": "object",
             "properties": {
-                "access_token": {
-                    "type": "string",
-                    "example": "**secret**"
-                },
-                "code": {
-                    "type": "string",
-                    "example": "CORPCODE_A"
-                },
-                "company": {
-                    "type": "string",
-                    "example": "Kezbek Indonesia Ltd"
-                },
-                "email": {
-                    "type": "string",
-                    "example": "john.doe@email.net"
-                },
-                "expired": {
+                "number": {
                     "type": "integer",
-                    "example": 11234823643
+                    "example": 1
                 },
-                "id": {
+                "settlements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SettlementProjection"
+                    }
+                },
+                "size": {
                     "type": "integer",
-                    "example": 1
+                    "example": 10
                 },
-                "msisdn": {
+                "sort": {
                     "type": "string",
-                    "example": "628118770510"
+                    "example": "ASC"
                 },
-                "refresh_token": {
+                "sort_by": {
                     "type": "string",
-                    "example": "**secret**"
+                    "example": "id"
                 },
-                "token": {
-                    "type": "string",
-                    "example": "**secret**"
+                "total_elements": {
+                    "type": "integer",
+                    "example": 100
                 },
-                "url_logo": {
-                    "type": "string",
-                    "example": "https://cdn-something.com/bucket/file.png"
+                "total_pages": {
+                    "type": "integer",
+                    "example": 10
                 }
             }
         },
-        "model.PartnerTransactionProjection": {
+        "model.SuppressionProjection": {
             "type": "object",
             "properties": {
-                "cashback": {
-                    "type": "number",
-                    "example": 2500
+                "detail": {
+                    "type": "string",
+                    "example": "smtp; 550 5.1.1 user unknown"
                 },
                 "email": {
                     "type": "string",
                     "example": "john.doe@email.net"
                 },
-                "id": {
-                    "type": "integer",
-                    "example": 1
-                },
-                "msisdn": {
+                "reason": {
                     "type": "string",
-                    "example": "628118770510"
+                    "example": "BOUNCE"
                 },
-                "qty": {
+                "suppressed_date": {
                     "type": "integer",
-                    "example": 2
-                },
-                "reward": {
-                    "type": "number",
-                    "example": 13000
-                },
-                "transaction": {
-                    "type": "number",
-                    "example": 250000
-                },
-                "wallet_code": {
-                    "type": "string",
-                    "example": "LSAJA"
+                    "example": 1672531200
                 }
             }
         },
-        "model.PartnerTransactionSearchResponse": {
+        "model.SuppressionSearchResponse": {
             "type": "object",
             "properties": {
                 "number": {
@@ -1146,6 +5909,12 @@ const docTemplate = `{
                     "type": "string",
                     "example": "id"
                 },
+                "suppressions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SuppressionProjection"
+                    }
+                },
                 "total_elements": {
                     "type": "integer",
                     "example": 100
@@ -1153,15 +5922,166 @@ const docTemplate = `{
                 "total_pages": {
                     "type": "integer",
                     "example": 10
+                }
+            }
+        },
+        "model.TemplatePreviewRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string"
                 },
-                "transactions": {
+                "data": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "locale": {
+                    "type": "string",
+                    "example": "en"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "subject": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.TemplatePreviewResponse": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "locale": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "subject": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.TierDistributionProjection": {
+            "type": "object",
+            "properties": {
+                "customers": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "tier": {
+                    "type": "string",
+                    "example": "GOLD"
+                }
+            }
+        },
+        "model.TierDistributionResponse": {
+            "type": "object",
+            "properties": {
+                "tiers": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.PartnerTransactionProjection"
+                        "$ref": "#/definitions/model.TierDistributionProjection"
                     }
                 }
             }
         },
+        "model.TransactionExportRequest": {
+            "type": "object",
+            "required": [
+                "format",
+                "limit",
+                "start"
+            ],
+            "properties": {
+                "cursor": {
+                    "type": "string"
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "2023-01-31"
+                },
+                "format": {
+                    "type": "string",
+                    "enum": [
+                        "CSV",
+                        "XLSX"
+                    ],
+                    "example": "XLSX"
+                },
+                "h2h_code": {
+                    "type": "string",
+                    "example": "XENIT"
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "max_cashback": {
+                    "type": "string",
+                    "example": "25000"
+                },
+                "max_transaction": {
+                    "type": "string",
+                    "example": "500000"
+                },
+                "min_cashback": {
+                    "type": "string",
+                    "example": "500"
+                },
+                "min_transaction": {
+                    "type": "string",
+                    "example": "10000"
+                },
+                "skip_count": {
+                    "type": "boolean"
+                },
+                "sort": {
+                    "type": "string",
+                    "enum": [
+                        "ASC",
+                        "DESC"
+                    ]
+                },
+                "sort_by": {
+                    "type": "string"
+                },
+                "start": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2023-01-01"
+                },
+                "status": {
+                    "type": "string",
+                    "default": "DISBURSED",
+                    "enum": [
+                        "DISBURSED",
+                        "FAILED"
+                    ]
+                },
+                "text_search": {
+                    "type": "string"
+                },
+                "wallet_code": {
+                    "type": "string",
+                    "example": "LSAJA"
+                }
+            }
+        },
         "model.TransactionRequest": {
             "type": "object",
             "required": [
@@ -1178,6 +6098,14 @@ const docTemplate = `{
                     "type": "string",
                     "example": "john.doe@gmailxyz.com"
                 },
+                "locale": {
+                    "type": "string",
+                    "enum": [
+                        "id",
+                        "en"
+                    ],
+                    "example": "en"
+                },
                 "merchant_code": {
                     "type": "string",
                     "example": "LSAJA,GPAID,JOSVO"
@@ -1225,6 +6153,36 @@ const docTemplate = `{
                     "example": "GOLD"
                 }
             }
+        },
+        "model.UnsubscribeConfirmationResponse": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "TRANSACTIONAL"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "NjI4MTE4NzcwNTEwfHxUUkFOU0FDVElPTkFM.a1b2c3"
+                }
+            }
+        },
+        "model.UnsubscribeResponse": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "TRANSACTIONAL"
+                },
+                "transaction_id": {
+                    "type": "string",
+                    "example": "TRX0012345678"
+                },
+                "transaction_timestamp": {
+                    "type": "integer",
+                    "example": 11285736234
+                }
+            }
         }
     }
 }`
@@ -0,0 +1,246 @@
+package handler
+
+import (
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/handler/middleware"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/internal/usecase/analytics"
+	"github.com/gofiber/fiber/v2"
+)
+
+type Analytics struct {
+	analytics.ReportProvider
+	AdminFilter fiber.Handler
+}
+
+type PartnerAnalytics struct {
+	analytics.ReportProvider
+	PartnerFilter fiber.Handler
+}
+
+func newAnalytics(a Analytics) *Analytics {
+	return &a
+}
+
+func newPartnerAnalytics(pa PartnerAnalytics) *PartnerAnalytics {
+	return &pa
+}
+
+func AnalyticsHandler(router fiber.Router, a Analytics) {
+	handler := newAnalytics(a)
+	router.Use(a.AdminFilter)
+	router.Get("/cashbacks", handler.cashbacks)
+	router.Get("/customers", handler.customers)
+	router.Get("/tiers", handler.tiers)
+	router.Get("/providers", handler.providers)
+}
+
+func PartnerAnalyticsHandler(router fiber.Router, pa PartnerAnalytics) {
+	handler := newPartnerAnalytics(pa)
+	router.Use(pa.PartnerFilter, middleware.PartnerRoleFilter(apps.RoleOfficerViewer,
+		apps.RoleOfficerFinance, apps.RoleOfficerAdmin))
+	router.Get("/cashbacks", handler.cashbacks)
+	router.Get("/customers", handler.customers)
+	router.Get("/tiers", handler.tiers)
+}
+
+func report(ctx *fiber.Ctx, partner bool, fn func(inp *model.AnalyticsRequest) (interface{}, *model.BusinessError)) error {
+	inp := model.AnalyticsRequest{}
+	if err := ctx.QueryParser(&inp); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	bad := apps.ValidateStruct(checker.Struct(inp))
+	if bad != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(bad)
+	}
+	inp.SessionRequest = middleware.ClientSession(ctx)
+	if partner {
+		inp.PartnerId = inp.SessionRequest.Id
+	}
+	v, ex := fn(&inp)
+	if ex != nil && ex.ErrorCode == apps.ErrCodeBussPeriodInvalid {
+		return ctx.Status(fiber.StatusBadRequest).JSON(apps.BusinessErrorResponse(ex))
+	}
+	if ex != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(apps.BusinessErrorResponse(ex))
+	}
+	return ctx.JSON(apps.DefaultSuccessResponse(apps.SuccessMsgDataFound, v))
+}
+
+// @Tags Analytics APIs
+// API Cashback Volume Report
+// @Summary API Cashback Volume Report
+// @Description API to report the cashback volume by day, wallet and provider of all or a partner
+// @Schemes
+// @Accept json
+// @Param x-api-key header string true "Back Office API Key"
+// @Param x-client-channel header string true "Client Channel" Enums(EBIZKEZBEK, B2BCLIENT)
+// @Param x-client-os  header string true "Client OS or Browser Agent" default(android 10)
+// @Param x-client-device  header string true "Client Device ID"
+// @Param x-client-version  header string true "Client Platform Version" default(1.0.0)
+// @Param x-client-timestamp  header string false "Client Original Timestamp in UNIX format (EPOCH)"
+// @Param Payload query model.AnalyticsRequest false "Report Period"
+// @Success 200 {object} model.CashbackVolumeResponse
+// @Failure 400 {object} model.Meta
+// @Failure 401 {object} model.Meta
+// @Failure 500 {object} model.Meta
+// @Failure 503 {object} model.Meta
+// @Router /v1/analytics/cashbacks [get]
+func (a *Analytics) cashbacks(ctx *fiber.Ctx) error {
+	return report(ctx, false, func(inp *model.AnalyticsRequest) (interface{}, *model.BusinessError) {
+		return a.CashbackVolume(inp)
+	})
+}
+
+// @Tags Analytics APIs
+// API Customer Summary Report
+// @Summary API Customer Summary Report
+// @Description API to report the unique customers and the average basket of all or a partner
+// @Schemes
+// @Accept json
+// @Param x-api-key header string true "Back Office API Key"
+// @Param x-client-channel header string true "Client Channel" Enums(EBIZKEZBEK, B2BCLIENT)
+// @Param x-client-os  header string true "Client OS or Browser Agent" default(android 10)
+// @Param x-client-device  header string true "Client Device ID"
+// @Param x-client-version  header string true "Client Platform Version" default(1.0.0)
+// @Param x-client-timestamp  header string false "Client Original Timestamp in UNIX format (EPOCH)"
+// @Param Payload query model.AnalyticsRequest false "Report Period"
+// @Success 200 {object} model.CustomerSummaryResponse
+// @Failure 400 {object} model.Meta
+// @Failure 401 {object} model.Meta
+// @Failure 500 {object} model.Meta
+// @Failure 503 {object} model.Meta
+// @Router /v1/analytics/customers [get]
+func (a *Analytics) customers(ctx *fiber.Ctx) error {
+	return report(ctx, false, func(inp *model.AnalyticsRequest) (interface{}, *model.BusinessError) {
+		return a.CustomerSummary(inp)
+	})
+}
+
+// @Tags Analytics APIs
+// API Tier Distribution Report
+// @Summary API Tier Distribution Report
+// @Description API to report the customers on each current tier of all or a partner
+// @Schemes
+// @Accept json
+// @Param x-api-key header string true "Back Office API Key"
+// @Param x-client-channel header string true "Client Channel" Enums(EBIZKEZBEK, B2BCLIENT)
+// @Param x-client-os  header string true "Client OS or Browser Agent" default(android 10)
+// @Param x-client-device  header string true "Client Device ID"
+// @Param x-client-version  header string true "Client Platform Version" default(1.0.0)
+// @Param x-client-timestamp  header string false "Client Original Timestamp in UNIX format (EPOCH)"
+// @Param partner_id query int false "Partner ID"
+// @Success 200 {object} model.TierDistributionResponse
+// @Failure 400 {object} model.Meta
+// @Failure 401 {object} model.Meta
+// @Failure 500 {object} model.Meta
+// @Failure 503 {object} model.Meta
+// @Router /v1/analytics/tiers [get]
+func (a *Analytics) tiers(ctx *fiber.Ctx) error {
+	return report(ctx, false, func(inp *model.AnalyticsRequest) (interface{}, *model.BusinessError) {
+		return a.TierDistribution(inp)
+	})
+}
+
+// @Tags Analytics APIs
+// API Provider Savings Report
+// @Summary API Provider Savings Report
+// @Description API to report the fee paid by wallet and provider against the most expensive and the default provider
+// @Schemes
+// @Accept json
+// @Param x-api-key header string true "Back Office API Key"
+// @Param x-client-channel header string true "Client Channel" Enums(EBIZKEZBEK, B2BCLIENT)
+// @Param x-client-os  header string true "Client OS or Browser Agent" default(android 10)
+// @Param x-client-device  header string true "Client Device ID"
+// @Param x-client-version  header string true "Client Platform Version" default(1.0.0)
+// @Param x-client-timestamp  header string false "Client Original Timestamp in UNIX format (EPOCH)"
+// @Param Payload query model.AnalyticsRequest false "Report Period"
+// @Success 200 {object} model.ProviderSavingsResponse
+// @Failure 400 {object} model.Meta
+// @Failure 401 {object} model.Meta
+// @Failure 500 {object} model.Meta
+// @Failure 503 {object} model.Meta
+// @Router /v1/analytics/providers [get]
+func (a *Analytics) providers(ctx *fiber.Ctx) error {
+	return report(ctx, false, func(inp *model.AnalyticsRequest) (interface{}, *model.BusinessError) {
+		return a.ProviderSavings(inp)
+	})
+}
+
+// @Tags Analytics Partner APIs
+// API Partner Cashback Volume Report
+// @Summary API Partner Cashback Volume Report
+// @Description API to report the cashback volume by day, wallet and provider of the partner
+// @Schemes
+// @Accept json
+// @Param Authorization header string true "Your Token to Access" default(Bearer )
+// @Param x-client-channel header string true "Client Channel" Enums(EBIZKEZBEK, B2BCLIENT)
+// @Param x-client-os  header string true "Client OS or Browser Agent" default(android 10)
+// @Param x-client-device  header string true "Client Device ID"
+// @Param x-client-version  header string true "Client Platform Version" default(1.0.0)
+// @Param x-client-timestamp  header string false "Client Original Timestamp in UNIX format (EPOCH)"
+// @Param start_date query string false "Start Date" default(2023-01-01)
+// @Param end_date query string false "End Date" default(2023-01-31)
+// @Success 200 {object} model.CashbackVolumeResponse
+// @Failure 400 {object} model.Meta
+// @Failure 401 {object} model.Meta
+// @Failure 403 {object} model.Meta
+// @Failure 500 {object} model.Meta
+// @Failure 503 {object} model.Meta
+// @Router /partner/v1/analytics/cashbacks [get]
+func (pa *PartnerAnalytics) cashbacks(ctx *fiber.Ctx) error {
+	return report(ctx, true, func(inp *model.AnalyticsRequest) (interface{}, *model.BusinessError) {
+		return pa.CashbackVolume(inp)
+	})
+}
+
+// @Tags Analytics Partner APIs
+// API Partner Customer Summary Report
+// @Summary API Partner Customer Summary Report
+// @Description API to report the unique customers and the average basket of the partner
+// @Schemes
+// @Accept json
+// @Param Authorization header string true "Your Token to Access" default(Bearer )
+// @Param x-client-channel header string true "Client Channel" Enums(EBIZKEZBEK, B2BCLIENT)
+// @Param x-client-os  header string true "Client OS or Browser Agent" default(android 10)
+// @Param x-client-device  header string true "Client Device ID"
+// @Param x-client-version  header string true "Client Platform Version" default(1.0.0)
+// @Param x-client-timestamp  header string false "Client Original Timestamp in UNIX format (EPOCH)"
+// @Param start_date query string false "Start Date" default(2023-01-01)
+// @Param end_date query string false "End Date" default(2023-01-31)
+// @Success 200 {object} model.CustomerSummaryResponse
+// @Failure 400 {object} model.Meta
+// @Failure 401 {object} model.Meta
+// @Failure 403 {object} model.Meta
+// @Failure 500 {object} model.Meta
+// @Failure 503 {object} model.Meta
+// @Router /partner/v1/analytics/customers [get]
+func (pa *PartnerAnalytics) customers(ctx *fiber.Ctx) error {
+	return report(ctx, true, func(inp *model.AnalyticsRequest) (interface{}, *model.BusinessError) {
+		return pa.CustomerSummary(inp)
+	})
+}
+
+// @Tags Analytics Partner APIs
+// API Partner Tier Distribution Report
+// @Summary API Partner Tier Distribution Report
+// @Description API to report the customers on each current tier of the partner
+// @Schemes
+// @Accept json
+// @Param Authorization header string true "Your Token to Access" default(Bearer )
+// @Param x-client-channel header string true "Client Channel" Enums(EBIZKEZBEK, B2BCLIENT)
+// @Param x-client-os  header string true "Client OS or Browser Agent" default(android 10)
+// @Param x-client-device  header string true "Client Device ID"
+// @Param x-client-version  header string true "Client Platform Version" default(1.0.0)
+// @Param x-client-timestamp  header string false "Client Original Timestamp in UNIX format (EPOCH)"
+// @Success 200 {object} model.TierDistributionResponse
+// @Failure 401 {object} model.Meta
+// @Failure 403 {object} model.Meta
+// @Failure 500 {object} model.Meta
+// @Failure 503 {object} model.Meta
+// @Router /partner/v1/analytics/tiers [get]
+func (pa *PartnerAnalytics) tiers(ctx *fiber.Ctx) error {
+	return report(ctx, true, func(inp *model.AnalyticsRequest) (interface{}, *model.BusinessError) {
+		return pa.TierDistribution(inp)
+	})
+}
@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/handler/middleware"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/mock/adaptor"
+	"github.com/adinandradrs/cezbek-engine/mock/storage"
+	"github.com/adinandradrs/cezbek-engine/mock/usecase/analytics"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAnalyticsHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	reportProvider := analytics.NewMockReportProvider(ctrl)
+	logger, _ := apps.NewLog(false)
+	adminAuthenticator := middleware.NewAdminAuthenticator(&middleware.AdminAuthenticator{
+		Logger: logger,
+		ApiKey: "b4ck0ff1c3",
+	})
+
+	api := fiber.New()
+	AnalyticsHandler(api.Group("/api/v1/analytics"), Analytics{
+		ReportProvider: reportProvider,
+		AdminFilter:    adminAuthenticator.AdminFilter(),
+	})
+
+	t.Run("should return 200 success to report cashback volume", func(t *testing.T) {
+		reportProvider.EXPECT().CashbackVolume(gomock.Any()).DoAndReturn(
+			func(inp *model.AnalyticsRequest) (*model.CashbackVolumeResponse, *model.BusinessError) {
+				assert.Equal(t, "2023-01-01", inp.StartDate)
+				assert.Equal(t, int64(9), inp.PartnerId)
+				return &model.CashbackVolumeResponse{StartDate: inp.StartDate}, nil
+			})
+		req := httptest.NewRequest(fiber.MethodGet,
+			"/api/v1/analytics/cashbacks?start_date=2023-01-01&end_date=2023-01-31&partner_id=9", nil)
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		res, _ := api.Test(req, 100)
+		m := model.Response{}
+		_ = json.NewDecoder(res.Body).Decode(&m)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+		assert.NotNil(t, m.Data)
+	})
+
+	t.Run("should return 401 without api key", func(t *testing.T) {
+		req := httptest.NewRequest(fiber.MethodGet, "/api/v1/analytics/tiers", nil)
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusUnauthorized, res.StatusCode)
+	})
+
+	t.Run("should return 400 on invalid date", func(t *testing.T) {
+		req := httptest.NewRequest(fiber.MethodGet, "/api/v1/analytics/customers?start_date=01-01-2023", nil)
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("should return 400 on invalid period", func(t *testing.T) {
+		reportProvider.EXPECT().CustomerSummary(gomock.Any()).Return(nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeBussPeriodInvalid,
+			ErrorMessage: apps.ErrMsgBussPeriodInvalid,
+		})
+		req := httptest.NewRequest(fiber.MethodGet,
+			"/api/v1/analytics/customers?start_date=2023-02-01&end_date=2023-01-01", nil)
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		res, _ := api.Test(req, 100)
+		m := model.Response{}
+		_ = json.NewDecoder(res.Body).Decode(&m)
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+		assert.Equal(t, apps.ErrCodeBussPeriodInvalid, m.Meta.Code)
+	})
+
+	t.Run("should return 500 failed to report tier distribution", func(t *testing.T) {
+		reportProvider.EXPECT().TierDistribution(gomock.Any()).Return(nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSomethingWrong,
+			ErrorMessage: apps.ErrMsgSomethingWrong,
+		})
+		req := httptest.NewRequest(fiber.MethodGet, "/api/v1/analytics/tiers", nil)
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
+	})
+
+	t.Run("should return 200 success to report provider savings", func(t *testing.T) {
+		reportProvider.EXPECT().ProviderSavings(gomock.Any()).DoAndReturn(
+			func(inp *model.AnalyticsRequest) (*model.ProviderSavingsResponse, *model.BusinessError) {
+				assert.Equal(t, "2023-01-31", inp.EndDate)
+				return &model.ProviderSavingsResponse{DefaultProvider: "XENIT"}, nil
+			})
+		req := httptest.NewRequest(fiber.MethodGet, "/api/v1/analytics/providers?end_date=2023-01-31", nil)
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	})
+}
+
+func TestPartnerAnalyticsHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	ciamPartner := adaptor.NewMockCiamWatcher(ctrl)
+	cacher := storage.NewMockCacher(ctrl)
+	reportProvider := analytics.NewMockReportProvider(ctrl)
+	jwtAuthenticator := middleware.NewJwtAuthenticator(&middleware.JwtAuthenticator{
+		Logger:      logger,
+		CiamPartner: ciamPartner,
+		Cacher:      cacher,
+	})
+	api := fiber.New()
+	PartnerAnalyticsHandler(api.Group("/api/partner/v1/analytics"), PartnerAnalytics{
+		ReportProvider: reportProvider,
+		PartnerFilter:  jwtAuthenticator.PartnerFilter(),
+	})
+	jwtInfo := map[string]interface{}{
+		"email":            "someone@email.net",
+		"cognito:username": "someone",
+	}
+	c, _ := json.Marshal(model.OfficerValidationResponse{
+		Id:      int64(1),
+		Code:    "CORP_A",
+		Company: "Company A",
+		Email:   "someone@email.net",
+		Role:    apps.RoleOfficerFinance,
+	})
+
+	t.Run("should return 200 success to report on the partner only", func(t *testing.T) {
+		cacher.EXPECT().Get("B2BTOKEN", apps.Hash("*secret*")).Return("someone@email.net", nil)
+		cacher.EXPECT().Get("B2BSESSION", "someone@email.net").Return(string(c), nil)
+		ciamPartner.EXPECT().JwtInfo(gomock.Any()).Return(jwtInfo, nil)
+		reportProvider.EXPECT().CashbackVolume(gomock.Any()).DoAndReturn(
+			func(inp *model.AnalyticsRequest) (*model.CashbackVolumeResponse, *model.BusinessError) {
+				assert.Equal(t, int64(1), inp.PartnerId)
+				return &model.CashbackVolumeResponse{}, nil
+			})
+		req := httptest.NewRequest(fiber.MethodGet, "/api/partner/v1/analytics/cashbacks?partner_id=9", nil)
+		req.Header.Add(fiber.HeaderAuthorization, "Bearer *secret*")
+		req.Header.Add(apps.HeaderClientChannel, apps.ChannelEBizKezbek)
+		req.Header.Add(apps.HeaderClientDeviceId, "f-123-456")
+		req.Header.Add(apps.HeaderClientOs, "Android 10")
+		req.Header.Add(apps.HeaderClientVersion, "1.0.0")
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	})
+
+	t.Run("should return 401 without token", func(t *testing.T) {
+		req := httptest.NewRequest(fiber.MethodGet, "/api/partner/v1/analytics/tiers", nil)
+		req.Header.Add(apps.HeaderClientChannel, apps.ChannelEBizKezbek)
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusUnauthorized, res.StatusCode)
+	})
+}
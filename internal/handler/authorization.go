@@ -2,6 +2,7 @@ package handler
 
 import (
 	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/handler/middleware"
 	"github.com/adinandradrs/cezbek-engine/internal/model"
 	"github.com/adinandradrs/cezbek-engine/internal/usecase/client"
 	"github.com/adinandradrs/cezbek-engine/internal/usecase/partner"
@@ -12,6 +13,8 @@ type Authorization struct {
 	PartnerOnboardProvider partner.OnboardProvider
 	ClientOnboardProvider  client.OnboardProvider
 	ClientFilter           fiber.Handler
+	JwtClientFilter        fiber.Handler
+	JwtPartnerFilter       fiber.Handler
 }
 
 func newAuthorizationResource(a Authorization) *Authorization {
@@ -22,6 +25,9 @@ func AuthorizationHandler(router fiber.Router, auth Authorization) {
 	handler := newAuthorizationResource(auth)
 	router.Post("/b2b", handler.b2bAuth)
 	router.Post("/otp", handler.otpAuth)
+	router.Post("/refresh", handler.refresh)
+	router.Post("/client/logout", auth.JwtClientFilter, handler.clientLogout)
+	router.Post("/b2b/logout", auth.JwtPartnerFilter, handler.b2bLogout)
 	router.Use(auth.ClientFilter).Post("/client", handler.clientAuth)
 }
 
@@ -79,6 +85,7 @@ func (a *Authorization) clientAuth(ctx *fiber.Ctx) error {
 // @Success 200 {object} model.OfficerAuthenticationResponse
 // @Failure 400 {object} model.Meta
 // @Failure 401 {object} model.Meta
+// @Failure 429 {object} model.Meta
 // @Failure 500 {object} model.Meta
 // @Router /v1/authorization/b2b [post]
 func (a *Authorization) b2bAuth(ctx *fiber.Ctx) error {
@@ -91,10 +98,14 @@ func (a *Authorization) b2bAuth(ctx *fiber.Ctx) error {
 		return ctx.Status(fiber.StatusBadRequest).JSON(bad)
 	}
 	v, ex := a.PartnerOnboardProvider.Authenticate(&inp)
+	if ex != nil && (ex.ErrorCode == apps.ErrCodeBussPartnerLocked ||
+		ex.ErrorCode == apps.ErrCodeBussOTPThrottled) {
+		return ctx.Status(fiber.StatusTooManyRequests).JSON(apps.BusinessErrorResponse(ex))
+	}
 	if ex != nil && ex.ErrorCode == apps.ErrCodeUnauthorized {
 		return ctx.Status(fiber.StatusUnauthorized).JSON(apps.BusinessErrorResponse(ex))
 	}
-	if ex != nil && ex.ErrorCode == apps.ErrCodeSomethingWrong {
+	if ex != nil {
 		return ctx.Status(fiber.StatusInternalServerError).JSON(apps.BusinessErrorResponse(ex))
 	}
 	return ctx.JSON(apps.DefaultSuccessResponse(apps.SuccessMsgSubmit, v))
@@ -116,6 +127,7 @@ func (a *Authorization) b2bAuth(ctx *fiber.Ctx) error {
 // @Success 200 {object} model.OfficerValidationResponse
 // @Failure 400 {object} model.Meta
 // @Failure 401 {object} model.Meta
+// @Failure 429 {object} model.Meta
 // @Failure 500 {object} model.Meta
 // @Router /v1/authorization/otp [post]
 func (a *Authorization) otpAuth(ctx *fiber.Ctx) error {
@@ -132,8 +144,115 @@ func (a *Authorization) otpAuth(ctx *fiber.Ctx) error {
 	if ex != nil && ex.ErrorCode == apps.ErrCodeBussPartnerOTPInvalid {
 		return ctx.Status(fiber.StatusBadRequest).JSON(apps.BusinessErrorResponse(ex))
 	}
+	if ex != nil && ex.ErrorCode == apps.ErrCodeBussPartnerLocked {
+		return ctx.Status(fiber.StatusTooManyRequests).JSON(apps.BusinessErrorResponse(ex))
+	}
+	if ex != nil && ex.ErrorCode == apps.ErrCodeSomethingWrong {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(apps.BusinessErrorResponse(ex))
+	}
+	return ctx.JSON(apps.DefaultSuccessResponse(apps.SuccessMsgSubmit, v))
+}
+
+// @Tags Authorization APIs
+// API Session Refresh
+// @Summary API Session Refresh
+// @Description API to issue a new token by using the CIAM refresh token and extend the active session
+// @Schemes
+// @Accept json
+// @Param x-client-refresh-token header string true "Refresh token from the last authorization"
+// @Param x-client-channel header string true "Client Channel" Enums(EBIZKEZBEK, B2BCLIENT)
+// @Param x-client-os  header string true "Client OS or Browser Agent" default(android 10)
+// @Param x-client-device  header string true "Client Device ID"
+// @Param x-client-version  header string true "Client Platform Version" default(1.0.0)
+// @Param x-client-timestamp  header string false "Client Original Timestamp in UNIX format (EPOCH)"
+// @Param request body model.SessionRefreshRequest true "Partner code for B2BCLIENT or officer email for EBIZKEZBEK"
+// @Success 200 {object} model.SessionResponse
+// @Failure 400 {object} model.Meta
+// @Failure 401 {object} model.Meta
+// @Failure 500 {object} model.Meta
+// @Router /v1/authorization/refresh [post]
+func (a *Authorization) refresh(ctx *fiber.Ctx) error {
+	inp := model.SessionRefreshRequest{}
+	if err := ctx.BodyParser(&inp); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	inp.RefreshToken = ctx.Get(apps.HeaderClientRefToken)
+	bad := apps.ValidateStruct(checker.Struct(inp))
+	if bad != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(bad)
+	}
+	var (
+		v  *model.SessionResponse
+		ex *model.BusinessError
+	)
+	switch ctx.Get(apps.HeaderClientChannel) {
+	case apps.ChannelB2BClient:
+		v, ex = a.ClientOnboardProvider.Refresh(&inp)
+	case apps.ChannelEBizKezbek:
+		v, ex = a.PartnerOnboardProvider.Refresh(&inp)
+	default:
+		return ctx.Status(fiber.StatusUnauthorized).JSON(apps.BusinessErrorResponse(&model.BusinessError{
+			ErrorCode:    apps.ErrCodeInvalidChannel,
+			ErrorMessage: apps.ErrMsgInvalidChannel,
+		}))
+	}
+	if ex != nil && ex.ErrorCode == apps.ErrCodeUnauthorized {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(apps.BusinessErrorResponse(ex))
+	}
 	if ex != nil && ex.ErrorCode == apps.ErrCodeSomethingWrong {
 		return ctx.Status(fiber.StatusInternalServerError).JSON(apps.BusinessErrorResponse(ex))
 	}
 	return ctx.JSON(apps.DefaultSuccessResponse(apps.SuccessMsgSubmit, v))
 }
+
+// @Tags Authorization APIs
+// API Client Logout
+// @Summary API Client Logout
+// @Description API to end the client session and revoke the current token
+// @Schemes
+// @Accept json
+// @Param Authorization header string true "Bearer Token"
+// @Param x-client-channel header string true "Client Channel" Enums(B2BCLIENT)
+// @Param x-client-os  header string true "Client OS or Browser Agent" default(android 10)
+// @Param x-client-device  header string true "Client Device ID"
+// @Param x-client-version  header string true "Client Platform Version" default(1.0.0)
+// @Param x-client-timestamp  header string false "Client Original Timestamp in UNIX format (EPOCH)"
+// @Success 200 {object} model.Meta
+// @Failure 401 {object} model.Meta
+// @Failure 500 {object} model.Meta
+// @Router /v1/authorization/client/logout [post]
+func (a *Authorization) clientLogout(ctx *fiber.Ctx) error {
+	inp := middleware.ClientSession(ctx)
+	return a.logout(ctx, a.ClientOnboardProvider.Logout(&inp))
+}
+
+// @Tags Authorization APIs
+// API B2B Logout
+// @Summary API B2B Logout
+// @Description API to end the B2B officer session and revoke the current token
+// @Schemes
+// @Accept json
+// @Param Authorization header string true "Bearer Token"
+// @Param x-client-channel header string true "Client Channel" Enums(EBIZKEZBEK)
+// @Param x-client-os  header string true "Client OS or Browser Agent" default(android 10)
+// @Param x-client-device  header string true "Client Device ID"
+// @Param x-client-version  header string true "Client Platform Version" default(1.0.0)
+// @Param x-client-timestamp  header string false "Client Original Timestamp in UNIX format (EPOCH)"
+// @Success 200 {object} model.Meta
+// @Failure 401 {object} model.Meta
+// @Failure 500 {object} model.Meta
+// @Router /v1/authorization/b2b/logout [post]
+func (a *Authorization) b2bLogout(ctx *fiber.Ctx) error {
+	inp := middleware.ClientSession(ctx)
+	return a.logout(ctx, a.PartnerOnboardProvider.Logout(&inp))
+}
+
+func (a *Authorization) logout(ctx *fiber.Ctx, ex *model.BusinessError) error {
+	if ex != nil && ex.ErrorCode == apps.ErrCodeUnauthorized {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(apps.BusinessErrorResponse(ex))
+	}
+	if ex != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(apps.BusinessErrorResponse(ex))
+	}
+	return ctx.JSON(apps.DefaultSuccessResponse(apps.SuccessMsgSubmit, nil))
+}
@@ -6,6 +6,8 @@ import (
 	"github.com/adinandradrs/cezbek-engine/internal/apps"
 	"github.com/adinandradrs/cezbek-engine/internal/handler/middleware"
 	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/mock/adaptor"
+	"github.com/adinandradrs/cezbek-engine/mock/storage"
 	"github.com/adinandradrs/cezbek-engine/mock/usecase/client"
 	"github.com/adinandradrs/cezbek-engine/mock/usecase/partner"
 	"github.com/gofiber/fiber/v2"
@@ -32,10 +34,37 @@ func TestAuthorizationHandler(t *testing.T) {
 		Logger: logger,
 	})
 	preAuthClientFilter := preAuthenticator.ClientFilter()
+	ciamPartner, cacher := adaptor.NewMockCiamWatcher(ctrl), storage.NewMockCacher(ctrl)
+	jwtAuthenticator := middleware.NewJwtAuthenticator(&middleware.JwtAuthenticator{
+		Logger:      logger,
+		CiamPartner: ciamPartner,
+		Cacher:      cacher,
+	})
 	AuthorizationHandler(authorization, Authorization{
 		PartnerOnboardProvider: partnerOnboardProvider,
 		ClientOnboardProvider:  clientOnboardProvider,
 		ClientFilter:           preAuthClientFilter,
+		JwtClientFilter:        jwtAuthenticator.ClientFilter(),
+		JwtPartnerFilter:       jwtAuthenticator.PartnerFilter(),
+	})
+	jwtInfo := map[string]interface{}{
+		"email":            "someone@email.net",
+		"cognito:username": "corp_a",
+		"jti":              "jti-abc-123",
+		"exp":              float64(time.Now().Add(time.Hour).Unix()),
+	}
+	id := int64(1)
+	sess, _ := json.Marshal(model.ClientAuthenticationResponse{
+		Id:      &id,
+		Code:    "CORP_A",
+		Company: "Company A",
+	})
+	b2bSess, _ := json.Marshal(model.OfficerValidationResponse{
+		Id:      id,
+		Code:    "CORP_A",
+		Company: "Company A",
+		Email:   "someone@email.net",
+		Role:    apps.RoleOfficerAdmin,
 	})
 
 	t.Run("should return 200 success to auth b2b", func(t *testing.T) {
@@ -63,6 +92,28 @@ func TestAuthorizationHandler(t *testing.T) {
 		assert.NotNil(t, m.Data)
 	})
 
+	t.Run("should return 429 locked to auth b2b", func(t *testing.T) {
+		inp := model.OfficerAuthenticationRequest{
+			Email: "someone@email.net",
+		}
+		b, _ := json.Marshal(inp)
+		partnerOnboardProvider.EXPECT().Authenticate(gomock.Any()).Return(nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeBussPartnerLocked,
+			ErrorMessage: apps.ErrMsgBussPartnerLocked,
+		})
+		req := httptest.NewRequest(fiber.MethodPost, "/api/v1/authorization/b2b", bytes.NewBuffer(b))
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		req.Header.Add(apps.HeaderClientChannel, apps.ChannelEBizKezbek)
+		req.Header.Add(apps.HeaderClientDeviceId, "f-123-456")
+		req.Header.Add(apps.HeaderClientOs, "Android 10")
+		req.Header.Add(apps.HeaderClientVersion, "1.0.0")
+		res, _ := api.Test(req, 100)
+		m := model.Response{}
+		_ = json.NewDecoder(res.Body).Decode(&m)
+		assert.Equal(t, fiber.StatusTooManyRequests, res.StatusCode)
+		assert.Equal(t, apps.ErrCodeBussPartnerLocked, m.Meta.Code)
+	})
+
 	t.Run("should return 400 invalid payload failed to auth b2b", func(t *testing.T) {
 		req := httptest.NewRequest(fiber.MethodPost, "/api/v1/authorization/b2b", nil)
 		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
@@ -118,6 +169,28 @@ func TestAuthorizationHandler(t *testing.T) {
 		assert.Equal(t, apps.ErrCodeSomethingWrong, m.Meta.Code)
 	})
 
+	t.Run("should return 500 on invalid otp template to auth b2b", func(t *testing.T) {
+		inp := model.OfficerAuthenticationRequest{
+			Email: "someone@email.net",
+		}
+		b, _ := json.Marshal(inp)
+		partnerOnboardProvider.EXPECT().Authenticate(gomock.Any()).Return(nil, &model.BusinessError{
+			ErrorMessage: apps.ErrMsgBussTemplateInvalid,
+			ErrorCode:    apps.ErrCodeBussTemplateInvalid,
+		})
+		req := httptest.NewRequest(fiber.MethodPost, "/api/v1/authorization/b2b", bytes.NewBuffer(b))
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		req.Header.Add(apps.HeaderClientChannel, apps.ChannelEBizKezbek)
+		req.Header.Add(apps.HeaderClientDeviceId, "f-123-456")
+		req.Header.Add(apps.HeaderClientOs, "Android 10")
+		req.Header.Add(apps.HeaderClientVersion, "1.0.0")
+		res, _ := api.Test(req, 100)
+		m := model.Response{}
+		_ = json.NewDecoder(res.Body).Decode(&m)
+		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
+		assert.Equal(t, apps.ErrCodeBussTemplateInvalid, m.Meta.Code)
+	})
+
 	t.Run("should return 200 success to auth OTP b2b", func(t *testing.T) {
 		inp := model.OfficerValidationRequest{
 			TransactionId: "TRX-001",
@@ -274,4 +347,144 @@ func TestAuthorizationHandler(t *testing.T) {
 		_ = json.NewDecoder(res.Body).Decode(&m)
 		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
 	})
+	t.Run("should return 200 to refresh client session", func(t *testing.T) {
+		inp := model.SessionRefreshRequest{
+			Username: "LAJADA",
+		}
+		exp := int64(3600)
+		clientOnboardProvider.EXPECT().Refresh(&model.SessionRefreshRequest{
+			Username:     "LAJADA",
+			RefreshToken: "**refresh**",
+		}).Return(&model.SessionResponse{
+			Token:        "**secret**",
+			RefreshToken: "**refresh**",
+			Expired:      &exp,
+		}, nil)
+		b, _ := json.Marshal(inp)
+		req := httptest.NewRequest(fiber.MethodPost, "/api/v1/authorization/refresh", bytes.NewBuffer(b))
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		req.Header.Add(apps.HeaderClientChannel, apps.ChannelB2BClient)
+		req.Header.Add(apps.HeaderClientDeviceId, "f-123-456")
+		req.Header.Add(apps.HeaderClientOs, "Android 10")
+		req.Header.Add(apps.HeaderClientVersion, "1.0.0")
+		req.Header.Add(apps.HeaderClientRefToken, "**refresh**")
+		res, _ := api.Test(req, 100)
+		m := model.Response{}
+		_ = json.NewDecoder(res.Body).Decode(&m)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+		assert.NotNil(t, m.Data)
+	})
+
+	t.Run("should return 401 to refresh b2b session", func(t *testing.T) {
+		inp := model.SessionRefreshRequest{
+			Username: "someone@email.net",
+		}
+		partnerOnboardProvider.EXPECT().Refresh(gomock.Any()).Return(nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeUnauthorized,
+			ErrorMessage: apps.ErrMsgUnauthorized,
+		})
+		b, _ := json.Marshal(inp)
+		req := httptest.NewRequest(fiber.MethodPost, "/api/v1/authorization/refresh", bytes.NewBuffer(b))
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		req.Header.Add(apps.HeaderClientChannel, apps.ChannelEBizKezbek)
+		req.Header.Add(apps.HeaderClientDeviceId, "f-123-456")
+		req.Header.Add(apps.HeaderClientOs, "Android 10")
+		req.Header.Add(apps.HeaderClientVersion, "1.0.0")
+		req.Header.Add(apps.HeaderClientRefToken, "**refresh**")
+		res, _ := api.Test(req, 100)
+		m := model.Response{}
+		_ = json.NewDecoder(res.Body).Decode(&m)
+		assert.Equal(t, fiber.StatusUnauthorized, res.StatusCode)
+		assert.Equal(t, apps.ErrCodeUnauthorized, m.Meta.Code)
+	})
+
+	t.Run("should return 400 to refresh without refresh token", func(t *testing.T) {
+		inp := model.SessionRefreshRequest{
+			Username: "someone@email.net",
+		}
+		b, _ := json.Marshal(inp)
+		req := httptest.NewRequest(fiber.MethodPost, "/api/v1/authorization/refresh", bytes.NewBuffer(b))
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		req.Header.Add(apps.HeaderClientChannel, apps.ChannelEBizKezbek)
+		req.Header.Add(apps.HeaderClientDeviceId, "f-123-456")
+		req.Header.Add(apps.HeaderClientOs, "Android 10")
+		req.Header.Add(apps.HeaderClientVersion, "1.0.0")
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("should return 200 success to logout client", func(t *testing.T) {
+		ciamPartner.EXPECT().JwtInfo(gomock.Any()).Return(jwtInfo, nil)
+		cacher.EXPECT().Ttl("JWTDENY", "jti-abc-123").Return(time.Duration(0), nil)
+		cacher.EXPECT().Get("CLIENTSESSION", "CORP_A").Return(string(sess), nil)
+		clientOnboardProvider.EXPECT().Logout(gomock.Any()).DoAndReturn(func(inp *model.SessionRequest) *model.BusinessError {
+			assert.Equal(t, "CORP_A", inp.Username)
+			assert.Equal(t, "jti-abc-123", inp.TokenId)
+			return nil
+		})
+		req := httptest.NewRequest(fiber.MethodPost, "/api/v1/authorization/client/logout", nil)
+		req.Header.Add(fiber.HeaderAuthorization, "Bearer *secret*")
+		req.Header.Add(apps.HeaderClientChannel, apps.ChannelB2BClient)
+		req.Header.Add(apps.HeaderClientDeviceId, "f-123-456")
+		req.Header.Add(apps.HeaderClientOs, "Android 10")
+		req.Header.Add(apps.HeaderClientVersion, "1.0.0")
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	})
+
+	t.Run("should ignore the given token id on logout without jti", func(t *testing.T) {
+		ciamPartner.EXPECT().JwtInfo(gomock.Any()).Return(map[string]interface{}{
+			"email":            "someone@email.net",
+			"cognito:username": "corp_a",
+		}, nil)
+		cacher.EXPECT().Get("CLIENTSESSION", "CORP_A").Return(string(sess), nil)
+		clientOnboardProvider.EXPECT().Logout(gomock.Any()).DoAndReturn(func(inp *model.SessionRequest) *model.BusinessError {
+			assert.Empty(t, inp.TokenId)
+			return nil
+		})
+		req := httptest.NewRequest(fiber.MethodPost, "/api/v1/authorization/client/logout", nil)
+		req.Header.Add(fiber.HeaderAuthorization, "Bearer *secret*")
+		req.Header.Add(apps.HeaderClientChannel, apps.ChannelB2BClient)
+		req.Header.Add(apps.HeaderClientDeviceId, "f-123-456")
+		req.Header.Add(apps.HeaderClientOs, "Android 10")
+		req.Header.Add(apps.HeaderClientVersion, "1.0.0")
+		req.Header.Add(apps.HeaderSessionTokenId, "jti-someone-else")
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	})
+
+	t.Run("should return 500 failed to logout b2b", func(t *testing.T) {
+		ciamPartner.EXPECT().JwtInfo(gomock.Any()).Return(jwtInfo, nil)
+		cacher.EXPECT().Ttl("JWTDENY", "jti-abc-123").Return(time.Duration(0), nil)
+		cacher.EXPECT().Get("B2BTOKEN", apps.Hash("*secret*")).Return("someone@email.net", nil)
+		cacher.EXPECT().Get("B2BSESSION", "someone@email.net").Return(string(b2bSess), nil)
+		partnerOnboardProvider.EXPECT().Logout(gomock.Any()).Return(&model.BusinessError{
+			ErrorCode:    apps.ErrCodeSomethingWrong,
+			ErrorMessage: apps.ErrMsgSomethingWrong,
+		})
+		req := httptest.NewRequest(fiber.MethodPost, "/api/v1/authorization/b2b/logout", nil)
+		req.Header.Add(fiber.HeaderAuthorization, "Bearer *secret*")
+		req.Header.Add(apps.HeaderClientChannel, apps.ChannelEBizKezbek)
+		req.Header.Add(apps.HeaderClientDeviceId, "f-123-456")
+		req.Header.Add(apps.HeaderClientOs, "Android 10")
+		req.Header.Add(apps.HeaderClientVersion, "1.0.0")
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
+	})
+
+	t.Run("should return 401 to logout with revoked token", func(t *testing.T) {
+		ciamPartner.EXPECT().JwtInfo(gomock.Any()).Return(jwtInfo, nil)
+		cacher.EXPECT().Ttl("JWTDENY", "jti-abc-123").Return(time.Hour, nil)
+		req := httptest.NewRequest(fiber.MethodPost, "/api/v1/authorization/b2b/logout", nil)
+		req.Header.Add(fiber.HeaderAuthorization, "Bearer *secret*")
+		req.Header.Add(apps.HeaderClientChannel, apps.ChannelEBizKezbek)
+		req.Header.Add(apps.HeaderClientDeviceId, "f-123-456")
+		req.Header.Add(apps.HeaderClientOs, "Android 10")
+		req.Header.Add(apps.HeaderClientVersion, "1.0.0")
+		res, _ := api.Test(req, 100)
+		m := model.Response{}
+		_ = json.NewDecoder(res.Body).Decode(&m)
+		assert.Equal(t, fiber.StatusUnauthorized, res.StatusCode)
+		assert.Equal(t, apps.ErrCodeUnauthorized, m.Meta.Code)
+	})
 }
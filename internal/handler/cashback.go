@@ -28,7 +28,7 @@ func CashbackHandler(router fiber.Router, c Cashback) {
 // @Tags Client Cashback APIs
 // API Apply Cashback
 // @Summary API Apply Cashback
-// @Description API to apply cashback on client's transaction
+// @Description API to apply cashback on client's transaction, the cashback and the provider fee are held from the deposit of a prepaid partner
 // @Schemes
 // @Accept json
 // @Param Authorization header string true "Your Token to Access" default(Bearer )
@@ -64,7 +64,8 @@ func (c *Cashback) add(ctx *fiber.Ctx) error {
 	}
 	v, ex := c.Add(&inp)
 	if ex != nil && (ex.ErrorCode == apps.ErrCodeBussMerchantCodeInvalid ||
-		ex.ErrorCode == apps.ErrCodeBussNoCashback) {
+		ex.ErrorCode == apps.ErrCodeBussNoCashback ||
+		ex.ErrorCode == apps.ErrCodeBussDepositInsufficient) {
 		return ctx.Status(fiber.StatusBadRequest).
 			JSON(apps.BusinessErrorResponse(ex))
 	}
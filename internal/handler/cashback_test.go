@@ -127,6 +127,27 @@ func TestCashbackHandler(t *testing.T) {
 		assert.Equal(t, apps.ErrCodeBussNoCashback, m.Meta.Code)
 	})
 
+	t.Run("should return 400 failed to apply cashback due insufficient deposit", func(t *testing.T) {
+		cacher.EXPECT().Get(gomock.Any(), gomock.Any()).Return(string(c), nil)
+		ciamPartner.EXPECT().JwtInfo(gomock.Any()).Return(jwtInfo, nil)
+		transactionProvider.EXPECT().Add(gomock.Any()).Return(nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeBussDepositInsufficient,
+			ErrorMessage: apps.ErrMsgBussDepositInsufficient,
+		})
+		req := httptest.NewRequest(fiber.MethodPost, "/api/v1/cashbacks", bytes.NewBuffer(b))
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		req.Header.Add(fiber.HeaderAuthorization, "Bearer *secret*")
+		req.Header.Add(apps.HeaderClientChannel, apps.ChannelB2BClient)
+		req.Header.Add(apps.HeaderClientDeviceId, "f-123-456")
+		req.Header.Add(apps.HeaderClientOs, "Android 10")
+		req.Header.Add(apps.HeaderClientVersion, "1.0.0")
+		res, _ := api.Test(req, 100)
+		m := model.Response{}
+		_ = json.NewDecoder(res.Body).Decode(&m)
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+		assert.Equal(t, apps.ErrCodeBussDepositInsufficient, m.Meta.Code)
+	})
+
 	t.Run("should return 500 failed to apply cashback due error on wallet services", func(t *testing.T) {
 		cacher.EXPECT().Get(gomock.Any(), gomock.Any()).Return(string(c), nil)
 		ciamPartner.EXPECT().JwtInfo(gomock.Any()).Return(jwtInfo, nil)
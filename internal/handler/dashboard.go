@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/handler/middleware"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/internal/usecase/analytics"
+	"github.com/gofiber/fiber/v2"
+)
+
+type PartnerDashboard struct {
+	analytics.ReportProvider
+	PartnerFilter fiber.Handler
+}
+
+func newPartnerDashboard(pd PartnerDashboard) *PartnerDashboard {
+	return &pd
+}
+
+func PartnerDashboardHandler(router fiber.Router, pd PartnerDashboard) {
+	handler := newPartnerDashboard(pd)
+	router.Use(pd.PartnerFilter, middleware.PartnerRoleFilter(apps.RoleOfficerViewer,
+		apps.RoleOfficerFinance, apps.RoleOfficerAdmin))
+	router.Get("/", handler.dashboard)
+}
+
+// @Tags Dashboard Partner APIs
+// API Partner Dashboard
+// @Summary API Partner Dashboard
+// @Description API to compare the KPI of the partner on the period with the previous period of the same length
+// @Schemes
+// @Accept json
+// @Param Authorization header string true "Your Token to Access" default(Bearer )
+// @Param x-client-channel header string true "Client Channel" Enums(EBIZKEZBEK, B2BCLIENT)
+// @Param x-client-os  header string true "Client OS or Browser Agent" default(android 10)
+// @Param x-client-device  header string true "Client Device ID"
+// @Param x-client-version  header string true "Client Platform Version" default(1.0.0)
+// @Param x-client-timestamp  header string false "Client Original Timestamp in UNIX format (EPOCH)"
+// @Param start_date query string false "Start Date" default(2023-01-01)
+// @Param end_date query string false "End Date" default(2023-01-31)
+// @Success 200 {object} model.DashboardResponse
+// @Failure 400 {object} model.Meta
+// @Failure 401 {object} model.Meta
+// @Failure 403 {object} model.Meta
+// @Failure 500 {object} model.Meta
+// @Failure 503 {object} model.Meta
+// @Router /partner/v1/dashboard [get]
+func (pd *PartnerDashboard) dashboard(ctx *fiber.Ctx) error {
+	return report(ctx, true, func(inp *model.AnalyticsRequest) (interface{}, *model.BusinessError) {
+		return pd.Dashboard(inp)
+	})
+}
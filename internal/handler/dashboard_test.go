@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/handler/middleware"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/mock/adaptor"
+	"github.com/adinandradrs/cezbek-engine/mock/storage"
+	"github.com/adinandradrs/cezbek-engine/mock/usecase/analytics"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPartnerDashboardHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	ciamPartner := adaptor.NewMockCiamWatcher(ctrl)
+	cacher := storage.NewMockCacher(ctrl)
+	reportProvider := analytics.NewMockReportProvider(ctrl)
+	jwtAuthenticator := middleware.NewJwtAuthenticator(&middleware.JwtAuthenticator{
+		Logger:      logger,
+		CiamPartner: ciamPartner,
+		Cacher:      cacher,
+	})
+	api := fiber.New()
+	PartnerDashboardHandler(api.Group("/api/partner/v1/dashboard"), PartnerDashboard{
+		ReportProvider: reportProvider,
+		PartnerFilter:  jwtAuthenticator.PartnerFilter(),
+	})
+	jwtInfo := map[string]interface{}{
+		"email":            "someone@email.net",
+		"cognito:username": "someone",
+	}
+	c, _ := json.Marshal(model.OfficerValidationResponse{
+		Id:      int64(1),
+		Code:    "CORP_A",
+		Company: "Company A",
+		Email:   "someone@email.net",
+		Role:    apps.RoleOfficerViewer,
+	})
+
+	t.Run("should return 200 success to show the dashboard of the partner only", func(t *testing.T) {
+		cacher.EXPECT().Get("B2BTOKEN", apps.Hash("*secret*")).Return("someone@email.net", nil)
+		cacher.EXPECT().Get("B2BSESSION", "someone@email.net").Return(string(c), nil)
+		ciamPartner.EXPECT().JwtInfo(gomock.Any()).Return(jwtInfo, nil)
+		reportProvider.EXPECT().Dashboard(gomock.Any()).DoAndReturn(
+			func(inp *model.AnalyticsRequest) (*model.DashboardResponse, *model.BusinessError) {
+				assert.Equal(t, int64(1), inp.PartnerId)
+				assert.Equal(t, "2023-01-01", inp.StartDate)
+				return &model.DashboardResponse{StartDate: inp.StartDate}, nil
+			})
+		req := httptest.NewRequest(fiber.MethodGet,
+			"/api/partner/v1/dashboard?start_date=2023-01-01&partner_id=9", nil)
+		req.Header.Add(fiber.HeaderAuthorization, "Bearer *secret*")
+		req.Header.Add(apps.HeaderClientChannel, apps.ChannelEBizKezbek)
+		req.Header.Add(apps.HeaderClientDeviceId, "f-123-456")
+		req.Header.Add(apps.HeaderClientOs, "Android 10")
+		req.Header.Add(apps.HeaderClientVersion, "1.0.0")
+		res, _ := api.Test(req, 100)
+		m := model.Response{}
+		_ = json.NewDecoder(res.Body).Decode(&m)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+		assert.NotNil(t, m.Data)
+	})
+
+	t.Run("should return 400 on invalid period", func(t *testing.T) {
+		cacher.EXPECT().Get("B2BTOKEN", apps.Hash("*secret*")).Return("someone@email.net", nil)
+		cacher.EXPECT().Get("B2BSESSION", "someone@email.net").Return(string(c), nil)
+		ciamPartner.EXPECT().JwtInfo(gomock.Any()).Return(jwtInfo, nil)
+		reportProvider.EXPECT().Dashboard(gomock.Any()).Return(nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeBussPeriodInvalid,
+			ErrorMessage: apps.ErrMsgBussPeriodInvalid,
+		})
+		req := httptest.NewRequest(fiber.MethodGet, "/api/partner/v1/dashboard?start_date=2099-01-01", nil)
+		req.Header.Add(fiber.HeaderAuthorization, "Bearer *secret*")
+		req.Header.Add(apps.HeaderClientChannel, apps.ChannelEBizKezbek)
+		req.Header.Add(apps.HeaderClientDeviceId, "f-123-456")
+		req.Header.Add(apps.HeaderClientOs, "Android 10")
+		req.Header.Add(apps.HeaderClientVersion, "1.0.0")
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("should return 401 without token", func(t *testing.T) {
+		req := httptest.NewRequest(fiber.MethodGet, "/api/partner/v1/dashboard", nil)
+		req.Header.Add(apps.HeaderClientChannel, apps.ChannelEBizKezbek)
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusUnauthorized, res.StatusCode)
+	})
+}
@@ -28,3 +28,10 @@ func DefaultHandler(router fiber.Router, path string) {
 func ping(ctx *fiber.Ctx) error {
 	return ctx.JSON(apps.DefaultSuccessResponse("succeeded ping with pong!", "pong"))
 }
+
+func JwksHandler(router fiber.Router, path string, jwks string) {
+	router.Get(path+"/.well-known/jwks.json", func(ctx *fiber.Ctx) error {
+		ctx.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		return ctx.SendString(jwks)
+	})
+}
@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/handler/middleware"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/internal/usecase/management"
+	"github.com/adinandradrs/cezbek-engine/internal/usecase/partner"
+	"github.com/gofiber/fiber/v2"
+	"strings"
+)
+
+type Deposit struct {
+	management.DepositManager
+	AdminFilter fiber.Handler
+}
+
+type PartnerDeposit struct {
+	partner.DepositProvider
+	PartnerFilter fiber.Handler
+}
+
+func newDeposit(d Deposit) *Deposit {
+	return &d
+}
+
+func newPartnerDeposit(pd PartnerDeposit) *PartnerDeposit {
+	return &pd
+}
+
+func DepositHandler(router fiber.Router, d Deposit) {
+	handler := newDeposit(d)
+	router.Use(d.AdminFilter)
+	router.Get("/:code", handler.find)
+	router.Post("/:code/topup", handler.topUp)
+}
+
+func PartnerDepositHandler(router fiber.Router, pd PartnerDeposit) {
+	handler := newPartnerDeposit(pd)
+	router.Use(pd.PartnerFilter, middleware.PartnerRoleFilter(apps.RoleOfficerFinance, apps.RoleOfficerAdmin))
+	router.Get("/", handler.find)
+}
+
+// @Tags Deposit Management APIs
+// API Deposit Detail
+// @Summary API Deposit Detail
+// @Description API to get the deposit balance of a prepaid partner, the available balance excludes the amount held by the cashback in progress
+// @Schemes
+// @Accept json
+// @Param x-api-key header string true "Back Office API Key"
+// @Param x-client-channel header string true "Client Channel" Enums(EBIZKEZBEK, B2BCLIENT)
+// @Param x-client-os  header string true "Client OS or Browser Agent" default(android 10)
+// @Param x-client-device  header string true "Client Device ID"
+// @Param x-client-version  header string true "Client Platform Version" default(1.0.0)
+// @Param x-client-timestamp  header string false "Client Original Timestamp in UNIX format (EPOCH)"
+// @Param code path string true "Partner Code" default(LAJADA)
+// @Success 200 {object} model.DepositResponse
+// @Failure 401 {object} model.Meta
+// @Failure 404 {object} model.Meta
+// @Failure 500 {object} model.Meta
+// @Router /v1/deposits/{code} [get]
+func (d *Deposit) find(ctx *fiber.Ctx) error {
+	v, ex := d.Find(strings.ToUpper(ctx.Params("code")))
+	if ex != nil && ex.ErrorCode == apps.ErrCodeNotFound {
+		return ctx.Status(fiber.StatusNotFound).JSON(apps.BusinessErrorResponse(ex))
+	}
+	if ex != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(apps.BusinessErrorResponse(ex))
+	}
+	return ctx.JSON(apps.DefaultSuccessResponse(apps.SuccessMsgDataFound, v))
+}
+
+// @Tags Deposit Management APIs
+// API Deposit Top Up
+// @Summary API Deposit Top Up
+// @Description API to record the fund transferred by a partner into its deposit, the partner becomes prepaid from its first top up and the low balance threshold is kept when it is not given
+// @Schemes
+// @Accept json
+// @Param x-api-key header string true "Back Office API Key"
+// @Param x-client-channel header string true "Client Channel" Enums(EBIZKEZBEK, B2BCLIENT)
+// @Param x-client-os  header string true "Client OS or Browser Agent" default(android 10)
+// @Param x-client-device  header string true "Client Device ID"
+// @Param x-client-version  header string true "Client Platform Version" default(1.0.0)
+// @Param x-client-timestamp  header string false "Client Original Timestamp in UNIX format (EPOCH)"
+// @Param code path string true "Partner Code" default(LAJADA)
+// @Param Payload body model.DepositTopUpRequest true "Top Up Payload"
+// @Success 200 {object} model.TransactionResponse
+// @Failure 400 {object} model.Meta
+// @Failure 401 {object} model.Meta
+// @Failure 404 {object} model.Meta
+// @Failure 500 {object} model.Meta
+// @Router /v1/deposits/{code}/topup [post]
+func (d *Deposit) topUp(ctx *fiber.Ctx) error {
+	inp := model.DepositTopUpRequest{}
+	if err := ctx.BodyParser(&inp); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	inp.Code = strings.ToUpper(ctx.Params("code"))
+	bad := apps.ValidateStruct(checker.Struct(inp))
+	if bad != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(bad)
+	}
+	v, ex := d.TopUp(&inp)
+	if ex != nil && ex.ErrorCode == apps.ErrCodeBussTopUpInvalid {
+		return ctx.Status(fiber.StatusBadRequest).JSON(apps.BusinessErrorResponse(ex))
+	}
+	if ex != nil && ex.ErrorCode == apps.ErrCodeNotFound {
+		return ctx.Status(fiber.StatusNotFound).JSON(apps.BusinessErrorResponse(ex))
+	}
+	if ex != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(apps.BusinessErrorResponse(ex))
+	}
+	return ctx.JSON(apps.DefaultSuccessResponse(apps.SuccessMsgSubmit, v))
+}
+
+// @Tags Deposit Partner APIs
+// API Deposit Balance
+// @Summary API Deposit Balance
+// @Description API to get the deposit balance of the partner, the balance is low once the available balance goes below its threshold
+// @Schemes
+// @Accept json
+// @Param Authorization header string true "Your Token to Access" default(Bearer )
+// @Param x-client-channel header string true "Client Channel" Enums(EBIZKEZBEK, B2BCLIENT)
+// @Param x-client-os  header string true "Client OS or Browser Agent" default(android 10)
+// @Param x-client-device  header string true "Client Device ID"
+// @Param x-client-version  header string true "Client Platform Version" default(1.0.0)
+// @Param x-client-timestamp  header string false "Client Original Timestamp in UNIX format (EPOCH)"
+// @Success 200 {object} model.DepositResponse
+// @Failure 401 {object} model.Meta
+// @Failure 403 {object} model.Meta
+// @Failure 404 {object} model.Meta
+// @Failure 500 {object} model.Meta
+// @Failure 503 {object} model.Meta
+// @Router /partner/v1/deposit [get]
+func (pd *PartnerDeposit) find(ctx *fiber.Ctx) error {
+	inp := middleware.ClientSession(ctx)
+	v, ex := pd.Find(&inp)
+	if ex != nil && ex.ErrorCode == apps.ErrCodeNotFound {
+		return ctx.Status(fiber.StatusNotFound).JSON(apps.BusinessErrorResponse(ex))
+	}
+	if ex != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(apps.BusinessErrorResponse(ex))
+	}
+	return ctx.JSON(apps.DefaultSuccessResponse(apps.SuccessMsgDataFound, v))
+}
@@ -0,0 +1,216 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/handler/middleware"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/mock/adaptor"
+	"github.com/adinandradrs/cezbek-engine/mock/storage"
+	"github.com/adinandradrs/cezbek-engine/mock/usecase/management"
+	"github.com/adinandradrs/cezbek-engine/mock/usecase/partner"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDepositHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	depositManager := management.NewMockDepositManager(ctrl)
+
+	logger, _ := apps.NewLog(false)
+	adminAuthenticator := middleware.NewAdminAuthenticator(&middleware.AdminAuthenticator{
+		Logger: logger,
+		ApiKey: "b4ck0ff1c3",
+	})
+
+	api := fiber.New()
+	deposits := api.Group("/api/v1/deposits")
+	DepositHandler(deposits, Deposit{
+		DepositManager: depositManager,
+		AdminFilter:    adminAuthenticator.AdminFilter(),
+	})
+	topUp := model.DepositTopUpRequest{
+		Amount:    decimal.NewFromInt(5000000),
+		Reference: "TRF/2023/01/0001",
+	}
+	t.Run("should return 401 on top up without api key", func(t *testing.T) {
+		b, _ := json.Marshal(topUp)
+		req := httptest.NewRequest(fiber.MethodPost, "/api/v1/deposits/LAJADA/topup", bytes.NewBuffer(b))
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusUnauthorized, res.StatusCode)
+	})
+
+	t.Run("should return 200 on find", func(t *testing.T) {
+		depositManager.EXPECT().Find("LAJADA").Return(&model.DepositResponse{
+			Code:      "LAJADA",
+			Balance:   decimal.NewFromInt(5000000),
+			Available: decimal.NewFromInt(5000000),
+		}, nil)
+		req := httptest.NewRequest(fiber.MethodGet, "/api/v1/deposits/lajada", nil)
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	})
+
+	t.Run("should return 404 on find a partner without deposit", func(t *testing.T) {
+		depositManager.EXPECT().Find("LAJADA").Return(nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeNotFound,
+			ErrorMessage: apps.ErrMsgNotFound,
+		})
+		req := httptest.NewRequest(fiber.MethodGet, "/api/v1/deposits/LAJADA", nil)
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusNotFound, res.StatusCode)
+	})
+
+	t.Run("should return 200 on top up", func(t *testing.T) {
+		depositManager.EXPECT().TopUp(gomock.Any()).DoAndReturn(
+			func(inp *model.DepositTopUpRequest) (*model.TransactionResponse, *model.BusinessError) {
+				assert.Equal(t, "LAJADA", inp.Code)
+				assert.False(t, inp.Threshold.Valid)
+				return &model.TransactionResponse{TransactionId: "TRX0012345678"}, nil
+			})
+		b, _ := json.Marshal(topUp)
+		req := httptest.NewRequest(fiber.MethodPost, "/api/v1/deposits/lajada/topup", bytes.NewBuffer(b))
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	})
+
+	t.Run("should return 400 on top up without reference", func(t *testing.T) {
+		b, _ := json.Marshal(model.DepositTopUpRequest{Amount: decimal.NewFromInt(5000000)})
+		req := httptest.NewRequest(fiber.MethodPost, "/api/v1/deposits/LAJADA/topup", bytes.NewBuffer(b))
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("should return 400 on top up a negative amount", func(t *testing.T) {
+		depositManager.EXPECT().TopUp(gomock.Any()).Return(nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeBussTopUpInvalid,
+			ErrorMessage: apps.ErrMsgBussTopUpInvalid,
+		})
+		b, _ := json.Marshal(model.DepositTopUpRequest{Amount: decimal.NewFromInt(-1), Reference: "TRF/2023/01/0001"})
+		req := httptest.NewRequest(fiber.MethodPost, "/api/v1/deposits/LAJADA/topup", bytes.NewBuffer(b))
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		res, _ := api.Test(req, 100)
+		m := model.Response{}
+		_ = json.NewDecoder(res.Body).Decode(&m)
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+		assert.Equal(t, apps.ErrCodeBussTopUpInvalid, m.Meta.Code)
+	})
+
+	t.Run("should return 500 on failed to top up", func(t *testing.T) {
+		depositManager.EXPECT().TopUp(gomock.Any()).Return(nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSubmitted,
+			ErrorMessage: apps.ErrMsgSubmitted,
+		})
+		b, _ := json.Marshal(topUp)
+		req := httptest.NewRequest(fiber.MethodPost, "/api/v1/deposits/LAJADA/topup", bytes.NewBuffer(b))
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
+	})
+}
+
+func TestPartnerDepositHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	ciamPartner := adaptor.NewMockCiamWatcher(ctrl)
+	cacher := storage.NewMockCacher(ctrl)
+	depositProvider := partner.NewMockDepositProvider(ctrl)
+	jwtAuthenticator := middleware.NewJwtAuthenticator(&middleware.JwtAuthenticator{
+		Logger:      logger,
+		CiamPartner: ciamPartner,
+		Cacher:      cacher,
+	})
+
+	api := fiber.New()
+	partnerDeposit := api.Group("/api/partner/v1/deposit")
+	PartnerDepositHandler(partnerDeposit, PartnerDeposit{
+		DepositProvider: depositProvider,
+		PartnerFilter:   jwtAuthenticator.PartnerFilter(),
+	})
+	jwtInfo := map[string]interface{}{
+		"email":            "someone@email.net",
+		"cognito:username": "someone",
+	}
+	session := func(role string) string {
+		c, _ := json.Marshal(model.OfficerValidationResponse{
+			Id:      int64(1),
+			Code:    "CORP_A",
+			Company: "Company A",
+			Email:   "someone@email.net",
+			Role:    role,
+		})
+		return string(c)
+	}
+	t.Run("should return 200 success to find deposit", func(t *testing.T) {
+		cacher.EXPECT().Get("B2BTOKEN", apps.Hash("*secret*")).Return("someone@email.net", nil)
+		cacher.EXPECT().Get("B2BSESSION", "someone@email.net").Return(session(apps.RoleOfficerFinance), nil)
+		ciamPartner.EXPECT().JwtInfo(gomock.Any()).Return(jwtInfo, nil)
+		depositProvider.EXPECT().Find(gomock.Any()).DoAndReturn(
+			func(inp *model.SessionRequest) (*model.DepositResponse, *model.BusinessError) {
+				assert.Equal(t, int64(1), inp.Id)
+				return &model.DepositResponse{Code: "CORP_A", Balance: decimal.NewFromInt(5000000)}, nil
+			})
+		req := httptest.NewRequest(fiber.MethodGet, "/api/partner/v1/deposit", nil)
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		req.Header.Add(fiber.HeaderAuthorization, "Bearer *secret*")
+		req.Header.Add(apps.HeaderClientChannel, apps.ChannelEBizKezbek)
+		req.Header.Add(apps.HeaderClientDeviceId, "f-123-456")
+		req.Header.Add(apps.HeaderClientOs, "Android 10")
+		req.Header.Add(apps.HeaderClientVersion, "1.0.0")
+		res, _ := api.Test(req, 100)
+		m := model.Response{}
+		_ = json.NewDecoder(res.Body).Decode(&m)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+		assert.NotNil(t, m.Data)
+	})
+
+	t.Run("should return 403 for viewer to find deposit", func(t *testing.T) {
+		cacher.EXPECT().Get("B2BTOKEN", apps.Hash("*secret*")).Return("someone@email.net", nil)
+		cacher.EXPECT().Get("B2BSESSION", "someone@email.net").Return(session(apps.RoleOfficerViewer), nil)
+		ciamPartner.EXPECT().JwtInfo(gomock.Any()).Return(jwtInfo, nil)
+		req := httptest.NewRequest(fiber.MethodGet, "/api/partner/v1/deposit", nil)
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		req.Header.Add(fiber.HeaderAuthorization, "Bearer *secret*")
+		req.Header.Add(apps.HeaderClientChannel, apps.ChannelEBizKezbek)
+		req.Header.Add(apps.HeaderClientDeviceId, "f-123-456")
+		req.Header.Add(apps.HeaderClientOs, "Android 10")
+		req.Header.Add(apps.HeaderClientVersion, "1.0.0")
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusForbidden, res.StatusCode)
+	})
+
+	t.Run("should return 404 for partner is not prepaid", func(t *testing.T) {
+		cacher.EXPECT().Get("B2BTOKEN", apps.Hash("*secret*")).Return("someone@email.net", nil)
+		cacher.EXPECT().Get("B2BSESSION", "someone@email.net").Return(session(apps.RoleOfficerAdmin), nil)
+		ciamPartner.EXPECT().JwtInfo(gomock.Any()).Return(jwtInfo, nil)
+		depositProvider.EXPECT().Find(gomock.Any()).Return(nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeNotFound,
+			ErrorMessage: apps.ErrMsgNotFound,
+		})
+		req := httptest.NewRequest(fiber.MethodGet, "/api/partner/v1/deposit", nil)
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		req.Header.Add(fiber.HeaderAuthorization, "Bearer *secret*")
+		req.Header.Add(apps.HeaderClientChannel, apps.ChannelEBizKezbek)
+		req.Header.Add(apps.HeaderClientDeviceId, "f-123-456")
+		req.Header.Add(apps.HeaderClientOs, "Android 10")
+		req.Header.Add(apps.HeaderClientVersion, "1.0.0")
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusNotFound, res.StatusCode)
+	})
+}
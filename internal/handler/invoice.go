@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/handler/middleware"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/internal/usecase/management"
+	"github.com/adinandradrs/cezbek-engine/internal/usecase/partner"
+	"github.com/gofiber/fiber/v2"
+	"strconv"
+)
+
+type Invoice struct {
+	management.InvoiceManager
+	AdminFilter fiber.Handler
+}
+
+type PartnerInvoice struct {
+	partner.InvoiceProvider
+	PartnerFilter fiber.Handler
+}
+
+func newInvoice(i Invoice) *Invoice {
+	return &i
+}
+
+func newPartnerInvoice(pi PartnerInvoice) *PartnerInvoice {
+	return &pi
+}
+
+func InvoiceHandler(router fiber.Router, i Invoice) {
+	handler := newInvoice(i)
+	router.Use(i.AdminFilter)
+	router.Put("/:id/payment", handler.pay)
+}
+
+func PartnerInvoiceHandler(router fiber.Router, pi PartnerInvoice) {
+	handler := newPartnerInvoice(pi)
+	router.Use(pi.PartnerFilter, middleware.PartnerRoleFilter(apps.RoleOfficerFinance, apps.RoleOfficerAdmin))
+	router.Get("/", handler.search)
+}
+
+// @Tags Invoice Management APIs
+// API Invoice Payment
+// @Summary API Invoice Payment
+// @Description API to mark a partner invoice as paid by the reference of the partner payment
+// @Schemes
+// @Accept json
+// @Param x-api-key header string true "Back Office API Key"
+// @Param x-client-channel header string true "Client Channel" Enums(EBIZKEZBEK, B2BCLIENT)
+// @Param x-client-os  header string true "Client OS or Browser Agent" default(android 10)
+// @Param x-client-device  header string true "Client Device ID"
+// @Param x-client-version  header string true "Client Platform Version" default(1.0.0)
+// @Param x-client-timestamp  header string false "Client Original Timestamp in UNIX format (EPOCH)"
+// @Param id path int true "Invoice ID"
+// @Param Payload body model.InvoicePaymentRequest true "Payment Payload"
+// @Success 200 {object} model.TransactionResponse
+// @Failure 400 {object} model.Meta
+// @Failure 401 {object} model.Meta
+// @Failure 404 {object} model.Meta
+// @Failure 500 {object} model.Meta
+// @Router /v1/invoices/{id}/payment [put]
+func (i *Invoice) pay(ctx *fiber.Ctx) error {
+	inp := model.InvoicePaymentRequest{}
+	if err := ctx.BodyParser(&inp); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	inp.Id, _ = strconv.ParseInt(ctx.Params("id"), 10, 64)
+	bad := apps.ValidateStruct(checker.Struct(inp))
+	if bad != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(bad)
+	}
+	v, ex := i.Pay(&inp)
+	if ex != nil && ex.ErrorCode == apps.ErrCodeNotFound {
+		return ctx.Status(fiber.StatusNotFound).JSON(apps.BusinessErrorResponse(ex))
+	}
+	if ex != nil && ex.ErrorCode == apps.ErrCodeBussInvoicePaid {
+		return ctx.Status(fiber.StatusBadRequest).JSON(apps.BusinessErrorResponse(ex))
+	}
+	if ex != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(apps.BusinessErrorResponse(ex))
+	}
+	return ctx.JSON(apps.DefaultSuccessResponse(apps.SuccessMsgSubmit, v))
+}
+
+// @Tags Invoice Partner APIs
+// API Invoice Search
+// @Summary API Invoice Search
+// @Description API to search the monthly invoices of the partner with their payment status, an unpaid invoice after its due date is overdue
+// @Schemes
+// @Accept json
+// @Param Authorization header string true "Your Token to Access" default(Bearer )
+// @Param x-client-channel header string true "Client Channel" Enums(EBIZKEZBEK, B2BCLIENT)
+// @Param x-client-os  header string true "Client OS or Browser Agent" default(android 10)
+// @Param x-client-device  header string true "Client Device ID"
+// @Param x-client-version  header string true "Client Platform Version" default(1.0.0)
+// @Param x-client-timestamp  header string false "Client Original Timestamp in UNIX format (EPOCH)"
+// @Param Payload query model.SearchRequest true "Search Payload"
+// @Success 200 {object} model.InvoiceSearchResponse
+// @Failure 400 {object} model.Meta
+// @Failure 401 {object} model.Meta
+// @Failure 403 {object} model.Meta
+// @Failure 500 {object} model.Meta
+// @Failure 503 {object} model.Meta
+// @Router /partner/v1/invoices [get]
+func (pi *PartnerInvoice) search(ctx *fiber.Ctx) error {
+	inp := model.SearchRequest{}
+	if err := ctx.QueryParser(&inp); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	inp.SessionRequest = middleware.ClientSession(ctx)
+	v, ex := pi.Search(&inp)
+	if ex != nil && ex.ErrorCode == apps.ErrCodeNotFound {
+		return ctx.Status(fiber.StatusOK).
+			JSON(apps.BusinessErrorResponse(ex))
+	}
+	return ctx.JSON(apps.DefaultSuccessResponse(apps.SuccessMsgDataFound, v))
+}
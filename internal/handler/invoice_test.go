@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/handler/middleware"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/mock/adaptor"
+	"github.com/adinandradrs/cezbek-engine/mock/storage"
+	"github.com/adinandradrs/cezbek-engine/mock/usecase/management"
+	"github.com/adinandradrs/cezbek-engine/mock/usecase/partner"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestInvoiceHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	invoiceManager := management.NewMockInvoiceManager(ctrl)
+
+	logger, _ := apps.NewLog(false)
+	adminAuthenticator := middleware.NewAdminAuthenticator(&middleware.AdminAuthenticator{
+		Logger: logger,
+		ApiKey: "b4ck0ff1c3",
+	})
+
+	api := fiber.New()
+	invoices := api.Group("/api/v1/invoices")
+	InvoiceHandler(invoices, Invoice{
+		InvoiceManager: invoiceManager,
+		AdminFilter:    adminAuthenticator.AdminFilter(),
+	})
+	t.Run("should return 401 on pay without api key", func(t *testing.T) {
+		req := httptest.NewRequest(fiber.MethodPut, "/api/v1/invoices/7/payment", nil)
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusUnauthorized, res.StatusCode)
+	})
+
+	t.Run("should return 200 on pay", func(t *testing.T) {
+		invoiceManager.EXPECT().Pay(gomock.Any()).DoAndReturn(
+			func(inp *model.InvoicePaymentRequest) (*model.TransactionResponse, *model.BusinessError) {
+				assert.Equal(t, int64(7), inp.Id)
+				assert.Equal(t, "TRF/2023/02/0001", inp.Reference)
+				return &model.TransactionResponse{TransactionId: "TRX0012345678"}, nil
+			})
+		b, _ := json.Marshal(model.InvoicePaymentRequest{Reference: "TRF/2023/02/0001"})
+		req := httptest.NewRequest(fiber.MethodPut, "/api/v1/invoices/7/payment", bytes.NewBuffer(b))
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	})
+
+	t.Run("should return 400 on pay without reference", func(t *testing.T) {
+		b, _ := json.Marshal(model.InvoicePaymentRequest{})
+		req := httptest.NewRequest(fiber.MethodPut, "/api/v1/invoices/7/payment", bytes.NewBuffer(b))
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("should return 400 on pay a paid invoice", func(t *testing.T) {
+		invoiceManager.EXPECT().Pay(gomock.Any()).Return(nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeBussInvoicePaid,
+			ErrorMessage: apps.ErrMsgBussInvoicePaid,
+		})
+		b, _ := json.Marshal(model.InvoicePaymentRequest{Reference: "TRF/2023/02/0001"})
+		req := httptest.NewRequest(fiber.MethodPut, "/api/v1/invoices/7/payment", bytes.NewBuffer(b))
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		res, _ := api.Test(req, 100)
+		m := model.Response{}
+		_ = json.NewDecoder(res.Body).Decode(&m)
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+		assert.Equal(t, apps.ErrCodeBussInvoicePaid, m.Meta.Code)
+	})
+
+	t.Run("should return 404 on pay an unknown invoice", func(t *testing.T) {
+		invoiceManager.EXPECT().Pay(gomock.Any()).Return(nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeNotFound,
+			ErrorMessage: apps.ErrMsgNotFound,
+		})
+		b, _ := json.Marshal(model.InvoicePaymentRequest{Reference: "TRF/2023/02/0001"})
+		req := httptest.NewRequest(fiber.MethodPut, "/api/v1/invoices/8/payment", bytes.NewBuffer(b))
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusNotFound, res.StatusCode)
+	})
+
+	t.Run("should return 500 on failed to pay", func(t *testing.T) {
+		invoiceManager.EXPECT().Pay(gomock.Any()).Return(nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSubmitted,
+			ErrorMessage: apps.ErrMsgSubmitted,
+		})
+		b, _ := json.Marshal(model.InvoicePaymentRequest{Reference: "TRF/2023/02/0001"})
+		req := httptest.NewRequest(fiber.MethodPut, "/api/v1/invoices/7/payment", bytes.NewBuffer(b))
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
+	})
+}
+
+func TestPartnerInvoiceHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	ciamPartner := adaptor.NewMockCiamWatcher(ctrl)
+	cacher := storage.NewMockCacher(ctrl)
+	invoiceProvider := partner.NewMockInvoiceProvider(ctrl)
+	jwtAuthenticator := middleware.NewJwtAuthenticator(&middleware.JwtAuthenticator{
+		Logger:      logger,
+		CiamPartner: ciamPartner,
+		Cacher:      cacher,
+	})
+
+	api := fiber.New()
+	partnerInvoices := api.Group("/api/partner/v1/invoices")
+	PartnerInvoiceHandler(partnerInvoices, PartnerInvoice{
+		InvoiceProvider: invoiceProvider,
+		PartnerFilter:   jwtAuthenticator.PartnerFilter(),
+	})
+	jwtInfo := map[string]interface{}{
+		"email":            "someone@email.net",
+		"cognito:username": "someone",
+	}
+	session := func(role string) string {
+		c, _ := json.Marshal(model.OfficerValidationResponse{
+			Id:      int64(1),
+			Code:    "CORP_A",
+			Company: "Company A",
+			Email:   "someone@email.net",
+			Role:    role,
+		})
+		return string(c)
+	}
+	t.Run("should return 200 success to search invoice", func(t *testing.T) {
+		cacher.EXPECT().Get("B2BTOKEN", apps.Hash("*secret*")).Return("someone@email.net", nil)
+		cacher.EXPECT().Get("B2BSESSION", "someone@email.net").Return(session(apps.RoleOfficerFinance), nil)
+		ciamPartner.EXPECT().JwtInfo(gomock.Any()).Return(jwtInfo, nil)
+		invoiceProvider.EXPECT().Search(gomock.Any()).DoAndReturn(
+			func(inp *model.SearchRequest) (*model.InvoiceSearchResponse, *model.BusinessError) {
+				assert.Equal(t, int64(1), inp.SessionRequest.Id)
+				return &model.InvoiceSearchResponse{
+					Invoices: []model.InvoiceProjection{
+						{Id: 1, InvoiceNo: "INV/CORP_A/202301", Status: apps.InvoiceUnpaid},
+					},
+				}, nil
+			})
+		req := httptest.NewRequest(fiber.MethodGet, "/api/partner/v1/invoices?limit=5&start=0", nil)
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		req.Header.Add(fiber.HeaderAuthorization, "Bearer *secret*")
+		req.Header.Add(apps.HeaderClientChannel, apps.ChannelEBizKezbek)
+		req.Header.Add(apps.HeaderClientDeviceId, "f-123-456")
+		req.Header.Add(apps.HeaderClientOs, "Android 10")
+		req.Header.Add(apps.HeaderClientVersion, "1.0.0")
+		res, _ := api.Test(req, 100)
+		m := model.Response{}
+		_ = json.NewDecoder(res.Body).Decode(&m)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+		assert.NotNil(t, m.Data)
+	})
+
+	t.Run("should return 403 for viewer to search invoice", func(t *testing.T) {
+		cacher.EXPECT().Get("B2BTOKEN", apps.Hash("*secret*")).Return("someone@email.net", nil)
+		cacher.EXPECT().Get("B2BSESSION", "someone@email.net").Return(session(apps.RoleOfficerViewer), nil)
+		ciamPartner.EXPECT().JwtInfo(gomock.Any()).Return(jwtInfo, nil)
+		req := httptest.NewRequest(fiber.MethodGet, "/api/partner/v1/invoices?limit=5&start=0", nil)
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		req.Header.Add(fiber.HeaderAuthorization, "Bearer *secret*")
+		req.Header.Add(apps.HeaderClientChannel, apps.ChannelEBizKezbek)
+		req.Header.Add(apps.HeaderClientDeviceId, "f-123-456")
+		req.Header.Add(apps.HeaderClientOs, "Android 10")
+		req.Header.Add(apps.HeaderClientVersion, "1.0.0")
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusForbidden, res.StatusCode)
+	})
+
+	t.Run("should return 200 failed to search invoice", func(t *testing.T) {
+		cacher.EXPECT().Get("B2BTOKEN", apps.Hash("*secret*")).Return("someone@email.net", nil)
+		cacher.EXPECT().Get("B2BSESSION", "someone@email.net").Return(session(apps.RoleOfficerAdmin), nil)
+		ciamPartner.EXPECT().JwtInfo(gomock.Any()).Return(jwtInfo, nil)
+		invoiceProvider.EXPECT().Search(gomock.Any()).Return(nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeNotFound,
+			ErrorMessage: apps.ErrMsgNotFound,
+		})
+		req := httptest.NewRequest(fiber.MethodGet, "/api/partner/v1/invoices?limit=5&start=0", nil)
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		req.Header.Add(fiber.HeaderAuthorization, "Bearer *secret*")
+		req.Header.Add(apps.HeaderClientChannel, apps.ChannelEBizKezbek)
+		req.Header.Add(apps.HeaderClientDeviceId, "f-123-456")
+		req.Header.Add(apps.HeaderClientOs, "Android 10")
+		req.Header.Add(apps.HeaderClientVersion, "1.0.0")
+		res, _ := api.Test(req, 100)
+		m := model.Response{}
+		_ = json.NewDecoder(res.Body).Decode(&m)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+		assert.Nil(t, m.Data)
+		assert.Equal(t, apps.ErrCodeNotFound, m.Meta.Code)
+	})
+}
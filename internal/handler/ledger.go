@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/internal/usecase/management"
+	"github.com/gofiber/fiber/v2"
+	"strconv"
+	"strings"
+)
+
+type Ledger struct {
+	management.LedgerManager
+	AdminFilter fiber.Handler
+}
+
+func newLedger(l Ledger) *Ledger {
+	return &l
+}
+
+func LedgerHandler(router fiber.Router, l Ledger) {
+	handler := newLedger(l)
+	router.Use(l.AdminFilter)
+	router.Get("/accounts/:type/:code/balance", handler.balance)
+	router.Get("/accounts/:type/:code/statement", handler.statement)
+	router.Post("/journals/:id/reversal", handler.reverse)
+}
+
+func ledgerRequest(ctx *fiber.Ctx) (*model.LedgerRequest, error) {
+	inp := model.LedgerRequest{}
+	if err := ctx.QueryParser(&inp); err != nil {
+		return nil, err
+	}
+	inp.AccountType = strings.ToUpper(ctx.Params("type"))
+	inp.AccountCode = ctx.Params("code")
+	return &inp, nil
+}
+
+// @Tags Ledger Management APIs
+// API Ledger Balance
+// @Summary API Ledger Balance
+// @Description API to get the balance of a ledger account within a period, the balance is on the debit side for the receivable and prefund accounts and on the credit side for the fee and payable accounts
+// @Schemes
+// @Accept json
+// @Param x-api-key header string true "Back Office API Key"
+// @Param x-client-channel header string true "Client Channel" Enums(EBIZKEZBEK, B2BCLIENT)
+// @Param x-client-os  header string true "Client OS or Browser Agent" default(android 10)
+// @Param x-client-device  header string true "Client Device ID"
+// @Param x-client-version  header string true "Client Platform Version" default(1.0.0)
+// @Param x-client-timestamp  header string false "Client Original Timestamp in UNIX format (EPOCH)"
+// @Param type path string true "Account Type" Enums(PARTNER_RECEIVABLE, PROVIDER_PREFUND, PROVIDER_FEE, CUSTOMER_CASHBACK_PAYABLE)
+// @Param code path string true "Account Code (partner code, H2H provider code or customer MSISDN)" default(LSAJA)
+// @Param start_date query string true "Start Date" default(2023-01-01)
+// @Param end_date query string true "End Date" default(2023-01-31)
+// @Success 200 {object} model.LedgerBalanceResponse
+// @Failure 400 {object} model.Meta
+// @Failure 401 {object} model.Meta
+// @Failure 500 {object} model.Meta
+// @Router /v1/ledgers/accounts/{type}/{code}/balance [get]
+func (l *Ledger) balance(ctx *fiber.Ctx) error {
+	inp, err := ledgerRequest(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	bad := apps.ValidateStruct(checker.Struct(inp))
+	if bad != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(bad)
+	}
+	v, ex := l.Balance(inp)
+	if ex != nil && ex.ErrorCode == apps.ErrCodeBussPeriodInvalid {
+		return ctx.Status(fiber.StatusBadRequest).JSON(apps.BusinessErrorResponse(ex))
+	}
+	if ex != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(apps.BusinessErrorResponse(ex))
+	}
+	return ctx.JSON(apps.DefaultSuccessResponse(apps.SuccessMsgDataFound, v))
+}
+
+// @Tags Ledger Management APIs
+// API Ledger Statement
+// @Summary API Ledger Statement
+// @Description API to list the entries of a ledger account within a period in the posting order along with their running balance
+// @Schemes
+// @Accept json
+// @Param x-api-key header string true "Back Office API Key"
+// @Param x-client-channel header string true "Client Channel" Enums(EBIZKEZBEK, B2BCLIENT)
+// @Param x-client-os  header string true "Client OS or Browser Agent" default(android 10)
+// @Param x-client-device  header string true "Client Device ID"
+// @Param x-client-version  header string true "Client Platform Version" default(1.0.0)
+// @Param x-client-timestamp  header string false "Client Original Timestamp in UNIX format (EPOCH)"
+// @Param type path string true "Account Type" Enums(PARTNER_RECEIVABLE, PROVIDER_PREFUND, PROVIDER_FEE, CUSTOMER_CASHBACK_PAYABLE)
+// @Param code path string true "Account Code (partner code, H2H provider code or customer MSISDN)" default(LSAJA)
+// @Param Payload query model.LedgerRequest true "Statement Payload"
+// @Success 200 {object} model.LedgerStatementResponse
+// @Failure 400 {object} model.Meta
+// @Failure 401 {object} model.Meta
+// @Failure 500 {object} model.Meta
+// @Router /v1/ledgers/accounts/{type}/{code}/statement [get]
+func (l *Ledger) statement(ctx *fiber.Ctx) error {
+	inp, err := ledgerRequest(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	bad := apps.ValidateStruct(checker.Struct(inp))
+	if bad != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(bad)
+	}
+	v, ex := l.Statement(inp)
+	if ex != nil && ex.ErrorCode == apps.ErrCodeBussPeriodInvalid {
+		return ctx.Status(fiber.StatusBadRequest).JSON(apps.BusinessErrorResponse(ex))
+	}
+	if ex != nil && ex.ErrorCode == apps.ErrCodeNotFound {
+		return ctx.Status(fiber.StatusOK).
+			JSON(apps.BusinessErrorResponse(ex))
+	}
+	if ex != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(apps.BusinessErrorResponse(ex))
+	}
+	return ctx.JSON(apps.DefaultSuccessResponse(apps.SuccessMsgDataFound, v))
+}
+
+// @Tags Ledger Management APIs
+// API Reverse Journal
+// @Summary API Reverse Journal
+// @Description API to reverse a posted journal by posting its mirror entries, a journal is reversed only once
+// @Schemes
+// @Accept json
+// @Param x-api-key header string true "Back Office API Key"
+// @Param x-client-channel header string true "Client Channel" Enums(EBIZKEZBEK, B2BCLIENT)
+// @Param x-client-os  header string true "Client OS or Browser Agent" default(android 10)
+// @Param x-client-device  header string true "Client Device ID"
+// @Param x-client-version  header string true "Client Platform Version" default(1.0.0)
+// @Param x-client-timestamp  header string false "Client Original Timestamp in UNIX format (EPOCH)"
+// @Param id path int true "Journal ID"
+// @Param Payload body model.LedgerReversalRequest true "Reversal Payload"
+// @Success 200 {object} model.TransactionResponse
+// @Failure 400 {object} model.Meta
+// @Failure 401 {object} model.Meta
+// @Failure 404 {object} model.Meta
+// @Failure 500 {object} model.Meta
+// @Router /v1/ledgers/journals/{id}/reversal [post]
+func (l *Ledger) reverse(ctx *fiber.Ctx) error {
+	inp := model.LedgerReversalRequest{}
+	if err := ctx.BodyParser(&inp); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	inp.Id, _ = strconv.ParseInt(ctx.Params("id"), 10, 64)
+	bad := apps.ValidateStruct(checker.Struct(inp))
+	if bad != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(bad)
+	}
+	v, ex := l.Reverse(&inp)
+	if ex != nil && ex.ErrorCode == apps.ErrCodeNotFound {
+		return ctx.Status(fiber.StatusNotFound).JSON(apps.BusinessErrorResponse(ex))
+	}
+	if ex != nil && ex.ErrorCode == apps.ErrCodeBussJournalReversed {
+		return ctx.Status(fiber.StatusBadRequest).JSON(apps.BusinessErrorResponse(ex))
+	}
+	if ex != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(apps.BusinessErrorResponse(ex))
+	}
+	return ctx.JSON(apps.DefaultSuccessResponse(apps.SuccessMsgSubmit, v))
+}
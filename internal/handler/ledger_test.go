@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/handler/middleware"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/mock/usecase/management"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLedgerHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ledgerManager := management.NewMockLedgerManager(ctrl)
+
+	logger, _ := apps.NewLog(false)
+	adminAuthenticator := middleware.NewAdminAuthenticator(&middleware.AdminAuthenticator{
+		Logger: logger,
+		ApiKey: "b4ck0ff1c3",
+	})
+
+	api := fiber.New()
+	ledgers := api.Group("/api/v1/ledgers")
+	LedgerHandler(ledgers, Ledger{
+		LedgerManager: ledgerManager,
+		AdminFilter:   adminAuthenticator.AdminFilter(),
+	})
+	t.Run("should return 401 on reversal without api key", func(t *testing.T) {
+		req := httptest.NewRequest(fiber.MethodPost, "/api/v1/ledgers/journals/1/reversal", nil)
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusUnauthorized, res.StatusCode)
+	})
+
+	t.Run("should return 200 on balance", func(t *testing.T) {
+		ledgerManager.EXPECT().Balance(gomock.Any()).DoAndReturn(
+			func(inp *model.LedgerRequest) (*model.LedgerBalanceResponse, *model.BusinessError) {
+				assert.Equal(t, apps.LedgerPartnerReceivable, inp.AccountType)
+				assert.Equal(t, "LSAJA", inp.AccountCode)
+				assert.Equal(t, "2023-01-01", inp.StartDate)
+				return &model.LedgerBalanceResponse{Closing: decimal.NewFromInt(16000)}, nil
+			})
+		req := httptest.NewRequest(fiber.MethodGet,
+			"/api/v1/ledgers/accounts/partner_receivable/LSAJA/balance?start_date=2023-01-01&end_date=2023-01-31", nil)
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	})
+
+	t.Run("should return 400 on balance of unknown account type", func(t *testing.T) {
+		req := httptest.NewRequest(fiber.MethodGet,
+			"/api/v1/ledgers/accounts/revenue/LSAJA/balance?start_date=2023-01-01&end_date=2023-01-31", nil)
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("should return 400 on balance without period", func(t *testing.T) {
+		req := httptest.NewRequest(fiber.MethodGet, "/api/v1/ledgers/accounts/partner_receivable/LSAJA/balance", nil)
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("should return 400 on balance of invalid period", func(t *testing.T) {
+		ledgerManager.EXPECT().Balance(gomock.Any()).Return(nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeBussPeriodInvalid,
+			ErrorMessage: apps.ErrMsgBussPeriodInvalid,
+		})
+		req := httptest.NewRequest(fiber.MethodGet,
+			"/api/v1/ledgers/accounts/partner_receivable/LSAJA/balance?start_date=2023-02-01&end_date=2023-01-31", nil)
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("should return 500 on failed to find balance", func(t *testing.T) {
+		ledgerManager.EXPECT().Balance(gomock.Any()).Return(nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSomethingWrong,
+			ErrorMessage: apps.ErrMsgSomethingWrong,
+		})
+		req := httptest.NewRequest(fiber.MethodGet,
+			"/api/v1/ledgers/accounts/partner_receivable/LSAJA/balance?start_date=2023-01-01&end_date=2023-01-31", nil)
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
+	})
+
+	t.Run("should return 200 on statement", func(t *testing.T) {
+		ledgerManager.EXPECT().Statement(gomock.Any()).DoAndReturn(
+			func(inp *model.LedgerRequest) (*model.LedgerStatementResponse, *model.BusinessError) {
+				assert.Equal(t, apps.LedgerCustomerPayable, inp.AccountType)
+				assert.Equal(t, 5, inp.Limit)
+				return &model.LedgerStatementResponse{
+					Entries: []model.LedgerEntryProjection{{Id: 1}},
+				}, nil
+			})
+		req := httptest.NewRequest(fiber.MethodGet, "/api/v1/ledgers/accounts/CUSTOMER_CASHBACK_PAYABLE/628123456789/"+
+			"statement?start_date=2023-01-01&end_date=2023-01-31&limit=5&start=0", nil)
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		res, _ := api.Test(req, 100)
+		m := model.Response{}
+		_ = json.NewDecoder(res.Body).Decode(&m)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+		assert.NotNil(t, m.Data)
+	})
+
+	t.Run("should return 200 failed to find statement", func(t *testing.T) {
+		ledgerManager.EXPECT().Statement(gomock.Any()).Return(nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeNotFound,
+			ErrorMessage: apps.ErrMsgNotFound,
+		})
+		req := httptest.NewRequest(fiber.MethodGet, "/api/v1/ledgers/accounts/CUSTOMER_CASHBACK_PAYABLE/628123456789/"+
+			"statement?start_date=2023-01-01&end_date=2023-01-31", nil)
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		res, _ := api.Test(req, 100)
+		m := model.Response{}
+		_ = json.NewDecoder(res.Body).Decode(&m)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+		assert.Nil(t, m.Data)
+		assert.Equal(t, apps.ErrCodeNotFound, m.Meta.Code)
+	})
+
+	t.Run("should return 200 on reverse", func(t *testing.T) {
+		ledgerManager.EXPECT().Reverse(gomock.Any()).DoAndReturn(
+			func(inp *model.LedgerReversalRequest) (*model.TransactionResponse, *model.BusinessError) {
+				assert.Equal(t, int64(7), inp.Id)
+				return &model.TransactionResponse{TransactionId: "TRX0012345678"}, nil
+			})
+		b, _ := json.Marshal(model.LedgerReversalRequest{Description: "Cashback was disbursed twice"})
+		req := httptest.NewRequest(fiber.MethodPost, "/api/v1/ledgers/journals/7/reversal", bytes.NewBuffer(b))
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	})
+
+	t.Run("should return 400 on reverse without description", func(t *testing.T) {
+		b, _ := json.Marshal(model.LedgerReversalRequest{})
+		req := httptest.NewRequest(fiber.MethodPost, "/api/v1/ledgers/journals/7/reversal", bytes.NewBuffer(b))
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("should return 400 on reverse a reversed journal", func(t *testing.T) {
+		ledgerManager.EXPECT().Reverse(gomock.Any()).Return(nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeBussJournalReversed,
+			ErrorMessage: apps.ErrMsgBussJournalReversed,
+		})
+		b, _ := json.Marshal(model.LedgerReversalRequest{Description: "Cashback was disbursed twice"})
+		req := httptest.NewRequest(fiber.MethodPost, "/api/v1/ledgers/journals/7/reversal", bytes.NewBuffer(b))
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		res, _ := api.Test(req, 100)
+		m := model.Response{}
+		_ = json.NewDecoder(res.Body).Decode(&m)
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+		assert.Equal(t, apps.ErrCodeBussJournalReversed, m.Meta.Code)
+	})
+
+	t.Run("should return 404 on reverse an unknown journal", func(t *testing.T) {
+		ledgerManager.EXPECT().Reverse(gomock.Any()).Return(nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeNotFound,
+			ErrorMessage: apps.ErrMsgNotFound,
+		})
+		b, _ := json.Marshal(model.LedgerReversalRequest{Description: "Cashback was disbursed twice"})
+		req := httptest.NewRequest(fiber.MethodPost, "/api/v1/ledgers/journals/8/reversal", bytes.NewBuffer(b))
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusNotFound, res.StatusCode)
+	})
+
+	t.Run("should return 500 on failed to reverse", func(t *testing.T) {
+		ledgerManager.EXPECT().Reverse(gomock.Any()).Return(nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSubmitted,
+			ErrorMessage: apps.ErrMsgSubmitted,
+		})
+		b, _ := json.Marshal(model.LedgerReversalRequest{Description: "Cashback was disbursed twice"})
+		req := httptest.NewRequest(fiber.MethodPost, "/api/v1/ledgers/journals/7/reversal", bytes.NewBuffer(b))
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
+	})
+}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"crypto/subtle"
 	"encoding/json"
 	"fmt"
 	"github.com/adinandradrs/cezbek-engine/internal/adaptor"
@@ -23,6 +24,11 @@ type JwtAuthenticator struct {
 	CiamPartner adaptor.CiamWatcher
 }
 
+type AdminAuthenticator struct {
+	Logger *zap.Logger
+	ApiKey string
+}
+
 func NewPreAuthenticator(a *PreAuthenticator) PreAuthenticator {
 	return *a
 }
@@ -31,23 +37,47 @@ func NewJwtAuthenticator(a *JwtAuthenticator) JwtAuthenticator {
 	return *a
 }
 
+func NewAdminAuthenticator(a *AdminAuthenticator) AdminAuthenticator {
+	if a.ApiKey == "" {
+		a.Logger.Panic("back office api key is not configured")
+	}
+	return *a
+}
+
 func (a *JwtAuthenticator) forwardClientSession(v string, ctx *fiber.Ctx) (res model.ClientAuthenticationResponse) {
 	_ = json.Unmarshal([]byte(v), &res)
 	ctx.Request().Header.Add(apps.HeaderSessionId, strconv.FormatInt(*res.Id, 10))
 	ctx.Request().Header.Add(apps.HeaderSessionUsername, res.Code)
 	ctx.Request().Header.Add(apps.HeaderSessionFullname, res.Company)
 	ctx.Request().Header.Add(apps.HeaderSessionRole, "B2BCLIENT")
+	ctx.Request().Header.Set(apps.HeaderSessionLocale, res.Locale)
+	return res
+}
+
+func (a *JwtAuthenticator) forwardPartnerSession(v string, ctx *fiber.Ctx) (res model.OfficerValidationResponse) {
+	_ = json.Unmarshal([]byte(v), &res)
+	ctx.Request().Header.Set(apps.HeaderSessionId, strconv.FormatInt(res.Id, 10))
+	ctx.Request().Header.Set(apps.HeaderSessionUsername, res.Code)
+	ctx.Request().Header.Set(apps.HeaderSessionFullname, res.Company)
+	ctx.Request().Header.Set(apps.HeaderSessionEmail, res.Email)
+	ctx.Request().Header.Set(apps.HeaderSessionRole, res.Role)
+	ctx.Request().Header.Set(apps.HeaderSessionLocale, res.Locale)
 	return res
 }
 
 func ClientSession(ctx *fiber.Ctx) model.SessionRequest {
 	id, _ := strconv.ParseInt(ctx.Get(apps.HeaderSessionId), 10, 64)
+	exp, _ := strconv.ParseInt(ctx.Get(apps.HeaderSessionTokenExpiry), 10, 64)
 	return model.SessionRequest{
-		Username: ctx.Get(apps.HeaderSessionUsername),
-		Email:    ctx.Get(apps.HeaderSessionEmail),
-		Msisdn:   ctx.Get(apps.HeaderSessionMsisdn),
-		Fullname: ctx.Get(apps.HeaderSessionFullname),
-		Id:       id,
+		Username:    ctx.Get(apps.HeaderSessionUsername),
+		Email:       ctx.Get(apps.HeaderSessionEmail),
+		Msisdn:      ctx.Get(apps.HeaderSessionMsisdn),
+		Fullname:    ctx.Get(apps.HeaderSessionFullname),
+		Role:        ctx.Get(apps.HeaderSessionRole),
+		Locale:      ctx.Get(apps.HeaderSessionLocale),
+		TokenId:     ctx.Get(apps.HeaderSessionTokenId),
+		TokenExpiry: exp,
+		Id:          id,
 		ContextRequest: model.ContextRequest{
 			Channel:       ctx.Get(apps.HeaderClientChannel),
 			DeviceId:      ctx.Get(apps.HeaderClientDeviceId),
@@ -86,14 +116,35 @@ func (a *JwtAuthenticator) jwtFilter(ctx *fiber.Ctx) error {
 			},
 		})
 	}
+	if a.revoked(res) {
+		a.Logger.Error("the given jwt has been revoked", zap.Any("jti", res["jti"]))
+		return ctx.Status(fiber.StatusUnauthorized).JSON(model.Response{
+			Meta: model.Meta{
+				Code:    apps.ErrCodeUnauthorized,
+				Message: apps.ErrMsgUnauthorized,
+			},
+		})
+	}
 	var v string
-	var uname string
-	if ctx.Get(apps.HeaderClientChannel) != apps.ChannelB2BClient {
-		uname = strings.ToLower(res["email"].(string))
-		v, ex = a.Cacher.Get("B2BSESSION", uname)
+	b2b := ctx.Get(apps.HeaderClientChannel) != apps.ChannelB2BClient
+	if b2b {
+		var uname string
+		uname, ex = a.Cacher.Get("B2BTOKEN", apps.Hash(jwt))
+		if ex == nil {
+			v, ex = a.Cacher.Get("B2BSESSION", uname)
+		}
 	} else {
-		uname = strings.ToUpper(res["cognito:username"].(string))
-		v, ex = a.Cacher.Get("CLIENTSESSION", uname)
+		uname, ok := res["cognito:username"].(string)
+		if !ok {
+			a.Logger.Error("the given jwt has no username")
+			return ctx.Status(fiber.StatusUnauthorized).JSON(model.Response{
+				Meta: model.Meta{
+					Code:    apps.ErrCodeUnauthorized,
+					Message: apps.ErrMsgUnauthorized,
+				},
+			})
+		}
+		v, ex = a.Cacher.Get("CLIENTSESSION", strings.ToUpper(uname))
 	}
 
 	if ex != nil {
@@ -105,10 +156,35 @@ func (a *JwtAuthenticator) jwtFilter(ctx *fiber.Ctx) error {
 			},
 		})
 	}
-	a.forwardClientSession(v, ctx)
+	if b2b {
+		a.forwardPartnerSession(v, ctx)
+	} else {
+		a.forwardClientSession(v, ctx)
+	}
+	a.forwardToken(res, ctx)
 	return ctx.Next()
 }
 
+func (a *JwtAuthenticator) revoked(claims map[string]interface{}) bool {
+	jti, ok := claims["jti"].(string)
+	if !ok {
+		return false
+	}
+	ttl, _ := a.Cacher.Ttl("JWTDENY", jti)
+	return ttl.Seconds() > 0
+}
+
+func (a *JwtAuthenticator) forwardToken(claims map[string]interface{}, ctx *fiber.Ctx) {
+	ctx.Request().Header.Del(apps.HeaderSessionTokenId)
+	ctx.Request().Header.Del(apps.HeaderSessionTokenExpiry)
+	if jti, ok := claims["jti"].(string); ok {
+		ctx.Request().Header.Set(apps.HeaderSessionTokenId, jti)
+	}
+	if exp, ok := claims["exp"].(float64); ok {
+		ctx.Request().Header.Set(apps.HeaderSessionTokenExpiry, strconv.FormatInt(int64(exp), 10))
+	}
+}
+
 func (a *JwtAuthenticator) PartnerFilter() fiber.Handler {
 	return func(ctx *fiber.Ctx) error {
 		err := validatePartnerChannel(ctx)
@@ -139,6 +215,20 @@ func (a *JwtAuthenticator) ClientFilter() fiber.Handler {
 	}
 }
 
+func PartnerRoleFilter(roles ...string) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		if !apps.StringExists(ctx.Get(apps.HeaderSessionRole), roles) {
+			return ctx.Status(fiber.StatusForbidden).JSON(model.Response{
+				Meta: model.Meta{
+					Code:    apps.ErrCodeForbidden,
+					Message: apps.ErrMsgForbidden,
+				},
+			})
+		}
+		return ctx.Next()
+	}
+}
+
 func validateClientChannel(ctx *fiber.Ctx) (err error) {
 	if ctx.Get(apps.HeaderClientChannel) != apps.ChannelB2BClient {
 		return fmt.Errorf("invalid channel")
@@ -187,3 +277,18 @@ func (a *PreAuthenticator) ClientFilter() fiber.Handler {
 		return ctx.Next()
 	}
 }
+
+func (a *AdminAuthenticator) AdminFilter() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		if subtle.ConstantTimeCompare([]byte(ctx.Get(apps.HeaderApiKey)), []byte(a.ApiKey)) != 1 {
+			a.Logger.Error("the given back office api key is not recognized")
+			return ctx.Status(fiber.StatusUnauthorized).JSON(model.Response{
+				Meta: model.Meta{
+					Code:    apps.ErrCodeUnauthorized,
+					Message: apps.ErrMsgUnauthorized,
+				},
+			})
+		}
+		return ctx.Next()
+	}
+}
@@ -0,0 +1,185 @@
+package handler
+
+import (
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/internal/usecase/notification"
+	"github.com/gofiber/fiber/v2"
+	"strconv"
+)
+
+type Notification struct {
+	notification.LogProvider
+	AdminFilter fiber.Handler
+}
+
+func newNotification(n Notification) *Notification {
+	return &n
+}
+
+func NotificationHandler(router fiber.Router, n Notification) {
+	handler := newNotification(n)
+	router.Use(n.AdminFilter)
+	router.Get("/", handler.search)
+	router.Post("/resend", handler.resend)
+	router.Post("/:id/resend", handler.resendById)
+}
+
+// @Tags Notification Management APIs
+// API Notification Log Search
+// @Summary API Notification Log Search
+// @Description API to search notification delivery logs, filtered by status and destination or subject
+// @Schemes
+// @Accept json
+// @Param x-api-key header string true "Back Office API Key"
+// @Param Payload query model.NotificationSearchRequest true "Search Payload"
+// @Success 200 {object} model.NotificationSearchResponse
+// @Failure 400 {object} model.Meta
+// @Failure 401 {object} model.Meta
+// @Failure 500 {object} model.Meta
+// @Router /v1/notifications [get]
+func (n *Notification) search(ctx *fiber.Ctx) error {
+	inp := model.NotificationSearchRequest{}
+	if err := ctx.QueryParser(&inp); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	v, ex := n.Search(&inp)
+	if ex != nil && ex.ErrorCode == apps.ErrCodeNotFound {
+		return ctx.Status(fiber.StatusOK).
+			JSON(apps.BusinessErrorResponse(ex))
+	}
+	return ctx.JSON(apps.DefaultSuccessResponse(apps.SuccessMsgDataFound, v))
+}
+
+// @Tags Notification Management APIs
+// API Notification Bulk Resend
+// @Summary API Notification Bulk Resend
+// @Description API to resend failed or dead notifications by their ids or by status
+// @Schemes
+// @Accept json
+// @Param x-api-key header string true "Back Office API Key"
+// @Param request body model.NotificationResendRequest true "Notification Resend Payload"
+// @Success 200 {object} model.NotificationResendResponse
+// @Failure 400 {object} model.Meta
+// @Failure 401 {object} model.Meta
+// @Failure 404 {object} model.Meta
+// @Failure 500 {object} model.Meta
+// @Router /v1/notifications/resend [post]
+func (n *Notification) resend(ctx *fiber.Ctx) error {
+	inp := model.NotificationResendRequest{}
+	if err := ctx.BodyParser(&inp); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	bad := apps.ValidateStruct(checker.Struct(inp))
+	if bad != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(bad)
+	}
+	return n.doResend(ctx, &inp)
+}
+
+// @Tags Notification Management APIs
+// API Notification Resend
+// @Summary API Notification Resend
+// @Description API to resend a single failed or dead notification
+// @Schemes
+// @Accept json
+// @Param x-api-key header string true "Back Office API Key"
+// @Param id path int true "Notification Log ID"
+// @Success 200 {object} model.NotificationResendResponse
+// @Failure 400 {object} model.Meta
+// @Failure 401 {object} model.Meta
+// @Failure 404 {object} model.Meta
+// @Failure 500 {object} model.Meta
+// @Router /v1/notifications/{id}/resend [post]
+func (n *Notification) resendById(ctx *fiber.Ctx) error {
+	id, err := strconv.ParseInt(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).
+			JSON(apps.BusinessErrorResponse(&model.BusinessError{
+				ErrorCode:    apps.ErrCodeBadPayload,
+				ErrorMessage: apps.ErrMsgBadPayload,
+			}))
+	}
+	return n.doResend(ctx, &model.NotificationResendRequest{Ids: []int64{id}})
+}
+
+func (n *Notification) doResend(ctx *fiber.Ctx, inp *model.NotificationResendRequest) error {
+	v, ex := n.Resend(inp)
+	if ex != nil && ex.ErrorCode == apps.ErrCodeNotFound {
+		return ctx.Status(fiber.StatusNotFound).JSON(apps.BusinessErrorResponse(ex))
+	}
+	if ex != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(apps.BusinessErrorResponse(ex))
+	}
+	return ctx.JSON(apps.DefaultSuccessResponse(apps.SuccessMsgSubmit, v))
+}
+
+type Unsubscribe struct {
+	notification.PreferenceProvider
+}
+
+func newUnsubscribe(u Unsubscribe) *Unsubscribe {
+	return &u
+}
+
+func UnsubscribeHandler(router fiber.Router, u Unsubscribe) {
+	handler := newUnsubscribe(u)
+	router.Get("/", handler.confirm)
+	router.Post("/", handler.unsubscribe)
+}
+
+// @Tags Notification Preference APIs
+// API Notification Unsubscribe Confirmation
+// @Summary API Notification Unsubscribe Confirmation
+// @Description API to confirm the notification category of the signed token of the unsubscribe link before the customer opts out, the customer is not opted out yet
+// @Schemes
+// @Accept json
+// @Param token query string true "Unsubscribe Token"
+// @Success 200 {object} model.UnsubscribeConfirmationResponse
+// @Failure 400 {object} model.Meta
+// @Router /v1/unsubscribe [get]
+func (u *Unsubscribe) confirm(ctx *fiber.Ctx) error {
+	inp := model.UnsubscribeRequest{}
+	if err := ctx.QueryParser(&inp); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	bad := apps.ValidateStruct(checker.Struct(inp))
+	if bad != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(bad)
+	}
+	v, ex := u.Confirm(&inp)
+	if ex != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(apps.BusinessErrorResponse(ex))
+	}
+	return ctx.JSON(apps.DefaultSuccessResponse(apps.SuccessMsgDataFound, v))
+}
+
+// @Tags Notification Preference APIs
+// API Notification Unsubscribe
+// @Summary API Notification Unsubscribe
+// @Description API to opt-out a customer from a notification category by the signed token of the unsubscribe link, it is called once the customer confirmed or by one-click unsubscribe
+// @Schemes
+// @Accept json
+// @Param token query string true "Unsubscribe Token"
+// @Success 200 {object} model.UnsubscribeResponse
+// @Failure 400 {object} model.Meta
+// @Failure 500 {object} model.Meta
+// @Router /v1/unsubscribe [post]
+func (u *Unsubscribe) unsubscribe(ctx *fiber.Ctx) error {
+	inp := model.UnsubscribeRequest{}
+	if err := ctx.QueryParser(&inp); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	bad := apps.ValidateStruct(checker.Struct(inp))
+	if bad != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(bad)
+	}
+	v, ex := u.Unsubscribe(&inp)
+	if ex != nil && ex.ErrorCode == apps.ErrCodeBussUnsubscribeInvalid {
+		return ctx.Status(fiber.StatusBadRequest).JSON(apps.BusinessErrorResponse(ex))
+	}
+	if ex != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(apps.BusinessErrorResponse(ex))
+	}
+	return ctx.JSON(apps.DefaultSuccessResponse(apps.SuccessMsgSubmit, v))
+}
@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/handler/middleware"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/mock/usecase/notification"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNotificationHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logProvider := notification.NewMockLogProvider(ctrl)
+
+	logger, _ := apps.NewLog(false)
+	adminAuthenticator := middleware.NewAdminAuthenticator(&middleware.AdminAuthenticator{
+		Logger: logger,
+		ApiKey: "b4ck0ff1c3",
+	})
+
+	api := fiber.New()
+	notifications := api.Group("/api/v1/notifications")
+	NotificationHandler(notifications, Notification{
+		LogProvider: logProvider,
+		AdminFilter: adminAuthenticator.AdminFilter(),
+	})
+	t.Run("should return 401 without api key", func(t *testing.T) {
+		req := httptest.NewRequest(fiber.MethodGet, "/api/v1/notifications?status=DEAD", nil)
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusUnauthorized, res.StatusCode)
+	})
+
+	t.Run("should return 200 on search", func(t *testing.T) {
+		logProvider.EXPECT().Search(gomock.Any()).DoAndReturn(
+			func(inp *model.NotificationSearchRequest) (*model.NotificationSearchResponse, *model.BusinessError) {
+				assert.Equal(t, apps.NotificationDead, inp.Status)
+				assert.Equal(t, 5, inp.Limit)
+				return &model.NotificationSearchResponse{
+					Notifications: []model.NotificationLogProjection{{Id: 1}},
+				}, nil
+			})
+		req := httptest.NewRequest(fiber.MethodGet, "/api/v1/notifications?status=DEAD&start=0&limit=5", nil)
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	})
+
+	t.Run("should return 200 on bulk resend", func(t *testing.T) {
+		inp := model.NotificationResendRequest{Status: apps.NotificationDead, Limit: 10}
+		logProvider.EXPECT().Resend(&inp).Return(&model.NotificationResendResponse{Total: 3}, nil)
+		b, _ := json.Marshal(inp)
+		req := httptest.NewRequest(fiber.MethodPost, "/api/v1/notifications/resend", bytes.NewBuffer(b))
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	})
+
+	t.Run("should return 400 on bulk resend without ids and status", func(t *testing.T) {
+		b, _ := json.Marshal(model.NotificationResendRequest{})
+		req := httptest.NewRequest(fiber.MethodPost, "/api/v1/notifications/resend", bytes.NewBuffer(b))
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("should return 200 on resend by id", func(t *testing.T) {
+		logProvider.EXPECT().Resend(&model.NotificationResendRequest{Ids: []int64{7}}).
+			Return(&model.NotificationResendResponse{Total: 1}, nil)
+		req := httptest.NewRequest(fiber.MethodPost, "/api/v1/notifications/7/resend", nil)
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	})
+
+	t.Run("should return 400 on resend by malformed id", func(t *testing.T) {
+		req := httptest.NewRequest(fiber.MethodPost, "/api/v1/notifications/abc/resend", nil)
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		res, _ := api.Test(req, 100)
+		m := model.Response{}
+		_ = json.NewDecoder(res.Body).Decode(&m)
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+		assert.Equal(t, apps.ErrCodeBadPayload, m.Meta.Code)
+	})
+
+	t.Run("should return 404 on resend by unknown id", func(t *testing.T) {
+		logProvider.EXPECT().Resend(&model.NotificationResendRequest{Ids: []int64{8}}).
+			Return(nil, &model.BusinessError{
+				ErrorCode:    apps.ErrCodeNotFound,
+				ErrorMessage: apps.ErrMsgNotFound,
+			})
+		req := httptest.NewRequest(fiber.MethodPost, "/api/v1/notifications/8/resend", nil)
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusNotFound, res.StatusCode)
+	})
+
+	t.Run("should return 500 on failed to queue", func(t *testing.T) {
+		logProvider.EXPECT().Resend(&model.NotificationResendRequest{Ids: []int64{9}}).
+			Return(nil, &model.BusinessError{
+				ErrorCode:    apps.ErrCodeSubmitted,
+				ErrorMessage: apps.ErrMsgSubmitted,
+			})
+		req := httptest.NewRequest(fiber.MethodPost, "/api/v1/notifications/9/resend", nil)
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
+	})
+}
+
+func TestUnsubscribeHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	preferenceProvider := notification.NewMockPreferenceProvider(ctrl)
+
+	api := fiber.New()
+	unsubscribe := api.Group("/api/v1/unsubscribe")
+	UnsubscribeHandler(unsubscribe, Unsubscribe{
+		PreferenceProvider: preferenceProvider,
+	})
+	t.Run("should return 200 on confirming unsubscribe link", func(t *testing.T) {
+		preferenceProvider.EXPECT().Confirm(&model.UnsubscribeRequest{Token: "abc.def"}).
+			Return(&model.UnsubscribeConfirmationResponse{Category: apps.NotificationCategoryTransactional,
+				Token: "abc.def"}, nil)
+		req := httptest.NewRequest(fiber.MethodGet, "/api/v1/unsubscribe?token=abc.def", nil)
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	})
+
+	t.Run("should return 200 on one-click unsubscribe", func(t *testing.T) {
+		preferenceProvider.EXPECT().Unsubscribe(&model.UnsubscribeRequest{Token: "abc.def"}).
+			Return(&model.UnsubscribeResponse{Category: apps.NotificationCategoryTransactional}, nil)
+		req := httptest.NewRequest(fiber.MethodPost, "/api/v1/unsubscribe?token=abc.def", nil)
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	})
+
+	t.Run("should return 400 on confirming invalid token", func(t *testing.T) {
+		preferenceProvider.EXPECT().Confirm(&model.UnsubscribeRequest{Token: "tampered"}).
+			Return(nil, &model.BusinessError{
+				ErrorCode:    apps.ErrCodeBussUnsubscribeInvalid,
+				ErrorMessage: apps.ErrMsgBussUnsubscribeInvalid,
+			})
+		req := httptest.NewRequest(fiber.MethodGet, "/api/v1/unsubscribe?token=tampered", nil)
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("should return 400 without token", func(t *testing.T) {
+		req := httptest.NewRequest(fiber.MethodGet, "/api/v1/unsubscribe", nil)
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("should return 400 on invalid token", func(t *testing.T) {
+		preferenceProvider.EXPECT().Unsubscribe(&model.UnsubscribeRequest{Token: "tampered"}).
+			Return(nil, &model.BusinessError{
+				ErrorCode:    apps.ErrCodeBussUnsubscribeInvalid,
+				ErrorMessage: apps.ErrMsgBussUnsubscribeInvalid,
+			})
+		req := httptest.NewRequest(fiber.MethodPost, "/api/v1/unsubscribe?token=tampered", nil)
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+	})
+}
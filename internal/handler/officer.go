@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/handler/middleware"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/internal/usecase/partner"
+	"github.com/gofiber/fiber/v2"
+	"strconv"
+)
+
+type PartnerOfficer struct {
+	partner.OfficerProvider
+	PartnerFilter fiber.Handler
+}
+
+func newPartnerOfficer(po PartnerOfficer) *PartnerOfficer {
+	return &po
+}
+
+func PartnerOfficerHandler(router fiber.Router, po PartnerOfficer) {
+	handler := newPartnerOfficer(po)
+	router.Use(po.PartnerFilter, middleware.PartnerRoleFilter(apps.RoleOfficerAdmin))
+	router.Get("/", handler.officers)
+	router.Post("/", handler.invite)
+	router.Delete("/:id", handler.remove)
+}
+
+// @Tags Officer Partner APIs
+// API Officer List
+// @Summary API Officer List
+// @Description API to list active officers of the partner, only for admin role
+// @Schemes
+// @Accept json
+// @Param Authorization header string true "Your Token to Access" default(Bearer )
+// @Param x-client-channel header string true "Client Channel" Enums(EBIZKEZBEK)
+// @Param x-client-os  header string true "Client OS or Browser Agent" default(android 10)
+// @Param x-client-device  header string true "Client Device ID"
+// @Param x-client-version  header string true "Client Platform Version" default(1.0.0)
+// @Param x-client-timestamp  header string false "Client Original Timestamp in UNIX format (EPOCH)"
+// @Success 200 {array} model.OfficerProjection
+// @Failure 401 {object} model.Meta
+// @Failure 403 {object} model.Meta
+// @Failure 500 {object} model.Meta
+// @Router /partner/v1/officers [get]
+func (po *PartnerOfficer) officers(ctx *fiber.Ctx) error {
+	inp := middleware.ClientSession(ctx)
+	v, ex := po.Officers(&inp)
+	if ex != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(apps.BusinessErrorResponse(ex))
+	}
+	return ctx.JSON(apps.DefaultSuccessResponse(apps.SuccessMsgDataFound, v))
+}
+
+// @Tags Officer Partner APIs
+// API Officer Invite
+// @Summary API Officer Invite
+// @Description API to invite a new officer to the partner portal with a partner-scoped role, only for admin role
+// @Schemes
+// @Accept json
+// @Param Authorization header string true "Your Token to Access" default(Bearer )
+// @Param x-client-channel header string true "Client Channel" Enums(EBIZKEZBEK)
+// @Param x-client-os  header string true "Client OS or Browser Agent" default(android 10)
+// @Param x-client-device  header string true "Client Device ID"
+// @Param x-client-version  header string true "Client Platform Version" default(1.0.0)
+// @Param x-client-timestamp  header string false "Client Original Timestamp in UNIX format (EPOCH)"
+// @Param request body model.AddOfficerRequest true "Officer Invitation Payload"
+// @Success 200 {object} model.TransactionResponse
+// @Failure 400 {object} model.Meta
+// @Failure 401 {object} model.Meta
+// @Failure 403 {object} model.Meta
+// @Failure 500 {object} model.Meta
+// @Router /partner/v1/officers [post]
+func (po *PartnerOfficer) invite(ctx *fiber.Ctx) error {
+	inp := model.AddOfficerRequest{}
+	if err := ctx.BodyParser(&inp); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	bad := apps.ValidateStruct(checker.Struct(inp))
+	if bad != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(bad)
+	}
+	inp.SessionRequest = middleware.ClientSession(ctx)
+	v, ex := po.Invite(&inp)
+	if ex != nil && ex.ErrorCode == apps.ErrCodeBussOfficerExists {
+		return ctx.Status(fiber.StatusBadRequest).JSON(apps.BusinessErrorResponse(ex))
+	}
+	if ex != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(apps.BusinessErrorResponse(ex))
+	}
+	return ctx.JSON(apps.DefaultSuccessResponse(apps.SuccessMsgSubmit, v))
+}
+
+// @Tags Officer Partner APIs
+// API Officer Remove
+// @Summary API Officer Remove
+// @Description API to remove an officer from the partner portal and end the officer session, only for admin role
+// @Schemes
+// @Accept json
+// @Param Authorization header string true "Your Token to Access" default(Bearer )
+// @Param x-client-channel header string true "Client Channel" Enums(EBIZKEZBEK)
+// @Param x-client-os  header string true "Client OS or Browser Agent" default(android 10)
+// @Param x-client-device  header string true "Client Device ID"
+// @Param x-client-version  header string true "Client Platform Version" default(1.0.0)
+// @Param x-client-timestamp  header string false "Client Original Timestamp in UNIX format (EPOCH)"
+// @Param id path int true "Officer ID"
+// @Success 200 {object} model.TransactionResponse
+// @Failure 400 {object} model.Meta
+// @Failure 401 {object} model.Meta
+// @Failure 403 {object} model.Meta
+// @Failure 404 {object} model.Meta
+// @Failure 500 {object} model.Meta
+// @Router /partner/v1/officers/{id} [delete]
+func (po *PartnerOfficer) remove(ctx *fiber.Ctx) error {
+	id, _ := strconv.ParseInt(ctx.Params("id"), 10, 64)
+	v, ex := po.Remove(&model.FindByIdRequest{
+		Id:             id,
+		SessionRequest: middleware.ClientSession(ctx),
+	})
+	if ex != nil && ex.ErrorCode == apps.ErrCodeNotFound {
+		return ctx.Status(fiber.StatusNotFound).JSON(apps.BusinessErrorResponse(ex))
+	}
+	if ex != nil && ex.ErrorCode == apps.ErrCodeBussOfficerSelfRemoval {
+		return ctx.Status(fiber.StatusBadRequest).JSON(apps.BusinessErrorResponse(ex))
+	}
+	if ex != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(apps.BusinessErrorResponse(ex))
+	}
+	return ctx.JSON(apps.DefaultSuccessResponse(apps.SuccessMsgSubmit, v))
+}
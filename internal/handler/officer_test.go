@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/handler/middleware"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/mock/adaptor"
+	"github.com/adinandradrs/cezbek-engine/mock/storage"
+	"github.com/adinandradrs/cezbek-engine/mock/usecase/partner"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPartnerOfficerHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	ciamPartner := adaptor.NewMockCiamWatcher(ctrl)
+	cacher := storage.NewMockCacher(ctrl)
+	officerProvider := partner.NewMockOfficerProvider(ctrl)
+	jwtAuthenticator := middleware.NewJwtAuthenticator(&middleware.JwtAuthenticator{
+		Logger:      logger,
+		CiamPartner: ciamPartner,
+		Cacher:      cacher,
+	})
+
+	api := fiber.New()
+	partnerOfficers := api.Group("/api/partner/v1/officers")
+	PartnerOfficerHandler(partnerOfficers, PartnerOfficer{
+		OfficerProvider: officerProvider,
+		PartnerFilter:   jwtAuthenticator.PartnerFilter(),
+	})
+	jwtInfo := map[string]interface{}{
+		"email":            "someone@email.net",
+		"cognito:username": "corp_a",
+	}
+	admin, _ := json.Marshal(model.OfficerValidationResponse{
+		Id:      int64(1),
+		Code:    "CORP_A",
+		Company: "Company A",
+		Email:   "someone@email.net",
+		Role:    apps.RoleOfficerAdmin,
+	})
+	viewer, _ := json.Marshal(model.OfficerValidationResponse{
+		Id:      int64(1),
+		Code:    "CORP_A",
+		Company: "Company A",
+		Email:   "someone@email.net",
+		Role:    apps.RoleOfficerViewer,
+	})
+	session := func(s []byte) {
+		ciamPartner.EXPECT().JwtInfo(gomock.Any()).Return(jwtInfo, nil)
+		cacher.EXPECT().Get("B2BTOKEN", apps.Hash("*secret*")).Return("someone@email.net", nil)
+		cacher.EXPECT().Get("B2BSESSION", "someone@email.net").Return(string(s), nil)
+	}
+	t.Run("should return 200 success to list officers", func(t *testing.T) {
+		session(admin)
+		officerProvider.EXPECT().Officers(gomock.Any()).Return([]model.OfficerProjection{
+			{Id: 1, Email: "someone@email.net", Fullname: "Someone", Role: apps.RoleOfficerAdmin},
+		}, nil)
+		req := httptest.NewRequest(fiber.MethodGet, "/api/partner/v1/officers", nil)
+		req.Header.Add(fiber.HeaderAuthorization, "Bearer *secret*")
+		req.Header.Add(apps.HeaderClientChannel, apps.ChannelEBizKezbek)
+		req.Header.Add(apps.HeaderClientDeviceId, "f-123-456")
+		req.Header.Add(apps.HeaderClientOs, "Android 10")
+		req.Header.Add(apps.HeaderClientVersion, "1.0.0")
+		res, _ := api.Test(req, 100)
+		m := model.Response{}
+		_ = json.NewDecoder(res.Body).Decode(&m)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+		assert.NotNil(t, m.Data)
+	})
+
+	t.Run("should return 403 to list officers by viewer", func(t *testing.T) {
+		session(viewer)
+		req := httptest.NewRequest(fiber.MethodGet, "/api/partner/v1/officers", nil)
+		req.Header.Add(fiber.HeaderAuthorization, "Bearer *secret*")
+		req.Header.Add(apps.HeaderClientChannel, apps.ChannelEBizKezbek)
+		req.Header.Add(apps.HeaderClientDeviceId, "f-123-456")
+		req.Header.Add(apps.HeaderClientOs, "Android 10")
+		req.Header.Add(apps.HeaderClientVersion, "1.0.0")
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusForbidden, res.StatusCode)
+	})
+
+	t.Run("should return 200 success to invite officer", func(t *testing.T) {
+		session(admin)
+		officerProvider.EXPECT().Invite(gomock.Any()).DoAndReturn(func(inp *model.AddOfficerRequest) (*model.TransactionResponse, *model.BusinessError) {
+			assert.Equal(t, int64(1), inp.SessionRequest.Id)
+			return &model.TransactionResponse{TransactionId: "TRX-001"}, nil
+		})
+		b, _ := json.Marshal(model.AddOfficerRequest{
+			Email:    "jane.doe@email.net",
+			Fullname: "Jane Doe",
+			Role:     apps.RoleOfficerFinance,
+		})
+		req := httptest.NewRequest(fiber.MethodPost, "/api/partner/v1/officers", bytes.NewBuffer(b))
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		req.Header.Add(fiber.HeaderAuthorization, "Bearer *secret*")
+		req.Header.Add(apps.HeaderClientChannel, apps.ChannelEBizKezbek)
+		req.Header.Add(apps.HeaderClientDeviceId, "f-123-456")
+		req.Header.Add(apps.HeaderClientOs, "Android 10")
+		req.Header.Add(apps.HeaderClientVersion, "1.0.0")
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	})
+
+	t.Run("should return 400 to invite officer with unknown role", func(t *testing.T) {
+		session(admin)
+		b, _ := json.Marshal(model.AddOfficerRequest{
+			Email:    "jane.doe@email.net",
+			Fullname: "Jane Doe",
+			Role:     "owner",
+		})
+		req := httptest.NewRequest(fiber.MethodPost, "/api/partner/v1/officers", bytes.NewBuffer(b))
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		req.Header.Add(fiber.HeaderAuthorization, "Bearer *secret*")
+		req.Header.Add(apps.HeaderClientChannel, apps.ChannelEBizKezbek)
+		req.Header.Add(apps.HeaderClientDeviceId, "f-123-456")
+		req.Header.Add(apps.HeaderClientOs, "Android 10")
+		req.Header.Add(apps.HeaderClientVersion, "1.0.0")
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("should return 400 to invite existing officer", func(t *testing.T) {
+		session(admin)
+		officerProvider.EXPECT().Invite(gomock.Any()).Return(nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeBussOfficerExists,
+			ErrorMessage: apps.ErrMsgBussOfficerExists,
+		})
+		b, _ := json.Marshal(model.AddOfficerRequest{
+			Email:    "jane.doe@email.net",
+			Fullname: "Jane Doe",
+			Role:     apps.RoleOfficerViewer,
+		})
+		req := httptest.NewRequest(fiber.MethodPost, "/api/partner/v1/officers", bytes.NewBuffer(b))
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		req.Header.Add(fiber.HeaderAuthorization, "Bearer *secret*")
+		req.Header.Add(apps.HeaderClientChannel, apps.ChannelEBizKezbek)
+		req.Header.Add(apps.HeaderClientDeviceId, "f-123-456")
+		req.Header.Add(apps.HeaderClientOs, "Android 10")
+		req.Header.Add(apps.HeaderClientVersion, "1.0.0")
+		res, _ := api.Test(req, 100)
+		m := model.Response{}
+		_ = json.NewDecoder(res.Body).Decode(&m)
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+		assert.Equal(t, apps.ErrCodeBussOfficerExists, m.Meta.Code)
+	})
+
+	t.Run("should return 200 success to remove officer", func(t *testing.T) {
+		session(admin)
+		officerProvider.EXPECT().Remove(gomock.Any()).DoAndReturn(func(inp *model.FindByIdRequest) (*model.TransactionResponse, *model.BusinessError) {
+			assert.Equal(t, int64(2), inp.Id)
+			assert.Equal(t, "someone@email.net", inp.SessionRequest.Email)
+			return &model.TransactionResponse{TransactionId: "TRX-001"}, nil
+		})
+		req := httptest.NewRequest(fiber.MethodDelete, "/api/partner/v1/officers/2", nil)
+		req.Header.Add(fiber.HeaderAuthorization, "Bearer *secret*")
+		req.Header.Add(apps.HeaderClientChannel, apps.ChannelEBizKezbek)
+		req.Header.Add(apps.HeaderClientDeviceId, "f-123-456")
+		req.Header.Add(apps.HeaderClientOs, "Android 10")
+		req.Header.Add(apps.HeaderClientVersion, "1.0.0")
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	})
+
+	t.Run("should return 404 to remove unknown officer", func(t *testing.T) {
+		session(admin)
+		officerProvider.EXPECT().Remove(gomock.Any()).Return(nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeNotFound,
+			ErrorMessage: apps.ErrMsgNotFound,
+		})
+		req := httptest.NewRequest(fiber.MethodDelete, "/api/partner/v1/officers/2", nil)
+		req.Header.Add(fiber.HeaderAuthorization, "Bearer *secret*")
+		req.Header.Add(apps.HeaderClientChannel, apps.ChannelEBizKezbek)
+		req.Header.Add(apps.HeaderClientDeviceId, "f-123-456")
+		req.Header.Add(apps.HeaderClientOs, "Android 10")
+		req.Header.Add(apps.HeaderClientVersion, "1.0.0")
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusNotFound, res.StatusCode)
+	})
+
+	t.Run("should return 400 to remove self", func(t *testing.T) {
+		session(admin)
+		officerProvider.EXPECT().Remove(gomock.Any()).Return(nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeBussOfficerSelfRemoval,
+			ErrorMessage: apps.ErrMsgBussOfficerSelfRemoval,
+		})
+		req := httptest.NewRequest(fiber.MethodDelete, "/api/partner/v1/officers/1", nil)
+		req.Header.Add(fiber.HeaderAuthorization, "Bearer *secret*")
+		req.Header.Add(apps.HeaderClientChannel, apps.ChannelEBizKezbek)
+		req.Header.Add(apps.HeaderClientDeviceId, "f-123-456")
+		req.Header.Add(apps.HeaderClientOs, "Android 10")
+		req.Header.Add(apps.HeaderClientVersion, "1.0.0")
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+	})
+}
@@ -9,6 +9,7 @@ import (
 
 type PartnerManagement struct {
 	management.PartnerManager
+	AdminFilter fiber.Handler
 }
 
 func newPartnerManagementResource(p PartnerManagement) *PartnerManagement {
@@ -17,7 +18,9 @@ func newPartnerManagementResource(p PartnerManagement) *PartnerManagement {
 
 func PartnerManagementHandler(router fiber.Router, pm PartnerManagement) {
 	handler := newPartnerManagementResource(pm)
+	router.Use(pm.AdminFilter)
 	router.Post("/", handler.add)
+	router.Delete("/:code/sessions", handler.revoke)
 }
 
 // @Tags Partner Management APIs
@@ -26,7 +29,7 @@ func PartnerManagementHandler(router fiber.Router, pm PartnerManagement) {
 // @Description API to register a new B2B Partner data as user and client
 // @Schemes
 // @Accept json
-// @Param Authorization header string false "Your Token to Access" default(Bearer )
+// @Param x-api-key header string true "Back Office API Key"
 // @Param x-client-channel header string true "Client Channel" Enums(EBIZKEZBEK, B2BCLIENT)
 // @Param x-client-os  header string true "Client OS or Browser Agent" default(android 10)
 // @Param x-client-device  header string true "Client Device ID"
@@ -38,6 +41,7 @@ func PartnerManagementHandler(router fiber.Router, pm PartnerManagement) {
 // @Param msisdn formData string true "MSISDN" default(628123456789)
 // @Param officer formData string true "Partner Officer" default(John Doe)
 // @Param address formData string true "Office Address" default(Bintaro Exchange Mall Blok A1)
+// @Param locale formData string false "Notification Locale" Enums(id, en)
 // @Param logo formData file true "Logo"
 // @Success 200 {object} model.TransactionResponse
 // @Failure 400 {object} model.Meta
@@ -55,6 +59,7 @@ func (p *PartnerManagement) add(ctx *fiber.Ctx) error {
 		Msisdn:  ctx.FormValue("msisdn"),
 		Officer: ctx.FormValue("officer"),
 		Address: ctx.FormValue("address"),
+		Locale:  ctx.FormValue("locale"),
 		Logo:    *logo,
 		SessionRequest: model.SessionRequest{
 			Id: 0,
@@ -79,3 +84,36 @@ func (p *PartnerManagement) add(ctx *fiber.Ctx) error {
 
 	return ctx.JSON(apps.DefaultSuccessResponse(apps.SuccessMsgSubmit, v))
 }
+
+// @Tags Partner Management APIs
+// API Revoke Partner Sessions
+// @Summary API Revoke Partner Sessions
+// @Description API to force revoke all active client and officer sessions of a B2B Partner
+// @Schemes
+// @Accept json
+// @Param x-api-key header string true "Back Office API Key"
+// @Param x-client-channel header string true "Client Channel" Enums(EBIZKEZBEK, B2BCLIENT)
+// @Param x-client-os  header string true "Client OS or Browser Agent" default(android 10)
+// @Param x-client-device  header string true "Client Device ID"
+// @Param x-client-version  header string true "Client Platform Version" default(1.0.0)
+// @Param x-client-timestamp  header string false "Client Original Timestamp in UNIX format (EPOCH)"
+// @Param code path string true "Partner Code" default(LAJADA)
+// @Success 200 {object} model.TransactionResponse
+// @Failure 401 {object} model.Meta
+// @Failure 404 {object} model.Meta
+// @Failure 500 {object} model.Meta
+// @Failure 503 {object} model.Meta
+// @Router /v1/partners/{code}/sessions [delete]
+func (p *PartnerManagement) revoke(ctx *fiber.Ctx) error {
+	v, ex := p.RevokeSessions(ctx.Params("code"))
+	if ex != nil && ex.ErrorCode == apps.ErrCodeNotFound {
+		return ctx.Status(fiber.StatusNotFound).JSON(apps.BusinessErrorResponse(ex))
+	}
+	if ex != nil && ex.ErrorCode == apps.ErrCodeESBUnavailable {
+		return ctx.Status(fiber.StatusServiceUnavailable).JSON(apps.BusinessErrorResponse(ex))
+	}
+	if ex != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(apps.BusinessErrorResponse(ex))
+	}
+	return ctx.JSON(apps.DefaultSuccessResponse(apps.SuccessMsgSubmit, v))
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/handler/middleware"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/mock/usecase/management"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPartnerManagementHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	partnerManager := management.NewMockPartnerManager(ctrl)
+	logger, _ := apps.NewLog(false)
+	adminAuthenticator := middleware.NewAdminAuthenticator(&middleware.AdminAuthenticator{
+		Logger: logger,
+		ApiKey: "b4ck0ff1c3",
+	})
+
+	api := fiber.New()
+	partners := api.Group("/api/v1/partners")
+	PartnerManagementHandler(partners, PartnerManagement{
+		PartnerManager: partnerManager,
+		AdminFilter:    adminAuthenticator.AdminFilter(),
+	})
+
+	t.Run("should return 200 success to revoke the partner sessions", func(t *testing.T) {
+		partnerManager.EXPECT().RevokeSessions("LAJADA").Return(&model.TransactionResponse{
+			TransactionTimestamp: 1,
+		}, nil)
+		req := httptest.NewRequest(fiber.MethodDelete, "/api/v1/partners/LAJADA/sessions", nil)
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		res, _ := api.Test(req, 100)
+		m := model.Response{}
+		_ = json.NewDecoder(res.Body).Decode(&m)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+		assert.NotNil(t, m.Data)
+	})
+
+	t.Run("should return 404 on unknown partner", func(t *testing.T) {
+		partnerManager.EXPECT().RevokeSessions("UNKNOWN").Return(nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeNotFound,
+			ErrorMessage: apps.ErrMsgNotFound,
+		})
+		req := httptest.NewRequest(fiber.MethodDelete, "/api/v1/partners/UNKNOWN/sessions", nil)
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusNotFound, res.StatusCode)
+	})
+
+	t.Run("should return 401 to revoke without api key", func(t *testing.T) {
+		req := httptest.NewRequest(fiber.MethodDelete, "/api/v1/partners/LAJADA/sessions", nil)
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusUnauthorized, res.StatusCode)
+	})
+
+	t.Run("should return 401 to add partner with wrong api key", func(t *testing.T) {
+		req := httptest.NewRequest(fiber.MethodPost, "/api/v1/partners", nil)
+		req.Header.Add(apps.HeaderApiKey, "wr0ng")
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusUnauthorized, res.StatusCode)
+	})
+}
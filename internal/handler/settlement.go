@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/internal/usecase/management"
+	"github.com/gofiber/fiber/v2"
+	"strconv"
+)
+
+type Settlement struct {
+	management.SettlementManager
+	AdminFilter fiber.Handler
+}
+
+func newSettlement(s Settlement) *Settlement {
+	return &s
+}
+
+func SettlementHandler(router fiber.Router, s Settlement) {
+	handler := newSettlement(s)
+	router.Use(s.AdminFilter)
+	router.Post("/", handler.reconcile)
+	router.Get("/", handler.search)
+	router.Get("/:id", handler.report)
+	router.Put("/exceptions/:id/resolution", handler.resolve)
+}
+
+// @Tags Settlement Management APIs
+// API Reconcile Settlement
+// @Summary API Reconcile Settlement
+// @Description API to reconcile a provider settlement file in CSV or JSON against the disbursed cashbacks, the lines are matched by the Kezbek or the provider reference
+// @Schemes
+// @Accept mpfd
+// @Param x-api-key header string true "Back Office API Key"
+// @Param x-client-channel header string true "Client Channel" Enums(EBIZKEZBEK, B2BCLIENT)
+// @Param x-client-os  header string true "Client OS or Browser Agent" default(android 10)
+// @Param x-client-device  header string true "Client Device ID"
+// @Param x-client-version  header string true "Client Platform Version" default(1.0.0)
+// @Param x-client-timestamp  header string false "Client Original Timestamp in UNIX format (EPOCH)"
+// @Param h2h_code formData string true "H2H Provider Code" default(LSAJAH2H)
+// @Param settlement_date formData string true "Settlement Date" default(2023-01-01)
+// @Param file formData file true "Settlement File"
+// @Success 200 {object} model.SettlementReportResponse
+// @Failure 400 {object} model.Meta
+// @Failure 401 {object} model.Meta
+// @Failure 500 {object} model.Meta
+// @Router /v1/settlements [post]
+func (s *Settlement) reconcile(ctx *fiber.Ctx) error {
+	file, _ := ctx.FormFile("file")
+	inp := model.SettlementRequest{
+		H2HCode:        ctx.FormValue("h2h_code"),
+		SettlementDate: ctx.FormValue("settlement_date"),
+		File:           file,
+	}
+	bad := apps.ValidateStruct(checker.Struct(inp))
+	if bad != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(bad)
+	}
+	v, ex := s.Reconcile(&inp)
+	if ex != nil && ex.ErrorCode == apps.ErrCodeBussSettlementInvalid {
+		return ctx.Status(fiber.StatusBadRequest).JSON(apps.BusinessErrorResponse(ex))
+	}
+	if ex != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(apps.BusinessErrorResponse(ex))
+	}
+	return ctx.JSON(apps.DefaultSuccessResponse(apps.SuccessMsgSubmit, v))
+}
+
+// @Tags Settlement Management APIs
+// API Search Settlement
+// @Summary API Search Settlement
+// @Description API to search the reconciliation runs with their count of unresolved exceptions
+// @Schemes
+// @Accept json
+// @Param x-api-key header string true "Back Office API Key"
+// @Param x-client-channel header string true "Client Channel" Enums(EBIZKEZBEK, B2BCLIENT)
+// @Param x-client-os  header string true "Client OS or Browser Agent" default(android 10)
+// @Param x-client-device  header string true "Client Device ID"
+// @Param x-client-version  header string true "Client Platform Version" default(1.0.0)
+// @Param x-client-timestamp  header string false "Client Original Timestamp in UNIX format (EPOCH)"
+// @Param Payload query model.SearchRequest true "Search Payload"
+// @Success 200 {object} model.SettlementSearchResponse
+// @Failure 400 {object} model.Meta
+// @Failure 401 {object} model.Meta
+// @Router /v1/settlements [get]
+func (s *Settlement) search(ctx *fiber.Ctx) error {
+	inp := model.SearchRequest{}
+	if err := ctx.QueryParser(&inp); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	v, ex := s.Search(&inp)
+	if ex != nil && ex.ErrorCode == apps.ErrCodeNotFound {
+		return ctx.Status(fiber.StatusOK).
+			JSON(apps.BusinessErrorResponse(ex))
+	}
+	return ctx.JSON(apps.DefaultSuccessResponse(apps.SuccessMsgDataFound, v))
+}
+
+// @Tags Settlement Management APIs
+// API Settlement Report
+// @Summary API Settlement Report
+// @Description API to get the report of a reconciliation run along with its exceptions
+// @Schemes
+// @Accept json
+// @Param x-api-key header string true "Back Office API Key"
+// @Param x-client-channel header string true "Client Channel" Enums(EBIZKEZBEK, B2BCLIENT)
+// @Param x-client-os  header string true "Client OS or Browser Agent" default(android 10)
+// @Param x-client-device  header string true "Client Device ID"
+// @Param x-client-version  header string true "Client Platform Version" default(1.0.0)
+// @Param x-client-timestamp  header string false "Client Original Timestamp in UNIX format (EPOCH)"
+// @Param id path int true "Settlement ID"
+// @Success 200 {object} model.SettlementReportResponse
+// @Failure 401 {object} model.Meta
+// @Failure 404 {object} model.Meta
+// @Failure 500 {object} model.Meta
+// @Router /v1/settlements/{id} [get]
+func (s *Settlement) report(ctx *fiber.Ctx) error {
+	id, _ := strconv.ParseInt(ctx.Params("id"), 10, 64)
+	v, ex := s.Report(id)
+	if ex != nil && ex.ErrorCode == apps.ErrCodeNotFound {
+		return ctx.Status(fiber.StatusNotFound).JSON(apps.BusinessErrorResponse(ex))
+	}
+	if ex != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(apps.BusinessErrorResponse(ex))
+	}
+	return ctx.JSON(apps.DefaultSuccessResponse(apps.SuccessMsgDataFound, v))
+}
+
+// @Tags Settlement Management APIs
+// API Resolve Settlement Exception
+// @Summary API Resolve Settlement Exception
+// @Description API to resolve a settlement exception by hand with the notes of how it has been settled
+// @Schemes
+// @Accept json
+// @Param x-api-key header string true "Back Office API Key"
+// @Param x-client-channel header string true "Client Channel" Enums(EBIZKEZBEK, B2BCLIENT)
+// @Param x-client-os  header string true "Client OS or Browser Agent" default(android 10)
+// @Param x-client-device  header string true "Client Device ID"
+// @Param x-client-version  header string true "Client Platform Version" default(1.0.0)
+// @Param x-client-timestamp  header string false "Client Original Timestamp in UNIX format (EPOCH)"
+// @Param id path int true "Settlement Exception ID"
+// @Param Payload body model.SettlementResolveRequest true "Resolution Payload"
+// @Success 200 {object} model.TransactionResponse
+// @Failure 400 {object} model.Meta
+// @Failure 401 {object} model.Meta
+// @Failure 404 {object} model.Meta
+// @Failure 500 {object} model.Meta
+// @Router /v1/settlements/exceptions/{id}/resolution [put]
+func (s *Settlement) resolve(ctx *fiber.Ctx) error {
+	inp := model.SettlementResolveRequest{}
+	if err := ctx.BodyParser(&inp); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	inp.Id, _ = strconv.ParseInt(ctx.Params("id"), 10, 64)
+	bad := apps.ValidateStruct(checker.Struct(inp))
+	if bad != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(bad)
+	}
+	v, ex := s.Resolve(&inp)
+	if ex != nil && ex.ErrorCode == apps.ErrCodeNotFound {
+		return ctx.Status(fiber.StatusNotFound).JSON(apps.BusinessErrorResponse(ex))
+	}
+	if ex != nil && ex.ErrorCode == apps.ErrCodeBussExceptionResolved {
+		return ctx.Status(fiber.StatusBadRequest).JSON(apps.BusinessErrorResponse(ex))
+	}
+	if ex != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(apps.BusinessErrorResponse(ex))
+	}
+	return ctx.JSON(apps.DefaultSuccessResponse(apps.SuccessMsgSubmit, v))
+}
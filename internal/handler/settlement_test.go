@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/handler/middleware"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/mock/usecase/management"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"mime/multipart"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSettlementHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	settlementManager := management.NewMockSettlementManager(ctrl)
+
+	logger, _ := apps.NewLog(false)
+	adminAuthenticator := middleware.NewAdminAuthenticator(&middleware.AdminAuthenticator{
+		Logger: logger,
+		ApiKey: "b4ck0ff1c3",
+	})
+
+	api := fiber.New()
+	settlements := api.Group("/api/v1/settlements")
+	SettlementHandler(settlements, Settlement{
+		SettlementManager: settlementManager,
+		AdminFilter:       adminAuthenticator.AdminFilter(),
+	})
+	form := func(file bool) (*bytes.Buffer, string) {
+		b := &bytes.Buffer{}
+		w := multipart.NewWriter(b)
+		_ = w.WriteField("h2h_code", "LSAJAH2H")
+		_ = w.WriteField("settlement_date", "2023-01-01")
+		if file {
+			f, _ := w.CreateFormFile("file", "LSAJAH2H-20230101.csv")
+			_, _ = f.Write([]byte("kezbek_ref_code,amount\nKZB-001,15000\n"))
+		}
+		_ = w.Close()
+		return b, w.FormDataContentType()
+	}
+	t.Run("should return 401 on reconcile without api key", func(t *testing.T) {
+		b, ct := form(true)
+		req := httptest.NewRequest(fiber.MethodPost, "/api/v1/settlements", b)
+		req.Header.Add(fiber.HeaderContentType, ct)
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusUnauthorized, res.StatusCode)
+	})
+
+	t.Run("should return 200 on reconcile", func(t *testing.T) {
+		settlementManager.EXPECT().Reconcile(gomock.Any()).DoAndReturn(
+			func(inp *model.SettlementRequest) (*model.SettlementReportResponse, *model.BusinessError) {
+				assert.Equal(t, "LSAJAH2H", inp.H2HCode)
+				assert.Equal(t, "LSAJAH2H-20230101.csv", inp.File.Filename)
+				return &model.SettlementReportResponse{
+					SettlementProjection: model.SettlementProjection{Id: 1, Lines: 1, Matched: 1},
+				}, nil
+			})
+		b, ct := form(true)
+		req := httptest.NewRequest(fiber.MethodPost, "/api/v1/settlements", b)
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		req.Header.Add(fiber.HeaderContentType, ct)
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	})
+
+	t.Run("should return 400 on reconcile without file", func(t *testing.T) {
+		b, ct := form(false)
+		req := httptest.NewRequest(fiber.MethodPost, "/api/v1/settlements", b)
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		req.Header.Add(fiber.HeaderContentType, ct)
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("should return 400 on reconcile an invalid file", func(t *testing.T) {
+		settlementManager.EXPECT().Reconcile(gomock.Any()).Return(nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeBussSettlementInvalid,
+			ErrorMessage: apps.ErrMsgBussSettlementInvalid,
+		})
+		b, ct := form(true)
+		req := httptest.NewRequest(fiber.MethodPost, "/api/v1/settlements", b)
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		req.Header.Add(fiber.HeaderContentType, ct)
+		res, _ := api.Test(req, 100)
+		m := model.Response{}
+		_ = json.NewDecoder(res.Body).Decode(&m)
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+		assert.Equal(t, apps.ErrCodeBussSettlementInvalid, m.Meta.Code)
+	})
+
+	t.Run("should return 500 on failed to reconcile", func(t *testing.T) {
+		settlementManager.EXPECT().Reconcile(gomock.Any()).Return(nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSubmitted,
+			ErrorMessage: apps.ErrMsgSubmitted,
+		})
+		b, ct := form(true)
+		req := httptest.NewRequest(fiber.MethodPost, "/api/v1/settlements", b)
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		req.Header.Add(fiber.HeaderContentType, ct)
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
+	})
+
+	t.Run("should return 200 on search", func(t *testing.T) {
+		settlementManager.EXPECT().Search(gomock.Any()).Return(&model.SettlementSearchResponse{
+			Settlements: []model.SettlementProjection{{Id: 1}},
+		}, nil)
+		req := httptest.NewRequest(fiber.MethodGet, "/api/v1/settlements?limit=5&start=0", nil)
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		res, _ := api.Test(req, 100)
+		m := model.Response{}
+		_ = json.NewDecoder(res.Body).Decode(&m)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+		assert.NotNil(t, m.Data)
+	})
+
+	t.Run("should return 200 on report", func(t *testing.T) {
+		settlementManager.EXPECT().Report(int64(1)).Return(&model.SettlementReportResponse{
+			SettlementProjection: model.SettlementProjection{Id: 1},
+		}, nil)
+		req := httptest.NewRequest(fiber.MethodGet, "/api/v1/settlements/1", nil)
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	})
+
+	t.Run("should return 404 on report an unknown settlement", func(t *testing.T) {
+		settlementManager.EXPECT().Report(int64(2)).Return(nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeNotFound,
+			ErrorMessage: apps.ErrMsgNotFound,
+		})
+		req := httptest.NewRequest(fiber.MethodGet, "/api/v1/settlements/2", nil)
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusNotFound, res.StatusCode)
+	})
+
+	t.Run("should return 200 on resolve", func(t *testing.T) {
+		settlementManager.EXPECT().Resolve(gomock.Any()).DoAndReturn(
+			func(inp *model.SettlementResolveRequest) (*model.TransactionResponse, *model.BusinessError) {
+				assert.Equal(t, int64(3), inp.Id)
+				return &model.TransactionResponse{TransactionId: "TRX0012345678"}, nil
+			})
+		b, _ := json.Marshal(model.SettlementResolveRequest{Notes: "Settled on the next statement"})
+		req := httptest.NewRequest(fiber.MethodPut, "/api/v1/settlements/exceptions/3/resolution", bytes.NewBuffer(b))
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	})
+
+	t.Run("should return 400 on resolve without notes", func(t *testing.T) {
+		b, _ := json.Marshal(model.SettlementResolveRequest{})
+		req := httptest.NewRequest(fiber.MethodPut, "/api/v1/settlements/exceptions/3/resolution", bytes.NewBuffer(b))
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("should return 400 on resolve a resolved exception", func(t *testing.T) {
+		settlementManager.EXPECT().Resolve(gomock.Any()).Return(nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeBussExceptionResolved,
+			ErrorMessage: apps.ErrMsgBussExceptionResolved,
+		})
+		b, _ := json.Marshal(model.SettlementResolveRequest{Notes: "Settled on the next statement"})
+		req := httptest.NewRequest(fiber.MethodPut, "/api/v1/settlements/exceptions/3/resolution", bytes.NewBuffer(b))
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("should return 404 on resolve an unknown exception", func(t *testing.T) {
+		settlementManager.EXPECT().Resolve(gomock.Any()).Return(nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeNotFound,
+			ErrorMessage: apps.ErrMsgNotFound,
+		})
+		b, _ := json.Marshal(model.SettlementResolveRequest{Notes: "Settled on the next statement"})
+		req := httptest.NewRequest(fiber.MethodPut, "/api/v1/settlements/exceptions/4/resolution", bytes.NewBuffer(b))
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusNotFound, res.StatusCode)
+	})
+}
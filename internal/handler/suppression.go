@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/handler/middleware"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/internal/usecase/partner"
+	"github.com/gofiber/fiber/v2"
+)
+
+type PartnerSuppression struct {
+	partner.SuppressionProvider
+	PartnerFilter fiber.Handler
+}
+
+func newPartnerSuppression(ps PartnerSuppression) *PartnerSuppression {
+	return &ps
+}
+
+func PartnerSuppressionHandler(router fiber.Router, ps PartnerSuppression) {
+	handler := newPartnerSuppression(ps)
+	router.Use(ps.PartnerFilter, middleware.PartnerRoleFilter(apps.RoleOfficerViewer,
+		apps.RoleOfficerFinance, apps.RoleOfficerAdmin))
+	router.Get("/", handler.search)
+}
+
+// @Tags Suppression Partner APIs
+// API Suppression Search
+// @Summary API Suppression Search
+// @Description API to search the suppressed customer emails by partner, an email is suppressed on a hard bounce or a complaint
+// @Schemes
+// @Accept json
+// @Param Authorization header string true "Your Token to Access" default(Bearer )
+// @Param x-client-channel header string true "Client Channel" Enums(EBIZKEZBEK, B2BCLIENT)
+// @Param x-client-os  header string true "Client OS or Browser Agent" default(android 10)
+// @Param x-client-device  header string true "Client Device ID"
+// @Param x-client-version  header string true "Client Platform Version" default(1.0.0)
+// @Param x-client-timestamp  header string false "Client Original Timestamp in UNIX format (EPOCH)"
+// @Param Payload query model.SearchRequest true "Search Payload"
+// @Success 200 {object} model.SuppressionSearchResponse
+// @Failure 400 {object} model.Meta
+// @Failure 401 {object} model.Meta
+// @Failure 403 {object} model.Meta
+// @Failure 500 {object} model.Meta
+// @Failure 503 {object} model.Meta
+// @Router /partner/v1/suppressions [get]
+func (ps *PartnerSuppression) search(ctx *fiber.Ctx) error {
+	inp := model.SearchRequest{}
+	if err := ctx.QueryParser(&inp); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	inp.SessionRequest = middleware.ClientSession(ctx)
+	v, ex := ps.Search(&inp)
+	if ex != nil && ex.ErrorCode == apps.ErrCodeNotFound {
+		return ctx.Status(fiber.StatusOK).
+			JSON(apps.BusinessErrorResponse(ex))
+	}
+	return ctx.JSON(apps.DefaultSuccessResponse(apps.SuccessMsgDataFound, v))
+}
@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/handler/middleware"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/mock/adaptor"
+	"github.com/adinandradrs/cezbek-engine/mock/storage"
+	"github.com/adinandradrs/cezbek-engine/mock/usecase/partner"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPartnerSuppressionHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	ciamPartner := adaptor.NewMockCiamWatcher(ctrl)
+	cacher := storage.NewMockCacher(ctrl)
+	suppressionProvider := partner.NewMockSuppressionProvider(ctrl)
+	jwtAuthenticator := middleware.NewJwtAuthenticator(&middleware.JwtAuthenticator{
+		Logger:      logger,
+		CiamPartner: ciamPartner,
+		Cacher:      cacher,
+	})
+
+	api := fiber.New()
+	partnerSuppressions := api.Group("/api/partner/v1/suppressions")
+	PartnerSuppressionHandler(partnerSuppressions, PartnerSuppression{
+		SuppressionProvider: suppressionProvider,
+		PartnerFilter:       jwtAuthenticator.PartnerFilter(),
+	})
+	jwtInfo := map[string]interface{}{
+		"email":            "someone@email.net",
+		"cognito:username": "someone",
+	}
+	c, _ := json.Marshal(model.OfficerValidationResponse{
+		Id:      int64(1),
+		Code:    "CORP_A",
+		Company: "Company A",
+		Email:   "someone@email.net",
+		Role:    apps.RoleOfficerViewer,
+	})
+	t.Run("should return 200 success to search suppression", func(t *testing.T) {
+		cacher.EXPECT().Get("B2BTOKEN", apps.Hash("*secret*")).Return("someone@email.net", nil)
+		cacher.EXPECT().Get("B2BSESSION", "someone@email.net").Return(string(c), nil)
+		ciamPartner.EXPECT().JwtInfo(gomock.Any()).Return(jwtInfo, nil)
+		suppressionProvider.EXPECT().Search(gomock.Any()).DoAndReturn(
+			func(inp *model.SearchRequest) (*model.SuppressionSearchResponse, *model.BusinessError) {
+				assert.Equal(t, int64(1), inp.SessionRequest.Id)
+				return &model.SuppressionSearchResponse{
+					Suppressions: []model.SuppressionProjection{
+						{Email: "someone@email.net", Reason: apps.SuppressionBounce},
+					},
+				}, nil
+			})
+		req := httptest.NewRequest(fiber.MethodGet, "/api/partner/v1/suppressions?limit=5&start=0", nil)
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		req.Header.Add(fiber.HeaderAuthorization, "Bearer *secret*")
+		req.Header.Add(apps.HeaderClientChannel, apps.ChannelEBizKezbek)
+		req.Header.Add(apps.HeaderClientDeviceId, "f-123-456")
+		req.Header.Add(apps.HeaderClientOs, "Android 10")
+		req.Header.Add(apps.HeaderClientVersion, "1.0.0")
+		res, _ := api.Test(req, 100)
+		m := model.Response{}
+		_ = json.NewDecoder(res.Body).Decode(&m)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+		assert.NotNil(t, m.Data)
+	})
+
+	t.Run("should return 200 failed to search suppression", func(t *testing.T) {
+		cacher.EXPECT().Get("B2BTOKEN", apps.Hash("*secret*")).Return("someone@email.net", nil)
+		cacher.EXPECT().Get("B2BSESSION", "someone@email.net").Return(string(c), nil)
+		ciamPartner.EXPECT().JwtInfo(gomock.Any()).Return(jwtInfo, nil)
+		suppressionProvider.EXPECT().Search(gomock.Any()).Return(nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeNotFound,
+			ErrorMessage: apps.ErrMsgNotFound,
+		})
+		req := httptest.NewRequest(fiber.MethodGet, "/api/partner/v1/suppressions?limit=5&start=0", nil)
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		req.Header.Add(fiber.HeaderAuthorization, "Bearer *secret*")
+		req.Header.Add(apps.HeaderClientChannel, apps.ChannelEBizKezbek)
+		req.Header.Add(apps.HeaderClientDeviceId, "f-123-456")
+		req.Header.Add(apps.HeaderClientOs, "Android 10")
+		req.Header.Add(apps.HeaderClientVersion, "1.0.0")
+		res, _ := api.Test(req, 100)
+		m := model.Response{}
+		_ = json.NewDecoder(res.Body).Decode(&m)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+		assert.Nil(t, m.Data)
+		assert.Equal(t, apps.ErrCodeNotFound, m.Meta.Code)
+	})
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/internal/usecase/notification"
+	"github.com/gofiber/fiber/v2"
+)
+
+type Template struct {
+	notification.TemplateProvider
+	AdminFilter fiber.Handler
+}
+
+func newTemplate(t Template) *Template {
+	return &t
+}
+
+func TemplateHandler(router fiber.Router, t Template) {
+	handler := newTemplate(t)
+	router.Use(t.AdminFilter)
+	router.Post("/preview", handler.preview)
+}
+
+// @Tags Template Management APIs
+// API Template Preview
+// @Summary API Template Preview
+// @Description API to render a notification template with sample data, a draft content could be given to validate it before saved
+// @Schemes
+// @Accept json
+// @Param x-api-key header string true "Back Office API Key"
+// @Param request body model.TemplatePreviewRequest true "Template Preview Payload"
+// @Success 200 {object} model.TemplatePreviewResponse
+// @Failure 400 {object} model.Meta
+// @Failure 401 {object} model.Meta
+// @Failure 404 {object} model.Meta
+// @Failure 500 {object} model.Meta
+// @Router /v1/templates/preview [post]
+func (t *Template) preview(ctx *fiber.Ctx) error {
+	inp := model.TemplatePreviewRequest{}
+	if err := ctx.BodyParser(&inp); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	bad := apps.ValidateStruct(checker.Struct(inp))
+	if bad != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(bad)
+	}
+	v, ex := t.Preview(&inp)
+	if ex != nil && ex.ErrorCode == apps.ErrCodeNotFound {
+		return ctx.Status(fiber.StatusNotFound).JSON(apps.BusinessErrorResponse(ex))
+	}
+	if ex != nil && ex.ErrorCode == apps.ErrCodeBussTemplateInvalid {
+		return ctx.Status(fiber.StatusBadRequest).JSON(apps.BusinessErrorResponse(ex))
+	}
+	if ex != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(apps.BusinessErrorResponse(ex))
+	}
+	return ctx.JSON(apps.DefaultSuccessResponse(apps.SuccessMsgDataFound, v))
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/handler/middleware"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/mock/usecase/notification"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTemplateHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	templateProvider := notification.NewMockTemplateProvider(ctrl)
+	logger, _ := apps.NewLog(false)
+	adminAuthenticator := middleware.NewAdminAuthenticator(&middleware.AdminAuthenticator{
+		Logger: logger,
+		ApiKey: "b4ck0ff1c3",
+	})
+
+	api := fiber.New()
+	templates := api.Group("/api/v1/templates")
+	TemplateHandler(templates, Template{
+		TemplateProvider: templateProvider,
+		AdminFilter:      adminAuthenticator.AdminFilter(),
+	})
+	t.Run("should return 200 success", func(t *testing.T) {
+		inp := model.TemplatePreviewRequest{Name: "OTP", Content: "<p>{{.otp}}</p>"}
+		templateProvider.EXPECT().Preview(&inp).Return(&model.TemplatePreviewResponse{
+			Name: "OTP",
+			NotificationContent: model.NotificationContent{
+				Subject: "OTP",
+				Content: "<p>123456</p>",
+			},
+		}, nil)
+		b, _ := json.Marshal(inp)
+		req := httptest.NewRequest(fiber.MethodPost, "/api/v1/templates/preview", bytes.NewBuffer(b))
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		res, _ := api.Test(req, 100)
+		m := model.Response{}
+		_ = json.NewDecoder(res.Body).Decode(&m)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+		assert.NotNil(t, m.Data)
+	})
+
+	t.Run("should return 401 on unknown api key", func(t *testing.T) {
+		b, _ := json.Marshal(model.TemplatePreviewRequest{Name: "OTP"})
+		req := httptest.NewRequest(fiber.MethodPost, "/api/v1/templates/preview", bytes.NewBuffer(b))
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		req.Header.Add(apps.HeaderApiKey, "unknown")
+		res, _ := api.Test(req, 100)
+		m := model.Response{}
+		_ = json.NewDecoder(res.Body).Decode(&m)
+		assert.Equal(t, fiber.StatusUnauthorized, res.StatusCode)
+		assert.Equal(t, apps.ErrCodeUnauthorized, m.Meta.Code)
+	})
+
+	t.Run("should return 400 on missing name", func(t *testing.T) {
+		b, _ := json.Marshal(model.TemplatePreviewRequest{Content: "<p>{{.otp}}</p>"})
+		req := httptest.NewRequest(fiber.MethodPost, "/api/v1/templates/preview", bytes.NewBuffer(b))
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("should return 400 on invalid template", func(t *testing.T) {
+		inp := model.TemplatePreviewRequest{Name: "OTP", Content: "<p>{{.otp</p>"}
+		templateProvider.EXPECT().Preview(&inp).Return(nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeBussTemplateInvalid,
+			ErrorMessage: apps.ErrMsgBussTemplateInvalid,
+		})
+		b, _ := json.Marshal(inp)
+		req := httptest.NewRequest(fiber.MethodPost, "/api/v1/templates/preview", bytes.NewBuffer(b))
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		res, _ := api.Test(req, 100)
+		m := model.Response{}
+		_ = json.NewDecoder(res.Body).Decode(&m)
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+		assert.Equal(t, apps.ErrCodeBussTemplateInvalid, m.Meta.Code)
+	})
+
+	t.Run("should return 404 on unknown template", func(t *testing.T) {
+		inp := model.TemplatePreviewRequest{Name: "UNKNOWN"}
+		templateProvider.EXPECT().Preview(&inp).Return(nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeNotFound,
+			ErrorMessage: apps.ErrMsgNotFound,
+		})
+		b, _ := json.Marshal(inp)
+		req := httptest.NewRequest(fiber.MethodPost, "/api/v1/templates/preview", bytes.NewBuffer(b))
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		req.Header.Add(apps.HeaderApiKey, "b4ck0ff1c3")
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusNotFound, res.StatusCode)
+	})
+}
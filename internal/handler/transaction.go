@@ -7,6 +7,7 @@ import (
 	"github.com/adinandradrs/cezbek-engine/internal/usecase/partner"
 	"github.com/gofiber/fiber/v2"
 	"strconv"
+	"strings"
 )
 
 type PartnerTransaction struct {
@@ -18,17 +19,38 @@ func newPartnerTransaction(pt PartnerTransaction) *PartnerTransaction {
 	return &pt
 }
 
+const transactionSorts = "omitempty,oneof=ID DATE WALLET_CODE TRANSACTION AMOUNT CASHBACK REWARD"
+
+func validateTransactionSearch(inp *model.PartnerTransactionSearchRequest) []*model.BadPayloadResponse {
+	inp.WalletCode = strings.ToUpper(inp.WalletCode)
+	inp.H2HCode = strings.ToUpper(inp.H2HCode)
+	inp.Status = strings.ToUpper(inp.Status)
+	inp.SortBy = strings.ToUpper(inp.SortBy)
+	inp.Sort = strings.ToUpper(inp.Sort)
+	if bad := apps.ValidateStruct(checker.Struct(inp)); bad != nil {
+		return bad
+	}
+	if bad := apps.ValidateStruct(checker.Var(inp.SortBy, transactionSorts)); bad != nil {
+		return bad
+	}
+	return apps.ValidateStruct(checker.Var(inp.Sort, "omitempty,oneof=ASC DESC"))
+}
+
 func PartnerTransactionHandler(router fiber.Router, pt PartnerTransaction) {
 	handler := newPartnerTransaction(pt)
-	router.Use(pt.PartnerFilter)
+	router.Use(pt.PartnerFilter, middleware.PartnerRoleFilter(apps.RoleOfficerViewer,
+		apps.RoleOfficerFinance, apps.RoleOfficerAdmin))
 	router.Get("/", handler.search)
+	router.Post("/export", middleware.PartnerRoleFilter(apps.RoleOfficerFinance, apps.RoleOfficerAdmin),
+		handler.export)
 	router.Get("/:id", handler.detail)
+	router.Get("/:id/receipt", handler.receipt)
 }
 
 // @Tags Transaction Partner APIs
 // API Transaction Search
 // @Summary API Transaction Search
-// @Description API to search transaction by partner
+// @Description API to search transaction by partner, sort_by is one of ID, DATE, WALLET_CODE, TRANSACTION, AMOUNT (same as TRANSACTION), CASHBACK or REWARD
 // @Schemes
 // @Accept json
 // @Param Authorization header string true "Your Token to Access" default(Bearer )
@@ -37,7 +59,7 @@ func PartnerTransactionHandler(router fiber.Router, pt PartnerTransaction) {
 // @Param x-client-device  header string true "Client Device ID"
 // @Param x-client-version  header string true "Client Platform Version" default(1.0.0)
 // @Param x-client-timestamp  header string false "Client Original Timestamp in UNIX format (EPOCH)"
-// @Param Payload query model.SearchRequest true "Search Payload"
+// @Param Payload query model.PartnerTransactionSearchRequest true "Search Payload"
 // @Success 200 {object} model.PartnerTransactionSearchResponse
 // @Failure 400 {object} model.Meta
 // @Failure 401 {object} model.Meta
@@ -46,12 +68,19 @@ func PartnerTransactionHandler(router fiber.Router, pt PartnerTransaction) {
 // @Failure 503 {object} model.Meta
 // @Router /partner/v1/transactions [get]
 func (pt *PartnerTransaction) search(ctx *fiber.Ctx) error {
-	inp := model.SearchRequest{}
+	inp := model.PartnerTransactionSearchRequest{}
 	if err := ctx.QueryParser(&inp); err != nil {
 		return ctx.Status(fiber.StatusBadRequest).JSON(err)
 	}
+	if bad := validateTransactionSearch(&inp); bad != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(bad)
+	}
 	inp.SessionRequest = middleware.ClientSession(ctx)
 	v, ex := pt.Search(&inp)
+	if ex != nil && ex.ErrorCode == apps.ErrCodeBadPayload {
+		return ctx.Status(fiber.StatusBadRequest).
+			JSON(apps.BusinessErrorResponse(ex))
+	}
 	if ex != nil && ex.ErrorCode == apps.ErrCodeNotFound {
 		return ctx.Status(fiber.StatusOK).
 			JSON(apps.BusinessErrorResponse(ex))
@@ -91,3 +120,80 @@ func (pt *PartnerTransaction) detail(ctx *fiber.Ctx) error {
 	}
 	return ctx.JSON(apps.DefaultSuccessResponse(apps.SuccessMsgDataFound, v))
 }
+
+// @Tags Transaction Partner APIs
+// API Transaction Receipt
+// @Summary API Transaction Receipt
+// @Description API to download the PDF cashback receipt of a transaction by partner
+// @Schemes
+// @Produce application/pdf
+// @Param Authorization header string true "Your Token to Access" default(Bearer )
+// @Param x-client-channel header string true "Client Channel" Enums(EBIZKEZBEK, B2BCLIENT)
+// @Param x-client-os  header string true "Client OS or Browser Agent" default(android 10)
+// @Param x-client-device  header string true "Client Device ID"
+// @Param x-client-version  header string true "Client Platform Version" default(1.0.0)
+// @Param x-client-timestamp  header string false "Client Original Timestamp in UNIX format (EPOCH)"
+// @Param id path int true "Transaction ID"
+// @Success 200 {file} file
+// @Failure 401 {object} model.Meta
+// @Failure 403 {object} model.Meta
+// @Failure 404 {object} model.Meta
+// @Failure 500 {object} model.Meta
+// @Failure 503 {object} model.Meta
+// @Router /partner/v1/transactions/{id}/receipt [get]
+func (pt *PartnerTransaction) receipt(ctx *fiber.Ctx) error {
+	id, _ := strconv.ParseInt(ctx.Params("id"), 10, 64)
+	v, ex := pt.Receipt(&model.FindByIdRequest{
+		Id:             id,
+		SessionRequest: middleware.ClientSession(ctx),
+	})
+	if ex != nil && ex.ErrorCode == apps.ErrCodeNotFound {
+		return ctx.Status(fiber.StatusNotFound).JSON(apps.BusinessErrorResponse(ex))
+	}
+	if ex != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(apps.BusinessErrorResponse(ex))
+	}
+	ctx.Set(fiber.HeaderContentType, "application/pdf")
+	ctx.Set(fiber.HeaderContentDisposition, `attachment; filename="`+v.Filename+`"`)
+	return ctx.Send(v.Content)
+}
+
+// @Tags Transaction Partner APIs
+// API Transaction Export
+// @Summary API Transaction Export
+// @Description API to export the transactions matching the search filters into a CSV or XLSX file, the file is generated asynchronously and its time-limited download link is emailed to the officer
+// @Schemes
+// @Accept json
+// @Param Authorization header string true "Your Token to Access" default(Bearer )
+// @Param x-client-channel header string true "Client Channel" Enums(EBIZKEZBEK, B2BCLIENT)
+// @Param x-client-os  header string true "Client OS or Browser Agent" default(android 10)
+// @Param x-client-device  header string true "Client Device ID"
+// @Param x-client-version  header string true "Client Platform Version" default(1.0.0)
+// @Param x-client-timestamp  header string false "Client Original Timestamp in UNIX format (EPOCH)"
+// @Param Payload body model.TransactionExportRequest true "Export Payload"
+// @Success 200 {object} model.TransactionResponse
+// @Failure 400 {object} model.Meta
+// @Failure 401 {object} model.Meta
+// @Failure 403 {object} model.Meta
+// @Failure 500 {object} model.Meta
+// @Failure 503 {object} model.Meta
+// @Router /partner/v1/transactions/export [post]
+func (pt *PartnerTransaction) export(ctx *fiber.Ctx) error {
+	inp := model.TransactionExportRequest{}
+	if err := ctx.BodyParser(&inp); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	inp.Format = strings.ToUpper(inp.Format)
+	if bad := apps.ValidateStruct(checker.Struct(inp)); bad != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(bad)
+	}
+	if bad := validateTransactionSearch(&inp.PartnerTransactionSearchRequest); bad != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(bad)
+	}
+	inp.SessionRequest = middleware.ClientSession(ctx)
+	v, ex := pt.Export(&inp)
+	if ex != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(apps.BusinessErrorResponse(ex))
+	}
+	return ctx.JSON(apps.DefaultSuccessResponse(apps.SuccessMsgSubmit, v))
+}
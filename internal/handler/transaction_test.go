@@ -13,6 +13,7 @@ import (
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -41,16 +42,18 @@ func TestPartnerTransactionHandler(t *testing.T) {
 		"cognito:username": "someone",
 	}
 
-	id := int64(1)
-	cauth := model.ClientAuthenticationResponse{
-		Id:      &id,
+	cauth := model.OfficerValidationResponse{
+		Id:      int64(1),
 		Code:    "CORP_A",
 		Company: "Company A",
+		Email:   "someone@email.net",
+		Role:    apps.RoleOfficerViewer,
 	}
 	c, _ := json.Marshal(cauth)
 
 	t.Run("should return 200 success to search transaction", func(t *testing.T) {
-		cacher.EXPECT().Get(gomock.Any(), gomock.Any()).Return(string(c), nil)
+		cacher.EXPECT().Get("B2BTOKEN", apps.Hash("*secret*")).Return(cauth.Email, nil)
+		cacher.EXPECT().Get("B2BSESSION", cauth.Email).Return(string(c), nil)
 		ciamPartner.EXPECT().JwtInfo(gomock.Any()).Return(jwtInfo, nil)
 		transactionProvider.EXPECT().Search(gomock.Any()).Return(&model.PartnerTransactionSearchResponse{
 			Transactions: []model.PartnerTransactionProjection{
@@ -81,7 +84,8 @@ func TestPartnerTransactionHandler(t *testing.T) {
 	})
 
 	t.Run("should return 200 failed to search transaction", func(t *testing.T) {
-		cacher.EXPECT().Get(gomock.Any(), gomock.Any()).Return(string(c), nil)
+		cacher.EXPECT().Get("B2BTOKEN", apps.Hash("*secret*")).Return(cauth.Email, nil)
+		cacher.EXPECT().Get("B2BSESSION", cauth.Email).Return(string(c), nil)
 		ciamPartner.EXPECT().JwtInfo(gomock.Any()).Return(jwtInfo, nil)
 		transactionProvider.EXPECT().Search(gomock.Any()).Return(nil, &model.BusinessError{
 			ErrorCode:    apps.ErrCodeNotFound,
@@ -102,8 +106,87 @@ func TestPartnerTransactionHandler(t *testing.T) {
 		assert.Equal(t, apps.ErrCodeNotFound, m.Meta.Code)
 	})
 
+	t.Run("should return 400 on malformed cursor to search transaction", func(t *testing.T) {
+		cacher.EXPECT().Get("B2BTOKEN", apps.Hash("*secret*")).Return(cauth.Email, nil)
+		cacher.EXPECT().Get("B2BSESSION", cauth.Email).Return(string(c), nil)
+		ciamPartner.EXPECT().JwtInfo(gomock.Any()).Return(jwtInfo, nil)
+		transactionProvider.EXPECT().Search(gomock.Any()).Return(nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeBadPayload,
+			ErrorMessage: apps.ErrMsgBadPayload,
+		})
+		req := httptest.NewRequest(fiber.MethodGet, "/api/partner/v1/transactions?limit=5&cursor=n0tacurs0r", nil)
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		req.Header.Add(fiber.HeaderAuthorization, "Bearer *secret*")
+		req.Header.Add(apps.HeaderClientChannel, apps.ChannelEBizKezbek)
+		req.Header.Add(apps.HeaderClientDeviceId, "f-123-456")
+		req.Header.Add(apps.HeaderClientOs, "Android 10")
+		req.Header.Add(apps.HeaderClientVersion, "1.0.0")
+		res, _ := api.Test(req, 100)
+		m := model.Response{}
+		_ = json.NewDecoder(res.Body).Decode(&m)
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+		assert.Equal(t, apps.ErrCodeBadPayload, m.Meta.Code)
+	})
+
+	t.Run("should return 200 success to search transaction with filters", func(t *testing.T) {
+		cacher.EXPECT().Get("B2BTOKEN", apps.Hash("*secret*")).Return(cauth.Email, nil)
+		cacher.EXPECT().Get("B2BSESSION", cauth.Email).Return(string(c), nil)
+		ciamPartner.EXPECT().JwtInfo(gomock.Any()).Return(jwtInfo, nil)
+		transactionProvider.EXPECT().Search(gomock.Any()).DoAndReturn(
+			func(inp *model.PartnerTransactionSearchRequest) (*model.PartnerTransactionSearchResponse, *model.BusinessError) {
+				assert.Equal(t, "2023-01-01", inp.StartDate)
+				assert.Equal(t, "LSAJA", inp.WalletCode)
+				assert.Equal(t, apps.TransactionFailed, inp.Status)
+				assert.Equal(t, "CASHBACK", inp.SortBy)
+				assert.Equal(t, "eyJrIjoiMTAiLCJpIjoxMH0", inp.Cursor)
+				assert.True(t, inp.SkipCount)
+				return &model.PartnerTransactionSearchResponse{}, nil
+			})
+		req := httptest.NewRequest(fiber.MethodGet, "/api/partner/v1/transactions?limit=5&start_date=2023-01-01"+
+			"&wallet_code=lsaja&status=failed&sort_by=cashback&sort=asc&cursor=eyJrIjoiMTAiLCJpIjoxMH0&skip_count=true", nil)
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		req.Header.Add(fiber.HeaderAuthorization, "Bearer *secret*")
+		req.Header.Add(apps.HeaderClientChannel, apps.ChannelEBizKezbek)
+		req.Header.Add(apps.HeaderClientDeviceId, "f-123-456")
+		req.Header.Add(apps.HeaderClientOs, "Android 10")
+		req.Header.Add(apps.HeaderClientVersion, "1.0.0")
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	})
+
+	t.Run("should return 400 on search sorted by unknown field", func(t *testing.T) {
+		cacher.EXPECT().Get("B2BTOKEN", apps.Hash("*secret*")).Return(cauth.Email, nil)
+		cacher.EXPECT().Get("B2BSESSION", cauth.Email).Return(string(c), nil)
+		ciamPartner.EXPECT().JwtInfo(gomock.Any()).Return(jwtInfo, nil)
+		req := httptest.NewRequest(fiber.MethodGet, "/api/partner/v1/transactions?sort_by=t.partner_id", nil)
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		req.Header.Add(fiber.HeaderAuthorization, "Bearer *secret*")
+		req.Header.Add(apps.HeaderClientChannel, apps.ChannelEBizKezbek)
+		req.Header.Add(apps.HeaderClientDeviceId, "f-123-456")
+		req.Header.Add(apps.HeaderClientOs, "Android 10")
+		req.Header.Add(apps.HeaderClientVersion, "1.0.0")
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("should return 400 on search with invalid amount", func(t *testing.T) {
+		cacher.EXPECT().Get("B2BTOKEN", apps.Hash("*secret*")).Return(cauth.Email, nil)
+		cacher.EXPECT().Get("B2BSESSION", cauth.Email).Return(string(c), nil)
+		ciamPartner.EXPECT().JwtInfo(gomock.Any()).Return(jwtInfo, nil)
+		req := httptest.NewRequest(fiber.MethodGet, "/api/partner/v1/transactions?min_cashback=1e3;select", nil)
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		req.Header.Add(fiber.HeaderAuthorization, "Bearer *secret*")
+		req.Header.Add(apps.HeaderClientChannel, apps.ChannelEBizKezbek)
+		req.Header.Add(apps.HeaderClientDeviceId, "f-123-456")
+		req.Header.Add(apps.HeaderClientOs, "Android 10")
+		req.Header.Add(apps.HeaderClientVersion, "1.0.0")
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+	})
+
 	t.Run("should return 200 success to view detail transaction", func(t *testing.T) {
-		cacher.EXPECT().Get(gomock.Any(), gomock.Any()).Return(string(c), nil)
+		cacher.EXPECT().Get("B2BTOKEN", apps.Hash("*secret*")).Return(cauth.Email, nil)
+		cacher.EXPECT().Get("B2BSESSION", cauth.Email).Return(string(c), nil)
 		ciamPartner.EXPECT().JwtInfo(gomock.Any()).Return(jwtInfo, nil)
 		transactionProvider.EXPECT().Detail(gomock.Any()).Return(&model.PartnerTransactionProjection{
 			Transaction: decimal.NewFromInt(385000),
@@ -130,7 +213,8 @@ func TestPartnerTransactionHandler(t *testing.T) {
 	})
 
 	t.Run("should return 200 failed to view detail transaction", func(t *testing.T) {
-		cacher.EXPECT().Get(gomock.Any(), gomock.Any()).Return(string(c), nil)
+		cacher.EXPECT().Get("B2BTOKEN", apps.Hash("*secret*")).Return(cauth.Email, nil)
+		cacher.EXPECT().Get("B2BSESSION", cauth.Email).Return(string(c), nil)
 		ciamPartner.EXPECT().JwtInfo(gomock.Any()).Return(jwtInfo, nil)
 		transactionProvider.EXPECT().Detail(gomock.Any()).Return(nil, &model.BusinessError{
 			ErrorCode:    apps.ErrCodeNotFound,
@@ -150,4 +234,165 @@ func TestPartnerTransactionHandler(t *testing.T) {
 		assert.Nil(t, m.Data)
 		assert.Equal(t, apps.ErrCodeNotFound, m.Meta.Code)
 	})
+
+	t.Run("should return 200 with pdf on download receipt", func(t *testing.T) {
+		cacher.EXPECT().Get("B2BTOKEN", apps.Hash("*secret*")).Return(cauth.Email, nil)
+		cacher.EXPECT().Get("B2BSESSION", cauth.Email).Return(string(c), nil)
+		ciamPartner.EXPECT().JwtInfo(gomock.Any()).Return(jwtInfo, nil)
+		transactionProvider.EXPECT().Receipt(gomock.Any()).DoAndReturn(
+			func(inp *model.FindByIdRequest) (*model.ReceiptResponse, *model.BusinessError) {
+				assert.Equal(t, int64(1), inp.Id)
+				assert.Equal(t, cauth.Code, inp.SessionRequest.Username)
+				return &model.ReceiptResponse{Filename: "TRX0012345678.pdf", Content: []byte("%PDF-1.4")}, nil
+			})
+		req := httptest.NewRequest(fiber.MethodGet, "/api/partner/v1/transactions/1/receipt", nil)
+		req.Header.Add(fiber.HeaderAuthorization, "Bearer *secret*")
+		req.Header.Add(apps.HeaderClientChannel, apps.ChannelEBizKezbek)
+		req.Header.Add(apps.HeaderClientDeviceId, "f-123-456")
+		req.Header.Add(apps.HeaderClientOs, "Android 10")
+		req.Header.Add(apps.HeaderClientVersion, "1.0.0")
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+		assert.Equal(t, "application/pdf", res.Header.Get(fiber.HeaderContentType))
+		assert.Equal(t, `attachment; filename="TRX0012345678.pdf"`, res.Header.Get(fiber.HeaderContentDisposition))
+	})
+
+	t.Run("should return 404 on download receipt of unknown transaction", func(t *testing.T) {
+		cacher.EXPECT().Get("B2BTOKEN", apps.Hash("*secret*")).Return(cauth.Email, nil)
+		cacher.EXPECT().Get("B2BSESSION", cauth.Email).Return(string(c), nil)
+		ciamPartner.EXPECT().JwtInfo(gomock.Any()).Return(jwtInfo, nil)
+		transactionProvider.EXPECT().Receipt(gomock.Any()).Return(nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeNotFound,
+			ErrorMessage: apps.ErrMsgNotFound,
+		})
+		req := httptest.NewRequest(fiber.MethodGet, "/api/partner/v1/transactions/2/receipt", nil)
+		req.Header.Add(fiber.HeaderAuthorization, "Bearer *secret*")
+		req.Header.Add(apps.HeaderClientChannel, apps.ChannelEBizKezbek)
+		req.Header.Add(apps.HeaderClientDeviceId, "f-123-456")
+		req.Header.Add(apps.HeaderClientOs, "Android 10")
+		req.Header.Add(apps.HeaderClientVersion, "1.0.0")
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusNotFound, res.StatusCode)
+	})
+
+	t.Run("should return 403 on officer without partner role", func(t *testing.T) {
+		nrole := cauth
+		nrole.Role = ""
+		n, _ := json.Marshal(nrole)
+		cacher.EXPECT().Get("B2BTOKEN", apps.Hash("*secret*")).Return(cauth.Email, nil)
+		cacher.EXPECT().Get("B2BSESSION", cauth.Email).Return(string(n), nil)
+		ciamPartner.EXPECT().JwtInfo(gomock.Any()).Return(jwtInfo, nil)
+		req := httptest.NewRequest(fiber.MethodGet, "/api/partner/v1/transactions/1", nil)
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		req.Header.Add(fiber.HeaderAuthorization, "Bearer *secret*")
+		req.Header.Add(apps.HeaderClientChannel, apps.ChannelEBizKezbek)
+		req.Header.Add(apps.HeaderClientDeviceId, "f-123-456")
+		req.Header.Add(apps.HeaderClientOs, "Android 10")
+		req.Header.Add(apps.HeaderClientVersion, "1.0.0")
+		res, _ := api.Test(req, 100)
+		m := model.Response{}
+		_ = json.NewDecoder(res.Body).Decode(&m)
+		assert.Equal(t, fiber.StatusForbidden, res.StatusCode)
+		assert.Equal(t, apps.ErrCodeForbidden, m.Meta.Code)
+	})
+
+	t.Run("should return 200 success to export transaction", func(t *testing.T) {
+		finance := cauth
+		finance.Role = apps.RoleOfficerFinance
+		f, _ := json.Marshal(finance)
+		cacher.EXPECT().Get("B2BTOKEN", apps.Hash("*secret*")).Return(cauth.Email, nil)
+		cacher.EXPECT().Get("B2BSESSION", cauth.Email).Return(string(f), nil)
+		ciamPartner.EXPECT().JwtInfo(gomock.Any()).Return(jwtInfo, nil)
+		transactionProvider.EXPECT().Export(gomock.Any()).DoAndReturn(
+			func(inp *model.TransactionExportRequest) (*model.TransactionResponse, *model.BusinessError) {
+				assert.Equal(t, apps.ExportCSV, inp.Format)
+				assert.Equal(t, int64(1), inp.SessionRequest.Id)
+				v := apps.Transaction("CORP_A")
+				return &v, nil
+			})
+		req := httptest.NewRequest(fiber.MethodPost, "/api/partner/v1/transactions/export",
+			strings.NewReader(`{"format":"csv","text_search":"someone"}`))
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		req.Header.Add(fiber.HeaderAuthorization, "Bearer *secret*")
+		req.Header.Add(apps.HeaderClientChannel, apps.ChannelEBizKezbek)
+		req.Header.Add(apps.HeaderClientDeviceId, "f-123-456")
+		req.Header.Add(apps.HeaderClientOs, "Android 10")
+		req.Header.Add(apps.HeaderClientVersion, "1.0.0")
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	})
+
+	t.Run("should return 400 on unknown export format", func(t *testing.T) {
+		finance := cauth
+		finance.Role = apps.RoleOfficerFinance
+		f, _ := json.Marshal(finance)
+		cacher.EXPECT().Get("B2BTOKEN", apps.Hash("*secret*")).Return(cauth.Email, nil)
+		cacher.EXPECT().Get("B2BSESSION", cauth.Email).Return(string(f), nil)
+		ciamPartner.EXPECT().JwtInfo(gomock.Any()).Return(jwtInfo, nil)
+		req := httptest.NewRequest(fiber.MethodPost, "/api/partner/v1/transactions/export",
+			strings.NewReader(`{"format":"pdf"}`))
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		req.Header.Add(fiber.HeaderAuthorization, "Bearer *secret*")
+		req.Header.Add(apps.HeaderClientChannel, apps.ChannelEBizKezbek)
+		req.Header.Add(apps.HeaderClientDeviceId, "f-123-456")
+		req.Header.Add(apps.HeaderClientOs, "Android 10")
+		req.Header.Add(apps.HeaderClientVersion, "1.0.0")
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("should return 500 on failed to queue export", func(t *testing.T) {
+		admin := cauth
+		admin.Role = apps.RoleOfficerAdmin
+		a, _ := json.Marshal(admin)
+		cacher.EXPECT().Get("B2BTOKEN", apps.Hash("*secret*")).Return(cauth.Email, nil)
+		cacher.EXPECT().Get("B2BSESSION", cauth.Email).Return(string(a), nil)
+		ciamPartner.EXPECT().JwtInfo(gomock.Any()).Return(jwtInfo, nil)
+		transactionProvider.EXPECT().Export(gomock.Any()).Return(nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSomethingWrong,
+			ErrorMessage: apps.ErrMsgSomethingWrong,
+		})
+		req := httptest.NewRequest(fiber.MethodPost, "/api/partner/v1/transactions/export",
+			strings.NewReader(`{"format":"XLSX"}`))
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		req.Header.Add(fiber.HeaderAuthorization, "Bearer *secret*")
+		req.Header.Add(apps.HeaderClientChannel, apps.ChannelEBizKezbek)
+		req.Header.Add(apps.HeaderClientDeviceId, "f-123-456")
+		req.Header.Add(apps.HeaderClientOs, "Android 10")
+		req.Header.Add(apps.HeaderClientVersion, "1.0.0")
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
+	})
+
+	t.Run("should return 403 on viewer to export transaction", func(t *testing.T) {
+		cacher.EXPECT().Get("B2BTOKEN", apps.Hash("*secret*")).Return(cauth.Email, nil)
+		cacher.EXPECT().Get("B2BSESSION", cauth.Email).Return(string(c), nil)
+		ciamPartner.EXPECT().JwtInfo(gomock.Any()).Return(jwtInfo, nil)
+		req := httptest.NewRequest(fiber.MethodPost, "/api/partner/v1/transactions/export",
+			strings.NewReader(`{"format":"CSV"}`))
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		req.Header.Add(fiber.HeaderAuthorization, "Bearer *secret*")
+		req.Header.Add(apps.HeaderClientChannel, apps.ChannelEBizKezbek)
+		req.Header.Add(apps.HeaderClientDeviceId, "f-123-456")
+		req.Header.Add(apps.HeaderClientOs, "Android 10")
+		req.Header.Add(apps.HeaderClientVersion, "1.0.0")
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusForbidden, res.StatusCode)
+	})
+
+	t.Run("should return 401 on unknown officer token", func(t *testing.T) {
+		cacher.EXPECT().Get("B2BTOKEN", apps.Hash("*secret*")).Return("", &model.TechnicalError{
+			Exception: "redis: nil",
+		})
+		ciamPartner.EXPECT().JwtInfo(gomock.Any()).Return(jwtInfo, nil)
+		req := httptest.NewRequest(fiber.MethodGet, "/api/partner/v1/transactions/1", nil)
+		req.Header.Add(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		req.Header.Add(fiber.HeaderAuthorization, "Bearer *secret*")
+		req.Header.Add(apps.HeaderClientChannel, apps.ChannelEBizKezbek)
+		req.Header.Add(apps.HeaderClientDeviceId, "f-123-456")
+		req.Header.Add(apps.HeaderClientOs, "Android 10")
+		req.Header.Add(apps.HeaderClientVersion, "1.0.0")
+		res, _ := api.Test(req, 100)
+		assert.Equal(t, fiber.StatusUnauthorized, res.StatusCode)
+	})
 }
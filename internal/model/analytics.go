@@ -0,0 +1,132 @@
+package model
+
+import "github.com/shopspring/decimal"
+
+type (
+	CashbackVolumeProjection struct {
+		Date        string          `json:"date" example:"2023-01-01"`
+		WalletCode  string          `json:"wallet_code" example:"LSAJA"`
+		Provider    string          `json:"provider" example:"XENIT"`
+		Total       int             `json:"total" example:"120"`
+		Transaction decimal.Decimal `json:"transaction" example:"30000000"`
+		Cashback    decimal.Decimal `json:"cashback" example:"300000"`
+		Reward      decimal.Decimal `json:"reward" example:"26000"`
+	}
+
+	CustomerSummaryProjection struct {
+		Transactions    int             `json:"transactions" example:"480"`
+		UniqueCustomers int             `json:"unique_customers" example:"215"`
+		Transaction     decimal.Decimal `json:"transaction" example:"120000000"`
+		AverageBasket   decimal.Decimal `json:"average_basket" example:"250000"`
+		AverageQty      decimal.Decimal `json:"average_qty" example:"2.4"`
+	}
+
+	TierDistributionProjection struct {
+		Tier      string `json:"tier" example:"GOLD"`
+		Customers int    `json:"customers" example:"42"`
+	}
+
+	ProviderSavingsProjection struct {
+		WalletCode     string          `json:"wallet_code,omitempty" example:"LSAJA"`
+		Provider       string          `json:"provider" example:"XENIT"`
+		Total          int             `json:"total" example:"120"`
+		Failovers      int             `json:"failovers" example:"3"`
+		FeePaid        decimal.Decimal `json:"fee_paid" example:"60000"`
+		HighestFee     decimal.Decimal `json:"highest_fee" example:"108000"`
+		DefaultFee     decimal.Decimal `json:"default_fee" example:"90000"`
+		Savings        decimal.Decimal `json:"savings" example:"48000"`
+		DefaultSavings decimal.Decimal `json:"default_savings" example:"30000"`
+	}
+
+	DashboardSnapshotProjection struct {
+		WalletCode   string          `json:"wallet_code" example:"LSAJA"`
+		Tier         string          `json:"tier" example:"GOLD"`
+		Transactions int             `json:"transactions" example:"120"`
+		Spend        decimal.Decimal `json:"spend" example:"30000000"`
+		Cashback     decimal.Decimal `json:"cashback" example:"300000"`
+		Reward       decimal.Decimal `json:"reward" example:"26000"`
+	}
+
+	DashboardCustomerProjection struct {
+		UniqueCustomers    int `json:"unique_customers" example:"215"`
+		ReturningCustomers int `json:"returning_customers" example:"87"`
+	}
+)
+
+type (
+	AnalyticsRequest struct {
+		StartDate string `json:"start_date" query:"start_date" example:"2023-01-01" validate:"omitempty,datetime=2006-01-02"`
+		EndDate   string `json:"end_date" query:"end_date" example:"2023-01-31" validate:"omitempty,datetime=2006-01-02"`
+		PartnerId int64  `json:"partner_id" query:"partner_id" example:"1"`
+		SessionRequest
+	}
+)
+
+type (
+	CashbackVolumeResponse struct {
+		StartDate string                     `json:"start_date" example:"2023-01-01"`
+		EndDate   string                     `json:"end_date" example:"2023-01-31"`
+		Volumes   []CashbackVolumeProjection `json:"volumes"`
+	}
+
+	CustomerSummaryResponse struct {
+		StartDate string `json:"start_date" example:"2023-01-01"`
+		EndDate   string `json:"end_date" example:"2023-01-31"`
+		CustomerSummaryProjection
+	}
+
+	TierDistributionResponse struct {
+		Tiers []TierDistributionProjection `json:"tiers"`
+	}
+
+	ProviderSavingsWallet struct {
+		WalletCode     string                      `json:"wallet_code" example:"LSAJA"`
+		Total          int                         `json:"total" example:"120"`
+		Failovers      int                         `json:"failovers" example:"3"`
+		FeePaid        decimal.Decimal             `json:"fee_paid" example:"60000"`
+		HighestFee     decimal.Decimal             `json:"highest_fee" example:"108000"`
+		DefaultFee     decimal.Decimal             `json:"default_fee" example:"90000"`
+		Savings        decimal.Decimal             `json:"savings" example:"48000"`
+		DefaultSavings decimal.Decimal             `json:"default_savings" example:"30000"`
+		Providers      []ProviderSavingsProjection `json:"providers"`
+	}
+
+	ProviderSavingsResponse struct {
+		StartDate       string                  `json:"start_date" example:"2023-01-01"`
+		EndDate         string                  `json:"end_date" example:"2023-01-31"`
+		DefaultProvider string                  `json:"default_provider" example:"XENIT"`
+		Wallets         []ProviderSavingsWallet `json:"wallets"`
+	}
+
+	KpiMetric struct {
+		Current  decimal.Decimal  `json:"current" example:"120"`
+		Previous decimal.Decimal  `json:"previous" example:"100"`
+		Growth   *decimal.Decimal `json:"growth" example:"20"`
+	}
+
+	DashboardTier struct {
+		Tier         string    `json:"tier" example:"GOLD"`
+		Transactions KpiMetric `json:"transactions"`
+	}
+
+	DashboardWallet struct {
+		WalletCode   string    `json:"wallet_code" example:"LSAJA"`
+		Transactions KpiMetric `json:"transactions"`
+		Spend        KpiMetric `json:"spend"`
+	}
+
+	DashboardResponse struct {
+		StartDate          string            `json:"start_date" example:"2023-01-01"`
+		EndDate            string            `json:"end_date" example:"2023-01-31"`
+		PrevStartDate      string            `json:"prev_start_date" example:"2022-12-01"`
+		PrevEndDate        string            `json:"prev_end_date" example:"2022-12-31"`
+		Transactions       KpiMetric         `json:"transactions"`
+		Spend              KpiMetric         `json:"spend"`
+		Cashback           KpiMetric         `json:"cashback"`
+		Reward             KpiMetric         `json:"reward"`
+		UniqueCustomers    KpiMetric         `json:"unique_customers"`
+		ReturningCustomers KpiMetric         `json:"returning_customers"`
+		Tiers              []DashboardTier   `json:"tiers"`
+		TopWallets         []DashboardWallet `json:"top_wallets"`
+	}
+)
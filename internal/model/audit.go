@@ -0,0 +1,14 @@
+package model
+
+import "database/sql"
+
+type (
+	Audit struct {
+		Id        int64          `json:"id" db:"id"`
+		Activity  sql.NullString `json:"activity" db:"activity"`
+		Actor     sql.NullString `json:"actor" db:"actor"`
+		Reference sql.NullString `json:"reference" db:"reference"`
+		Detail    sql.NullString `json:"detail" db:"detail"`
+		BaseEntity
+	}
+)
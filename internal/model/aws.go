@@ -17,6 +17,11 @@ type (
 		Secret   string
 	}
 
+	CiamRefreshRequest struct {
+		Username     string
+		RefreshToken string
+	}
+
 	S3UploadRequest struct {
 		ContentType string
 		Source      io.Reader
@@ -27,6 +32,14 @@ type (
 		Destination string
 		Subject     string
 		Content     string
+		Text        string            `json:",omitempty"`
+		Attachments []EmailAttachment `json:",omitempty"`
+	}
+
+	EmailAttachment struct {
+		Filename    string
+		ContentType string
+		Content     []byte
 	}
 )
 
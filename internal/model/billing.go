@@ -0,0 +1,75 @@
+package model
+
+import (
+	"database/sql"
+	"github.com/shopspring/decimal"
+)
+
+type (
+	Invoice struct {
+		Id               int64           `json:"id" db:"id"`
+		PartnerId        int64           `json:"partner_id" db:"partner_id"`
+		InvoiceNo        sql.NullString  `json:"invoice_no" db:"invoice_no"`
+		Period           sql.NullString  `json:"period" db:"period"`
+		Transactions     int             `json:"transactions" db:"transactions"`
+		Cashback         decimal.Decimal `json:"cashback" db:"cashback"`
+		Reward           decimal.Decimal `json:"reward" db:"reward"`
+		ProviderFee      decimal.Decimal `json:"provider_fee" db:"provider_fee"`
+		ServiceFee       decimal.Decimal `json:"service_fee" db:"service_fee"`
+		Tax              decimal.Decimal `json:"tax" db:"tax"`
+		Total            decimal.Decimal `json:"total" db:"total"`
+		Status           sql.NullString  `json:"status" db:"status"`
+		Path             sql.NullString  `json:"path" db:"path"`
+		DueDate          sql.NullTime    `json:"due_date" db:"due_date"`
+		PaymentReference sql.NullString  `json:"payment_reference" db:"payment_reference"`
+		BaseEntity
+	}
+
+	BillingSummaryProjection struct {
+		PartnerId    int64           `db:"partner_id"`
+		PartnerCode  string          `db:"partner_code"`
+		Partner      string          `db:"partner"`
+		Email        string          `db:"email"`
+		Locale       string          `db:"locale"`
+		Address      string          `db:"address"`
+		Transactions int             `db:"transactions"`
+		Cashback     decimal.Decimal `db:"cashback"`
+		Reward       decimal.Decimal `db:"reward"`
+		ProviderFee  decimal.Decimal `db:"provider_fee"`
+	}
+
+	InvoiceProjection struct {
+		Id               int64           `json:"id" example:"1"`
+		InvoiceNo        string          `json:"invoice_no" example:"INV/LAJADA/202301"`
+		Period           string          `json:"period" example:"2023-01"`
+		Transactions     int             `json:"transactions" example:"480"`
+		Cashback         decimal.Decimal `json:"cashback" example:"1200000"`
+		Reward           decimal.Decimal `json:"reward" example:"260000"`
+		ProviderFee      decimal.Decimal `json:"provider_fee" example:"480000"`
+		ServiceFee       decimal.Decimal `json:"service_fee" example:"73000"`
+		Tax              decimal.Decimal `json:"tax" example:"60830"`
+		Total            decimal.Decimal `json:"total" example:"2073830"`
+		Status           string          `json:"status" example:"UNPAID" enums:"UNPAID,PAID,OVERDUE"`
+		Path             string          `json:"-"`
+		Url              string          `json:"url" example:"https://cdn.kezbek.id/invoice/1/INV-LAJADA-202301-8f2d0a6c1b9e4d57.pdf"`
+		PaymentReference string          `json:"payment_reference,omitempty" example:"TRF/2023/02/0001"`
+		IssuedDate       int64           `json:"issued_date" example:"1675209600"`
+		DueDate          int64           `json:"due_date" example:"1676419200"`
+		PaidDate         int64           `json:"paid_date,omitempty" example:"1676332800"`
+	}
+)
+
+type (
+	InvoicePaymentRequest struct {
+		Id        int64  `json:"id" swaggerignore:"true"`
+		Reference string `json:"reference" example:"TRF/2023/02/0001" validate:"required"`
+		SessionRequest
+	}
+)
+
+type (
+	InvoiceSearchResponse struct {
+		Invoices []InvoiceProjection `json:"invoices,omitempty"`
+		PaginationResponse
+	}
+)
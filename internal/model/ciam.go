@@ -0,0 +1,19 @@
+package model
+
+import "database/sql"
+
+type (
+	CiamUser struct {
+		Id           int64          `json:"id" db:"id"`
+		SubId        sql.NullString `json:"sub_id" db:"sub_id"`
+		Username     sql.NullString `json:"username" db:"username"`
+		Name         sql.NullString `json:"name" db:"name"`
+		Email        sql.NullString `json:"email" db:"email"`
+		PhoneNumber  sql.NullString `json:"phone_number" db:"phone_number"`
+		Picture      sql.NullString `json:"picture" db:"picture"`
+		Password     sql.NullString `json:"-" db:"password"`
+		TokenVersion int64          `json:"token_version" db:"token_version"`
+		Status       int            `json:"status" db:"status"`
+		BaseEntity
+	}
+)
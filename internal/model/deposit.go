@@ -0,0 +1,62 @@
+package model
+
+import (
+	"database/sql"
+	"github.com/shopspring/decimal"
+)
+
+type (
+	DepositMovement struct {
+		Id        int64           `json:"id" db:"id"`
+		PartnerId int64           `json:"partner_id" db:"partner_id"`
+		Kind      sql.NullString  `json:"kind" db:"kind"`
+		Amount    decimal.Decimal `json:"amount" db:"amount"`
+		Reference sql.NullString  `json:"reference" db:"reference"`
+		BaseEntity
+	}
+
+	DepositProjection struct {
+		PartnerId int64           `db:"partner_id"`
+		Code      string          `db:"code"`
+		Partner   string          `db:"partner"`
+		Email     string          `db:"email"`
+		Msisdn    string          `db:"msisdn"`
+		Locale    string          `db:"locale"`
+		Balance   decimal.Decimal `db:"balance"`
+		Held      decimal.Decimal `db:"held"`
+		Available decimal.Decimal `db:"available"`
+		Threshold decimal.Decimal `db:"threshold"`
+	}
+)
+
+type (
+	DepositTopUpRequest struct {
+		Code      string              `json:"code" swaggerignore:"true" validate:"required"`
+		Amount    decimal.Decimal     `json:"amount" swaggertype:"number" example:"5000000"`
+		Threshold decimal.NullDecimal `json:"threshold" swaggertype:"number" example:"1000000"`
+		Reference string              `json:"reference" example:"TRF/2023/01/0001" validate:"required"`
+		SessionRequest
+	}
+)
+
+type (
+	DepositResponse struct {
+		Code       string          `json:"code" example:"LAJADA"`
+		Balance    decimal.Decimal `json:"balance" example:"5000000"`
+		Held       decimal.Decimal `json:"held" example:"16000"`
+		Available  decimal.Decimal `json:"available" example:"4984000"`
+		Threshold  decimal.Decimal `json:"threshold" example:"1000000"`
+		LowBalance bool            `json:"low_balance" example:"false"`
+	}
+)
+
+func Deposit(v DepositProjection) DepositResponse {
+	return DepositResponse{
+		Code:       v.Code,
+		Balance:    v.Balance,
+		Held:       v.Held,
+		Available:  v.Available,
+		Threshold:  v.Threshold,
+		LowBalance: v.Available.LessThan(v.Threshold),
+	}
+}
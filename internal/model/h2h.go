@@ -54,7 +54,9 @@ type (
 	}
 
 	H2HTransactionResponse struct {
-		HostCode string `json:"host_code" example:"LSAJAH2H"`
+		HostCode string          `json:"host_code" example:"LSAJAH2H"`
+		Fee      decimal.Decimal `json:"fee" example:"500"`
+		Failover bool            `json:"failover" example:"false"`
 		TransactionResponse
 	}
 )
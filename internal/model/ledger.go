@@ -0,0 +1,109 @@
+package model
+
+import (
+	"database/sql"
+	"github.com/shopspring/decimal"
+)
+
+type (
+	LedgerJournal struct {
+		Id          int64          `json:"id" db:"id"`
+		Kind        sql.NullString `json:"kind" db:"kind"`
+		Reference   sql.NullString `json:"reference" db:"reference"`
+		ReversalOf  sql.NullInt64  `json:"reversal_of" db:"reversal_of"`
+		Description sql.NullString `json:"description" db:"description"`
+		Entries     []LedgerEntry  `json:"entries" db:"-"`
+		BaseEntity
+	}
+
+	LedgerEntry struct {
+		Id          int64           `json:"id" db:"id"`
+		JournalId   int64           `json:"journal_id" db:"journal_id"`
+		AccountType sql.NullString  `json:"account_type" db:"account_type"`
+		AccountCode sql.NullString  `json:"account_code" db:"account_code"`
+		Debit       decimal.Decimal `json:"debit" db:"debit"`
+		Credit      decimal.Decimal `json:"credit" db:"credit"`
+	}
+
+	LedgerJournalProjection struct {
+		Id         int64  `db:"id"`
+		Kind       string `db:"kind"`
+		Reference  string `db:"reference"`
+		ReversalOf int64  `db:"reversal_of"`
+		Reversed   bool   `db:"reversed"`
+	}
+
+	LedgerBalanceProjection struct {
+		Opening decimal.Decimal `db:"opening"`
+		Debit   decimal.Decimal `db:"debit"`
+		Credit  decimal.Decimal `db:"credit"`
+	}
+
+	LedgerEntryProjection struct {
+		Id          int64           `json:"id" example:"1"`
+		JournalId   int64           `json:"journal_id" example:"1"`
+		Kind        string          `json:"kind" example:"CASHBACK" enums:"CASHBACK,REVERSAL,SETTLEMENT"`
+		Reference   string          `json:"reference" example:"C00223010112000062811"`
+		Description string          `json:"description,omitempty" example:"Cashback disbursed by LSAJAH2H"`
+		Debit       decimal.Decimal `json:"debit" example:"16000"`
+		Credit      decimal.Decimal `json:"credit" example:"0"`
+		Balance     decimal.Decimal `json:"balance" example:"16000"`
+		PostedDate  int64           `json:"posted_date" example:"1672617600"`
+	}
+)
+
+type (
+	LedgerRequest struct {
+		AccountType string `json:"account_type" swaggerignore:"true" validate:"required,oneof=PARTNER_RECEIVABLE PROVIDER_PREFUND PROVIDER_FEE CUSTOMER_CASHBACK_PAYABLE"`
+		AccountCode string `json:"account_code" swaggerignore:"true" validate:"required"`
+		StartDate   string `json:"start_date" query:"start_date" example:"2023-01-01" validate:"required,datetime=2006-01-02"`
+		EndDate     string `json:"end_date" query:"end_date" example:"2023-01-31" validate:"required,datetime=2006-01-02"`
+		SearchRequest
+	}
+
+	LedgerReversalRequest struct {
+		Id          int64  `json:"id" swaggerignore:"true"`
+		Description string `json:"description" example:"Cashback was disbursed twice" validate:"required"`
+		SessionRequest
+	}
+)
+
+type (
+	LedgerBalanceResponse struct {
+		AccountType string          `json:"account_type" example:"PARTNER_RECEIVABLE"`
+		AccountCode string          `json:"account_code" example:"LSAJA"`
+		StartDate   string          `json:"start_date" example:"2023-01-01"`
+		EndDate     string          `json:"end_date" example:"2023-01-31"`
+		Opening     decimal.Decimal `json:"opening" example:"0"`
+		Debit       decimal.Decimal `json:"debit" example:"16000"`
+		Credit      decimal.Decimal `json:"credit" example:"0"`
+		Closing     decimal.Decimal `json:"closing" example:"16000"`
+	}
+
+	LedgerStatementResponse struct {
+		LedgerBalanceResponse
+		Entries []LedgerEntryProjection `json:"entries,omitempty"`
+		PaginationResponse
+	}
+)
+
+func LedgerTransfer(debitType string, debitCode string, creditType string, creditCode string,
+	amount decimal.Decimal) []LedgerEntry {
+	if amount.IsZero() {
+		return nil
+	}
+	return []LedgerEntry{
+		{
+			AccountType: sql.NullString{String: debitType, Valid: true},
+			AccountCode: sql.NullString{String: debitCode, Valid: true},
+			Debit:       amount,
+			Credit:      decimal.Zero,
+		},
+		{
+			AccountType: sql.NullString{String: creditType, Valid: true},
+			AccountCode: sql.NullString{String: creditCode, Valid: true},
+			Debit:       decimal.Zero,
+			Credit:      amount,
+		},
+	}
+}
@@ -0,0 +1,176 @@
+package model
+
+import "database/sql"
+
+type (
+	NotificationLog struct {
+		Id                int64          `json:"id" db:"id"`
+		MessageId         sql.NullString `json:"message_id" db:"message_id"`
+		Queue             sql.NullString `json:"queue" db:"queue"`
+		Channel           sql.NullString `json:"channel" db:"channel"`
+		Destination       sql.NullString `json:"destination" db:"destination"`
+		Subject           sql.NullString `json:"subject" db:"subject"`
+		Content           sql.NullString `json:"content" db:"content"`
+		Status            sql.NullString `json:"status" db:"status"`
+		Attempts          int            `json:"attempts" db:"attempts"`
+		ProviderMessageId sql.NullString `json:"provider_message_id" db:"provider_message_id"`
+		Error             sql.NullString `json:"error" db:"error"`
+		BaseEntity
+	}
+
+	NotificationPreference struct {
+		Id       int64          `json:"id" db:"id"`
+		Msisdn   sql.NullString `json:"msisdn" db:"msisdn"`
+		Email    sql.NullString `json:"email" db:"email"`
+		Category sql.NullString `json:"category" db:"category"`
+		OptedOut bool           `json:"opted_out" db:"opted_out"`
+		BaseEntity
+	}
+
+	EmailSuppression struct {
+		Id                int64          `json:"id" db:"id"`
+		Email             sql.NullString `json:"email" db:"email"`
+		Reason            sql.NullString `json:"reason" db:"reason"`
+		Detail            sql.NullString `json:"detail" db:"detail"`
+		ProviderMessageId sql.NullString `json:"provider_message_id" db:"provider_message_id"`
+		BaseEntity
+	}
+
+	SuppressionProjection struct {
+		Email          string `json:"email" example:"john.doe@email.net"`
+		Reason         string `json:"reason" example:"BOUNCE"`
+		Detail         string `json:"detail,omitempty" example:"smtp; 550 5.1.1 user unknown"`
+		SuppressedDate int64  `json:"suppressed_date" example:"1672531200"`
+	}
+
+	NotificationLogProjection struct {
+		Id                int64  `json:"id" example:"1"`
+		Channel           string `json:"channel" example:"EMAIL"`
+		Destination       string `json:"destination" example:"john.doe@email.net"`
+		Subject           string `json:"subject" example:"Your cashback invoice"`
+		Status            string `json:"status" example:"FAILED"`
+		Attempts          int    `json:"attempts" example:"3"`
+		ProviderMessageId string `json:"provider_message_id,omitempty" example:"0100018a-2b3c"`
+		Error             string `json:"error,omitempty" example:"MessageRejected: Email address is not verified"`
+		LastAttempt       int64  `json:"last_attempt" example:"1672531200"`
+	}
+
+	NotificationEvent struct {
+		Event    string
+		Category string
+		Partner  string
+		Msisdn   string
+		Email    string
+		Locale   string
+		Data     map[string]interface{}
+	}
+
+	NotificationRequest struct {
+		Channel     string `json:"Channel,omitempty"`
+		Destination string
+		Subject     string
+		Content     string
+	}
+
+	SendMessageRequest struct {
+		Channel     string
+		Destination string
+		Content     string
+	}
+
+	MessagingGatewayRequest struct {
+		Channel string `json:"channel"`
+		From    string `json:"from"`
+		To      string `json:"to"`
+		Text    string `json:"text"`
+	}
+
+	MessagingGatewayResponse struct {
+		MessageId string `json:"message_id"`
+		Status    string `json:"status"`
+		Error     string `json:"error"`
+	}
+
+	SnsNotification struct {
+		Type    string `json:"Type"`
+		Message string `json:"Message"`
+	}
+
+	SesFeedback struct {
+		NotificationType string       `json:"notificationType"`
+		Bounce           SesBounce    `json:"bounce"`
+		Complaint        SesComplaint `json:"complaint"`
+		Mail             SesMail      `json:"mail"`
+	}
+
+	SesBounce struct {
+		BounceType        string         `json:"bounceType"`
+		BouncedRecipients []SesRecipient `json:"bouncedRecipients"`
+	}
+
+	SesComplaint struct {
+		ComplaintFeedbackType string         `json:"complaintFeedbackType"`
+		ComplainedRecipients  []SesRecipient `json:"complainedRecipients"`
+	}
+
+	SesRecipient struct {
+		EmailAddress   string `json:"emailAddress"`
+		DiagnosticCode string `json:"diagnosticCode"`
+	}
+
+	SesMail struct {
+		MessageId string `json:"messageId"`
+	}
+
+	QueueMessage struct {
+		Id           string
+		Queue        string
+		Body         string
+		ReceiveCount int64
+		Final        bool
+	}
+)
+
+type (
+	NotificationSearchRequest struct {
+		Status string `json:"status" enums:"SENT,FAILED,DEAD,RESENT,SUPPRESSED"`
+		SearchRequest
+	}
+
+	UnsubscribeRequest struct {
+		Token string `json:"token" query:"token" validate:"required"`
+	}
+
+	NotificationResendRequest struct {
+		Ids    []int64 `json:"ids" example:"1,2"`
+		Status string  `json:"status" example:"DEAD" validate:"required_without=Ids,omitempty,oneof=FAILED DEAD"`
+		Limit  int     `json:"limit" example:"100"`
+	}
+)
+
+type (
+	NotificationSearchResponse struct {
+		Notifications []NotificationLogProjection `json:"notifications,omitempty"`
+		PaginationResponse
+	}
+
+	SuppressionSearchResponse struct {
+		Suppressions []SuppressionProjection `json:"suppressions,omitempty"`
+		PaginationResponse
+	}
+
+	UnsubscribeResponse struct {
+		Category string `json:"category" example:"TRANSACTIONAL"`
+		TransactionResponse
+	}
+
+	UnsubscribeConfirmationResponse struct {
+		Category string `json:"category" example:"TRANSACTIONAL"`
+		Token    string `json:"token" example:"NjI4MTE4NzcwNTEwfHxUUkFOU0FDVElPTkFM.a1b2c3"`
+	}
+
+	NotificationResendResponse struct {
+		Total int `json:"total" example:"2"`
+		TransactionResponse
+	}
+)
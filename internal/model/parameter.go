@@ -3,9 +3,34 @@ package model
 import "database/sql"
 
 type Parameter struct {
-	Id         sql.NullInt64  `json:"id"`
-	ParamGroup sql.NullString `json:"param_group"`
-	ParamName  sql.NullString `json:"param_name"`
-	ParamValue sql.NullString `json:"value_param"`
+	Id           sql.NullInt64  `json:"id"`
+	ParamGroup   sql.NullString `json:"param_group"`
+	ParamName    sql.NullString `json:"param_name"`
+	ParamValue   sql.NullString `json:"value_param"`
+	ParamVersion int            `json:"param_version"`
+	ParamLocale  sql.NullString `json:"param_locale"`
 	BaseEntity
 }
+
+type (
+	NotificationContent struct {
+		Subject string `json:"subject"`
+		Content string `json:"content"`
+	}
+
+	TemplatePreviewRequest struct {
+		Name    string                 `json:"name" validate:"required"`
+		Version int                    `json:"version"`
+		Locale  string                 `json:"locale" example:"en"`
+		Subject string                 `json:"subject"`
+		Content string                 `json:"content"`
+		Data    map[string]interface{} `json:"data"`
+	}
+
+	TemplatePreviewResponse struct {
+		Name    string `json:"name"`
+		Version int    `json:"version"`
+		Locale  string `json:"locale"`
+		NotificationContent
+	}
+)
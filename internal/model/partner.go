@@ -18,9 +18,21 @@ type (
 		Officer sql.NullString `json:"officer" db:"officer"`
 		Address sql.NullString `json:"address" db:"address"`
 		Logo    sql.NullString `json:"logo" db:"logo"`
+		Locale  sql.NullString `json:"locale" db:"locale"`
+		Role    sql.NullString `json:"role" db:"role"`
 		Status  int            `json:"status" db:"status"`
 		BaseEntity
 	}
+
+	PartnerOfficer struct {
+		Id        int64          `json:"id" db:"id"`
+		PartnerId int64          `json:"partner_id" db:"partner_id"`
+		Email     sql.NullString `json:"email" db:"email"`
+		Fullname  sql.NullString `json:"fullname" db:"fullname"`
+		Role      sql.NullString `json:"role" db:"role"`
+		Status    int            `json:"status" db:"status"`
+		BaseEntity
+	}
 )
 
 type (
@@ -31,6 +43,7 @@ type (
 		Msisdn  string               `json:"msisdn" validate:"required"`
 		Officer string               `json:"officer" validate:"required"`
 		Address string               `json:"address" validate:"required"`
+		Locale  string               `json:"locale" validate:"omitempty,oneof=id en"`
 		Logo    multipart.FileHeader `swaggerignore:"true" validate:"required"`
 		SessionRequest
 	}
@@ -58,6 +71,18 @@ type (
 		TransactionId string `swaggerignore:"true" validate:"required"`
 		Otp           string `json:"otp" example:"123456" validate:"required"`
 	}
+
+	AddOfficerRequest struct {
+		Email    string `json:"email" example:"jane.doe@lajada.id" validate:"required,email"`
+		Fullname string `json:"fullname" example:"Jane Doe" validate:"required"`
+		Role     string `json:"role" example:"finance" validate:"required,oneof=viewer finance admin"`
+		SessionRequest
+	}
+
+	SessionRefreshRequest struct {
+		Username     string `json:"username" example:"LAJADA" validate:"required"`
+		RefreshToken string `swaggerignore:"true" validate:"required"`
+	}
 )
 
 type (
@@ -65,6 +90,7 @@ type (
 		Id      *int64 `json:"id,omitempty"`
 		Code    string `json:"code"`
 		Company string `json:"company"`
+		Locale  string `json:"locale,omitempty"`
 		SessionResponse
 	}
 
@@ -80,6 +106,15 @@ type (
 		Email   string `json:"email" example:"john.doe@email.net"`
 		Code    string `json:"code" example:"CORPCODE_A"`
 		Company string `json:"company"  example:"Kezbek Indonesia Ltd"`
+		Role    string `json:"role" example:"admin"`
+		Locale  string `json:"locale" example:"id"`
 		SessionResponse
 	}
+
+	OfficerProjection struct {
+		Id       int64  `json:"id" db:"id" example:"1"`
+		Email    string `json:"email" db:"email" example:"jane.doe@lajada.id"`
+		Fullname string `json:"fullname" db:"fullname" example:"Jane Doe"`
+		Role     string `json:"role" db:"role" example:"finance"`
+	}
 )
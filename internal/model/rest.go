@@ -48,21 +48,24 @@ type (
 	}
 
 	SearchRequest struct {
-		TextSearch string `json:"text_search"`
-		Start      int    `json:"start" binding:"required" example:"0"`
-		Limit      int    `json:"limit" binding:"required" example:"5"`
-		SortBy     string `json:"sort_by" `
-		Sort       string `json:"sort" enums:"ASC,DESC"`
+		TextSearch string `json:"text_search" query:"text_search"`
+		Start      int    `json:"start" query:"start" binding:"required" example:"0"`
+		Limit      int    `json:"limit" query:"limit" binding:"required" example:"5"`
+		SortBy     string `json:"sort_by" query:"sort_by"`
+		Sort       string `json:"sort" query:"sort" enums:"ASC,DESC"`
 		SessionRequest
 	}
 
 	SessionRequest struct {
-		Id       int64  `swaggerignore:"true"`
-		Username string `swaggerignore:"true"`
-		Msisdn   string `swaggerignore:"true"`
-		Email    string `swaggerignore:"true"`
-		Role     string `swaggerignore:"true"`
-		Fullname string `swaggerignore:"true"`
+		Id          int64  `swaggerignore:"true"`
+		Username    string `swaggerignore:"true"`
+		Msisdn      string `swaggerignore:"true"`
+		Email       string `swaggerignore:"true"`
+		Role        string `swaggerignore:"true"`
+		Fullname    string `swaggerignore:"true"`
+		Locale      string `swaggerignore:"true"`
+		TokenId     string `swaggerignore:"true"`
+		TokenExpiry int64  `swaggerignore:"true"`
 		ContextRequest
 	}
 
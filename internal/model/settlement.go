@@ -0,0 +1,99 @@
+package model
+
+import (
+	"database/sql"
+	"github.com/shopspring/decimal"
+	"mime/multipart"
+)
+
+type (
+	Settlement struct {
+		Id             int64          `json:"id" db:"id"`
+		H2HCode        sql.NullString `json:"h2h_code" db:"h2h_code"`
+		SettlementDate sql.NullTime   `json:"settlement_date" db:"settlement_date"`
+		Filename       sql.NullString `json:"filename" db:"filename"`
+		Lines          int            `json:"lines" db:"lines"`
+		Matched        int            `json:"matched" db:"matched"`
+		Flagged        int            `json:"flagged" db:"flagged"`
+		BaseEntity
+	}
+
+	SettlementException struct {
+		Id              int64           `json:"id" db:"id"`
+		SettlementId    int64           `json:"settlement_id" db:"settlement_id"`
+		Type            sql.NullString  `json:"type" db:"type"`
+		KezbekRefCode   sql.NullString  `json:"kezbek_ref_code" db:"kezbek_ref_code"`
+		ProviderRefCode sql.NullString  `json:"provider_ref_code" db:"provider_ref_code"`
+		Amount          decimal.Decimal `json:"amount" db:"amount"`
+		Expected        decimal.Decimal `json:"expected" db:"expected"`
+		Status          sql.NullString  `json:"status" db:"status"`
+		Notes           sql.NullString  `json:"notes" db:"notes"`
+		BaseEntity
+	}
+
+	SettlementLine struct {
+		KezbekRefCode   string          `json:"kezbek_ref_code"`
+		ProviderRefCode string          `json:"provider_ref_code"`
+		Amount          decimal.Decimal `json:"amount"`
+	}
+
+	SettlementCashbackProjection struct {
+		Id              int64           `db:"id"`
+		KezbekRefCode   string          `db:"kezbek_ref_code"`
+		ProviderRefCode string          `db:"provider_ref_code"`
+		Amount          decimal.Decimal `db:"amount"`
+		Msisdn          string          `db:"msisdn"`
+		OnDate          bool            `db:"on_date"`
+		Settled         bool            `db:"settled"`
+	}
+
+	SettlementProjection struct {
+		Id             int64  `json:"id" example:"1"`
+		H2HCode        string `json:"h2h_code" db:"h2h_code" example:"LSAJAH2H"`
+		SettlementDate string `json:"settlement_date" example:"2023-01-01"`
+		Filename       string `json:"filename" example:"LSAJAH2H-20230101.csv"`
+		Lines          int    `json:"lines" example:"120"`
+		Matched        int    `json:"matched" example:"118"`
+		Flagged        int    `json:"flagged" example:"2"`
+		Unresolved     int    `json:"unresolved" example:"1"`
+		ImportedDate   int64  `json:"imported_date" example:"1672617600"`
+	}
+
+	SettlementExceptionProjection struct {
+		Id              int64           `json:"id" example:"1"`
+		Type            string          `json:"type" example:"AMOUNT_MISMATCH" enums:"MISSING_CASHBACK,MISSING_SETTLEMENT,DUPLICATE,AMOUNT_MISMATCH"`
+		KezbekRefCode   string          `json:"kezbek_ref_code,omitempty" example:"C00223010112000062811"`
+		ProviderRefCode string          `json:"provider_ref_code,omitempty" example:"trx-001"`
+		Amount          decimal.Decimal `json:"amount" example:"15000"`
+		Expected        decimal.Decimal `json:"expected" example:"16000"`
+		Status          string          `json:"status" example:"OPEN" enums:"OPEN,RESOLVED"`
+		Notes           string          `json:"notes,omitempty" example:"Reward was settled on the next statement"`
+	}
+)
+
+type (
+	SettlementRequest struct {
+		H2HCode        string                `json:"h2h_code" validate:"required"`
+		SettlementDate string                `json:"settlement_date" validate:"required,datetime=2006-01-02"`
+		File           *multipart.FileHeader `swaggerignore:"true" validate:"required"`
+		SessionRequest
+	}
+
+	SettlementResolveRequest struct {
+		Id    int64  `json:"id" swaggerignore:"true"`
+		Notes string `json:"notes" example:"Reward was settled on the next statement" validate:"required"`
+		SessionRequest
+	}
+)
+
+type (
+	SettlementReportResponse struct {
+		SettlementProjection
+		Exceptions []SettlementExceptionProjection `json:"exceptions,omitempty"`
+	}
+
+	SettlementSearchResponse struct {
+		Settlements []SettlementProjection `json:"settlements,omitempty"`
+		PaginationResponse
+	}
+)
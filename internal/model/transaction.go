@@ -2,6 +2,8 @@ package model
 
 import (
 	"database/sql"
+	"encoding/base64"
+	"encoding/json"
 	"github.com/shopspring/decimal"
 )
 
@@ -22,20 +24,26 @@ type (
 	}
 
 	PartnerTransactionProjection struct {
-		Id          int64           `json:"id" example:"1"`
-		WalletCode  string          `json:"wallet_code,omitempty" example:"LSAJA"`
-		Email       string          `json:"email,omitempty" example:"john.doe@email.net"`
-		Msisdn      string          `json:"msisdn,omitempty" example:"628118770510"`
-		Qty         int             `json:"qty,omitempty" example:"2"`
-		Transaction decimal.Decimal `json:"transaction,omitempty" example:"250000"`
-		Cashback    decimal.Decimal `json:"cashback,omitempty" example:"2500"`
-		Reward      decimal.Decimal `json:"reward,omitempty" example:"13000"`
+		Id              int64           `json:"id" example:"1"`
+		KezbekRefCode   string          `json:"kezbek_ref_code,omitempty" example:"TRX0012345678"`
+		WalletCode      string          `json:"wallet_code,omitempty" example:"LSAJA"`
+		Email           string          `json:"email,omitempty" example:"john.doe@email.net"`
+		Msisdn          string          `json:"msisdn,omitempty" example:"628118770510"`
+		Qty             int             `json:"qty,omitempty" example:"2"`
+		Transaction     decimal.Decimal `json:"transaction,omitempty" example:"250000"`
+		Cashback        decimal.Decimal `json:"cashback,omitempty" example:"2500"`
+		Reward          decimal.Decimal `json:"reward,omitempty" example:"13000"`
+		TransactionDate int64           `json:"transaction_date,omitempty" example:"1672531200"`
+		H2HCode         string          `json:"h2h_code,omitempty" db:"h2h_code" example:"XENIT"`
+		Status          string          `json:"status,omitempty" example:"DISBURSED" enums:"DISBURSED,FAILED"`
+		SortKey         string          `json:"-"`
 	}
 )
 
 type (
 	PartnerTransactionSearchResponse struct {
 		Transactions []PartnerTransactionProjection `json:"transactions,omitempty"`
+		NextCursor   string                         `json:"next_cursor,omitempty" example:"eyJrIjoiMTAiLCJpIjoxMH0"`
 		PaginationResponse
 	}
 
@@ -54,6 +62,74 @@ type (
 		Email                string          `json:"email" example:"john.doe@gmailxyz.com"`
 		MerchantCode         string          `json:"merchant_code" example:"LSAJA,GPAID,JOSVO"`
 		TransactionReference string          `json:"transaction_reference" example:"INV/001/002"`
+		Locale               string          `json:"locale" example:"en" validate:"omitempty,oneof=id en"`
 		SessionRequest
 	}
+
+	PartnerTransactionSearchRequest struct {
+		StartDate      string `json:"start_date" query:"start_date" example:"2023-01-01" validate:"omitempty,datetime=2006-01-02"`
+		EndDate        string `json:"end_date" query:"end_date" example:"2023-01-31" validate:"omitempty,datetime=2006-01-02"`
+		WalletCode     string `json:"wallet_code" query:"wallet_code" example:"LSAJA"`
+		H2HCode        string `json:"h2h_code" query:"h2h_code" example:"XENIT"`
+		Status         string `json:"status" query:"status" enums:"DISBURSED,FAILED" default:"DISBURSED" validate:"omitempty,oneof=DISBURSED FAILED"`
+		MinTransaction string `json:"min_transaction" query:"min_transaction" example:"10000" validate:"omitempty,numeric"`
+		MaxTransaction string `json:"max_transaction" query:"max_transaction" example:"500000" validate:"omitempty,numeric"`
+		MinCashback    string `json:"min_cashback" query:"min_cashback" example:"500" validate:"omitempty,numeric"`
+		MaxCashback    string `json:"max_cashback" query:"max_cashback" example:"25000" validate:"omitempty,numeric"`
+		Cursor         string `json:"cursor" query:"cursor"`
+		SkipCount      bool   `json:"skip_count" query:"skip_count"`
+		SearchRequest
+	}
+
+	TransactionExportRequest struct {
+		Format string `json:"format" example:"XLSX" enums:"CSV,XLSX" validate:"required,oneof=CSV XLSX"`
+		PartnerTransactionSearchRequest
+	}
+
+	Cursor struct {
+		Key string `json:"k"`
+		Id  int64  `json:"i"`
+	}
+)
+
+type (
+	ReceiptRequest struct {
+		PartnerId       int64
+		PartnerCode     string
+		Partner         string
+		KezbekRefCode   string
+		WalletCode      string
+		Msisdn          string
+		Email           string
+		Qty             int
+		Transaction     decimal.Decimal
+		Cashback        decimal.Decimal
+		Reward          decimal.Decimal
+		TransactionDate int64
+		Locale          string
+	}
+
+	ReceiptResponse struct {
+		Filename string
+		Path     string
+		Url      string
+		Content  []byte
+	}
 )
+
+func (c Cursor) String() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func ParseCursor(s string) (*Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	c := Cursor{}
+	if err = json.Unmarshal(b, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
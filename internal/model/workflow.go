@@ -3,19 +3,30 @@ package model
 import (
 	"database/sql"
 	"github.com/shopspring/decimal"
+	"time"
 )
 
 type (
 	Cashback struct {
-		Id            int64               `json:"id" db:"id"`
-		KezbekRefCode sql.NullString      `json:"kezbek_ref_code" db:"kezbek_ref_code"`
-		Amount        decimal.NullDecimal `json:"amount" db:"amount"`
-		Reward        decimal.NullDecimal `json:"reward" db:"reward"`
-		WalletCode    sql.NullString      `json:"wallet_code" db:"wallet_code"`
-		H2HCode       sql.NullString      `json:"h2h_code" db:"h2h_code"`
+		Id              int64               `json:"id" db:"id"`
+		KezbekRefCode   sql.NullString      `json:"kezbek_ref_code" db:"kezbek_ref_code"`
+		Amount          decimal.NullDecimal `json:"amount" db:"amount"`
+		Reward          decimal.NullDecimal `json:"reward" db:"reward"`
+		WalletCode      sql.NullString      `json:"wallet_code" db:"wallet_code"`
+		H2HCode         sql.NullString      `json:"h2h_code" db:"h2h_code"`
+		ProviderRefCode sql.NullString      `json:"provider_ref_code" db:"provider_ref_code"`
+		Fee             decimal.NullDecimal `json:"fee" db:"fee"`
+		Failover        bool                `json:"failover" db:"failover"`
 		BaseEntity
 	}
 
+	CashbackReconciliation struct {
+		Capture  *DepositMovement `json:"capture"`
+		Held     decimal.Decimal  `json:"held"`
+		Cashback *Cashback        `json:"cashback"`
+		Journal  LedgerJournal    `json:"journal"`
+	}
+
 	Tier struct {
 		Id                   int64          `json:"id" db:"id"`
 		PartnerId            int64          `json:"partner_id" db:"partner_id"`
@@ -43,6 +54,21 @@ type (
 		BaseEntity
 	}
 
+	TierChangeProjection struct {
+		Id           int64     `json:"id" db:"id"`
+		PartnerId    int64     `json:"partner_id" db:"partner_id"`
+		PartnerCode  string    `json:"partner_code" db:"partner_code"`
+		Partner      string    `json:"partner" db:"partner"`
+		Locale       string    `json:"locale" db:"locale"`
+		Msisdn       string    `json:"msisdn" db:"msisdn"`
+		Email        string    `json:"email" db:"email"`
+		CurrentGrade int       `json:"current_grade" db:"current_grade"`
+		CurrentTier  string    `json:"current_tier" db:"current_tier"`
+		PrevGrade    int       `json:"prev_grade" db:"prev_grade"`
+		PrevTier     string    `json:"prev_tier" db:"prev_tier"`
+		ExpiredDate  time.Time `json:"expired_date" db:"expired_date"`
+	}
+
 	WfRewardTierGradeProjection struct {
 		Tier  *string `json:"tier,omitempty" db:"tier"`
 		Grade *int    `json:"grade,omitempty" db:"grade"`
@@ -62,8 +88,11 @@ type (
 type (
 	TierRequest struct {
 		PartnerId     int64
+		PartnerCode   string
+		Partner       string
 		Msisdn        string
 		Email         string
+		Locale        string
 		TransactionId int64
 	}
 
@@ -77,4 +106,10 @@ type (
 	FindCashbackResponse struct {
 		Amount decimal.Decimal
 	}
+
+	TierChange struct {
+		Tier     *Tier
+		Reward   *WfRewardTierProjection
+		Upgraded bool
+	}
 )
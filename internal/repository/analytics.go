@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/internal/storage"
+	"github.com/georgysavva/scany/pgxscan"
+	"go.uber.org/zap"
+)
+
+type Analytics struct {
+	Pool   storage.Pooler
+	Logger *zap.Logger
+}
+
+type AnalyticsPersister interface {
+	CashbackVolume(inp *model.AnalyticsRequest) ([]model.CashbackVolumeProjection, *model.TechnicalError)
+	CustomerSummary(inp *model.AnalyticsRequest) (*model.CustomerSummaryProjection, *model.TechnicalError)
+	TierDistribution(inp *model.AnalyticsRequest) ([]model.TierDistributionProjection, *model.TechnicalError)
+	ProviderSavings(inp *model.AnalyticsRequest, defaultProvider string) ([]model.ProviderSavingsProjection, *model.TechnicalError)
+	DashboardSnapshot(inp *model.AnalyticsRequest) ([]model.DashboardSnapshotProjection, *model.TechnicalError)
+	DashboardCustomer(inp *model.AnalyticsRequest) (*model.DashboardCustomerProjection, *model.TechnicalError)
+}
+
+func NewAnalytics(a Analytics) AnalyticsPersister {
+	return &a
+}
+
+const analyticsCriteria = ` where t.is_deleted = false and t.created_date >= $1::date
+		and t.created_date < $2::date + 1 and ($3::bigint = 0 or t.partner_id = $3) `
+
+func (a *Analytics) CashbackVolume(inp *model.AnalyticsRequest) ([]model.CashbackVolumeProjection, *model.TechnicalError) {
+	var data []model.CashbackVolumeProjection
+	err := pgxscan.Select(context.Background(), a.Pool, &data, `select to_char(t.created_date, 'YYYY-MM-DD') as date,
+			c.wallet_code, c.h2h_code as provider, count(c.id) as total, sum(t.amount) as transaction,
+			sum(c.amount) as cashback, sum(c.reward) as reward
+			from transactions t inner join cashbacks c on t.kezbek_ref_code = c.kezbek_ref_code`+analyticsCriteria+`
+			group by 1, 2, 3 order by 1, 2, 3`, inp.StartDate, inp.EndDate, inp.PartnerId)
+	if err != nil {
+		return nil, apps.Exception("failed to summarize cashback volume", err, zap.Any("", inp), a.Logger)
+	}
+	return data, nil
+}
+
+func (a *Analytics) CustomerSummary(inp *model.AnalyticsRequest) (*model.CustomerSummaryProjection, *model.TechnicalError) {
+	v := model.CustomerSummaryProjection{}
+	rows, err := a.Pool.Query(context.Background(), `select count(t.id) as transactions,
+			count(distinct t.msisdn) as unique_customers, coalesce(sum(t.amount), 0) as transaction,
+			coalesce(round(avg(t.amount), 2), 0) as average_basket, coalesce(round(avg(t.qty), 2), 0) as average_qty
+			from transactions t`+analyticsCriteria, inp.StartDate, inp.EndDate, inp.PartnerId)
+	if err != nil {
+		return nil, apps.Exception("failed to summarize customer", err, zap.Any("", inp), a.Logger)
+	}
+	defer rows.Close()
+
+	err = pgxscan.ScanOne(&v, rows)
+	if err != nil {
+		return nil, apps.Exception("failed to map customer summary", err, zap.Any("", inp), a.Logger)
+	}
+	return &v, nil
+}
+
+func (a *Analytics) TierDistribution(inp *model.AnalyticsRequest) ([]model.TierDistributionProjection, *model.TechnicalError) {
+	var data []model.TierDistributionProjection
+	err := pgxscan.Select(context.Background(), a.Pool, &data, `select t.current_tier as tier,
+			count(t.id) as customers from tiers t
+			where t.is_deleted = false and t.expired_date > now() and ($1::bigint = 0 or t.partner_id = $1)
+			group by t.current_tier, t.current_grade order by t.current_grade`, inp.PartnerId)
+	if err != nil {
+		return nil, apps.Exception("failed to summarize tier distribution", err, zap.Any("", inp), a.Logger)
+	}
+	return data, nil
+}
+
+func (a *Analytics) ProviderSavings(inp *model.AnalyticsRequest, defaultProvider string) ([]model.ProviderSavingsProjection, *model.TechnicalError) {
+	var data []model.ProviderSavingsProjection
+	err := pgxscan.Select(context.Background(), a.Pool, &data, `select c.wallet_code, c.h2h_code as provider,
+			count(c.id) as total, count(c.id) filter (where c.failover) as failovers,
+			coalesce(sum(coalesce(c.fee, f.paid, 0)), 0) as fee_paid,
+			coalesce(sum(greatest(f.highest, coalesce(c.fee, f.paid, 0))), 0) as highest_fee,
+			coalesce(sum(coalesce(f.fallback, c.fee, f.paid, 0)), 0) as default_fee
+			from transactions t inner join cashbacks c on t.kezbek_ref_code = c.kezbek_ref_code
+			left join lateral (select min(pf.fee) filter (where hp.code = c.h2h_code) as paid,
+				max(pf.fee) as highest, min(pf.fee) filter (where hp.code = $4) as fallback
+				from h2h_provider_fees pf inner join h2h_providers hp on hp.id = pf.h2h_provider_id
+				where pf.wallet_code = c.wallet_code and pf.is_deleted = false and pf.status = $5
+				and hp.is_deleted = false and hp.status = $5) f on true`+analyticsCriteria+`
+			and c.is_deleted = false group by 1, 2 order by 1, 2`,
+		inp.StartDate, inp.EndDate, inp.PartnerId, defaultProvider, apps.StatusActive)
+	if err != nil {
+		return nil, apps.Exception("failed to summarize provider savings", err, zap.Any("", inp), a.Logger)
+	}
+	return data, nil
+}
+
+func (a *Analytics) DashboardSnapshot(inp *model.AnalyticsRequest) ([]model.DashboardSnapshotProjection, *model.TechnicalError) {
+	var data []model.DashboardSnapshotProjection
+	err := pgxscan.Select(context.Background(), a.Pool, &data, `select s.wallet_code, s.tier,
+			sum(s.transactions) as transactions, sum(s.amount) as spend, sum(s.cashback) as cashback,
+			sum(s.reward) as reward from daily_snapshots s
+			where s.snapshot_date >= $1::date and s.snapshot_date <= $2::date and s.partner_id = $3
+			group by 1, 2 order by 1, 2`, inp.StartDate, inp.EndDate, inp.PartnerId)
+	if err != nil {
+		return nil, apps.Exception("failed to summarize dashboard snapshot", err, zap.Any("", inp), a.Logger)
+	}
+	return data, nil
+}
+
+func (a *Analytics) DashboardCustomer(inp *model.AnalyticsRequest) (*model.DashboardCustomerProjection, *model.TechnicalError) {
+	v := model.DashboardCustomerProjection{}
+	rows, err := a.Pool.Query(context.Background(), `select count(distinct t.msisdn) as unique_customers,
+			count(distinct t.msisdn) filter (where exists (select 1 from transactions p
+				where p.partner_id = t.partner_id and p.msisdn = t.msisdn and p.is_deleted = false
+				and p.created_date < $1::date)) as returning_customers
+			from transactions t inner join daily_snapshot_runs r on r.snapshot_date = t.created_date::date
+			and t.created_date <= r.built_date`+analyticsCriteria, inp.StartDate, inp.EndDate, inp.PartnerId)
+	if err != nil {
+		return nil, apps.Exception("failed to summarize dashboard customer", err, zap.Any("", inp), a.Logger)
+	}
+	defer rows.Close()
+
+	err = pgxscan.ScanOne(&v, rows)
+	if err != nil {
+		return nil, apps.Exception("failed to map dashboard customer", err, zap.Any("", inp), a.Logger)
+	}
+	return &v, nil
+}
@@ -0,0 +1,223 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/chrisyxlee/pgxpoolmock"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v4"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAnalytics_CashbackVolume(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool := pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewAnalytics(Analytics{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	inp := &model.AnalyticsRequest{
+		StartDate: "2023-01-01",
+		EndDate:   "2023-01-31",
+		PartnerId: 1,
+	}
+	t.Run("should success", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"date", "wallet_code", "provider", "total", "transaction",
+			"cashback", "reward"}).AddRow("2023-01-01", "LSAJA", "XENIT", 2, decimal.NewFromInt(500000),
+			decimal.NewFromInt(5000), decimal.NewFromInt(13000)).ToPgxRows()
+		pool.EXPECT().Query(ctx, gomock.Any(), inp.StartDate, inp.EndDate, inp.PartnerId).Return(rows, nil)
+		v, ex := persister.CashbackVolume(inp)
+		assert.Nil(t, ex)
+		assert.Len(t, v, 1)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		pool.EXPECT().Query(ctx, gomock.Any(), inp.StartDate, inp.EndDate, inp.PartnerId).
+			Return(nil, fmt.Errorf("something went wrong"))
+		v, ex := persister.CashbackVolume(inp)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
+
+func TestAnalytics_CustomerSummary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool := pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewAnalytics(Analytics{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	inp := &model.AnalyticsRequest{
+		StartDate: "2023-01-01",
+		EndDate:   "2023-01-31",
+	}
+	t.Run("should success", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"transactions", "unique_customers", "transaction",
+			"average_basket", "average_qty"}).AddRow(4, 3, decimal.NewFromInt(1000000),
+			decimal.NewFromInt(250000), decimal.NewFromFloat(2.5)).ToPgxRows()
+		pool.EXPECT().Query(ctx, gomock.Any(), inp.StartDate, inp.EndDate, int64(0)).Return(rows, nil)
+		v, ex := persister.CustomerSummary(inp)
+		assert.Nil(t, ex)
+		assert.Equal(t, 3, v.UniqueCustomers)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		pool.EXPECT().Query(ctx, gomock.Any(), inp.StartDate, inp.EndDate, int64(0)).
+			Return(nil, fmt.Errorf("something went wrong"))
+		v, ex := persister.CustomerSummary(inp)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+
+	t.Run("should return exception on failed to map the result", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"transactions"}).ToPgxRows()
+		pool.EXPECT().Query(ctx, gomock.Any(), inp.StartDate, inp.EndDate, int64(0)).Return(rows, nil)
+		v, ex := persister.CustomerSummary(inp)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
+
+func TestAnalytics_TierDistribution(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool := pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewAnalytics(Analytics{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	inp := &model.AnalyticsRequest{PartnerId: 1}
+	t.Run("should success", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"tier", "customers"}).
+			AddRow("BRONZE", 10).AddRow("GOLD", 2).ToPgxRows()
+		pool.EXPECT().Query(ctx, gomock.Any(), inp.PartnerId).Return(rows, nil)
+		v, ex := persister.TierDistribution(inp)
+		assert.Nil(t, ex)
+		assert.Len(t, v, 2)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		pool.EXPECT().Query(ctx, gomock.Any(), inp.PartnerId).Return(nil, fmt.Errorf("something went wrong"))
+		v, ex := persister.TierDistribution(inp)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
+
+func TestAnalytics_ProviderSavings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool := pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewAnalytics(Analytics{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	inp := &model.AnalyticsRequest{
+		StartDate: "2023-01-01",
+		EndDate:   "2023-01-31",
+	}
+	t.Run("should success", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"wallet_code", "provider", "total", "failovers", "fee_paid",
+			"highest_fee", "default_fee"}).AddRow("LSAJA", "XENIT", 2, 1, decimal.NewFromInt(1000),
+			decimal.NewFromInt(1800), decimal.NewFromInt(1500)).ToPgxRows()
+		pool.EXPECT().Query(ctx, gomock.Any(), inp.StartDate, inp.EndDate, int64(0), "XENIT", apps.StatusActive).
+			Return(rows, nil)
+		v, ex := persister.ProviderSavings(inp, "XENIT")
+		assert.Nil(t, ex)
+		assert.Len(t, v, 1)
+		assert.Equal(t, 1, v[0].Failovers)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		pool.EXPECT().Query(ctx, gomock.Any(), inp.StartDate, inp.EndDate, int64(0), "XENIT", apps.StatusActive).
+			Return(nil, fmt.Errorf("something went wrong"))
+		v, ex := persister.ProviderSavings(inp, "XENIT")
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
+
+func TestAnalytics_DashboardSnapshot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool := pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewAnalytics(Analytics{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	inp := &model.AnalyticsRequest{
+		StartDate: "2023-01-01",
+		EndDate:   "2023-01-31",
+		PartnerId: 1,
+	}
+	t.Run("should success", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"wallet_code", "tier", "transactions", "spend", "cashback",
+			"reward"}).AddRow("LSAJA", "GOLD", 3, decimal.NewFromInt(750000), decimal.NewFromInt(7500),
+			decimal.NewFromInt(13000)).ToPgxRows()
+		pool.EXPECT().Query(ctx, gomock.Any(), inp.StartDate, inp.EndDate, inp.PartnerId).Return(rows, nil)
+		v, ex := persister.DashboardSnapshot(inp)
+		assert.Nil(t, ex)
+		assert.Len(t, v, 1)
+		assert.Equal(t, "GOLD", v[0].Tier)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		pool.EXPECT().Query(ctx, gomock.Any(), inp.StartDate, inp.EndDate, inp.PartnerId).
+			Return(nil, fmt.Errorf("something went wrong"))
+		v, ex := persister.DashboardSnapshot(inp)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
+
+func TestAnalytics_DashboardCustomer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool := pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewAnalytics(Analytics{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	inp := &model.AnalyticsRequest{
+		StartDate: "2023-01-01",
+		EndDate:   "2023-01-31",
+		PartnerId: 1,
+	}
+	t.Run("should success", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"unique_customers", "returning_customers"}).AddRow(5, 2).ToPgxRows()
+		pool.EXPECT().Query(ctx, gomock.Any(), inp.StartDate, inp.EndDate, inp.PartnerId).DoAndReturn(
+			func(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+				assert.Contains(t, sql, "inner join daily_snapshot_runs r on r.snapshot_date = t.created_date::date")
+				return rows, nil
+			})
+		v, ex := persister.DashboardCustomer(inp)
+		assert.Nil(t, ex)
+		assert.Equal(t, 2, v.ReturningCustomers)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		pool.EXPECT().Query(ctx, gomock.Any(), inp.StartDate, inp.EndDate, inp.PartnerId).
+			Return(nil, fmt.Errorf("something went wrong"))
+		v, ex := persister.DashboardCustomer(inp)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/internal/storage"
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap"
+)
+
+type Audit struct {
+	Pool   storage.Pooler
+	Logger *zap.Logger
+}
+
+type AuditPersister interface {
+	Add(audit model.Audit) *model.TechnicalError
+}
+
+func NewAudit(a Audit) AuditPersister {
+	return &a
+}
+
+func (a *Audit) Add(audit model.Audit) *model.TechnicalError {
+	tx, err := a.Pool.BeginTx(context.Background(),
+		pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return apps.Exception("failed to begin add audit tx", err, zap.Any("", audit), a.Logger)
+	}
+	defer tx.Rollback(context.Background())
+
+	_, err = tx.Exec(context.Background(), `INSERT INTO audit_trails 
+		(activity, actor, reference, detail, is_deleted, created_by, created_date)
+		VALUES ($1, $2, $3, $4, FALSE, $5, NOW())`,
+		audit.Activity.String, audit.Actor.String, audit.Reference.String,
+		audit.Detail.String, audit.CreatedBy.Int64)
+	if err != nil {
+		return apps.Exception("failed to add audit tx", err, zap.Any("", audit), a.Logger)
+	}
+	if err = tx.Commit(context.Background()); err != nil {
+		a.Logger.Panic("failed to commit add audit trx", zap.Any("audit", audit))
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/chrisyxlee/pgxpoolmock"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAudit_Add(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool, tx := pgxpoolmock.NewMockPgxIface(ctrl), pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewAudit(Audit{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	audit := model.Audit{
+		Activity:  sql.NullString{String: "OTP_LOCKOUT"},
+		Actor:     sql.NullString{String: "someone@email.net"},
+		Reference: sql.NullString{String: "TRX-001"},
+		Detail:    sql.NullString{String: "locked out after 5 failed attempts"},
+	}
+	cmd := `INSERT INTO audit_trails 
+		(activity, actor, reference, detail, is_deleted, created_by, created_date)
+		VALUES ($1, $2, $3, $4, FALSE, $5, NOW())`
+	t.Run("should success", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).
+			Return(tx, nil)
+		tx.EXPECT().Exec(ctx, cmd, audit.Activity.String, audit.Actor.String, audit.Reference.String,
+			audit.Detail.String, audit.CreatedBy.Int64).Return(nil, nil)
+		tx.EXPECT().Commit(ctx).Times(1).Return(nil)
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		ex := persister.Add(audit)
+		assert.Nil(t, ex)
+	})
+
+	t.Run("should return exception on failed to begin transaction", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).
+			Return(nil, fmt.Errorf("something went wrong"))
+		ex := persister.Add(audit)
+		assert.NotNil(t, ex)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).
+			Return(tx, nil)
+		tx.EXPECT().Exec(ctx, cmd, audit.Activity.String, audit.Actor.String, audit.Reference.String,
+			audit.Detail.String, audit.CreatedBy.Int64).Return(nil, fmt.Errorf("something went wrong"))
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		ex := persister.Add(audit)
+		assert.NotNil(t, ex)
+	})
+
+	t.Run("should panic on failed to commit", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).
+			Return(tx, nil)
+		tx.EXPECT().Exec(ctx, cmd, audit.Activity.String, audit.Actor.String, audit.Reference.String,
+			audit.Detail.String, audit.CreatedBy.Int64).Return(nil, nil)
+		tx.EXPECT().Commit(ctx).Times(1).Return(fmt.Errorf("something went wrong"))
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		assert.Panics(t, func() {
+			_ = persister.Add(audit)
+		})
+	})
+}
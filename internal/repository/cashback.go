@@ -2,46 +2,73 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"github.com/adinandradrs/cezbek-engine/internal/apps"
 	"github.com/adinandradrs/cezbek-engine/internal/model"
 	"github.com/adinandradrs/cezbek-engine/internal/storage"
+	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
 	"go.uber.org/zap"
 )
 
+const (
+	serializationFailure = "40001"
+	maxCashbackAttempts  = 3
+)
+
 type Cashback struct {
 	Pool   storage.Pooler
 	Logger *zap.Logger
 }
 
 type CashbackPersister interface {
-	Add(cashback model.Cashback) *model.TechnicalError
+	Add(cashback model.Cashback, journal model.LedgerJournal) *model.TechnicalError
 }
 
 func NewCashback(c Cashback) CashbackPersister {
 	return &c
 }
 
-func (c *Cashback) Add(cashback model.Cashback) *model.TechnicalError {
+func retryable(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == serializationFailure
+}
+
+func (c *Cashback) Add(cashback model.Cashback, journal model.LedgerJournal) *model.TechnicalError {
+	var err error
+	for i := 1; i <= maxCashbackAttempts; i++ {
+		if err = c.add(cashback, journal); !retryable(err) {
+			break
+		}
+		c.Logger.Warn("retry add cashback on serialization failure", zap.Int("attempt", i),
+			zap.String("kezbek_ref_code", cashback.KezbekRefCode.String))
+	}
+	if err != nil {
+		return apps.Exception("failed to add cashback tx", err, zap.Any("", cashback), c.Logger)
+	}
+	return nil
+}
+
+func (c *Cashback) add(cashback model.Cashback, journal model.LedgerJournal) error {
 	tx, err := c.Pool.BeginTx(context.Background(),
 		pgx.TxOptions{IsoLevel: pgx.Serializable})
 	if err != nil {
-		return apps.Exception("failed to begin add cashback tx", err, zap.Any("", cashback), c.Logger)
+		return err
 	}
 	defer tx.Rollback(context.Background())
 
 	_, err = tx.Exec(context.Background(), `INSERT INTO cashbacks 
 		(kezbek_ref_code, amount, reward, wallet_code,
-		h2h_code, status, is_deleted, created_by, created_date)
-		VALUES ($1, $2, $3, $4, $5, $6, FALSE, $7, NOW())`,
+		h2h_code, provider_ref_code, fee, failover, status, is_deleted, created_by, created_date)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, FALSE, $10, NOW())`,
 		cashback.KezbekRefCode.String, cashback.Amount.Decimal, cashback.Reward.Decimal,
-		cashback.WalletCode.String, cashback.H2HCode.String, apps.StatusInactive,
-		cashback.CreatedBy.Int64)
+		cashback.WalletCode.String, cashback.H2HCode.String, cashback.ProviderRefCode.String, cashback.Fee.Decimal,
+		cashback.Failover, apps.StatusInactive, cashback.CreatedBy.Int64)
 	if err != nil {
-		return apps.Exception("failed to add cashback tx", err, zap.Any("", cashback), c.Logger)
+		return err
 	}
-	if err = tx.Commit(context.Background()); err != nil {
-		c.Logger.Panic("failed to commit add cashback trx", zap.Any("cashback", cashback))
+	if _, err = postJournal(tx, journal); err != nil {
+		return err
 	}
-	return nil
+	return tx.Commit(context.Background())
 }
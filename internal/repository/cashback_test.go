@@ -8,6 +8,7 @@ import (
 	"github.com/adinandradrs/cezbek-engine/internal/model"
 	"github.com/chrisyxlee/pgxpoolmock"
 	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
@@ -25,40 +26,58 @@ func TestCashback_Add(t *testing.T) {
 	})
 	ctx := context.Background()
 	cashback := model.Cashback{
-		KezbekRefCode: sql.NullString{String: "REF001"},
-		Amount:        decimal.NullDecimal{Decimal: decimal.NewFromInt(10000)},
-		Reward:        decimal.NullDecimal{Decimal: decimal.NewFromInt(500)},
-		WalletCode:    sql.NullString{String: "CODE_A"},
-		H2HCode:       sql.NullString{String: "HOST_CODE"},
+		KezbekRefCode:   sql.NullString{String: "REF001"},
+		Amount:          decimal.NullDecimal{Decimal: decimal.NewFromInt(10000)},
+		Reward:          decimal.NullDecimal{Decimal: decimal.NewFromInt(500)},
+		WalletCode:      sql.NullString{String: "CODE_A"},
+		H2HCode:         sql.NullString{String: "HOST_CODE"},
+		ProviderRefCode: sql.NullString{String: "trx-001"},
+		Fee:             decimal.NullDecimal{Decimal: decimal.NewFromInt(750), Valid: true},
+		Failover:        true,
 		BaseEntity: model.BaseEntity{
 			CreatedBy: sql.NullInt64{Int64: 1},
 		},
 	}
+	journal := model.LedgerJournal{
+		Kind:      sql.NullString{String: apps.LedgerJournalCashback, Valid: true},
+		Reference: sql.NullString{String: "REF001", Valid: true},
+		Entries: model.LedgerTransfer(apps.LedgerPartnerReceivable, "LSAJA", apps.LedgerCustomerPayable,
+			"628123456789", decimal.NewFromInt(10500)),
+		BaseEntity: model.BaseEntity{
+			CreatedBy: sql.NullInt64{Int64: 1},
+		},
+	}
+	post := func() {
+		rows := pgxpoolmock.NewRows([]string{"id"}).AddRow(int64(1)).ToPgxRows()
+		rows.Next()
+		tx.EXPECT().QueryRow(ctx, gomock.Any(), apps.LedgerJournalCashback, "REF001", journal.ReversalOf,
+			journal.Description, int64(1)).Return(rows)
+		for _, e := range journal.Entries {
+			tx.EXPECT().Exec(ctx, gomock.Any(), int64(1), e.AccountType.String, e.AccountCode.String, e.Debit,
+				e.Credit).Return(nil, nil)
+		}
+	}
 	cmd := `INSERT INTO cashbacks 
 		(kezbek_ref_code, amount, reward, wallet_code,
-		h2h_code, status, is_deleted, created_by, created_date)
-		VALUES ($1, $2, $3, $4, $5, $6, FALSE, $7, NOW())`
+		h2h_code, provider_ref_code, fee, failover, status, is_deleted, created_by, created_date)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, FALSE, $10, NOW())`
 	t.Run("should success", func(t *testing.T) {
 		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable}).
 			Return(tx, nil)
 		tx.EXPECT().Exec(ctx, cmd, cashback.KezbekRefCode.String, cashback.Amount.Decimal, cashback.Reward.Decimal,
-			cashback.WalletCode.String, cashback.H2HCode.String, apps.StatusInactive,
-			cashback.CreatedBy.Int64).Return(nil, nil)
+			cashback.WalletCode.String, cashback.H2HCode.String, cashback.ProviderRefCode.String, cashback.Fee.Decimal,
+			cashback.Failover, apps.StatusInactive, cashback.CreatedBy.Int64).Return(nil, nil)
+		post()
 		tx.EXPECT().Commit(ctx).Times(1).Return(nil)
 		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
-		defer func() {
-			if r := recover(); r != nil {
-				assert.Equal(t, "failed to commit add cashback trx", r)
-			}
-		}()
-		ex := persister.Add(cashback)
+		ex := persister.Add(cashback, journal)
 		assert.Nil(t, ex)
 	})
 
 	t.Run("should return exception on failed to begin transaction", func(t *testing.T) {
 		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable}).
 			Return(nil, fmt.Errorf("something went wrong"))
-		ex := persister.Add(cashback)
+		ex := persister.Add(cashback, journal)
 		assert.NotNil(t, ex)
 	})
 
@@ -66,27 +85,61 @@ func TestCashback_Add(t *testing.T) {
 		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable}).
 			Return(tx, nil)
 		tx.EXPECT().Exec(ctx, cmd, cashback.KezbekRefCode.String, cashback.Amount.Decimal, cashback.Reward.Decimal,
-			cashback.WalletCode.String, cashback.H2HCode.String, apps.StatusInactive,
-			cashback.CreatedBy.Int64).Return(nil, fmt.Errorf("something went wrong"))
+			cashback.WalletCode.String, cashback.H2HCode.String, cashback.ProviderRefCode.String, cashback.Fee.Decimal,
+			cashback.Failover, apps.StatusInactive, cashback.CreatedBy.Int64).Return(nil, fmt.Errorf("something went wrong"))
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		ex := persister.Add(cashback, journal)
+		assert.NotNil(t, ex)
+	})
+
+	t.Run("should return exception on unbalanced journal", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable}).
+			Return(tx, nil)
+		tx.EXPECT().Exec(ctx, cmd, cashback.KezbekRefCode.String, cashback.Amount.Decimal, cashback.Reward.Decimal,
+			cashback.WalletCode.String, cashback.H2HCode.String, cashback.ProviderRefCode.String, cashback.Fee.Decimal,
+			cashback.Failover, apps.StatusInactive, cashback.CreatedBy.Int64).Return(nil, nil)
 		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
-		ex := persister.Add(cashback)
+		ex := persister.Add(cashback, model.LedgerJournal{Entries: journal.Entries[:1]})
+		assert.NotNil(t, ex)
+	})
+
+	t.Run("should retry on serialization failure", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable}).
+			Return(tx, nil).Times(2)
+		tx.EXPECT().Exec(ctx, cmd, cashback.KezbekRefCode.String, cashback.Amount.Decimal, cashback.Reward.Decimal,
+			cashback.WalletCode.String, cashback.H2HCode.String, cashback.ProviderRefCode.String, cashback.Fee.Decimal,
+			cashback.Failover, apps.StatusInactive, cashback.CreatedBy.Int64).Return(nil, nil).Times(2)
+		post()
+		post()
+		tx.EXPECT().Commit(ctx).Times(1).Return(&pgconn.PgError{Code: "40001"})
+		tx.EXPECT().Commit(ctx).Times(1).Return(nil)
+		tx.EXPECT().Rollback(ctx).Times(2).Return(nil)
+		ex := persister.Add(cashback, journal)
+		assert.Nil(t, ex)
+	})
+
+	t.Run("should return exception on exhausted serialization retries", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable}).
+			Return(tx, nil).Times(maxCashbackAttempts)
+		tx.EXPECT().Exec(ctx, cmd, cashback.KezbekRefCode.String, cashback.Amount.Decimal, cashback.Reward.Decimal,
+			cashback.WalletCode.String, cashback.H2HCode.String, cashback.ProviderRefCode.String, cashback.Fee.Decimal,
+			cashback.Failover, apps.StatusInactive, cashback.CreatedBy.Int64).
+			Return(nil, &pgconn.PgError{Code: "40001"}).Times(maxCashbackAttempts)
+		tx.EXPECT().Rollback(ctx).Times(maxCashbackAttempts).Return(nil)
+		ex := persister.Add(cashback, journal)
 		assert.NotNil(t, ex)
 	})
 
-	t.Run("should rollback transaction on failed to commit", func(t *testing.T) {
+	t.Run("should return exception on failed to commit", func(t *testing.T) {
 		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable}).
 			Return(tx, nil)
 		tx.EXPECT().Exec(ctx, cmd, cashback.KezbekRefCode.String, cashback.Amount.Decimal, cashback.Reward.Decimal,
-			cashback.WalletCode.String, cashback.H2HCode.String, apps.StatusInactive,
-			cashback.CreatedBy.Int64).Return(nil, nil)
+			cashback.WalletCode.String, cashback.H2HCode.String, cashback.ProviderRefCode.String, cashback.Fee.Decimal,
+			cashback.Failover, apps.StatusInactive, cashback.CreatedBy.Int64).Return(nil, nil)
+		post()
 		tx.EXPECT().Commit(ctx).Times(1).Return(fmt.Errorf("something went wrong"))
 		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
-		defer func() {
-			if r := recover(); r != nil {
-				assert.Equal(t, "failed to commit add cashback trx", r)
-			}
-		}()
-		ex := persister.Add(cashback)
+		ex := persister.Add(cashback, journal)
 		assert.NotNil(t, ex)
 	})
 }
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/internal/storage"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap"
+)
+
+type CiamUser struct {
+	Pool   storage.Pooler
+	Logger *zap.Logger
+}
+
+type CiamUserPersister interface {
+	Add(user model.CiamUser) *model.TechnicalError
+	FindActiveByUsername(username string) (*model.CiamUser, *model.TechnicalError)
+	RevokeTokens(username string) *model.TechnicalError
+}
+
+func NewCiamUser(c CiamUser) CiamUserPersister {
+	return &c
+}
+
+func (c *CiamUser) Add(user model.CiamUser) *model.TechnicalError {
+	tx, err := c.Pool.BeginTx(context.Background(),
+		pgx.TxOptions{IsoLevel: pgx.Serializable})
+	if err != nil {
+		return apps.Exception("failed to begin add ciam user tx", err, zap.String("username", user.Username.String), c.Logger)
+	}
+	defer tx.Rollback(context.Background())
+
+	_, err = tx.Exec(context.Background(), `insert into ciam_users (sub_id, username, name, email, 
+		phone_number, picture, password, token_version, status, is_deleted, created_by, created_date) 
+		values ($1, $2, $3, $4, $5, $6, $7, 0, $8, false, $9, now())`,
+		user.SubId.String, user.Username.String, user.Name.String, user.Email.String,
+		user.PhoneNumber.String, user.Picture.String, user.Password.String, apps.StatusActive,
+		user.CreatedBy.Int64)
+	if err != nil {
+		return apps.Exception("failed to add ciam user tx", err, zap.String("username", user.Username.String), c.Logger)
+	}
+	if err = tx.Commit(context.Background()); err != nil {
+		c.Logger.Panic("failed to commit add ciam user trx", zap.String("username", user.Username.String))
+	}
+	return nil
+}
+
+func (c *CiamUser) FindActiveByUsername(username string) (*model.CiamUser, *model.TechnicalError) {
+	d := model.CiamUser{}
+	rows, err := c.Pool.Query(context.Background(), `select id, sub_id, username, name, email, 
+		phone_number, picture, password, token_version from ciam_users where lower(username) = lower($1) 
+		and status = $2 and is_deleted = false`, username, apps.StatusActive)
+	if err != nil {
+		return nil, apps.Exception("failed to find active ciam user by username", err, zap.String("username", username), c.Logger)
+	}
+	defer rows.Close()
+
+	err = pgxscan.ScanOne(&d, rows)
+	if err != nil {
+		return nil, apps.Exception("failed to map active ciam user by username", err, zap.String("username", username), c.Logger)
+	}
+
+	return &d, nil
+}
+
+func (c *CiamUser) RevokeTokens(username string) *model.TechnicalError {
+	tx, err := c.Pool.BeginTx(context.Background(),
+		pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return apps.Exception("failed to begin revoke ciam user tokens tx", err, zap.String("username", username), c.Logger)
+	}
+	defer tx.Rollback(context.Background())
+
+	_, err = tx.Exec(context.Background(), `update ciam_users set token_version = token_version + 1, 
+		updated_date = now() where lower(username) = lower($1) and is_deleted = false`, username)
+	if err != nil {
+		return apps.Exception("failed to revoke ciam user tokens tx", err, zap.String("username", username), c.Logger)
+	}
+	if err = tx.Commit(context.Background()); err != nil {
+		c.Logger.Panic("failed to commit revoke ciam user tokens trx", zap.String("username", username))
+	}
+	return nil
+}
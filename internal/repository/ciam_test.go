@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/chrisyxlee/pgxpoolmock"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCiamUser_Add(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool, tx := pgxpoolmock.NewMockPgxIface(ctrl), pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewCiamUser(CiamUser{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	user := model.CiamUser{
+		SubId:       sql.NullString{String: "4f1c2a5e-0000-4000-8000-000000000001", Valid: true},
+		Username:    sql.NullString{String: "CORP_A", Valid: true},
+		Name:        sql.NullString{String: "PT. Corporate A", Valid: true},
+		Email:       sql.NullString{String: "someone@email.net", Valid: true},
+		PhoneNumber: sql.NullString{String: "+6281123456789", Valid: true},
+		Picture:     sql.NullString{String: "logo.png", Valid: true},
+		Password:    sql.NullString{String: "$2a$12$hash", Valid: true},
+	}
+	cmd := `insert into ciam_users (sub_id, username, name, email, 
+		phone_number, picture, password, token_version, status, is_deleted, created_by, created_date) 
+		values ($1, $2, $3, $4, $5, $6, $7, 0, $8, false, $9, now())`
+	t.Run("should success", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable}).Return(tx, nil)
+		tx.EXPECT().Exec(ctx, cmd, user.SubId.String, user.Username.String, user.Name.String, user.Email.String,
+			user.PhoneNumber.String, user.Picture.String, user.Password.String, apps.StatusActive,
+			user.CreatedBy.Int64).Return(nil, nil)
+		tx.EXPECT().Commit(ctx).Times(1).Return(nil)
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		ex := persister.Add(user)
+		assert.Nil(t, ex)
+	})
+
+	t.Run("should return exception on failed to begin transaction", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable}).
+			Return(nil, fmt.Errorf("something went wrong"))
+		ex := persister.Add(user)
+		assert.NotNil(t, ex)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable}).Return(tx, nil)
+		tx.EXPECT().Exec(ctx, cmd, user.SubId.String, user.Username.String, user.Name.String, user.Email.String,
+			user.PhoneNumber.String, user.Picture.String, user.Password.String, apps.StatusActive,
+			user.CreatedBy.Int64).Return(nil, fmt.Errorf("something went wrong"))
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		ex := persister.Add(user)
+		assert.NotNil(t, ex)
+	})
+
+	t.Run("should panic on failed to commit", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable}).Return(tx, nil)
+		tx.EXPECT().Exec(ctx, cmd, user.SubId.String, user.Username.String, user.Name.String, user.Email.String,
+			user.PhoneNumber.String, user.Picture.String, user.Password.String, apps.StatusActive,
+			user.CreatedBy.Int64).Return(nil, nil)
+		tx.EXPECT().Commit(ctx).Times(1).Return(fmt.Errorf("something went wrong"))
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		assert.Panics(t, func() {
+			_ = persister.Add(user)
+		})
+	})
+}
+
+func TestCiamUser_FindActiveByUsername(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool := pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewCiamUser(CiamUser{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	cmd := `select id, sub_id, username, name, email, 
+		phone_number, picture, password, token_version from ciam_users where lower(username) = lower($1) 
+		and status = $2 and is_deleted = false`
+	t.Run("should success", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"id", "username", "token_version"}).
+			AddRow(int64(1), sql.NullString{String: "CORP_A", Valid: true}, int64(2)).ToPgxRows()
+		pool.EXPECT().Query(ctx, cmd, "corp_a", apps.StatusActive).Return(rows, nil)
+		v, ex := persister.FindActiveByUsername("corp_a")
+		assert.Nil(t, ex)
+		assert.Equal(t, "CORP_A", v.Username.String)
+		assert.Equal(t, int64(2), v.TokenVersion)
+	})
+
+	t.Run("should return exception on failed to execute query", func(t *testing.T) {
+		pool.EXPECT().Query(ctx, cmd, "corp_a", apps.StatusActive).
+			Return(nil, fmt.Errorf("something went wrong"))
+		v, ex := persister.FindActiveByUsername("corp_a")
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+
+	t.Run("should return exception on map query result", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"id"}).AddRow("invalid").ToPgxRows()
+		pool.EXPECT().Query(ctx, cmd, "corp_a", apps.StatusActive).Return(rows, nil)
+		v, ex := persister.FindActiveByUsername("corp_a")
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
+
+func TestCiamUser_RevokeTokens(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool, tx := pgxpoolmock.NewMockPgxIface(ctrl), pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewCiamUser(CiamUser{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	cmd := `update ciam_users set token_version = token_version + 1, 
+		updated_date = now() where lower(username) = lower($1) and is_deleted = false`
+	t.Run("should success", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).Return(tx, nil)
+		tx.EXPECT().Exec(ctx, cmd, "CORP_A").Return(nil, nil)
+		tx.EXPECT().Commit(ctx).Times(1).Return(nil)
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		ex := persister.RevokeTokens("CORP_A")
+		assert.Nil(t, ex)
+	})
+
+	t.Run("should return exception on failed to begin transaction", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).
+			Return(nil, fmt.Errorf("something went wrong"))
+		ex := persister.RevokeTokens("CORP_A")
+		assert.NotNil(t, ex)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).Return(tx, nil)
+		tx.EXPECT().Exec(ctx, cmd, "CORP_A").Return(nil, fmt.Errorf("something went wrong"))
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		ex := persister.RevokeTokens("CORP_A")
+		assert.NotNil(t, ex)
+	})
+}
@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/internal/storage"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgx/v4"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+type Deposit struct {
+	Pool   storage.Pooler
+	Logger *zap.Logger
+}
+
+type DepositPersister interface {
+	FindByPartner(partnerId int64) (*model.DepositProjection, *model.TechnicalError)
+	TopUp(m model.DepositMovement, threshold decimal.NullDecimal) *model.TechnicalError
+	Hold(m model.DepositMovement) (*model.DepositProjection, *model.TechnicalError)
+	Capture(m model.DepositMovement, held decimal.Decimal) *model.TechnicalError
+	Release(m model.DepositMovement) *model.TechnicalError
+}
+
+func NewDeposit(d Deposit) DepositPersister {
+	return &d
+}
+
+const depositProjection = `select d.partner_id, p.code, p.partner, coalesce(p.email, '') as email,
+		coalesce(p.msisdn, '') as msisdn, coalesce(p.locale, '') as locale,
+		d.balance, d.held, d.balance - d.held as available, d.threshold
+		from partner_deposits d inner join partners p on p.id = d.partner_id
+		where d.partner_id = $1`
+
+func addMovement(tx pgx.Tx, m model.DepositMovement) error {
+	_, err := tx.Exec(context.Background(), `INSERT INTO deposit_movements
+		(partner_id, kind, amount, reference, created_by, created_date)
+		VALUES ($1, $2, $3, $4, $5, NOW())`,
+		m.PartnerId, m.Kind.String, m.Amount, m.Reference.String, m.CreatedBy.Int64)
+	return err
+}
+
+func (d *Deposit) FindByPartner(partnerId int64) (*model.DepositProjection, *model.TechnicalError) {
+	v := model.DepositProjection{}
+	rows, err := d.Pool.Query(context.Background(), depositProjection, partnerId)
+	if err != nil {
+		return nil, apps.Exception("failed to find deposit by partner", err, zap.Int64("partner_id", partnerId),
+			d.Logger)
+	}
+	defer rows.Close()
+
+	err = pgxscan.ScanOne(&v, rows)
+	if err != nil {
+		return nil, apps.Exception("failed to map deposit by partner", err, zap.Int64("partner_id", partnerId),
+			d.Logger)
+	}
+	return &v, nil
+}
+
+func (d *Deposit) TopUp(m model.DepositMovement, threshold decimal.NullDecimal) *model.TechnicalError {
+	tx, err := d.Pool.BeginTx(context.Background(),
+		pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return apps.Exception("failed to begin top up deposit tx", err, zap.Int64("partner_id", m.PartnerId), d.Logger)
+	}
+	defer tx.Rollback(context.Background())
+
+	_, err = tx.Exec(context.Background(), `INSERT INTO partner_deposits
+		(partner_id, balance, held, threshold, created_by, created_date)
+		VALUES ($1, $2, 0, coalesce($3::numeric, 0), $4, NOW())
+		ON CONFLICT (partner_id) DO UPDATE SET balance = partner_deposits.balance + excluded.balance,
+		threshold = coalesce($3::numeric, partner_deposits.threshold), updated_by = $4, updated_date = NOW()`,
+		m.PartnerId, m.Amount, threshold, m.CreatedBy.Int64)
+	if err != nil {
+		return apps.Exception("failed to top up deposit", err, zap.Any("", m), d.Logger)
+	}
+	if err = addMovement(tx, m); err != nil {
+		return apps.Exception("failed to add top up movement", err, zap.Any("", m), d.Logger)
+	}
+	if err = tx.Commit(context.Background()); err != nil {
+		d.Logger.Panic("failed to commit top up deposit trx", zap.Any("movement", m))
+	}
+	return nil
+}
+
+func (d *Deposit) Hold(m model.DepositMovement) (*model.DepositProjection, *model.TechnicalError) {
+	v := model.DepositProjection{}
+	tx, err := d.Pool.BeginTx(context.Background(),
+		pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return nil, apps.Exception("failed to begin hold deposit tx", err, zap.Int64("partner_id", m.PartnerId),
+			d.Logger)
+	}
+	defer tx.Rollback(context.Background())
+
+	rows, err := tx.Query(context.Background(), depositProjection+` for update of d`, m.PartnerId)
+	if err != nil {
+		return nil, apps.Exception("failed to lock deposit", err, zap.Int64("partner_id", m.PartnerId), d.Logger)
+	}
+	err = pgxscan.ScanOne(&v, rows)
+	rows.Close()
+	if pgxscan.NotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, apps.Exception("failed to map locked deposit", err, zap.Int64("partner_id", m.PartnerId),
+			d.Logger)
+	}
+	if v.Available.LessThan(m.Amount) {
+		return &v, nil
+	}
+	_, err = tx.Exec(context.Background(), `update partner_deposits set held = held + $1,
+		updated_by = $2, updated_date = now() where partner_id = $3`, m.Amount, m.CreatedBy.Int64, m.PartnerId)
+	if err != nil {
+		return nil, apps.Exception("failed to hold deposit", err, zap.Any("", m), d.Logger)
+	}
+	if err = addMovement(tx, m); err != nil {
+		return nil, apps.Exception("failed to add hold movement", err, zap.Any("", m), d.Logger)
+	}
+	if err = tx.Commit(context.Background()); err != nil {
+		d.Logger.Panic("failed to commit hold deposit trx", zap.Any("movement", m))
+	}
+	return &v, nil
+}
+
+func (d *Deposit) Capture(m model.DepositMovement, held decimal.Decimal) *model.TechnicalError {
+	return d.settle(m, `update partner_deposits set balance = balance - $1, held = held - $4,
+		updated_by = $2, updated_date = now() where partner_id = $3`, held)
+}
+
+func (d *Deposit) Release(m model.DepositMovement) *model.TechnicalError {
+	return d.settle(m, `update partner_deposits set held = held - $1,
+		updated_by = $2, updated_date = now() where partner_id = $3`)
+}
+
+func (d *Deposit) settle(m model.DepositMovement, cmd string, args ...interface{}) *model.TechnicalError {
+	tx, err := d.Pool.BeginTx(context.Background(),
+		pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return apps.Exception("failed to begin settle deposit tx", err, zap.Int64("partner_id", m.PartnerId), d.Logger)
+	}
+	defer tx.Rollback(context.Background())
+
+	_, err = tx.Exec(context.Background(), cmd, append([]interface{}{m.Amount, m.CreatedBy.Int64, m.PartnerId},
+		args...)...)
+	if err != nil {
+		return apps.Exception("failed to settle deposit", err, zap.Any("", m), d.Logger)
+	}
+	if err = addMovement(tx, m); err != nil {
+		return apps.Exception("failed to add settle movement", err, zap.Any("", m), d.Logger)
+	}
+	if err = tx.Commit(context.Background()); err != nil {
+		d.Logger.Panic("failed to commit settle deposit trx", zap.Any("movement", m))
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/chrisyxlee/pgxpoolmock"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v4"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var depositColumns = []string{"partner_id", "code", "partner", "email", "msisdn", "locale", "balance", "held",
+	"available", "threshold"}
+
+func depositMovement(kind string, amount int64) model.DepositMovement {
+	return model.DepositMovement{
+		PartnerId: 1,
+		Kind:      sql.NullString{String: kind, Valid: true},
+		Amount:    decimal.NewFromInt(amount),
+		Reference: sql.NullString{String: "C00223010112000062811", Valid: true},
+		BaseEntity: model.BaseEntity{
+			CreatedBy: sql.NullInt64{Int64: 1, Valid: true},
+		},
+	}
+}
+
+func TestDeposit_FindByPartner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool := pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewDeposit(Deposit{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	t.Run("should success", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows(depositColumns).
+			AddRow(int64(1), "LAJADA", "Lajada", "finance@lajada.id", "628123456789", "id",
+				decimal.NewFromInt(5000000), decimal.Zero, decimal.NewFromInt(5000000),
+				decimal.NewFromInt(1000000)).ToPgxRows()
+		pool.EXPECT().Query(ctx, gomock.Any(), int64(1)).Return(rows, nil)
+		v, ex := persister.FindByPartner(1)
+		assert.Nil(t, ex)
+		assert.Equal(t, "LAJADA", v.Code)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		pool.EXPECT().Query(ctx, gomock.Any(), int64(1)).Return(nil, fmt.Errorf("something went wrong"))
+		v, ex := persister.FindByPartner(1)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+
+	t.Run("should return exception on deposit not found", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows(depositColumns).ToPgxRows()
+		pool.EXPECT().Query(ctx, gomock.Any(), int64(1)).Return(rows, nil)
+		v, ex := persister.FindByPartner(1)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
+
+func TestDeposit_TopUp(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool, tx := pgxpoolmock.NewMockPgxIface(ctrl), pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewDeposit(Deposit{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	m := depositMovement(apps.DepositTopUp, 5000000)
+	threshold := decimal.NullDecimal{Decimal: decimal.NewFromInt(1000000), Valid: true}
+	t.Run("should success", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).Return(tx, nil)
+		tx.EXPECT().Exec(ctx, gomock.Any(), m.PartnerId, m.Amount, threshold, m.CreatedBy.Int64).Return(nil, nil)
+		tx.EXPECT().Exec(ctx, gomock.Any(), m.PartnerId, m.Kind.String, m.Amount, m.Reference.String,
+			m.CreatedBy.Int64).Return(nil, nil)
+		tx.EXPECT().Commit(ctx).Times(1).Return(nil)
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		ex := persister.TopUp(m, threshold)
+		assert.Nil(t, ex)
+	})
+
+	t.Run("should return exception on failed to begin transaction", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).
+			Return(nil, fmt.Errorf("something went wrong"))
+		ex := persister.TopUp(m, threshold)
+		assert.NotNil(t, ex)
+	})
+
+	t.Run("should return exception on failed to add movement", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).Return(tx, nil)
+		tx.EXPECT().Exec(ctx, gomock.Any(), m.PartnerId, m.Amount, threshold, m.CreatedBy.Int64).Return(nil, nil)
+		tx.EXPECT().Exec(ctx, gomock.Any(), m.PartnerId, m.Kind.String, m.Amount, m.Reference.String,
+			m.CreatedBy.Int64).Return(nil, fmt.Errorf("something went wrong"))
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		ex := persister.TopUp(m, threshold)
+		assert.NotNil(t, ex)
+	})
+}
+
+func TestDeposit_Hold(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool, tx := pgxpoolmock.NewMockPgxIface(ctrl), pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewDeposit(Deposit{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	m := depositMovement(apps.DepositHold, 16500)
+	deposit := func(available int64) pgx.Rows {
+		return pgxpoolmock.NewRows(depositColumns).
+			AddRow(int64(1), "LAJADA", "Lajada", "finance@lajada.id", "628123456789", "id",
+				decimal.NewFromInt(available), decimal.Zero, decimal.NewFromInt(available),
+				decimal.NewFromInt(1000000)).ToPgxRows()
+	}
+	t.Run("should success", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).Return(tx, nil)
+		tx.EXPECT().Query(ctx, gomock.Any(), m.PartnerId).Return(deposit(5000000), nil)
+		tx.EXPECT().Exec(ctx, gomock.Any(), m.Amount, m.CreatedBy.Int64, m.PartnerId).Return(nil, nil)
+		tx.EXPECT().Exec(ctx, gomock.Any(), m.PartnerId, m.Kind.String, m.Amount, m.Reference.String,
+			m.CreatedBy.Int64).Return(nil, nil)
+		tx.EXPECT().Commit(ctx).Times(1).Return(nil)
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		v, ex := persister.Hold(m)
+		assert.Nil(t, ex)
+		assert.True(t, decimal.NewFromInt(5000000).Equal(v.Available))
+	})
+
+	t.Run("should not hold on insufficient deposit", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).Return(tx, nil)
+		tx.EXPECT().Query(ctx, gomock.Any(), m.PartnerId).Return(deposit(10000), nil)
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		v, ex := persister.Hold(m)
+		assert.Nil(t, ex)
+		assert.True(t, decimal.NewFromInt(10000).Equal(v.Available))
+	})
+
+	t.Run("should return nothing on partner without deposit", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).Return(tx, nil)
+		tx.EXPECT().Query(ctx, gomock.Any(), m.PartnerId).Return(pgxpoolmock.NewRows(depositColumns).ToPgxRows(), nil)
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		v, ex := persister.Hold(m)
+		assert.Nil(t, ex)
+		assert.Nil(t, v)
+	})
+
+	t.Run("should return exception on failed to lock deposit", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).Return(tx, nil)
+		tx.EXPECT().Query(ctx, gomock.Any(), m.PartnerId).Return(nil, fmt.Errorf("something went wrong"))
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		v, ex := persister.Hold(m)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+
+	t.Run("should return exception on failed to hold deposit", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).Return(tx, nil)
+		tx.EXPECT().Query(ctx, gomock.Any(), m.PartnerId).Return(deposit(5000000), nil)
+		tx.EXPECT().Exec(ctx, gomock.Any(), m.Amount, m.CreatedBy.Int64, m.PartnerId).
+			Return(nil, fmt.Errorf("something went wrong"))
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		v, ex := persister.Hold(m)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
+
+func TestDeposit_Capture(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool, tx := pgxpoolmock.NewMockPgxIface(ctrl), pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewDeposit(Deposit{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	m, held := depositMovement(apps.DepositCapture, 16600), decimal.NewFromInt(16500)
+	t.Run("should success", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).Return(tx, nil)
+		tx.EXPECT().Exec(ctx, gomock.Any(), m.Amount, m.CreatedBy.Int64, m.PartnerId, held).Return(nil, nil)
+		tx.EXPECT().Exec(ctx, gomock.Any(), m.PartnerId, m.Kind.String, m.Amount, m.Reference.String,
+			m.CreatedBy.Int64).Return(nil, nil)
+		tx.EXPECT().Commit(ctx).Times(1).Return(nil)
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		ex := persister.Capture(m, held)
+		assert.Nil(t, ex)
+	})
+
+	t.Run("should return exception on failed to capture", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).Return(tx, nil)
+		tx.EXPECT().Exec(ctx, gomock.Any(), m.Amount, m.CreatedBy.Int64, m.PartnerId, held).
+			Return(nil, fmt.Errorf("something went wrong"))
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		ex := persister.Capture(m, held)
+		assert.NotNil(t, ex)
+	})
+}
+
+func TestDeposit_Release(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool, tx := pgxpoolmock.NewMockPgxIface(ctrl), pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewDeposit(Deposit{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	m := depositMovement(apps.DepositRelease, 16500)
+	t.Run("should success", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).Return(tx, nil)
+		tx.EXPECT().Exec(ctx, gomock.Any(), m.Amount, m.CreatedBy.Int64, m.PartnerId).Return(nil, nil)
+		tx.EXPECT().Exec(ctx, gomock.Any(), m.PartnerId, m.Kind.String, m.Amount, m.Reference.String,
+			m.CreatedBy.Int64).Return(nil, nil)
+		tx.EXPECT().Commit(ctx).Times(1).Return(nil)
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		ex := persister.Release(m)
+		assert.Nil(t, ex)
+	})
+
+	t.Run("should return exception on failed to begin transaction", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).
+			Return(nil, fmt.Errorf("something went wrong"))
+		ex := persister.Release(m)
+		assert.NotNil(t, ex)
+	})
+}
@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/internal/storage"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap"
+	"strings"
+	"time"
+)
+
+type Invoice struct {
+	Pool   storage.Pooler
+	Logger *zap.Logger
+}
+
+type InvoicePersister interface {
+	Unbilled(before time.Time) ([]string, *model.TechnicalError)
+	Summarize(period string, start time.Time, end time.Time) ([]model.BillingSummaryProjection, *model.TechnicalError)
+	Add(m model.Invoice) (*int64, *model.TechnicalError)
+	FindById(id int64) (*model.Invoice, *model.TechnicalError)
+	Pay(m model.Invoice) *model.TechnicalError
+	CountByPartner(inp *model.SearchRequest) (*int, *model.TechnicalError)
+	SearchByPartner(inp *model.SearchRequest) ([]model.InvoiceProjection, *model.TechnicalError)
+}
+
+func NewInvoice(i Invoice) InvoicePersister {
+	return &i
+}
+
+func (i *Invoice) Unbilled(before time.Time) ([]string, *model.TechnicalError) {
+	var data []string
+	err := pgxscan.Select(context.Background(), i.Pool, &data, `select distinct to_char(t.created_date, 'YYYY-MM')
+			from transactions t inner join cashbacks c on t.kezbek_ref_code = c.kezbek_ref_code
+			where t.is_deleted = false and c.is_deleted = false and t.created_date < $1
+			and not exists (select 1 from invoices i where i.partner_id = t.partner_id
+				and i.period = to_char(t.created_date, 'YYYY-MM'))
+			and not exists (select 1 from ledger_journals j inner join ledger_journals r on r.reversal_of = j.id
+				where j.kind = $2 and j.reference = c.kezbek_ref_code)
+			order by 1`, before, apps.LedgerJournalCashback)
+	if err != nil {
+		return nil, apps.Exception("failed to find unbilled periods", err, zap.Time("before", before), i.Logger)
+	}
+	return data, nil
+}
+
+func (i *Invoice) Summarize(period string, start time.Time, end time.Time) ([]model.BillingSummaryProjection, *model.TechnicalError) {
+	var data []model.BillingSummaryProjection
+	rows, err := i.Pool.Query(context.Background(), `select p.id as partner_id, p.code as partner_code,
+			p.partner, p.email, coalesce(p.locale, '') as locale, coalesce(p.address, '') as address,
+			count(c.id) as transactions, coalesce(sum(c.amount), 0) as cashback,
+			coalesce(sum(c.reward), 0) as reward, coalesce(sum(coalesce(c.fee, f.fee)), 0) as provider_fee
+			from transactions t inner join cashbacks c on t.kezbek_ref_code = c.kezbek_ref_code
+			inner join partners p on p.id = t.partner_id
+			left join lateral (select min(pf.fee) as fee from h2h_provider_fees pf
+				inner join h2h_providers hp on hp.id = pf.h2h_provider_id
+				where hp.code = c.h2h_code and pf.wallet_code = c.wallet_code and pf.is_deleted = false) f on true
+			where t.is_deleted = false and c.is_deleted = false
+			and t.created_date >= $1 and t.created_date < $2
+			and not exists (select 1 from invoices i where i.partner_id = p.id and i.period = $3)
+			and not exists (select 1 from ledger_journals j inner join ledger_journals r on r.reversal_of = j.id
+				where j.kind = $4 and j.reference = c.kezbek_ref_code)
+			group by p.id, p.code, p.partner, p.email, p.locale, p.address order by p.id`,
+		start, end, period, apps.LedgerJournalCashback)
+	if err != nil {
+		return nil, apps.Exception("failed to summarize billing", err, zap.String("period", period), i.Logger)
+	}
+	defer rows.Close()
+
+	err = pgxscan.ScanAll(&data, rows)
+	if err != nil {
+		return nil, apps.Exception("failed to map billing summary", err, zap.String("period", period), i.Logger)
+	}
+	return data, nil
+}
+
+func (i *Invoice) Add(m model.Invoice) (*int64, *model.TechnicalError) {
+	var id int64
+	tx, err := i.Pool.BeginTx(context.Background(),
+		pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return nil, apps.Exception("failed to begin add invoice tx", err,
+			zap.String("invoice_no", m.InvoiceNo.String), i.Logger)
+	}
+	defer tx.Rollback(context.Background())
+
+	err = tx.QueryRow(context.Background(), `INSERT INTO invoices
+		(partner_id, invoice_no, period, transactions, cashback, reward, provider_fee,
+		service_fee, tax, total, status, path, due_date, is_deleted, created_by, created_date)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, FALSE, 0, NOW()) RETURNING id`,
+		m.PartnerId, m.InvoiceNo.String, m.Period.String, m.Transactions, m.Cashback, m.Reward,
+		m.ProviderFee, m.ServiceFee, m.Tax, m.Total, m.Status.String, m.Path.String, m.DueDate.Time).Scan(&id)
+	if err != nil {
+		return nil, apps.Exception("failed to add invoice", err, zap.String("invoice_no", m.InvoiceNo.String), i.Logger)
+	}
+	if err = tx.Commit(context.Background()); err != nil {
+		i.Logger.Panic("failed to commit add invoice trx", zap.String("invoice_no", m.InvoiceNo.String))
+	}
+	return &id, nil
+}
+
+func (i *Invoice) FindById(id int64) (*model.Invoice, *model.TechnicalError) {
+	v := model.Invoice{}
+	rows, err := i.Pool.Query(context.Background(), `select id, partner_id, invoice_no, period, total, status
+			from invoices where id = $1 and is_deleted = false`, id)
+	if err != nil {
+		return nil, apps.Exception("failed to find invoice by id", err, zap.Int64("id", id), i.Logger)
+	}
+	defer rows.Close()
+
+	err = pgxscan.ScanOne(&v, rows)
+	if err != nil {
+		return nil, apps.Exception("failed to map invoice by id", err, zap.Int64("id", id), i.Logger)
+	}
+	return &v, nil
+}
+
+func (i *Invoice) Pay(m model.Invoice) *model.TechnicalError {
+	tx, err := i.Pool.BeginTx(context.Background(),
+		pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return apps.Exception("failed to begin pay invoice tx", err, zap.Int64("id", m.Id), i.Logger)
+	}
+	defer tx.Rollback(context.Background())
+
+	_, err = tx.Exec(context.Background(), `update invoices set status = $1, payment_reference = $2,
+		updated_by = $3, updated_date = now() where id = $4`,
+		apps.InvoicePaid, m.PaymentReference.String, m.UpdatedBy.Int64, m.Id)
+	if err != nil {
+		return apps.Exception("failed to pay invoice", err, zap.Int64("id", m.Id), i.Logger)
+	}
+	if err = tx.Commit(context.Background()); err != nil {
+		i.Logger.Panic("failed to commit pay invoice trx", zap.Int64("id", m.Id))
+	}
+	return nil
+}
+
+func (i *Invoice) CountByPartner(inp *model.SearchRequest) (*int, *model.TechnicalError) {
+	var count int
+	err := i.Pool.QueryRow(context.Background(), `select count(id) from invoices
+		where partner_id = $1 and is_deleted = false`, inp.SessionRequest.Id).Scan(&count)
+	if err != nil {
+		return nil, apps.Exception("failed to count partner invoice", err, zap.Any("", inp), i.Logger)
+	}
+	return &count, nil
+}
+
+func (i *Invoice) SearchByPartner(inp *model.SearchRequest) ([]model.InvoiceProjection, *model.TechnicalError) {
+	var data []model.InvoiceProjection
+	sort := "DESC"
+	if strings.EqualFold(inp.Sort, "ASC") {
+		sort = "ASC"
+	}
+	args := []interface{}{inp.SessionRequest.Id, apps.InvoiceUnpaid, apps.InvoiceOverdue, apps.InvoicePaid,
+		inp.Limit, inp.Start}
+	cmd := `select id, invoice_no, period, transactions, cashback, reward, provider_fee, service_fee,
+			tax, total, case when status = $2 and due_date < now() then $3 else status end as status,
+			coalesce(path, '') as path, coalesce(payment_reference, '') as payment_reference,
+			extract(epoch from created_date)::bigint as issued_date,
+			extract(epoch from due_date)::bigint as due_date,
+			case when status = $4 then extract(epoch from updated_date)::bigint else 0 end as paid_date
+			from invoices where partner_id = $1 and is_deleted = false
+			order by period ` + sort + `, id ` + sort + ` limit $5 offset $6`
+	err := pgxscan.Select(context.Background(), i.Pool, &data, cmd, args...)
+	if err != nil {
+		return nil, apps.Exception("failed to search partner invoice", err, zap.Any("", inp), i.Logger)
+	}
+	return data, nil
+}
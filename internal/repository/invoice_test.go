@@ -0,0 +1,288 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/chrisyxlee/pgxpoolmock"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v4"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestInvoice_Unbilled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool := pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewInvoice(Invoice{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	before := time.Date(2023, 3, 1, 0, 0, 0, 0, time.Local)
+	t.Run("should success", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"to_char"}).AddRow("2023-01").AddRow("2023-02").ToPgxRows()
+		pool.EXPECT().Query(ctx, gomock.Any(), before, apps.LedgerJournalCashback).Return(rows, nil)
+		v, ex := persister.Unbilled(before)
+		assert.Nil(t, ex)
+		assert.Equal(t, []string{"2023-01", "2023-02"}, v)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		pool.EXPECT().Query(ctx, gomock.Any(), before, apps.LedgerJournalCashback).
+			Return(nil, fmt.Errorf("something went wrong"))
+		v, ex := persister.Unbilled(before)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
+
+func TestInvoice_Summarize(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool := pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewInvoice(Invoice{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.Local)
+	end := start.AddDate(0, 1, 0)
+	t.Run("should success", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"partner_id", "partner_code", "partner", "email", "locale", "address",
+			"transactions", "cashback", "reward", "provider_fee"}).
+			AddRow(int64(1), "LAJADA", "PT. Lajada", "finance@lajada.id", "id", "Jakarta", 2,
+				decimal.NewFromInt(20000), decimal.NewFromInt(5000), decimal.NewFromInt(2000)).ToPgxRows()
+		pool.EXPECT().Query(ctx, gomock.Any(), start, end, "2023-01", apps.LedgerJournalCashback).Return(rows, nil)
+		v, ex := persister.Summarize("2023-01", start, end)
+		assert.Nil(t, ex)
+		assert.Len(t, v, 1)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		pool.EXPECT().Query(ctx, gomock.Any(), start, end, "2023-01", apps.LedgerJournalCashback).
+			Return(nil, fmt.Errorf("something went wrong"))
+		v, ex := persister.Summarize("2023-01", start, end)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+
+	t.Run("should return exception on failed to map query result", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"partner_id"}).AddRow("LAJADA").ToPgxRows()
+		pool.EXPECT().Query(ctx, gomock.Any(), start, end, "2023-01", apps.LedgerJournalCashback).Return(rows, nil)
+		v, ex := persister.Summarize("2023-01", start, end)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
+
+func TestInvoice_Add(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool, tx := pgxpoolmock.NewMockPgxIface(ctrl), pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewInvoice(Invoice{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	m := model.Invoice{
+		PartnerId:    1,
+		InvoiceNo:    sql.NullString{String: "INV/LAJADA/202301"},
+		Period:       sql.NullString{String: "2023-01"},
+		Transactions: 2,
+		Cashback:     decimal.NewFromInt(20000),
+		Reward:       decimal.NewFromInt(5000),
+		ProviderFee:  decimal.NewFromInt(2000),
+		ServiceFee:   decimal.NewFromInt(500),
+		Tax:          decimal.NewFromInt(275),
+		Total:        decimal.NewFromInt(27775),
+		Status:       sql.NullString{String: apps.InvoiceUnpaid},
+		Path:         sql.NullString{String: "invoice/1/INV-LAJADA-202301-8f2d0a6c1b9e4d57.pdf"},
+		DueDate:      sql.NullTime{Time: time.Now()},
+	}
+	args := []interface{}{m.PartnerId, m.InvoiceNo.String, m.Period.String, m.Transactions, m.Cashback,
+		m.Reward, m.ProviderFee, m.ServiceFee, m.Tax, m.Total, m.Status.String, m.Path.String, m.DueDate.Time}
+	t.Run("should success", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"id"}).AddRow(int64(1)).ToPgxRows()
+		rows.Next()
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).Return(tx, nil)
+		tx.EXPECT().QueryRow(ctx, gomock.Any(), args...).Return(rows)
+		tx.EXPECT().Commit(ctx).Times(1).Return(nil)
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		v, ex := persister.Add(m)
+		assert.Nil(t, ex)
+		assert.NotNil(t, v)
+	})
+
+	t.Run("should return exception on failed to begin transaction", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).
+			Return(nil, fmt.Errorf("something went wrong"))
+		v, ex := persister.Add(m)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows(nil).ToPgxRows()
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).Return(tx, nil)
+		tx.EXPECT().QueryRow(ctx, gomock.Any(), args...).Return(rows)
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		v, ex := persister.Add(m)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
+
+func TestInvoice_FindById(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool := pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewInvoice(Invoice{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	t.Run("should success", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"id", "partner_id", "invoice_no", "period", "total", "status"}).
+			AddRow(int64(1), int64(1), sql.NullString{String: "INV/LAJADA/202301", Valid: true},
+				sql.NullString{String: "2023-01", Valid: true}, decimal.NewFromInt(27775),
+				sql.NullString{String: apps.InvoiceUnpaid, Valid: true}).ToPgxRows()
+		pool.EXPECT().Query(ctx, gomock.Any(), int64(1)).Return(rows, nil)
+		v, ex := persister.FindById(1)
+		assert.Nil(t, ex)
+		assert.Equal(t, apps.InvoiceUnpaid, v.Status.String)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		pool.EXPECT().Query(ctx, gomock.Any(), int64(1)).Return(nil, fmt.Errorf("something went wrong"))
+		v, ex := persister.FindById(1)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+
+	t.Run("should return exception on data not found", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"id"}).ToPgxRows()
+		pool.EXPECT().Query(ctx, gomock.Any(), int64(1)).Return(rows, nil)
+		v, ex := persister.FindById(1)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
+
+func TestInvoice_Pay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool, tx := pgxpoolmock.NewMockPgxIface(ctrl), pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewInvoice(Invoice{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	m := model.Invoice{
+		Id:               1,
+		PaymentReference: sql.NullString{String: "TRF/2023/02/0001"},
+		BaseEntity: model.BaseEntity{
+			UpdatedBy: sql.NullInt64{Int64: 1},
+		},
+	}
+	t.Run("should success", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).Return(tx, nil)
+		tx.EXPECT().Exec(ctx, gomock.Any(), apps.InvoicePaid, m.PaymentReference.String,
+			m.UpdatedBy.Int64, m.Id).Return(nil, nil)
+		tx.EXPECT().Commit(ctx).Times(1).Return(nil)
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		ex := persister.Pay(m)
+		assert.Nil(t, ex)
+	})
+
+	t.Run("should return exception on failed to begin transaction", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).
+			Return(nil, fmt.Errorf("something went wrong"))
+		ex := persister.Pay(m)
+		assert.NotNil(t, ex)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).Return(tx, nil)
+		tx.EXPECT().Exec(ctx, gomock.Any(), apps.InvoicePaid, m.PaymentReference.String,
+			m.UpdatedBy.Int64, m.Id).Return(nil, fmt.Errorf("something went wrong"))
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		ex := persister.Pay(m)
+		assert.NotNil(t, ex)
+	})
+}
+
+func TestInvoice_CountByPartner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool := pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewInvoice(Invoice{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	inp := &model.SearchRequest{
+		SessionRequest: model.SessionRequest{Id: 1},
+	}
+	t.Run("should success", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"count"}).AddRow(3).ToPgxRows()
+		pool.EXPECT().QueryRow(ctx, gomock.Any(), int64(1)).Return(rows)
+		v, ex := persister.CountByPartner(inp)
+		assert.Nil(t, ex)
+		assert.NotNil(t, v)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows(nil).ToPgxRows()
+		pool.EXPECT().QueryRow(ctx, gomock.Any(), int64(1)).Return(rows)
+		v, ex := persister.CountByPartner(inp)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
+
+func TestInvoice_SearchByPartner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool := pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewInvoice(Invoice{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	inp := &model.SearchRequest{
+		Start:          0,
+		Limit:          10,
+		SessionRequest: model.SessionRequest{Id: 1},
+	}
+	t.Run("should success", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"id", "invoice_no", "period", "total", "status"}).
+			AddRow(int64(1), "INV/LAJADA/202301", "2023-01", decimal.NewFromInt(27775), apps.InvoiceOverdue).
+			ToPgxRows()
+		pool.EXPECT().Query(ctx, gomock.Any(), int64(1), apps.InvoiceUnpaid, apps.InvoiceOverdue,
+			apps.InvoicePaid, 10, 0).Return(rows, nil)
+		v, ex := persister.SearchByPartner(inp)
+		assert.Nil(t, ex)
+		assert.Len(t, v, 1)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		pool.EXPECT().Query(ctx, gomock.Any(), int64(1), apps.InvoiceUnpaid, apps.InvoiceOverdue,
+			apps.InvoicePaid, 10, 0).Return(nil, fmt.Errorf("something went wrong"))
+		v, ex := persister.SearchByPartner(inp)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/internal/storage"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgx/v4"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+type Ledger struct {
+	Pool   storage.Pooler
+	Logger *zap.Logger
+}
+
+type LedgerPersister interface {
+	Post(j model.LedgerJournal) (*int64, *model.TechnicalError)
+	Reverse(j model.LedgerJournal) (*int64, *model.TechnicalError)
+	FindJournalById(id int64) (*model.LedgerJournalProjection, *model.TechnicalError)
+	Entries(journalId int64) ([]model.LedgerEntry, *model.TechnicalError)
+	Balance(inp *model.LedgerRequest) (*model.LedgerBalanceProjection, *model.TechnicalError)
+	CountStatement(inp *model.LedgerRequest) (*int, *model.TechnicalError)
+	Statement(inp *model.LedgerRequest) ([]model.LedgerEntryProjection, *model.TechnicalError)
+}
+
+func NewLedger(l Ledger) LedgerPersister {
+	return &l
+}
+
+func postJournal(tx pgx.Tx, j model.LedgerJournal) (*int64, error) {
+	debit, credit := decimal.Zero, decimal.Zero
+	for _, e := range j.Entries {
+		debit = debit.Add(e.Debit)
+		credit = credit.Add(e.Credit)
+	}
+	if len(j.Entries) == 0 || !debit.Equal(credit) {
+		return nil, errors.New("journal is empty or unbalanced")
+	}
+	var id int64
+	err := tx.QueryRow(context.Background(), `INSERT INTO ledger_journals
+		(kind, reference, reversal_of, description, created_by, created_date)
+		VALUES ($1, $2, $3, $4, $5, NOW()) RETURNING id`,
+		j.Kind.String, j.Reference.String, j.ReversalOf, j.Description, j.CreatedBy.Int64).Scan(&id)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range j.Entries {
+		_, err = tx.Exec(context.Background(), `INSERT INTO ledger_entries
+			(journal_id, account_type, account_code, debit, credit, created_date)
+			VALUES ($1, $2, $3, $4, $5, NOW())`,
+			id, e.AccountType.String, e.AccountCode.String, e.Debit, e.Credit)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &id, nil
+}
+
+func (l *Ledger) Post(j model.LedgerJournal) (*int64, *model.TechnicalError) {
+	tx, err := l.Pool.BeginTx(context.Background(),
+		pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return nil, apps.Exception("failed to begin post journal tx", err, zap.String("kind", j.Kind.String), l.Logger)
+	}
+	defer tx.Rollback(context.Background())
+
+	id, err := postJournal(tx, j)
+	if err != nil {
+		return nil, apps.Exception("failed to post journal", err, zap.Any("", j), l.Logger)
+	}
+	if err = tx.Commit(context.Background()); err != nil {
+		l.Logger.Panic("failed to commit post journal trx", zap.Any("journal", j))
+	}
+	return id, nil
+}
+
+func (l *Ledger) Reverse(j model.LedgerJournal) (*int64, *model.TechnicalError) {
+	tx, err := l.Pool.BeginTx(context.Background(),
+		pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return nil, apps.Exception("failed to begin reverse journal tx", err, zap.Int64("journal_id", j.ReversalOf.Int64),
+			l.Logger)
+	}
+	defer tx.Rollback(context.Background())
+
+	var locked int64
+	err = tx.QueryRow(context.Background(), `select id from ledger_journals where id = $1 for update`,
+		j.ReversalOf.Int64).Scan(&locked)
+	if err != nil {
+		return nil, apps.Exception("failed to lock journal", err, zap.Int64("journal_id", j.ReversalOf.Int64), l.Logger)
+	}
+	var reversed bool
+	err = tx.QueryRow(context.Background(), `select exists(select 1 from ledger_journals where reversal_of = $1)`,
+		j.ReversalOf.Int64).Scan(&reversed)
+	if err != nil {
+		return nil, apps.Exception("failed to check journal reversal", err, zap.Int64("journal_id", j.ReversalOf.Int64),
+			l.Logger)
+	}
+	if reversed {
+		return nil, nil
+	}
+	id, err := postJournal(tx, j)
+	if err != nil {
+		return nil, apps.Exception("failed to post reversal journal", err, zap.Any("", j), l.Logger)
+	}
+	if err = tx.Commit(context.Background()); err != nil {
+		l.Logger.Panic("failed to commit reverse journal trx", zap.Any("journal", j))
+	}
+	return id, nil
+}
+
+func (l *Ledger) FindJournalById(id int64) (*model.LedgerJournalProjection, *model.TechnicalError) {
+	v := model.LedgerJournalProjection{}
+	rows, err := l.Pool.Query(context.Background(), `select j.id, j.kind, coalesce(j.reference, '') as reference,
+			coalesce(j.reversal_of, 0) as reversal_of,
+			exists(select 1 from ledger_journals r where r.reversal_of = j.id) as reversed
+			from ledger_journals j where j.id = $1`, id)
+	if err != nil {
+		return nil, apps.Exception("failed to find journal by id", err, zap.Int64("id", id), l.Logger)
+	}
+	defer rows.Close()
+
+	err = pgxscan.ScanOne(&v, rows)
+	if err != nil {
+		return nil, apps.Exception("failed to map journal by id", err, zap.Int64("id", id), l.Logger)
+	}
+	return &v, nil
+}
+
+func (l *Ledger) Entries(journalId int64) ([]model.LedgerEntry, *model.TechnicalError) {
+	var data []model.LedgerEntry
+	err := pgxscan.Select(context.Background(), l.Pool, &data, `select id, journal_id, account_type, account_code,
+			debit, credit from ledger_entries where journal_id = $1 order by id`, journalId)
+	if err != nil {
+		return nil, apps.Exception("failed to find journal entries", err, zap.Int64("journal_id", journalId), l.Logger)
+	}
+	return data, nil
+}
+
+func (l *Ledger) Balance(inp *model.LedgerRequest) (*model.LedgerBalanceProjection, *model.TechnicalError) {
+	v := model.LedgerBalanceProjection{}
+	rows, err := l.Pool.Query(context.Background(), `select
+			coalesce(sum(case when created_date < $3::date then debit - credit else 0 end), 0) as opening,
+			coalesce(sum(case when created_date >= $3::date then debit else 0 end), 0) as debit,
+			coalesce(sum(case when created_date >= $3::date then credit else 0 end), 0) as credit
+			from ledger_entries where account_type = $1 and account_code = $2 and created_date < $4::date + 1`,
+		inp.AccountType, inp.AccountCode, inp.StartDate, inp.EndDate)
+	if err != nil {
+		return nil, apps.Exception("failed to find ledger balance", err, zap.Any("", inp), l.Logger)
+	}
+	defer rows.Close()
+
+	err = pgxscan.ScanOne(&v, rows)
+	if err != nil {
+		return nil, apps.Exception("failed to map ledger balance", err, zap.Any("", inp), l.Logger)
+	}
+	return &v, nil
+}
+
+func (l *Ledger) CountStatement(inp *model.LedgerRequest) (*int, *model.TechnicalError) {
+	var count int
+	err := l.Pool.QueryRow(context.Background(), `select count(id) from ledger_entries
+		where account_type = $1 and account_code = $2 and created_date >= $3::date and created_date < $4::date + 1`,
+		inp.AccountType, inp.AccountCode, inp.StartDate, inp.EndDate).Scan(&count)
+	if err != nil {
+		return nil, apps.Exception("failed to count ledger statement", err, zap.Any("", inp), l.Logger)
+	}
+	return &count, nil
+}
+
+func (l *Ledger) Statement(inp *model.LedgerRequest) ([]model.LedgerEntryProjection, *model.TechnicalError) {
+	var data []model.LedgerEntryProjection
+	err := pgxscan.Select(context.Background(), l.Pool, &data, `select * from (select e.id, e.journal_id, j.kind,
+			coalesce(j.reference, '') as reference, coalesce(j.description, '') as description, e.debit, e.credit,
+			sum(e.debit - e.credit) over (order by e.id) as balance,
+			extract(epoch from e.created_date)::bigint as posted_date
+			from ledger_entries e inner join ledger_journals j on j.id = e.journal_id
+			where e.account_type = $1 and e.account_code = $2
+			and e.created_date >= $3::date and e.created_date < $4::date + 1) s
+			order by s.id limit $5 offset $6`,
+		inp.AccountType, inp.AccountCode, inp.StartDate, inp.EndDate, inp.Limit, inp.Start)
+	if err != nil {
+		return nil, apps.Exception("failed to find ledger statement", err, zap.Any("", inp), l.Logger)
+	}
+	return data, nil
+}
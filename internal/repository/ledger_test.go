@@ -0,0 +1,342 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/chrisyxlee/pgxpoolmock"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v4"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLedger_Post(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool, tx := pgxpoolmock.NewMockPgxIface(ctrl), pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewLedger(Ledger{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	j := model.LedgerJournal{
+		Kind:        sql.NullString{String: apps.LedgerJournalReversal, Valid: true},
+		Reference:   sql.NullString{String: "C00223010112000062811", Valid: true},
+		ReversalOf:  sql.NullInt64{Int64: 1, Valid: true},
+		Description: sql.NullString{String: "Cashback was disbursed twice", Valid: true},
+		Entries: model.LedgerTransfer(apps.LedgerCustomerPayable, "628123456789", apps.LedgerPartnerReceivable,
+			"LSAJA", decimal.NewFromInt(16000)),
+		BaseEntity: model.BaseEntity{
+			CreatedBy: sql.NullInt64{Int64: 1, Valid: true},
+		},
+	}
+	args := []interface{}{j.Kind.String, j.Reference.String, j.ReversalOf, j.Description, j.CreatedBy.Int64}
+	t.Run("should success", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"id"}).AddRow(int64(2)).ToPgxRows()
+		rows.Next()
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).Return(tx, nil)
+		tx.EXPECT().QueryRow(ctx, gomock.Any(), args...).Return(rows)
+		for _, e := range j.Entries {
+			tx.EXPECT().Exec(ctx, gomock.Any(), int64(2), e.AccountType.String, e.AccountCode.String, e.Debit,
+				e.Credit).Return(nil, nil)
+		}
+		tx.EXPECT().Commit(ctx).Times(1).Return(nil)
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		v, ex := persister.Post(j)
+		assert.Nil(t, ex)
+		assert.Equal(t, int64(2), *v)
+	})
+
+	t.Run("should return exception on failed to begin transaction", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).
+			Return(nil, fmt.Errorf("something went wrong"))
+		v, ex := persister.Post(j)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+
+	t.Run("should return exception on unbalanced journal", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).Return(tx, nil)
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		v, ex := persister.Post(model.LedgerJournal{Entries: j.Entries[1:]})
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+
+	t.Run("should return exception on failed to add entry", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"id"}).AddRow(int64(2)).ToPgxRows()
+		rows.Next()
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).Return(tx, nil)
+		tx.EXPECT().QueryRow(ctx, gomock.Any(), args...).Return(rows)
+		tx.EXPECT().Exec(ctx, gomock.Any(), int64(2), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, fmt.Errorf("something went wrong"))
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		v, ex := persister.Post(j)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
+
+func TestLedger_Reverse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool, tx := pgxpoolmock.NewMockPgxIface(ctrl), pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewLedger(Ledger{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	j := model.LedgerJournal{
+		Kind:       sql.NullString{String: apps.LedgerJournalReversal, Valid: true},
+		Reference:  sql.NullString{String: "C00223010112000062811", Valid: true},
+		ReversalOf: sql.NullInt64{Int64: 1, Valid: true},
+		Entries: model.LedgerTransfer(apps.LedgerCustomerPayable, "628123456789", apps.LedgerPartnerReceivable,
+			"LSAJA", decimal.NewFromInt(16000)),
+		BaseEntity: model.BaseEntity{
+			CreatedBy: sql.NullInt64{Int64: 1, Valid: true},
+		},
+	}
+	args := []interface{}{j.Kind.String, j.Reference.String, j.ReversalOf, j.Description, j.CreatedBy.Int64}
+	lock := func(reversed bool) {
+		locked := pgxpoolmock.NewRows([]string{"id"}).AddRow(int64(1)).ToPgxRows()
+		locked.Next()
+		check := pgxpoolmock.NewRows([]string{"exists"}).AddRow(reversed).ToPgxRows()
+		check.Next()
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).Return(tx, nil)
+		tx.EXPECT().QueryRow(ctx, gomock.Any(), int64(1)).Return(locked)
+		tx.EXPECT().QueryRow(ctx, gomock.Any(), int64(1)).Return(check)
+	}
+	t.Run("should success", func(t *testing.T) {
+		lock(false)
+		rows := pgxpoolmock.NewRows([]string{"id"}).AddRow(int64(2)).ToPgxRows()
+		rows.Next()
+		tx.EXPECT().QueryRow(ctx, gomock.Any(), args...).Return(rows)
+		for _, e := range j.Entries {
+			tx.EXPECT().Exec(ctx, gomock.Any(), int64(2), e.AccountType.String, e.AccountCode.String, e.Debit,
+				e.Credit).Return(nil, nil)
+		}
+		tx.EXPECT().Commit(ctx).Times(1).Return(nil)
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		v, ex := persister.Reverse(j)
+		assert.Nil(t, ex)
+		assert.Equal(t, int64(2), *v)
+	})
+
+	t.Run("should skip on reversed journal", func(t *testing.T) {
+		lock(true)
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		v, ex := persister.Reverse(j)
+		assert.Nil(t, ex)
+		assert.Nil(t, v)
+	})
+
+	t.Run("should return exception on failed to begin transaction", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).
+			Return(nil, fmt.Errorf("something went wrong"))
+		v, ex := persister.Reverse(j)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+
+	t.Run("should return exception on journal not found", func(t *testing.T) {
+		locked := pgxpoolmock.NewRows(nil).ToPgxRows()
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).Return(tx, nil)
+		tx.EXPECT().QueryRow(ctx, gomock.Any(), int64(1)).Return(locked)
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		v, ex := persister.Reverse(j)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
+
+func TestLedger_FindJournalById(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool := pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewLedger(Ledger{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	t.Run("should success", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"id", "kind", "reference", "reversal_of", "reversed"}).
+			AddRow(int64(1), apps.LedgerJournalCashback, "C00223010112000062811", int64(0), false).ToPgxRows()
+		pool.EXPECT().Query(ctx, gomock.Any(), int64(1)).Return(rows, nil)
+		v, ex := persister.FindJournalById(1)
+		assert.Nil(t, ex)
+		assert.Equal(t, apps.LedgerJournalCashback, v.Kind)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		pool.EXPECT().Query(ctx, gomock.Any(), int64(1)).Return(nil, fmt.Errorf("something went wrong"))
+		v, ex := persister.FindJournalById(1)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+
+	t.Run("should return exception on journal not found", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"id"}).ToPgxRows()
+		pool.EXPECT().Query(ctx, gomock.Any(), int64(1)).Return(rows, nil)
+		v, ex := persister.FindJournalById(1)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
+
+func TestLedger_Entries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool := pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewLedger(Ledger{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	t.Run("should success", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"id", "journal_id", "account_type", "account_code", "debit", "credit"}).
+			AddRow(int64(1), int64(1), sql.NullString{String: apps.LedgerPartnerReceivable, Valid: true},
+				sql.NullString{String: "LSAJA", Valid: true}, decimal.NewFromInt(16000), decimal.Zero).
+			AddRow(int64(2), int64(1), sql.NullString{String: apps.LedgerCustomerPayable, Valid: true},
+				sql.NullString{String: "628123456789", Valid: true}, decimal.Zero, decimal.NewFromInt(16000)).
+			ToPgxRows()
+		pool.EXPECT().Query(ctx, gomock.Any(), int64(1)).Return(rows, nil)
+		v, ex := persister.Entries(1)
+		assert.Nil(t, ex)
+		assert.Len(t, v, 2)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		pool.EXPECT().Query(ctx, gomock.Any(), int64(1)).Return(nil, fmt.Errorf("something went wrong"))
+		v, ex := persister.Entries(1)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
+
+func TestLedger_Balance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool := pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewLedger(Ledger{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	inp := &model.LedgerRequest{
+		AccountType: apps.LedgerPartnerReceivable,
+		AccountCode: "LSAJA",
+		StartDate:   "2023-01-01",
+		EndDate:     "2023-01-31",
+	}
+	t.Run("should success", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"opening", "debit", "credit"}).
+			AddRow(decimal.NewFromInt(1000), decimal.NewFromInt(16000), decimal.Zero).ToPgxRows()
+		pool.EXPECT().Query(ctx, gomock.Any(), inp.AccountType, inp.AccountCode, inp.StartDate, inp.EndDate).
+			Return(rows, nil)
+		v, ex := persister.Balance(inp)
+		assert.Nil(t, ex)
+		assert.True(t, decimal.NewFromInt(16000).Equal(v.Debit))
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		pool.EXPECT().Query(ctx, gomock.Any(), inp.AccountType, inp.AccountCode, inp.StartDate, inp.EndDate).
+			Return(nil, fmt.Errorf("something went wrong"))
+		v, ex := persister.Balance(inp)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+
+	t.Run("should return exception on failed to map query result", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"opening"}).AddRow("LSAJA").ToPgxRows()
+		pool.EXPECT().Query(ctx, gomock.Any(), inp.AccountType, inp.AccountCode, inp.StartDate, inp.EndDate).
+			Return(rows, nil)
+		v, ex := persister.Balance(inp)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
+
+func TestLedger_CountStatement(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool := pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewLedger(Ledger{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	inp := &model.LedgerRequest{
+		AccountType: apps.LedgerPartnerReceivable,
+		AccountCode: "LSAJA",
+		StartDate:   "2023-01-01",
+		EndDate:     "2023-01-31",
+	}
+	t.Run("should success", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"count"}).AddRow(3).ToPgxRows()
+		pool.EXPECT().QueryRow(ctx, gomock.Any(), inp.AccountType, inp.AccountCode, inp.StartDate, inp.EndDate).
+			Return(rows)
+		v, ex := persister.CountStatement(inp)
+		assert.Nil(t, ex)
+		assert.NotNil(t, v)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows(nil).ToPgxRows()
+		pool.EXPECT().QueryRow(ctx, gomock.Any(), inp.AccountType, inp.AccountCode, inp.StartDate, inp.EndDate).
+			Return(rows)
+		v, ex := persister.CountStatement(inp)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
+
+func TestLedger_Statement(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool := pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewLedger(Ledger{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	inp := &model.LedgerRequest{
+		AccountType: apps.LedgerPartnerReceivable,
+		AccountCode: "LSAJA",
+		StartDate:   "2023-01-01",
+		EndDate:     "2023-01-31",
+		SearchRequest: model.SearchRequest{
+			Limit: 5,
+			Start: 0,
+		},
+	}
+	t.Run("should success", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"id", "journal_id", "kind", "reference", "description", "debit",
+			"credit", "balance", "posted_date"}).
+			AddRow(int64(1), int64(1), apps.LedgerJournalCashback, "C00223010112000062811", "",
+				decimal.NewFromInt(16000), decimal.Zero, decimal.NewFromInt(16000), int64(1672617600)).ToPgxRows()
+		pool.EXPECT().Query(ctx, gomock.Any(), inp.AccountType, inp.AccountCode, inp.StartDate, inp.EndDate,
+			inp.Limit, inp.Start).Return(rows, nil)
+		v, ex := persister.Statement(inp)
+		assert.Nil(t, ex)
+		assert.Len(t, v, 1)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		pool.EXPECT().Query(ctx, gomock.Any(), inp.AccountType, inp.AccountCode, inp.StartDate, inp.EndDate,
+			inp.Limit, inp.Start).Return(nil, fmt.Errorf("something went wrong"))
+		v, ex := persister.Statement(inp)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/internal/storage"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap"
+	"strconv"
+	"strings"
+)
+
+type Notification struct {
+	Pool   storage.Pooler
+	Logger *zap.Logger
+}
+
+type NotificationPersister interface {
+	Save(m model.NotificationLog) *model.TechnicalError
+	Count(inp *model.NotificationSearchRequest) (*int, *model.TechnicalError)
+	Search(inp *model.NotificationSearchRequest) ([]model.NotificationLogProjection, *model.TechnicalError)
+	FindByIds(ids []int64) ([]model.NotificationLog, *model.TechnicalError)
+	FindByStatus(status string, limit int) ([]model.NotificationLog, *model.TechnicalError)
+	UpdateStatus(ids []int64, status string) *model.TechnicalError
+}
+
+var notificationSorts = map[string]string{
+	"ID":       "id",
+	"STATUS":   "status",
+	"ATTEMPTS": "attempts",
+	"DATE":     "coalesce(updated_date, created_date)",
+}
+
+func NewNotification(n Notification) NotificationPersister {
+	return &n
+}
+
+func (n *Notification) Save(m model.NotificationLog) *model.TechnicalError {
+	tx, err := n.Pool.BeginTx(context.Background(),
+		pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return apps.Exception("failed to begin save notification log tx", err,
+			zap.String("message_id", m.MessageId.String), n.Logger)
+	}
+	defer tx.Rollback(context.Background())
+
+	_, err = tx.Exec(context.Background(), `INSERT INTO notification_logs 
+		(message_id, queue, channel, destination, subject, content, status, attempts, provider_message_id, 
+		error, is_deleted, created_by, created_date) VALUES ($1, $2, $3, $4, $5, $6, $7, 1, $8, $9, FALSE, 0, NOW()) 
+		ON CONFLICT (message_id) DO UPDATE SET status = EXCLUDED.status, attempts = notification_logs.attempts + 1, 
+		provider_message_id = EXCLUDED.provider_message_id, error = EXCLUDED.error, updated_date = NOW()`,
+		m.MessageId.String, m.Queue.String, m.Channel.String, m.Destination.String, m.Subject.String,
+		m.Content.String, m.Status.String, m.ProviderMessageId.String, m.Error.String)
+	if err != nil {
+		return apps.Exception("failed to save notification log", err,
+			zap.String("message_id", m.MessageId.String), n.Logger)
+	}
+	if err = tx.Commit(context.Background()); err != nil {
+		n.Logger.Panic("failed to commit save notification log trx", zap.String("message_id", m.MessageId.String))
+	}
+	return nil
+}
+
+func (n *Notification) criteria(inp *model.NotificationSearchRequest) (string, []interface{}) {
+	where, args := " where is_deleted = false ", []interface{}{}
+	if inp.Status != "" {
+		args = append(args, inp.Status)
+		where += " and status = $" + strconv.Itoa(len(args))
+	}
+	if inp.TextSearch != "" {
+		args = append(args, "%"+inp.TextSearch+"%")
+		p := "$" + strconv.Itoa(len(args))
+		where += " and (upper(destination) like upper(" + p + ") or upper(subject) like upper(" + p + ")) "
+	}
+	return where, args
+}
+
+func (n *Notification) Count(inp *model.NotificationSearchRequest) (*int, *model.TechnicalError) {
+	var count int
+	where, args := n.criteria(inp)
+	err := n.Pool.QueryRow(context.Background(), `select count(id) from notification_logs`+where, args...).Scan(&count)
+	if err != nil {
+		return nil, apps.Exception("failed to count notification log", err, zap.Any("", inp), n.Logger)
+	}
+	return &count, nil
+}
+
+func (n *Notification) Search(inp *model.NotificationSearchRequest) ([]model.NotificationLogProjection, *model.TechnicalError) {
+	var data []model.NotificationLogProjection
+	where, args := n.criteria(inp)
+	sortBy, ok := notificationSorts[strings.ToUpper(inp.SortBy)]
+	if !ok {
+		sortBy = "id"
+	}
+	sort := "DESC"
+	if strings.EqualFold(inp.Sort, "ASC") {
+		sort = "ASC"
+	}
+	args = append(args, inp.Limit, inp.Start)
+	cmd := `select id, channel, destination, subject, status, attempts, 
+			coalesce(provider_message_id, '') as provider_message_id, coalesce(error, '') as error, 
+			extract(epoch from coalesce(updated_date, created_date))::bigint as last_attempt 
+			from notification_logs` + where + ` order by ` + sortBy + " " + sort +
+		` limit $` + strconv.Itoa(len(args)-1) + ` offset $` + strconv.Itoa(len(args))
+	err := pgxscan.Select(context.Background(), n.Pool, &data, cmd, args...)
+	if err != nil {
+		return nil, apps.Exception("failed to search notification log", err, zap.Any("", inp), n.Logger)
+	}
+	return data, nil
+}
+
+func (n *Notification) FindByIds(ids []int64) ([]model.NotificationLog, *model.TechnicalError) {
+	var data []model.NotificationLog
+	err := pgxscan.Select(context.Background(), n.Pool, &data, `select id, message_id, queue, channel, 
+			destination, subject, content, status, attempts from notification_logs 
+			where id = any($1) and is_deleted = false`, ids)
+	if err != nil {
+		return nil, apps.Exception("failed to find notification log by ids", err, zap.Int64s("ids", ids), n.Logger)
+	}
+	return data, nil
+}
+
+func (n *Notification) FindByStatus(status string, limit int) ([]model.NotificationLog, *model.TechnicalError) {
+	var data []model.NotificationLog
+	err := pgxscan.Select(context.Background(), n.Pool, &data, `select id, message_id, queue, channel, 
+			destination, subject, content, status, attempts from notification_logs 
+			where status = $1 and is_deleted = false order by id limit $2`, status, limit)
+	if err != nil {
+		return nil, apps.Exception("failed to find notification log by status", err, zap.String("status", status), n.Logger)
+	}
+	return data, nil
+}
+
+func (n *Notification) UpdateStatus(ids []int64, status string) *model.TechnicalError {
+	tx, err := n.Pool.BeginTx(context.Background(),
+		pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return apps.Exception("failed to begin update notification status tx", err, zap.Int64s("ids", ids), n.Logger)
+	}
+	defer tx.Rollback(context.Background())
+
+	_, err = tx.Exec(context.Background(), `UPDATE notification_logs SET status = $1, updated_date = NOW() 
+		WHERE id = any($2)`, status, ids)
+	if err != nil {
+		return apps.Exception("failed to update notification status", err, zap.Int64s("ids", ids), n.Logger)
+	}
+	if err = tx.Commit(context.Background()); err != nil {
+		n.Logger.Panic("failed to commit update notification status trx", zap.Int64s("ids", ids))
+	}
+	return nil
+}
@@ -0,0 +1,242 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/chrisyxlee/pgxpoolmock"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNotification_Save(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool, tx := pgxpoolmock.NewMockPgxIface(ctrl), pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewNotification(Notification{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	m := model.NotificationLog{
+		MessageId:   sql.NullString{String: "MSG-001"},
+		Queue:       sql.NullString{String: "notification_email_otp"},
+		Channel:     sql.NullString{String: apps.NotificationChannelEmail},
+		Destination: sql.NullString{String: "someone@email.net"},
+		Subject:     sql.NullString{String: "OTP"},
+		Content:     sql.NullString{String: "<p>123456</p>"},
+		Status:      sql.NullString{String: apps.NotificationSent},
+	}
+	t.Run("should success", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).
+			Return(tx, nil)
+		tx.EXPECT().Exec(ctx, gomock.Any(), m.MessageId.String, m.Queue.String, m.Channel.String,
+			m.Destination.String, m.Subject.String, m.Content.String, m.Status.String,
+			m.ProviderMessageId.String, m.Error.String).Return(nil, nil)
+		tx.EXPECT().Commit(ctx).Times(1).Return(nil)
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		ex := persister.Save(m)
+		assert.Nil(t, ex)
+	})
+
+	t.Run("should return exception on failed to begin transaction", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).
+			Return(nil, fmt.Errorf("something went wrong"))
+		ex := persister.Save(m)
+		assert.NotNil(t, ex)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).
+			Return(tx, nil)
+		tx.EXPECT().Exec(ctx, gomock.Any(), m.MessageId.String, m.Queue.String, m.Channel.String,
+			m.Destination.String, m.Subject.String, m.Content.String, m.Status.String,
+			m.ProviderMessageId.String, m.Error.String).Return(nil, fmt.Errorf("something went wrong"))
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		ex := persister.Save(m)
+		assert.NotNil(t, ex)
+	})
+}
+
+func TestNotification_Count(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool := pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewNotification(Notification{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	cmd := `select count(id) from notification_logs where is_deleted = false `
+	t.Run("should success without filter", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"count"}).AddRow(10).ToPgxRows()
+		pool.EXPECT().QueryRow(ctx, cmd).Return(rows)
+		v, ex := persister.Count(&model.NotificationSearchRequest{})
+		assert.Nil(t, ex)
+		assert.NotNil(t, v)
+	})
+
+	t.Run("should success with status and text search", func(t *testing.T) {
+		where := " and status = $1 and (upper(destination) like upper($2) or upper(subject) like upper($2)) "
+		rows := pgxpoolmock.NewRows([]string{"count"}).AddRow(2).ToPgxRows()
+		pool.EXPECT().QueryRow(ctx, cmd+where, apps.NotificationDead, "%someone%").Return(rows)
+		v, ex := persister.Count(&model.NotificationSearchRequest{
+			Status: apps.NotificationDead,
+			SearchRequest: model.SearchRequest{
+				TextSearch: "someone",
+			},
+		})
+		assert.Nil(t, ex)
+		assert.NotNil(t, v)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows(nil).ToPgxRows()
+		pool.EXPECT().QueryRow(ctx, cmd).Return(rows)
+		v, ex := persister.Count(&model.NotificationSearchRequest{})
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
+
+func TestNotification_Search(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool := pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewNotification(Notification{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	inp := &model.NotificationSearchRequest{
+		Status: apps.NotificationFailed,
+		SearchRequest: model.SearchRequest{
+			Start:  0,
+			Limit:  5,
+			SortBy: "attempts",
+			Sort:   "ASC",
+		},
+	}
+	t.Run("should success", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"id", "channel", "destination", "subject", "status", "attempts",
+			"provider_message_id", "error", "last_attempt"}).AddRow(int64(1), apps.NotificationChannelEmail,
+			"someone@email.net", "OTP", apps.NotificationFailed, 3, "", "throttled", int64(1668056400)).ToPgxRows()
+		pool.EXPECT().Query(ctx, gomock.Any(), apps.NotificationFailed, 5, 0).Return(rows, nil)
+		v, ex := persister.Search(inp)
+		assert.Nil(t, ex)
+		assert.Len(t, v, 1)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		pool.EXPECT().Query(ctx, gomock.Any(), apps.NotificationFailed, 5, 0).
+			Return(nil, fmt.Errorf("something went wrong"))
+		v, ex := persister.Search(inp)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
+
+func TestNotification_FindByIds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool := pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewNotification(Notification{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	ids := []int64{1, 2}
+	t.Run("should success", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"id", "message_id", "queue", "status"}).
+			AddRow(int64(1), sql.NullString{String: "MSG-001", Valid: true},
+				sql.NullString{String: "notification_email_otp", Valid: true},
+				sql.NullString{String: apps.NotificationDead, Valid: true}).ToPgxRows()
+		pool.EXPECT().Query(ctx, gomock.Any(), ids).Return(rows, nil)
+		v, ex := persister.FindByIds(ids)
+		assert.Nil(t, ex)
+		assert.Len(t, v, 1)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		pool.EXPECT().Query(ctx, gomock.Any(), ids).Return(nil, fmt.Errorf("something went wrong"))
+		v, ex := persister.FindByIds(ids)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
+
+func TestNotification_FindByStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool := pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewNotification(Notification{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	t.Run("should success", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"id", "status"}).
+			AddRow(int64(1), sql.NullString{String: apps.NotificationDead, Valid: true}).ToPgxRows()
+		pool.EXPECT().Query(ctx, gomock.Any(), apps.NotificationDead, 100).Return(rows, nil)
+		v, ex := persister.FindByStatus(apps.NotificationDead, 100)
+		assert.Nil(t, ex)
+		assert.Len(t, v, 1)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		pool.EXPECT().Query(ctx, gomock.Any(), apps.NotificationDead, 100).
+			Return(nil, fmt.Errorf("something went wrong"))
+		v, ex := persister.FindByStatus(apps.NotificationDead, 100)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
+
+func TestNotification_UpdateStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool, tx := pgxpoolmock.NewMockPgxIface(ctrl), pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewNotification(Notification{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	ids := []int64{1, 2}
+	cmd := `UPDATE notification_logs SET status = $1, updated_date = NOW() 
+		WHERE id = any($2)`
+	t.Run("should success", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).
+			Return(tx, nil)
+		tx.EXPECT().Exec(ctx, cmd, apps.NotificationResent, ids).Return(nil, nil)
+		tx.EXPECT().Commit(ctx).Times(1).Return(nil)
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		ex := persister.UpdateStatus(ids, apps.NotificationResent)
+		assert.Nil(t, ex)
+	})
+
+	t.Run("should return exception on failed to begin transaction", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).
+			Return(nil, fmt.Errorf("something went wrong"))
+		ex := persister.UpdateStatus(ids, apps.NotificationResent)
+		assert.NotNil(t, ex)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).
+			Return(tx, nil)
+		tx.EXPECT().Exec(ctx, cmd, apps.NotificationResent, ids).Return(nil, fmt.Errorf("something went wrong"))
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		ex := persister.UpdateStatus(ids, apps.NotificationResent)
+		assert.NotNil(t, ex)
+	})
+}
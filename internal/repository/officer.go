@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/internal/storage"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap"
+)
+
+type Officer struct {
+	Pool   storage.Pooler
+	Logger *zap.Logger
+}
+
+type OfficerPersister interface {
+	Add(officer model.PartnerOfficer) *model.TechnicalError
+	Remove(officer model.PartnerOfficer) *model.TechnicalError
+	CountByEmail(email string) (*int, *model.TechnicalError)
+	CountPartnerByEmail(pid int64, email string) (*int, *model.TechnicalError)
+	FindActiveById(pid int64, id int64) (*model.PartnerOfficer, *model.TechnicalError)
+	FindActiveByPartner(pid int64) ([]model.OfficerProjection, *model.TechnicalError)
+}
+
+func NewOfficer(o Officer) OfficerPersister {
+	return &o
+}
+
+func (o *Officer) Add(officer model.PartnerOfficer) *model.TechnicalError {
+	tx, err := o.Pool.BeginTx(context.Background(),
+		pgx.TxOptions{IsoLevel: pgx.Serializable})
+	if err != nil {
+		return apps.Exception("failed to begin add officer tx", err, zap.Any("", officer), o.Logger)
+	}
+	defer tx.Rollback(context.Background())
+
+	_, err = tx.Exec(context.Background(), `insert into partner_officers (partner_id, email, fullname, role, 
+		status, is_deleted, created_by, created_date) values ($1, $2, $3, $4, $5, false, $6, now())`,
+		officer.PartnerId, officer.Email.String, officer.Fullname.String, officer.Role.String,
+		apps.StatusActive, officer.CreatedBy.Int64)
+	if err != nil {
+		return apps.Exception("failed to add officer tx", err, zap.Any("", officer), o.Logger)
+	}
+	if err = tx.Commit(context.Background()); err != nil {
+		o.Logger.Panic("failed to commit add officer trx", zap.Any("officer", officer))
+	}
+	return nil
+}
+
+func (o *Officer) Remove(officer model.PartnerOfficer) *model.TechnicalError {
+	tx, err := o.Pool.BeginTx(context.Background(),
+		pgx.TxOptions{IsoLevel: pgx.Serializable})
+	if err != nil {
+		return apps.Exception("failed to begin remove officer tx", err, zap.Any("", officer), o.Logger)
+	}
+	defer tx.Rollback(context.Background())
+
+	_, err = tx.Exec(context.Background(), `update partner_officers set is_deleted = true, 
+		updated_by = $1, updated_date = now() where id = $2 and partner_id = $3`,
+		officer.UpdatedBy.Int64, officer.Id, officer.PartnerId)
+	if err != nil {
+		return apps.Exception("failed to remove officer tx", err, zap.Any("", officer), o.Logger)
+	}
+	if err = tx.Commit(context.Background()); err != nil {
+		o.Logger.Panic("failed to commit remove officer trx", zap.Any("officer", officer))
+	}
+	return nil
+}
+
+func (o *Officer) CountByEmail(email string) (*int, *model.TechnicalError) {
+	total := 0
+	rows, err := o.Pool.Query(context.Background(), `select count(id) as total from partner_officers 
+		where email = $1 and is_deleted = false`, email)
+	if err != nil {
+		return nil, apps.Exception("failed to count officer by email", err, zap.String("email", email), o.Logger)
+	}
+	defer rows.Close()
+
+	err = pgxscan.ScanOne(&total, rows)
+	if err != nil {
+		return nil, apps.Exception("failed to map count officer by email", err, zap.String("email", email), o.Logger)
+	}
+	return &total, nil
+}
+
+func (o *Officer) CountPartnerByEmail(pid int64, email string) (*int, *model.TechnicalError) {
+	total := 0
+	rows, err := o.Pool.Query(context.Background(), `select count(id) as total from partners 
+		where lower(email) = $1 and id <> $2 and is_deleted = false`, email, pid)
+	if err != nil {
+		return nil, apps.Exception("failed to count partner by email", err, zap.String("email", email), o.Logger)
+	}
+	defer rows.Close()
+
+	err = pgxscan.ScanOne(&total, rows)
+	if err != nil {
+		return nil, apps.Exception("failed to map count partner by email", err, zap.String("email", email), o.Logger)
+	}
+	return &total, nil
+}
+
+func (o *Officer) FindActiveById(pid int64, id int64) (*model.PartnerOfficer, *model.TechnicalError) {
+	d := model.PartnerOfficer{}
+	rows, err := o.Pool.Query(context.Background(), `select id, partner_id, email, fullname, role 
+		from partner_officers where partner_id = $1 and id = $2 and status = $3 
+		and is_deleted = false`, pid, id, apps.StatusActive)
+	if err != nil {
+		return nil, apps.Exception("failed to find active officer by id", err, zap.Int64("id", id), o.Logger)
+	}
+	defer rows.Close()
+
+	err = pgxscan.ScanOne(&d, rows)
+	if err != nil {
+		return nil, apps.Exception("failed to map active officer by id", err, zap.Int64("id", id), o.Logger)
+	}
+	return &d, nil
+}
+
+func (o *Officer) FindActiveByPartner(pid int64) ([]model.OfficerProjection, *model.TechnicalError) {
+	var data []model.OfficerProjection
+	err := pgxscan.Select(context.Background(), o.Pool, &data, `select id, email, fullname, role 
+		from partner_officers where partner_id = $1 and status = $2 
+		and is_deleted = false order by id`, pid, apps.StatusActive)
+	if err != nil {
+		return nil, apps.Exception("failed to find active officers by partner", err, zap.Int64("partner_id", pid), o.Logger)
+	}
+	return data, nil
+}
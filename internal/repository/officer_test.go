@@ -0,0 +1,229 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/chrisyxlee/pgxpoolmock"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestOfficer_Add(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool, tx := pgxpoolmock.NewMockPgxIface(ctrl), pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewOfficer(Officer{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	officer := model.PartnerOfficer{
+		PartnerId: 1,
+		Email:     sql.NullString{String: "jane.doe@email.net", Valid: true},
+		Fullname:  sql.NullString{String: "Jane Doe", Valid: true},
+		Role:      sql.NullString{String: apps.RoleOfficerFinance, Valid: true},
+		BaseEntity: model.BaseEntity{
+			CreatedBy: sql.NullInt64{Int64: 1, Valid: true},
+		},
+	}
+	cmd := `insert into partner_officers (partner_id, email, fullname, role, 
+		status, is_deleted, created_by, created_date) values ($1, $2, $3, $4, $5, false, $6, now())`
+	t.Run("should success", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable}).Return(tx, nil)
+		tx.EXPECT().Exec(ctx, cmd, officer.PartnerId, officer.Email.String, officer.Fullname.String,
+			officer.Role.String, apps.StatusActive, officer.CreatedBy.Int64).Return(nil, nil)
+		tx.EXPECT().Commit(ctx).Times(1).Return(nil)
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		ex := persister.Add(officer)
+		assert.Nil(t, ex)
+	})
+
+	t.Run("should return exception on failed to begin transaction", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable}).
+			Return(nil, fmt.Errorf("something went wrong"))
+		ex := persister.Add(officer)
+		assert.NotNil(t, ex)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable}).Return(tx, nil)
+		tx.EXPECT().Exec(ctx, cmd, officer.PartnerId, officer.Email.String, officer.Fullname.String,
+			officer.Role.String, apps.StatusActive, officer.CreatedBy.Int64).Return(nil, fmt.Errorf("something went wrong"))
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		ex := persister.Add(officer)
+		assert.NotNil(t, ex)
+	})
+}
+
+func TestOfficer_Remove(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool, tx := pgxpoolmock.NewMockPgxIface(ctrl), pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewOfficer(Officer{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	officer := model.PartnerOfficer{
+		Id:        2,
+		PartnerId: 1,
+		BaseEntity: model.BaseEntity{
+			UpdatedBy: sql.NullInt64{Int64: 1, Valid: true},
+		},
+	}
+	cmd := `update partner_officers set is_deleted = true, 
+		updated_by = $1, updated_date = now() where id = $2 and partner_id = $3`
+	t.Run("should success", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable}).Return(tx, nil)
+		tx.EXPECT().Exec(ctx, cmd, officer.UpdatedBy.Int64, officer.Id, officer.PartnerId).Return(nil, nil)
+		tx.EXPECT().Commit(ctx).Times(1).Return(nil)
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		ex := persister.Remove(officer)
+		assert.Nil(t, ex)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable}).Return(tx, nil)
+		tx.EXPECT().Exec(ctx, cmd, officer.UpdatedBy.Int64, officer.Id, officer.PartnerId).
+			Return(nil, fmt.Errorf("something went wrong"))
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		ex := persister.Remove(officer)
+		assert.NotNil(t, ex)
+	})
+}
+
+func TestOfficer_CountByEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool := pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewOfficer(Officer{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	email := "jane.doe@email.net"
+	cmd := `select count(id) as total from partner_officers 
+		where email = $1 and is_deleted = false`
+	t.Run("should success", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"total"}).AddRow(1).ToPgxRows()
+		pool.EXPECT().Query(ctx, cmd, email).Return(rows, nil)
+		v, ex := persister.CountByEmail(email)
+		assert.Nil(t, ex)
+		assert.Equal(t, 1, *v)
+	})
+
+	t.Run("should return exception on failed to execute query", func(t *testing.T) {
+		pool.EXPECT().Query(ctx, cmd, email).Return(nil, fmt.Errorf("something went wrong"))
+		v, ex := persister.CountByEmail(email)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
+
+func TestOfficer_CountPartnerByEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool := pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewOfficer(Officer{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	email := "jane.doe@email.net"
+	cmd := `select count(id) as total from partners 
+		where lower(email) = $1 and id <> $2 and is_deleted = false`
+	t.Run("should success", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"total"}).AddRow(1).ToPgxRows()
+		pool.EXPECT().Query(ctx, cmd, email, int64(7)).Return(rows, nil)
+		v, ex := persister.CountPartnerByEmail(7, email)
+		assert.Nil(t, ex)
+		assert.Equal(t, 1, *v)
+	})
+
+	t.Run("should return exception on failed to execute query", func(t *testing.T) {
+		pool.EXPECT().Query(ctx, cmd, email, int64(7)).Return(nil, fmt.Errorf("something went wrong"))
+		v, ex := persister.CountPartnerByEmail(7, email)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
+
+func TestOfficer_FindActiveById(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool := pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewOfficer(Officer{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	cmd := `select id, partner_id, email, fullname, role 
+		from partner_officers where partner_id = $1 and id = $2 and status = $3 
+		and is_deleted = false`
+	t.Run("should success", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"id", "partner_id", "email", "fullname", "role"}).
+			AddRow(int64(2), int64(1), sql.NullString{String: "jane.doe@email.net", Valid: true},
+				sql.NullString{String: "Jane Doe", Valid: true},
+				sql.NullString{String: apps.RoleOfficerViewer, Valid: true}).ToPgxRows()
+		pool.EXPECT().Query(ctx, cmd, int64(1), int64(2), apps.StatusActive).Return(rows, nil)
+		v, ex := persister.FindActiveById(1, 2)
+		assert.Nil(t, ex)
+		assert.Equal(t, int64(2), v.Id)
+	})
+
+	t.Run("should return exception on failed to execute query", func(t *testing.T) {
+		pool.EXPECT().Query(ctx, cmd, int64(1), int64(2), apps.StatusActive).
+			Return(nil, fmt.Errorf("something went wrong"))
+		v, ex := persister.FindActiveById(1, 2)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+
+	t.Run("should return exception on map query result", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"id"}).AddRow("invalid").ToPgxRows()
+		pool.EXPECT().Query(ctx, cmd, int64(1), int64(2), apps.StatusActive).Return(rows, nil)
+		v, ex := persister.FindActiveById(1, 2)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
+
+func TestOfficer_FindActiveByPartner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool := pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewOfficer(Officer{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	cmd := `select id, email, fullname, role 
+		from partner_officers where partner_id = $1 and status = $2 
+		and is_deleted = false order by id`
+	t.Run("should success", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"id", "email", "fullname", "role"}).
+			AddRow(int64(1), "jane.doe@email.net", "Jane Doe", apps.RoleOfficerAdmin).ToPgxRows()
+		pool.EXPECT().Query(ctx, cmd, int64(1), apps.StatusActive).Return(rows, nil)
+		v, ex := persister.FindActiveByPartner(1)
+		assert.Nil(t, ex)
+		assert.Len(t, v, 1)
+	})
+
+	t.Run("should return exception on failed to execute query", func(t *testing.T) {
+		pool.EXPECT().Query(ctx, cmd, int64(1), apps.StatusActive).Return(nil, fmt.Errorf("something went wrong"))
+		v, ex := persister.FindActiveByPartner(1)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
@@ -16,6 +16,8 @@ type Parameter struct {
 
 type ParamPersister interface {
 	FindByParamGroup(g string) ([]*model.Parameter, *model.TechnicalError)
+	FindLatestByParamGroup(g string) ([]*model.Parameter, *model.TechnicalError)
+	FindByParamGroupAndName(g string, n string, l string, v int) (*model.Parameter, *model.TechnicalError)
 }
 
 func NewParameter(p Parameter) ParamPersister {
@@ -37,3 +39,37 @@ func (p *Parameter) FindByParamGroup(g string) ([]*model.Parameter, *model.Techn
 	}
 	return params, nil
 }
+
+func (p *Parameter) FindLatestByParamGroup(g string) ([]*model.Parameter, *model.TechnicalError) {
+	var params []*model.Parameter
+	rows, err := p.Pool.Query(context.Background(), `SELECT DISTINCT ON (param_name, param_locale) id, param_group, 
+		param_name, param_value, param_version, param_locale from parameters where param_group = $1 
+		order by param_name, param_locale, param_version desc`, g)
+	if err != nil {
+		return nil, apps.Exception("failed to find latest by param group", err, zap.String("group", g), p.Logger)
+	}
+	defer rows.Close()
+
+	err = pgxscan.ScanAll(&params, rows)
+	if err != nil {
+		return nil, apps.Exception("failed to map find latest by param group", err, zap.String("group", g), p.Logger)
+	}
+	return params, nil
+}
+
+func (p *Parameter) FindByParamGroupAndName(g string, n string, l string, v int) (*model.Parameter, *model.TechnicalError) {
+	param := model.Parameter{}
+	rows, err := p.Pool.Query(context.Background(), `SELECT id, param_group, param_name, param_value, param_version, 
+		param_locale from parameters where param_group = $1 and param_name = $2 and coalesce(param_locale, '') = $3 
+		and ($4 = 0 or param_version = $4) order by param_version desc limit 1`, g, n, l, v)
+	if err != nil {
+		return nil, apps.Exception("failed to find by param group and name", err, zap.Strings("", []string{g, n}), p.Logger)
+	}
+	defer rows.Close()
+
+	err = pgxscan.ScanOne(&param, rows)
+	if err != nil {
+		return nil, apps.Exception("failed to map find by param group and name", err, zap.Strings("", []string{g, n}), p.Logger)
+	}
+	return &param, nil
+}
@@ -61,3 +61,94 @@ func TestParameter_FindByParamGroup(t *testing.T) {
 	})
 
 }
+
+func TestParameter_FindLatestByParamGroup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	g := "EMAIL_TEMPLATE"
+	pool := pgxpoolmock.NewMockPgxIface(ctrl)
+	ctx := context.Background()
+	persister := NewParameter(Parameter{
+		Logger: logger,
+		Pool:   pool,
+	})
+	cmd := `SELECT DISTINCT ON (param_name, param_locale) id, param_group, 
+		param_name, param_value, param_version, param_locale from parameters where param_group = $1 
+		order by param_name, param_locale, param_version desc`
+	t.Run("should success", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"id", "param_group", "param_name", "param_value", "param_version", "param_locale"}).AddRow(
+			sql.NullInt64{Int64: 1, Valid: true},
+			sql.NullString{String: g, Valid: true},
+			sql.NullString{String: "OTP", Valid: true},
+			sql.NullString{String: "<p>{{.otp}}</p>", Valid: true},
+			2,
+			sql.NullString{String: "en", Valid: true},
+		).ToPgxRows()
+		pool.EXPECT().Query(ctx, cmd, g).Return(rows, nil)
+		data, ex := persister.FindLatestByParamGroup(g)
+		assert.Nil(t, ex)
+		assert.Equal(t, 2, data[0].ParamVersion)
+		assert.Equal(t, "en", data[0].ParamLocale.String)
+	})
+
+	t.Run("should return exception on failed to execute query", func(t *testing.T) {
+		pool.EXPECT().Query(ctx, cmd, g).Return(nil, fmt.Errorf("something went wrong on execute query"))
+		data, ex := persister.FindLatestByParamGroup(g)
+		assert.Nil(t, data)
+		assert.NotNil(t, ex)
+	})
+
+	t.Run("should return exception on map query result", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"id"}).AddRow("invalid").ToPgxRows()
+		pool.EXPECT().Query(ctx, cmd, g).Return(rows, nil)
+		data, ex := persister.FindLatestByParamGroup(g)
+		assert.Nil(t, data)
+		assert.NotNil(t, ex)
+	})
+}
+
+func TestParameter_FindByParamGroupAndName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	g := "EMAIL_TEMPLATE"
+	pool := pgxpoolmock.NewMockPgxIface(ctrl)
+	ctx := context.Background()
+	persister := NewParameter(Parameter{
+		Logger: logger,
+		Pool:   pool,
+	})
+	cmd := `SELECT id, param_group, param_name, param_value, param_version, 
+		param_locale from parameters where param_group = $1 and param_name = $2 and coalesce(param_locale, '') = $3 
+		and ($4 = 0 or param_version = $4) order by param_version desc limit 1`
+	t.Run("should success", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"id", "param_group", "param_name", "param_value", "param_version", "param_locale"}).AddRow(
+			sql.NullInt64{Int64: 1, Valid: true},
+			sql.NullString{String: g, Valid: true},
+			sql.NullString{String: "OTP", Valid: true},
+			sql.NullString{String: "<p>{{.otp}}</p>", Valid: true},
+			1,
+			sql.NullString{String: "en", Valid: true},
+		).ToPgxRows()
+		pool.EXPECT().Query(ctx, cmd, g, "OTP", "en", 1).Return(rows, nil)
+		data, ex := persister.FindByParamGroupAndName(g, "OTP", "en", 1)
+		assert.Nil(t, ex)
+		assert.Equal(t, "<p>{{.otp}}</p>", data.ParamValue.String)
+	})
+
+	t.Run("should return exception on failed to execute query", func(t *testing.T) {
+		pool.EXPECT().Query(ctx, cmd, g, "OTP", "", 0).Return(nil, fmt.Errorf("something went wrong on execute query"))
+		data, ex := persister.FindByParamGroupAndName(g, "OTP", "", 0)
+		assert.Nil(t, data)
+		assert.NotNil(t, ex)
+	})
+
+	t.Run("should return exception on map query result", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"id"}).AddRow("invalid").ToPgxRows()
+		pool.EXPECT().Query(ctx, cmd, g, "OTP", "", 0).Return(rows, nil)
+		data, ex := persister.FindByParamGroupAndName(g, "OTP", "", 0)
+		assert.Nil(t, data)
+		assert.NotNil(t, ex)
+	})
+}
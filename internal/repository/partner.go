@@ -20,6 +20,7 @@ type PartnerPersister interface {
 	CountByIdentifier(m model.Partner) (*int, *model.TechnicalError)
 	FindActiveByCodeAndApiKey(code string, key string) (*model.Partner, *model.TechnicalError)
 	FindActiveByEmail(email string) (*model.Partner, *model.TechnicalError)
+	FindActiveByCode(code string) (*model.Partner, *model.TechnicalError)
 }
 
 func NewPartner(p Partner) PartnerPersister {
@@ -36,15 +37,24 @@ func (p *Partner) Add(data model.Partner) *model.TechnicalError {
 	defer tx.Rollback(context.Background())
 
 	err = tx.QueryRow(context.Background(), `insert into partners (partner, code, api_key, salt, secret, email, 
-		msisdn, officer, address, logo, status, is_deleted, created_by, created_date)
-		values ($1, $2, $3, $4, $5::bytea, $6, $7, $8, $9, $10, $11, false, $12, now()) returning id`,
+		msisdn, officer, address, logo, locale, status, is_deleted, created_by, created_date)
+		values ($1, $2, $3, $4, $5::bytea, $6, $7, $8, $9, $10, $11, $12, false, $13, now()) returning id`,
 		data.Partner.String, data.Code.String, data.ApiKey.String, data.Salt.String, data.Secret, data.Email.String,
-		data.Msisdn.String, data.Officer.String, data.Address.String, data.Logo.String, data.Status, data.CreatedBy.Int64).Scan(&pid)
+		data.Msisdn.String, data.Officer.String, data.Address.String, data.Logo.String, data.Locale.String, data.Status,
+		data.CreatedBy.Int64).Scan(&pid)
 	if err != nil {
 		return apps.Exception("failed to insert into partners table", err,
 			zap.String("code", data.Code.String), p.Logger)
 	}
 
+	_, err = tx.Exec(context.Background(), `insert into partner_officers (partner_id, email, fullname, role, 
+		status, is_deleted, created_by, created_date) values ($1, $2, $3, $4, $5, false, $6, now())`,
+		pid, data.Email.String, data.Officer.String, apps.RoleOfficerAdmin, data.Status, data.CreatedBy.Int64)
+	if err != nil {
+		return apps.Exception("failed to insert into partner_officers table", err,
+			zap.String("code", data.Code.String), p.Logger)
+	}
+
 	if err = tx.Commit(context.Background()); err != nil {
 		p.Logger.Panic("transaction add partner failed", zap.Error(err))
 	}
@@ -77,7 +87,7 @@ func (p *Partner) CountByIdentifier(data model.Partner) (*int, *model.TechnicalE
 func (p *Partner) FindActiveByCodeAndApiKey(code string, key string) (*model.Partner, *model.TechnicalError) {
 	d := model.Partner{}
 	rows, err := p.Pool.Query(context.Background(), ` select id, partner, code, api_key, salt, secret,
-			email, msisdn from partners where code = $1 and api_key = $2 
+			email, msisdn, logo, locale from partners where code = $1 and api_key = $2 
 			and status = $3 and is_deleted = false `, code, key, apps.StatusActive)
 	if err != nil {
 		return nil, apps.Exception("failed to find active by code and api key", err, zap.Strings("", []string{code, key}), p.Logger)
@@ -94,10 +104,13 @@ func (p *Partner) FindActiveByCodeAndApiKey(code string, key string) (*model.Par
 
 func (p *Partner) FindActiveByEmail(email string) (*model.Partner, *model.TechnicalError) {
 	d := model.Partner{}
-	rows, err := p.Pool.Query(context.Background(), ` select id, partner, code, 
-			api_key, salt, secret, email, msisdn, logo, 
-			address from partners where email = $1 and status = $2 
-			and is_deleted = false `, email, apps.StatusActive)
+	rows, err := p.Pool.Query(context.Background(), ` select p.id, p.partner, p.code, 
+			p.api_key, p.salt, p.secret, coalesce(o.email, p.email) as email, p.msisdn, p.logo, 
+			p.address, p.locale, coalesce(o.role, $3) as role from partners p left join partner_officers o 
+			on o.partner_id = p.id and o.email = $1 and o.status = $2 and o.is_deleted = false 
+			where p.status = $2 and p.is_deleted = false and (o.id is not null or (p.email = $1 
+			and not exists (select 1 from partner_officers x where x.partner_id = p.id and x.email = $1 
+			and x.is_deleted = false))) order by o.id is null, p.id limit 1 `, email, apps.StatusActive, apps.RoleOfficerAdmin)
 	if err != nil {
 		return nil, apps.Exception("failed to find active by email", err, zap.String("", email), p.Logger)
 	}
@@ -110,3 +123,21 @@ func (p *Partner) FindActiveByEmail(email string) (*model.Partner, *model.Techni
 
 	return &d, nil
 }
+
+func (p *Partner) FindActiveByCode(code string) (*model.Partner, *model.TechnicalError) {
+	d := model.Partner{}
+	rows, err := p.Pool.Query(context.Background(), ` select id, partner, code, 
+			email, msisdn, logo, locale from partners where code = $1 and status = $2 
+			and is_deleted = false `, code, apps.StatusActive)
+	if err != nil {
+		return nil, apps.Exception("failed to find active by code", err, zap.String("code", code), p.Logger)
+	}
+	defer rows.Close()
+
+	err = pgxscan.ScanOne(&d, rows)
+	if err != nil {
+		return nil, apps.Exception("failed to map active by code", err, zap.String("code", code), p.Logger)
+	}
+
+	return &d, nil
+}
@@ -40,17 +40,22 @@ func TestPartner_Add(t *testing.T) {
 		Logger: logger,
 		Pool:   pool,
 	})
+	officerCmd := `insert into partner_officers (partner_id, email, fullname, role, 
+		status, is_deleted, created_by, created_date) values ($1, $2, $3, $4, $5, false, $6, now())`
 
 	t.Run("should success", func(t *testing.T) {
 		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable}).
 			Return(tx, nil)
 		rows := pgxpoolmock.NewRows([]string{"id"}).AddRow(1).ToPgxRows()
 		tx.EXPECT().QueryRow(context.Background(), `insert into partners (partner, code, api_key, salt, secret, email, 
-		msisdn, officer, address, logo, status, is_deleted, created_by, created_date)
-		values ($1, $2, $3, $4, $5::bytea, $6, $7, $8, $9, $10, $11, false, $12, now()) returning id`,
+		msisdn, officer, address, logo, locale, status, is_deleted, created_by, created_date)
+		values ($1, $2, $3, $4, $5::bytea, $6, $7, $8, $9, $10, $11, $12, false, $13, now()) returning id`,
 			data.Partner.String, data.Code.String, data.ApiKey.String, data.Salt.String, data.Secret, data.Email.String,
-			data.Msisdn.String, data.Officer.String, data.Address.String, data.Logo.String, data.Status, data.CreatedBy.Int64).
+			data.Msisdn.String, data.Officer.String, data.Address.String, data.Logo.String, data.Locale.String, data.Status,
+			data.CreatedBy.Int64).
 			Return(rows)
+		tx.EXPECT().Exec(ctx, officerCmd, gomock.Any(), data.Email.String, data.Officer.String,
+			apps.RoleOfficerAdmin, data.Status, data.CreatedBy.Int64).Return(nil, nil)
 		tx.EXPECT().Commit(ctx).Times(1).Return(nil)
 		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
 		defer func() {
@@ -74,26 +79,48 @@ func TestPartner_Add(t *testing.T) {
 			Return(tx, nil)
 		rows := pgxpoolmock.NewRows([]string{}).AddRow().ToPgxRows()
 		tx.EXPECT().QueryRow(context.Background(), `insert into partners (partner, code, api_key, salt, secret, email, 
-		msisdn, officer, address, logo, status, is_deleted, created_by, created_date)
-		values ($1, $2, $3, $4, $5::bytea, $6, $7, $8, $9, $10, $11, false, $12, now()) returning id`,
+		msisdn, officer, address, logo, locale, status, is_deleted, created_by, created_date)
+		values ($1, $2, $3, $4, $5::bytea, $6, $7, $8, $9, $10, $11, $12, false, $13, now()) returning id`,
 			data.Partner.String, data.Code.String, data.ApiKey.String, data.Salt.String, data.Secret, data.Email.String,
-			data.Msisdn.String, data.Officer.String, data.Address.String, data.Logo.String, data.Status, data.CreatedBy.Int64).
+			data.Msisdn.String, data.Officer.String, data.Address.String, data.Logo.String, data.Locale.String, data.Status,
+			data.CreatedBy.Int64).
 			Return(rows)
 		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
 		ex := persister.Add(data)
 		assert.NotNil(t, ex)
 	})
 
+	t.Run("should return exception on failed to insert officer", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable}).
+			Return(tx, nil)
+		rows := pgxpoolmock.NewRows([]string{"id"}).AddRow(1).ToPgxRows()
+		tx.EXPECT().QueryRow(context.Background(), `insert into partners (partner, code, api_key, salt, secret, email, 
+		msisdn, officer, address, logo, locale, status, is_deleted, created_by, created_date)
+		values ($1, $2, $3, $4, $5::bytea, $6, $7, $8, $9, $10, $11, $12, false, $13, now()) returning id`,
+			data.Partner.String, data.Code.String, data.ApiKey.String, data.Salt.String, data.Secret, data.Email.String,
+			data.Msisdn.String, data.Officer.String, data.Address.String, data.Logo.String, data.Locale.String, data.Status,
+			data.CreatedBy.Int64).
+			Return(rows)
+		tx.EXPECT().Exec(ctx, officerCmd, gomock.Any(), data.Email.String, data.Officer.String,
+			apps.RoleOfficerAdmin, data.Status, data.CreatedBy.Int64).Return(nil, fmt.Errorf("something went wrong"))
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		ex := persister.Add(data)
+		assert.NotNil(t, ex)
+	})
+
 	t.Run("should rollback on commit failure", func(t *testing.T) {
 		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable}).
 			Return(tx, nil)
 		rows := pgxpoolmock.NewRows([]string{"id"}).AddRow(1).ToPgxRows()
 		tx.EXPECT().QueryRow(context.Background(), `insert into partners (partner, code, api_key, salt, secret, email, 
-		msisdn, officer, address, logo, status, is_deleted, created_by, created_date)
-		values ($1, $2, $3, $4, $5::bytea, $6, $7, $8, $9, $10, $11, false, $12, now()) returning id`,
+		msisdn, officer, address, logo, locale, status, is_deleted, created_by, created_date)
+		values ($1, $2, $3, $4, $5::bytea, $6, $7, $8, $9, $10, $11, $12, false, $13, now()) returning id`,
 			data.Partner.String, data.Code.String, data.ApiKey.String, data.Salt.String, data.Secret, data.Email.String,
-			data.Msisdn.String, data.Officer.String, data.Address.String, data.Logo.String, data.Status, data.CreatedBy.Int64).
+			data.Msisdn.String, data.Officer.String, data.Address.String, data.Logo.String, data.Locale.String, data.Status,
+			data.CreatedBy.Int64).
 			Return(rows)
+		tx.EXPECT().Exec(ctx, officerCmd, gomock.Any(), data.Email.String, data.Officer.String,
+			apps.RoleOfficerAdmin, data.Status, data.CreatedBy.Int64).Return(nil, nil)
 		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
 		tx.EXPECT().Commit(ctx).Times(1).Return(fmt.Errorf("something went wrong on commit insert partner tx"))
 		defer func() {
@@ -168,24 +195,26 @@ func TestPartner_FindActiveByCodeAndApiKey(t *testing.T) {
 
 	t.Run("should success", func(t *testing.T) {
 		rows := pgxpoolmock.NewRows([]string{"id", "partner", "code", "api_key", "salt",
-			"secret", "email", "msisdn"}).AddRow(int64(1), sql.NullString{String: "PT. LinkSaja Indonesia Terpadu", Valid: true},
+			"secret", "email", "msisdn", "locale"}).AddRow(int64(1), sql.NullString{String: "PT. LinkSaja Indonesia Terpadu", Valid: true},
 			sql.NullString{String: "LINKSAJA", Valid: true}, sql.NullString{String: "api-key-123-abc-456", Valid: true},
 			sql.NullString{String: "s4lTs3cr3T", Valid: true}, []byte("something"),
 			sql.NullString{String: "someone@email.net", Valid: true},
-			sql.NullString{String: "628123456789", Valid: true}).ToPgxRows()
+			sql.NullString{String: "628123456789", Valid: true},
+			sql.NullString{String: "en", Valid: true}).ToPgxRows()
 		pool.EXPECT().Query(ctx, ` select id, partner, code, api_key, salt, secret,
-			email, msisdn from partners where code = $1 and api_key = $2 
+			email, msisdn, logo, locale from partners where code = $1 and api_key = $2 
 			and status = $3 and is_deleted = false `, code, key, apps.StatusActive).
 			Return(rows, nil)
 		data, ex := persister.FindActiveByCodeAndApiKey(code, key)
 		assert.Equal(t, int64(1), data.Id)
+		assert.Equal(t, "en", data.Locale.String)
 		assert.NotNil(t, data)
 		assert.Nil(t, ex)
 	})
 
 	t.Run("should return exception on failed to execute query", func(t *testing.T) {
 		pool.EXPECT().Query(ctx, ` select id, partner, code, api_key, salt, secret,
-			email, msisdn from partners where code = $1 and api_key = $2 
+			email, msisdn, logo, locale from partners where code = $1 and api_key = $2 
 			and status = $3 and is_deleted = false `, code, key, apps.StatusActive).
 			Return(nil, fmt.Errorf("something went wrong on execute query"))
 		data, ex := persister.FindActiveByCodeAndApiKey(code, key)
@@ -201,7 +230,7 @@ func TestPartner_FindActiveByCodeAndApiKey(t *testing.T) {
 			sql.NullString{String: "someone@email.net", Valid: true},
 			sql.NullString{String: "628123456789", Valid: true}).ToPgxRows()
 		pool.EXPECT().Query(ctx, ` select id, partner, code, api_key, salt, secret,
-			email, msisdn from partners where code = $1 and api_key = $2 
+			email, msisdn, logo, locale from partners where code = $1 and api_key = $2 
 			and status = $3 and is_deleted = false `, code, key, apps.StatusActive).
 			Return(rows, nil)
 		data, ex := persister.FindActiveByCodeAndApiKey(code, key)
@@ -224,17 +253,22 @@ func TestPartner_FindActiveByEmail(t *testing.T) {
 
 	t.Run("should success", func(t *testing.T) {
 		rows := pgxpoolmock.NewRows([]string{"id", "partner", "code", "api_key", "salt",
-			"secret", "email", "msisdn", "logo", "address"}).AddRow(int64(1), sql.NullString{String: "PT. LinkSaja Indonesia Terpadu", Valid: true},
+			"secret", "email", "msisdn", "logo", "address", "locale", "role"}).AddRow(int64(1), sql.NullString{String: "PT. LinkSaja Indonesia Terpadu", Valid: true},
 			sql.NullString{String: "LINKSAJA", Valid: true}, sql.NullString{String: "api-key-123-abc-456", Valid: true},
 			sql.NullString{String: "s4lTs3cr3T", Valid: true}, []byte("something"),
 			sql.NullString{String: "someone@email.net", Valid: true},
 			sql.NullString{String: "628123456789", Valid: true},
 			sql.NullString{String: "/logo/linksaja-1.png", Valid: true},
-			sql.NullString{String: "Jl. Nakula Sadewa no. 8B Jakarta Selatan", Valid: true}).ToPgxRows()
-		pool.EXPECT().Query(ctx, ` select id, partner, code, 
-			api_key, salt, secret, email, msisdn, logo, 
-			address from partners where email = $1 and status = $2 
-			and is_deleted = false `, email, apps.StatusActive).
+			sql.NullString{String: "Jl. Nakula Sadewa no. 8B Jakarta Selatan", Valid: true},
+			sql.NullString{String: "id", Valid: true},
+			sql.NullString{String: "admin", Valid: true}).ToPgxRows()
+		pool.EXPECT().Query(ctx, ` select p.id, p.partner, p.code, 
+			p.api_key, p.salt, p.secret, coalesce(o.email, p.email) as email, p.msisdn, p.logo, 
+			p.address, p.locale, coalesce(o.role, $3) as role from partners p left join partner_officers o 
+			on o.partner_id = p.id and o.email = $1 and o.status = $2 and o.is_deleted = false 
+			where p.status = $2 and p.is_deleted = false and (o.id is not null or (p.email = $1 
+			and not exists (select 1 from partner_officers x where x.partner_id = p.id and x.email = $1 
+			and x.is_deleted = false))) order by o.id is null, p.id limit 1 `, email, apps.StatusActive, apps.RoleOfficerAdmin).
 			Return(rows, nil)
 		data, ex := persister.FindActiveByEmail(email)
 		assert.Equal(t, int64(1), data.Id)
@@ -243,10 +277,13 @@ func TestPartner_FindActiveByEmail(t *testing.T) {
 	})
 
 	t.Run("should return exception on failed to execute query", func(t *testing.T) {
-		pool.EXPECT().Query(ctx, ` select id, partner, code, 
-			api_key, salt, secret, email, msisdn, logo, 
-			address from partners where email = $1 and status = $2 
-			and is_deleted = false `, email, apps.StatusActive).
+		pool.EXPECT().Query(ctx, ` select p.id, p.partner, p.code, 
+			p.api_key, p.salt, p.secret, coalesce(o.email, p.email) as email, p.msisdn, p.logo, 
+			p.address, p.locale, coalesce(o.role, $3) as role from partners p left join partner_officers o 
+			on o.partner_id = p.id and o.email = $1 and o.status = $2 and o.is_deleted = false 
+			where p.status = $2 and p.is_deleted = false and (o.id is not null or (p.email = $1 
+			and not exists (select 1 from partner_officers x where x.partner_id = p.id and x.email = $1 
+			and x.is_deleted = false))) order by o.id is null, p.id limit 1 `, email, apps.StatusActive, apps.RoleOfficerAdmin).
 			Return(nil, fmt.Errorf("something went wrong on execute query"))
 		data, ex := persister.FindActiveByEmail(email)
 		assert.Nil(t, data)
@@ -262,13 +299,71 @@ func TestPartner_FindActiveByEmail(t *testing.T) {
 			sql.NullString{String: "628123456789", Valid: true},
 			sql.NullString{String: "/logo/linksaja-1.png", Valid: true},
 			sql.NullString{String: "Jl. Nakula Sadewa no. 8B Jakarta Selatan", Valid: true}).ToPgxRows()
-		pool.EXPECT().Query(ctx, ` select id, partner, code, 
-			api_key, salt, secret, email, msisdn, logo, 
-			address from partners where email = $1 and status = $2 
-			and is_deleted = false `, email, apps.StatusActive).
+		pool.EXPECT().Query(ctx, ` select p.id, p.partner, p.code, 
+			p.api_key, p.salt, p.secret, coalesce(o.email, p.email) as email, p.msisdn, p.logo, 
+			p.address, p.locale, coalesce(o.role, $3) as role from partners p left join partner_officers o 
+			on o.partner_id = p.id and o.email = $1 and o.status = $2 and o.is_deleted = false 
+			where p.status = $2 and p.is_deleted = false and (o.id is not null or (p.email = $1 
+			and not exists (select 1 from partner_officers x where x.partner_id = p.id and x.email = $1 
+			and x.is_deleted = false))) order by o.id is null, p.id limit 1 `, email, apps.StatusActive, apps.RoleOfficerAdmin).
 			Return(rows, nil)
 		data, ex := persister.FindActiveByEmail(email)
 		assert.Nil(t, data)
 		assert.NotNil(t, ex)
 	})
 }
+
+func TestPartner_FindActiveByCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool := pgxpoolmock.NewMockPgxIface(ctrl)
+	code := "LINKSAJA"
+	ctx := context.Background()
+	persister := NewPartner(Partner{
+		Logger: logger,
+		Pool:   pool,
+	})
+
+	t.Run("should success", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"id", "partner", "code", "email", "msisdn", "logo", "locale"}).
+			AddRow(int64(1), sql.NullString{String: "PT. LinkSaja Indonesia Terpadu", Valid: true},
+				sql.NullString{String: "LINKSAJA", Valid: true},
+				sql.NullString{String: "someone@email.net", Valid: true},
+				sql.NullString{String: "628123456789", Valid: true},
+				sql.NullString{String: "partner/logo/1.png", Valid: true},
+				sql.NullString{String: "en", Valid: true}).ToPgxRows()
+		pool.EXPECT().Query(ctx, ` select id, partner, code, 
+			email, msisdn, logo, locale from partners where code = $1 and status = $2 
+			and is_deleted = false `, code, apps.StatusActive).
+			Return(rows, nil)
+		data, ex := persister.FindActiveByCode(code)
+		assert.Equal(t, int64(1), data.Id)
+		assert.Nil(t, ex)
+	})
+
+	t.Run("should return exception on failed to execute query", func(t *testing.T) {
+		pool.EXPECT().Query(ctx, ` select id, partner, code, 
+			email, msisdn, logo, locale from partners where code = $1 and status = $2 
+			and is_deleted = false `, code, apps.StatusActive).
+			Return(nil, fmt.Errorf("something went wrong on execute query"))
+		data, ex := persister.FindActiveByCode(code)
+		assert.Nil(t, data)
+		assert.NotNil(t, ex)
+	})
+
+	t.Run("should return exception on map query result", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"id", "partner", "code", "email", "msisdn"}).
+			AddRow(1, sql.NullString{String: "PT. LinkSaja Indonesia Terpadu", Valid: true},
+				sql.NullString{String: "LINKSAJA", Valid: true},
+				sql.NullString{String: "someone@email.net", Valid: true},
+				sql.NullString{String: "628123456789", Valid: true}).ToPgxRows()
+		pool.EXPECT().Query(ctx, ` select id, partner, code, 
+			email, msisdn, logo, locale from partners where code = $1 and status = $2 
+			and is_deleted = false `, code, apps.StatusActive).
+			Return(rows, nil)
+		data, ex := persister.FindActiveByCode(code)
+		assert.Nil(t, data)
+		assert.NotNil(t, ex)
+	})
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/internal/storage"
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap"
+)
+
+type Preference struct {
+	Pool   storage.Pooler
+	Logger *zap.Logger
+}
+
+type PreferencePersister interface {
+	Save(m model.NotificationPreference) *model.TechnicalError
+	CountOptedOut(msisdn string, email string, category string) (*int, *model.TechnicalError)
+}
+
+func NewPreference(p Preference) PreferencePersister {
+	return &p
+}
+
+func (p *Preference) Save(m model.NotificationPreference) *model.TechnicalError {
+	tx, err := p.Pool.BeginTx(context.Background(),
+		pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return apps.Exception("failed to begin save notification preference tx", err,
+			zap.String("msisdn", m.Msisdn.String), p.Logger)
+	}
+	defer tx.Rollback(context.Background())
+
+	_, err = tx.Exec(context.Background(), `INSERT INTO notification_preferences
+		(msisdn, email, category, opted_out, is_deleted, created_by, created_date)
+		VALUES ($1, $2, $3, $4, FALSE, 0, NOW())
+		ON CONFLICT (msisdn, email, category) DO UPDATE SET opted_out = EXCLUDED.opted_out, updated_date = NOW()`,
+		m.Msisdn.String, m.Email.String, m.Category.String, m.OptedOut)
+	if err != nil {
+		return apps.Exception("failed to save notification preference", err,
+			zap.String("msisdn", m.Msisdn.String), p.Logger)
+	}
+	if err = tx.Commit(context.Background()); err != nil {
+		p.Logger.Panic("failed to commit save notification preference trx", zap.String("msisdn", m.Msisdn.String))
+	}
+	return nil
+}
+
+func (p *Preference) CountOptedOut(msisdn string, email string, category string) (*int, *model.TechnicalError) {
+	var count int
+	err := p.Pool.QueryRow(context.Background(), `select count(id) from notification_preferences
+		where category = $1 and opted_out = true and is_deleted = false
+		and (msisdn = $2 or ($3 <> '' and email = $3))`, category, msisdn, email).Scan(&count)
+	if err != nil {
+		return nil, apps.Exception("failed to count notification opt-out", err, zap.String("msisdn", msisdn), p.Logger)
+	}
+	return &count, nil
+}
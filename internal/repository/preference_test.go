@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/chrisyxlee/pgxpoolmock"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPreference_Save(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool, tx := pgxpoolmock.NewMockPgxIface(ctrl), pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewPreference(Preference{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	m := model.NotificationPreference{
+		Msisdn:   sql.NullString{String: "628118770510"},
+		Email:    sql.NullString{String: "someone@email.net"},
+		Category: sql.NullString{String: apps.NotificationCategoryTransactional},
+		OptedOut: true,
+	}
+	t.Run("should success", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).
+			Return(tx, nil)
+		tx.EXPECT().Exec(ctx, gomock.Any(), m.Msisdn.String, m.Email.String, m.Category.String, true).
+			Return(nil, nil)
+		tx.EXPECT().Commit(ctx).Times(1).Return(nil)
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		ex := persister.Save(m)
+		assert.Nil(t, ex)
+	})
+
+	t.Run("should return exception on failed to begin transaction", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).
+			Return(nil, fmt.Errorf("something went wrong"))
+		ex := persister.Save(m)
+		assert.NotNil(t, ex)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).
+			Return(tx, nil)
+		tx.EXPECT().Exec(ctx, gomock.Any(), m.Msisdn.String, m.Email.String, m.Category.String, true).
+			Return(nil, fmt.Errorf("something went wrong"))
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		ex := persister.Save(m)
+		assert.NotNil(t, ex)
+	})
+}
+
+func TestPreference_CountOptedOut(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool := pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewPreference(Preference{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	t.Run("should success", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"count"}).AddRow(1).ToPgxRows()
+		pool.EXPECT().QueryRow(ctx, gomock.Any(), apps.NotificationCategoryTransactional, "628118770510",
+			"someone@email.net").Return(rows)
+		v, ex := persister.CountOptedOut("628118770510", "someone@email.net", apps.NotificationCategoryTransactional)
+		assert.Nil(t, ex)
+		assert.NotNil(t, v)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows(nil).ToPgxRows()
+		pool.EXPECT().QueryRow(ctx, gomock.Any(), apps.NotificationCategoryTransactional, "628118770510", "").
+			Return(rows)
+		v, ex := persister.CountOptedOut("628118770510", "", apps.NotificationCategoryTransactional)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
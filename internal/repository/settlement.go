@@ -0,0 +1,194 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/internal/storage"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap"
+)
+
+type Settlement struct {
+	Pool   storage.Pooler
+	Logger *zap.Logger
+}
+
+type SettlementPersister interface {
+	Cashbacks(h2hCode string, date string, refs []string) ([]model.SettlementCashbackProjection, *model.TechnicalError)
+	Add(m model.Settlement, exceptions []model.SettlementException, settled []int64,
+		journal model.LedgerJournal) (*int64, *model.TechnicalError)
+	FindById(id int64) (*model.SettlementProjection, *model.TechnicalError)
+	Exceptions(id int64) ([]model.SettlementExceptionProjection, *model.TechnicalError)
+	Count() (*int, *model.TechnicalError)
+	Search(inp *model.SearchRequest) ([]model.SettlementProjection, *model.TechnicalError)
+	FindExceptionById(id int64) (*model.SettlementException, *model.TechnicalError)
+	Resolve(m model.SettlementException) *model.TechnicalError
+}
+
+func NewSettlement(s Settlement) SettlementPersister {
+	return &s
+}
+
+const settlementProjection = `select s.id, s.h2h_code, to_char(s.settlement_date, 'YYYY-MM-DD') as settlement_date,
+		s.filename, s.lines, s.matched, s.flagged,
+		(select count(e.id) from settlement_exceptions e where e.settlement_id = s.id and e.status = $1) as unresolved,
+		extract(epoch from s.created_date)::bigint as imported_date
+		from settlements s where s.is_deleted = false `
+
+func (s *Settlement) Cashbacks(h2hCode string, date string, refs []string) ([]model.SettlementCashbackProjection, *model.TechnicalError) {
+	var data []model.SettlementCashbackProjection
+	err := pgxscan.Select(context.Background(), s.Pool, &data, `select c.id, c.kezbek_ref_code,
+			coalesce(c.provider_ref_code, '') as provider_ref_code, c.amount + c.reward as amount,
+			coalesce(t.msisdn, '') as msisdn,
+			(c.created_date >= $2::date and c.created_date < $2::date + 1) as on_date,
+			c.settlement_id is not null as settled
+			from cashbacks c left join transactions t on t.kezbek_ref_code = c.kezbek_ref_code
+			where c.is_deleted = false and c.h2h_code = $1
+			and ((c.created_date >= $2::date and c.created_date < $2::date + 1)
+			or c.kezbek_ref_code = any($3) or c.provider_ref_code = any($3))`, h2hCode, date, refs)
+	if err != nil {
+		return nil, apps.Exception("failed to find settlement cashbacks", err, zap.String("h2h_code", h2hCode),
+			s.Logger)
+	}
+	return data, nil
+}
+
+func (s *Settlement) Add(m model.Settlement, exceptions []model.SettlementException, settled []int64,
+	journal model.LedgerJournal) (*int64, *model.TechnicalError) {
+	var id int64
+	tx, err := s.Pool.BeginTx(context.Background(),
+		pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return nil, apps.Exception("failed to begin add settlement tx", err,
+			zap.String("h2h_code", m.H2HCode.String), s.Logger)
+	}
+	defer tx.Rollback(context.Background())
+
+	err = tx.QueryRow(context.Background(), `INSERT INTO settlements
+		(h2h_code, settlement_date, filename, lines, matched, flagged, is_deleted, created_by, created_date)
+		VALUES ($1, $2, $3, $4, $5, $6, FALSE, $7, NOW()) RETURNING id`,
+		m.H2HCode.String, m.SettlementDate.Time, m.Filename.String, m.Lines, m.Matched, m.Flagged,
+		m.CreatedBy.Int64).Scan(&id)
+	if err != nil {
+		return nil, apps.Exception("failed to add settlement", err, zap.String("h2h_code", m.H2HCode.String), s.Logger)
+	}
+	for _, e := range exceptions {
+		_, err = tx.Exec(context.Background(), `INSERT INTO settlement_exceptions
+			(settlement_id, type, kezbek_ref_code, provider_ref_code, amount, expected, status,
+			is_deleted, created_by, created_date)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, FALSE, $8, NOW())`,
+			id, e.Type.String, e.KezbekRefCode.String, e.ProviderRefCode.String, e.Amount, e.Expected,
+			apps.SettlementOpen, m.CreatedBy.Int64)
+		if err != nil {
+			return nil, apps.Exception("failed to add settlement exception", err, zap.Any("", e), s.Logger)
+		}
+	}
+	if len(settled) > 0 {
+		tag, err := tx.Exec(context.Background(), `update cashbacks set settlement_id = $1,
+			updated_by = $2, updated_date = now() where id = any($3) and settlement_id is null`,
+			id, m.CreatedBy.Int64, settled)
+		if err == nil && tag.RowsAffected() != int64(len(settled)) {
+			err = errors.New("cashbacks are settled already")
+		}
+		if err != nil {
+			return nil, apps.Exception("failed to settle cashbacks", err, zap.Int64s("cashbacks", settled), s.Logger)
+		}
+	}
+	if len(journal.Entries) > 0 {
+		if _, err = postJournal(tx, journal); err != nil {
+			return nil, apps.Exception("failed to post settlement journal", err, zap.Any("", journal), s.Logger)
+		}
+	}
+	if err = tx.Commit(context.Background()); err != nil {
+		s.Logger.Panic("failed to commit add settlement trx", zap.String("h2h_code", m.H2HCode.String))
+	}
+	return &id, nil
+}
+
+func (s *Settlement) FindById(id int64) (*model.SettlementProjection, *model.TechnicalError) {
+	v := model.SettlementProjection{}
+	rows, err := s.Pool.Query(context.Background(), settlementProjection+` and s.id = $2`,
+		apps.SettlementOpen, id)
+	if err != nil {
+		return nil, apps.Exception("failed to find settlement by id", err, zap.Int64("id", id), s.Logger)
+	}
+	defer rows.Close()
+
+	err = pgxscan.ScanOne(&v, rows)
+	if err != nil {
+		return nil, apps.Exception("failed to map settlement by id", err, zap.Int64("id", id), s.Logger)
+	}
+	return &v, nil
+}
+
+func (s *Settlement) Exceptions(id int64) ([]model.SettlementExceptionProjection, *model.TechnicalError) {
+	var data []model.SettlementExceptionProjection
+	err := pgxscan.Select(context.Background(), s.Pool, &data, `select id, type,
+			coalesce(kezbek_ref_code, '') as kezbek_ref_code, coalesce(provider_ref_code, '') as provider_ref_code,
+			amount, expected, status, coalesce(notes, '') as notes
+			from settlement_exceptions where settlement_id = $1 and is_deleted = false order by id`, id)
+	if err != nil {
+		return nil, apps.Exception("failed to find settlement exceptions", err, zap.Int64("id", id), s.Logger)
+	}
+	return data, nil
+}
+
+func (s *Settlement) Count() (*int, *model.TechnicalError) {
+	var count int
+	err := s.Pool.QueryRow(context.Background(), `select count(id) from settlements
+		where is_deleted = false`).Scan(&count)
+	if err != nil {
+		return nil, apps.Exception("failed to count settlement", err, zap.Error(err), s.Logger)
+	}
+	return &count, nil
+}
+
+func (s *Settlement) Search(inp *model.SearchRequest) ([]model.SettlementProjection, *model.TechnicalError) {
+	var data []model.SettlementProjection
+	err := pgxscan.Select(context.Background(), s.Pool, &data, settlementProjection+
+		` order by s.settlement_date desc, s.id desc limit $2 offset $3`,
+		apps.SettlementOpen, inp.Limit, inp.Start)
+	if err != nil {
+		return nil, apps.Exception("failed to search settlement", err, zap.Any("", inp), s.Logger)
+	}
+	return data, nil
+}
+
+func (s *Settlement) FindExceptionById(id int64) (*model.SettlementException, *model.TechnicalError) {
+	v := model.SettlementException{}
+	rows, err := s.Pool.Query(context.Background(), `select id, settlement_id, type, status
+			from settlement_exceptions where id = $1 and is_deleted = false`, id)
+	if err != nil {
+		return nil, apps.Exception("failed to find settlement exception by id", err, zap.Int64("id", id), s.Logger)
+	}
+	defer rows.Close()
+
+	err = pgxscan.ScanOne(&v, rows)
+	if err != nil {
+		return nil, apps.Exception("failed to map settlement exception by id", err, zap.Int64("id", id), s.Logger)
+	}
+	return &v, nil
+}
+
+func (s *Settlement) Resolve(m model.SettlementException) *model.TechnicalError {
+	tx, err := s.Pool.BeginTx(context.Background(),
+		pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return apps.Exception("failed to begin resolve settlement exception tx", err, zap.Int64("id", m.Id), s.Logger)
+	}
+	defer tx.Rollback(context.Background())
+
+	_, err = tx.Exec(context.Background(), `update settlement_exceptions set status = $1, notes = $2,
+		updated_by = $3, updated_date = now() where id = $4`,
+		apps.SettlementResolved, m.Notes.String, m.UpdatedBy.Int64, m.Id)
+	if err != nil {
+		return apps.Exception("failed to resolve settlement exception", err, zap.Int64("id", m.Id), s.Logger)
+	}
+	if err = tx.Commit(context.Background()); err != nil {
+		s.Logger.Panic("failed to commit resolve settlement exception trx", zap.Int64("id", m.Id))
+	}
+	return nil
+}
@@ -0,0 +1,365 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/chrisyxlee/pgxpoolmock"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSettlement_Cashbacks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool := pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewSettlement(Settlement{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	refs := []string{"C00223010112000062811", "trx-001"}
+	t.Run("should success", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"id", "kezbek_ref_code", "provider_ref_code", "amount", "msisdn",
+			"on_date", "settled"}).AddRow(int64(1), "C00223010112000062811", "trx-001", decimal.NewFromInt(15000),
+			"628123456789", true, false).ToPgxRows()
+		pool.EXPECT().Query(ctx, gomock.Any(), apps.H2HLinksaja, "2023-01-01", refs).Return(rows, nil)
+		v, ex := persister.Cashbacks(apps.H2HLinksaja, "2023-01-01", refs)
+		assert.Nil(t, ex)
+		assert.Len(t, v, 1)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		pool.EXPECT().Query(ctx, gomock.Any(), apps.H2HLinksaja, "2023-01-01", refs).
+			Return(nil, fmt.Errorf("something went wrong"))
+		v, ex := persister.Cashbacks(apps.H2HLinksaja, "2023-01-01", refs)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
+
+func TestSettlement_Add(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool, tx := pgxpoolmock.NewMockPgxIface(ctrl), pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewSettlement(Settlement{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	m := model.Settlement{
+		H2HCode:        sql.NullString{String: apps.H2HLinksaja},
+		SettlementDate: sql.NullTime{Time: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
+		Filename:       sql.NullString{String: "LSAJAH2H-20230101.csv"},
+		Lines:          2,
+		Matched:        1,
+		Flagged:        1,
+	}
+	e := model.SettlementException{
+		Type:          sql.NullString{String: apps.SettlementAmountMismatch},
+		KezbekRefCode: sql.NullString{String: "C00223010112000062811"},
+		Amount:        decimal.NewFromInt(15000),
+		Expected:      decimal.NewFromInt(16000),
+	}
+	args := []interface{}{m.H2HCode.String, m.SettlementDate.Time, m.Filename.String, m.Lines, m.Matched,
+		m.Flagged, m.CreatedBy.Int64}
+	journal := model.LedgerJournal{
+		Kind:      sql.NullString{String: apps.LedgerJournalSettlement, Valid: true},
+		Reference: sql.NullString{String: "LSAJAH2H-20230101.csv", Valid: true},
+		Entries: model.LedgerTransfer(apps.LedgerCustomerPayable, "628123456789", apps.LedgerProviderPrefund,
+			apps.H2HLinksaja, decimal.NewFromInt(16000)),
+	}
+	settled := []int64{3}
+	journalArgs := []interface{}{apps.LedgerJournalSettlement, "LSAJAH2H-20230101.csv", journal.ReversalOf,
+		journal.Description, int64(0)}
+	t.Run("should success", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"id"}).AddRow(int64(1)).ToPgxRows()
+		rows.Next()
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).Return(tx, nil)
+		tx.EXPECT().QueryRow(ctx, gomock.Any(), args...).Return(rows)
+		tx.EXPECT().Exec(ctx, gomock.Any(), int64(1), e.Type.String, e.KezbekRefCode.String, "",
+			e.Amount, e.Expected, apps.SettlementOpen, int64(0)).Return(nil, nil)
+		tx.EXPECT().Exec(ctx, gomock.Any(), int64(1), int64(0), settled).Return(pgconn.CommandTag("UPDATE 1"), nil)
+		journalRows := pgxpoolmock.NewRows([]string{"id"}).AddRow(int64(2)).ToPgxRows()
+		journalRows.Next()
+		tx.EXPECT().QueryRow(ctx, gomock.Any(), journalArgs...).Return(journalRows)
+		for _, en := range journal.Entries {
+			tx.EXPECT().Exec(ctx, gomock.Any(), int64(2), en.AccountType.String, en.AccountCode.String, en.Debit,
+				en.Credit).Return(nil, nil)
+		}
+		tx.EXPECT().Commit(ctx).Times(1).Return(nil)
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		v, ex := persister.Add(m, []model.SettlementException{e}, settled, journal)
+		assert.Nil(t, ex)
+		assert.Equal(t, int64(1), *v)
+	})
+
+	t.Run("should success without settled cashback", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"id"}).AddRow(int64(1)).ToPgxRows()
+		rows.Next()
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).Return(tx, nil)
+		tx.EXPECT().QueryRow(ctx, gomock.Any(), args...).Return(rows)
+		tx.EXPECT().Exec(ctx, gomock.Any(), int64(1), e.Type.String, e.KezbekRefCode.String, "",
+			e.Amount, e.Expected, apps.SettlementOpen, int64(0)).Return(nil, nil)
+		tx.EXPECT().Commit(ctx).Times(1).Return(nil)
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		v, ex := persister.Add(m, []model.SettlementException{e}, nil, model.LedgerJournal{})
+		assert.Nil(t, ex)
+		assert.Equal(t, int64(1), *v)
+	})
+
+	t.Run("should return exception on failed to post journal", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"id"}).AddRow(int64(1)).ToPgxRows()
+		rows.Next()
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).Return(tx, nil)
+		tx.EXPECT().QueryRow(ctx, gomock.Any(), args...).Return(rows)
+		tx.EXPECT().Exec(ctx, gomock.Any(), int64(1), e.Type.String, e.KezbekRefCode.String, "",
+			e.Amount, e.Expected, apps.SettlementOpen, int64(0)).Return(nil, nil)
+		tx.EXPECT().Exec(ctx, gomock.Any(), int64(1), int64(0), settled).Return(pgconn.CommandTag("UPDATE 1"), nil)
+		journalRows := pgxpoolmock.NewRows([]string{"id"}).AddRow(int64(2)).ToPgxRows()
+		journalRows.Next()
+		tx.EXPECT().QueryRow(ctx, gomock.Any(), journalArgs...).Return(journalRows)
+		tx.EXPECT().Exec(ctx, gomock.Any(), int64(2), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, fmt.Errorf("something went wrong"))
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		v, ex := persister.Add(m, []model.SettlementException{e}, settled, journal)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+
+	t.Run("should return exception on settled cashbacks", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"id"}).AddRow(int64(1)).ToPgxRows()
+		rows.Next()
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).Return(tx, nil)
+		tx.EXPECT().QueryRow(ctx, gomock.Any(), args...).Return(rows)
+		tx.EXPECT().Exec(ctx, gomock.Any(), int64(1), e.Type.String, e.KezbekRefCode.String, "",
+			e.Amount, e.Expected, apps.SettlementOpen, int64(0)).Return(nil, nil)
+		tx.EXPECT().Exec(ctx, gomock.Any(), int64(1), int64(0), settled).Return(pgconn.CommandTag("UPDATE 0"), nil)
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		v, ex := persister.Add(m, []model.SettlementException{e}, settled, journal)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+
+	t.Run("should return exception on failed to begin transaction", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).
+			Return(nil, fmt.Errorf("something went wrong"))
+		v, ex := persister.Add(m, []model.SettlementException{e}, settled, journal)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+
+	t.Run("should return exception on failed to add exception", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"id"}).AddRow(int64(1)).ToPgxRows()
+		rows.Next()
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).Return(tx, nil)
+		tx.EXPECT().QueryRow(ctx, gomock.Any(), args...).Return(rows)
+		tx.EXPECT().Exec(ctx, gomock.Any(), int64(1), e.Type.String, e.KezbekRefCode.String, "",
+			e.Amount, e.Expected, apps.SettlementOpen, int64(0)).Return(nil, fmt.Errorf("something went wrong"))
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		v, ex := persister.Add(m, []model.SettlementException{e}, settled, journal)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
+
+func TestSettlement_FindById(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool := pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewSettlement(Settlement{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	t.Run("should success", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"id", "h2h_code", "settlement_date", "filename", "lines", "matched",
+			"flagged", "unresolved", "imported_date"}).
+			AddRow(int64(1), apps.H2HLinksaja, "2023-01-01", "LSAJAH2H-20230101.csv", 2, 1, 1, 1,
+				int64(1672617600)).ToPgxRows()
+		pool.EXPECT().Query(ctx, gomock.Any(), apps.SettlementOpen, int64(1)).Return(rows, nil)
+		v, ex := persister.FindById(1)
+		assert.Nil(t, ex)
+		assert.Equal(t, 1, v.Unresolved)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		pool.EXPECT().Query(ctx, gomock.Any(), apps.SettlementOpen, int64(1)).
+			Return(nil, fmt.Errorf("something went wrong"))
+		v, ex := persister.FindById(1)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+
+	t.Run("should return exception on data not found", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"id"}).ToPgxRows()
+		pool.EXPECT().Query(ctx, gomock.Any(), apps.SettlementOpen, int64(1)).Return(rows, nil)
+		v, ex := persister.FindById(1)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
+
+func TestSettlement_Exceptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool := pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewSettlement(Settlement{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	t.Run("should success", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"id", "type", "kezbek_ref_code", "provider_ref_code", "amount",
+			"expected", "status", "notes"}).
+			AddRow(int64(1), apps.SettlementAmountMismatch, "C00223010112000062811", "trx-001",
+				decimal.NewFromInt(15000), decimal.NewFromInt(16000), apps.SettlementOpen, "").ToPgxRows()
+		pool.EXPECT().Query(ctx, gomock.Any(), int64(1)).Return(rows, nil)
+		v, ex := persister.Exceptions(1)
+		assert.Nil(t, ex)
+		assert.Len(t, v, 1)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		pool.EXPECT().Query(ctx, gomock.Any(), int64(1)).Return(nil, fmt.Errorf("something went wrong"))
+		v, ex := persister.Exceptions(1)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
+
+func TestSettlement_Count(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool := pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewSettlement(Settlement{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	t.Run("should success", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"count"}).AddRow(3).ToPgxRows()
+		pool.EXPECT().QueryRow(ctx, gomock.Any()).Return(rows)
+		v, ex := persister.Count()
+		assert.Nil(t, ex)
+		assert.NotNil(t, v)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows(nil).ToPgxRows()
+		pool.EXPECT().QueryRow(ctx, gomock.Any()).Return(rows)
+		v, ex := persister.Count()
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
+
+func TestSettlement_Search(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool := pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewSettlement(Settlement{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	inp := &model.SearchRequest{Start: 0, Limit: 10}
+	t.Run("should success", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"id", "h2h_code", "settlement_date"}).
+			AddRow(int64(1), apps.H2HLinksaja, "2023-01-01").ToPgxRows()
+		pool.EXPECT().Query(ctx, gomock.Any(), apps.SettlementOpen, 10, 0).Return(rows, nil)
+		v, ex := persister.Search(inp)
+		assert.Nil(t, ex)
+		assert.Len(t, v, 1)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		pool.EXPECT().Query(ctx, gomock.Any(), apps.SettlementOpen, 10, 0).
+			Return(nil, fmt.Errorf("something went wrong"))
+		v, ex := persister.Search(inp)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
+
+func TestSettlement_FindExceptionById(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool := pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewSettlement(Settlement{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	t.Run("should success", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"id", "settlement_id", "type", "status"}).
+			AddRow(int64(1), int64(1), sql.NullString{String: apps.SettlementDuplicate, Valid: true},
+				sql.NullString{String: apps.SettlementOpen, Valid: true}).ToPgxRows()
+		pool.EXPECT().Query(ctx, gomock.Any(), int64(1)).Return(rows, nil)
+		v, ex := persister.FindExceptionById(1)
+		assert.Nil(t, ex)
+		assert.Equal(t, apps.SettlementOpen, v.Status.String)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		pool.EXPECT().Query(ctx, gomock.Any(), int64(1)).Return(nil, fmt.Errorf("something went wrong"))
+		v, ex := persister.FindExceptionById(1)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
+
+func TestSettlement_Resolve(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool, tx := pgxpoolmock.NewMockPgxIface(ctrl), pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewSettlement(Settlement{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	m := model.SettlementException{
+		Id:    1,
+		Notes: sql.NullString{String: "Reward was settled on the next statement"},
+	}
+	t.Run("should success", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).Return(tx, nil)
+		tx.EXPECT().Exec(ctx, gomock.Any(), apps.SettlementResolved, m.Notes.String, int64(0), m.Id).
+			Return(nil, nil)
+		tx.EXPECT().Commit(ctx).Times(1).Return(nil)
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		ex := persister.Resolve(m)
+		assert.Nil(t, ex)
+	})
+
+	t.Run("should return exception on failed to begin transaction", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).
+			Return(nil, fmt.Errorf("something went wrong"))
+		ex := persister.Resolve(m)
+		assert.NotNil(t, ex)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).Return(tx, nil)
+		tx.EXPECT().Exec(ctx, gomock.Any(), apps.SettlementResolved, m.Notes.String, int64(0), m.Id).
+			Return(nil, fmt.Errorf("something went wrong"))
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		ex := persister.Resolve(m)
+		assert.NotNil(t, ex)
+	})
+}
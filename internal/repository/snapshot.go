@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/internal/storage"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap"
+	"time"
+)
+
+type Snapshot struct {
+	Pool   storage.Pooler
+	Logger *zap.Logger
+}
+
+type SnapshotPersister interface {
+	Stale(since time.Time) ([]string, *model.TechnicalError)
+	Rebuild(date string) (*int64, *model.TechnicalError)
+}
+
+func NewSnapshot(s Snapshot) SnapshotPersister {
+	return &s
+}
+
+func (s *Snapshot) Stale(since time.Time) ([]string, *model.TechnicalError) {
+	var data []string
+	err := pgxscan.Select(context.Background(), s.Pool, &data, `select to_char(x.day, 'YYYY-MM-DD') from (
+			select t.created_date::date as day,
+			greatest(t.created_date, t.updated_date, c.created_date, c.updated_date) as changed
+			from transactions t left join cashbacks c on c.kezbek_ref_code = t.kezbek_ref_code
+			where greatest(t.created_date, t.updated_date, c.created_date, c.updated_date) >= $1
+			union all
+			select t.created_date::date, r.created_date from ledger_journals r
+			inner join ledger_journals j on j.id = r.reversal_of and j.kind = $2
+			inner join transactions t on t.kezbek_ref_code = j.reference
+			where r.created_date >= $1
+			union all
+			select t.created_date::date, tj.created_date from tier_journeys tj
+			inner join transactions t on t.id = tj.last_transaction_id
+			where tj.created_date >= $1) x
+			left join daily_snapshot_runs s on s.snapshot_date = x.day
+			where x.day < current_date and (s.built_date is null or x.changed > s.built_date)
+			group by x.day order by x.day`, since, apps.LedgerJournalCashback)
+	if err != nil {
+		return nil, apps.Exception("failed to find stale snapshot days", err, zap.Time("since", since), s.Logger)
+	}
+	return data, nil
+}
+
+func (s *Snapshot) Rebuild(date string) (*int64, *model.TechnicalError) {
+	tx, err := s.Pool.BeginTx(context.Background(),
+		pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return nil, apps.Exception("failed to begin rebuild snapshot tx", err, zap.String("date", date), s.Logger)
+	}
+	defer tx.Rollback(context.Background())
+
+	_, err = tx.Exec(context.Background(), `delete from daily_snapshots where snapshot_date = $1::date`, date)
+	if err != nil {
+		return nil, apps.Exception("failed to clear snapshot", err, zap.String("date", date), s.Logger)
+	}
+	tag, err := tx.Exec(context.Background(), `insert into daily_snapshots
+		(snapshot_date, partner_id, wallet_code, h2h_code, tier, transactions, cashbacks, amount,
+		cashback, reward, fee, unique_msisdn, created_date)
+		select $1::date, t.partner_id, t.wallet_code, coalesce(c.h2h_code, ''), coalesce(tj.current_tier, ''),
+		count(t.id), count(c.id), coalesce(sum(t.amount), 0), coalesce(sum(c.amount), 0),
+		coalesce(sum(c.reward), 0), coalesce(sum(coalesce(c.fee, f.fee)), 0), count(distinct t.msisdn), now()
+		from transactions t
+		left join cashbacks c on c.kezbek_ref_code = t.kezbek_ref_code and c.is_deleted = false
+			and not exists (select 1 from ledger_journals j inner join ledger_journals r on r.reversal_of = j.id
+			where j.kind = $2 and j.reference = c.kezbek_ref_code)
+		left join lateral (select min(pf.fee) as fee from h2h_provider_fees pf
+			inner join h2h_providers hp on hp.id = pf.h2h_provider_id
+			where hp.code = c.h2h_code and pf.wallet_code = c.wallet_code and pf.is_deleted = false) f on true
+		left join lateral (select tj.current_tier from tier_journeys tj inner join tiers tr on tr.id = tj.tier_id
+			where tr.partner_id = t.partner_id and tr.msisdn = t.msisdn and tj.is_deleted = false
+			and (tj.last_transaction_id = t.id or tj.created_date <= t.created_date)
+			order by tj.last_transaction_id = t.id desc, tj.created_date desc limit 1) tj on true
+		where t.is_deleted = false and t.created_date >= $1::date and t.created_date < $1::date + 1
+		group by t.partner_id, t.wallet_code, 4, 5`, date, apps.LedgerJournalCashback)
+	if err != nil {
+		return nil, apps.Exception("failed to aggregate snapshot", err, zap.String("date", date), s.Logger)
+	}
+	_, err = tx.Exec(context.Background(), `insert into daily_snapshot_runs (snapshot_date, built_date)
+		values ($1::date, now()) on conflict (snapshot_date) do update set built_date = excluded.built_date`, date)
+	if err != nil {
+		return nil, apps.Exception("failed to mark snapshot run", err, zap.String("date", date), s.Logger)
+	}
+	if err = tx.Commit(context.Background()); err != nil {
+		s.Logger.Panic("failed to commit rebuild snapshot trx", zap.String("date", date))
+	}
+	rows := tag.RowsAffected()
+	return &rows, nil
+}
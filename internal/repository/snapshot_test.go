@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/chrisyxlee/pgxpoolmock"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSnapshot_Stale(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool := pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewSnapshot(Snapshot{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	since := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	t.Run("should success", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"to_char"}).AddRow("2023-01-02").AddRow("2023-01-05").ToPgxRows()
+		pool.EXPECT().Query(ctx, gomock.Any(), since, apps.LedgerJournalCashback).Return(rows, nil)
+		v, ex := persister.Stale(since)
+		assert.Nil(t, ex)
+		assert.Equal(t, []string{"2023-01-02", "2023-01-05"}, v)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		pool.EXPECT().Query(ctx, gomock.Any(), since, apps.LedgerJournalCashback).
+			Return(nil, fmt.Errorf("something went wrong"))
+		v, ex := persister.Stale(since)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
+
+func TestSnapshot_Rebuild(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool, tx := pgxpoolmock.NewMockPgxIface(ctrl), pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewSnapshot(Snapshot{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	clear := `delete from daily_snapshots where snapshot_date = $1::date`
+	t.Run("should success", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).Return(tx, nil)
+		tx.EXPECT().Exec(ctx, clear, "2023-01-02").Return(pgconn.CommandTag("DELETE 2"), nil)
+		tx.EXPECT().Exec(ctx, gomock.Any(), "2023-01-02", apps.LedgerJournalCashback).
+			Return(pgconn.CommandTag("INSERT 0 3"), nil)
+		tx.EXPECT().Exec(ctx, gomock.Any(), "2023-01-02").Return(pgconn.CommandTag("INSERT 0 1"), nil)
+		tx.EXPECT().Commit(ctx).Return(nil)
+		tx.EXPECT().Rollback(ctx).Return(nil)
+		v, ex := persister.Rebuild("2023-01-02")
+		assert.Nil(t, ex)
+		assert.Equal(t, int64(3), *v)
+	})
+
+	t.Run("should return exception on failed to begin transaction", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).
+			Return(nil, fmt.Errorf("something went wrong"))
+		v, ex := persister.Rebuild("2023-01-02")
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+
+	t.Run("should return exception on failed to aggregate", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).Return(tx, nil)
+		tx.EXPECT().Exec(ctx, clear, "2023-01-02").Return(pgconn.CommandTag("DELETE 2"), nil)
+		tx.EXPECT().Exec(ctx, gomock.Any(), "2023-01-02", apps.LedgerJournalCashback).
+			Return(nil, fmt.Errorf("something went wrong"))
+		tx.EXPECT().Rollback(ctx).Return(nil)
+		v, ex := persister.Rebuild("2023-01-02")
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
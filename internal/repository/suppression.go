@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/internal/storage"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap"
+	"strconv"
+	"strings"
+)
+
+type Suppression struct {
+	Pool   storage.Pooler
+	Logger *zap.Logger
+}
+
+type SuppressionPersister interface {
+	Save(m model.EmailSuppression) *model.TechnicalError
+	CountByEmail(email string) (*int, *model.TechnicalError)
+	CountByPartner(inp *model.SearchRequest) (*int, *model.TechnicalError)
+	SearchByPartner(inp *model.SearchRequest) ([]model.SuppressionProjection, *model.TechnicalError)
+}
+
+func NewSuppression(s Suppression) SuppressionPersister {
+	return &s
+}
+
+func (s *Suppression) Save(m model.EmailSuppression) *model.TechnicalError {
+	tx, err := s.Pool.BeginTx(context.Background(),
+		pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return apps.Exception("failed to begin save email suppression tx", err,
+			zap.String("email", m.Email.String), s.Logger)
+	}
+	defer tx.Rollback(context.Background())
+
+	_, err = tx.Exec(context.Background(), `INSERT INTO email_suppressions
+		(email, reason, detail, provider_message_id, is_deleted, created_by, created_date)
+		VALUES ($1, $2, $3, $4, FALSE, 0, NOW())
+		ON CONFLICT (email) DO UPDATE SET reason = EXCLUDED.reason, detail = EXCLUDED.detail,
+		provider_message_id = EXCLUDED.provider_message_id, is_deleted = FALSE, updated_date = NOW()`,
+		strings.ToLower(m.Email.String), m.Reason.String, m.Detail.String, m.ProviderMessageId.String)
+	if err != nil {
+		return apps.Exception("failed to save email suppression", err,
+			zap.String("email", m.Email.String), s.Logger)
+	}
+	if err = tx.Commit(context.Background()); err != nil {
+		s.Logger.Panic("failed to commit save email suppression trx", zap.String("email", m.Email.String))
+	}
+	return nil
+}
+
+func (s *Suppression) CountByEmail(email string) (*int, *model.TechnicalError) {
+	var count int
+	err := s.Pool.QueryRow(context.Background(), `select count(id) from email_suppressions
+		where email = $1 and is_deleted = false`, strings.ToLower(email)).Scan(&count)
+	if err != nil {
+		return nil, apps.Exception("failed to count email suppression", err, zap.String("email", email), s.Logger)
+	}
+	return &count, nil
+}
+
+func (s *Suppression) partnerCriteria(inp *model.SearchRequest) (string, []interface{}) {
+	where := ` where s.is_deleted = false and exists (select 1 from transactions t
+			where lower(t.email) = s.email and t.partner_id = $1) `
+	args := []interface{}{inp.SessionRequest.Id}
+	if inp.TextSearch != "" {
+		where += ` and s.email like $2 `
+		args = append(args, "%"+strings.ToLower(inp.TextSearch)+"%")
+	}
+	return where, args
+}
+
+func (s *Suppression) CountByPartner(inp *model.SearchRequest) (*int, *model.TechnicalError) {
+	var count int
+	where, args := s.partnerCriteria(inp)
+	err := s.Pool.QueryRow(context.Background(), `select count(s.id) from email_suppressions s`+where,
+		args...).Scan(&count)
+	if err != nil {
+		return nil, apps.Exception("failed to count partner email suppression", err, zap.Any("", inp), s.Logger)
+	}
+	return &count, nil
+}
+
+func (s *Suppression) SearchByPartner(inp *model.SearchRequest) ([]model.SuppressionProjection, *model.TechnicalError) {
+	var data []model.SuppressionProjection
+	where, args := s.partnerCriteria(inp)
+	sort := "DESC"
+	if strings.EqualFold(inp.Sort, "ASC") {
+		sort = "ASC"
+	}
+	args = append(args, inp.Limit, inp.Start)
+	cmd := `select s.email, s.reason, coalesce(s.detail, '') as detail,
+			extract(epoch from coalesce(s.updated_date, s.created_date))::bigint as suppressed_date
+			from email_suppressions s` + where + ` order by coalesce(s.updated_date, s.created_date) ` + sort +
+		`, s.id ` + sort + ` limit $` + strconv.Itoa(len(args)-1) + ` offset $` + strconv.Itoa(len(args))
+	err := pgxscan.Select(context.Background(), s.Pool, &data, cmd, args...)
+	if err != nil {
+		return nil, apps.Exception("failed to search partner email suppression", err, zap.Any("", inp), s.Logger)
+	}
+	return data, nil
+}
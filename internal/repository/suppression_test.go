@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/chrisyxlee/pgxpoolmock"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSuppression_Save(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool, tx := pgxpoolmock.NewMockPgxIface(ctrl), pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewSuppression(Suppression{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	m := model.EmailSuppression{
+		Email:             sql.NullString{String: "Someone@Email.net"},
+		Reason:            sql.NullString{String: apps.SuppressionBounce},
+		Detail:            sql.NullString{String: "smtp; 550 5.1.1 user unknown"},
+		ProviderMessageId: sql.NullString{String: "0100018a-2b3c"},
+	}
+	t.Run("should success", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).
+			Return(tx, nil)
+		tx.EXPECT().Exec(ctx, gomock.Any(), "someone@email.net", m.Reason.String, m.Detail.String,
+			m.ProviderMessageId.String).Return(nil, nil)
+		tx.EXPECT().Commit(ctx).Times(1).Return(nil)
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		ex := persister.Save(m)
+		assert.Nil(t, ex)
+	})
+
+	t.Run("should return exception on failed to begin transaction", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).
+			Return(nil, fmt.Errorf("something went wrong"))
+		ex := persister.Save(m)
+		assert.NotNil(t, ex)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}).
+			Return(tx, nil)
+		tx.EXPECT().Exec(ctx, gomock.Any(), "someone@email.net", m.Reason.String, m.Detail.String,
+			m.ProviderMessageId.String).Return(nil, fmt.Errorf("something went wrong"))
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		ex := persister.Save(m)
+		assert.NotNil(t, ex)
+	})
+}
+
+func TestSuppression_CountByEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool := pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewSuppression(Suppression{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	t.Run("should success", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"count"}).AddRow(1).ToPgxRows()
+		pool.EXPECT().QueryRow(ctx, gomock.Any(), "someone@email.net").Return(rows)
+		v, ex := persister.CountByEmail("SOMEONE@email.net")
+		assert.Nil(t, ex)
+		assert.NotNil(t, v)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows(nil).ToPgxRows()
+		pool.EXPECT().QueryRow(ctx, gomock.Any(), "someone@email.net").Return(rows)
+		v, ex := persister.CountByEmail("someone@email.net")
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
+
+func TestSuppression_CountByPartner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool := pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewSuppression(Suppression{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	t.Run("should success without text search", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"count"}).AddRow(3).ToPgxRows()
+		pool.EXPECT().QueryRow(ctx, gomock.Any(), int64(1)).Return(rows)
+		v, ex := persister.CountByPartner(&model.SearchRequest{
+			SessionRequest: model.SessionRequest{Id: 1},
+		})
+		assert.Nil(t, ex)
+		assert.NotNil(t, v)
+	})
+
+	t.Run("should success with text search", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"count"}).AddRow(1).ToPgxRows()
+		pool.EXPECT().QueryRow(ctx, gomock.Any(), int64(1), "%someone%").Return(rows)
+		v, ex := persister.CountByPartner(&model.SearchRequest{
+			TextSearch:     "SomeOne",
+			SessionRequest: model.SessionRequest{Id: 1},
+		})
+		assert.Nil(t, ex)
+		assert.NotNil(t, v)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows(nil).ToPgxRows()
+		pool.EXPECT().QueryRow(ctx, gomock.Any(), int64(1)).Return(rows)
+		v, ex := persister.CountByPartner(&model.SearchRequest{
+			SessionRequest: model.SessionRequest{Id: 1},
+		})
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
+
+func TestSuppression_SearchByPartner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool := pgxpoolmock.NewMockPgxIface(ctrl)
+	persister := NewSuppression(Suppression{
+		Logger: logger,
+		Pool:   pool,
+	})
+	ctx := context.Background()
+	inp := &model.SearchRequest{
+		Start:          0,
+		Limit:          10,
+		SessionRequest: model.SessionRequest{Id: 1},
+	}
+	t.Run("should success", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"email", "reason", "detail", "suppressed_date"}).
+			AddRow("someone@email.net", apps.SuppressionBounce, "smtp; 550 5.1.1 user unknown",
+				int64(1668056400)).ToPgxRows()
+		pool.EXPECT().Query(ctx, gomock.Any(), int64(1), 10, 0).Return(rows, nil)
+		v, ex := persister.SearchByPartner(inp)
+		assert.Nil(t, ex)
+		assert.Len(t, v, 1)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		pool.EXPECT().Query(ctx, gomock.Any(), int64(1), 10, 0).
+			Return(nil, fmt.Errorf("something went wrong"))
+		v, ex := persister.SearchByPartner(inp)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
@@ -20,7 +20,7 @@ type TierPersister interface {
 	FindByPartnerMsisdn(pid int64, msisdn string) (*model.Tier, *model.TechnicalError)
 	Add(tier model.Tier) *model.TechnicalError
 	Update(tier model.Tier) *model.TechnicalError
-	Expire(expired time.Time) *model.TechnicalError
+	Expire(expired time.Time) ([]model.TierChangeProjection, *model.TechnicalError)
 	CountExpire() (*int, *model.TechnicalError)
 }
 
@@ -144,14 +144,15 @@ func (t *Tier) CountExpire() (*int, *model.TechnicalError) {
 	return &count, nil
 }
 
-func (t *Tier) Expire(expired time.Time) *model.TechnicalError {
+func (t *Tier) Expire(expired time.Time) ([]model.TierChangeProjection, *model.TechnicalError) {
+	var d []model.TierChangeProjection
 	tx, err := t.Pool.BeginTx(context.Background(),
 		pgx.TxOptions{IsoLevel: pgx.Serializable})
 	if err != nil {
-		return apps.Exception("failed to begin expire tier tx", err, zap.Time("", time.Now()), t.Logger)
+		return nil, apps.Exception("failed to begin expire tier tx", err, zap.Time("", time.Now()), t.Logger)
 	}
 	defer tx.Rollback(context.Background())
-	_, err = tx.Exec(context.Background(), `UPDATE tiers SET
+	rows, err := tx.Query(context.Background(), `UPDATE tiers SET
 		current_grade = prev_grade, 
 		current_tier = prev_tier, 
 		prev_grade = current_grade, 
@@ -161,14 +162,24 @@ func (t *Tier) Expire(expired time.Time) *model.TechnicalError {
 		updated_date = NOW(), 
 		updated_by = 0 
 		WHERE 
-			expired_date <= now()`,
+			expired_date <= now() 
+		RETURNING id, partner_id, 
+			coalesce((select p.code from partners p where p.id = tiers.partner_id), '') as partner_code, 
+			coalesce((select p.partner from partners p where p.id = tiers.partner_id), '') as partner, 
+			coalesce((select p.locale from partners p where p.id = tiers.partner_id), '') as locale, 
+			coalesce(msisdn, '') as msisdn, coalesce(email, '') as email, current_grade, current_tier, 
+			prev_grade, prev_tier, expired_date`,
 		expired,
 	)
 	if err != nil {
-		return apps.Exception("failed to expire tier tx", err, zap.Time("", time.Now()), t.Logger)
+		return nil, apps.Exception("failed to expire tier tx", err, zap.Time("", time.Now()), t.Logger)
+	}
+	err = pgxscan.ScanAll(&d, rows)
+	if err != nil {
+		return nil, apps.Exception("failed to map expired tier tx", err, zap.Time("", time.Now()), t.Logger)
 	}
 	if err = tx.Commit(context.Background()); err != nil {
 		t.Logger.Panic("failed to commit expire tier", zap.Time("", time.Now()))
 	}
-	return nil
+	return d, nil
 }
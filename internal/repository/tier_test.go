@@ -114,44 +114,64 @@ func TestTier_Expire(t *testing.T) {
 		updated_date = NOW(), 
 		updated_by = 0 
 		WHERE 
-			expired_date <= now()`
+			expired_date <= now() 
+		RETURNING id, partner_id, 
+			coalesce((select p.code from partners p where p.id = tiers.partner_id), '') as partner_code, 
+			coalesce((select p.partner from partners p where p.id = tiers.partner_id), '') as partner, 
+			coalesce((select p.locale from partners p where p.id = tiers.partner_id), '') as locale, 
+			coalesce(msisdn, '') as msisdn, coalesce(email, '') as email, current_grade, current_tier, 
+			prev_grade, prev_tier, expired_date`
+	columns := []string{"id", "partner_id", "partner_code", "partner", "locale", "msisdn", "email",
+		"current_grade", "current_tier", "prev_grade", "prev_tier", "expired_date"}
 	ctx := context.Background()
 	exp := time.Now()
 	t.Run("should success", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows(columns).AddRow(int64(1), int64(1), "LSAJA", "PT. LinkSaja", "id",
+			"628118770510", "someone@email.net", 1, "BRONZE", 2, "SILVER", exp).ToPgxRows()
 		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable}).
 			Return(tx, nil)
-		tx.EXPECT().Exec(ctx, cmd, exp).Return(nil, nil)
+		tx.EXPECT().Query(ctx, cmd, exp).Return(rows, nil)
 		tx.EXPECT().Commit(ctx).Times(1).Return(nil)
 		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
-		defer func() {
-			if r := recover(); r != nil {
-				assert.Equal(t, "failed to commit expire tier", r)
-			}
-		}()
-		ex := persister.Expire(exp)
+		v, ex := persister.Expire(exp)
 		assert.Nil(t, ex)
+		assert.NotNil(t, v)
 	})
 
 	t.Run("should return exception on failed to begin transaction", func(t *testing.T) {
 		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable}).
 			Return(nil, fmt.Errorf("something went wrong"))
-		ex := persister.Expire(exp)
+		v, ex := persister.Expire(exp)
 		assert.NotNil(t, ex)
+		assert.Nil(t, v)
 	})
 
 	t.Run("should return exception on failed to query", func(t *testing.T) {
 		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable}).
 			Return(tx, nil)
-		tx.EXPECT().Exec(ctx, cmd, exp).Return(nil, fmt.Errorf("something went wrong"))
+		tx.EXPECT().Query(ctx, cmd, exp).Return(nil, fmt.Errorf("something went wrong"))
 		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
-		ex := persister.Expire(exp)
+		v, ex := persister.Expire(exp)
 		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+
+	t.Run("should return exception on failed to map the result", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows([]string{"id"}).AddRow("x").ToPgxRows()
+		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable}).
+			Return(tx, nil)
+		tx.EXPECT().Query(ctx, cmd, exp).Return(rows, nil)
+		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
+		v, ex := persister.Expire(exp)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
 	})
 
 	t.Run("should rollback on failed to commit transaction", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows(columns).ToPgxRows()
 		pool.EXPECT().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable}).
 			Return(tx, nil)
-		tx.EXPECT().Exec(ctx, cmd, exp).Return(nil, nil)
+		tx.EXPECT().Query(ctx, cmd, exp).Return(rows, nil)
 		tx.EXPECT().Commit(ctx).Times(1).Return(fmt.Errorf("something went wrong"))
 		tx.EXPECT().Rollback(ctx).Times(1).Return(nil)
 		defer func() {
@@ -159,8 +179,7 @@ func TestTier_Expire(t *testing.T) {
 				assert.Equal(t, "failed to commit expire tier", r)
 			}
 		}()
-		ex := persister.Expire(exp)
-		assert.Nil(t, ex)
+		_, _ = persister.Expire(exp)
 	})
 }
 
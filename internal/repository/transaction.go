@@ -8,6 +8,8 @@ import (
 	"github.com/georgysavva/scany/pgxscan"
 	"github.com/jackc/pgx/v4"
 	"go.uber.org/zap"
+	"strconv"
+	"strings"
 )
 
 type Transaction struct {
@@ -17,9 +19,10 @@ type Transaction struct {
 
 type TransactionPersister interface {
 	Add(trx model.Transaction) (*int64, *model.TechnicalError)
-	SearchByPartner(inp *model.SearchRequest) ([]model.PartnerTransactionProjection, *model.TechnicalError)
-	CountByPartner(inp *model.SearchRequest) (*int, *model.TechnicalError)
+	SearchByPartner(inp *model.PartnerTransactionSearchRequest) ([]model.PartnerTransactionProjection, *model.TechnicalError)
+	CountByPartner(inp *model.PartnerTransactionSearchRequest) (*int, *model.TechnicalError)
 	DetailByPartner(inp *model.FindByIdRequest) (*model.PartnerTransactionProjection, *model.TechnicalError)
+	StreamByPartner(inp *model.PartnerTransactionSearchRequest, fn func(v model.PartnerTransactionProjection) error) *model.TechnicalError
 }
 
 func NewTransaction(t Transaction) TransactionPersister {
@@ -28,8 +31,9 @@ func NewTransaction(t Transaction) TransactionPersister {
 
 func (t *Transaction) DetailByPartner(inp *model.FindByIdRequest) (*model.PartnerTransactionProjection, *model.TechnicalError) {
 	v := model.PartnerTransactionProjection{}
-	rows, err := t.Pool.Query(context.Background(), `select t.id, t.wallet_code, t.email, t.msisdn, 
-			t.qty, t.amount as transaction, c.amount as cashback, c.reward 
+	rows, err := t.Pool.Query(context.Background(), `select t.id, t.kezbek_ref_code, t.wallet_code, t.email, t.msisdn, 
+			t.qty, t.amount as transaction, c.amount as cashback, c.reward, 
+			extract(epoch from t.created_date)::bigint as transaction_date 
 			from transactions t inner join cashbacks c 
 			on t.kezbek_ref_code = c.kezbek_ref_code and t.id = $1 and t.partner_id = $2`, inp.Id, inp.SessionRequest.Id)
 	if err != nil {
@@ -44,72 +48,125 @@ func (t *Transaction) DetailByPartner(inp *model.FindByIdRequest) (*model.Partne
 	return &v, nil
 }
 
-func (t *Transaction) CountByPartner(inp *model.SearchRequest) (*int, *model.TechnicalError) {
-	var (
-		count int
-		row   pgx.Row
-	)
-	where := " AND '1' = $2 "
-	if inp.TextSearch != "" {
-		where = ` AND (t.msisdn like $2 OR t.email like $2 OR t.kezbek_ref_code like $2 ) `
-	}
-	cmd := `select count(t.id) 
-			from transactions t inner join cashbacks c 
+var transactionSorts = map[string]struct{ column, cast string }{
+	"ID":          {"t.id", "bigint"},
+	"DATE":        {"t.created_date", "timestamp"},
+	"WALLET_CODE": {"t.wallet_code", "text"},
+	"TRANSACTION": {"t.amount", "numeric"},
+	"AMOUNT":      {"t.amount", "numeric"},
+	"CASHBACK":    {"coalesce(c.amount, 0)", "numeric"},
+	"REWARD":      {"coalesce(c.reward, 0)", "numeric"},
+}
+
+const partnerTransactionProjection = `select t.id, t.kezbek_ref_code, t.wallet_code, t.email, t.msisdn,
+			t.qty, t.amount as transaction, coalesce(c.amount, 0) as cashback, coalesce(c.reward, 0) as reward,
+			extract(epoch from t.created_date)::bigint as transaction_date, coalesce(c.h2h_code, '') as h2h_code,
+			case when c.id is null then 'FAILED' else 'DISBURSED' end as status`
+
+const partnerTransactionFrom = `
+			from transactions t left join cashbacks c
 			on t.kezbek_ref_code = c.kezbek_ref_code
-			where t.partner_id = $1 ` + where
+			where t.partner_id = $1`
 
-	if inp.TextSearch == "" {
-		row = t.Pool.QueryRow(context.Background(), cmd, inp.SessionRequest.Id, "1")
-	} else {
-		row = t.Pool.QueryRow(context.Background(), cmd, inp.SessionRequest.Id, inp.TextSearch)
-	}
-	err := row.Scan(&count)
-	if err != nil {
-		return nil, apps.Exception("failed to count partner transaction", err, zap.Any("", inp), t.Logger)
-	}
-	return &count, nil
+type transactionFilter struct {
+	where strings.Builder
+	args  []interface{}
+}
+
+func (f *transactionFilter) bind(v interface{}) string {
+	f.args = append(f.args, v)
+	return "$" + strconv.Itoa(len(f.args))
 }
 
-func (t *Transaction) buildOrder(inp *model.SearchRequest) {
-	if inp.SortBy == "" {
-		inp.SortBy = " t.id "
+func (t *Transaction) filter(inp *model.PartnerTransactionSearchRequest) *transactionFilter {
+	f := &transactionFilter{args: []interface{}{inp.SessionRequest.Id}}
+	if inp.TextSearch != "" {
+		p := f.bind("%" + inp.TextSearch + "%")
+		f.where.WriteString(" and (upper(t.msisdn) like upper(" + p + ") or upper(t.email) like upper(" + p +
+			") or upper(t.kezbek_ref_code) like upper(" + p + "))")
+	}
+	if inp.StartDate != "" {
+		f.where.WriteString(" and t.created_date >= " + f.bind(inp.StartDate) + "::date")
+	}
+	if inp.EndDate != "" {
+		f.where.WriteString(" and t.created_date < " + f.bind(inp.EndDate) + "::date + 1")
 	}
-	if inp.Sort == "" {
-		inp.Sort = " DESC "
+	if inp.WalletCode != "" {
+		f.where.WriteString(" and t.wallet_code = " + f.bind(inp.WalletCode))
 	}
-	if inp.SortBy == "WALLET_CODE" {
-		inp.SortBy = "t.wallet_code"
+	if inp.H2HCode != "" {
+		f.where.WriteString(" and c.h2h_code = " + f.bind(inp.H2HCode))
 	}
-	if inp.SortBy == "AMOUNT" {
-		inp.SortBy = "c.amount"
+	if inp.MinTransaction != "" {
+		f.where.WriteString(" and t.amount >= " + f.bind(inp.MinTransaction) + "::numeric")
 	}
-	if inp.SortBy == "REWARD" {
-		inp.SortBy = "c.reward"
+	if inp.MaxTransaction != "" {
+		f.where.WriteString(" and t.amount <= " + f.bind(inp.MaxTransaction) + "::numeric")
 	}
+	if inp.MinCashback != "" {
+		f.where.WriteString(" and coalesce(c.amount, 0) >= " + f.bind(inp.MinCashback) + "::numeric")
+	}
+	if inp.MaxCashback != "" {
+		f.where.WriteString(" and coalesce(c.amount, 0) <= " + f.bind(inp.MaxCashback) + "::numeric")
+	}
+	if inp.Status == apps.TransactionFailed {
+		f.where.WriteString(" and c.id is null")
+	} else {
+		f.where.WriteString(" and c.id is not null")
+	}
+	return f
+}
 
+func (t *Transaction) order(inp *model.PartnerTransactionSearchRequest) (column string, cast string, desc bool) {
+	sort, ok := transactionSorts[strings.ToUpper(inp.SortBy)]
+	if !ok {
+		sort = transactionSorts["ID"]
+	}
+	return sort.column, sort.cast, strings.ToUpper(inp.Sort) != "ASC"
 }
 
-func (t *Transaction) SearchByPartner(inp *model.SearchRequest) ([]model.PartnerTransactionProjection, *model.TechnicalError) {
-	var data []model.PartnerTransactionProjection
-	where := " AND '1' = $2 "
-	if inp.TextSearch != "" {
-		where = ` AND (UPPER(t.msisdn) like UPPER($2) OR UPPER(t.email) like UPPER($2) OR UPPER(t.kezbek_ref_code) like UPPER($2) ) `
+func orderBy(column string, desc bool) string {
+	if desc {
+		return " order by " + column + " desc, t.id desc"
 	}
-	t.buildOrder(inp)
-	cmd := `select t.id, t.wallet_code, t.email, t.msisdn, 
-			t.qty, t.amount as transaction, c.amount as cashback, c.reward 
-			from transactions t inner join cashbacks c 
-			on t.kezbek_ref_code = c.kezbek_ref_code
-			where t.partner_id = $1 ` + where + `
-			order by ` + inp.SortBy + " " + inp.Sort + ` limit $3 offset $4`
-	var err error
-	if inp.TextSearch != "" {
-		err = pgxscan.Select(context.Background(), t.Pool, &data,
-			cmd, inp.SessionRequest.Id, "%"+inp.TextSearch+"%", inp.Limit, inp.Start)
+	return " order by " + column + " asc, t.id asc"
+}
+
+func (t *Transaction) CountByPartner(inp *model.PartnerTransactionSearchRequest) (*int, *model.TechnicalError) {
+	var count int
+	f := t.filter(inp)
+	err := t.Pool.QueryRow(context.Background(), `select count(t.id)`+partnerTransactionFrom+f.where.String(),
+		f.args...).Scan(&count)
+	if err != nil {
+		return nil, apps.Exception("failed to count partner transaction", err, zap.Any("", inp), t.Logger)
+	}
+	return &count, nil
+}
+
+func (t *Transaction) SearchByPartner(inp *model.PartnerTransactionSearchRequest) ([]model.PartnerTransactionProjection,
+	*model.TechnicalError) {
+	var data []model.PartnerTransactionProjection
+	f := t.filter(inp)
+	column, cast, desc := t.order(inp)
+	page := ""
+	if inp.Cursor != "" {
+		c, err := model.ParseCursor(inp.Cursor)
+		if err != nil {
+			return nil, apps.Exception("failed to parse partner transaction cursor", err, zap.Any("", inp), t.Logger)
+		}
+		cmp := " > "
+		if desc {
+			cmp = " < "
+		}
+		f.where.WriteString(" and (" + column + ", t.id)" + cmp + "(" + f.bind(c.Key) + "::" + cast + ", " +
+			f.bind(c.Id) + ")")
 	} else {
-		err = pgxscan.Select(context.Background(), t.Pool, &data,
-			cmd, inp.SessionRequest.Id, "1", inp.Limit, inp.Start)
+		page = " offset " + f.bind(inp.Start)
 	}
+	page = " limit " + f.bind(inp.Limit) + page
+	err := pgxscan.Select(context.Background(), t.Pool, &data, partnerTransactionProjection+
+		", ("+column+")::text as sort_key"+partnerTransactionFrom+f.where.String()+orderBy(column, desc)+page,
+		f.args...)
 	if err != nil {
 		return nil, apps.Exception("failed to search partner transaction", err,
 			zap.Any("", inp), t.Logger)
@@ -117,6 +174,33 @@ func (t *Transaction) SearchByPartner(inp *model.SearchRequest) ([]model.Partner
 	return data, nil
 }
 
+func (t *Transaction) StreamByPartner(inp *model.PartnerTransactionSearchRequest,
+	fn func(v model.PartnerTransactionProjection) error) *model.TechnicalError {
+	f := t.filter(inp)
+	column, _, desc := t.order(inp)
+	rows, err := t.Pool.Query(context.Background(), partnerTransactionProjection+partnerTransactionFrom+
+		f.where.String()+orderBy(column, desc), f.args...)
+	if err != nil {
+		return apps.Exception("failed to stream partner transaction", err, zap.Any("", inp), t.Logger)
+	}
+	defer rows.Close()
+
+	rs := pgxscan.NewRowScanner(rows)
+	for rows.Next() {
+		v := model.PartnerTransactionProjection{}
+		if err = rs.Scan(&v); err != nil {
+			return apps.Exception("failed to map streamed partner transaction", err, zap.Any("", inp), t.Logger)
+		}
+		if err = fn(v); err != nil {
+			return apps.Exception("failed to handle streamed partner transaction", err, zap.Any("", inp), t.Logger)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return apps.Exception("failed to stream partner transaction", err, zap.Any("", inp), t.Logger)
+	}
+	return nil
+}
+
 func (t *Transaction) Add(trx model.Transaction) (*int64, *model.TechnicalError) {
 	tx, err := t.Pool.BeginTx(context.Background(),
 		pgx.TxOptions{IsoLevel: pgx.Serializable})
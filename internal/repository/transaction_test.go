@@ -11,6 +11,7 @@ import (
 	"github.com/jackc/pgx/v4"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
+	"strings"
 	"testing"
 )
 
@@ -24,8 +25,9 @@ func TestTransaction_DetailByPartner(t *testing.T) {
 		Logger: logger,
 		Pool:   pool,
 	})
-	cmd := `select t.id, t.wallet_code, t.email, t.msisdn, 
-			t.qty, t.amount as transaction, c.amount as cashback, c.reward 
+	cmd := `select t.id, t.kezbek_ref_code, t.wallet_code, t.email, t.msisdn, 
+			t.qty, t.amount as transaction, c.amount as cashback, c.reward, 
+			extract(epoch from t.created_date)::bigint as transaction_date 
 			from transactions t inner join cashbacks c 
 			on t.kezbek_ref_code = c.kezbek_ref_code and t.id = $1 and t.partner_id = $2`
 	inp := &model.FindByIdRequest{
@@ -73,46 +75,57 @@ func TestTransaction_CountByPartner(t *testing.T) {
 		Pool:   pool,
 	})
 
-	cmd := `select count(t.id) 
-			from transactions t inner join cashbacks c 
+	cmd := `select count(t.id)
+			from transactions t left join cashbacks c
 			on t.kezbek_ref_code = c.kezbek_ref_code
-			where t.partner_id = $1 `
-	inp := &model.SearchRequest{
-		SessionRequest: model.SessionRequest{
-			Id: 7,
+			where t.partner_id = $1`
+	inp := &model.PartnerTransactionSearchRequest{
+		SearchRequest: model.SearchRequest{
+			SessionRequest: model.SessionRequest{
+				Id: 7,
+			},
 		},
 	}
 	t.Run("should return exception on failed to map the result", func(t *testing.T) {
-		where := " AND '1' = $2 "
 		rows := pgxpoolmock.NewRows(nil).ToPgxRows()
-		pool.EXPECT().QueryRow(ctx, cmd+where, inp.SessionRequest.Id, gomock.Any()).
-			Return(rows)
+		pool.EXPECT().QueryRow(ctx, cmd+" and c.id is not null", inp.SessionRequest.Id).Return(rows)
 		v, ex := persister.CountByPartner(inp)
 		assert.Nil(t, v)
 		assert.NotNil(t, ex)
 	})
 
-	t.Run("should success without text search", func(t *testing.T) {
-		where := " AND '1' = $2 "
+	t.Run("should success without filter", func(t *testing.T) {
 		rows := pgxpoolmock.NewRows([]string{"count"}).
 			AddRow(10).ToPgxRows()
-		pool.EXPECT().QueryRow(ctx, cmd+where, inp.SessionRequest.Id, gomock.Any()).
-			Return(rows)
+		rows.Next()
+		pool.EXPECT().QueryRow(ctx, cmd+" and c.id is not null", inp.SessionRequest.Id).Return(rows)
 		v, ex := persister.CountByPartner(inp)
 		assert.Nil(t, ex)
-		assert.NotNil(t, v)
+		assert.Equal(t, 10, *v)
 	})
 
-	t.Run("should success with text search", func(t *testing.T) {
-		inp.TextSearch = "something to search"
-		where := ` AND (t.msisdn like $2 OR t.email like $2 OR t.kezbek_ref_code like $2 ) `
+	t.Run("should success with text search and filters", func(t *testing.T) {
+		inp.TextSearch = "someone"
+		inp.StartDate = "2023-01-01"
+		inp.EndDate = "2023-01-31"
+		inp.WalletCode = "LSAJA"
+		inp.H2HCode = "XENIT"
+		inp.MinTransaction = "10000"
+		inp.MaxCashback = "25000"
+		inp.Status = apps.TransactionFailed
 		rows := pgxpoolmock.NewRows([]string{"count"}).
 			AddRow(10).ToPgxRows()
-		pool.EXPECT().QueryRow(ctx, cmd+where, inp.SessionRequest.Id, gomock.Any()).
+		rows.Next()
+		pool.EXPECT().QueryRow(ctx, cmd+
+			" and (upper(t.msisdn) like upper($2) or upper(t.email) like upper($2) or upper(t.kezbek_ref_code) like upper($2))"+
+			" and t.created_date >= $3::date and t.created_date < $4::date + 1 and t.wallet_code = $5"+
+			" and c.h2h_code = $6 and t.amount >= $7::numeric and coalesce(c.amount, 0) <= $8::numeric"+
+			" and c.id is null",
+			inp.SessionRequest.Id, "%someone%", "2023-01-01", "2023-01-31", "LSAJA", "XENIT", "10000", "25000").
 			Return(rows)
 		v, ex := persister.CountByPartner(inp)
 		assert.Nil(t, ex)
-		assert.NotNil(t, v)
+		assert.Equal(t, 10, *v)
 	})
 }
 
@@ -126,103 +139,168 @@ func TestTransaction_SearchByPartner(t *testing.T) {
 		Logger: logger,
 		Pool:   pool,
 	})
-	inp := &model.SearchRequest{
-		TextSearch: "",
-		Start:      1,
-		Limit:      10,
-		SortBy:     "",
-		Sort:       "",
-		SessionRequest: model.SessionRequest{
-			Id: 1,
+	inp := &model.PartnerTransactionSearchRequest{
+		SearchRequest: model.SearchRequest{
+			Start: 10,
+			Limit: 10,
+			SessionRequest: model.SessionRequest{
+				Id: 1,
+			},
 		},
 	}
-	cmd := `select t.id, t.wallet_code, t.email, t.msisdn, 
-			t.qty, t.amount as transaction, c.amount as cashback, c.reward 
-			from transactions t inner join cashbacks c 
-			on t.kezbek_ref_code = c.kezbek_ref_code
-			where t.partner_id = $1  AND '1' = $2 ` + `
-			order by `
+	columns := []string{"id", "kezbek_ref_code", "wallet_code", "email", "msisdn", "qty", "transaction",
+		"cashback", "reward", "transaction_date", "h2h_code", "status", "sort_key"}
+	result := func() pgx.Rows {
+		return pgxpoolmock.NewRows(columns).
+			AddRow(int64(1), "TRX0012345678", "CODE_A", "someone@email.id", "628118770510", 1,
+				decimal.NewFromInt(25000), decimal.NewFromInt(2500), decimal.NewFromInt(1000), int64(1672531200),
+				"XENIT", apps.TransactionDisbursed, "1").ToPgxRows()
+	}
 
-	t.Run("should success without text search", func(t *testing.T) {
-		rows := pgxpoolmock.NewRows([]string{"id", "wallet_code", "email", "msisdn", "qty", "transaction",
-			"cashback", "reward"}).
-			AddRow(int64(1), "CODE_A", "someone@email.id", "628118770510", 1, decimal.NewFromInt(25000),
-				decimal.NewFromInt(2500), decimal.NewFromInt(1000)).ToPgxRows()
-		pool.EXPECT().Query(ctx, cmd+" t.id   DESC  limit $3 offset $4", inp.SessionRequest.Id, "1", inp.Limit, inp.Start).Return(rows, nil)
+	t.Run("should success by the offset sorted by id", func(t *testing.T) {
+		pool.EXPECT().Query(ctx, gomock.Any(), inp.SessionRequest.Id, inp.Start, inp.Limit).DoAndReturn(
+			func(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+				assert.Contains(t, sql, ", (t.id)::text as sort_key")
+				assert.True(t, strings.HasSuffix(sql, " order by t.id desc, t.id desc limit $3 offset $2"))
+				return result(), nil
+			})
 		v, ex := persister.SearchByPartner(inp)
 		assert.Nil(t, ex)
-		assert.NotNil(t, v)
+		assert.Len(t, v, 1)
+		assert.Equal(t, "1", v[0].SortKey)
+		assert.Equal(t, apps.TransactionDisbursed, v[0].Status)
 	})
 
-	t.Run("should success without text search and sorted by wallet code", func(t *testing.T) {
-		inp.SortBy = "WALLET_CODE"
-		rows := pgxpoolmock.NewRows([]string{"id", "wallet_code", "email", "msisdn", "qty", "transaction",
-			"cashback", "reward"}).
-			AddRow(int64(1), "CODE_A", "someone@email.id", "628118770510", 1, decimal.NewFromInt(25000),
-				decimal.NewFromInt(2500), decimal.NewFromInt(1000)).ToPgxRows()
-		pool.EXPECT().Query(ctx, cmd+"t.wallet_code  DESC  limit $3 offset $4", inp.SessionRequest.Id, "1", inp.Limit, inp.Start).Return(rows, nil)
+	t.Run("should success after the cursor sorted by cashback", func(t *testing.T) {
+		inp.SortBy = "CASHBACK"
+		inp.Sort = "ASC"
+		inp.WalletCode = "LSAJA"
+		inp.Cursor = model.Cursor{Key: "2500", Id: 7}.String()
+		pool.EXPECT().Query(ctx, gomock.Any(), inp.SessionRequest.Id, "LSAJA", "2500", int64(7), inp.Limit).DoAndReturn(
+			func(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+				assert.Contains(t, sql, " and t.wallet_code = $2 and c.id is not null and (coalesce(c.amount, 0), t.id) > ($3::numeric, $4)")
+				assert.True(t, strings.HasSuffix(sql, " order by coalesce(c.amount, 0) asc, t.id asc limit $5"))
+				return result(), nil
+			})
 		v, ex := persister.SearchByPartner(inp)
 		assert.Nil(t, ex)
-		assert.NotNil(t, v)
+		assert.Len(t, v, 1)
 	})
 
-	t.Run("should success without text search and sorted by amount", func(t *testing.T) {
+	t.Run("should sort by the transaction amount on amount", func(t *testing.T) {
 		inp.SortBy = "AMOUNT"
-		rows := pgxpoolmock.NewRows([]string{"id", "wallet_code", "email", "msisdn", "qty", "transaction",
-			"cashback", "reward"}).
-			AddRow(int64(1), "CODE_A", "someone@email.id", "628118770510", 1, decimal.NewFromInt(25000),
-				decimal.NewFromInt(2500), decimal.NewFromInt(1000)).ToPgxRows()
-		pool.EXPECT().Query(ctx, cmd+"c.amount  DESC  limit $3 offset $4", inp.SessionRequest.Id, "1", inp.Limit, inp.Start).Return(rows, nil)
+		inp.Sort = "DESC"
+		inp.WalletCode = ""
+		inp.Cursor = ""
+		pool.EXPECT().Query(ctx, gomock.Any(), inp.SessionRequest.Id, inp.Start, inp.Limit).DoAndReturn(
+			func(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+				assert.True(t, strings.HasSuffix(sql, " order by t.amount desc, t.id desc limit $3 offset $2"))
+				return result(), nil
+			})
 		v, ex := persister.SearchByPartner(inp)
 		assert.Nil(t, ex)
-		assert.NotNil(t, v)
+		assert.Len(t, v, 1)
 	})
 
-	t.Run("should success without text search and sorted by amount", func(t *testing.T) {
-		inp.SortBy = "REWARD"
-		rows := pgxpoolmock.NewRows([]string{"id", "wallet_code", "email", "msisdn", "qty", "transaction",
-			"cashback", "reward"}).
-			AddRow(int64(1), "CODE_A", "someone@email.id", "628118770510", 1, decimal.NewFromInt(25000),
-				decimal.NewFromInt(2500), decimal.NewFromInt(1000)).ToPgxRows()
-		pool.EXPECT().Query(ctx, cmd+"c.reward  DESC  limit $3 offset $4", inp.SessionRequest.Id, "1", inp.Limit, inp.Start).Return(rows, nil)
+	t.Run("should sort by id on unknown sort field", func(t *testing.T) {
+		inp.SortBy = "t.id; drop table transactions"
+		inp.Sort = "DESC"
+		inp.WalletCode = ""
+		inp.Cursor = ""
+		pool.EXPECT().Query(ctx, gomock.Any(), inp.SessionRequest.Id, inp.Start, inp.Limit).DoAndReturn(
+			func(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+				assert.NotContains(t, sql, "drop table")
+				assert.True(t, strings.HasSuffix(sql, " order by t.id desc, t.id desc limit $3 offset $2"))
+				return result(), nil
+			})
 		v, ex := persister.SearchByPartner(inp)
 		assert.Nil(t, ex)
-		assert.NotNil(t, v)
+		assert.Len(t, v, 1)
 	})
 
-	t.Run("should success with text search", func(t *testing.T) {
-		inp.SortBy = ""
-		inp.TextSearch = "someone"
-		rows := pgxpoolmock.NewRows([]string{"id", "wallet_code", "email", "msisdn", "qty", "transaction",
-			"cashback", "reward"}).
-			AddRow(int64(1), "CODE_A", "someone@email.id", "628118770510", 1, decimal.NewFromInt(25000),
-				decimal.NewFromInt(2500), decimal.NewFromInt(1000)).ToPgxRows()
-		pool.EXPECT().Query(ctx, `select t.id, t.wallet_code, t.email, t.msisdn, 
-			t.qty, t.amount as transaction, c.amount as cashback, c.reward 
-			from transactions t inner join cashbacks c 
-			on t.kezbek_ref_code = c.kezbek_ref_code
-			where t.partner_id = $1  AND (UPPER(t.msisdn) like UPPER($2) OR UPPER(t.email) like UPPER($2) OR UPPER(t.kezbek_ref_code) like UPPER($2) ) `+`
-			order by `+" t.id   DESC  limit $3 offset $4", inp.SessionRequest.Id, "%"+inp.TextSearch+"%", inp.Limit, inp.Start).Return(rows, nil)
+	t.Run("should return exception on invalid cursor", func(t *testing.T) {
+		inp.Cursor = "not a cursor"
 		v, ex := persister.SearchByPartner(inp)
-		assert.Nil(t, ex)
-		assert.NotNil(t, v)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
 	})
 
 	t.Run("should return exception on failed to query", func(t *testing.T) {
-		inp.SortBy = ""
-		inp.TextSearch = "someone"
-		pool.EXPECT().Query(ctx, `select t.id, t.wallet_code, t.email, t.msisdn, 
-			t.qty, t.amount as transaction, c.amount as cashback, c.reward 
-			from transactions t inner join cashbacks c 
-			on t.kezbek_ref_code = c.kezbek_ref_code
-			where t.partner_id = $1  AND (UPPER(t.msisdn) like UPPER($2) OR UPPER(t.email) like UPPER($2) OR UPPER(t.kezbek_ref_code) like UPPER($2) ) `+`
-			order by `+" t.id   DESC  limit $3 offset $4", inp.SessionRequest.Id, "%"+inp.TextSearch+"%", inp.Limit, inp.Start).Return(nil, fmt.Errorf("something went wrong"))
+		inp.Cursor = ""
+		pool.EXPECT().Query(ctx, gomock.Any(), inp.SessionRequest.Id, inp.Start, inp.Limit).
+			Return(nil, fmt.Errorf("something went wrong"))
 		v, ex := persister.SearchByPartner(inp)
 		assert.NotNil(t, ex)
 		assert.Nil(t, v)
 	})
 }
 
+func TestTransaction_StreamByPartner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	pool := pgxpoolmock.NewMockPgxIface(ctrl)
+	ctx := context.Background()
+	persister := NewTransaction(Transaction{
+		Logger: logger,
+		Pool:   pool,
+	})
+	inp := &model.PartnerTransactionSearchRequest{
+		Cursor: model.Cursor{Key: "10", Id: 10}.String(),
+		SearchRequest: model.SearchRequest{
+			SessionRequest: model.SessionRequest{
+				Id: 1,
+			},
+		},
+	}
+	columns := []string{"id", "kezbek_ref_code", "wallet_code", "email", "msisdn", "qty", "transaction",
+		"cashback", "reward", "transaction_date", "h2h_code", "status"}
+	t.Run("should success", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows(columns).
+			AddRow(int64(1), "TRX0012345678", "CODE_A", "someone@email.id", "628118770510", 1,
+				decimal.NewFromInt(25000), decimal.NewFromInt(2500), decimal.NewFromInt(1000), int64(1672531200),
+				"XENIT", apps.TransactionDisbursed).
+			AddRow(int64(2), "TRX0012345679", "CODE_B", "", "628118770511", 2,
+				decimal.NewFromInt(50000), decimal.Zero, decimal.Zero, int64(1672617600),
+				"", apps.TransactionFailed).ToPgxRows()
+		pool.EXPECT().Query(ctx, gomock.Any(), inp.SessionRequest.Id).DoAndReturn(
+			func(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+				assert.NotContains(t, sql, "limit")
+				return rows, nil
+			})
+		var data []model.PartnerTransactionProjection
+		ex := persister.StreamByPartner(inp, func(v model.PartnerTransactionProjection) error {
+			data = append(data, v)
+			return nil
+		})
+		assert.Nil(t, ex)
+		assert.Len(t, data, 2)
+		assert.Equal(t, apps.TransactionFailed, data[1].Status)
+	})
+
+	t.Run("should return exception on failed to handle a row", func(t *testing.T) {
+		rows := pgxpoolmock.NewRows(columns).
+			AddRow(int64(1), "TRX0012345678", "CODE_A", "someone@email.id", "628118770510", 1,
+				decimal.NewFromInt(25000), decimal.NewFromInt(2500), decimal.NewFromInt(1000), int64(1672531200),
+				"XENIT", apps.TransactionDisbursed).ToPgxRows()
+		pool.EXPECT().Query(ctx, gomock.Any(), inp.SessionRequest.Id).Return(rows, nil)
+		ex := persister.StreamByPartner(inp, func(v model.PartnerTransactionProjection) error {
+			return fmt.Errorf("broken pipe")
+		})
+		assert.NotNil(t, ex)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		inp.TextSearch = "someone"
+		pool.EXPECT().Query(ctx, gomock.Any(), inp.SessionRequest.Id, "%someone%").
+			Return(nil, fmt.Errorf("something went wrong"))
+		ex := persister.StreamByPartner(inp, func(v model.PartnerTransactionProjection) error {
+			return nil
+		})
+		assert.NotNil(t, ex)
+	})
+}
+
 func TestTransaction_Add(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
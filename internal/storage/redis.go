@@ -33,6 +33,7 @@ type (
 type Cacher interface {
 	Set(k string, p string, v interface{}, d time.Duration) *model.TechnicalError
 	Hset(k string, p string, v interface{}) *model.TechnicalError
+	Incr(k string, p string, d time.Duration) (int64, *model.TechnicalError)
 	Delete(k string, p string) *model.TechnicalError
 	Get(k string, p string) (v string, e *model.TechnicalError)
 	Hget(k string, p string) (v string, e *model.TechnicalError)
@@ -179,3 +180,33 @@ func (r *singleRedis) Hget(k string, p string) (v string, e *model.TechnicalErro
 	}
 	return v, nil
 }
+
+func (r *clusterRedis) Incr(k string, p string, d time.Duration) (int64, *model.TechnicalError) {
+	var v *redis.IntCmd
+	_, err := r.cache.TxPipelined(func(pipe redis.Pipeliner) error {
+		if d != 0*time.Second {
+			pipe.SetNX(k+":"+p, 0, d)
+		}
+		v = pipe.Incr(k + ":" + p)
+		return nil
+	})
+	if err != nil {
+		return 0, apps.Exception("failed on cluster incr ops", err, zap.String("keypair", k+":"+p), r.logger)
+	}
+	return v.Val(), nil
+}
+
+func (r *singleRedis) Incr(k string, p string, d time.Duration) (int64, *model.TechnicalError) {
+	var v *redis.IntCmd
+	_, err := r.cache.TxPipelined(func(pipe redis.Pipeliner) error {
+		if d != 0*time.Second {
+			pipe.SetNX(k+":"+p, 0, d)
+		}
+		v = pipe.Incr(k + ":" + p)
+		return nil
+	})
+	if err != nil {
+		return 0, apps.Exception("failed on incr ops", err, zap.String("keypair", k+":"+p), r.logger)
+	}
+	return v.Val(), nil
+}
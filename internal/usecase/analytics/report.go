@@ -0,0 +1,316 @@
+package analytics
+
+import (
+	"encoding/json"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/internal/repository"
+	"github.com/adinandradrs/cezbek-engine/internal/storage"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	dateLayout    = "2006-01-02"
+	defaultPeriod = 30
+	maxPeriod     = 366
+	topWallets    = 5
+)
+
+type Report struct {
+	Dao             repository.AnalyticsPersister
+	Cacher          storage.Cacher
+	TTL             time.Duration
+	DefaultProvider string
+	Logger          *zap.Logger
+}
+
+type ReportProvider interface {
+	CashbackVolume(inp *model.AnalyticsRequest) (*model.CashbackVolumeResponse, *model.BusinessError)
+	CustomerSummary(inp *model.AnalyticsRequest) (*model.CustomerSummaryResponse, *model.BusinessError)
+	TierDistribution(inp *model.AnalyticsRequest) (*model.TierDistributionResponse, *model.BusinessError)
+	ProviderSavings(inp *model.AnalyticsRequest) (*model.ProviderSavingsResponse, *model.BusinessError)
+	Dashboard(inp *model.AnalyticsRequest) (*model.DashboardResponse, *model.BusinessError)
+}
+
+func NewReport(r Report) ReportProvider {
+	return &r
+}
+
+func (r *Report) period(inp *model.AnalyticsRequest) *model.BusinessError {
+	end := time.Now()
+	if inp.EndDate != "" {
+		end, _ = time.Parse(dateLayout, inp.EndDate)
+	}
+	start := end.AddDate(0, 0, 1-defaultPeriod)
+	if inp.StartDate != "" {
+		start, _ = time.Parse(dateLayout, inp.StartDate)
+	}
+	if start.After(end) || end.Sub(start) > maxPeriod*24*time.Hour {
+		return &model.BusinessError{
+			ErrorCode:    apps.ErrCodeBussPeriodInvalid,
+			ErrorMessage: apps.ErrMsgBussPeriodInvalid,
+		}
+	}
+	inp.StartDate, inp.EndDate = start.Format(dateLayout), end.Format(dateLayout)
+	return nil
+}
+
+func (r *Report) cached(k string, inp *model.AnalyticsRequest, v interface{}) (string, bool) {
+	p := strconv.FormatInt(inp.PartnerId, 10) + ":" + inp.StartDate + ":" + inp.EndDate
+	s, ex := r.Cacher.Get("ANALYTICS:"+k, p)
+	if ex != nil || s == "" {
+		return p, false
+	}
+	return p, json.Unmarshal([]byte(s), v) == nil
+}
+
+func (r *Report) cache(k string, p string, v interface{}) {
+	b, _ := json.Marshal(v)
+	if ex := r.Cacher.Set("ANALYTICS:"+k, p, string(b), r.TTL); ex != nil {
+		r.Logger.Warn("failed to cache report", zap.String("report", k), zap.String("key", p))
+	}
+}
+
+func (r *Report) CashbackVolume(inp *model.AnalyticsRequest) (*model.CashbackVolumeResponse, *model.BusinessError) {
+	if bx := r.period(inp); bx != nil {
+		return nil, bx
+	}
+	res := model.CashbackVolumeResponse{}
+	p, ok := r.cached("CASHBACK", inp, &res)
+	if ok {
+		return &res, nil
+	}
+	v, ex := r.Dao.CashbackVolume(inp)
+	if ex != nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSomethingWrong,
+			ErrorMessage: apps.ErrMsgSomethingWrong,
+		}
+	}
+	res = model.CashbackVolumeResponse{
+		StartDate: inp.StartDate,
+		EndDate:   inp.EndDate,
+		Volumes:   v,
+	}
+	r.cache("CASHBACK", p, res)
+	return &res, nil
+}
+
+func (r *Report) CustomerSummary(inp *model.AnalyticsRequest) (*model.CustomerSummaryResponse, *model.BusinessError) {
+	if bx := r.period(inp); bx != nil {
+		return nil, bx
+	}
+	res := model.CustomerSummaryResponse{}
+	p, ok := r.cached("CUSTOMER", inp, &res)
+	if ok {
+		return &res, nil
+	}
+	v, ex := r.Dao.CustomerSummary(inp)
+	if ex != nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSomethingWrong,
+			ErrorMessage: apps.ErrMsgSomethingWrong,
+		}
+	}
+	res = model.CustomerSummaryResponse{
+		StartDate:                 inp.StartDate,
+		EndDate:                   inp.EndDate,
+		CustomerSummaryProjection: *v,
+	}
+	r.cache("CUSTOMER", p, res)
+	return &res, nil
+}
+
+func (r *Report) TierDistribution(inp *model.AnalyticsRequest) (*model.TierDistributionResponse, *model.BusinessError) {
+	inp.StartDate, inp.EndDate = "", ""
+	res := model.TierDistributionResponse{}
+	p, ok := r.cached("TIER", inp, &res)
+	if ok {
+		return &res, nil
+	}
+	v, ex := r.Dao.TierDistribution(inp)
+	if ex != nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSomethingWrong,
+			ErrorMessage: apps.ErrMsgSomethingWrong,
+		}
+	}
+	res = model.TierDistributionResponse{Tiers: v}
+	r.cache("TIER", p, res)
+	return &res, nil
+}
+
+func (r *Report) ProviderSavings(inp *model.AnalyticsRequest) (*model.ProviderSavingsResponse, *model.BusinessError) {
+	if bx := r.period(inp); bx != nil {
+		return nil, bx
+	}
+	res := model.ProviderSavingsResponse{}
+	p, ok := r.cached("SAVINGS", inp, &res)
+	if ok {
+		return &res, nil
+	}
+	v, ex := r.Dao.ProviderSavings(inp, r.DefaultProvider)
+	if ex != nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSomethingWrong,
+			ErrorMessage: apps.ErrMsgSomethingWrong,
+		}
+	}
+	res = model.ProviderSavingsResponse{
+		StartDate:       inp.StartDate,
+		EndDate:         inp.EndDate,
+		DefaultProvider: r.DefaultProvider,
+		Wallets:         []model.ProviderSavingsWallet{},
+	}
+	for _, s := range v {
+		s.Savings, s.DefaultSavings = s.HighestFee.Sub(s.FeePaid), s.DefaultFee.Sub(s.FeePaid)
+		n := len(res.Wallets)
+		if n == 0 || res.Wallets[n-1].WalletCode != s.WalletCode {
+			res.Wallets = append(res.Wallets, model.ProviderSavingsWallet{WalletCode: s.WalletCode})
+			n++
+		}
+		w := &res.Wallets[n-1]
+		w.Total += s.Total
+		w.Failovers += s.Failovers
+		w.FeePaid = w.FeePaid.Add(s.FeePaid)
+		w.HighestFee = w.HighestFee.Add(s.HighestFee)
+		w.DefaultFee = w.DefaultFee.Add(s.DefaultFee)
+		w.Savings = w.Savings.Add(s.Savings)
+		w.DefaultSavings = w.DefaultSavings.Add(s.DefaultSavings)
+		s.WalletCode = ""
+		w.Providers = append(w.Providers, s)
+	}
+	r.cache("SAVINGS", p, res)
+	return &res, nil
+}
+
+func kpi(current decimal.Decimal, previous decimal.Decimal) model.KpiMetric {
+	m := model.KpiMetric{Current: current, Previous: previous}
+	if !previous.IsZero() {
+		g := current.Sub(previous).Div(previous).Mul(decimal.NewFromInt(100)).Round(2)
+		m.Growth = &g
+	}
+	return m
+}
+
+func count(v int) decimal.Decimal {
+	return decimal.NewFromInt(int64(v))
+}
+
+func (r *Report) dashboard(inp *model.AnalyticsRequest) ([]model.DashboardSnapshotProjection,
+	*model.DashboardCustomerProjection, *model.BusinessError) {
+	v, ex := r.Dao.DashboardSnapshot(inp)
+	if ex != nil {
+		return nil, nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSomethingWrong,
+			ErrorMessage: apps.ErrMsgSomethingWrong,
+		}
+	}
+	c, ex := r.Dao.DashboardCustomer(inp)
+	if ex != nil {
+		return nil, nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSomethingWrong,
+			ErrorMessage: apps.ErrMsgSomethingWrong,
+		}
+	}
+	return v, c, nil
+}
+
+func (r *Report) Dashboard(inp *model.AnalyticsRequest) (*model.DashboardResponse, *model.BusinessError) {
+	closed := time.Now().AddDate(0, 0, -1).Format(dateLayout)
+	if inp.EndDate == "" || inp.EndDate > closed {
+		inp.EndDate = closed
+	}
+	if bx := r.period(inp); bx != nil {
+		return nil, bx
+	}
+	res := model.DashboardResponse{}
+	p, ok := r.cached("DASHBOARD", inp, &res)
+	if ok {
+		return &res, nil
+	}
+	start, _ := time.Parse(dateLayout, inp.StartDate)
+	end, _ := time.Parse(dateLayout, inp.EndDate)
+	days := int(end.Sub(start).Hours()/24) + 1
+	prev := model.AnalyticsRequest{
+		StartDate: start.AddDate(0, 0, -days).Format(dateLayout),
+		EndDate:   start.AddDate(0, 0, -1).Format(dateLayout),
+		PartnerId: inp.PartnerId,
+	}
+	cv, cc, bx := r.dashboard(inp)
+	if bx != nil {
+		return nil, bx
+	}
+	pv, pc, bx := r.dashboard(&prev)
+	if bx != nil {
+		return nil, bx
+	}
+
+	res = model.DashboardResponse{
+		StartDate:          inp.StartDate,
+		EndDate:            inp.EndDate,
+		PrevStartDate:      prev.StartDate,
+		PrevEndDate:        prev.EndDate,
+		UniqueCustomers:    kpi(count(cc.UniqueCustomers), count(pc.UniqueCustomers)),
+		ReturningCustomers: kpi(count(cc.ReturningCustomers), count(pc.ReturningCustomers)),
+		Tiers:              []model.DashboardTier{},
+		TopWallets:         []model.DashboardWallet{},
+	}
+	add := func(m *model.KpiMetric, current bool, v decimal.Decimal) {
+		if current {
+			m.Current = m.Current.Add(v)
+		} else {
+			m.Previous = m.Previous.Add(v)
+		}
+	}
+	tiers, wallets := map[string]*model.DashboardTier{}, map[string]*model.DashboardWallet{}
+	sum := func(v []model.DashboardSnapshotProjection, current bool) {
+		for _, s := range v {
+			if tiers[s.Tier] == nil {
+				tiers[s.Tier] = &model.DashboardTier{Tier: s.Tier}
+			}
+			if wallets[s.WalletCode] == nil {
+				wallets[s.WalletCode] = &model.DashboardWallet{WalletCode: s.WalletCode}
+			}
+			add(&res.Transactions, current, count(s.Transactions))
+			add(&res.Spend, current, s.Spend)
+			add(&res.Cashback, current, s.Cashback)
+			add(&res.Reward, current, s.Reward)
+			add(&tiers[s.Tier].Transactions, current, count(s.Transactions))
+			add(&wallets[s.WalletCode].Transactions, current, count(s.Transactions))
+			add(&wallets[s.WalletCode].Spend, current, s.Spend)
+		}
+	}
+	sum(cv, true)
+	sum(pv, false)
+	res.Transactions = kpi(res.Transactions.Current, res.Transactions.Previous)
+	res.Spend = kpi(res.Spend.Current, res.Spend.Previous)
+	res.Cashback = kpi(res.Cashback.Current, res.Cashback.Previous)
+	res.Reward = kpi(res.Reward.Current, res.Reward.Previous)
+	for _, t := range tiers {
+		t.Transactions = kpi(t.Transactions.Current, t.Transactions.Previous)
+		res.Tiers = append(res.Tiers, *t)
+	}
+	sort.Slice(res.Tiers, func(i, j int) bool {
+		a, b := res.Tiers[i].Transactions.Current, res.Tiers[j].Transactions.Current
+		return a.GreaterThan(b) || (a.Equal(b) && res.Tiers[i].Tier < res.Tiers[j].Tier)
+	})
+	for _, w := range wallets {
+		w.Transactions, w.Spend = kpi(w.Transactions.Current, w.Transactions.Previous),
+			kpi(w.Spend.Current, w.Spend.Previous)
+		res.TopWallets = append(res.TopWallets, *w)
+	}
+	sort.Slice(res.TopWallets, func(i, j int) bool {
+		a, b := res.TopWallets[i].Spend.Current, res.TopWallets[j].Spend.Current
+		return a.GreaterThan(b) || (a.Equal(b) && res.TopWallets[i].WalletCode < res.TopWallets[j].WalletCode)
+	})
+	if len(res.TopWallets) > topWallets {
+		res.TopWallets = res.TopWallets[:topWallets]
+	}
+	r.cache("DASHBOARD", p, res)
+	return &res, nil
+}
@@ -0,0 +1,351 @@
+package analytics
+
+import (
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/mock/repository"
+	"github.com/adinandradrs/cezbek-engine/mock/storage"
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestReport_CashbackVolume(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	dao, cacher := repository.NewMockAnalyticsPersister(ctrl), storage.NewMockCacher(ctrl)
+	svc := NewReport(Report{
+		Dao:    dao,
+		Cacher: cacher,
+		TTL:    time.Minute,
+		Logger: logger,
+	})
+
+	t.Run("should success from database", func(t *testing.T) {
+		inp := &model.AnalyticsRequest{StartDate: "2023-01-01", EndDate: "2023-01-31", PartnerId: 1}
+		cacher.EXPECT().Get("ANALYTICS:CASHBACK", "1:2023-01-01:2023-01-31").Return("", &model.TechnicalError{
+			Exception: "redis: nil",
+		})
+		dao.EXPECT().CashbackVolume(inp).Return([]model.CashbackVolumeProjection{
+			{
+				Date:        "2023-01-01",
+				WalletCode:  "LSAJA",
+				Provider:    "XENIT",
+				Total:       2,
+				Transaction: decimal.NewFromInt(500000),
+				Cashback:    decimal.NewFromInt(5000),
+			},
+		}, nil)
+		cacher.EXPECT().Set("ANALYTICS:CASHBACK", "1:2023-01-01:2023-01-31", gomock.Any(), time.Minute).Return(nil)
+		v, ex := svc.CashbackVolume(inp)
+		assert.Nil(t, ex)
+		assert.Len(t, v.Volumes, 1)
+	})
+
+	t.Run("should success from cache", func(t *testing.T) {
+		inp := &model.AnalyticsRequest{StartDate: "2023-01-01", EndDate: "2023-01-31"}
+		cacher.EXPECT().Get("ANALYTICS:CASHBACK", "0:2023-01-01:2023-01-31").Return(
+			`{"start_date":"2023-01-01","end_date":"2023-01-31","volumes":[{"wallet_code":"LSAJA","total":2}]}`, nil)
+		v, ex := svc.CashbackVolume(inp)
+		assert.Nil(t, ex)
+		assert.Equal(t, "LSAJA", v.Volumes[0].WalletCode)
+	})
+
+	t.Run("should success with default period", func(t *testing.T) {
+		inp := &model.AnalyticsRequest{}
+		end := time.Now().Format("2006-01-02")
+		start := time.Now().AddDate(0, 0, -29).Format("2006-01-02")
+		cacher.EXPECT().Get("ANALYTICS:CASHBACK", "0:"+start+":"+end).Return("", nil)
+		dao.EXPECT().CashbackVolume(inp).Return(nil, nil)
+		cacher.EXPECT().Set("ANALYTICS:CASHBACK", "0:"+start+":"+end, gomock.Any(), time.Minute).Return(nil)
+		v, ex := svc.CashbackVolume(inp)
+		assert.Nil(t, ex)
+		assert.Equal(t, start, v.StartDate)
+	})
+
+	t.Run("should return exception on invalid period", func(t *testing.T) {
+		v, ex := svc.CashbackVolume(&model.AnalyticsRequest{StartDate: "2023-02-01", EndDate: "2023-01-31"})
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeBussPeriodInvalid, ex.ErrorCode)
+		v, ex = svc.CashbackVolume(&model.AnalyticsRequest{StartDate: "2021-01-01", EndDate: "2023-01-31"})
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeBussPeriodInvalid, ex.ErrorCode)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		inp := &model.AnalyticsRequest{StartDate: "2023-01-01", EndDate: "2023-01-31"}
+		cacher.EXPECT().Get("ANALYTICS:CASHBACK", gomock.Any()).Return("", nil)
+		dao.EXPECT().CashbackVolume(inp).Return(nil, &model.TechnicalError{
+			Exception: "something went wrong",
+		})
+		v, ex := svc.CashbackVolume(inp)
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeSomethingWrong, ex.ErrorCode)
+	})
+}
+
+func TestReport_CustomerSummary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	dao, cacher := repository.NewMockAnalyticsPersister(ctrl), storage.NewMockCacher(ctrl)
+	svc := NewReport(Report{
+		Dao:    dao,
+		Cacher: cacher,
+		TTL:    time.Minute,
+		Logger: logger,
+	})
+	inp := &model.AnalyticsRequest{StartDate: "2023-01-01", EndDate: "2023-01-31", PartnerId: 1}
+
+	t.Run("should success", func(t *testing.T) {
+		cacher.EXPECT().Get("ANALYTICS:CUSTOMER", "1:2023-01-01:2023-01-31").Return("", nil)
+		dao.EXPECT().CustomerSummary(inp).Return(&model.CustomerSummaryProjection{
+			Transactions:    4,
+			UniqueCustomers: 3,
+			AverageBasket:   decimal.NewFromInt(250000),
+		}, nil)
+		cacher.EXPECT().Set("ANALYTICS:CUSTOMER", "1:2023-01-01:2023-01-31", gomock.Any(), time.Minute).Return(
+			&model.TechnicalError{Exception: "something went wrong"})
+		v, ex := svc.CustomerSummary(inp)
+		assert.Nil(t, ex)
+		assert.Equal(t, 3, v.UniqueCustomers)
+		assert.Equal(t, "2023-01-01", v.StartDate)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		cacher.EXPECT().Get("ANALYTICS:CUSTOMER", "1:2023-01-01:2023-01-31").Return("", nil)
+		dao.EXPECT().CustomerSummary(inp).Return(nil, &model.TechnicalError{
+			Exception: "something went wrong",
+		})
+		v, ex := svc.CustomerSummary(inp)
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeSomethingWrong, ex.ErrorCode)
+	})
+}
+
+func TestReport_TierDistribution(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	dao, cacher := repository.NewMockAnalyticsPersister(ctrl), storage.NewMockCacher(ctrl)
+	svc := NewReport(Report{
+		Dao:    dao,
+		Cacher: cacher,
+		TTL:    time.Minute,
+		Logger: logger,
+	})
+
+	t.Run("should success", func(t *testing.T) {
+		inp := &model.AnalyticsRequest{StartDate: "2023-01-01", PartnerId: 1}
+		cacher.EXPECT().Get("ANALYTICS:TIER", "1::").Return("", nil)
+		dao.EXPECT().TierDistribution(inp).Return([]model.TierDistributionProjection{
+			{Tier: "BRONZE", Customers: 10},
+			{Tier: "GOLD", Customers: 2},
+		}, nil)
+		cacher.EXPECT().Set("ANALYTICS:TIER", "1::", gomock.Any(), time.Minute).Return(nil)
+		v, ex := svc.TierDistribution(inp)
+		assert.Nil(t, ex)
+		assert.Len(t, v.Tiers, 2)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		inp := &model.AnalyticsRequest{}
+		cacher.EXPECT().Get("ANALYTICS:TIER", "0::").Return("", nil)
+		dao.EXPECT().TierDistribution(inp).Return(nil, &model.TechnicalError{
+			Exception: "something went wrong",
+		})
+		v, ex := svc.TierDistribution(inp)
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeSomethingWrong, ex.ErrorCode)
+	})
+}
+
+func TestReport_ProviderSavings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	dao, cacher := repository.NewMockAnalyticsPersister(ctrl), storage.NewMockCacher(ctrl)
+	svc := NewReport(Report{
+		Dao:             dao,
+		Cacher:          cacher,
+		TTL:             time.Minute,
+		DefaultProvider: "XENIT",
+		Logger:          logger,
+	})
+
+	t.Run("should success", func(t *testing.T) {
+		inp := &model.AnalyticsRequest{StartDate: "2023-01-01", EndDate: "2023-01-31"}
+		cacher.EXPECT().Get("ANALYTICS:SAVINGS", "0:2023-01-01:2023-01-31").Return("", nil)
+		dao.EXPECT().ProviderSavings(inp, "XENIT").Return([]model.ProviderSavingsProjection{
+			{
+				WalletCode: "GOPAID",
+				Provider:   "GOPAIDH2H",
+				Total:      2,
+				FeePaid:    decimal.NewFromInt(1000),
+				HighestFee: decimal.NewFromInt(1800),
+				DefaultFee: decimal.NewFromInt(1500),
+			},
+			{
+				WalletCode: "GOPAID",
+				Provider:   "XENIT",
+				Total:      1,
+				Failovers:  1,
+				FeePaid:    decimal.NewFromInt(750),
+				HighestFee: decimal.NewFromInt(900),
+				DefaultFee: decimal.NewFromInt(750),
+			},
+			{
+				WalletCode: "LSAJA",
+				Provider:   "LSAJAH2H",
+				Total:      1,
+				FeePaid:    decimal.NewFromInt(500),
+				HighestFee: decimal.NewFromInt(500),
+				DefaultFee: decimal.NewFromInt(500),
+			},
+		}, nil)
+		cacher.EXPECT().Set("ANALYTICS:SAVINGS", "0:2023-01-01:2023-01-31", gomock.Any(), time.Minute).Return(nil)
+		v, ex := svc.ProviderSavings(inp)
+		assert.Nil(t, ex)
+		assert.Equal(t, "XENIT", v.DefaultProvider)
+		assert.Len(t, v.Wallets, 2)
+		assert.Equal(t, 3, v.Wallets[0].Total)
+		assert.Equal(t, 1, v.Wallets[0].Failovers)
+		assert.True(t, decimal.NewFromInt(950).Equal(v.Wallets[0].Savings))
+		assert.True(t, decimal.NewFromInt(500).Equal(v.Wallets[0].DefaultSavings))
+		assert.Len(t, v.Wallets[0].Providers, 2)
+		assert.True(t, decimal.NewFromInt(800).Equal(v.Wallets[0].Providers[0].Savings))
+		assert.True(t, decimal.Zero.Equal(v.Wallets[1].Savings))
+	})
+
+	t.Run("should success from cache", func(t *testing.T) {
+		inp := &model.AnalyticsRequest{StartDate: "2023-01-01", EndDate: "2023-01-31"}
+		cacher.EXPECT().Get("ANALYTICS:SAVINGS", "0:2023-01-01:2023-01-31").Return(
+			`{"default_provider":"XENIT","wallets":[{"wallet_code":"LSAJA","total":1}]}`, nil)
+		v, ex := svc.ProviderSavings(inp)
+		assert.Nil(t, ex)
+		assert.Equal(t, "LSAJA", v.Wallets[0].WalletCode)
+	})
+
+	t.Run("should return exception on invalid period", func(t *testing.T) {
+		inp := &model.AnalyticsRequest{StartDate: "2023-02-01", EndDate: "2023-01-31"}
+		v, ex := svc.ProviderSavings(inp)
+		assert.Equal(t, apps.ErrCodeBussPeriodInvalid, ex.ErrorCode)
+		assert.Nil(t, v)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		inp := &model.AnalyticsRequest{StartDate: "2023-01-01", EndDate: "2023-01-31"}
+		cacher.EXPECT().Get("ANALYTICS:SAVINGS", "0:2023-01-01:2023-01-31").Return("", nil)
+		dao.EXPECT().ProviderSavings(inp, "XENIT").Return(nil, &model.TechnicalError{
+			Exception: "something went wrong",
+		})
+		v, ex := svc.ProviderSavings(inp)
+		assert.Equal(t, apps.ErrCodeSomethingWrong, ex.ErrorCode)
+		assert.Nil(t, v)
+	})
+}
+
+func TestReport_Dashboard(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	dao, cacher := repository.NewMockAnalyticsPersister(ctrl), storage.NewMockCacher(ctrl)
+	svc := NewReport(Report{
+		Dao:    dao,
+		Cacher: cacher,
+		TTL:    time.Minute,
+		Logger: logger,
+	})
+	prev := &model.AnalyticsRequest{StartDate: "2022-12-22", EndDate: "2022-12-31", PartnerId: 1}
+
+	t.Run("should success to compare with the previous period", func(t *testing.T) {
+		inp := &model.AnalyticsRequest{StartDate: "2023-01-01", EndDate: "2023-01-10", PartnerId: 1}
+		cacher.EXPECT().Get("ANALYTICS:DASHBOARD", "1:2023-01-01:2023-01-10").Return("", nil)
+		dao.EXPECT().DashboardSnapshot(inp).Return([]model.DashboardSnapshotProjection{
+			{WalletCode: "GOPAID", Tier: "GOLD", Transactions: 2, Spend: decimal.NewFromInt(400000),
+				Cashback: decimal.NewFromInt(4000), Reward: decimal.NewFromInt(13000)},
+			{WalletCode: "LSAJA", Tier: "BRONZE", Transactions: 4, Spend: decimal.NewFromInt(200000),
+				Cashback: decimal.NewFromInt(2000)},
+		}, nil)
+		dao.EXPECT().DashboardCustomer(inp).Return(&model.DashboardCustomerProjection{
+			UniqueCustomers: 4, ReturningCustomers: 1}, nil)
+		dao.EXPECT().DashboardSnapshot(prev).Return([]model.DashboardSnapshotProjection{
+			{WalletCode: "LSAJA", Tier: "BRONZE", Transactions: 5, Spend: decimal.NewFromInt(250000),
+				Cashback: decimal.NewFromInt(2500)},
+		}, nil)
+		dao.EXPECT().DashboardCustomer(prev).Return(&model.DashboardCustomerProjection{UniqueCustomers: 2}, nil)
+		cacher.EXPECT().Set("ANALYTICS:DASHBOARD", "1:2023-01-01:2023-01-10", gomock.Any(), time.Minute).Return(nil)
+		v, ex := svc.Dashboard(inp)
+		assert.Nil(t, ex)
+		assert.Equal(t, "2022-12-22", v.PrevStartDate)
+		assert.True(t, decimal.NewFromInt(6).Equal(v.Transactions.Current))
+		assert.True(t, decimal.NewFromInt(20).Equal(*v.Transactions.Growth))
+		assert.True(t, decimal.NewFromInt(140).Equal(*v.Spend.Growth))
+		assert.Nil(t, v.Reward.Growth)
+		assert.True(t, decimal.NewFromInt(100).Equal(*v.UniqueCustomers.Growth))
+		assert.Equal(t, "BRONZE", v.Tiers[0].Tier)
+		assert.True(t, decimal.NewFromInt(-20).Equal(*v.Tiers[0].Transactions.Growth))
+		assert.Equal(t, "GOPAID", v.TopWallets[0].WalletCode)
+		assert.Nil(t, v.TopWallets[0].Spend.Growth)
+		assert.True(t, decimal.NewFromInt(250000).Equal(v.TopWallets[1].Spend.Previous))
+	})
+
+	t.Run("should success from cache", func(t *testing.T) {
+		inp := &model.AnalyticsRequest{StartDate: "2023-01-01", EndDate: "2023-01-10", PartnerId: 1}
+		cacher.EXPECT().Get("ANALYTICS:DASHBOARD", "1:2023-01-01:2023-01-10").Return(
+			`{"start_date":"2023-01-01","end_date":"2023-01-10","transactions":{"current":6,"previous":5}}`, nil)
+		v, ex := svc.Dashboard(inp)
+		assert.Nil(t, ex)
+		assert.True(t, decimal.NewFromInt(6).Equal(v.Transactions.Current))
+	})
+
+	t.Run("should end yesterday by default", func(t *testing.T) {
+		inp := &model.AnalyticsRequest{PartnerId: 1}
+		end := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+		cacher.EXPECT().Get("ANALYTICS:DASHBOARD", gomock.Any()).Return("", nil)
+		dao.EXPECT().DashboardSnapshot(gomock.Any()).Return(nil, nil).Times(2)
+		dao.EXPECT().DashboardCustomer(gomock.Any()).Return(&model.DashboardCustomerProjection{}, nil).Times(2)
+		cacher.EXPECT().Set("ANALYTICS:DASHBOARD", gomock.Any(), gomock.Any(), time.Minute).Return(nil)
+		v, ex := svc.Dashboard(inp)
+		assert.Nil(t, ex)
+		assert.Equal(t, end, v.EndDate)
+		assert.Empty(t, v.TopWallets)
+	})
+
+	t.Run("should end yesterday when the period ends today", func(t *testing.T) {
+		start := time.Now().AddDate(0, 0, -5).Format("2006-01-02")
+		end := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+		inp := &model.AnalyticsRequest{StartDate: start, EndDate: time.Now().Format("2006-01-02"), PartnerId: 1}
+		cacher.EXPECT().Get("ANALYTICS:DASHBOARD", "1:"+start+":"+end).Return("", nil)
+		dao.EXPECT().DashboardSnapshot(gomock.Any()).DoAndReturn(
+			func(inp *model.AnalyticsRequest) ([]model.DashboardSnapshotProjection, *model.TechnicalError) {
+				assert.Equal(t, end, inp.EndDate)
+				return nil, nil
+			})
+		dao.EXPECT().DashboardCustomer(gomock.Any()).DoAndReturn(
+			func(inp *model.AnalyticsRequest) (*model.DashboardCustomerProjection, *model.TechnicalError) {
+				assert.Equal(t, end, inp.EndDate)
+				return &model.DashboardCustomerProjection{}, nil
+			})
+		dao.EXPECT().DashboardSnapshot(gomock.Any()).Return(nil, nil)
+		dao.EXPECT().DashboardCustomer(gomock.Any()).Return(&model.DashboardCustomerProjection{}, nil)
+		cacher.EXPECT().Set("ANALYTICS:DASHBOARD", "1:"+start+":"+end, gomock.Any(), time.Minute).Return(nil)
+		v, ex := svc.Dashboard(inp)
+		assert.Nil(t, ex)
+		assert.Equal(t, end, v.EndDate)
+		assert.Equal(t, time.Now().AddDate(0, 0, -10).Format("2006-01-02"), v.PrevStartDate)
+	})
+
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		inp := &model.AnalyticsRequest{StartDate: "2023-01-01", EndDate: "2023-01-10", PartnerId: 1}
+		cacher.EXPECT().Get("ANALYTICS:DASHBOARD", "1:2023-01-01:2023-01-10").Return("", nil)
+		dao.EXPECT().DashboardSnapshot(inp).Return(nil, nil)
+		dao.EXPECT().DashboardCustomer(inp).Return(nil, &model.TechnicalError{Exception: "something went wrong"})
+		v, ex := svc.Dashboard(inp)
+		assert.Equal(t, apps.ErrCodeSomethingWrong, ex.ErrorCode)
+		assert.Nil(t, v)
+	})
+}
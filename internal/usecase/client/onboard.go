@@ -8,6 +8,7 @@ import (
 	"github.com/adinandradrs/cezbek-engine/internal/storage"
 	"github.com/goccy/go-json"
 	"go.uber.org/zap"
+	"strings"
 	"time"
 )
 
@@ -21,6 +22,8 @@ type Onboard struct {
 
 type OnboardProvider interface {
 	Authenticate(inp *model.ClientAuthenticationRequest) (*model.ClientAuthenticationResponse, *model.BusinessError)
+	Refresh(inp *model.SessionRefreshRequest) (*model.SessionResponse, *model.BusinessError)
+	Logout(inp *model.SessionRequest) *model.BusinessError
 }
 
 func NewOnboard(o Onboard) OnboardProvider {
@@ -59,6 +62,7 @@ func (o *Onboard) Authenticate(inp *model.ClientAuthenticationRequest) (*model.C
 		Id:      &p.Id,
 		Code:    p.Code.String,
 		Company: p.Partner.String,
+		Locale:  p.Locale.String,
 		SessionResponse: model.SessionResponse{
 			RefreshToken: auth.RefreshToken,
 			Token:        auth.Token,
@@ -78,3 +82,76 @@ func (o *Onboard) Authenticate(inp *model.ClientAuthenticationRequest) (*model.C
 	resp.AccessToken = ""
 	return &resp, nil
 }
+
+func (o *Onboard) Refresh(inp *model.SessionRefreshRequest) (*model.SessionResponse, *model.BusinessError) {
+	v, ex := o.Cacher.Get("CLIENTSESSION", strings.ToUpper(inp.Username))
+	if ex != nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeUnauthorized,
+			ErrorMessage: apps.ErrMsgUnauthorized,
+		}
+	}
+	var sess model.ClientAuthenticationResponse
+	err := json.Unmarshal([]byte(v), &sess)
+	if err != nil || sess.RefreshToken != inp.RefreshToken {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeUnauthorized,
+			ErrorMessage: apps.ErrMsgUnauthorized,
+		}
+	}
+
+	auth, ex := o.CiamWatcher.Refresh(model.CiamRefreshRequest{
+		Username:     sess.Code,
+		RefreshToken: inp.RefreshToken,
+	})
+	if ex != nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeUnauthorized,
+			ErrorMessage: apps.ErrMsgUnauthorized,
+		}
+	}
+	sess.SessionResponse = model.SessionResponse{
+		RefreshToken: auth.RefreshToken,
+		Token:        auth.Token,
+		AccessToken:  auth.AccessToken,
+		Expired:      &auth.ExpiresIn,
+	}
+	cache, err := json.Marshal(sess)
+	if err != nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSomethingWrong,
+			ErrorMessage: apps.ErrMsgSomethingWrong,
+		}
+	}
+	o.Cacher.Set("CLIENTSESSION", sess.Code, cache, o.AuthTTL)
+	return &model.SessionResponse{
+		RefreshToken: auth.RefreshToken,
+		Token:        auth.Token,
+		Expired:      &auth.ExpiresIn,
+	}, nil
+}
+
+func (o *Onboard) Logout(inp *model.SessionRequest) *model.BusinessError {
+	v, ex := o.Cacher.Get("CLIENTSESSION", inp.Username)
+	if ex != nil {
+		return &model.BusinessError{
+			ErrorCode:    apps.ErrCodeUnauthorized,
+			ErrorMessage: apps.ErrMsgUnauthorized,
+		}
+	}
+	var sess model.ClientAuthenticationResponse
+	_ = json.Unmarshal([]byte(v), &sess)
+	if ex = o.CiamWatcher.SignOut(sess.AccessToken); ex != nil {
+		o.Logger.Error("failed to sign out client from CIAM", zap.String("code", inp.Username))
+	}
+	if inp.TokenId != "" {
+		o.Cacher.Set("JWTDENY", inp.TokenId, inp.Username, apps.TokenTTL(inp.TokenExpiry, o.AuthTTL))
+	}
+	if ex = o.Cacher.Delete("CLIENTSESSION", inp.Username); ex != nil {
+		return &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSomethingWrong,
+			ErrorMessage: apps.ErrMsgSomethingWrong,
+		}
+	}
+	return nil
+}
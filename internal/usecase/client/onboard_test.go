@@ -2,6 +2,7 @@ package client
 
 import (
 	"database/sql"
+	"encoding/json"
 	"github.com/adinandradrs/cezbek-engine/internal/apps"
 	"github.com/adinandradrs/cezbek-engine/internal/model"
 	"github.com/adinandradrs/cezbek-engine/mock/adaptor"
@@ -95,3 +96,145 @@ func TestOnboard_AuthenticateClient(t *testing.T) {
 		assert.Nil(t, v)
 	})
 }
+
+func TestOnboard_Refresh(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+
+	authTTL, _ := time.ParseDuration("1s")
+	dao, ciamWatcher, cacher := repository.NewMockPartnerPersister(ctrl),
+		adaptor.NewMockCiamWatcher(ctrl), storage.NewMockCacher(ctrl)
+	svc := NewOnboard(Onboard{
+		Logger:      logger,
+		Cacher:      cacher,
+		Dao:         dao,
+		CiamWatcher: ciamWatcher,
+		AuthTTL:     authTTL,
+	})
+	inp := &model.SessionRefreshRequest{
+		Username:     "dummy-code",
+		RefreshToken: "ref-token-abc",
+	}
+	id := int64(1)
+	sess, _ := json.Marshal(model.ClientAuthenticationResponse{
+		Id:      &id,
+		Code:    "DUMMY-CODE",
+		Company: "PT. Partner A",
+		SessionResponse: model.SessionResponse{
+			Token:        "token-abc",
+			RefreshToken: "ref-token-abc",
+		},
+	})
+	t.Run("should success", func(t *testing.T) {
+		cacher.EXPECT().Get("CLIENTSESSION", "DUMMY-CODE").Return(string(sess), nil)
+		ciamWatcher.EXPECT().Refresh(model.CiamRefreshRequest{
+			Username:     "DUMMY-CODE",
+			RefreshToken: inp.RefreshToken,
+		}).Return(&model.CiamAuthenticationResponse{
+			Token:        "token-def",
+			ExpiresIn:    int64(1),
+			AccessToken:  "access-token-def",
+			RefreshToken: "ref-token-abc",
+		}, nil)
+		cacher.EXPECT().Set("CLIENTSESSION", "DUMMY-CODE", gomock.Any(), authTTL)
+		v, ex := svc.Refresh(inp)
+		assert.Nil(t, ex)
+		assert.Equal(t, "token-def", v.Token)
+		assert.Empty(t, v.AccessToken)
+	})
+	t.Run("should return exception on no active session", func(t *testing.T) {
+		cacher.EXPECT().Get("CLIENTSESSION", "DUMMY-CODE").Return("", &model.TechnicalError{
+			Exception: "redis: nil",
+			Occurred:  time.Now().Unix(),
+			Ticket:    "ERR-001",
+		})
+		v, ex := svc.Refresh(inp)
+		assert.Equal(t, apps.ErrCodeUnauthorized, ex.ErrorCode)
+		assert.Nil(t, v)
+	})
+	t.Run("should return exception on mismatch refresh token", func(t *testing.T) {
+		cacher.EXPECT().Get("CLIENTSESSION", "DUMMY-CODE").Return(string(sess), nil)
+		v, ex := svc.Refresh(&model.SessionRefreshRequest{
+			Username:     "DUMMY-CODE",
+			RefreshToken: "ref-token-stolen",
+		})
+		assert.Equal(t, apps.ErrCodeUnauthorized, ex.ErrorCode)
+		assert.Nil(t, v)
+	})
+	t.Run("should return exception on ciam to refresh", func(t *testing.T) {
+		cacher.EXPECT().Get("CLIENTSESSION", "DUMMY-CODE").Return(string(sess), nil)
+		ciamWatcher.EXPECT().Refresh(gomock.Any()).Return(nil, &model.TechnicalError{
+			Exception: "something went wrong",
+			Occurred:  time.Now().Unix(),
+			Ticket:    "ERR-001",
+		})
+		v, ex := svc.Refresh(inp)
+		assert.Equal(t, apps.ErrCodeUnauthorized, ex.ErrorCode)
+		assert.Nil(t, v)
+	})
+}
+
+func TestOnboard_Logout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+
+	authTTL, _ := time.ParseDuration("1s")
+	ciamWatcher, cacher := adaptor.NewMockCiamWatcher(ctrl), storage.NewMockCacher(ctrl)
+	svc := NewOnboard(Onboard{
+		Logger:      logger,
+		Cacher:      cacher,
+		CiamWatcher: ciamWatcher,
+		AuthTTL:     authTTL,
+	})
+	inp := &model.SessionRequest{
+		Username: "DUMMY-CODE",
+		TokenId:  "jti-abc-123",
+	}
+	sess, _ := json.Marshal(model.SessionResponse{
+		Token:       "token-abc",
+		AccessToken: "access-token-abc",
+	})
+	t.Run("should success", func(t *testing.T) {
+		cacher.EXPECT().Get("CLIENTSESSION", "DUMMY-CODE").Return(string(sess), nil)
+		ciamWatcher.EXPECT().SignOut("access-token-abc").Return(nil)
+		cacher.EXPECT().Set("JWTDENY", "jti-abc-123", "DUMMY-CODE", authTTL)
+		cacher.EXPECT().Delete("CLIENTSESSION", "DUMMY-CODE").Return(nil)
+		ex := svc.Logout(inp)
+		assert.Nil(t, ex)
+	})
+	t.Run("should success on ciam to sign-out failed", func(t *testing.T) {
+		cacher.EXPECT().Get("CLIENTSESSION", "DUMMY-CODE").Return(string(sess), nil)
+		ciamWatcher.EXPECT().SignOut("access-token-abc").Return(&model.TechnicalError{
+			Exception: "something went wrong",
+			Occurred:  time.Now().Unix(),
+			Ticket:    "ERR-001",
+		})
+		cacher.EXPECT().Set("JWTDENY", "jti-abc-123", "DUMMY-CODE", authTTL)
+		cacher.EXPECT().Delete("CLIENTSESSION", "DUMMY-CODE").Return(nil)
+		ex := svc.Logout(inp)
+		assert.Nil(t, ex)
+	})
+	t.Run("should return exception on no active session", func(t *testing.T) {
+		cacher.EXPECT().Get("CLIENTSESSION", "DUMMY-CODE").Return("", &model.TechnicalError{
+			Exception: "redis: nil",
+			Occurred:  time.Now().Unix(),
+			Ticket:    "ERR-001",
+		})
+		ex := svc.Logout(inp)
+		assert.Equal(t, apps.ErrCodeUnauthorized, ex.ErrorCode)
+	})
+	t.Run("should return exception on failed to delete session", func(t *testing.T) {
+		cacher.EXPECT().Get("CLIENTSESSION", "DUMMY-CODE").Return(string(sess), nil)
+		ciamWatcher.EXPECT().SignOut("access-token-abc").Return(nil)
+		cacher.EXPECT().Set("JWTDENY", "jti-abc-123", "DUMMY-CODE", authTTL)
+		cacher.EXPECT().Delete("CLIENTSESSION", "DUMMY-CODE").Return(&model.TechnicalError{
+			Exception: "something went wrong",
+			Occurred:  time.Now().Unix(),
+			Ticket:    "ERR-001",
+		})
+		ex := svc.Logout(inp)
+		assert.Equal(t, apps.ErrCodeSomethingWrong, ex.ErrorCode)
+	})
+}
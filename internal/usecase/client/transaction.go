@@ -9,24 +9,27 @@ import (
 	"github.com/adinandradrs/cezbek-engine/internal/repository"
 	"github.com/adinandradrs/cezbek-engine/internal/storage"
 	"github.com/adinandradrs/cezbek-engine/internal/usecase/h2h"
+	"github.com/adinandradrs/cezbek-engine/internal/usecase/notification"
 	"github.com/adinandradrs/cezbek-engine/internal/usecase/workflow"
 	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
-	"strconv"
-	"strings"
+	"time"
 )
 
 type Transaction struct {
 	TransactionDao repository.TransactionPersister
 	CashbackDao    repository.CashbackPersister
+	DepositDao     repository.DepositPersister
 	TierDao        repository.TierPersister
 	workflow.TierProvider
 	workflow.CashbackProvider
+	workflow.ReceiptProvider
 	h2h.Factory
-	SqsAdapter                    adaptor.SQSAdapter
-	Cacher                        storage.Cacher
-	QueueNotificationEmailInvoice *string
-	Logger                        *zap.Logger
+	EventProvider       notification.EventProvider
+	SqsAdapter          adaptor.SQSAdapter
+	QueueReconciliation *string
+	Cacher              storage.Cacher
+	Logger              *zap.Logger
 }
 
 type TransactionProvider interface {
@@ -84,61 +87,190 @@ func (t *Transaction) Add(inp *model.TransactionRequest) (*model.TransactionResp
 			CreatedBy: sql.NullInt64{Int64: inp.SessionRequest.Id},
 		},
 	}
+	locale := inp.Locale
+	if locale == "" {
+		locale = inp.SessionRequest.Locale
+	}
+	treq := &model.TierRequest{
+		PartnerId:   data.PartnerId,
+		PartnerCode: inp.SessionRequest.Username,
+		Partner:     data.Partner.String,
+		Email:       data.Email.String,
+		Msisdn:      data.Msisdn.String,
+		Locale:      locale,
+	}
+	tier, ex := t.TierProvider.Evaluate(treq)
+	if ex != nil {
+		t.Logger.Error("failed to evaluate tier", zap.Any("tx", inp))
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeBussRewardFailed,
+			ErrorMessage: apps.ErrMsgBussRewardFailed,
+		}
+	}
+	t.Logger.Info("", zap.Any("treward", tier.Reward), zap.Any("camt", camt))
+	pyld := t.sendCashbackRequest(tier.Reward, camt, data)
+	held, bx := t.hold(&data, pyld)
+	if bx != nil {
+		return nil, bx
+	}
 	id, ex := t.TransactionDao.Add(data)
 	if ex != nil {
 		t.Logger.Error("failed to add transaction - data access", zap.Any("tx", inp))
-		return nil, &model.BusinessError{
+		return nil, t.release(held, &model.BusinessError{
 			ErrorCode:    apps.ErrCodeBussClientAddTransaction,
 			ErrorMessage: apps.ErrMsgBussClientAddTransaction,
-		}
+		})
 	}
 	data.Id = *id
-	bx = t.processCashback(&data, camt, inp)
+	treq.TransactionId = *id
+	if ex = t.TierProvider.Save(treq, tier); ex != nil {
+		t.Logger.Error("failed to save tier", zap.Any("tx", inp))
+		return nil, t.release(held, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeBussRewardFailed,
+			ErrorMessage: apps.ErrMsgBussRewardFailed,
+		})
+	}
+	bx = t.processCashback(&data, camt, treq, tier, pyld, held)
 	if bx != nil {
 		return nil, bx
 	}
 	return &trx, nil
 }
 
-func (t *Transaction) processCashback(data *model.Transaction, camt *model.FindCashbackResponse, inp *model.TransactionRequest) *model.BusinessError {
+func (t *Transaction) processCashback(data *model.Transaction, camt *model.FindCashbackResponse,
+	treq *model.TierRequest, tier *model.TierChange, pyld *model.H2HSendCashbackRequest,
+	held *model.DepositMovement) *model.BusinessError {
+	v, bx := t.Factory.SendCashback(pyld)
+	if bx != nil {
+		return t.release(held, bx)
+	}
+	t.Logger.Info("", zap.Any("cashback_resp", v))
 	reward := decimal.Zero
-	treward, ex := t.TierProvider.Save(&model.TierRequest{
-		PartnerId:     data.PartnerId,
-		Email:         data.Email.String,
-		Msisdn:        data.Msisdn.String,
-		TransactionId: data.Id,
-	})
+	if tier.Reward != nil {
+		reward = tier.Reward.Reward
+	}
+	t.capture(held, pyld.Amount.Add(v.Fee), v.TransactionId)
+	cb := model.Cashback{
+		KezbekRefCode:   data.KezbekRefCode,
+		WalletCode:      data.WalletCode,
+		Reward:          decimal.NullDecimal{Decimal: reward},
+		Amount:          decimal.NullDecimal{Decimal: camt.Amount},
+		H2HCode:         sql.NullString{String: v.HostCode},
+		ProviderRefCode: sql.NullString{String: v.TransactionId},
+		Fee:             decimal.NullDecimal{Decimal: v.Fee, Valid: true},
+		Failover:        v.Failover,
+		BaseEntity:      data.BaseEntity,
+	}
+	j := t.cashbackJournal(data, treq.PartnerCode, v, pyld.Amount)
+	if ex := t.CashbackDao.Add(cb, j); ex != nil {
+		t.Logger.Error("failed to add disbursed cashback", zap.String("kezbek_ref_code", data.KezbekRefCode.String),
+			zap.String("provider_ref_code", v.TransactionId))
+		t.reconcile(model.CashbackReconciliation{Cashback: &cb, Journal: j})
+	}
+	go t.TierProvider.NotifyUpgrade(treq, tier)
+	go t.invoice(data, *pyld, camt.Amount, reward, treq.PartnerCode, treq.Locale)
+	return nil
+}
+
+func (t *Transaction) hold(d *model.Transaction, pyld *model.H2HSendCashbackRequest) (*model.DepositMovement,
+	*model.BusinessError) {
+	p, bx := t.Factory.MaxPricing(pyld.WalletCode)
+	if bx != nil {
+		return nil, bx
+	}
+	m := model.DepositMovement{
+		PartnerId:  d.PartnerId,
+		Kind:       sql.NullString{String: apps.DepositHold, Valid: true},
+		Amount:     pyld.Amount.Add(p.Fee),
+		Reference:  d.KezbekRefCode,
+		BaseEntity: d.BaseEntity,
+	}
+	v, ex := t.DepositDao.Hold(m)
 	if ex != nil {
-		t.Logger.Error("failed to save tier", zap.Any("tx", inp))
-		return &model.BusinessError{
-			ErrorCode:    apps.ErrCodeBussRewardFailed,
-			ErrorMessage: apps.ErrMsgBussRewardFailed,
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSomethingWrong,
+			ErrorMessage: apps.ErrMsgSomethingWrong,
+		}
+	}
+	if v == nil {
+		return nil, nil
+	}
+	if v.Available.LessThan(m.Amount) {
+		t.Logger.Warn("insufficient partner deposit", zap.Int64("partner_id", d.PartnerId),
+			zap.String("available", v.Available.String()), zap.String("amount", m.Amount.String()))
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeBussDepositInsufficient,
+			ErrorMessage: apps.ErrMsgBussDepositInsufficient,
 		}
 	}
+	if !v.Available.LessThan(v.Threshold) && v.Available.Sub(m.Amount).LessThan(v.Threshold) {
+		go t.EventProvider.Publish(&model.NotificationEvent{
+			Event:    apps.NotificationEventDepositLow,
+			Category: apps.NotificationCategoryTransactional,
+			Partner:  v.Code,
+			Msisdn:   v.Msisdn,
+			Email:    v.Email,
+			Locale:   v.Locale,
+			Data: map[string]interface{}{
+				"partner":   v.Partner,
+				"available": v.Available.Sub(m.Amount),
+				"threshold": v.Threshold,
+			},
+		})
+	}
+	return &m, nil
+}
 
-	t.Logger.Info("", zap.Any("treward", treward), zap.Any("camt", camt))
-	pyld := t.sendCashbackRequest(treward,
-		camt, *data)
-	v, bx := t.Factory.SendCashback(pyld)
-	if bx != nil {
-		return bx
+func (t *Transaction) capture(held *model.DepositMovement, amount decimal.Decimal, providerRef string) {
+	if held == nil {
+		return
 	}
-	t.Logger.Info("", zap.Any("cashback_resp", v))
-	go t.queueEmailInvoice(data, *pyld)
+	m := *held
+	m.Kind = sql.NullString{String: apps.DepositCapture, Valid: true}
+	m.Amount = amount
+	if ex := t.DepositDao.Capture(m, held.Amount); ex != nil {
+		t.Logger.Error("failed to capture deposit", zap.String("reference", m.Reference.String),
+			zap.String("provider_ref_code", providerRef))
+		t.reconcile(model.CashbackReconciliation{Capture: &m, Held: held.Amount})
+	}
+}
 
-	if treward != nil {
-		reward = treward.Reward
-	}
-	go t.CashbackDao.Add(model.Cashback{
-		KezbekRefCode: data.KezbekRefCode,
-		WalletCode:    data.WalletCode,
-		Reward:        decimal.NullDecimal{Decimal: reward},
-		Amount:        decimal.NullDecimal{Decimal: camt.Amount},
-		H2HCode:       sql.NullString{String: v.HostCode},
-		BaseEntity:    data.BaseEntity,
-	})
+func (t *Transaction) reconcile(v model.CashbackReconciliation) {
+	msg, _ := json.Marshal(v)
+	if err := t.SqsAdapter.SendMessage(*t.QueueReconciliation, string(msg)); err != nil {
+		t.Logger.Error("failed to queue cashback reconciliation", zap.String("reconciliation", string(msg)),
+			zap.Error(err))
+	}
+}
 
-	return nil
+func (t *Transaction) release(held *model.DepositMovement, bx *model.BusinessError) *model.BusinessError {
+	if held == nil {
+		return bx
+	}
+	m := *held
+	m.Kind = sql.NullString{String: apps.DepositRelease, Valid: true}
+	if ex := t.DepositDao.Release(m); ex != nil {
+		t.Logger.Error("failed to release deposit", zap.String("reference", m.Reference.String))
+		return &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSomethingWrong,
+			ErrorMessage: apps.ErrMsgSomethingWrong,
+		}
+	}
+	return bx
+}
+
+func (t *Transaction) cashbackJournal(d *model.Transaction, partner string, v *model.H2HTransactionResponse,
+	amount decimal.Decimal) model.LedgerJournal {
+	return model.LedgerJournal{
+		Kind:        sql.NullString{String: apps.LedgerJournalCashback, Valid: true},
+		Reference:   d.KezbekRefCode,
+		Description: sql.NullString{String: "Cashback disbursed by " + v.HostCode, Valid: true},
+		Entries: append(model.LedgerTransfer(apps.LedgerPartnerReceivable, partner,
+			apps.LedgerCustomerPayable, d.Msisdn.String, amount),
+			model.LedgerTransfer(apps.LedgerPartnerReceivable, partner,
+				apps.LedgerProviderFee, v.HostCode, v.Fee)...),
+		BaseEntity: d.BaseEntity,
+	}
 }
 
 func (t *Transaction) sendCashbackRequest(reward *model.WfRewardTierProjection, cashback *model.FindCashbackResponse, d model.Transaction) *model.H2HSendCashbackRequest {
@@ -160,40 +292,52 @@ func (t *Transaction) sendCashbackRequest(reward *model.WfRewardTierProjection,
 	}
 }
 
-func (t *Transaction) invoiceEmailContent(tx *model.Transaction, amt decimal.Decimal) string {
-	tmpl, _ := t.Cacher.Hget("EMAIL_TEMPLATE", "INVOICE")
-	tmpl = strings.ReplaceAll(tmpl, "${reference}", tx.KezbekRefCode.String)
-	tmpl = strings.ReplaceAll(tmpl, "${msisdn}", tx.Msisdn.String)
-	tmpl = strings.ReplaceAll(tmpl, "${email}", tx.Email.String)
-	tmpl = strings.ReplaceAll(tmpl, "${walletCode}", tx.WalletCode.String)
-	tmpl = strings.ReplaceAll(tmpl, "${partner}", tx.Partner.String)
-	tmpl = strings.ReplaceAll(tmpl, "${qty}", strconv.Itoa(tx.Qty))
-	tmpl = strings.ReplaceAll(tmpl, "${transactionAmount}", tx.Amount.String())
-	tmpl = strings.ReplaceAll(tmpl, "${cashbackAmount}", amt.String())
-	tmpl = strings.ReplaceAll(tmpl, "\n", "")
-	tmpl = strings.ReplaceAll(tmpl, "\t", "")
-	return tmpl
+func (t *Transaction) invoice(tx *model.Transaction, csb model.H2HSendCashbackRequest, cashback decimal.Decimal,
+	reward decimal.Decimal, partner string, locale string) *model.BusinessError {
+	receiptUrl := ""
+	v, bx := t.ReceiptProvider.Generate(&model.ReceiptRequest{
+		PartnerId:       tx.PartnerId,
+		PartnerCode:     partner,
+		Partner:         tx.Partner.String,
+		KezbekRefCode:   tx.KezbekRefCode.String,
+		WalletCode:      tx.WalletCode.String,
+		Msisdn:          tx.Msisdn.String,
+		Email:           tx.Email.String,
+		Qty:             tx.Qty,
+		Transaction:     tx.Amount,
+		Cashback:        cashback,
+		Reward:          reward,
+		TransactionDate: time.Now().Unix(),
+		Locale:          locale,
+	})
+	if bx != nil {
+		t.Logger.Error("failed to generate receipt", zap.String("reference", tx.KezbekRefCode.String))
+	} else {
+		receiptUrl = v.Url
+	}
+	return t.queueInvoice(tx, csb, receiptUrl, partner, locale)
 }
 
-func (t *Transaction) queueEmailInvoice(tx *model.Transaction, csb model.H2HSendCashbackRequest) *model.BusinessError {
-	sbj, _ := t.Cacher.Hget("EMAIL_SUBJECT", "INVOICE")
-	msg, err := json.Marshal(model.SendEmailRequest{
-		Content:     t.invoiceEmailContent(tx, csb.Amount),
-		Subject:     sbj,
-		Destination: tx.Email.String,
+func (t *Transaction) queueInvoice(tx *model.Transaction, csb model.H2HSendCashbackRequest, receiptUrl string,
+	partner string, locale string) *model.BusinessError {
+	return t.EventProvider.Publish(&model.NotificationEvent{
+		Event:    apps.NotificationEventInvoice,
+		Category: apps.NotificationCategoryTransactional,
+		Partner:  partner,
+		Msisdn:   tx.Msisdn.String,
+		Email:    tx.Email.String,
+		Locale:   locale,
+		Data: map[string]interface{}{
+			"reference":         tx.KezbekRefCode.String,
+			"msisdn":            tx.Msisdn.String,
+			"email":             tx.Email.String,
+			"walletCode":        tx.WalletCode.String,
+			"partner":           tx.Partner.String,
+			"qty":               tx.Qty,
+			"transactionAmount": tx.Amount,
+			"cashbackAmount":    csb.Amount,
+			"date":              time.Now(),
+			"receiptUrl":        receiptUrl,
+		},
 	})
-	if err != nil {
-		return &model.BusinessError{
-			ErrorCode:    apps.ErrCodeSomethingWrong,
-			ErrorMessage: apps.ErrMsgSomethingWrong,
-		}
-	}
-	err = t.SqsAdapter.SendMessage(*t.QueueNotificationEmailInvoice, string(msg))
-	if err != nil {
-		return &model.BusinessError{
-			ErrorCode:    apps.ErrCodeSomethingWrong,
-			ErrorMessage: apps.ErrMsgSomethingWrong,
-		}
-	}
-	return nil
 }
@@ -9,10 +9,12 @@ import (
 	"github.com/adinandradrs/cezbek-engine/mock/adaptor"
 	"github.com/adinandradrs/cezbek-engine/mock/repository"
 	"github.com/adinandradrs/cezbek-engine/mock/storage"
+	"github.com/adinandradrs/cezbek-engine/mock/usecase/notification"
 	"github.com/adinandradrs/cezbek-engine/mock/usecase/workflow"
 	"github.com/golang/mock/gomock"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
+	"sync"
 	"testing"
 	"time"
 )
@@ -21,25 +23,30 @@ func TestTransaction_Add(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	logger, _ := apps.NewLog(false)
-	transactionDao, cashbackDao, tierProvider, cashbackProvider, cacher, sqsAdapter :=
+	transactionDao, cashbackDao, depositDao, tierProvider, cashbackProvider, cacher :=
 		repository.NewMockTransactionPersister(ctrl), repository.NewMockCashbackPersister(ctrl),
-		workflow.NewMockTierProvider(ctrl), workflow.NewMockCashbackProvider(ctrl),
-		storage.NewMockCacher(ctrl), adaptor.NewMockSQSAdapter(ctrl)
+		repository.NewMockDepositPersister(ctrl), workflow.NewMockTierProvider(ctrl),
+		workflow.NewMockCashbackProvider(ctrl), storage.NewMockCacher(ctrl)
 	josvoAdapter := adaptor.NewMockJosvoAdapter(ctrl)
 	gopaidAdapter := adaptor.NewMockGopaidAdapter(ctrl)
 	linksajaAdapter := adaptor.NewMockLinksajaAdapter(ctrl)
 	mtransAdapter := adaptor.NewMockMiddletransAdapter(ctrl)
 	xenitAdapter := adaptor.NewMockXenitAdapter(ctrl)
-	queueNotificationEmailInvoice := "mock-queue"
+	eventProvider := notification.NewMockEventProvider(ctrl)
+	receiptProvider := workflow.NewMockReceiptProvider(ctrl)
+	sqsAdapter, q := adaptor.NewMockSQSAdapter(ctrl), "mock-queue"
 	svc := NewTransaction(Transaction{
-		Logger:                        logger,
-		Cacher:                        cacher,
-		TierProvider:                  tierProvider,
-		CashbackProvider:              cashbackProvider,
-		SqsAdapter:                    sqsAdapter,
-		QueueNotificationEmailInvoice: &queueNotificationEmailInvoice,
-		TransactionDao:                transactionDao,
-		CashbackDao:                   cashbackDao,
+		Logger:              logger,
+		Cacher:              cacher,
+		EventProvider:       eventProvider,
+		TierProvider:        tierProvider,
+		CashbackProvider:    cashbackProvider,
+		ReceiptProvider:     receiptProvider,
+		TransactionDao:      transactionDao,
+		CashbackDao:         cashbackDao,
+		DepositDao:          depositDao,
+		SqsAdapter:          sqsAdapter,
+		QueueReconciliation: &q,
 		Factory: h2h.Factory{
 			Cacher: cacher,
 			Josvo: h2h.Josvo{
@@ -69,19 +76,66 @@ func TestTransaction_Add(t *testing.T) {
 		Msisdn:               "6281123456890",
 		SessionRequest: model.SessionRequest{
 			Id:       int64(1),
+			Username: "CORPA",
 			Email:    "corporate@email.xyz",
 			Fullname: "PT. Corporate A",
+			Locale:   "id",
 		},
 	}
 	t.Run("should success", func(t *testing.T) {
 		providers := []model.H2HPricingProjection{
 			{
 				Code: "LSAJAH2H",
+				Fee:  decimal.NewFromInt(750),
 			},
 		}
 		b, _ := json.Marshal(providers)
-		tierProvider.EXPECT().Save(gomock.Any()).Return(&model.WfRewardTierProjection{Reward: decimal.NewFromInt(100)}, nil)
-		cashbackDao.EXPECT().Add(gomock.Any()).Return(nil)
+		var wg sync.WaitGroup
+		wg.Add(2)
+		tier := &model.TierChange{
+			Reward:   &model.WfRewardTierProjection{Reward: decimal.NewFromInt(100)},
+			Upgraded: true,
+		}
+		tierProvider.EXPECT().Evaluate(gomock.Any()).Return(tier, nil)
+		tierProvider.EXPECT().Save(gomock.Any(), tier).Do(func(inp *model.TierRequest, v *model.TierChange) {
+			assert.Equal(t, int64(1), inp.TransactionId)
+		}).Return(nil)
+		tierProvider.EXPECT().NotifyUpgrade(gomock.Any(), tier).Do(func(inp *model.TierRequest, v *model.TierChange) {
+			assert.Equal(t, int64(1), inp.TransactionId)
+			wg.Done()
+		})
+		cashbackDao.EXPECT().Add(gomock.Any(), gomock.Any()).Do(func(m model.Cashback, j model.LedgerJournal) {
+			assert.Equal(t, "trx-001", m.ProviderRefCode.String)
+			assert.True(t, decimal.NewFromInt(750).Equal(m.Fee.Decimal))
+			assert.False(t, m.Failover)
+			assert.Equal(t, apps.LedgerJournalCashback, j.Kind.String)
+			assert.Equal(t, []model.LedgerEntry{
+				{
+					AccountType: sql.NullString{String: apps.LedgerPartnerReceivable, Valid: true},
+					AccountCode: sql.NullString{String: "CORPA", Valid: true},
+					Debit:       decimal.NewFromInt(300),
+					Credit:      decimal.Zero,
+				},
+				{
+					AccountType: sql.NullString{String: apps.LedgerCustomerPayable, Valid: true},
+					AccountCode: sql.NullString{String: "6281123456890", Valid: true},
+					Debit:       decimal.Zero,
+					Credit:      decimal.NewFromInt(300),
+				},
+				{
+					AccountType: sql.NullString{String: apps.LedgerPartnerReceivable, Valid: true},
+					AccountCode: sql.NullString{String: "CORPA", Valid: true},
+					Debit:       decimal.NewFromInt(750),
+					Credit:      decimal.Zero,
+				},
+				{
+					AccountType: sql.NullString{String: apps.LedgerProviderFee, Valid: true},
+					AccountCode: sql.NullString{String: "LSAJAH2H", Valid: true},
+					Debit:       decimal.Zero,
+					Credit:      decimal.NewFromInt(750),
+				},
+			}, j.Entries)
+		}).Return(nil)
 		cashbackProvider.EXPECT().FindCashbackAmount(gomock.Any()).Return(&model.FindCashbackResponse{
 			Amount: decimal.NewFromInt(200),
 		}, nil)
@@ -91,25 +145,238 @@ func TestTransaction_Add(t *testing.T) {
 		}, nil)
 		cacher.EXPECT().Get("H2H:LINKSAJA", "TOKEN").Return("something-abc", nil)
 		cacher.EXPECT().Hget("WALLET_CODE", inp.MerchantCode).Return("WCODE_A", nil)
-		cacher.EXPECT().Hget("PROVIDER_FEE", gomock.Any()).Return(string(b), nil)
-		cacher.EXPECT().Hget("EMAIL_SUBJECT", "INVOICE").Return("A subject", nil)
-		cacher.EXPECT().Hget("EMAIL_TEMPLATE", "INVOICE").Return("The content", nil)
-		sqsAdapter.EXPECT().SendMessage(gomock.Any(), gomock.Any()).Return(nil)
+		cacher.EXPECT().Hget("PROVIDER_FEE", gomock.Any()).Times(2).Return(string(b), nil)
+		depositDao.EXPECT().Hold(gomock.Any()).DoAndReturn(
+			func(m model.DepositMovement) (*model.DepositProjection, *model.TechnicalError) {
+				assert.Equal(t, apps.DepositHold, m.Kind.String)
+				assert.True(t, decimal.NewFromInt(1050).Equal(m.Amount))
+				return &model.DepositProjection{
+					Available: decimal.NewFromInt(5000000),
+					Threshold: decimal.NewFromInt(1000000),
+				}, nil
+			})
+		depositDao.EXPECT().Capture(gomock.Any(), gomock.Any()).Do(func(m model.DepositMovement, held decimal.Decimal) {
+			assert.Equal(t, apps.DepositCapture, m.Kind.String)
+			assert.True(t, decimal.NewFromInt(1050).Equal(m.Amount))
+			assert.True(t, decimal.NewFromInt(1050).Equal(held))
+		}).Return(nil)
+		receiptProvider.EXPECT().Generate(gomock.Any()).DoAndReturn(
+			func(r *model.ReceiptRequest) (*model.ReceiptResponse, *model.BusinessError) {
+				assert.Equal(t, "CORPA", r.PartnerCode)
+				assert.Equal(t, decimal.NewFromInt(200), r.Cashback)
+				assert.Equal(t, decimal.NewFromInt(100), r.Reward)
+				return &model.ReceiptResponse{Url: "https://cdn.kezbek.id/receipt/1/TRX-abc.pdf"}, nil
+			})
+		eventProvider.EXPECT().Publish(gomock.Any()).DoAndReturn(func(e *model.NotificationEvent) *model.BusinessError {
+			defer wg.Done()
+			assert.Equal(t, apps.NotificationEventInvoice, e.Event)
+			assert.Equal(t, apps.NotificationCategoryTransactional, e.Category)
+			assert.Equal(t, "CORPA", e.Partner)
+			assert.Equal(t, "en", e.Locale)
+			assert.Equal(t, decimal.NewFromInt(300), e.Data["cashbackAmount"])
+			assert.Equal(t, inp.Fullname, e.Data["partner"])
+			assert.Equal(t, "https://cdn.kezbek.id/receipt/1/TRX-abc.pdf", e.Data["receiptUrl"])
+			return nil
+		})
 		tid := int64(1)
 		transactionDao.EXPECT().Add(gomock.Any()).Return(&tid, nil)
+		req := inp
+		req.Locale = "en"
+		v, ex := svc.Add(&req)
+		assert.Nil(t, ex)
+		assert.NotNil(t, v)
+		wg.Wait()
+	})
+
+	t.Run("should success with invoice for customer without email on failed receipt", func(t *testing.T) {
+		providers := []model.H2HPricingProjection{
+			{
+				Code: "LSAJAH2H",
+			},
+		}
+		b, _ := json.Marshal(providers)
+		var wg sync.WaitGroup
+		wg.Add(2)
+		tierProvider.EXPECT().Evaluate(gomock.Any()).Return(&model.TierChange{}, nil)
+		tierProvider.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+		tierProvider.EXPECT().NotifyUpgrade(gomock.Any(), gomock.Any()).Do(func(inp *model.TierRequest, v *model.TierChange) {
+			wg.Done()
+		})
+		cashbackDao.EXPECT().Add(gomock.Any(), gomock.Any()).Do(func(m model.Cashback, j model.LedgerJournal) {
+			assert.Len(t, j.Entries, 2)
+		}).Return(nil)
+		cashbackProvider.EXPECT().FindCashbackAmount(gomock.Any()).Return(&model.FindCashbackResponse{
+			Amount: decimal.NewFromInt(200),
+		}, nil)
+		linksajaAdapter.EXPECT().FundTransfer(gomock.Any()).Return(&model.LinksajaFundTransferResponse{
+			TransactionID:   "trx-002",
+			TransactionTime: "123456",
+		}, nil)
+		cacher.EXPECT().Get("H2H:LINKSAJA", "TOKEN").Return("something-abc", nil)
+		cacher.EXPECT().Hget("WALLET_CODE", inp.MerchantCode).Return("WCODE_A", nil)
+		cacher.EXPECT().Hget("PROVIDER_FEE", gomock.Any()).Times(2).Return(string(b), nil)
+		depositDao.EXPECT().Hold(gomock.Any()).Return(nil, nil)
+		receiptProvider.EXPECT().Generate(gomock.Any()).Return(nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSomethingWrong,
+			ErrorMessage: apps.ErrMsgSomethingWrong,
+		})
+		eventProvider.EXPECT().Publish(gomock.Any()).DoAndReturn(func(e *model.NotificationEvent) *model.BusinessError {
+			defer wg.Done()
+			assert.Equal(t, "id", e.Locale)
+			assert.Equal(t, "", e.Email)
+			assert.Equal(t, "", e.Data["receiptUrl"])
+			return nil
+		})
+		tid := int64(2)
+		transactionDao.EXPECT().Add(gomock.Any()).Return(&tid, nil)
+		req := inp
+		req.Email = ""
+		v, ex := svc.Add(&req)
+		assert.Nil(t, ex)
+		assert.NotNil(t, v)
+		wg.Wait()
+	})
+
+	t.Run("should success and queue reconciliation on failed to add cashback", func(t *testing.T) {
+		providers := []model.H2HPricingProjection{
+			{
+				Code: "LSAJAH2H",
+			},
+		}
+		b, _ := json.Marshal(providers)
+		var wg sync.WaitGroup
+		wg.Add(2)
+		tierProvider.EXPECT().Evaluate(gomock.Any()).Return(&model.TierChange{}, nil)
+		tierProvider.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+		tierProvider.EXPECT().NotifyUpgrade(gomock.Any(), gomock.Any()).Do(func(inp *model.TierRequest, v *model.TierChange) {
+			wg.Done()
+		})
+		cashbackDao.EXPECT().Add(gomock.Any(), gomock.Any()).Return(&model.TechnicalError{
+			Exception: "something went wrong",
+			Occurred:  time.Now().Unix(),
+			Ticket:    "ERR-001",
+		})
+		sqsAdapter.EXPECT().SendMessage(q, gomock.Any()).DoAndReturn(func(q string, msg string) error {
+			v := model.CashbackReconciliation{}
+			assert.Nil(t, json.Unmarshal([]byte(msg), &v))
+			assert.Nil(t, v.Capture)
+			assert.Equal(t, "trx-003", v.Cashback.ProviderRefCode.String)
+			assert.Equal(t, apps.LedgerJournalCashback, v.Journal.Kind.String)
+			return nil
+		})
+		receiptProvider.EXPECT().Generate(gomock.Any()).Return(&model.ReceiptResponse{}, nil)
+		eventProvider.EXPECT().Publish(gomock.Any()).DoAndReturn(func(e *model.NotificationEvent) *model.BusinessError {
+			wg.Done()
+			return nil
+		})
+		cashbackProvider.EXPECT().FindCashbackAmount(gomock.Any()).Return(&model.FindCashbackResponse{
+			Amount: decimal.NewFromInt(200),
+		}, nil)
+		linksajaAdapter.EXPECT().FundTransfer(gomock.Any()).Return(&model.LinksajaFundTransferResponse{
+			TransactionID:   "trx-003",
+			TransactionTime: "123456",
+		}, nil)
+		cacher.EXPECT().Get("H2H:LINKSAJA", "TOKEN").Return("something-abc", nil)
+		cacher.EXPECT().Hget("WALLET_CODE", inp.MerchantCode).Return("WCODE_A", nil)
+		cacher.EXPECT().Hget("PROVIDER_FEE", gomock.Any()).Times(2).Return(string(b), nil)
+		depositDao.EXPECT().Hold(gomock.Any()).Return(nil, nil)
+		tid := int64(5)
+		transactionDao.EXPECT().Add(gomock.Any()).Return(&tid, nil)
 		v, ex := svc.Add(&inp)
 		assert.Nil(t, ex)
 		assert.NotNil(t, v)
+		wg.Wait()
+	})
+
+	t.Run("should return exception on insufficient deposit", func(t *testing.T) {
+		providers := []model.H2HPricingProjection{
+			{
+				Code: "LSAJAH2H",
+				Fee:  decimal.NewFromInt(750),
+			},
+		}
+		b, _ := json.Marshal(providers)
+		tierProvider.EXPECT().Evaluate(gomock.Any()).Return(&model.TierChange{}, nil)
+		cashbackProvider.EXPECT().FindCashbackAmount(gomock.Any()).Return(&model.FindCashbackResponse{
+			Amount: decimal.NewFromInt(200),
+		}, nil)
+		cacher.EXPECT().Hget("WALLET_CODE", inp.MerchantCode).Return("WCODE_A", nil)
+		cacher.EXPECT().Hget("PROVIDER_FEE", gomock.Any()).Return(string(b), nil)
+		depositDao.EXPECT().Hold(gomock.Any()).Return(&model.DepositProjection{
+			Available: decimal.NewFromInt(900),
+			Threshold: decimal.NewFromInt(1000000),
+		}, nil)
+		v, ex := svc.Add(&inp)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeBussDepositInsufficient, ex.ErrorCode)
 	})
 
-	t.Run("should error on data access failed to insert", func(t *testing.T) {
+	t.Run("should release deposit and alert low balance on failed disbursement", func(t *testing.T) {
+		providers := []model.H2HPricingProjection{
+			{
+				Code: "XPAYH2H",
+				Fee:  decimal.NewFromInt(750),
+			},
+		}
+		b, _ := json.Marshal(providers)
+		var wg sync.WaitGroup
+		wg.Add(1)
+		tierProvider.EXPECT().Evaluate(gomock.Any()).Return(&model.TierChange{}, nil)
+		tierProvider.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+		cashbackProvider.EXPECT().FindCashbackAmount(gomock.Any()).Return(&model.FindCashbackResponse{
+			Amount: decimal.NewFromInt(200),
+		}, nil)
 		cacher.EXPECT().Hget("WALLET_CODE", inp.MerchantCode).Return("WCODE_A", nil)
+		cacher.EXPECT().Hget("PROVIDER_FEE", gomock.Any()).Times(2).Return(string(b), nil)
+		depositDao.EXPECT().Hold(gomock.Any()).Return(&model.DepositProjection{
+			Code:      "CORPA",
+			Email:     "finance@corpa.id",
+			Available: decimal.NewFromInt(1000500),
+			Threshold: decimal.NewFromInt(1000000),
+		}, nil)
+		depositDao.EXPECT().Release(gomock.Any()).Do(func(m model.DepositMovement) {
+			assert.Equal(t, apps.DepositRelease, m.Kind.String)
+		}).Return(nil)
+		eventProvider.EXPECT().Publish(gomock.Any()).DoAndReturn(func(e *model.NotificationEvent) *model.BusinessError {
+			defer wg.Done()
+			assert.Equal(t, apps.NotificationEventDepositLow, e.Event)
+			assert.Equal(t, "finance@corpa.id", e.Email)
+			assert.Equal(t, decimal.NewFromInt(999550), e.Data["available"])
+			return nil
+		})
+		tid := int64(4)
+		transactionDao.EXPECT().Add(gomock.Any()).Return(&tid, nil)
+		v, ex := svc.Add(&inp)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeBadPayload, ex.ErrorCode)
+		wg.Wait()
+	})
+
+	t.Run("should error and release deposit on data access failed to insert", func(t *testing.T) {
+		providers := []model.H2HPricingProjection{
+			{
+				Code: "LSAJAH2H",
+				Fee:  decimal.NewFromInt(750),
+			},
+		}
+		b, _ := json.Marshal(providers)
+		cacher.EXPECT().Hget("WALLET_CODE", inp.MerchantCode).Return("WCODE_A", nil)
+		cacher.EXPECT().Hget("PROVIDER_FEE", gomock.Any()).Return(string(b), nil)
 		cashbackProvider.EXPECT().FindCashbackAmount(&model.FindCashbackRequest{
 			Amount: inp.Amount,
 			Qty:    inp.Qty,
 		}).Return(&model.FindCashbackResponse{
 			Amount: decimal.NewFromInt(1000),
 		}, nil)
+		tierProvider.EXPECT().Evaluate(gomock.Any()).Return(&model.TierChange{}, nil)
+		depositDao.EXPECT().Hold(gomock.Any()).Return(&model.DepositProjection{
+			Available: decimal.NewFromInt(5000000),
+		}, nil)
+		depositDao.EXPECT().Release(gomock.Any()).Do(func(m model.DepositMovement) {
+			assert.True(t, decimal.NewFromInt(1750).Equal(m.Amount))
+		}).Return(nil)
 		transactionDao.EXPECT().Add(gomock.Any()).Return(nil, &model.TechnicalError{
 			Exception: "something went wrong",
 			Occurred:  time.Now().Unix(),
@@ -151,8 +418,8 @@ func TestTransaction_Add(t *testing.T) {
 		assert.Equal(t, apps.ErrCodeBussNoCashback, ex.ErrorCode)
 	})
 
-	t.Run("should return exception on process cashback failed", func(t *testing.T) {
-		tierProvider.EXPECT().Save(gomock.Any()).Return(nil, &model.TechnicalError{
+	t.Run("should return exception on failed to evaluate tier", func(t *testing.T) {
+		tierProvider.EXPECT().Evaluate(gomock.Any()).Return(nil, &model.TechnicalError{
 			Exception: "something went wrong",
 			Occurred:  time.Now().Unix(),
 			Ticket:    "ERR-001",
@@ -161,11 +428,157 @@ func TestTransaction_Add(t *testing.T) {
 			Amount: decimal.NewFromInt(200),
 		}, nil)
 		cacher.EXPECT().Hget("WALLET_CODE", inp.MerchantCode).Return("WCODE_A", nil)
-		tid := int64(1)
+		v, ex := svc.Add(&inp)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeBussRewardFailed, ex.ErrorCode)
+	})
+
+	t.Run("should return exception on failed to release deposit after failed to save tier", func(t *testing.T) {
+		providers := []model.H2HPricingProjection{
+			{
+				Code: "LSAJAH2H",
+				Fee:  decimal.NewFromInt(750),
+			},
+		}
+		b, _ := json.Marshal(providers)
+		tierProvider.EXPECT().Evaluate(gomock.Any()).Return(&model.TierChange{}, nil)
+		tierProvider.EXPECT().Save(gomock.Any(), gomock.Any()).Return(&model.TechnicalError{
+			Exception: "something went wrong",
+			Occurred:  time.Now().Unix(),
+			Ticket:    "ERR-001",
+		})
+		cashbackProvider.EXPECT().FindCashbackAmount(gomock.Any()).Return(&model.FindCashbackResponse{
+			Amount: decimal.NewFromInt(200),
+		}, nil)
+		cacher.EXPECT().Hget("WALLET_CODE", inp.MerchantCode).Return("WCODE_A", nil)
+		cacher.EXPECT().Hget("PROVIDER_FEE", gomock.Any()).Return(string(b), nil)
+		depositDao.EXPECT().Hold(gomock.Any()).Return(&model.DepositProjection{
+			Available: decimal.NewFromInt(5000000),
+		}, nil)
+		depositDao.EXPECT().Release(gomock.Any()).Return(&model.TechnicalError{
+			Exception: "something went wrong",
+			Occurred:  time.Now().Unix(),
+			Ticket:    "ERR-001",
+		})
+		tid := int64(7)
 		transactionDao.EXPECT().Add(gomock.Any()).Return(&tid, nil)
 		v, ex := svc.Add(&inp)
 		assert.NotNil(t, ex)
 		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeSomethingWrong, ex.ErrorCode)
+	})
+
+	t.Run("should hold the most expensive fee and capture the fee of the disbursing provider", func(t *testing.T) {
+		providers := []model.H2HPricingProjection{
+			{
+				Code: "XPAYH2H",
+				Fee:  decimal.NewFromInt(500),
+			},
+			{
+				Code: "LSAJAH2H",
+				Fee:  decimal.NewFromInt(750),
+			},
+		}
+		b, _ := json.Marshal(providers)
+		var wg sync.WaitGroup
+		wg.Add(2)
+		tierProvider.EXPECT().Evaluate(gomock.Any()).Return(&model.TierChange{}, nil)
+		tierProvider.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+		tierProvider.EXPECT().NotifyUpgrade(gomock.Any(), gomock.Any()).Do(func(inp *model.TierRequest, v *model.TierChange) {
+			wg.Done()
+		})
+		cashbackProvider.EXPECT().FindCashbackAmount(gomock.Any()).Return(&model.FindCashbackResponse{
+			Amount: decimal.NewFromInt(200),
+		}, nil)
+		linksajaAdapter.EXPECT().FundTransfer(gomock.Any()).Return(&model.LinksajaFundTransferResponse{
+			TransactionID:   "trx-004",
+			TransactionTime: "123456",
+		}, nil)
+		cacher.EXPECT().Get("H2H:LINKSAJA", "TOKEN").Return("something-abc", nil)
+		cacher.EXPECT().Hget("WALLET_CODE", inp.MerchantCode).Return("WCODE_A", nil)
+		cacher.EXPECT().Hget("PROVIDER_FEE", gomock.Any()).Times(2).Return(string(b), nil)
+		depositDao.EXPECT().Hold(gomock.Any()).DoAndReturn(
+			func(m model.DepositMovement) (*model.DepositProjection, *model.TechnicalError) {
+				assert.True(t, decimal.NewFromInt(950).Equal(m.Amount))
+				return &model.DepositProjection{
+					Available: decimal.NewFromInt(5000000),
+				}, nil
+			})
+		depositDao.EXPECT().Capture(gomock.Any(), gomock.Any()).Do(func(m model.DepositMovement, held decimal.Decimal) {
+			assert.True(t, decimal.NewFromInt(950).Equal(m.Amount))
+			assert.True(t, decimal.NewFromInt(950).Equal(held))
+		}).Return(nil)
+		cashbackDao.EXPECT().Add(gomock.Any(), gomock.Any()).Do(func(m model.Cashback, j model.LedgerJournal) {
+			assert.True(t, m.Failover)
+		}).Return(nil)
+		receiptProvider.EXPECT().Generate(gomock.Any()).Return(&model.ReceiptResponse{}, nil)
+		eventProvider.EXPECT().Publish(gomock.Any()).DoAndReturn(func(e *model.NotificationEvent) *model.BusinessError {
+			wg.Done()
+			return nil
+		})
+		tid := int64(8)
+		transactionDao.EXPECT().Add(gomock.Any()).Return(&tid, nil)
+		v, ex := svc.Add(&inp)
+		assert.Nil(t, ex)
+		assert.NotNil(t, v)
+		wg.Wait()
+	})
+
+	t.Run("should success and queue reconciliation on failed to capture deposit", func(t *testing.T) {
+		providers := []model.H2HPricingProjection{
+			{
+				Code: "LSAJAH2H",
+				Fee:  decimal.NewFromInt(750),
+			},
+		}
+		b, _ := json.Marshal(providers)
+		var wg sync.WaitGroup
+		wg.Add(2)
+		tierProvider.EXPECT().Evaluate(gomock.Any()).Return(&model.TierChange{}, nil)
+		tierProvider.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+		tierProvider.EXPECT().NotifyUpgrade(gomock.Any(), gomock.Any()).Do(func(inp *model.TierRequest, v *model.TierChange) {
+			wg.Done()
+		})
+		cashbackProvider.EXPECT().FindCashbackAmount(gomock.Any()).Return(&model.FindCashbackResponse{
+			Amount: decimal.NewFromInt(200),
+		}, nil)
+		linksajaAdapter.EXPECT().FundTransfer(gomock.Any()).Return(&model.LinksajaFundTransferResponse{
+			TransactionID:   "trx-005",
+			TransactionTime: "123456",
+		}, nil)
+		cacher.EXPECT().Get("H2H:LINKSAJA", "TOKEN").Return("something-abc", nil)
+		cacher.EXPECT().Hget("WALLET_CODE", inp.MerchantCode).Return("WCODE_A", nil)
+		cacher.EXPECT().Hget("PROVIDER_FEE", gomock.Any()).Times(2).Return(string(b), nil)
+		depositDao.EXPECT().Hold(gomock.Any()).Return(&model.DepositProjection{
+			Available: decimal.NewFromInt(5000000),
+		}, nil)
+		depositDao.EXPECT().Capture(gomock.Any(), gomock.Any()).Return(&model.TechnicalError{
+			Exception: "something went wrong",
+			Occurred:  time.Now().Unix(),
+			Ticket:    "ERR-001",
+		})
+		sqsAdapter.EXPECT().SendMessage(q, gomock.Any()).DoAndReturn(func(q string, msg string) error {
+			v := model.CashbackReconciliation{}
+			assert.Nil(t, json.Unmarshal([]byte(msg), &v))
+			assert.Nil(t, v.Cashback)
+			assert.Equal(t, apps.DepositCapture, v.Capture.Kind.String)
+			assert.True(t, decimal.NewFromInt(950).Equal(v.Capture.Amount))
+			assert.True(t, decimal.NewFromInt(950).Equal(v.Held))
+			return nil
+		})
+		cashbackDao.EXPECT().Add(gomock.Any(), gomock.Any()).Return(nil)
+		receiptProvider.EXPECT().Generate(gomock.Any()).Return(&model.ReceiptResponse{}, nil)
+		eventProvider.EXPECT().Publish(gomock.Any()).DoAndReturn(func(e *model.NotificationEvent) *model.BusinessError {
+			wg.Done()
+			return nil
+		})
+		tid := int64(9)
+		transactionDao.EXPECT().Add(gomock.Any()).Return(&tid, nil)
+		v, ex := svc.Add(&inp)
+		assert.Nil(t, ex)
+		assert.NotNil(t, v)
+		wg.Wait()
 	})
 
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/adinandradrs/cezbek-engine/internal/apps"
 	"github.com/adinandradrs/cezbek-engine/internal/model"
 	"github.com/adinandradrs/cezbek-engine/internal/storage"
+	"sort"
 	"strings"
 	"time"
 )
@@ -51,40 +52,73 @@ func NewFactory(f Factory) Factory {
 	return f
 }
 
-func (f Factory) SendCashback(inp *model.H2HSendCashbackRequest) (*model.H2HTransactionResponse, *model.BusinessError) {
-	var factory FactoryProvider
-	w := strings.ToUpper(inp.WalletCode)
-	v, ex := f.Cacher.Hget("PROVIDER_FEE", w)
-	if ex != nil {
+func (f Factory) pricings(walletCode string) ([]model.H2HPricingProjection, *model.BusinessError) {
+	v, ex := f.Cacher.Hget("PROVIDER_FEE", strings.ToUpper(walletCode))
+	var providers []model.H2HPricingProjection
+	if ex == nil {
+		_ = json.Unmarshal([]byte(v), &providers)
+	}
+	if len(providers) == 0 {
 		return nil, &model.BusinessError{
 			ErrorCode:    apps.ErrCodeBussMerchantCodeInvalid,
 			ErrorMessage: apps.ErrMsgBussMerchantCodeInvalid,
 		}
 	}
-	var providers []model.H2HPricingProjection
-	_ = json.Unmarshal([]byte(v), &providers)
-	if providers[0].Code == apps.H2HLinksaja {
-		factory = NewLinksaja(f.Linksaja)
-	} else if providers[0].Code == apps.H2HJosvo {
-		factory = NewJosvo(f.Josvo)
-	} else if providers[0].Code == apps.H2HGpaid {
-		factory = NewGopaid(f.Gopaid)
-	} else if providers[0].Code == apps.H2HMidtrans {
-		factory = NewMiddletrans(f.Middletrans)
-	} else if providers[0].Code == apps.H2HXenit {
-		factory = NewXenit(f.Xenit)
-	} else {
-		return nil, &model.BusinessError{
-			ErrorCode:    apps.ErrCodeBadPayload,
-			ErrorMessage: apps.ErrMsgBadPayload,
-		}
+	sort.SliceStable(providers, func(i, j int) bool {
+		return providers[i].Fee.LessThan(providers[j].Fee)
+	})
+	return providers, nil
+}
+
+func (f Factory) MaxPricing(walletCode string) (*model.H2HPricingProjection, *model.BusinessError) {
+	providers, bx := f.pricings(walletCode)
+	if bx != nil {
+		return nil, bx
 	}
-	trx, bx := factory.SendCashback(inp)
+	return &providers[len(providers)-1], nil
+}
+
+func (f Factory) provider(code string) FactoryProvider {
+	switch code {
+	case apps.H2HLinksaja:
+		return NewLinksaja(f.Linksaja)
+	case apps.H2HJosvo:
+		return NewJosvo(f.Josvo)
+	case apps.H2HGpaid:
+		return NewGopaid(f.Gopaid)
+	case apps.H2HMidtrans:
+		return NewMiddletrans(f.Middletrans)
+	case apps.H2HXenit:
+		return NewXenit(f.Xenit)
+	}
+	return nil
+}
+
+func (f Factory) SendCashback(inp *model.H2HSendCashbackRequest) (*model.H2HTransactionResponse, *model.BusinessError) {
+	providers, bx := f.pricings(inp.WalletCode)
 	if bx != nil {
 		return nil, bx
 	}
-	return &model.H2HTransactionResponse{
-		TransactionResponse: *trx,
-		HostCode:            providers[0].Code,
-	}, nil
+	bx = &model.BusinessError{
+		ErrorCode:    apps.ErrCodeBadPayload,
+		ErrorMessage: apps.ErrMsgBadPayload,
+	}
+	for i, p := range providers {
+		factory := f.provider(p.Code)
+		if factory == nil {
+			continue
+		}
+		trx, pbx := factory.SendCashback(inp)
+		if pbx != nil {
+			bx = pbx
+			continue
+		}
+		return &model.H2HTransactionResponse{
+			TransactionResponse: *trx,
+			HostCode:            p.Code,
+			Fee:                 p.Fee,
+			Failover:            i > 0,
+		}, nil
+	}
+	return nil, bx
 }
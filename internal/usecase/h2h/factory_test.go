@@ -2,6 +2,7 @@ package h2h
 
 import (
 	"encoding/json"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
 	"github.com/adinandradrs/cezbek-engine/internal/model"
 	"github.com/adinandradrs/cezbek-engine/mock/adaptor"
 	"github.com/adinandradrs/cezbek-engine/mock/storage"
@@ -65,6 +66,7 @@ func TestFactory_SendCashback(t *testing.T) {
 		v, ex := svc.SendCashback(inp)
 		assert.Nil(t, ex)
 		assert.NotNil(t, v)
+		assert.True(t, decimal.NewFromInt(750).Equal(v.Fee))
 	})
 
 	t.Run("should execute JOSVO H2H", func(t *testing.T) {
@@ -155,6 +157,66 @@ func TestFactory_SendCashback(t *testing.T) {
 		assert.NotNil(t, v)
 	})
 
+	t.Run("should failover to the next cheapest H2H", func(t *testing.T) {
+		inp.WalletCode = "GOPAID"
+		providers := []model.H2HPricingProjection{
+			{
+				Code:       "XENIT",
+				Provider:   "Xenit H2H",
+				WalletCode: "GOPAID",
+				Fee:        decimal.NewFromInt(900),
+			},
+			{
+				Code:       "GOPAIDH2H",
+				Provider:   "GoPaid H2H",
+				WalletCode: "GOPAID",
+				Fee:        decimal.NewFromInt(600),
+			},
+		}
+		c, _ := json.Marshal(providers)
+		cacher.EXPECT().Hget("PROVIDER_FEE", "GOPAID").Return(string(c), nil)
+		gpadp.EXPECT().Topup(gomock.Any()).Return(nil, &model.TechnicalError{
+			Exception: "something went wrong",
+		})
+		xadp.EXPECT().WalletTopup(gomock.Any()).Return(&model.XenitWalletTopupResponse{
+			TopupRef:     "REF-001",
+			TopupTime:    "1125642689",
+			TopupMessage: "success",
+			TopupStatus:  "200",
+		}, nil)
+		v, ex := svc.SendCashback(inp)
+		assert.Nil(t, ex)
+		assert.Equal(t, "XENIT", v.HostCode)
+		assert.True(t, decimal.NewFromInt(900).Equal(v.Fee))
+		assert.True(t, v.Failover)
+	})
+
+	t.Run("should return exception on all H2H failed", func(t *testing.T) {
+		inp.WalletCode = "GOPAID"
+		providers := []model.H2HPricingProjection{
+			{
+				Code:       "GOPAIDH2H",
+				Provider:   "GoPaid H2H",
+				WalletCode: "GOPAID",
+				Fee:        decimal.NewFromInt(600),
+			},
+			{
+				Code:       "UNKNOWN",
+				Provider:   "Unknown H2H",
+				WalletCode: "GOPAID",
+				Fee:        decimal.NewFromInt(700),
+			},
+		}
+		c, _ := json.Marshal(providers)
+		cacher.EXPECT().Hget("PROVIDER_FEE", "GOPAID").Return(string(c), nil)
+		gpadp.EXPECT().Topup(gomock.Any()).Return(nil, &model.TechnicalError{
+			Exception: "something went wrong",
+		})
+		v, ex := svc.SendCashback(inp)
+		assert.Equal(t, apps.ErrCodeBussH2HCashbackFailed, ex.ErrorCode)
+		assert.Nil(t, v)
+	})
+
 	t.Run("should return invalid wallet", func(t *testing.T) {
 		inp.WalletCode = "XPAY"
 		cacher.EXPECT().Hget("PROVIDER_FEE", "XPAY").Return("", &model.TechnicalError{
@@ -167,3 +229,42 @@ func TestFactory_SendCashback(t *testing.T) {
 		assert.Nil(t, v)
 	})
 }
+
+func TestFactory_MaxPricing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cacher := storage.NewMockCacher(ctrl)
+	svc := NewFactory(Factory{
+		Cacher: cacher,
+	})
+	t.Run("should return most expensive provider", func(t *testing.T) {
+		providers := []model.H2HPricingProjection{
+			{
+				Code:       "XENIT",
+				Provider:   "Xenit H2H",
+				WalletCode: "JOSVO",
+				Fee:        decimal.NewFromInt(800),
+			},
+			{
+				Code:       "JOSVOH2H",
+				Provider:   "Josvo H2H",
+				WalletCode: "JOSVO",
+				Fee:        decimal.NewFromInt(500),
+			},
+		}
+		c, _ := json.Marshal(providers)
+		cacher.EXPECT().Hget("PROVIDER_FEE", "JOSVO").Return(string(c), nil)
+		v, ex := svc.MaxPricing("josvo")
+		assert.Nil(t, ex)
+		assert.Equal(t, "XENIT", v.Code)
+		assert.True(t, decimal.NewFromInt(800).Equal(v.Fee))
+	})
+
+	t.Run("should return invalid wallet on no provider", func(t *testing.T) {
+		cacher.EXPECT().Hget("PROVIDER_FEE", "XPAY").Return("[]", nil)
+		v, ex := svc.MaxPricing("XPAY")
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
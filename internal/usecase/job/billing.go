@@ -0,0 +1,223 @@
+package job
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"github.com/adinandradrs/cezbek-engine/internal/adaptor"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/internal/repository"
+	"github.com/adinandradrs/cezbek-engine/internal/usecase/notification"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Billing struct {
+	Dao              repository.InvoicePersister
+	S3Watcher        adaptor.S3Watcher
+	SqsAdapter       adaptor.SQSAdapter
+	TemplateProvider notification.TemplateProvider
+	Queue            *string
+	ServiceFee       decimal.Decimal
+	Tax              decimal.Decimal
+	DueDays          int
+	CDN              *string
+	PathS3           *string
+	Secret           string
+	Logger           *zap.Logger
+}
+
+type BillingWatcher interface {
+	Bill()
+}
+
+func NewBilling(b Billing) BillingWatcher {
+	return &b
+}
+
+var invoiceLabels = map[string]map[string]string{
+	apps.LocaleEnglish: {
+		"title":        "Invoice",
+		"invoiceNo":    "Invoice Number",
+		"period":       "Billing Period",
+		"issued":       "Issued Date",
+		"due":          "Due Date",
+		"transactions": "Disbursed Cashbacks",
+		"cashback":     "Cashback",
+		"reward":       "Tier Reward",
+		"providerFee":  "Provider Fee",
+		"serviceFee":   "Service Fee",
+		"tax":          "Tax",
+		"total":        "Total Due",
+		"footer":       "This invoice is generated electronically and is valid without a signature.",
+	},
+	apps.LocaleIndonesian: {
+		"title":        "Tagihan",
+		"invoiceNo":    "Nomor Tagihan",
+		"period":       "Periode Tagihan",
+		"issued":       "Tanggal Terbit",
+		"due":          "Jatuh Tempo",
+		"transactions": "Cashback Dicairkan",
+		"cashback":     "Cashback",
+		"reward":       "Hadiah Tier",
+		"providerFee":  "Biaya Penyedia",
+		"serviceFee":   "Biaya Layanan",
+		"tax":          "Pajak",
+		"total":        "Total Tagihan",
+		"footer":       "Tagihan ini dibuat secara elektronik dan sah tanpa tanda tangan.",
+	},
+}
+
+func (b *Billing) Bill() {
+	now := time.Now()
+	closed := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	periods, ex := b.Dao.Unbilled(closed)
+	if ex != nil {
+		b.Logger.Error("failed to find unbilled periods")
+		return
+	}
+	for _, period := range periods {
+		start, err := time.ParseInLocation("2006-01", period, now.Location())
+		if err != nil {
+			b.Logger.Error("invalid billing period", zap.String("period", period))
+			continue
+		}
+		b.bill(period, start, start.AddDate(0, 1, 0), now)
+	}
+}
+
+func (b *Billing) bill(period string, start time.Time, end time.Time, issued time.Time) {
+	v, ex := b.Dao.Summarize(period, start, end)
+	if ex != nil {
+		b.Logger.Error("failed to summarize billing", zap.String("period", period))
+		return
+	}
+	b.Logger.Info("billing total partner", zap.String("period", period), zap.Int("total", len(v)))
+	for _, s := range v {
+		b.invoice(period, issued, s)
+	}
+}
+
+func (b *Billing) charge(s model.BillingSummaryProjection) (decimal.Decimal, decimal.Decimal, decimal.Decimal) {
+	fee := s.Cashback.Add(s.Reward).Mul(b.ServiceFee).Round(2)
+	tax := s.ProviderFee.Add(fee).Mul(b.Tax).Round(2)
+	return fee, tax, s.Cashback.Add(s.Reward).Add(s.ProviderFee).Add(fee).Add(tax)
+}
+
+func (b *Billing) path(partnerId int64, invoiceNo string) string {
+	return *b.PathS3 + "invoice/" + strconv.FormatInt(partnerId, 10) + "/" +
+		strings.ReplaceAll(invoiceNo, "/", "-") + "-" + apps.HMAC(invoiceNo, b.Secret)[:16] + ".pdf"
+}
+
+func (b *Billing) invoice(period string, issued time.Time, s model.BillingSummaryProjection) {
+	fee, tax, total := b.charge(s)
+	no := "INV/" + s.PartnerCode + "/" + strings.ReplaceAll(period, "-", "")
+	m := model.Invoice{
+		PartnerId:    s.PartnerId,
+		InvoiceNo:    sql.NullString{String: no, Valid: true},
+		Period:       sql.NullString{String: period, Valid: true},
+		Transactions: s.Transactions,
+		Cashback:     s.Cashback,
+		Reward:       s.Reward,
+		ProviderFee:  s.ProviderFee,
+		ServiceFee:   fee,
+		Tax:          tax,
+		Total:        total,
+		Status:       sql.NullString{String: apps.InvoiceUnpaid, Valid: true},
+		Path:         sql.NullString{String: b.path(s.PartnerId, no), Valid: true},
+		DueDate:      sql.NullTime{Time: issued.AddDate(0, 0, b.DueDays), Valid: true},
+	}
+	_, ex := b.S3Watcher.Upload(&model.S3UploadRequest{
+		Destination: m.Path.String,
+		Source:      bytes.NewReader(b.render(s, m, issued)),
+		ContentType: "application/pdf",
+	})
+	if ex != nil {
+		b.Logger.Error("failed to upload invoice", zap.String("invoice_no", no))
+		return
+	}
+	if _, ex = b.Dao.Add(m); ex != nil {
+		b.Logger.Error("failed to add invoice", zap.String("invoice_no", no))
+		return
+	}
+	if bx := b.queueEmail(s, m); bx != nil {
+		b.Logger.Error("failed to queue invoice email", zap.String("invoice_no", no),
+			zap.String("email", s.Email))
+	}
+}
+
+func (b *Billing) render(s model.BillingSummaryProjection, m model.Invoice, issued time.Time) []byte {
+	labels, ok := invoiceLabels[strings.SplitN(apps.NormalizeLocale(s.Locale), "-", 2)[0]]
+	if !ok {
+		labels = invoiceLabels[apps.LocaleEnglish]
+	}
+	pdf := apps.NewPDF()
+	const left, right = 50.0, apps.PdfWidth - 50
+	y := apps.PdfHeight - 60
+	pdf.Text(left, y, 18, true, labels["title"])
+	pdf.Text(left, y-18, 11, false, s.Partner)
+	pdf.Text(left, y-32, 9, false, s.Address)
+	y -= 46
+	pdf.Line(left, y, right, y)
+
+	row := func(label string, value string, bold bool) {
+		y -= 22
+		pdf.Text(left, y, 10, bold, label)
+		pdf.Text(left+200, y, 10, bold, value)
+	}
+	row(labels["invoiceNo"], m.InvoiceNo.String, false)
+	row(labels["period"], m.Period.String, false)
+	row(labels["issued"], notification.LocalizedDate(issued, s.Locale), false)
+	row(labels["due"], notification.LocalizedDate(m.DueDate.Time, s.Locale), false)
+	row(labels["transactions"], strconv.Itoa(m.Transactions), false)
+	y -= 14
+	pdf.Line(left, y, right, y)
+	row(labels["cashback"], notification.IDR(m.Cashback), false)
+	row(labels["reward"], notification.IDR(m.Reward), false)
+	row(labels["providerFee"], notification.IDR(m.ProviderFee), false)
+	row(labels["serviceFee"], notification.IDR(m.ServiceFee), false)
+	row(labels["tax"], notification.IDR(m.Tax), false)
+	y -= 14
+	pdf.Line(left, y, right, y)
+	row(labels["total"], notification.IDR(m.Total), true)
+	pdf.Text(left, 50, 8, false, labels["footer"])
+	return pdf.Bytes()
+}
+
+func (b *Billing) queueEmail(s model.BillingSummaryProjection, m model.Invoice) *model.BusinessError {
+	v, bx := b.TemplateProvider.Render(apps.NotificationEventBilling, s.Locale, map[string]interface{}{
+		"partner":    s.Partner,
+		"invoiceNo":  m.InvoiceNo.String,
+		"period":     m.Period.String,
+		"total":      m.Total,
+		"dueDate":    m.DueDate.Time,
+		"invoiceUrl": *b.CDN + m.Path.String,
+	})
+	if bx != nil {
+		return bx
+	}
+	msg, err := json.Marshal(model.NotificationRequest{
+		Channel:     apps.NotificationChannelEmail,
+		Content:     v.Content,
+		Subject:     v.Subject,
+		Destination: s.Email,
+	})
+	if err != nil {
+		return &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSomethingWrong,
+			ErrorMessage: apps.ErrMsgSomethingWrong,
+		}
+	}
+	err = b.SqsAdapter.SendMessage(*b.Queue, string(msg))
+	if err != nil {
+		return &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSomethingWrong,
+			ErrorMessage: apps.ErrMsgSomethingWrong,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package job
+
+import (
+	"encoding/json"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/mock/adaptor"
+	"github.com/adinandradrs/cezbek-engine/mock/repository"
+	"github.com/adinandradrs/cezbek-engine/mock/usecase/notification"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBilling_Bill(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	dao, s3Watcher, sqsAdapter := repository.NewMockInvoicePersister(ctrl), adaptor.NewMockS3Watcher(ctrl),
+		adaptor.NewMockSQSAdapter(ctrl)
+	templateProvider := notification.NewMockTemplateProvider(ctrl)
+	q, cdn, path := "mock-queue", "https://cdn.kezbek.id/", "kezbek/"
+	svc := NewBilling(Billing{
+		Dao:              dao,
+		S3Watcher:        s3Watcher,
+		SqsAdapter:       sqsAdapter,
+		TemplateProvider: templateProvider,
+		Queue:            &q,
+		ServiceFee:       decimal.NewFromFloat(0.02),
+		Tax:              decimal.NewFromFloat(0.11),
+		DueDays:          14,
+		CDN:              &cdn,
+		PathS3:           &path,
+		Secret:           "secret",
+		Logger:           logger,
+	})
+	now := time.Now()
+	period := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()).AddDate(0, -1, 0).Format("2006-01")
+	summary := model.BillingSummaryProjection{
+		PartnerId:    1,
+		PartnerCode:  "LAJADA",
+		Partner:      "PT. Lajada Piranti Commerce",
+		Email:        "finance@lajada.id",
+		Locale:       "id",
+		Address:      "Jakarta",
+		Transactions: 2,
+		Cashback:     decimal.NewFromInt(20000),
+		Reward:       decimal.NewFromInt(5000),
+		ProviderFee:  decimal.NewFromInt(2000),
+	}
+
+	t.Run("should success", func(t *testing.T) {
+		dao.EXPECT().Unbilled(gomock.Any()).Return([]string{period}, nil)
+		dao.EXPECT().Summarize(period, gomock.Any(), gomock.Any()).Return(
+			[]model.BillingSummaryProjection{summary}, nil)
+		s3Watcher.EXPECT().Upload(gomock.Any()).DoAndReturn(func(r *model.S3UploadRequest) (*s3manager.UploadOutput, *model.TechnicalError) {
+			assert.True(t, strings.HasPrefix(r.Destination, "kezbek/invoice/1/INV-LAJADA-"))
+			assert.Equal(t, "application/pdf", r.ContentType)
+			return nil, nil
+		})
+		id := int64(1)
+		dao.EXPECT().Add(gomock.Any()).DoAndReturn(func(m model.Invoice) (*int64, *model.TechnicalError) {
+			assert.Equal(t, "INV/LAJADA/"+strings.ReplaceAll(period, "-", ""), m.InvoiceNo.String)
+			assert.Equal(t, apps.InvoiceUnpaid, m.Status.String)
+			assert.True(t, decimal.NewFromInt(500).Equal(m.ServiceFee))
+			assert.True(t, decimal.NewFromInt(275).Equal(m.Tax))
+			assert.True(t, decimal.NewFromInt(27775).Equal(m.Total))
+			return &id, nil
+		})
+		templateProvider.EXPECT().Render(apps.NotificationEventBilling, "id", gomock.Any()).DoAndReturn(
+			func(name string, locale string, data map[string]interface{}) (*model.NotificationContent, *model.BusinessError) {
+				assert.True(t, strings.HasPrefix(data["invoiceUrl"].(string), cdn+"kezbek/invoice/1/"))
+				return &model.NotificationContent{Subject: "Tagihan", Content: "<p>Tagihan</p>"}, nil
+			})
+		sqsAdapter.EXPECT().SendMessage(q, gomock.Any()).DoAndReturn(func(q string, msg string) error {
+			v := model.NotificationRequest{}
+			_ = json.Unmarshal([]byte(msg), &v)
+			assert.Equal(t, apps.NotificationChannelEmail, v.Channel)
+			assert.Equal(t, "finance@lajada.id", v.Destination)
+			return nil
+		})
+		svc.Bill()
+	})
+
+	t.Run("should bill every unbilled period", func(t *testing.T) {
+		dao.EXPECT().Unbilled(gomock.Any()).Return([]string{"2023-01", "2023-02"}, nil)
+		dao.EXPECT().Summarize("2023-01", time.Date(2023, 1, 1, 0, 0, 0, 0, time.Local),
+			time.Date(2023, 2, 1, 0, 0, 0, 0, time.Local)).Return(nil, nil)
+		dao.EXPECT().Summarize("2023-02", time.Date(2023, 2, 1, 0, 0, 0, 0, time.Local),
+			time.Date(2023, 3, 1, 0, 0, 0, 0, time.Local)).Return(nil, nil)
+		svc.Bill()
+	})
+
+	t.Run("should skip on failed to find unbilled periods", func(t *testing.T) {
+		dao.EXPECT().Unbilled(gomock.Any()).Return(nil, &model.TechnicalError{
+			Exception: "something went wrong",
+		})
+		svc.Bill()
+	})
+
+	t.Run("should skip on failed to summarize", func(t *testing.T) {
+		dao.EXPECT().Unbilled(gomock.Any()).Return([]string{period}, nil)
+		dao.EXPECT().Summarize(period, gomock.Any(), gomock.Any()).Return(nil, &model.TechnicalError{
+			Exception: "something went wrong",
+		})
+		svc.Bill()
+	})
+
+	t.Run("should not add invoice on failed to upload", func(t *testing.T) {
+		dao.EXPECT().Unbilled(gomock.Any()).Return([]string{period}, nil)
+		dao.EXPECT().Summarize(period, gomock.Any(), gomock.Any()).Return(
+			[]model.BillingSummaryProjection{summary}, nil)
+		s3Watcher.EXPECT().Upload(gomock.Any()).Return(nil, &model.TechnicalError{
+			Exception: "something went wrong",
+		})
+		svc.Bill()
+	})
+
+	t.Run("should not email on failed to add invoice", func(t *testing.T) {
+		dao.EXPECT().Unbilled(gomock.Any()).Return([]string{period}, nil)
+		dao.EXPECT().Summarize(period, gomock.Any(), gomock.Any()).Return(
+			[]model.BillingSummaryProjection{summary}, nil)
+		s3Watcher.EXPECT().Upload(gomock.Any()).Return(nil, nil)
+		dao.EXPECT().Add(gomock.Any()).Return(nil, &model.TechnicalError{
+			Exception: "something went wrong",
+		})
+		svc.Bill()
+	})
+
+	t.Run("should continue on failed to render email", func(t *testing.T) {
+		id := int64(1)
+		dao.EXPECT().Unbilled(gomock.Any()).Return([]string{period}, nil)
+		dao.EXPECT().Summarize(period, gomock.Any(), gomock.Any()).Return(
+			[]model.BillingSummaryProjection{summary}, nil)
+		s3Watcher.EXPECT().Upload(gomock.Any()).Return(nil, nil)
+		dao.EXPECT().Add(gomock.Any()).Return(&id, nil)
+		templateProvider.EXPECT().Render(apps.NotificationEventBilling, "id", gomock.Any()).Return(nil,
+			&model.BusinessError{ErrorCode: apps.ErrCodeNotFound})
+		svc.Bill()
+	})
+}
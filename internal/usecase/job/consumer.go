@@ -0,0 +1,168 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"github.com/adinandradrs/cezbek-engine/internal/adaptor"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"go.uber.org/zap"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	maxReceiveMessages = 10
+	maxWaitSeconds     = 20
+	defaultVisibility  = 30
+)
+
+type MessageHandler func(m model.QueueMessage) error
+
+type Consumer struct {
+	SqsAdapter        adaptor.SQSAdapter
+	Queue             string
+	MaxMessages       int64
+	WaitSeconds       int64
+	VisibilityTimeout int64
+	Workers           int
+	MaxAttempts       int64
+	DeadLetterQueue   string
+	Logger            *zap.Logger
+}
+
+type QueueConsumer interface {
+	Poll(h MessageHandler) (int, *model.TechnicalError)
+	Run(ctx context.Context, h MessageHandler)
+}
+
+func NewConsumer(c Consumer) QueueConsumer {
+	if c.MaxMessages <= 0 || c.MaxMessages > maxReceiveMessages {
+		c.MaxMessages = maxReceiveMessages
+	}
+	if c.WaitSeconds <= 0 || c.WaitSeconds > maxWaitSeconds {
+		c.WaitSeconds = maxWaitSeconds
+	}
+	if c.VisibilityTimeout <= 0 {
+		c.VisibilityTimeout = defaultVisibility
+	}
+	if c.Workers <= 0 {
+		c.Workers = int(c.MaxMessages)
+	}
+	return &c
+}
+
+func (c *Consumer) heartbeat(h string, stop <-chan struct{}) {
+	ticker := time.NewTicker(time.Duration(c.VisibilityTimeout) * time.Second / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if err := c.SqsAdapter.ChangeVisibility(c.Queue, h, c.VisibilityTimeout); err != nil {
+				c.Logger.Warn("failed to extend message visibility", zap.String("queue", c.Queue), zap.Error(err))
+			}
+		}
+	}
+}
+
+func (c *Consumer) message(m *sqs.Message) model.QueueMessage {
+	count, _ := strconv.ParseInt(aws.StringValue(m.Attributes[sqs.MessageSystemAttributeNameApproximateReceiveCount]), 10, 64)
+	return model.QueueMessage{
+		Id:           aws.StringValue(m.MessageId),
+		Queue:        c.Queue,
+		Body:         aws.StringValue(m.Body),
+		ReceiveCount: count,
+		Final:        c.DeadLetterQueue != "" && c.MaxAttempts > 0 && count >= c.MaxAttempts,
+	}
+}
+
+func (c *Consumer) process(m *sqs.Message, h MessageHandler) (err error) {
+	stop := make(chan struct{})
+	go c.heartbeat(*m.ReceiptHandle, stop)
+	defer close(stop)
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("message handler panic: %v", r)
+		}
+	}()
+	return h(c.message(m))
+}
+
+func (c *Consumer) deadLetter(m *sqs.Message) bool {
+	if !c.message(m).Final {
+		return false
+	}
+	if err := c.SqsAdapter.SendMessage(c.DeadLetterQueue, aws.StringValue(m.Body)); err != nil {
+		c.Logger.Error("failed to move message into dead-letter queue", zap.String("queue", c.Queue),
+			zap.Stringp("id", m.MessageId), zap.Error(err))
+		return false
+	}
+	c.Logger.Warn("message is moved into dead-letter queue", zap.String("queue", c.Queue),
+		zap.Stringp("id", m.MessageId))
+	return true
+}
+
+func (c *Consumer) Poll(h MessageHandler) (int, *model.TechnicalError) {
+	msgs, err := c.SqsAdapter.ReceiveMessages(c.Queue, c.MaxMessages, c.WaitSeconds, c.VisibilityTimeout)
+	if err != nil {
+		return 0, apps.Exception("failed to receive queue messages", err, zap.String("queue", c.Queue), c.Logger)
+	}
+	var (
+		wg     sync.WaitGroup
+		mtx    sync.Mutex
+		done   []string
+		failed int
+	)
+	sem := make(chan struct{}, c.Workers)
+	for _, m := range msgs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(m *sqs.Message) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := c.process(m, h); err != nil {
+				c.Logger.Error("failed to process queue message", zap.String("queue", c.Queue),
+					zap.Stringp("id", m.MessageId), zap.Error(err))
+				mtx.Lock()
+				failed++
+				mtx.Unlock()
+				if !c.deadLetter(m) {
+					return
+				}
+			}
+			mtx.Lock()
+			done = append(done, *m.ReceiptHandle)
+			mtx.Unlock()
+		}(m)
+	}
+	wg.Wait()
+	if len(done) > 0 {
+		if err = c.SqsAdapter.DeleteMessageBatch(c.Queue, done); err != nil {
+			c.Logger.Error("failed to delete queue messages", zap.String("queue", c.Queue), zap.Error(err))
+		}
+	}
+	return failed, nil
+}
+
+func (c *Consumer) Run(ctx context.Context, h MessageHandler) {
+	c.Logger.Info("queue consumer is started", zap.String("queue", c.Queue), zap.Int("workers", c.Workers))
+	for {
+		select {
+		case <-ctx.Done():
+			c.Logger.Info("queue consumer is stopped", zap.String("queue", c.Queue))
+			return
+		default:
+		}
+		if _, ex := c.Poll(h); ex != nil {
+			select {
+			case <-ctx.Done():
+			case <-time.After(time.Second):
+			}
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/mock/adaptor"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"sort"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func messages(n int) []*sqs.Message {
+	msgs := make([]*sqs.Message, n)
+	for i := range msgs {
+		msgs[i] = &sqs.Message{
+			MessageId:     aws.String("id-" + strconv.Itoa(i)),
+			ReceiptHandle: aws.String("h-" + strconv.Itoa(i)),
+			Body:          aws.String(strconv.Itoa(i)),
+		}
+	}
+	return msgs
+}
+
+func TestConsumer_Poll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	sqsAdapter, q := adaptor.NewMockSQSAdapter(ctrl), "mock-queue"
+	svc := NewConsumer(Consumer{
+		SqsAdapter: sqsAdapter,
+		Queue:      q,
+		Workers:    3,
+		Logger:     logger,
+	})
+	t.Run("should success with bounded workers and batch delete", func(t *testing.T) {
+		var running, peak int32
+		sqsAdapter.EXPECT().ReceiveMessages(q, int64(10), int64(20), int64(30)).Return(messages(10), nil)
+		sqsAdapter.EXPECT().DeleteMessageBatch(q, gomock.Any()).DoAndReturn(func(q string, hs []string) error {
+			assert.Len(t, hs, 9)
+			sort.Strings(hs)
+			assert.NotContains(t, hs, "h-4")
+			return nil
+		})
+		failed, ex := svc.Poll(func(m model.QueueMessage) error {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			if m.Body == "4" {
+				return fmt.Errorf("something went wrong")
+			}
+			return nil
+		})
+		assert.Nil(t, ex)
+		assert.Equal(t, 1, failed)
+		assert.LessOrEqual(t, atomic.LoadInt32(&peak), int32(3))
+	})
+	t.Run("should keep message on handler panic", func(t *testing.T) {
+		sqsAdapter.EXPECT().ReceiveMessages(q, int64(10), int64(20), int64(30)).Return(messages(1), nil)
+		failed, ex := svc.Poll(func(m model.QueueMessage) error {
+			panic("boom")
+		})
+		assert.Nil(t, ex)
+		assert.Equal(t, 1, failed)
+	})
+	t.Run("should return exception on receive messages", func(t *testing.T) {
+		sqsAdapter.EXPECT().ReceiveMessages(q, int64(10), int64(20), int64(30)).
+			Return(nil, fmt.Errorf("something went wrong"))
+		failed, ex := svc.Poll(func(m model.QueueMessage) error { return nil })
+		assert.NotNil(t, ex)
+		assert.Equal(t, 0, failed)
+	})
+}
+
+func TestConsumer_DeadLetter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	sqsAdapter, q, dlq := adaptor.NewMockSQSAdapter(ctrl), "mock-queue", "mock-queue-dlq"
+	svc := NewConsumer(Consumer{
+		SqsAdapter:      sqsAdapter,
+		Queue:           q,
+		MaxAttempts:     3,
+		DeadLetterQueue: dlq,
+		Logger:          logger,
+	})
+	msgs := func(count string) []*sqs.Message {
+		m := messages(1)
+		m[0].Attributes = map[string]*string{sqs.MessageSystemAttributeNameApproximateReceiveCount: aws.String(count)}
+		return m
+	}
+	t.Run("should move exhausted message into dead-letter queue", func(t *testing.T) {
+		sqsAdapter.EXPECT().ReceiveMessages(q, int64(10), int64(20), int64(30)).Return(msgs("3"), nil)
+		sqsAdapter.EXPECT().SendMessage(dlq, "0").Return(nil)
+		sqsAdapter.EXPECT().DeleteMessageBatch(q, []string{"h-0"}).Return(nil)
+		failed, ex := svc.Poll(func(m model.QueueMessage) error {
+			assert.True(t, m.Final)
+			assert.Equal(t, int64(3), m.ReceiveCount)
+			assert.Equal(t, q, m.Queue)
+			return fmt.Errorf("something went wrong")
+		})
+		assert.Nil(t, ex)
+		assert.Equal(t, 1, failed)
+	})
+	t.Run("should keep message below max attempts", func(t *testing.T) {
+		sqsAdapter.EXPECT().ReceiveMessages(q, int64(10), int64(20), int64(30)).Return(msgs("2"), nil)
+		failed, ex := svc.Poll(func(m model.QueueMessage) error {
+			assert.False(t, m.Final)
+			return fmt.Errorf("something went wrong")
+		})
+		assert.Nil(t, ex)
+		assert.Equal(t, 1, failed)
+	})
+	t.Run("should keep message on failed to move into dead-letter queue", func(t *testing.T) {
+		sqsAdapter.EXPECT().ReceiveMessages(q, int64(10), int64(20), int64(30)).Return(msgs("5"), nil)
+		sqsAdapter.EXPECT().SendMessage(dlq, "0").Return(fmt.Errorf("something went wrong"))
+		failed, ex := svc.Poll(func(m model.QueueMessage) error {
+			return fmt.Errorf("something went wrong")
+		})
+		assert.Nil(t, ex)
+		assert.Equal(t, 1, failed)
+	})
+}
+
+func TestConsumer_Heartbeat(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	sqsAdapter, q := adaptor.NewMockSQSAdapter(ctrl), "mock-queue"
+	svc := NewConsumer(Consumer{
+		SqsAdapter:        sqsAdapter,
+		Queue:             q,
+		MaxMessages:       1,
+		VisibilityTimeout: 1,
+		Logger:            logger,
+	})
+	t.Run("should extend visibility of slow message", func(t *testing.T) {
+		sqsAdapter.EXPECT().ReceiveMessages(q, int64(1), int64(20), int64(1)).Return(messages(1), nil)
+		sqsAdapter.EXPECT().ChangeVisibility(q, "h-0", int64(1)).Return(nil).MinTimes(1)
+		sqsAdapter.EXPECT().DeleteMessageBatch(q, []string{"h-0"}).Return(nil)
+		failed, ex := svc.Poll(func(m model.QueueMessage) error {
+			time.Sleep(1200 * time.Millisecond)
+			return nil
+		})
+		assert.Nil(t, ex)
+		assert.Equal(t, 0, failed)
+	})
+}
+
+func TestConsumer_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	sqsAdapter, q := adaptor.NewMockSQSAdapter(ctrl), "mock-queue"
+	svc := NewConsumer(Consumer{
+		SqsAdapter: sqsAdapter,
+		Queue:      q,
+		Logger:     logger,
+	})
+	t.Run("should complete in-flight batch on shutdown", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		sqsAdapter.EXPECT().ReceiveMessages(q, int64(10), int64(20), int64(30)).Return(messages(2), nil)
+		sqsAdapter.EXPECT().DeleteMessageBatch(q, gomock.Any()).Return(nil)
+		var handled int32
+		svc.Run(ctx, func(m model.QueueMessage) error {
+			cancel()
+			atomic.AddInt32(&handled, 1)
+			return nil
+		})
+		assert.Equal(t, int32(2), atomic.LoadInt32(&handled))
+	})
+}
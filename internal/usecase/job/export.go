@@ -0,0 +1,157 @@
+package job
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"github.com/adinandradrs/cezbek-engine/internal/adaptor"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/internal/repository"
+	"github.com/adinandradrs/cezbek-engine/internal/usecase/notification"
+	"go.uber.org/zap"
+	"io"
+	"strconv"
+	"time"
+)
+
+const contentTypeXLSX = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+var exportHeader = []string{"Kezbek Ref Code", "Wallet Code", "Email", "MSISDN", "Qty", "Transaction",
+	"Cashback", "Reward", "Transaction Date", "Provider", "Status"}
+
+type Export struct {
+	Dao              repository.TransactionPersister
+	S3Watcher        adaptor.S3Watcher
+	SqsAdapter       adaptor.SQSAdapter
+	TemplateProvider notification.TemplateProvider
+	Consumer         QueueConsumer
+	Queue            *string
+	PathS3           *string
+	LinkTTL          time.Duration
+	Logger           *zap.Logger
+}
+
+type ExportWatcher interface {
+	ConsumeTransactionExport(ctx context.Context)
+}
+
+func NewExport(e Export) ExportWatcher {
+	return &e
+}
+
+func (e *Export) write(w io.Writer, inp *model.TransactionExportRequest) error {
+	date := func(v int64) string {
+		return time.Unix(v, 0).UTC().Format("2006-01-02 15:04:05")
+	}
+	if inp.Format == apps.ExportXLSX {
+		x := apps.NewXLSX(w, "Transactions")
+		header := make([]interface{}, len(exportHeader))
+		for i, h := range exportHeader {
+			header[i] = h
+		}
+		if err := x.Row(header...); err != nil {
+			return err
+		}
+		ex := e.Dao.StreamByPartner(&inp.PartnerTransactionSearchRequest,
+			func(v model.PartnerTransactionProjection) error {
+				return x.Row(v.KezbekRefCode, v.WalletCode, v.Email, v.Msisdn, v.Qty, v.Transaction, v.Cashback,
+					v.Reward, date(v.TransactionDate), v.H2HCode, v.Status)
+			})
+		if ex != nil {
+			return errors.New(ex.Exception)
+		}
+		return x.Close()
+	}
+	c := csv.NewWriter(w)
+	if err := c.Write(exportHeader); err != nil {
+		return err
+	}
+	ex := e.Dao.StreamByPartner(&inp.PartnerTransactionSearchRequest,
+		func(v model.PartnerTransactionProjection) error {
+			return c.Write([]string{v.KezbekRefCode, v.WalletCode, v.Email, v.Msisdn, strconv.Itoa(v.Qty),
+				v.Transaction.String(), v.Cashback.String(), v.Reward.String(), date(v.TransactionDate), v.H2HCode,
+				v.Status})
+		})
+	if ex != nil {
+		return errors.New(ex.Exception)
+	}
+	c.Flush()
+	return c.Error()
+}
+
+func (e *Export) export(m model.QueueMessage) error {
+	inp := model.TransactionExportRequest{}
+	if err := json.Unmarshal([]byte(m.Body), &inp); err != nil || inp.SessionRequest.Id == 0 {
+		e.Logger.Warn("drop malformed transaction export", zap.String("id", m.Id))
+		return nil
+	}
+	ext, contentType := ".csv", "text/csv"
+	if inp.Format == apps.ExportXLSX {
+		ext, contentType = ".xlsx", contentTypeXLSX
+	}
+	path := *e.PathS3 + "export/" + strconv.FormatInt(inp.SessionRequest.Id, 10) + "/transactions-" +
+		time.Now().Format("20060102150405") + "-" + m.Id + ext
+
+	pr, pw := io.Pipe()
+	defer pr.Close()
+	go func() {
+		pw.CloseWithError(e.write(pw, &inp))
+	}()
+	_, ex := e.S3Watcher.Upload(&model.S3UploadRequest{
+		ContentType: contentType,
+		Source:      pr,
+		Destination: path,
+	})
+	if ex != nil {
+		return errors.New(ex.Exception)
+	}
+	url, ex := e.S3Watcher.Presign(path, e.LinkTTL)
+	if ex != nil {
+		return errors.New(ex.Exception)
+	}
+	if bx := e.queueEmail(&inp, *url); bx != nil {
+		return errors.New(bx.ErrorMessage)
+	}
+	e.Logger.Info("transaction export is sent", zap.Int64("partner_id", inp.SessionRequest.Id),
+		zap.String("path", path))
+	return nil
+}
+
+func (e *Export) queueEmail(inp *model.TransactionExportRequest, url string) *model.BusinessError {
+	v, bx := e.TemplateProvider.Render(apps.NotificationEventTransactionExport, inp.SessionRequest.Locale,
+		map[string]interface{}{
+			"partner": inp.SessionRequest.Fullname,
+			"format":  inp.Format,
+			"url":     url,
+			"expiry":  time.Now().Add(e.LinkTTL),
+		})
+	if bx != nil {
+		return bx
+	}
+	msg, err := json.Marshal(model.NotificationRequest{
+		Channel:     apps.NotificationChannelEmail,
+		Content:     v.Content,
+		Subject:     v.Subject,
+		Destination: inp.SessionRequest.Email,
+	})
+	if err != nil {
+		return &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSomethingWrong,
+			ErrorMessage: apps.ErrMsgSomethingWrong,
+		}
+	}
+	err = e.SqsAdapter.SendMessage(*e.Queue, string(msg))
+	if err != nil {
+		return &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSomethingWrong,
+			ErrorMessage: apps.ErrMsgSomethingWrong,
+		}
+	}
+	return nil
+}
+
+func (e *Export) ConsumeTransactionExport(ctx context.Context) {
+	e.Consumer.Run(ctx, e.export)
+}
@@ -0,0 +1,186 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/mock/adaptor"
+	"github.com/adinandradrs/cezbek-engine/mock/repository"
+	"github.com/adinandradrs/cezbek-engine/mock/usecase/notification"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+)
+
+func exportMessage(format string) []*sqs.Message {
+	b, _ := json.Marshal(model.TransactionExportRequest{
+		Format: format,
+		PartnerTransactionSearchRequest: model.PartnerTransactionSearchRequest{
+			Status: apps.TransactionDisbursed,
+			SearchRequest: model.SearchRequest{
+				SessionRequest: model.SessionRequest{
+					Id:       1,
+					Fullname: "Company A",
+					Email:    "finance@corp-a.id",
+					Locale:   "en",
+				},
+			},
+		},
+	})
+	return []*sqs.Message{{
+		MessageId:     aws.String("msg-001"),
+		ReceiptHandle: aws.String("q-handler"),
+		Body:          aws.String(string(b)),
+	}}
+}
+
+func TestExport_ConsumeTransactionExport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	sqsAdapter, q, qEmail, path := adaptor.NewMockSQSAdapter(ctrl), "mock-queue", "mock-email-queue", "kezbek/"
+	dao, s3Watcher := repository.NewMockTransactionPersister(ctrl), adaptor.NewMockS3Watcher(ctrl)
+	templateProvider := notification.NewMockTemplateProvider(ctrl)
+	svc := NewExport(Export{
+		Dao:              dao,
+		S3Watcher:        s3Watcher,
+		SqsAdapter:       sqsAdapter,
+		TemplateProvider: templateProvider,
+		Consumer: NewConsumer(Consumer{
+			SqsAdapter: sqsAdapter,
+			Queue:      q,
+			Logger:     logger,
+		}),
+		Queue:   &qEmail,
+		PathS3:  &path,
+		LinkTTL: time.Hour,
+		Logger:  logger,
+	})
+	stream := func(inp *model.PartnerTransactionSearchRequest,
+		fn func(v model.PartnerTransactionProjection) error) *model.TechnicalError {
+		assert.Equal(t, int64(1), inp.SessionRequest.Id)
+		assert.Equal(t, apps.TransactionDisbursed, inp.Status)
+		_ = fn(model.PartnerTransactionProjection{
+			KezbekRefCode:   "TRX0012345678",
+			WalletCode:      "CODE_A",
+			Msisdn:          "628118770510",
+			Qty:             2,
+			Transaction:     decimal.NewFromInt(250000),
+			Cashback:        decimal.NewFromInt(2500),
+			Reward:          decimal.NewFromInt(1000),
+			TransactionDate: 1672531200,
+			H2HCode:         "XENIT",
+			Status:          apps.TransactionDisbursed,
+		})
+		return nil
+	}
+	url := "https://bucket.s3.amazonaws.com/kezbek/export/1/transactions.csv?X-Amz-Signature=abc"
+
+	t.Run("should success to export csv", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		sqsAdapter.EXPECT().ReceiveMessages(q, int64(10), int64(20), int64(30)).Return(
+			exportMessage(apps.ExportCSV), nil)
+		dao.EXPECT().StreamByPartner(gomock.Any(), gomock.Any()).DoAndReturn(stream)
+		s3Watcher.EXPECT().Upload(gomock.Any()).DoAndReturn(func(r *model.S3UploadRequest) (*s3manager.UploadOutput, *model.TechnicalError) {
+			assert.True(t, strings.HasPrefix(r.Destination, "kezbek/export/1/transactions-"))
+			assert.True(t, strings.HasSuffix(r.Destination, "-msg-001.csv"))
+			assert.Equal(t, "text/csv", r.ContentType)
+			b, err := ioutil.ReadAll(r.Source)
+			assert.Nil(t, err)
+			assert.Equal(t, "Kezbek Ref Code,Wallet Code,Email,MSISDN,Qty,Transaction,Cashback,Reward,"+
+				"Transaction Date,Provider,Status\n"+
+				"TRX0012345678,CODE_A,,628118770510,2,250000,2500,1000,2023-01-01 00:00:00,XENIT,DISBURSED\n", string(b))
+			return nil, nil
+		})
+		s3Watcher.EXPECT().Presign(gomock.Any(), time.Hour).Return(&url, nil)
+		templateProvider.EXPECT().Render(apps.NotificationEventTransactionExport, "en", gomock.Any()).Return(
+			&model.NotificationContent{Subject: "subject", Content: "content"}, nil)
+		sqsAdapter.EXPECT().SendMessage(qEmail, gomock.Any()).DoAndReturn(func(q string, msg string) error {
+			assert.Contains(t, msg, "finance@corp-a.id")
+			return nil
+		})
+		sqsAdapter.EXPECT().DeleteMessageBatch(q, []string{"q-handler"}).DoAndReturn(func(q string, h []string) error {
+			cancel()
+			return nil
+		})
+		svc.ConsumeTransactionExport(ctx)
+	})
+
+	t.Run("should success to export xlsx", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		sqsAdapter.EXPECT().ReceiveMessages(q, int64(10), int64(20), int64(30)).Return(
+			exportMessage(apps.ExportXLSX), nil)
+		dao.EXPECT().StreamByPartner(gomock.Any(), gomock.Any()).DoAndReturn(stream)
+		s3Watcher.EXPECT().Upload(gomock.Any()).DoAndReturn(func(r *model.S3UploadRequest) (*s3manager.UploadOutput, *model.TechnicalError) {
+			assert.True(t, strings.HasSuffix(r.Destination, "-msg-001.xlsx"))
+			assert.Equal(t, contentTypeXLSX, r.ContentType)
+			b, err := ioutil.ReadAll(r.Source)
+			assert.Nil(t, err)
+			assert.Equal(t, "PK", string(b[:2]))
+			return nil, nil
+		})
+		s3Watcher.EXPECT().Presign(gomock.Any(), time.Hour).Return(&url, nil)
+		templateProvider.EXPECT().Render(apps.NotificationEventTransactionExport, "en", gomock.Any()).Return(
+			&model.NotificationContent{Subject: "subject", Content: "content"}, nil)
+		sqsAdapter.EXPECT().SendMessage(qEmail, gomock.Any()).Return(nil)
+		sqsAdapter.EXPECT().DeleteMessageBatch(q, []string{"q-handler"}).DoAndReturn(func(q string, h []string) error {
+			cancel()
+			return nil
+		})
+		svc.ConsumeTransactionExport(ctx)
+	})
+
+	t.Run("should drop malformed message", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		sqsAdapter.EXPECT().ReceiveMessages(q, int64(10), int64(20), int64(30)).Return([]*sqs.Message{{
+			MessageId:     aws.String("msg-001"),
+			ReceiptHandle: aws.String("q-handler"),
+			Body:          aws.String("not a json"),
+		}}, nil)
+		sqsAdapter.EXPECT().DeleteMessageBatch(q, []string{"q-handler"}).DoAndReturn(func(q string, h []string) error {
+			cancel()
+			return nil
+		})
+		svc.ConsumeTransactionExport(ctx)
+	})
+
+	t.Run("should keep message on failed to stream transactions", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		sqsAdapter.EXPECT().ReceiveMessages(q, int64(10), int64(20), int64(30)).Return(
+			exportMessage(apps.ExportCSV), nil)
+		dao.EXPECT().StreamByPartner(gomock.Any(), gomock.Any()).Return(&model.TechnicalError{
+			Exception: "something went wrong",
+		})
+		s3Watcher.EXPECT().Upload(gomock.Any()).DoAndReturn(func(r *model.S3UploadRequest) (*s3manager.UploadOutput, *model.TechnicalError) {
+			_, err := ioutil.ReadAll(r.Source)
+			assert.NotNil(t, err)
+			cancel()
+			return nil, &model.TechnicalError{Exception: err.Error()}
+		})
+		svc.ConsumeTransactionExport(ctx)
+	})
+
+	t.Run("should keep message on failed to presign", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		sqsAdapter.EXPECT().ReceiveMessages(q, int64(10), int64(20), int64(30)).Return(
+			exportMessage(apps.ExportCSV), nil)
+		dao.EXPECT().StreamByPartner(gomock.Any(), gomock.Any()).DoAndReturn(stream)
+		s3Watcher.EXPECT().Upload(gomock.Any()).DoAndReturn(func(r *model.S3UploadRequest) (*s3manager.UploadOutput, *model.TechnicalError) {
+			_, _ = ioutil.ReadAll(r.Source)
+			return nil, nil
+		})
+		s3Watcher.EXPECT().Presign(gomock.Any(), time.Hour).DoAndReturn(func(path string, ttl time.Duration) (*string, *model.TechnicalError) {
+			cancel()
+			return nil, &model.TechnicalError{Exception: "something went wrong"}
+		})
+		svc.ConsumeTransactionExport(ctx)
+	})
+}
@@ -0,0 +1,85 @@
+package job
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/internal/repository"
+	"go.uber.org/zap"
+)
+
+const sesPermanentBounce = "Permanent"
+
+type Feedback struct {
+	Dao      repository.SuppressionPersister
+	Consumer QueueConsumer
+	Logger   *zap.Logger
+}
+
+type FeedbackWatcher interface {
+	ConsumeEmailFeedback(ctx context.Context)
+}
+
+func NewFeedback(f Feedback) FeedbackWatcher {
+	return &f
+}
+
+func (f *Feedback) suppressions(v model.SesFeedback) []model.EmailSuppression {
+	var (
+		reason     string
+		recipients []model.SesRecipient
+	)
+	switch v.NotificationType {
+	case "Bounce":
+		if v.Bounce.BounceType != sesPermanentBounce {
+			return nil
+		}
+		reason, recipients = apps.SuppressionBounce, v.Bounce.BouncedRecipients
+	case "Complaint":
+		reason, recipients = apps.SuppressionComplaint, v.Complaint.ComplainedRecipients
+	default:
+		return nil
+	}
+	data := make([]model.EmailSuppression, 0, len(recipients))
+	for _, r := range recipients {
+		detail := r.DiagnosticCode
+		if reason == apps.SuppressionComplaint {
+			detail = v.Complaint.ComplaintFeedbackType
+		}
+		data = append(data, model.EmailSuppression{
+			Email:             sql.NullString{String: r.EmailAddress, Valid: true},
+			Reason:            sql.NullString{String: reason, Valid: true},
+			Detail:            sql.NullString{String: detail, Valid: detail != ""},
+			ProviderMessageId: sql.NullString{String: v.Mail.MessageId, Valid: true},
+		})
+	}
+	return data
+}
+
+func (f *Feedback) suppress(m model.QueueMessage) error {
+	sns := model.SnsNotification{}
+	if err := json.Unmarshal([]byte(m.Body), &sns); err != nil || sns.Message == "" {
+		f.Logger.Warn("drop malformed email feedback", zap.String("id", m.Id))
+		return nil
+	}
+	v := model.SesFeedback{}
+	if err := json.Unmarshal([]byte(sns.Message), &v); err != nil {
+		f.Logger.Warn("drop malformed email feedback", zap.String("id", m.Id), zap.Error(err))
+		return nil
+	}
+	for _, s := range f.suppressions(v) {
+		if ex := f.Dao.Save(s); ex != nil {
+			return errors.New(ex.Exception)
+		}
+		f.Logger.Info("email address is suppressed", zap.String("email", s.Email.String),
+			zap.String("reason", s.Reason.String))
+	}
+	return nil
+}
+
+func (f *Feedback) ConsumeEmailFeedback(ctx context.Context) {
+	f.Consumer.Run(ctx, f.suppress)
+}
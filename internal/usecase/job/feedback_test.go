@@ -0,0 +1,114 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/mock/adaptor"
+	"github.com/adinandradrs/cezbek-engine/mock/repository"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func feedbackMessage(v string) []*sqs.Message {
+	b, _ := json.Marshal(model.SnsNotification{Type: "Notification", Message: v})
+	return []*sqs.Message{{
+		MessageId:     aws.String("msg-001"),
+		ReceiptHandle: aws.String("q-handler"),
+		Body:          aws.String(string(b)),
+	}}
+}
+
+func TestFeedback_ConsumeEmailFeedback(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	sqsAdapter, q := adaptor.NewMockSQSAdapter(ctrl), "mock-queue"
+	dao := repository.NewMockSuppressionPersister(ctrl)
+	svc := NewFeedback(Feedback{
+		Dao: dao,
+		Consumer: NewConsumer(Consumer{
+			SqsAdapter: sqsAdapter,
+			Queue:      q,
+			Logger:     logger,
+		}),
+		Logger: logger,
+	})
+
+	t.Run("should success on permanent bounce", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		sqsAdapter.EXPECT().ReceiveMessages(q, int64(10), int64(20), int64(30)).Return(
+			feedbackMessage(`{"notificationType":"Bounce","bounce":{"bounceType":"Permanent",
+			"bouncedRecipients":[{"emailAddress":"someone@email.net","diagnosticCode":"smtp; 550 5.1.1 user unknown"}]},
+			"mail":{"messageId":"0100018a-2b3c"}}`), nil)
+		dao.EXPECT().Save(gomock.Any()).DoAndReturn(func(s model.EmailSuppression) *model.TechnicalError {
+			assert.Equal(t, "someone@email.net", s.Email.String)
+			assert.Equal(t, apps.SuppressionBounce, s.Reason.String)
+			assert.Equal(t, "smtp; 550 5.1.1 user unknown", s.Detail.String)
+			assert.Equal(t, "0100018a-2b3c", s.ProviderMessageId.String)
+			return nil
+		})
+		sqsAdapter.EXPECT().DeleteMessageBatch(q, []string{"q-handler"}).DoAndReturn(func(q string, h []string) error {
+			cancel()
+			return nil
+		})
+		svc.ConsumeEmailFeedback(ctx)
+	})
+
+	t.Run("should success on complaint", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		sqsAdapter.EXPECT().ReceiveMessages(q, int64(10), int64(20), int64(30)).Return(
+			feedbackMessage(`{"notificationType":"Complaint","complaint":{"complaintFeedbackType":"abuse",
+			"complainedRecipients":[{"emailAddress":"someone@email.net"},{"emailAddress":"other@email.net"}]},
+			"mail":{"messageId":"0100018a-2b3c"}}`), nil)
+		dao.EXPECT().Save(gomock.Any()).DoAndReturn(func(s model.EmailSuppression) *model.TechnicalError {
+			assert.Equal(t, apps.SuppressionComplaint, s.Reason.String)
+			assert.Equal(t, "abuse", s.Detail.String)
+			return nil
+		}).Times(2)
+		sqsAdapter.EXPECT().DeleteMessageBatch(q, []string{"q-handler"}).DoAndReturn(func(q string, h []string) error {
+			cancel()
+			return nil
+		})
+		svc.ConsumeEmailFeedback(ctx)
+	})
+
+	t.Run("should skip transient bounce", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		sqsAdapter.EXPECT().ReceiveMessages(q, int64(10), int64(20), int64(30)).Return(
+			feedbackMessage(`{"notificationType":"Bounce","bounce":{"bounceType":"Transient",
+			"bouncedRecipients":[{"emailAddress":"someone@email.net"}]}}`), nil)
+		sqsAdapter.EXPECT().DeleteMessageBatch(q, []string{"q-handler"}).DoAndReturn(func(q string, h []string) error {
+			cancel()
+			return nil
+		})
+		svc.ConsumeEmailFeedback(ctx)
+	})
+
+	t.Run("should drop malformed message", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		sqsAdapter.EXPECT().ReceiveMessages(q, int64(10), int64(20), int64(30)).Return(
+			feedbackMessage("not a json"), nil)
+		sqsAdapter.EXPECT().DeleteMessageBatch(q, []string{"q-handler"}).DoAndReturn(func(q string, h []string) error {
+			cancel()
+			return nil
+		})
+		svc.ConsumeEmailFeedback(ctx)
+	})
+
+	t.Run("should keep message on failed to save", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		sqsAdapter.EXPECT().ReceiveMessages(q, int64(10), int64(20), int64(30)).Return(
+			feedbackMessage(`{"notificationType":"Complaint","complaint":{
+			"complainedRecipients":[{"emailAddress":"someone@email.net"}]}}`), nil)
+		dao.EXPECT().Save(gomock.Any()).DoAndReturn(func(s model.EmailSuppression) *model.TechnicalError {
+			cancel()
+			return &model.TechnicalError{Exception: "something went wrong"}
+		})
+		svc.ConsumeEmailFeedback(ctx)
+	})
+}
@@ -0,0 +1,91 @@
+package job
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/adinandradrs/cezbek-engine/internal/adaptor"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/internal/repository"
+	"go.uber.org/zap"
+)
+
+type Notifier struct {
+	SesAdapter       adaptor.SESAdapter
+	MessagingAdapter adaptor.MessagingAdapter
+	Dao              repository.NotificationPersister
+	SuppressionDao   repository.SuppressionPersister
+	Logger           *zap.Logger
+}
+
+func (n *Notifier) suppressed(inp model.NotificationRequest) bool {
+	if inp.Channel != apps.NotificationChannelEmail {
+		return false
+	}
+	c, ex := n.SuppressionDao.CountByEmail(inp.Destination)
+	return ex == nil && *c > 0
+}
+
+func (n *Notifier) deliver(inp model.NotificationRequest) (*model.TransactionResponse, *model.TechnicalError) {
+	if inp.Channel == apps.NotificationChannelEmail {
+		return n.SesAdapter.SendEmail(model.SendEmailRequest{
+			Destination: inp.Destination,
+			Subject:     inp.Subject,
+			Content:     inp.Content,
+		})
+	}
+	return n.MessagingAdapter.Send(model.SendMessageRequest{
+		Channel:     inp.Channel,
+		Destination: inp.Destination,
+		Content:     inp.Content,
+	})
+}
+
+func (n *Notifier) send(kind string, msg model.QueueMessage) error {
+	inp := model.NotificationRequest{}
+	_ = json.Unmarshal([]byte(msg.Body), &inp)
+	if inp.Channel == "" {
+		inp.Channel = apps.NotificationChannelEmail
+	}
+	n.Logger.Info("send notification check payload", zap.Any("input", inp))
+	log := model.NotificationLog{
+		MessageId:   sql.NullString{String: msg.Id, Valid: true},
+		Queue:       sql.NullString{String: msg.Queue, Valid: true},
+		Channel:     sql.NullString{String: inp.Channel, Valid: true},
+		Destination: sql.NullString{String: inp.Destination, Valid: true},
+		Subject:     sql.NullString{String: inp.Subject, Valid: true},
+		Content:     sql.NullString{String: inp.Content, Valid: true},
+		Status:      sql.NullString{String: apps.NotificationSent, Valid: true},
+	}
+	if n.suppressed(inp) {
+		n.Logger.Warn("skip "+kind+" notification into suppressed address",
+			zap.String("destination", inp.Destination))
+		log.Status.String = apps.NotificationSuppressed
+		if lex := n.Dao.Save(log); lex != nil {
+			n.Logger.Error("failed to record notification log", zap.String("message_id", msg.Id))
+		}
+		return nil
+	}
+	tx, ex := n.deliver(inp)
+	if ex != nil {
+		n.Logger.Error("send "+kind+" notification failed", zap.String("channel", inp.Channel),
+			zap.String("destination", inp.Destination), zap.Any("ex", ex))
+		log.Status.String = apps.NotificationFailed
+		if msg.Final {
+			log.Status.String = apps.NotificationDead
+		}
+		log.Error = sql.NullString{String: ex.Exception, Valid: true}
+	} else {
+		n.Logger.Info("send "+kind+" notification success", zap.String("channel", inp.Channel),
+			zap.String("destination", inp.Destination), zap.Any("tx", tx))
+		log.ProviderMessageId = sql.NullString{String: tx.TransactionId, Valid: true}
+	}
+	if lex := n.Dao.Save(log); lex != nil {
+		n.Logger.Error("failed to record notification log", zap.String("message_id", msg.Id))
+	}
+	if ex != nil {
+		return errors.New(ex.Exception)
+	}
+	return nil
+}
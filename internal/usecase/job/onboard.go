@@ -1,44 +1,42 @@
 package job
 
 import (
-	"encoding/json"
-	"github.com/adinandradrs/cezbek-engine/internal/adaptor"
+	"context"
 	"github.com/adinandradrs/cezbek-engine/internal/apps"
 	"github.com/adinandradrs/cezbek-engine/internal/model"
 	"go.uber.org/zap"
 )
 
 type Onboard struct {
-	SesAdapter                adaptor.SESAdapter
-	SqsAdapter                adaptor.SQSAdapter
-	Logger                    *zap.Logger
-	QueueNotificationEmailOtp *string
+	Notifier Notifier
+	Consumer QueueConsumer
+	Logger   *zap.Logger
 }
 
 type OnboardWatcher interface {
 	SendOtpEmail() *model.BusinessError
+	ConsumeOtpEmail(ctx context.Context)
 }
 
 func NewOnboard(o Onboard) OnboardWatcher {
 	return &o
 }
 
+func (o *Onboard) sendOtpEmail(m model.QueueMessage) error {
+	return o.Notifier.send("OTP", m)
+}
+
 func (o *Onboard) SendOtpEmail() *model.BusinessError {
-	msg := o.SqsAdapter.GetMessages(*o.QueueNotificationEmailOtp)
-	if msg != nil {
-		inp := model.SendEmailRequest{}
-		_ = json.Unmarshal([]byte(*msg.Body), &inp)
-		o.Logger.Info("send email check payload", zap.Any("input", inp))
-		tx, ex := o.SesAdapter.SendEmail(inp)
-		if ex != nil {
-			o.Logger.Error("SES send OTP to email failed", zap.String("email", inp.Destination), zap.Any("ex", ex))
-			return &model.BusinessError{
-				ErrorCode:    apps.ErrCodeSomethingWrong,
-				ErrorMessage: apps.ErrMsgSomethingWrong,
-			}
+	failed, ex := o.Consumer.Poll(o.sendOtpEmail)
+	if ex != nil || failed > 0 {
+		return &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSomethingWrong,
+			ErrorMessage: apps.ErrMsgSomethingWrong,
 		}
-		o.SqsAdapter.DeleteMessages(*o.QueueNotificationEmailOtp, *msg.ReceiptHandle)
-		o.Logger.Info("SES send OTP to email success", zap.String("email", inp.Destination), zap.Any("tx", tx))
 	}
 	return nil
 }
+
+func (o *Onboard) ConsumeOtpEmail(ctx context.Context) {
+	o.Consumer.Run(ctx, o.sendOtpEmail)
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/adinandradrs/cezbek-engine/internal/apps"
 	"github.com/adinandradrs/cezbek-engine/internal/model"
 	"github.com/adinandradrs/cezbek-engine/mock/adaptor"
+	"github.com/adinandradrs/cezbek-engine/mock/repository"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/golang/mock/gomock"
@@ -19,11 +20,21 @@ func TestOnboard_SendOtpEmail(t *testing.T) {
 	logger, _ := apps.NewLog(false)
 	sesAdapter, sqsAdapter, q := adaptor.NewMockSESAdapter(ctrl),
 		adaptor.NewMockSQSAdapter(ctrl), "mock-queue"
+	dao, suppressionDao := repository.NewMockNotificationPersister(ctrl), repository.NewMockSuppressionPersister(ctrl)
+	unsuppressed := 0
 	svc := NewOnboard(Onboard{
-		Logger:                    logger,
-		QueueNotificationEmailOtp: &q,
-		SesAdapter:                sesAdapter,
-		SqsAdapter:                sqsAdapter,
+		Logger: logger,
+		Consumer: NewConsumer(Consumer{
+			SqsAdapter: sqsAdapter,
+			Queue:      q,
+			Logger:     logger,
+		}),
+		Notifier: Notifier{
+			SesAdapter:     sesAdapter,
+			Dao:            dao,
+			SuppressionDao: suppressionDao,
+			Logger:         logger,
+		},
 	})
 	t.Run("should success", func(t *testing.T) {
 		msg := sqs.Message{
@@ -32,13 +43,20 @@ func TestOnboard_SendOtpEmail(t *testing.T) {
 		}
 		inp := model.SendEmailRequest{}
 		_ = json.Unmarshal([]byte(*msg.Body), &inp)
-		sqsAdapter.EXPECT().GetMessages(q).Return(&msg)
+		sqsAdapter.EXPECT().ReceiveMessages(q, int64(10), int64(20), int64(30)).Return([]*sqs.Message{&msg}, nil)
+		suppressionDao.EXPECT().CountByEmail(gomock.Any()).Return(&unsuppressed, nil)
 		sesAdapter.EXPECT().SendEmail(inp).Return(
 			&model.TransactionResponse{
 				TransactionId:        "trx-001",
 				TransactionTimestamp: time.Now().Unix(),
 			}, nil)
-		sqsAdapter.EXPECT().DeleteMessages(q, gomock.Any())
+		dao.EXPECT().Save(gomock.Any()).DoAndReturn(func(m model.NotificationLog) *model.TechnicalError {
+			assert.Equal(t, apps.NotificationSent, m.Status.String)
+			assert.Equal(t, "trx-001", m.ProviderMessageId.String)
+			assert.Equal(t, q, m.Queue.String)
+			return nil
+		})
+		sqsAdapter.EXPECT().DeleteMessageBatch(q, []string{*msg.ReceiptHandle}).Return(nil)
 		ex := svc.SendOtpEmail()
 		assert.Nil(t, ex)
 	})
@@ -49,13 +67,19 @@ func TestOnboard_SendOtpEmail(t *testing.T) {
 		}
 		inp := model.SendEmailRequest{}
 		_ = json.Unmarshal([]byte(*msg.Body), &inp)
-		sqsAdapter.EXPECT().GetMessages(q).Return(&msg)
+		sqsAdapter.EXPECT().ReceiveMessages(q, int64(10), int64(20), int64(30)).Return([]*sqs.Message{&msg}, nil)
+		suppressionDao.EXPECT().CountByEmail(gomock.Any()).Return(&unsuppressed, nil)
 		sesAdapter.EXPECT().SendEmail(inp).Return(
 			nil, &model.TechnicalError{
 				Exception: "something went wrong",
 				Occurred:  time.Now().Unix(),
 				Ticket:    "err-001",
 			})
+		dao.EXPECT().Save(gomock.Any()).DoAndReturn(func(m model.NotificationLog) *model.TechnicalError {
+			assert.Equal(t, apps.NotificationFailed, m.Status.String)
+			assert.Equal(t, "something went wrong", m.Error.String)
+			return nil
+		})
 		ex := svc.SendOtpEmail()
 		assert.NotNil(t, ex)
 	})
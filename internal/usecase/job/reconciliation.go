@@ -0,0 +1,51 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/internal/repository"
+	"go.uber.org/zap"
+)
+
+type Reconciliation struct {
+	DepositDao  repository.DepositPersister
+	CashbackDao repository.CashbackPersister
+	Consumer    QueueConsumer
+	Logger      *zap.Logger
+}
+
+type ReconciliationWatcher interface {
+	ConsumeCashbackReconciliation(ctx context.Context)
+}
+
+func NewReconciliation(r Reconciliation) ReconciliationWatcher {
+	return &r
+}
+
+func (r *Reconciliation) reconcile(m model.QueueMessage) error {
+	v := model.CashbackReconciliation{}
+	if err := json.Unmarshal([]byte(m.Body), &v); err != nil || (v.Capture == nil && v.Cashback == nil) {
+		r.Logger.Warn("drop malformed cashback reconciliation", zap.String("id", m.Id))
+		return nil
+	}
+	if v.Capture != nil {
+		if ex := r.DepositDao.Capture(*v.Capture, v.Held); ex != nil {
+			return errors.New(ex.Exception)
+		}
+		r.Logger.Info("disbursed cashback deposit is captured", zap.String("reference", v.Capture.Reference.String))
+	}
+	if v.Cashback != nil {
+		if ex := r.CashbackDao.Add(*v.Cashback, v.Journal); ex != nil {
+			return errors.New(ex.Exception)
+		}
+		r.Logger.Info("disbursed cashback is added", zap.String("kezbek_ref_code", v.Cashback.KezbekRefCode.String),
+			zap.String("provider_ref_code", v.Cashback.ProviderRefCode.String))
+	}
+	return nil
+}
+
+func (r *Reconciliation) ConsumeCashbackReconciliation(ctx context.Context) {
+	r.Consumer.Run(ctx, r.reconcile)
+}
@@ -0,0 +1,126 @@
+package job
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/mock/adaptor"
+	"github.com/adinandradrs/cezbek-engine/mock/repository"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func reconciliationMessage(v interface{}) []*sqs.Message {
+	b, ok := v.(string)
+	if !ok {
+		j, _ := json.Marshal(v)
+		b = string(j)
+	}
+	return []*sqs.Message{{
+		MessageId:     aws.String("msg-001"),
+		ReceiptHandle: aws.String("q-handler"),
+		Body:          aws.String(b),
+	}}
+}
+
+func TestReconciliation_ConsumeCashbackReconciliation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	sqsAdapter, q := adaptor.NewMockSQSAdapter(ctrl), "mock-queue"
+	depositDao, cashbackDao := repository.NewMockDepositPersister(ctrl), repository.NewMockCashbackPersister(ctrl)
+	svc := NewReconciliation(Reconciliation{
+		DepositDao:  depositDao,
+		CashbackDao: cashbackDao,
+		Consumer: NewConsumer(Consumer{
+			SqsAdapter: sqsAdapter,
+			Queue:      q,
+			Logger:     logger,
+		}),
+		Logger: logger,
+	})
+
+	t.Run("should success to capture deposit", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		sqsAdapter.EXPECT().ReceiveMessages(q, int64(10), int64(20), int64(30)).Return(
+			reconciliationMessage(model.CashbackReconciliation{
+				Capture: &model.DepositMovement{
+					PartnerId: 1,
+					Kind:      sql.NullString{String: apps.DepositCapture, Valid: true},
+					Amount:    decimal.NewFromInt(950),
+					Reference: sql.NullString{String: "C00212345", Valid: true},
+				},
+				Held: decimal.NewFromInt(950),
+			}), nil)
+		depositDao.EXPECT().Capture(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(m model.DepositMovement, held decimal.Decimal) *model.TechnicalError {
+				assert.Equal(t, apps.DepositCapture, m.Kind.String)
+				assert.Equal(t, "C00212345", m.Reference.String)
+				assert.True(t, decimal.NewFromInt(950).Equal(m.Amount))
+				assert.True(t, decimal.NewFromInt(950).Equal(held))
+				return nil
+			})
+		sqsAdapter.EXPECT().DeleteMessageBatch(q, []string{"q-handler"}).DoAndReturn(func(q string, h []string) error {
+			cancel()
+			return nil
+		})
+		svc.ConsumeCashbackReconciliation(ctx)
+	})
+
+	t.Run("should success to add cashback", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		sqsAdapter.EXPECT().ReceiveMessages(q, int64(10), int64(20), int64(30)).Return(
+			reconciliationMessage(model.CashbackReconciliation{
+				Cashback: &model.Cashback{
+					KezbekRefCode:   sql.NullString{String: "C00212345", Valid: true},
+					ProviderRefCode: sql.NullString{String: "trx-001"},
+				},
+				Journal: model.LedgerJournal{
+					Kind: sql.NullString{String: apps.LedgerJournalCashback, Valid: true},
+				},
+			}), nil)
+		cashbackDao.EXPECT().Add(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(m model.Cashback, j model.LedgerJournal) *model.TechnicalError {
+				assert.Equal(t, "trx-001", m.ProviderRefCode.String)
+				assert.Equal(t, apps.LedgerJournalCashback, j.Kind.String)
+				return nil
+			})
+		sqsAdapter.EXPECT().DeleteMessageBatch(q, []string{"q-handler"}).DoAndReturn(func(q string, h []string) error {
+			cancel()
+			return nil
+		})
+		svc.ConsumeCashbackReconciliation(ctx)
+	})
+
+	t.Run("should drop malformed message", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		sqsAdapter.EXPECT().ReceiveMessages(q, int64(10), int64(20), int64(30)).Return(
+			reconciliationMessage("not a json"), nil)
+		sqsAdapter.EXPECT().DeleteMessageBatch(q, []string{"q-handler"}).DoAndReturn(func(q string, h []string) error {
+			cancel()
+			return nil
+		})
+		svc.ConsumeCashbackReconciliation(ctx)
+	})
+
+	t.Run("should keep message on failed to capture", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		sqsAdapter.EXPECT().ReceiveMessages(q, int64(10), int64(20), int64(30)).Return(
+			reconciliationMessage(model.CashbackReconciliation{
+				Capture: &model.DepositMovement{PartnerId: 1, Amount: decimal.NewFromInt(950)},
+				Held:    decimal.NewFromInt(950),
+			}), nil)
+		depositDao.EXPECT().Capture(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(m model.DepositMovement, held decimal.Decimal) *model.TechnicalError {
+				cancel()
+				return &model.TechnicalError{Exception: "something went wrong"}
+			})
+		svc.ConsumeCashbackReconciliation(ctx)
+	})
+}
@@ -0,0 +1,70 @@
+package job
+
+import (
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/internal/repository"
+	"go.uber.org/zap"
+	"time"
+)
+
+const snapshotDateLayout = "2006-01-02"
+
+type Snapshot struct {
+	Dao      repository.SnapshotPersister
+	Lookback time.Duration
+	Logger   *zap.Logger
+}
+
+type SnapshotWatcher interface {
+	Build()
+	Rebuild(start string, end string) *model.BusinessError
+}
+
+func NewSnapshot(s Snapshot) SnapshotWatcher {
+	return &s
+}
+
+func (s *Snapshot) rebuild(date string) bool {
+	v, ex := s.Dao.Rebuild(date)
+	if ex != nil {
+		s.Logger.Error("failed to rebuild snapshot", zap.String("date", date))
+		return false
+	}
+	s.Logger.Info("snapshot is rebuilt", zap.String("date", date), zap.Int64("rows", *v))
+	return true
+}
+
+func (s *Snapshot) Build() {
+	days, ex := s.Dao.Stale(time.Now().Add(-s.Lookback))
+	if ex != nil {
+		s.Logger.Error("failed to find stale snapshot days")
+		return
+	}
+	s.Logger.Info("snapshot stale days", zap.Int("total", len(days)))
+	for _, d := range days {
+		s.rebuild(d)
+	}
+}
+
+func (s *Snapshot) Rebuild(start string, end string) *model.BusinessError {
+	from, ferr := time.Parse(snapshotDateLayout, start)
+	to, terr := time.Parse(snapshotDateLayout, end)
+	if ferr != nil || terr != nil || to.Before(from) {
+		return &model.BusinessError{
+			ErrorCode:    apps.ErrCodeBadPayload,
+			ErrorMessage: apps.ErrMsgBadPayload,
+		}
+	}
+	ok := true
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		ok = s.rebuild(d.Format(snapshotDateLayout)) && ok
+	}
+	if !ok {
+		return &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSomethingWrong,
+			ErrorMessage: apps.ErrMsgSomethingWrong,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package job
+
+import (
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/mock/repository"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSnapshot_Build(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	dao := repository.NewMockSnapshotPersister(ctrl)
+	svc := NewSnapshot(Snapshot{
+		Dao:      dao,
+		Lookback: 72 * time.Hour,
+		Logger:   logger,
+	})
+	rows := int64(4)
+
+	t.Run("should success to rebuild stale days", func(t *testing.T) {
+		dao.EXPECT().Stale(gomock.Any()).DoAndReturn(func(since time.Time) ([]string, *model.TechnicalError) {
+			assert.WithinDuration(t, time.Now().Add(-72*time.Hour), since, time.Minute)
+			return []string{"2023-01-02", "2023-01-05"}, nil
+		})
+		dao.EXPECT().Rebuild("2023-01-02").Return(nil, &model.TechnicalError{Exception: "something went wrong"})
+		dao.EXPECT().Rebuild("2023-01-05").Return(&rows, nil)
+		svc.Build()
+	})
+
+	t.Run("should skip on failed to find stale days", func(t *testing.T) {
+		dao.EXPECT().Stale(gomock.Any()).Return(nil, &model.TechnicalError{Exception: "something went wrong"})
+		svc.Build()
+	})
+}
+
+func TestSnapshot_Rebuild(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	dao := repository.NewMockSnapshotPersister(ctrl)
+	svc := NewSnapshot(Snapshot{
+		Dao:    dao,
+		Logger: logger,
+	})
+	rows := int64(4)
+
+	t.Run("should success to rebuild every day of the range", func(t *testing.T) {
+		dao.EXPECT().Rebuild("2023-01-30").Return(&rows, nil)
+		dao.EXPECT().Rebuild("2023-01-31").Return(&rows, nil)
+		dao.EXPECT().Rebuild("2023-02-01").Return(&rows, nil)
+		bx := svc.Rebuild("2023-01-30", "2023-02-01")
+		assert.Nil(t, bx)
+	})
+
+	t.Run("should return exception on failed day after the rest are rebuilt", func(t *testing.T) {
+		dao.EXPECT().Rebuild("2023-01-30").Return(nil, &model.TechnicalError{Exception: "something went wrong"})
+		dao.EXPECT().Rebuild("2023-01-31").Return(&rows, nil)
+		bx := svc.Rebuild("2023-01-30", "2023-01-31")
+		assert.Equal(t, apps.ErrCodeSomethingWrong, bx.ErrorCode)
+	})
+
+	t.Run("should return exception on invalid range", func(t *testing.T) {
+		bx := svc.Rebuild("2023-02-01", "2023-01-31")
+		assert.Equal(t, apps.ErrCodeBadPayload, bx.ErrorCode)
+		bx = svc.Rebuild("01-02-2023", "2023-01-31")
+		assert.Equal(t, apps.ErrCodeBadPayload, bx.ErrorCode)
+	})
+}
@@ -1,15 +1,23 @@
 package job
 
 import (
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
 	"github.com/adinandradrs/cezbek-engine/internal/repository"
+	"github.com/adinandradrs/cezbek-engine/internal/storage"
+	"github.com/adinandradrs/cezbek-engine/internal/usecase/notification"
+	"github.com/adinandradrs/cezbek-engine/internal/usecase/workflow"
+	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
 	"time"
 )
 
 type Tier struct {
-	Dao     repository.TierPersister
-	Expired *time.Duration
-	Logger  *zap.Logger
+	Dao           repository.TierPersister
+	Cacher        storage.Cacher
+	EventProvider notification.EventProvider
+	Expired       *time.Duration
+	Logger        *zap.Logger
 }
 
 type TierWatcher interface {
@@ -27,11 +35,25 @@ func (t *Tier) Expire() {
 	} else if ex == nil && *count > 0 {
 		exp := time.Now().Add(*t.Expired)
 		t.Logger.Info("expired tiers total data", zap.Int("total", *count), zap.Time("next", exp))
-		ex = t.Dao.Expire(exp)
+		v, ex := t.Dao.Expire(exp)
 		if ex != nil {
 			t.Logger.Error("failed to expire tier data")
 		}
+		t.notifyDowngrade(v)
 	} else {
 		t.Logger.Info("expired tiers total data", zap.Int("total", *count))
 	}
 }
+
+func (t *Tier) notifyDowngrade(v []model.TierChangeProjection) {
+	for _, d := range v {
+		if d.CurrentGrade >= d.PrevGrade {
+			continue
+		}
+		bx := t.EventProvider.Publish(workflow.TierEvent(t.Cacher, apps.NotificationEventTierDowngraded, d,
+			decimal.Zero))
+		if bx != nil {
+			t.Logger.Error("failed to notify tier downgrade", zap.String("msisdn", d.Msisdn))
+		}
+	}
+}
@@ -2,8 +2,12 @@ package job
 
 import (
 	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
 	"github.com/adinandradrs/cezbek-engine/mock/repository"
+	"github.com/adinandradrs/cezbek-engine/mock/storage"
+	"github.com/adinandradrs/cezbek-engine/mock/usecase/notification"
 	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"testing"
 	"time"
@@ -13,21 +17,57 @@ func TestTier_Expire(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	logger, _ := apps.NewLog(false)
-	dao := repository.NewMockTierPersister(ctrl)
+	dao, cacher, eventProvider := repository.NewMockTierPersister(ctrl), storage.NewMockCacher(ctrl),
+		notification.NewMockEventProvider(ctrl)
 	exp, _ := time.ParseDuration("1h")
 	svc := NewTier(Tier{
-		Expired: &exp,
-		Dao:     dao,
-		Logger:  logger,
+		Expired:       &exp,
+		Dao:           dao,
+		Cacher:        cacher,
+		EventProvider: eventProvider,
+		Logger:        logger,
 	})
 	t.Run("should success", func(t *testing.T) {
 		cdata := 5
 		dao.EXPECT().CountExpire().Return(&cdata, nil)
-		dao.EXPECT().Expire(gomock.Any()).Return(nil)
+		dao.EXPECT().Expire(gomock.Any()).Return(nil, nil)
 		svc.Expire()
 		assert.Equal(t, 5, cdata)
 	})
 
+	t.Run("should success to notify downgraded customers only", func(t *testing.T) {
+		cdata := 2
+		dao.EXPECT().CountExpire().Return(&cdata, nil)
+		dao.EXPECT().Expire(gomock.Any()).Return([]model.TierChangeProjection{
+			{
+				PartnerCode:  "LSAJA",
+				Msisdn:       "628118770510",
+				Locale:       "id",
+				CurrentGrade: 1,
+				CurrentTier:  "BRONZE",
+				PrevGrade:    2,
+				PrevTier:     "SILVER",
+			},
+			{
+				Msisdn:       "628118770511",
+				CurrentGrade: 1,
+				CurrentTier:  "BRONZE",
+				PrevGrade:    1,
+				PrevTier:     "BRONZE",
+			},
+		}, nil)
+		cacher.EXPECT().Get("WFREWARD:BRONZE", "1").Return("", &model.TechnicalError{Exception: "not found"})
+		eventProvider.EXPECT().Publish(gomock.Any()).DoAndReturn(func(e *model.NotificationEvent) *model.BusinessError {
+			assert.Equal(t, apps.NotificationEventTierDowngraded, e.Event)
+			assert.Equal(t, "LSAJA", e.Partner)
+			assert.Equal(t, "628118770510", e.Msisdn)
+			assert.Equal(t, "BRONZE", e.Data["tier"])
+			assert.Equal(t, decimal.Zero, e.Data["reward"])
+			return nil
+		})
+		svc.Expire()
+	})
+
 	t.Run("should no execute expire tier", func(t *testing.T) {
 		cdata := 0
 		dao.EXPECT().CountExpire().Return(&cdata, nil)
@@ -4,6 +4,7 @@ import (
 	"github.com/adinandradrs/cezbek-engine/internal/apps"
 	"github.com/adinandradrs/cezbek-engine/internal/model"
 	"github.com/adinandradrs/cezbek-engine/mock/adaptor"
+	"github.com/adinandradrs/cezbek-engine/mock/repository"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -18,28 +19,82 @@ func TestTransaction_SendInvoiceEmail(t *testing.T) {
 	sesAdapter, sqsAdapter := adaptor.NewMockSESAdapter(ctrl),
 		adaptor.NewMockSQSAdapter(ctrl)
 	q := "mock-queue"
+	dao, suppressionDao := repository.NewMockNotificationPersister(ctrl), repository.NewMockSuppressionPersister(ctrl)
+	unsuppressed := 0
+	messagingAdapter := adaptor.NewMockMessagingAdapter(ctrl)
 	svc := NewTransaction(Transaction{
-		Logger:                    logger,
-		SqsAdapter:                sqsAdapter,
-		SesAdapter:                sesAdapter,
-		QueueNotificationEmailTrx: &q,
+		Logger: logger,
+		Consumer: NewConsumer(Consumer{
+			SqsAdapter: sqsAdapter,
+			Queue:      q,
+			Logger:     logger,
+		}),
+		Notifier: Notifier{
+			SesAdapter:       sesAdapter,
+			MessagingAdapter: messagingAdapter,
+			Dao:              dao,
+			SuppressionDao:   suppressionDao,
+			Logger:           logger,
+		},
 	})
 
 	t.Run("should success", func(t *testing.T) {
 		b := "html content - bla bla"
 		h := "q-handler"
-		sqsAdapter.EXPECT().GetMessages(q).Return(&sqs.Message{
+		sqsAdapter.EXPECT().ReceiveMessages(q, int64(10), int64(20), int64(30)).Return([]*sqs.Message{{
 			Body:          &b,
 			ReceiptHandle: &h,
+		}}, nil)
+		suppressionDao.EXPECT().CountByEmail(gomock.Any()).Return(&unsuppressed, nil)
+		sesAdapter.EXPECT().SendEmail(gomock.Any()).Return(&model.TransactionResponse{TransactionId: "ses-001"}, nil)
+		dao.EXPECT().Save(gomock.Any()).Return(nil)
+		sqsAdapter.EXPECT().DeleteMessageBatch(q, []string{h}).Return(nil)
+		ex := svc.SendInvoiceEmail()
+		assert.Nil(t, ex)
+	})
+
+	t.Run("should success on sms channel", func(t *testing.T) {
+		b := `{"Channel":"SMS","Destination":"628118770510","Content":"text content"}`
+		h := "q-handler-sms"
+		sqsAdapter.EXPECT().ReceiveMessages(q, int64(10), int64(20), int64(30)).Return([]*sqs.Message{{
+			Body:          &b,
+			ReceiptHandle: &h,
+		}}, nil)
+		messagingAdapter.EXPECT().Send(model.SendMessageRequest{
+			Channel:     apps.NotificationChannelSms,
+			Destination: "628118770510",
+			Content:     "text content",
+		}).Return(&model.TransactionResponse{TransactionId: "sms-001"}, nil)
+		dao.EXPECT().Save(gomock.Any()).DoAndReturn(func(m model.NotificationLog) *model.TechnicalError {
+			assert.Equal(t, apps.NotificationChannelSms, m.Channel.String)
+			assert.Equal(t, "sms-001", m.ProviderMessageId.String)
+			return nil
+		})
+		sqsAdapter.EXPECT().DeleteMessageBatch(q, []string{h}).Return(nil)
+		ex := svc.SendInvoiceEmail()
+		assert.Nil(t, ex)
+	})
+
+	t.Run("should skip suppressed email address", func(t *testing.T) {
+		b := `{"Destination":"someone@email.net","Subject":"Invoice","Content":"html content"}`
+		h := "q-handler-suppressed"
+		suppressed := 1
+		sqsAdapter.EXPECT().ReceiveMessages(q, int64(10), int64(20), int64(30)).Return([]*sqs.Message{{
+			Body:          &b,
+			ReceiptHandle: &h,
+		}}, nil)
+		suppressionDao.EXPECT().CountByEmail("someone@email.net").Return(&suppressed, nil)
+		dao.EXPECT().Save(gomock.Any()).DoAndReturn(func(m model.NotificationLog) *model.TechnicalError {
+			assert.Equal(t, apps.NotificationSuppressed, m.Status.String)
+			return nil
 		})
-		sesAdapter.EXPECT().SendEmail(gomock.Any()).Return(nil, nil)
-		sqsAdapter.EXPECT().DeleteMessages(q, h)
+		sqsAdapter.EXPECT().DeleteMessageBatch(q, []string{h}).Return(nil)
 		ex := svc.SendInvoiceEmail()
 		assert.Nil(t, ex)
 	})
 
 	t.Run("should skip when no message in queue", func(t *testing.T) {
-		sqsAdapter.EXPECT().GetMessages(q).Return(nil)
+		sqsAdapter.EXPECT().ReceiveMessages(q, int64(10), int64(20), int64(30)).Return(nil, nil)
 		ex := svc.SendInvoiceEmail()
 		assert.Nil(t, ex)
 	})
@@ -47,15 +102,17 @@ func TestTransaction_SendInvoiceEmail(t *testing.T) {
 	t.Run("should return exception when failed to send email", func(t *testing.T) {
 		b := "html content - bla bla"
 		h := "q-handler"
-		sqsAdapter.EXPECT().GetMessages(q).Return(&sqs.Message{
+		sqsAdapter.EXPECT().ReceiveMessages(q, int64(10), int64(20), int64(30)).Return([]*sqs.Message{{
 			Body:          &b,
 			ReceiptHandle: &h,
-		})
+		}}, nil)
+		suppressionDao.EXPECT().CountByEmail(gomock.Any()).Return(&unsuppressed, nil)
 		sesAdapter.EXPECT().SendEmail(gomock.Any()).Return(nil, &model.TechnicalError{
 			Exception: "something went wrong",
 			Occurred:  time.Now().Unix(),
 			Ticket:    "ERR-001",
 		})
+		dao.EXPECT().Save(gomock.Any()).Return(&model.TechnicalError{Exception: "something went wrong"})
 		ex := svc.SendInvoiceEmail()
 		assert.NotNil(t, ex)
 	})
@@ -0,0 +1,81 @@
+package management
+
+import (
+	"database/sql"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/internal/repository"
+	"go.uber.org/zap"
+	"strconv"
+	"time"
+)
+
+type Deposit struct {
+	Dao        repository.DepositPersister
+	PartnerDao repository.PartnerPersister
+	Logger     *zap.Logger
+}
+
+type DepositManager interface {
+	TopUp(inp *model.DepositTopUpRequest) (*model.TransactionResponse, *model.BusinessError)
+	Find(code string) (*model.DepositResponse, *model.BusinessError)
+}
+
+func NewDeposit(d Deposit) DepositManager {
+	return &d
+}
+
+func (d *Deposit) TopUp(inp *model.DepositTopUpRequest) (*model.TransactionResponse, *model.BusinessError) {
+	if !inp.Amount.IsPositive() {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeBussTopUpInvalid,
+			ErrorMessage: apps.ErrMsgBussTopUpInvalid,
+		}
+	}
+	p, ex := d.PartnerDao.FindActiveByCode(inp.Code)
+	if ex != nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeNotFound,
+			ErrorMessage: apps.ErrMsgNotFound,
+		}
+	}
+	ex = d.Dao.TopUp(model.DepositMovement{
+		PartnerId: p.Id,
+		Kind:      sql.NullString{String: apps.DepositTopUp, Valid: true},
+		Amount:    inp.Amount,
+		Reference: sql.NullString{String: inp.Reference, Valid: true},
+		BaseEntity: model.BaseEntity{
+			CreatedBy: sql.NullInt64{Int64: inp.SessionRequest.Id, Valid: true},
+		},
+	}, inp.Threshold)
+	if ex != nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSubmitted,
+			ErrorMessage: apps.ErrMsgSubmitted,
+		}
+	}
+	d.Logger.Info("deposit topped up", zap.String("code", inp.Code), zap.String("amount", inp.Amount.String()))
+	return &model.TransactionResponse{
+		TransactionId:        apps.TransactionId(strconv.FormatInt(p.Id, 10) + apps.DefaultTrxId),
+		TransactionTimestamp: time.Now().Unix(),
+	}, nil
+}
+
+func (d *Deposit) Find(code string) (*model.DepositResponse, *model.BusinessError) {
+	p, ex := d.PartnerDao.FindActiveByCode(code)
+	if ex != nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeNotFound,
+			ErrorMessage: apps.ErrMsgNotFound,
+		}
+	}
+	v, ex := d.Dao.FindByPartner(p.Id)
+	if ex != nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeNotFound,
+			ErrorMessage: apps.ErrMsgNotFound,
+		}
+	}
+	r := model.Deposit(*v)
+	return &r, nil
+}
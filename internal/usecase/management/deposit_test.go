@@ -0,0 +1,112 @@
+package management
+
+import (
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/mock/repository"
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestDeposit_TopUp(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	dao, partnerDao := repository.NewMockDepositPersister(ctrl), repository.NewMockPartnerPersister(ctrl)
+	svc := NewDeposit(Deposit{
+		Dao:        dao,
+		PartnerDao: partnerDao,
+		Logger:     logger,
+	})
+	inp := &model.DepositTopUpRequest{
+		Code:      "LAJADA",
+		Amount:    decimal.NewFromInt(5000000),
+		Threshold: decimal.NullDecimal{Decimal: decimal.NewFromInt(1000000), Valid: true},
+		Reference: "TRF/2023/01/0001",
+		SessionRequest: model.SessionRequest{
+			Id: 1,
+		},
+	}
+	t.Run("should success", func(t *testing.T) {
+		partnerDao.EXPECT().FindActiveByCode("LAJADA").Return(&model.Partner{Id: 2}, nil)
+		dao.EXPECT().TopUp(gomock.Any(), inp.Threshold).Do(func(m model.DepositMovement, _ decimal.NullDecimal) {
+			assert.Equal(t, int64(2), m.PartnerId)
+			assert.Equal(t, apps.DepositTopUp, m.Kind.String)
+			assert.Equal(t, "TRF/2023/01/0001", m.Reference.String)
+		}).Return(nil)
+		v, bx := svc.TopUp(inp)
+		assert.Nil(t, bx)
+		assert.NotNil(t, v)
+	})
+
+	t.Run("should return exception on amount is not positive", func(t *testing.T) {
+		req := *inp
+		req.Amount = decimal.Zero
+		v, bx := svc.TopUp(&req)
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeBussTopUpInvalid, bx.ErrorCode)
+	})
+
+	t.Run("should return exception on partner not found", func(t *testing.T) {
+		partnerDao.EXPECT().FindActiveByCode("LAJADA").Return(nil, &model.TechnicalError{
+			Exception: "something went wrong",
+			Occurred:  time.Now().Unix(),
+			Ticket:    "ERR-001",
+		})
+		v, bx := svc.TopUp(inp)
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeNotFound, bx.ErrorCode)
+	})
+
+	t.Run("should return exception on failed to top up", func(t *testing.T) {
+		partnerDao.EXPECT().FindActiveByCode("LAJADA").Return(&model.Partner{Id: 2}, nil)
+		dao.EXPECT().TopUp(gomock.Any(), inp.Threshold).Return(&model.TechnicalError{
+			Exception: "something went wrong",
+			Occurred:  time.Now().Unix(),
+			Ticket:    "ERR-001",
+		})
+		v, bx := svc.TopUp(inp)
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeSubmitted, bx.ErrorCode)
+	})
+}
+
+func TestDeposit_Find(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	dao, partnerDao := repository.NewMockDepositPersister(ctrl), repository.NewMockPartnerPersister(ctrl)
+	svc := NewDeposit(Deposit{
+		Dao:        dao,
+		PartnerDao: partnerDao,
+		Logger:     logger,
+	})
+	t.Run("should success", func(t *testing.T) {
+		partnerDao.EXPECT().FindActiveByCode("LAJADA").Return(&model.Partner{Id: 2}, nil)
+		dao.EXPECT().FindByPartner(int64(2)).Return(&model.DepositProjection{
+			Code:      "LAJADA",
+			Balance:   decimal.NewFromInt(1000000),
+			Held:      decimal.NewFromInt(16000),
+			Available: decimal.NewFromInt(984000),
+			Threshold: decimal.NewFromInt(1000000),
+		}, nil)
+		v, bx := svc.Find("LAJADA")
+		assert.Nil(t, bx)
+		assert.True(t, v.LowBalance)
+	})
+
+	t.Run("should return exception on deposit not found", func(t *testing.T) {
+		partnerDao.EXPECT().FindActiveByCode("LAJADA").Return(&model.Partner{Id: 2}, nil)
+		dao.EXPECT().FindByPartner(int64(2)).Return(nil, &model.TechnicalError{
+			Exception: "something went wrong",
+			Occurred:  time.Now().Unix(),
+			Ticket:    "ERR-001",
+		})
+		v, bx := svc.Find("LAJADA")
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeNotFound, bx.ErrorCode)
+	})
+}
@@ -0,0 +1,52 @@
+package management
+
+import (
+	"database/sql"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/internal/repository"
+	"go.uber.org/zap"
+	"strconv"
+	"time"
+)
+
+type Invoice struct {
+	Dao    repository.InvoicePersister
+	Logger *zap.Logger
+}
+
+type InvoiceManager interface {
+	Pay(inp *model.InvoicePaymentRequest) (*model.TransactionResponse, *model.BusinessError)
+}
+
+func NewInvoice(i Invoice) InvoiceManager {
+	return &i
+}
+
+func (i *Invoice) Pay(inp *model.InvoicePaymentRequest) (*model.TransactionResponse, *model.BusinessError) {
+	v, ex := i.Dao.FindById(inp.Id)
+	if ex != nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeNotFound,
+			ErrorMessage: apps.ErrMsgNotFound,
+		}
+	}
+	if v.Status.String == apps.InvoicePaid {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeBussInvoicePaid,
+			ErrorMessage: apps.ErrMsgBussInvoicePaid,
+		}
+	}
+	v.PaymentReference = sql.NullString{String: inp.Reference, Valid: true}
+	v.UpdatedBy = sql.NullInt64{Int64: inp.SessionRequest.Id, Valid: true}
+	if ex = i.Dao.Pay(*v); ex != nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSubmitted,
+			ErrorMessage: apps.ErrMsgSubmitted,
+		}
+	}
+	return &model.TransactionResponse{
+		TransactionId:        apps.TransactionId(strconv.FormatInt(v.PartnerId, 10) + apps.DefaultTrxId),
+		TransactionTimestamp: time.Now().Unix(),
+	}, nil
+}
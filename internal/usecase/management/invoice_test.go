@@ -0,0 +1,71 @@
+package management
+
+import (
+	"database/sql"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/mock/repository"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestInvoice_Pay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	dao := repository.NewMockInvoicePersister(ctrl)
+	svc := NewInvoice(Invoice{
+		Dao:    dao,
+		Logger: logger,
+	})
+	inp := &model.InvoicePaymentRequest{
+		Id:        1,
+		Reference: "TRF/2023/02/0001",
+	}
+	invoice := func(status string) *model.Invoice {
+		return &model.Invoice{
+			Id:        1,
+			PartnerId: 1,
+			Status:    sql.NullString{String: status, Valid: true},
+		}
+	}
+	ex := &model.TechnicalError{
+		Exception: "something went wrong",
+		Occurred:  time.Now().Unix(),
+		Ticket:    "ERR-001",
+	}
+	t.Run("should success", func(t *testing.T) {
+		dao.EXPECT().FindById(int64(1)).Return(invoice(apps.InvoiceUnpaid), nil)
+		dao.EXPECT().Pay(gomock.Any()).DoAndReturn(func(m model.Invoice) *model.TechnicalError {
+			assert.Equal(t, "TRF/2023/02/0001", m.PaymentReference.String)
+			return nil
+		})
+		v, bx := svc.Pay(inp)
+		assert.Nil(t, bx)
+		assert.NotNil(t, v)
+	})
+
+	t.Run("should return exception on invoice not found", func(t *testing.T) {
+		dao.EXPECT().FindById(int64(1)).Return(nil, ex)
+		v, bx := svc.Pay(inp)
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeNotFound, bx.ErrorCode)
+	})
+
+	t.Run("should return exception on paid invoice", func(t *testing.T) {
+		dao.EXPECT().FindById(int64(1)).Return(invoice(apps.InvoicePaid), nil)
+		v, bx := svc.Pay(inp)
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeBussInvoicePaid, bx.ErrorCode)
+	})
+
+	t.Run("should return exception on failed to pay", func(t *testing.T) {
+		dao.EXPECT().FindById(int64(1)).Return(invoice(apps.InvoiceUnpaid), nil)
+		dao.EXPECT().Pay(gomock.Any()).Return(ex)
+		v, bx := svc.Pay(inp)
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeSubmitted, bx.ErrorCode)
+	})
+}
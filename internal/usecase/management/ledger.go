@@ -0,0 +1,144 @@
+package management
+
+import (
+	"database/sql"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/internal/repository"
+	"go.uber.org/zap"
+	"strconv"
+	"time"
+)
+
+const maxLedgerPeriod = 366
+
+type Ledger struct {
+	Dao    repository.LedgerPersister
+	Logger *zap.Logger
+}
+
+type LedgerManager interface {
+	Balance(inp *model.LedgerRequest) (*model.LedgerBalanceResponse, *model.BusinessError)
+	Statement(inp *model.LedgerRequest) (*model.LedgerStatementResponse, *model.BusinessError)
+	Reverse(inp *model.LedgerReversalRequest) (*model.TransactionResponse, *model.BusinessError)
+}
+
+func NewLedger(l Ledger) LedgerManager {
+	return &l
+}
+
+func creditNormal(accountType string) bool {
+	return accountType == apps.LedgerProviderFee || accountType == apps.LedgerCustomerPayable
+}
+
+func (l *Ledger) Balance(inp *model.LedgerRequest) (*model.LedgerBalanceResponse, *model.BusinessError) {
+	start, _ := time.Parse("2006-01-02", inp.StartDate)
+	end, _ := time.Parse("2006-01-02", inp.EndDate)
+	if start.After(end) || end.Sub(start) > maxLedgerPeriod*24*time.Hour {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeBussPeriodInvalid,
+			ErrorMessage: apps.ErrMsgBussPeriodInvalid,
+		}
+	}
+	v, ex := l.Dao.Balance(inp)
+	if ex != nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSomethingWrong,
+			ErrorMessage: apps.ErrMsgSomethingWrong,
+		}
+	}
+	opening, closing := v.Opening, v.Opening.Add(v.Debit).Sub(v.Credit)
+	if creditNormal(inp.AccountType) {
+		opening, closing = opening.Neg(), closing.Neg()
+	}
+	return &model.LedgerBalanceResponse{
+		AccountType: inp.AccountType,
+		AccountCode: inp.AccountCode,
+		StartDate:   inp.StartDate,
+		EndDate:     inp.EndDate,
+		Opening:     opening,
+		Debit:       v.Debit,
+		Credit:      v.Credit,
+		Closing:     closing,
+	}, nil
+}
+
+func (l *Ledger) Statement(inp *model.LedgerRequest) (*model.LedgerStatementResponse, *model.BusinessError) {
+	b, bx := l.Balance(inp)
+	if bx != nil {
+		return nil, bx
+	}
+	model.Page(&inp.SearchRequest)
+	c, countEx := l.Dao.CountStatement(inp)
+	v, searchEx := l.Dao.Statement(inp)
+	if countEx != nil || searchEx != nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeNotFound,
+			ErrorMessage: apps.ErrMsgNotFound,
+		}
+	}
+	for i := range v {
+		if creditNormal(inp.AccountType) {
+			v[i].Balance = v[i].Balance.Neg()
+		}
+		v[i].Balance = b.Opening.Add(v[i].Balance)
+	}
+	return &model.LedgerStatementResponse{
+		LedgerBalanceResponse: *b,
+		Entries:               v,
+		PaginationResponse:    model.Pagination(*c, inp.Limit, inp.Start),
+	}, nil
+}
+
+func (l *Ledger) Reverse(inp *model.LedgerReversalRequest) (*model.TransactionResponse, *model.BusinessError) {
+	j, ex := l.Dao.FindJournalById(inp.Id)
+	if ex != nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeNotFound,
+			ErrorMessage: apps.ErrMsgNotFound,
+		}
+	}
+	if j.Reversed || j.Kind == apps.LedgerJournalReversal {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeBussJournalReversed,
+			ErrorMessage: apps.ErrMsgBussJournalReversed,
+		}
+	}
+	entries, ex := l.Dao.Entries(j.Id)
+	if ex != nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSomethingWrong,
+			ErrorMessage: apps.ErrMsgSomethingWrong,
+		}
+	}
+	for i := range entries {
+		entries[i].Debit, entries[i].Credit = entries[i].Credit, entries[i].Debit
+	}
+	id, ex := l.Dao.Reverse(model.LedgerJournal{
+		Kind:        sql.NullString{String: apps.LedgerJournalReversal, Valid: true},
+		Reference:   sql.NullString{String: j.Reference, Valid: true},
+		ReversalOf:  sql.NullInt64{Int64: j.Id, Valid: true},
+		Description: sql.NullString{String: inp.Description, Valid: true},
+		Entries:     entries,
+		BaseEntity: model.BaseEntity{
+			CreatedBy: sql.NullInt64{Int64: inp.SessionRequest.Id, Valid: true},
+		},
+	})
+	if ex != nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSubmitted,
+			ErrorMessage: apps.ErrMsgSubmitted,
+		}
+	}
+	if id == nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeBussJournalReversed,
+			ErrorMessage: apps.ErrMsgBussJournalReversed,
+		}
+	}
+	l.Logger.Info("journal reversed", zap.Int64("journal_id", j.Id), zap.Int64("reversal_id", *id))
+	return &model.TransactionResponse{
+		TransactionId:        apps.TransactionId(strconv.FormatInt(*id, 10) + apps.DefaultTrxId),
+		TransactionTimestamp: time.Now().Unix(),
+	}, nil
+}
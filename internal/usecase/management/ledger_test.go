@@ -0,0 +1,220 @@
+package management
+
+import (
+	"database/sql"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/mock/repository"
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestLedger_Balance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	dao := repository.NewMockLedgerPersister(ctrl)
+	svc := NewLedger(Ledger{
+		Dao:    dao,
+		Logger: logger,
+	})
+	inp := func(accountType string) *model.LedgerRequest {
+		return &model.LedgerRequest{
+			AccountType: accountType,
+			AccountCode: "LSAJA",
+			StartDate:   "2023-01-01",
+			EndDate:     "2023-01-31",
+		}
+	}
+	balance := &model.LedgerBalanceProjection{
+		Opening: decimal.NewFromInt(-1000),
+		Debit:   decimal.NewFromInt(500),
+		Credit:  decimal.NewFromInt(16000),
+	}
+	t.Run("should success on debit side", func(t *testing.T) {
+		dao.EXPECT().Balance(gomock.Any()).Return(&model.LedgerBalanceProjection{
+			Opening: decimal.NewFromInt(1000),
+			Debit:   decimal.NewFromInt(16000),
+			Credit:  decimal.NewFromInt(500),
+		}, nil)
+		v, bx := svc.Balance(inp(apps.LedgerPartnerReceivable))
+		assert.Nil(t, bx)
+		assert.True(t, decimal.NewFromInt(1000).Equal(v.Opening))
+		assert.True(t, decimal.NewFromInt(16500).Equal(v.Closing))
+	})
+
+	t.Run("should success on credit side", func(t *testing.T) {
+		dao.EXPECT().Balance(gomock.Any()).Return(balance, nil)
+		v, bx := svc.Balance(inp(apps.LedgerCustomerPayable))
+		assert.Nil(t, bx)
+		assert.True(t, decimal.NewFromInt(1000).Equal(v.Opening))
+		assert.True(t, decimal.NewFromInt(16500).Equal(v.Closing))
+	})
+
+	t.Run("should return exception on invalid period", func(t *testing.T) {
+		req := inp(apps.LedgerPartnerReceivable)
+		req.StartDate = "2023-02-01"
+		v, bx := svc.Balance(req)
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeBussPeriodInvalid, bx.ErrorCode)
+	})
+
+	t.Run("should return exception on failed to find balance", func(t *testing.T) {
+		dao.EXPECT().Balance(gomock.Any()).Return(nil, &model.TechnicalError{Exception: "something went wrong"})
+		v, bx := svc.Balance(inp(apps.LedgerPartnerReceivable))
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeSomethingWrong, bx.ErrorCode)
+	})
+}
+
+func TestLedger_Statement(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	dao := repository.NewMockLedgerPersister(ctrl)
+	svc := NewLedger(Ledger{
+		Dao:    dao,
+		Logger: logger,
+	})
+	inp := &model.LedgerRequest{
+		AccountType: apps.LedgerCustomerPayable,
+		AccountCode: "628123456789",
+		StartDate:   "2023-01-01",
+		EndDate:     "2023-01-31",
+	}
+	t.Run("should success", func(t *testing.T) {
+		count := 2
+		dao.EXPECT().Balance(gomock.Any()).Return(&model.LedgerBalanceProjection{
+			Opening: decimal.NewFromInt(-1000),
+			Debit:   decimal.NewFromInt(500),
+			Credit:  decimal.NewFromInt(16000),
+		}, nil)
+		dao.EXPECT().CountStatement(gomock.Any()).Return(&count, nil)
+		dao.EXPECT().Statement(gomock.Any()).Return([]model.LedgerEntryProjection{
+			{Id: 1, Credit: decimal.NewFromInt(16000), Balance: decimal.NewFromInt(-16000)},
+			{Id: 2, Debit: decimal.NewFromInt(500), Balance: decimal.NewFromInt(-15500)},
+		}, nil)
+		v, bx := svc.Statement(inp)
+		assert.Nil(t, bx)
+		assert.True(t, decimal.NewFromInt(17000).Equal(v.Entries[0].Balance))
+		assert.True(t, decimal.NewFromInt(16500).Equal(v.Entries[1].Balance))
+		assert.Equal(t, 10, v.Size)
+	})
+
+	t.Run("should return exception on failed to find statement", func(t *testing.T) {
+		dao.EXPECT().Balance(gomock.Any()).Return(&model.LedgerBalanceProjection{}, nil)
+		dao.EXPECT().CountStatement(gomock.Any()).Return(nil, &model.TechnicalError{Exception: "something went wrong"})
+		dao.EXPECT().Statement(gomock.Any()).Return(nil, nil)
+		v, bx := svc.Statement(inp)
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeNotFound, bx.ErrorCode)
+	})
+
+	t.Run("should return exception on failed to find balance", func(t *testing.T) {
+		dao.EXPECT().Balance(gomock.Any()).Return(nil, &model.TechnicalError{Exception: "something went wrong"})
+		v, bx := svc.Statement(inp)
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeSomethingWrong, bx.ErrorCode)
+	})
+}
+
+func TestLedger_Reverse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	dao := repository.NewMockLedgerPersister(ctrl)
+	svc := NewLedger(Ledger{
+		Dao:    dao,
+		Logger: logger,
+	})
+	inp := &model.LedgerReversalRequest{
+		Id:             1,
+		Description:    "Cashback was disbursed twice",
+		SessionRequest: model.SessionRequest{Id: 2},
+	}
+	journal := func(kind string, reversed bool) *model.LedgerJournalProjection {
+		return &model.LedgerJournalProjection{
+			Id:        1,
+			Kind:      kind,
+			Reference: "C00223010112000062811",
+			Reversed:  reversed,
+		}
+	}
+	entries := func() []model.LedgerEntry {
+		return model.LedgerTransfer(apps.LedgerPartnerReceivable, "LSAJA", apps.LedgerCustomerPayable,
+			"628123456789", decimal.NewFromInt(16000))
+	}
+	ex := &model.TechnicalError{
+		Exception: "something went wrong",
+		Occurred:  time.Now().Unix(),
+		Ticket:    "ERR-001",
+	}
+	t.Run("should success", func(t *testing.T) {
+		dao.EXPECT().FindJournalById(int64(1)).Return(journal(apps.LedgerJournalCashback, false), nil)
+		dao.EXPECT().Entries(int64(1)).Return(entries(), nil)
+		dao.EXPECT().Reverse(gomock.Any()).DoAndReturn(func(j model.LedgerJournal) (*int64, *model.TechnicalError) {
+			assert.Equal(t, apps.LedgerJournalReversal, j.Kind.String)
+			assert.Equal(t, sql.NullInt64{Int64: 1, Valid: true}, j.ReversalOf)
+			assert.Equal(t, model.LedgerTransfer(apps.LedgerCustomerPayable, "628123456789",
+				apps.LedgerPartnerReceivable, "LSAJA", decimal.NewFromInt(16000)), []model.LedgerEntry{
+				j.Entries[1], j.Entries[0],
+			})
+			assert.Equal(t, int64(2), j.CreatedBy.Int64)
+			id := int64(2)
+			return &id, nil
+		})
+		v, bx := svc.Reverse(inp)
+		assert.Nil(t, bx)
+		assert.NotNil(t, v)
+	})
+
+	t.Run("should return exception on journal not found", func(t *testing.T) {
+		dao.EXPECT().FindJournalById(int64(1)).Return(nil, ex)
+		v, bx := svc.Reverse(inp)
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeNotFound, bx.ErrorCode)
+	})
+
+	t.Run("should return exception on reversed journal", func(t *testing.T) {
+		dao.EXPECT().FindJournalById(int64(1)).Return(journal(apps.LedgerJournalCashback, true), nil)
+		v, bx := svc.Reverse(inp)
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeBussJournalReversed, bx.ErrorCode)
+	})
+
+	t.Run("should return exception on reversal journal", func(t *testing.T) {
+		dao.EXPECT().FindJournalById(int64(1)).Return(journal(apps.LedgerJournalReversal, false), nil)
+		v, bx := svc.Reverse(inp)
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeBussJournalReversed, bx.ErrorCode)
+	})
+
+	t.Run("should return exception on failed to find entries", func(t *testing.T) {
+		dao.EXPECT().FindJournalById(int64(1)).Return(journal(apps.LedgerJournalCashback, false), nil)
+		dao.EXPECT().Entries(int64(1)).Return(nil, ex)
+		v, bx := svc.Reverse(inp)
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeSomethingWrong, bx.ErrorCode)
+	})
+
+	t.Run("should return exception on journal reversed concurrently", func(t *testing.T) {
+		dao.EXPECT().FindJournalById(int64(1)).Return(journal(apps.LedgerJournalCashback, false), nil)
+		dao.EXPECT().Entries(int64(1)).Return(entries(), nil)
+		dao.EXPECT().Reverse(gomock.Any()).Return(nil, nil)
+		v, bx := svc.Reverse(inp)
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeBussJournalReversed, bx.ErrorCode)
+	})
+
+	t.Run("should return exception on failed to post", func(t *testing.T) {
+		dao.EXPECT().FindJournalById(int64(1)).Return(journal(apps.LedgerJournalCashback, false), nil)
+		dao.EXPECT().Entries(int64(1)).Return(entries(), nil)
+		dao.EXPECT().Reverse(gomock.Any()).Return(nil, ex)
+		v, bx := svc.Reverse(inp)
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeSubmitted, bx.ErrorCode)
+	})
+}
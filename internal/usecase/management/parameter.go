@@ -1,6 +1,7 @@
 package management
 
 import (
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
 	"github.com/adinandradrs/cezbek-engine/internal/model"
 	"github.com/adinandradrs/cezbek-engine/internal/repository"
 	"github.com/adinandradrs/cezbek-engine/internal/storage"
@@ -17,6 +18,7 @@ type ParamManager interface {
 	CacheWallets() *model.TechnicalError
 	CacheEmailTemplates() *model.TechnicalError
 	CacheEmailSubjects() *model.TechnicalError
+	CacheNotificationChannels() *model.TechnicalError
 }
 
 func NewParameter(p Parameter) ParamManager {
@@ -28,8 +30,21 @@ func (p *Parameter) groupFetchCache(group string) *model.TechnicalError {
 	if ex != nil {
 		return ex
 	}
+	return p.groupCache(group, v)
+}
+
+func (p *Parameter) latestGroupFetchCache(group string) *model.TechnicalError {
+	v, ex := p.Dao.FindLatestByParamGroup(group)
+	if ex != nil {
+		return ex
+	}
+	return p.groupCache(group, v)
+}
+
+func (p *Parameter) groupCache(group string, v []*model.Parameter) *model.TechnicalError {
 	for idx := range v {
-		ex = p.Cacher.Hset(group, v[idx].ParamName.String, v[idx].ParamValue.String)
+		ex := p.Cacher.Hset(group, apps.LocaleKey(v[idx].ParamName.String, apps.NormalizeLocale(v[idx].ParamLocale.String)),
+			v[idx].ParamValue.String)
 		if ex != nil {
 			return ex
 		}
@@ -42,9 +57,13 @@ func (p *Parameter) CacheWallets() *model.TechnicalError {
 }
 
 func (p *Parameter) CacheEmailTemplates() *model.TechnicalError {
-	return p.groupFetchCache("EMAIL_TEMPLATE")
+	return p.latestGroupFetchCache("EMAIL_TEMPLATE")
 }
 
 func (p *Parameter) CacheEmailSubjects() *model.TechnicalError {
-	return p.groupFetchCache("EMAIL_SUBJECT")
+	return p.latestGroupFetchCache("EMAIL_SUBJECT")
+}
+
+func (p *Parameter) CacheNotificationChannels() *model.TechnicalError {
+	return p.groupFetchCache("NOTIFICATION_CHANNEL")
 }
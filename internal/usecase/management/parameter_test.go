@@ -75,14 +75,31 @@ func TestParameter_CacheEmailTemplates(t *testing.T) {
 			ParamName:  sql.NullString{String: "Name A", Valid: true},
 			ParamValue: sql.NullString{String: "Value A", Valid: true},
 		}}
-		dao.EXPECT().FindByParamGroup("EMAIL_TEMPLATE").Return(params, nil)
+		dao.EXPECT().FindLatestByParamGroup("EMAIL_TEMPLATE").Return(params, nil)
 		cacher.EXPECT().Hset(gomock.Any(), gomock.Any(), gomock.Any()).
 			Return(nil)
 		ex := svc.CacheEmailTemplates()
 		assert.Nil(t, ex)
 	})
+	t.Run("should success with localized template", func(t *testing.T) {
+		params := []*model.Parameter{{
+			ParamGroup: sql.NullString{String: "EMAIL_TEMPLATE", Valid: true},
+			ParamName:  sql.NullString{String: "OTP", Valid: true},
+			ParamValue: sql.NullString{String: "<p>{{.otp}}</p>", Valid: true},
+		}, {
+			ParamGroup:  sql.NullString{String: "EMAIL_TEMPLATE", Valid: true},
+			ParamName:   sql.NullString{String: "OTP", Valid: true},
+			ParamValue:  sql.NullString{String: "<p>Your OTP is {{.otp}}</p>", Valid: true},
+			ParamLocale: sql.NullString{String: "EN_us", Valid: true},
+		}}
+		dao.EXPECT().FindLatestByParamGroup("EMAIL_TEMPLATE").Return(params, nil)
+		cacher.EXPECT().Hset("EMAIL_TEMPLATE", "OTP", "<p>{{.otp}}</p>").Return(nil)
+		cacher.EXPECT().Hset("EMAIL_TEMPLATE", "OTP:en-us", "<p>Your OTP is {{.otp}}</p>").Return(nil)
+		ex := svc.CacheEmailTemplates()
+		assert.Nil(t, ex)
+	})
 	t.Run("should return exception on DAO failure ops", func(t *testing.T) {
-		dao.EXPECT().FindByParamGroup("EMAIL_TEMPLATE").Return(nil,
+		dao.EXPECT().FindLatestByParamGroup("EMAIL_TEMPLATE").Return(nil,
 			apps.Exception("something went wrong",
 				fmt.Errorf("something went wrong"), zap.Any("", ""), logger))
 		ex := svc.CacheEmailTemplates()
@@ -94,7 +111,7 @@ func TestParameter_CacheEmailTemplates(t *testing.T) {
 			ParamName:  sql.NullString{String: "Name A", Valid: true},
 			ParamValue: sql.NullString{String: "Value A", Valid: true},
 		}}
-		dao.EXPECT().FindByParamGroup("EMAIL_TEMPLATE").Return(params, nil)
+		dao.EXPECT().FindLatestByParamGroup("EMAIL_TEMPLATE").Return(params, nil)
 		cacher.EXPECT().Hset(gomock.Any(), gomock.Any(), gomock.Any()).
 			Return(apps.Exception("something went wrong",
 				fmt.Errorf("something went wrong"), zap.Any("", ""), logger))
@@ -120,14 +137,14 @@ func TestParameter_CacheEmailSubjects(t *testing.T) {
 			ParamName:  sql.NullString{String: "Name A", Valid: true},
 			ParamValue: sql.NullString{String: "Value A", Valid: true},
 		}}
-		dao.EXPECT().FindByParamGroup("EMAIL_SUBJECT").Return(params, nil)
+		dao.EXPECT().FindLatestByParamGroup("EMAIL_SUBJECT").Return(params, nil)
 		cacher.EXPECT().Hset(gomock.Any(), gomock.Any(), gomock.Any()).
 			Return(nil)
 		ex := manager.CacheEmailSubjects()
 		assert.Nil(t, ex)
 	})
 	t.Run("should return exception on DAO failure ops", func(t *testing.T) {
-		dao.EXPECT().FindByParamGroup("EMAIL_SUBJECT").Return(nil,
+		dao.EXPECT().FindLatestByParamGroup("EMAIL_SUBJECT").Return(nil,
 			apps.Exception("something went wrong",
 				fmt.Errorf("something went wrong"), zap.Any("", ""), logger))
 		ex := manager.CacheEmailSubjects()
@@ -139,7 +156,7 @@ func TestParameter_CacheEmailSubjects(t *testing.T) {
 			ParamName:  sql.NullString{String: "Name A", Valid: true},
 			ParamValue: sql.NullString{String: "Value A", Valid: true},
 		}}
-		dao.EXPECT().FindByParamGroup("EMAIL_SUBJECT").Return(params, nil)
+		dao.EXPECT().FindLatestByParamGroup("EMAIL_SUBJECT").Return(params, nil)
 		cacher.EXPECT().Hset(gomock.Any(), gomock.Any(), gomock.Any()).
 			Return(apps.Exception("something went wrong",
 				fmt.Errorf("something went wrong"), zap.Any("", ""), logger))
@@ -147,3 +164,35 @@ func TestParameter_CacheEmailSubjects(t *testing.T) {
 		assert.NotNil(t, ex)
 	})
 }
+
+func TestParameter_CacheNotificationChannels(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	dao, cacher := repository.NewMockParamPersister(ctrl),
+		storage.NewMockCacher(ctrl)
+	svc := NewParameter(Parameter{
+		Logger: logger,
+		Cacher: cacher,
+		Dao:    dao,
+	})
+	t.Run("should success", func(t *testing.T) {
+		params := []*model.Parameter{{
+			ParamGroup: sql.NullString{String: "NOTIFICATION_CHANNEL", Valid: true},
+			ParamName:  sql.NullString{String: "INVOICE.LSAJA", Valid: true},
+			ParamValue: sql.NullString{String: "WHATSAPP", Valid: true},
+		}}
+		dao.EXPECT().FindByParamGroup("NOTIFICATION_CHANNEL").Return(params, nil)
+		cacher.EXPECT().Hset("NOTIFICATION_CHANNEL", "INVOICE.LSAJA", "WHATSAPP").
+			Return(nil)
+		ex := svc.CacheNotificationChannels()
+		assert.Nil(t, ex)
+	})
+	t.Run("should return exception on DAO failure ops", func(t *testing.T) {
+		dao.EXPECT().FindByParamGroup("NOTIFICATION_CHANNEL").Return(nil,
+			apps.Exception("something went wrong",
+				fmt.Errorf("something went wrong"), zap.Any("", ""), logger))
+		ex := svc.CacheNotificationChannels()
+		assert.NotNil(t, ex)
+	})
+}
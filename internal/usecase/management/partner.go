@@ -2,10 +2,12 @@ package management
 
 import (
 	"database/sql"
+	"encoding/json"
 	"github.com/adinandradrs/cezbek-engine/internal/adaptor"
 	"github.com/adinandradrs/cezbek-engine/internal/apps"
 	"github.com/adinandradrs/cezbek-engine/internal/model"
 	"github.com/adinandradrs/cezbek-engine/internal/repository"
+	"github.com/adinandradrs/cezbek-engine/internal/storage"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -16,14 +18,18 @@ import (
 
 type Partner struct {
 	Dao         repository.PartnerPersister
+	OfficerDao  repository.OfficerPersister
 	CiamWatcher adaptor.CiamWatcher
 	S3Watcher   adaptor.S3Watcher
 	PathS3      *string
+	Cacher      storage.Cacher
+	AuthTTL     time.Duration
 	Logger      *zap.Logger
 }
 
 type PartnerManager interface {
 	Add(inp *model.AddPartnerRequest) (*model.TransactionResponse, *model.BusinessError)
+	RevokeSessions(code string) (*model.TransactionResponse, *model.BusinessError)
 }
 
 func NewPartner(p Partner) PartnerManager {
@@ -82,6 +88,7 @@ func (p *Partner) Add(inp *model.AddPartnerRequest) (*model.TransactionResponse,
 		Code:    sql.NullString{String: inp.Code, Valid: true},
 		Email:   sql.NullString{String: inp.Email, Valid: true},
 		Msisdn:  sql.NullString{String: inp.Msisdn, Valid: true},
+		Locale:  sql.NullString{String: apps.NormalizeLocale(inp.Locale), Valid: inp.Locale != ""},
 		Status:  apps.StatusActive,
 		BaseEntity: model.BaseEntity{
 			CreatedBy: sql.NullInt64{Int64: inp.SessionRequest.Id, Valid: true},
@@ -141,3 +148,57 @@ func (p *Partner) Add(inp *model.AddPartnerRequest) (*model.TransactionResponse,
 		TransactionTimestamp: time.Now().Unix(),
 	}, nil
 }
+
+func (p *Partner) revokeSession(k string, u string) {
+	v, ex := p.Cacher.Get(k, u)
+	if ex != nil {
+		return
+	}
+	var sess model.SessionResponse
+	_ = json.Unmarshal([]byte(v), &sess)
+	claims, ex := p.CiamWatcher.JwtInfo(sess.Token)
+	if ex == nil {
+		if jti, ok := claims["jti"].(string); ok {
+			exp, _ := claims["exp"].(float64)
+			p.Cacher.Set("JWTDENY", jti, u, apps.TokenTTL(int64(exp), p.AuthTTL))
+		}
+	}
+	if ex = p.Cacher.Delete(k, u); ex != nil {
+		p.Logger.Error("failed to delete session", zap.String("key", k), zap.String("username", u))
+	}
+}
+
+func (p *Partner) RevokeSessions(code string) (*model.TransactionResponse, *model.BusinessError) {
+	data, ex := p.Dao.FindActiveByCode(code)
+	if ex != nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeNotFound,
+			ErrorMessage: apps.ErrMsgNotFound,
+		}
+	}
+	p.revokeSession("CLIENTSESSION", strings.ToUpper(data.Code.String))
+	officers, ex := p.OfficerDao.FindActiveByPartner(data.Id)
+	if ex != nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSomethingWrong,
+			ErrorMessage: apps.ErrMsgSomethingWrong,
+		}
+	}
+	primary := strings.ToLower(data.Email.String)
+	p.revokeSession("B2BSESSION", primary)
+	for _, o := range officers {
+		if e := strings.ToLower(o.Email); e != primary {
+			p.revokeSession("B2BSESSION", e)
+		}
+	}
+	if ex = p.CiamWatcher.RevokeUser(data.Code.String); ex != nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeESBUnavailable,
+			ErrorMessage: apps.ErrMsgESBUnavailable,
+		}
+	}
+	return &model.TransactionResponse{
+		TransactionId:        apps.TransactionId(data.Code.String + apps.DefaultTrxId),
+		TransactionTimestamp: time.Now().Unix(),
+	}, nil
+}
@@ -1,10 +1,13 @@
 package management
 
 import (
+	"database/sql"
+	"encoding/json"
 	"github.com/adinandradrs/cezbek-engine/internal/apps"
 	"github.com/adinandradrs/cezbek-engine/internal/model"
 	"github.com/adinandradrs/cezbek-engine/mock/adaptor"
 	"github.com/adinandradrs/cezbek-engine/mock/repository"
+	"github.com/adinandradrs/cezbek-engine/mock/storage"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -105,3 +108,125 @@ func TestPartner_Add(t *testing.T) {
 	})
 
 }
+
+func TestPartner_RevokeSessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	authTTL, _ := time.ParseDuration("1s")
+	dao, officerDao, ciamWatcher, cacher := repository.NewMockPartnerPersister(ctrl),
+		repository.NewMockOfficerPersister(ctrl), adaptor.NewMockCiamWatcher(ctrl), storage.NewMockCacher(ctrl)
+	svc := NewPartner(Partner{
+		Dao:         dao,
+		OfficerDao:  officerDao,
+		CiamWatcher: ciamWatcher,
+		Cacher:      cacher,
+		AuthTTL:     authTTL,
+		Logger:      logger,
+	})
+	p := &model.Partner{
+		Id:    int64(1),
+		Code:  sql.NullString{String: "MOCK", Valid: true},
+		Email: sql.NullString{String: "Mock@email.net", Valid: true},
+	}
+	sess, _ := json.Marshal(model.SessionResponse{
+		Token: "token-abc",
+	})
+
+	t.Run("should success", func(t *testing.T) {
+		dao.EXPECT().FindActiveByCode("MOCK").Return(p, nil)
+		cacher.EXPECT().Get("CLIENTSESSION", "MOCK").Return(string(sess), nil)
+		ciamWatcher.EXPECT().JwtInfo("token-abc").Return(map[string]interface{}{
+			"jti": "jti-abc-123",
+		}, nil)
+		cacher.EXPECT().Set("JWTDENY", "jti-abc-123", "MOCK", authTTL)
+		cacher.EXPECT().Delete("CLIENTSESSION", "MOCK").Return(nil)
+		officerDao.EXPECT().FindActiveByPartner(p.Id).Return([]model.OfficerProjection{
+			{Id: 1, Email: "Mock@email.net", Role: apps.RoleOfficerAdmin},
+		}, nil)
+		cacher.EXPECT().Get("B2BSESSION", "mock@email.net").Return("", &model.TechnicalError{
+			Exception: "redis: nil",
+			Occurred:  time.Now().Unix(),
+			Ticket:    uuid.NewString(),
+		})
+		ciamWatcher.EXPECT().RevokeUser("MOCK").Return(nil)
+		trx, ex := svc.RevokeSessions("MOCK")
+		assert.NotNil(t, trx)
+		assert.Nil(t, ex)
+	})
+
+	t.Run("should success to revoke the primary email along with the officers", func(t *testing.T) {
+		dao.EXPECT().FindActiveByCode("MOCK").Return(p, nil)
+		cacher.EXPECT().Get("CLIENTSESSION", "MOCK").Return("", &model.TechnicalError{
+			Exception: "redis: nil",
+			Occurred:  time.Now().Unix(),
+			Ticket:    uuid.NewString(),
+		})
+		officerDao.EXPECT().FindActiveByPartner(p.Id).Return([]model.OfficerProjection{
+			{Id: 2, Email: "Jane.Doe@email.net", Role: apps.RoleOfficerFinance},
+		}, nil)
+		cacher.EXPECT().Get("B2BSESSION", "mock@email.net").Return(string(sess), nil)
+		ciamWatcher.EXPECT().JwtInfo("token-abc").Return(map[string]interface{}{
+			"jti": "jti-abc-456",
+		}, nil)
+		cacher.EXPECT().Set("JWTDENY", "jti-abc-456", "mock@email.net", authTTL)
+		cacher.EXPECT().Delete("B2BSESSION", "mock@email.net").Return(nil)
+		cacher.EXPECT().Get("B2BSESSION", "jane.doe@email.net").Return("", &model.TechnicalError{
+			Exception: "redis: nil",
+			Occurred:  time.Now().Unix(),
+			Ticket:    uuid.NewString(),
+		})
+		ciamWatcher.EXPECT().RevokeUser("MOCK").Return(nil)
+		trx, ex := svc.RevokeSessions("MOCK")
+		assert.NotNil(t, trx)
+		assert.Nil(t, ex)
+	})
+
+	t.Run("should return exception on partner not found", func(t *testing.T) {
+		dao.EXPECT().FindActiveByCode("MOCK").Return(nil, &model.TechnicalError{
+			Exception: "no rows in result set",
+			Occurred:  time.Now().Unix(),
+			Ticket:    uuid.NewString(),
+		})
+		trx, ex := svc.RevokeSessions("MOCK")
+		assert.Nil(t, trx)
+		assert.Equal(t, apps.ErrCodeNotFound, ex.ErrorCode)
+	})
+
+	t.Run("should return exception on ciam to revoke", func(t *testing.T) {
+		dao.EXPECT().FindActiveByCode("MOCK").Return(p, nil)
+		cacher.EXPECT().Get(gomock.Any(), gomock.Any()).Return("", &model.TechnicalError{
+			Exception: "redis: nil",
+			Occurred:  time.Now().Unix(),
+			Ticket:    uuid.NewString(),
+		}).Times(2)
+		officerDao.EXPECT().FindActiveByPartner(p.Id).Return([]model.OfficerProjection{
+			{Id: 1, Email: "mock@email.net", Role: apps.RoleOfficerAdmin},
+		}, nil)
+		ciamWatcher.EXPECT().RevokeUser("MOCK").Return(&model.TechnicalError{
+			Exception: "something went wrong",
+			Occurred:  time.Now().Unix(),
+			Ticket:    uuid.NewString(),
+		})
+		trx, ex := svc.RevokeSessions("MOCK")
+		assert.Nil(t, trx)
+		assert.Equal(t, apps.ErrCodeESBUnavailable, ex.ErrorCode)
+	})
+
+	t.Run("should return exception on failed to find officers", func(t *testing.T) {
+		dao.EXPECT().FindActiveByCode("MOCK").Return(p, nil)
+		cacher.EXPECT().Get("CLIENTSESSION", "MOCK").Return("", &model.TechnicalError{
+			Exception: "redis: nil",
+			Occurred:  time.Now().Unix(),
+			Ticket:    uuid.NewString(),
+		})
+		officerDao.EXPECT().FindActiveByPartner(p.Id).Return(nil, &model.TechnicalError{
+			Exception: "something went wrong",
+			Occurred:  time.Now().Unix(),
+			Ticket:    uuid.NewString(),
+		})
+		trx, ex := svc.RevokeSessions("MOCK")
+		assert.Nil(t, trx)
+		assert.Equal(t, apps.ErrCodeSomethingWrong, ex.ErrorCode)
+	})
+}
@@ -0,0 +1,315 @@
+package management
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/internal/repository"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Settlement struct {
+	Dao    repository.SettlementPersister
+	Logger *zap.Logger
+}
+
+type SettlementManager interface {
+	Reconcile(inp *model.SettlementRequest) (*model.SettlementReportResponse, *model.BusinessError)
+	Report(id int64) (*model.SettlementReportResponse, *model.BusinessError)
+	Search(inp *model.SearchRequest) (*model.SettlementSearchResponse, *model.BusinessError)
+	Resolve(inp *model.SettlementResolveRequest) (*model.TransactionResponse, *model.BusinessError)
+}
+
+func NewSettlement(s Settlement) SettlementManager {
+	return &s
+}
+
+var settlementColumns = map[string]string{
+	"kezbek_ref_code":   "kezbek",
+	"kezbek_ref_no":     "kezbek",
+	"merchant_ref":      "kezbek",
+	"provider_ref_code": "provider",
+	"transaction_id":    "provider",
+	"reference":         "provider",
+	"amount":            "amount",
+}
+
+func invalidSettlement() *model.BusinessError {
+	return &model.BusinessError{
+		ErrorCode:    apps.ErrCodeBussSettlementInvalid,
+		ErrorMessage: apps.ErrMsgBussSettlementInvalid,
+	}
+}
+
+func parseSettlementCsv(b []byte) ([]model.SettlementLine, *model.BusinessError) {
+	rows, err := csv.NewReader(bytes.NewReader(b)).ReadAll()
+	if err != nil || len(rows) < 2 {
+		return nil, invalidSettlement()
+	}
+	cols := map[string]int{}
+	for i, h := range rows[0] {
+		if f, ok := settlementColumns[strings.ToLower(strings.TrimSpace(h))]; ok {
+			cols[f] = i
+		}
+	}
+	_, kezbek := cols["kezbek"]
+	_, provider := cols["provider"]
+	if _, ok := cols["amount"]; !ok || (!kezbek && !provider) {
+		return nil, invalidSettlement()
+	}
+	cell := func(row []string, f string) string {
+		if i, ok := cols[f]; ok && i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+	var lines []model.SettlementLine
+	for _, row := range rows[1:] {
+		amt, err := decimal.NewFromString(cell(row, "amount"))
+		if err != nil {
+			return nil, invalidSettlement()
+		}
+		lines = append(lines, model.SettlementLine{
+			KezbekRefCode:   cell(row, "kezbek"),
+			ProviderRefCode: cell(row, "provider"),
+			Amount:          amt,
+		})
+	}
+	return lines, nil
+}
+
+func (s *Settlement) parse(inp *model.SettlementRequest) ([]model.SettlementLine, *model.BusinessError) {
+	f, err := inp.File.Open()
+	if err != nil {
+		return nil, invalidSettlement()
+	}
+	defer f.Close()
+	b, err := io.ReadAll(f)
+	if err != nil {
+		return nil, invalidSettlement()
+	}
+	var lines []model.SettlementLine
+	switch strings.ToLower(filepath.Ext(inp.File.Filename)) {
+	case ".csv":
+		v, bx := parseSettlementCsv(b)
+		if bx != nil {
+			return nil, bx
+		}
+		lines = v
+	case ".json":
+		if err = json.Unmarshal(b, &lines); err != nil {
+			return nil, invalidSettlement()
+		}
+	default:
+		return nil, invalidSettlement()
+	}
+	if len(lines) == 0 {
+		return nil, invalidSettlement()
+	}
+	for _, l := range lines {
+		if l.KezbekRefCode == "" && l.ProviderRefCode == "" {
+			return nil, invalidSettlement()
+		}
+	}
+	return lines, nil
+}
+
+func settlementException(kind string, kezbek string, provider string, amount decimal.Decimal,
+	expected decimal.Decimal) model.SettlementException {
+	return model.SettlementException{
+		Type:            sql.NullString{String: kind, Valid: true},
+		KezbekRefCode:   sql.NullString{String: kezbek, Valid: kezbek != ""},
+		ProviderRefCode: sql.NullString{String: provider, Valid: provider != ""},
+		Amount:          amount,
+		Expected:        expected,
+	}
+}
+
+func match(lines []model.SettlementLine, cashbacks []model.SettlementCashbackProjection) (
+	[]model.SettlementCashbackProjection, []model.SettlementException) {
+	byKezbek, byProvider := map[string]int{}, map[string]int{}
+	for i, c := range cashbacks {
+		byKezbek[c.KezbekRefCode] = i
+		if c.ProviderRefCode != "" {
+			byProvider[c.ProviderRefCode] = i
+		}
+	}
+	seen := map[int]bool{}
+	var (
+		settled    []model.SettlementCashbackProjection
+		exceptions []model.SettlementException
+	)
+	for _, l := range lines {
+		i, ok := byKezbek[l.KezbekRefCode]
+		if !ok || l.KezbekRefCode == "" {
+			i, ok = byProvider[l.ProviderRefCode]
+			ok = ok && l.ProviderRefCode != ""
+		}
+		if !ok {
+			exceptions = append(exceptions, settlementException(apps.SettlementMissingCashback, l.KezbekRefCode,
+				l.ProviderRefCode, l.Amount, decimal.Zero))
+			continue
+		}
+		c := cashbacks[i]
+		if seen[i] || c.Settled {
+			exceptions = append(exceptions, settlementException(apps.SettlementDuplicate, c.KezbekRefCode,
+				c.ProviderRefCode, l.Amount, c.Amount))
+			continue
+		}
+		seen[i] = true
+		if !l.Amount.Equal(c.Amount) {
+			exceptions = append(exceptions, settlementException(apps.SettlementAmountMismatch, c.KezbekRefCode,
+				c.ProviderRefCode, l.Amount, c.Amount))
+			continue
+		}
+		settled = append(settled, c)
+	}
+	for i, c := range cashbacks {
+		if c.OnDate && !c.Settled && !seen[i] {
+			exceptions = append(exceptions, settlementException(apps.SettlementMissingSettlement, c.KezbekRefCode,
+				c.ProviderRefCode, decimal.Zero, c.Amount))
+		}
+	}
+	return settled, exceptions
+}
+
+func settlementJournal(code string, inp *model.SettlementRequest,
+	settled []model.SettlementCashbackProjection) model.LedgerJournal {
+	j := model.LedgerJournal{
+		Kind:        sql.NullString{String: apps.LedgerJournalSettlement, Valid: true},
+		Reference:   sql.NullString{String: inp.File.Filename, Valid: true},
+		Description: sql.NullString{String: "Settlement of " + code + " on " + inp.SettlementDate, Valid: true},
+		BaseEntity: model.BaseEntity{
+			CreatedBy: sql.NullInt64{Int64: inp.SessionRequest.Id, Valid: true},
+		},
+	}
+	for _, c := range settled {
+		j.Entries = append(j.Entries, model.LedgerTransfer(apps.LedgerCustomerPayable, c.Msisdn,
+			apps.LedgerProviderPrefund, code, c.Amount)...)
+	}
+	return j
+}
+
+func (s *Settlement) Reconcile(inp *model.SettlementRequest) (*model.SettlementReportResponse, *model.BusinessError) {
+	lines, bx := s.parse(inp)
+	if bx != nil {
+		return nil, bx
+	}
+	var refs []string
+	for _, l := range lines {
+		for _, r := range []string{l.KezbekRefCode, l.ProviderRefCode} {
+			if r != "" {
+				refs = append(refs, r)
+			}
+		}
+	}
+	code := strings.ToUpper(inp.H2HCode)
+	cashbacks, ex := s.Dao.Cashbacks(code, inp.SettlementDate, refs)
+	if ex != nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSomethingWrong,
+			ErrorMessage: apps.ErrMsgSomethingWrong,
+		}
+	}
+	settled, exceptions := match(lines, cashbacks)
+	matched := len(settled)
+	var ids []int64
+	for _, c := range settled {
+		ids = append(ids, c.Id)
+	}
+	date, _ := time.Parse("2006-01-02", inp.SettlementDate)
+	id, ex := s.Dao.Add(model.Settlement{
+		H2HCode:        sql.NullString{String: code, Valid: true},
+		SettlementDate: sql.NullTime{Time: date, Valid: true},
+		Filename:       sql.NullString{String: inp.File.Filename, Valid: true},
+		Lines:          len(lines),
+		Matched:        matched,
+		Flagged:        len(exceptions),
+		BaseEntity: model.BaseEntity{
+			CreatedBy: sql.NullInt64{Int64: inp.SessionRequest.Id, Valid: true},
+		},
+	}, exceptions, ids, settlementJournal(code, inp, settled))
+	if ex != nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSubmitted,
+			ErrorMessage: apps.ErrMsgSubmitted,
+		}
+	}
+	s.Logger.Info("settlement reconciled", zap.String("h2h_code", code), zap.Int("lines", len(lines)),
+		zap.Int("matched", matched), zap.Int("flagged", len(exceptions)))
+	return s.Report(*id)
+}
+
+func (s *Settlement) Report(id int64) (*model.SettlementReportResponse, *model.BusinessError) {
+	v, ex := s.Dao.FindById(id)
+	if ex != nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeNotFound,
+			ErrorMessage: apps.ErrMsgNotFound,
+		}
+	}
+	exceptions, ex := s.Dao.Exceptions(id)
+	if ex != nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSomethingWrong,
+			ErrorMessage: apps.ErrMsgSomethingWrong,
+		}
+	}
+	return &model.SettlementReportResponse{
+		SettlementProjection: *v,
+		Exceptions:           exceptions,
+	}, nil
+}
+
+func (s *Settlement) Search(inp *model.SearchRequest) (*model.SettlementSearchResponse, *model.BusinessError) {
+	model.Page(inp)
+	c, countEx := s.Dao.Count()
+	v, searchEx := s.Dao.Search(inp)
+	if countEx != nil || searchEx != nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeNotFound,
+			ErrorMessage: apps.ErrMsgNotFound,
+		}
+	}
+	return &model.SettlementSearchResponse{
+		Settlements:        v,
+		PaginationResponse: model.Pagination(*c, inp.Limit, inp.Start),
+	}, nil
+}
+
+func (s *Settlement) Resolve(inp *model.SettlementResolveRequest) (*model.TransactionResponse, *model.BusinessError) {
+	v, ex := s.Dao.FindExceptionById(inp.Id)
+	if ex != nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeNotFound,
+			ErrorMessage: apps.ErrMsgNotFound,
+		}
+	}
+	if v.Status.String == apps.SettlementResolved {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeBussExceptionResolved,
+			ErrorMessage: apps.ErrMsgBussExceptionResolved,
+		}
+	}
+	v.Notes = sql.NullString{String: inp.Notes, Valid: true}
+	v.UpdatedBy = sql.NullInt64{Int64: inp.SessionRequest.Id, Valid: true}
+	if ex = s.Dao.Resolve(*v); ex != nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSubmitted,
+			ErrorMessage: apps.ErrMsgSubmitted,
+		}
+	}
+	return &model.TransactionResponse{
+		TransactionId:        apps.TransactionId(strconv.FormatInt(v.SettlementId, 10) + apps.DefaultTrxId),
+		TransactionTimestamp: time.Now().Unix(),
+	}, nil
+}
@@ -0,0 +1,283 @@
+package management
+
+import (
+	"bytes"
+	"database/sql"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/mock/repository"
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"mime/multipart"
+	"testing"
+	"time"
+)
+
+func settlementFile(t *testing.T, name string, content string) *multipart.FileHeader {
+	b := &bytes.Buffer{}
+	w := multipart.NewWriter(b)
+	f, _ := w.CreateFormFile("file", name)
+	_, _ = f.Write([]byte(content))
+	_ = w.Close()
+	form, err := multipart.NewReader(b, w.Boundary()).ReadForm(1024)
+	assert.Nil(t, err)
+	return form.File["file"][0]
+}
+
+func TestSettlement_Reconcile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	dao := repository.NewMockSettlementPersister(ctrl)
+	svc := NewSettlement(Settlement{
+		Dao:    dao,
+		Logger: logger,
+	})
+	cashbacks := []model.SettlementCashbackProjection{
+		{Id: 1, KezbekRefCode: "KZB-001", ProviderRefCode: "trx-001", Amount: decimal.NewFromInt(15000),
+			Msisdn: "628123456789", OnDate: true},
+		{Id: 2, KezbekRefCode: "KZB-002", ProviderRefCode: "trx-002", Amount: decimal.NewFromInt(16000), OnDate: true},
+		{Id: 3, KezbekRefCode: "KZB-003", ProviderRefCode: "trx-003", Amount: decimal.NewFromInt(5000), OnDate: true},
+	}
+	inp := func(name string, content string) *model.SettlementRequest {
+		return &model.SettlementRequest{
+			H2HCode:        "lsajah2h",
+			SettlementDate: "2023-01-01",
+			File:           settlementFile(t, name, content),
+			SessionRequest: model.SessionRequest{Id: 1},
+		}
+	}
+	ex := &model.TechnicalError{
+		Exception: "something went wrong",
+		Occurred:  time.Now().Unix(),
+		Ticket:    "ERR-001",
+	}
+	t.Run("should success on csv", func(t *testing.T) {
+		dao.EXPECT().Cashbacks("LSAJAH2H", "2023-01-01", gomock.Any()).Return(cashbacks, nil)
+		dao.EXPECT().Add(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(m model.Settlement, exceptions []model.SettlementException, settled []int64,
+				j model.LedgerJournal) (*int64, *model.TechnicalError) {
+				assert.Equal(t, model.LedgerTransfer(apps.LedgerCustomerPayable, "628123456789",
+					apps.LedgerProviderPrefund, "LSAJAH2H", decimal.NewFromInt(15000)), j.Entries)
+				assert.Equal(t, 4, m.Lines)
+				assert.Equal(t, 1, m.Matched)
+				assert.Equal(t, 4, m.Flagged)
+				types := []string{}
+				for _, e := range exceptions {
+					types = append(types, e.Type.String)
+				}
+				assert.Equal(t, []string{apps.SettlementAmountMismatch, apps.SettlementDuplicate,
+					apps.SettlementMissingCashback, apps.SettlementMissingSettlement}, types)
+				assert.True(t, decimal.NewFromInt(16000).Equal(exceptions[0].Expected))
+				id := int64(1)
+				return &id, nil
+			})
+		dao.EXPECT().FindById(int64(1)).Return(&model.SettlementProjection{Id: 1, Flagged: 4}, nil)
+		dao.EXPECT().Exceptions(int64(1)).Return([]model.SettlementExceptionProjection{{Id: 1}}, nil)
+		v, bx := svc.Reconcile(inp("LSAJAH2H-20230101.csv", "Merchant_Ref,Transaction_Id,Amount\n"+
+			"KZB-001,,15000\n,trx-002,15500\nKZB-001,trx-001,15000\nKZB-999,trx-999,1000\n"))
+		assert.Nil(t, bx)
+		assert.Equal(t, 4, v.Flagged)
+	})
+
+	t.Run("should success on json", func(t *testing.T) {
+		dao.EXPECT().Cashbacks("LSAJAH2H", "2023-01-01", []string{"KZB-001", "trx-002", "KZB-003"}).
+			Return(cashbacks, nil)
+		dao.EXPECT().Add(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(m model.Settlement, exceptions []model.SettlementException, settled []int64,
+				j model.LedgerJournal) (*int64, *model.TechnicalError) {
+				assert.Equal(t, 3, m.Matched)
+				assert.Equal(t, []int64{1, 2, 3}, settled)
+				assert.Len(t, j.Entries, 6)
+				assert.Empty(t, exceptions)
+				id := int64(2)
+				return &id, nil
+			})
+		dao.EXPECT().FindById(int64(2)).Return(&model.SettlementProjection{Id: 2}, nil)
+		dao.EXPECT().Exceptions(int64(2)).Return(nil, nil)
+		v, bx := svc.Reconcile(inp("LSAJAH2H-20230101.json", `[{"kezbek_ref_code":"KZB-001","amount":15000},
+			{"provider_ref_code":"trx-002","amount":"16000"},{"kezbek_ref_code":"KZB-003","amount":5000}]`))
+		assert.Nil(t, bx)
+		assert.Equal(t, int64(2), v.Id)
+	})
+
+	t.Run("should flag settled cashbacks as duplicate", func(t *testing.T) {
+		dao.EXPECT().Cashbacks("LSAJAH2H", "2023-01-01", gomock.Any()).Return([]model.SettlementCashbackProjection{
+			{Id: 1, KezbekRefCode: "KZB-001", Amount: decimal.NewFromInt(15000), OnDate: true, Settled: true},
+			{Id: 2, KezbekRefCode: "KZB-002", Amount: decimal.NewFromInt(16000), OnDate: true, Settled: true},
+		}, nil)
+		dao.EXPECT().Add(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(m model.Settlement, exceptions []model.SettlementException, settled []int64,
+				j model.LedgerJournal) (*int64, *model.TechnicalError) {
+				assert.Equal(t, 0, m.Matched)
+				assert.Empty(t, settled)
+				assert.Empty(t, j.Entries)
+				assert.Len(t, exceptions, 1)
+				assert.Equal(t, apps.SettlementDuplicate, exceptions[0].Type.String)
+				id := int64(3)
+				return &id, nil
+			})
+		dao.EXPECT().FindById(int64(3)).Return(&model.SettlementProjection{Id: 3, Flagged: 1}, nil)
+		dao.EXPECT().Exceptions(int64(3)).Return([]model.SettlementExceptionProjection{{Id: 2}}, nil)
+		v, bx := svc.Reconcile(inp("LSAJAH2H-20230101.csv", "kezbek_ref_code,amount\nKZB-001,15000\n"))
+		assert.Nil(t, bx)
+		assert.Equal(t, 1, v.Flagged)
+	})
+
+	t.Run("should return exception on invalid file", func(t *testing.T) {
+		for name, content := range map[string]string{
+			"settlement.csv":  "kezbek_ref_code,nominal\nKZB-001,15000\n",
+			"settlement.json": `{"kezbek_ref_code":"KZB-001"}`,
+			"settlement.xlsx": "KZB-001",
+			"empty.csv":       "kezbek_ref_code,amount\n",
+			"amount.csv":      "kezbek_ref_code,amount\nKZB-001,fifteen\n",
+			"noref.json":      `[{"amount":15000}]`,
+		} {
+			v, bx := svc.Reconcile(inp(name, content))
+			assert.Nil(t, v)
+			assert.Equal(t, apps.ErrCodeBussSettlementInvalid, bx.ErrorCode, name)
+		}
+	})
+
+	t.Run("should return exception on failed to find cashbacks", func(t *testing.T) {
+		dao.EXPECT().Cashbacks("LSAJAH2H", "2023-01-01", gomock.Any()).Return(nil, ex)
+		v, bx := svc.Reconcile(inp("settlement.csv", "kezbek_ref_code,amount\nKZB-001,15000\n"))
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeSomethingWrong, bx.ErrorCode)
+	})
+
+	t.Run("should return exception on failed to add", func(t *testing.T) {
+		dao.EXPECT().Cashbacks("LSAJAH2H", "2023-01-01", gomock.Any()).Return(cashbacks, nil)
+		dao.EXPECT().Add(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, ex)
+		v, bx := svc.Reconcile(inp("settlement.csv", "kezbek_ref_code,amount\nKZB-001,15000\n"))
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeSubmitted, bx.ErrorCode)
+	})
+}
+
+func TestSettlement_Report(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	dao := repository.NewMockSettlementPersister(ctrl)
+	svc := NewSettlement(Settlement{
+		Dao:    dao,
+		Logger: logger,
+	})
+	ex := &model.TechnicalError{
+		Exception: "something went wrong",
+		Occurred:  time.Now().Unix(),
+		Ticket:    "ERR-001",
+	}
+	t.Run("should success", func(t *testing.T) {
+		dao.EXPECT().FindById(int64(1)).Return(&model.SettlementProjection{Id: 1}, nil)
+		dao.EXPECT().Exceptions(int64(1)).Return([]model.SettlementExceptionProjection{{Id: 1}}, nil)
+		v, bx := svc.Report(1)
+		assert.Nil(t, bx)
+		assert.Len(t, v.Exceptions, 1)
+	})
+
+	t.Run("should return exception on settlement not found", func(t *testing.T) {
+		dao.EXPECT().FindById(int64(1)).Return(nil, ex)
+		v, bx := svc.Report(1)
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeNotFound, bx.ErrorCode)
+	})
+
+	t.Run("should return exception on failed to find exceptions", func(t *testing.T) {
+		dao.EXPECT().FindById(int64(1)).Return(&model.SettlementProjection{Id: 1}, nil)
+		dao.EXPECT().Exceptions(int64(1)).Return(nil, ex)
+		v, bx := svc.Report(1)
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeSomethingWrong, bx.ErrorCode)
+	})
+}
+
+func TestSettlement_Search(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	dao := repository.NewMockSettlementPersister(ctrl)
+	svc := NewSettlement(Settlement{
+		Dao:    dao,
+		Logger: logger,
+	})
+	t.Run("should success", func(t *testing.T) {
+		count := 1
+		dao.EXPECT().Count().Return(&count, nil)
+		dao.EXPECT().Search(gomock.Any()).Return([]model.SettlementProjection{{Id: 1}}, nil)
+		v, bx := svc.Search(&model.SearchRequest{Limit: 5, Start: 0})
+		assert.Nil(t, bx)
+		assert.Len(t, v.Settlements, 1)
+	})
+
+	t.Run("should return exception on failed to search", func(t *testing.T) {
+		dao.EXPECT().Count().Return(nil, &model.TechnicalError{Exception: "something went wrong"})
+		dao.EXPECT().Search(gomock.Any()).Return(nil, nil)
+		v, bx := svc.Search(&model.SearchRequest{Limit: 5, Start: 0})
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeNotFound, bx.ErrorCode)
+	})
+}
+
+func TestSettlement_Resolve(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	dao := repository.NewMockSettlementPersister(ctrl)
+	svc := NewSettlement(Settlement{
+		Dao:    dao,
+		Logger: logger,
+	})
+	inp := &model.SettlementResolveRequest{
+		Id:             1,
+		Notes:          "Reward was settled on the next statement",
+		SessionRequest: model.SessionRequest{Id: 2},
+	}
+	exception := func(status string) *model.SettlementException {
+		return &model.SettlementException{
+			Id:           1,
+			SettlementId: 1,
+			Status:       sql.NullString{String: status, Valid: true},
+		}
+	}
+	ex := &model.TechnicalError{
+		Exception: "something went wrong",
+		Occurred:  time.Now().Unix(),
+		Ticket:    "ERR-001",
+	}
+	t.Run("should success", func(t *testing.T) {
+		dao.EXPECT().FindExceptionById(int64(1)).Return(exception(apps.SettlementOpen), nil)
+		dao.EXPECT().Resolve(gomock.Any()).DoAndReturn(func(m model.SettlementException) *model.TechnicalError {
+			assert.Equal(t, inp.Notes, m.Notes.String)
+			assert.Equal(t, int64(2), m.UpdatedBy.Int64)
+			return nil
+		})
+		v, bx := svc.Resolve(inp)
+		assert.Nil(t, bx)
+		assert.NotNil(t, v)
+	})
+
+	t.Run("should return exception on exception not found", func(t *testing.T) {
+		dao.EXPECT().FindExceptionById(int64(1)).Return(nil, ex)
+		v, bx := svc.Resolve(inp)
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeNotFound, bx.ErrorCode)
+	})
+
+	t.Run("should return exception on resolved exception", func(t *testing.T) {
+		dao.EXPECT().FindExceptionById(int64(1)).Return(exception(apps.SettlementResolved), nil)
+		v, bx := svc.Resolve(inp)
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeBussExceptionResolved, bx.ErrorCode)
+	})
+
+	t.Run("should return exception on failed to resolve", func(t *testing.T) {
+		dao.EXPECT().FindExceptionById(int64(1)).Return(exception(apps.SettlementOpen), nil)
+		dao.EXPECT().Resolve(gomock.Any()).Return(ex)
+		v, bx := svc.Resolve(inp)
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeSubmitted, bx.ErrorCode)
+	})
+}
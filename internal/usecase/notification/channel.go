@@ -0,0 +1,55 @@
+package notification
+
+import (
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/storage"
+	"go.uber.org/zap"
+	"strings"
+)
+
+const groupChannel = "NOTIFICATION_CHANNEL"
+
+var channels = []string{apps.NotificationChannelEmail, apps.NotificationChannelSms, apps.NotificationChannelWhatsapp}
+
+type Channel struct {
+	Cacher  storage.Cacher
+	Default string
+	Logger  *zap.Logger
+}
+
+type ChannelProvider interface {
+	Select(event string, partner string, email string) string
+}
+
+func NewChannel(c Channel) ChannelProvider {
+	return &c
+}
+
+func ChannelKey(event string, partner string) string {
+	if partner == "" {
+		return event
+	}
+	return event + "." + partner
+}
+
+func (c *Channel) rule(event string, partner string) string {
+	for _, k := range []string{ChannelKey(event, partner), ChannelKey(event, "")} {
+		v, ex := c.Cacher.Hget(groupChannel, k)
+		if ex == nil && apps.StringExists(strings.ToUpper(v), channels) {
+			return strings.ToUpper(v)
+		}
+	}
+	if apps.StringExists(strings.ToUpper(c.Default), channels) {
+		return strings.ToUpper(c.Default)
+	}
+	return apps.NotificationChannelEmail
+}
+
+func (c *Channel) Select(event string, partner string, email string) string {
+	v := c.rule(event, partner)
+	if v == apps.NotificationChannelEmail && email == "" {
+		c.Logger.Info("no email to notify, fallback to sms", zap.String("event", event), zap.String("partner", partner))
+		return apps.NotificationChannelSms
+	}
+	return v
+}
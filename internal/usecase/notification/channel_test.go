@@ -0,0 +1,44 @@
+package notification
+
+import (
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/mock/storage"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestChannel_Select(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	cacher := storage.NewMockCacher(ctrl)
+	svc := NewChannel(Channel{
+		Cacher: cacher,
+		Logger: logger,
+	})
+	miss := &model.TechnicalError{Exception: "redis: nil", Occurred: time.Now().Unix(), Ticket: "ERR-001"}
+	t.Run("should success with partner rule", func(t *testing.T) {
+		cacher.EXPECT().Hget("NOTIFICATION_CHANNEL", "INVOICE.LSAJA").Return("whatsapp", nil)
+		assert.Equal(t, apps.NotificationChannelWhatsapp, svc.Select("INVOICE", "LSAJA", "someone@email.net"))
+	})
+
+	t.Run("should success with event rule", func(t *testing.T) {
+		cacher.EXPECT().Hget("NOTIFICATION_CHANNEL", "INVOICE.LSAJA").Return("", miss)
+		cacher.EXPECT().Hget("NOTIFICATION_CHANNEL", "INVOICE").Return(apps.NotificationChannelSms, nil)
+		assert.Equal(t, apps.NotificationChannelSms, svc.Select("INVOICE", "LSAJA", "someone@email.net"))
+	})
+
+	t.Run("should success with default email", func(t *testing.T) {
+		cacher.EXPECT().Hget("NOTIFICATION_CHANNEL", "INVOICE.LSAJA").Return("", miss)
+		cacher.EXPECT().Hget("NOTIFICATION_CHANNEL", "INVOICE").Return("PIGEON", nil)
+		assert.Equal(t, apps.NotificationChannelEmail, svc.Select("INVOICE", "LSAJA", "someone@email.net"))
+	})
+
+	t.Run("should fallback to sms when email is empty", func(t *testing.T) {
+		cacher.EXPECT().Hget("NOTIFICATION_CHANNEL", "INVOICE.LSAJA").Return(apps.NotificationChannelEmail, nil)
+		assert.Equal(t, apps.NotificationChannelSms, svc.Select("INVOICE", "LSAJA", ""))
+	})
+}
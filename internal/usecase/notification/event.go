@@ -0,0 +1,70 @@
+package notification
+
+import (
+	"encoding/json"
+	"github.com/adinandradrs/cezbek-engine/internal/adaptor"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"go.uber.org/zap"
+)
+
+type Event struct {
+	SqsAdapter         adaptor.SQSAdapter
+	TemplateProvider   TemplateProvider
+	ChannelProvider    ChannelProvider
+	PreferenceProvider PreferenceProvider
+	Queue              *string
+	Logger             *zap.Logger
+}
+
+type EventProvider interface {
+	Publish(inp *model.NotificationEvent) *model.BusinessError
+}
+
+func NewEvent(e Event) EventProvider {
+	return &e
+}
+
+func (e *Event) Publish(inp *model.NotificationEvent) *model.BusinessError {
+	if !e.PreferenceProvider.Allowed(inp.Msisdn, inp.Email, inp.Category) {
+		e.Logger.Info("customer opted out of notification", zap.String("event", inp.Event),
+			zap.String("msisdn", inp.Msisdn))
+		return nil
+	}
+	channel := e.ChannelProvider.Select(inp.Event, inp.Partner, inp.Email)
+	name, destination := inp.Event, inp.Email
+	if channel != apps.NotificationChannelEmail {
+		name, destination = inp.Event+TextSuffix, inp.Msisdn
+	}
+	data := map[string]interface{}{}
+	for k, v := range inp.Data {
+		data[k] = v
+	}
+	data["unsubscribeUrl"] = e.PreferenceProvider.UnsubscribeLink(inp.Msisdn, inp.Email, inp.Category)
+	v, bx := e.TemplateProvider.Render(name, inp.Locale, data)
+	if bx != nil {
+		e.Logger.Error("failed to render notification", zap.String("event", inp.Event),
+			zap.String("channel", channel))
+		return bx
+	}
+	msg, err := json.Marshal(model.NotificationRequest{
+		Channel:     channel,
+		Content:     v.Content,
+		Subject:     v.Subject,
+		Destination: destination,
+	})
+	if err != nil {
+		return &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSomethingWrong,
+			ErrorMessage: apps.ErrMsgSomethingWrong,
+		}
+	}
+	err = e.SqsAdapter.SendMessage(*e.Queue, string(msg))
+	if err != nil {
+		return &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSomethingWrong,
+			ErrorMessage: apps.ErrMsgSomethingWrong,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package notification
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/mock/adaptor"
+	mock "github.com/adinandradrs/cezbek-engine/mock/usecase/notification"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestEvent_Publish(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	sqsAdapter, templateProvider, channelProvider, preferenceProvider := adaptor.NewMockSQSAdapter(ctrl),
+		mock.NewMockTemplateProvider(ctrl), mock.NewMockChannelProvider(ctrl), mock.NewMockPreferenceProvider(ctrl)
+	queue := "mock-queue"
+	svc := NewEvent(Event{
+		SqsAdapter:         sqsAdapter,
+		TemplateProvider:   templateProvider,
+		ChannelProvider:    channelProvider,
+		PreferenceProvider: preferenceProvider,
+		Queue:              &queue,
+		Logger:             logger,
+	})
+	inp := &model.NotificationEvent{
+		Event:    apps.NotificationEventTierUpgraded,
+		Category: apps.NotificationCategoryTier,
+		Partner:  "LSAJA",
+		Msisdn:   "628118770510",
+		Email:    "someone@email.net",
+		Locale:   "id",
+		Data:     map[string]interface{}{"tier": "GOLD"},
+	}
+	link := "https://kezbek.id/api/v1/unsubscribe?token=abc"
+
+	t.Run("should success on email", func(t *testing.T) {
+		preferenceProvider.EXPECT().Allowed(inp.Msisdn, inp.Email, apps.NotificationCategoryTier).Return(true)
+		preferenceProvider.EXPECT().UnsubscribeLink(inp.Msisdn, inp.Email, apps.NotificationCategoryTier).Return(link)
+		channelProvider.EXPECT().Select(apps.NotificationEventTierUpgraded, "LSAJA", inp.Email).
+			Return(apps.NotificationChannelEmail)
+		templateProvider.EXPECT().Render(apps.NotificationEventTierUpgraded, "id", gomock.Any()).DoAndReturn(
+			func(name string, locale string, data map[string]interface{}) (*model.NotificationContent, *model.BusinessError) {
+				assert.Equal(t, "GOLD", data["tier"])
+				assert.Equal(t, link, data["unsubscribeUrl"])
+				return &model.NotificationContent{Subject: "Your tier", Content: "The content"}, nil
+			})
+		sqsAdapter.EXPECT().SendMessage(queue, gomock.Any()).DoAndReturn(func(q string, m string) error {
+			msg := model.NotificationRequest{}
+			_ = json.Unmarshal([]byte(m), &msg)
+			assert.Equal(t, apps.NotificationChannelEmail, msg.Channel)
+			assert.Equal(t, inp.Email, msg.Destination)
+			assert.Equal(t, "Your tier", msg.Subject)
+			return nil
+		})
+		ex := svc.Publish(inp)
+		assert.Nil(t, ex)
+		assert.Nil(t, inp.Data["unsubscribeUrl"])
+	})
+
+	t.Run("should success on sms with the text template", func(t *testing.T) {
+		preferenceProvider.EXPECT().Allowed(inp.Msisdn, inp.Email, apps.NotificationCategoryTier).Return(true)
+		preferenceProvider.EXPECT().UnsubscribeLink(inp.Msisdn, inp.Email, apps.NotificationCategoryTier).Return(link)
+		channelProvider.EXPECT().Select(apps.NotificationEventTierUpgraded, "LSAJA", inp.Email).
+			Return(apps.NotificationChannelSms)
+		templateProvider.EXPECT().Render(apps.NotificationEventTierUpgraded+TextSuffix, "id", gomock.Any()).
+			Return(&model.NotificationContent{Content: "The content"}, nil)
+		sqsAdapter.EXPECT().SendMessage(queue, gomock.Any()).DoAndReturn(func(q string, m string) error {
+			msg := model.NotificationRequest{}
+			_ = json.Unmarshal([]byte(m), &msg)
+			assert.Equal(t, apps.NotificationChannelSms, msg.Channel)
+			assert.Equal(t, inp.Msisdn, msg.Destination)
+			return nil
+		})
+		ex := svc.Publish(inp)
+		assert.Nil(t, ex)
+	})
+
+	t.Run("should success without queue for opted out customer", func(t *testing.T) {
+		preferenceProvider.EXPECT().Allowed(inp.Msisdn, inp.Email, apps.NotificationCategoryTier).Return(false)
+		ex := svc.Publish(inp)
+		assert.Nil(t, ex)
+	})
+
+	t.Run("should return exception on failed to render", func(t *testing.T) {
+		preferenceProvider.EXPECT().Allowed(inp.Msisdn, inp.Email, apps.NotificationCategoryTier).Return(true)
+		preferenceProvider.EXPECT().UnsubscribeLink(inp.Msisdn, inp.Email, apps.NotificationCategoryTier).Return(link)
+		channelProvider.EXPECT().Select(apps.NotificationEventTierUpgraded, "LSAJA", inp.Email).
+			Return(apps.NotificationChannelEmail)
+		templateProvider.EXPECT().Render(apps.NotificationEventTierUpgraded, "id", gomock.Any()).
+			Return(nil, &model.BusinessError{
+				ErrorCode:    apps.ErrCodeBussTemplateInvalid,
+				ErrorMessage: apps.ErrMsgBussTemplateInvalid,
+			})
+		ex := svc.Publish(inp)
+		assert.Equal(t, apps.ErrCodeBussTemplateInvalid, ex.ErrorCode)
+	})
+
+	t.Run("should return exception on failed to queue", func(t *testing.T) {
+		preferenceProvider.EXPECT().Allowed(inp.Msisdn, inp.Email, apps.NotificationCategoryTier).Return(true)
+		preferenceProvider.EXPECT().UnsubscribeLink(inp.Msisdn, inp.Email, apps.NotificationCategoryTier).Return(link)
+		channelProvider.EXPECT().Select(apps.NotificationEventTierUpgraded, "LSAJA", inp.Email).
+			Return(apps.NotificationChannelEmail)
+		templateProvider.EXPECT().Render(apps.NotificationEventTierUpgraded, "id", gomock.Any()).
+			Return(&model.NotificationContent{Subject: "Your tier", Content: "The content"}, nil)
+		sqsAdapter.EXPECT().SendMessage(queue, gomock.Any()).Return(fmt.Errorf("something went wrong"))
+		ex := svc.Publish(inp)
+		assert.Equal(t, apps.ErrCodeSomethingWrong, ex.ErrorCode)
+	})
+}
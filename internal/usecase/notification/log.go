@@ -0,0 +1,110 @@
+package notification
+
+import (
+	"encoding/json"
+	"github.com/adinandradrs/cezbek-engine/internal/adaptor"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/internal/repository"
+	"go.uber.org/zap"
+	"time"
+)
+
+const (
+	defaultResendLimit = 100
+	maxResendLimit     = 500
+)
+
+type Log struct {
+	Dao        repository.NotificationPersister
+	SqsAdapter adaptor.SQSAdapter
+	Logger     *zap.Logger
+}
+
+type LogProvider interface {
+	Search(inp *model.NotificationSearchRequest) (*model.NotificationSearchResponse, *model.BusinessError)
+	Resend(inp *model.NotificationResendRequest) (*model.NotificationResendResponse, *model.BusinessError)
+}
+
+func NewLog(l Log) LogProvider {
+	return &l
+}
+
+func (l *Log) Search(inp *model.NotificationSearchRequest) (*model.NotificationSearchResponse, *model.BusinessError) {
+	model.Page(&inp.SearchRequest)
+	c, countEx := l.Dao.Count(inp)
+	v, searchEx := l.Dao.Search(inp)
+	if countEx != nil || searchEx != nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeNotFound,
+			ErrorMessage: apps.ErrMsgNotFound,
+		}
+	}
+	return &model.NotificationSearchResponse{
+		Notifications:      v,
+		PaginationResponse: model.Pagination(*c, inp.Limit, inp.Start),
+	}, nil
+}
+
+func (l *Log) resendable(inp *model.NotificationResendRequest) ([]model.NotificationLog, *model.TechnicalError) {
+	if len(inp.Ids) > 0 {
+		return l.Dao.FindByIds(inp.Ids)
+	}
+	if inp.Limit <= 0 {
+		inp.Limit = defaultResendLimit
+	}
+	if inp.Limit > maxResendLimit {
+		inp.Limit = maxResendLimit
+	}
+	return l.Dao.FindByStatus(inp.Status, inp.Limit)
+}
+
+func (l *Log) Resend(inp *model.NotificationResendRequest) (*model.NotificationResendResponse, *model.BusinessError) {
+	logs, ex := l.resendable(inp)
+	if ex != nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSomethingWrong,
+			ErrorMessage: apps.ErrMsgSomethingWrong,
+		}
+	}
+	ids, candidates := []int64{}, 0
+	for _, v := range logs {
+		if v.Status.String != apps.NotificationFailed && v.Status.String != apps.NotificationDead {
+			continue
+		}
+		candidates++
+		msg, _ := json.Marshal(model.NotificationRequest{
+			Channel:     v.Channel.String,
+			Destination: v.Destination.String,
+			Subject:     v.Subject.String,
+			Content:     v.Content.String,
+		})
+		if err := l.SqsAdapter.SendMessage(v.Queue.String, string(msg)); err != nil {
+			l.Logger.Error("failed to resend notification", zap.Int64("id", v.Id), zap.Error(err))
+			continue
+		}
+		ids = append(ids, v.Id)
+	}
+	if candidates == 0 {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeNotFound,
+			ErrorMessage: apps.ErrMsgNotFound,
+		}
+	}
+	if len(ids) == 0 {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSubmitted,
+			ErrorMessage: apps.ErrMsgSubmitted,
+		}
+	}
+	if ex = l.Dao.UpdateStatus(ids, apps.NotificationResent); ex != nil {
+		l.Logger.Error("failed to mark notification as resent", zap.Int64s("ids", ids))
+	}
+	return &model.NotificationResendResponse{
+		Total: len(ids),
+		TransactionResponse: model.TransactionResponse{
+			TransactionId:        apps.TransactionId(apps.DefaultTrxId + apps.DefaultTrxId),
+			TransactionTimestamp: time.Now().Unix(),
+		},
+	}, nil
+}
@@ -0,0 +1,123 @@
+package notification
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/mock/adaptor"
+	"github.com/adinandradrs/cezbek-engine/mock/repository"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLog_Search(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	dao := repository.NewMockNotificationPersister(ctrl)
+	svc := NewLog(Log{
+		Dao:    dao,
+		Logger: logger,
+	})
+	inp := &model.NotificationSearchRequest{
+		Status: apps.NotificationDead,
+		SearchRequest: model.SearchRequest{
+			Start: 0,
+			Limit: 5,
+		},
+	}
+	count := 1
+	t.Run("should success", func(t *testing.T) {
+		dao.EXPECT().Count(inp).Return(&count, nil)
+		dao.EXPECT().Search(inp).Return([]model.NotificationLogProjection{{Id: 1}}, nil)
+		v, ex := svc.Search(inp)
+		assert.Nil(t, ex)
+		assert.Len(t, v.Notifications, 1)
+	})
+
+	t.Run("should return not found on failed to search", func(t *testing.T) {
+		dao.EXPECT().Count(inp).Return(&count, nil)
+		dao.EXPECT().Search(inp).Return(nil, &model.TechnicalError{
+			Exception: "something went wrong",
+		})
+		v, ex := svc.Search(inp)
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeNotFound, ex.ErrorCode)
+	})
+}
+
+func TestLog_Resend(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	dao := repository.NewMockNotificationPersister(ctrl)
+	sqsAdapter := adaptor.NewMockSQSAdapter(ctrl)
+	svc := NewLog(Log{
+		Dao:        dao,
+		SqsAdapter: sqsAdapter,
+		Logger:     logger,
+	})
+	logs := []model.NotificationLog{
+		{
+			Id:          1,
+			Queue:       sql.NullString{String: "notification_email_otp", Valid: true},
+			Destination: sql.NullString{String: "someone@email.net", Valid: true},
+			Subject:     sql.NullString{String: "OTP", Valid: true},
+			Content:     sql.NullString{String: "<p>123456</p>", Valid: true},
+			Status:      sql.NullString{String: apps.NotificationDead, Valid: true},
+		},
+		{
+			Id:     2,
+			Status: sql.NullString{String: apps.NotificationSent, Valid: true},
+		},
+	}
+	t.Run("should success by ids", func(t *testing.T) {
+		inp := &model.NotificationResendRequest{Ids: []int64{1, 2}}
+		dao.EXPECT().FindByIds(inp.Ids).Return(logs, nil)
+		sqsAdapter.EXPECT().SendMessage("notification_email_otp", gomock.Any()).Return(nil)
+		dao.EXPECT().UpdateStatus([]int64{1}, apps.NotificationResent).Return(nil)
+		v, ex := svc.Resend(inp)
+		assert.Nil(t, ex)
+		assert.Equal(t, 1, v.Total)
+	})
+
+	t.Run("should success by status with default limit", func(t *testing.T) {
+		inp := &model.NotificationResendRequest{Status: apps.NotificationDead}
+		dao.EXPECT().FindByStatus(apps.NotificationDead, defaultResendLimit).Return(logs[:1], nil)
+		sqsAdapter.EXPECT().SendMessage("notification_email_otp", gomock.Any()).Return(nil)
+		dao.EXPECT().UpdateStatus([]int64{1}, apps.NotificationResent).Return(nil)
+		v, ex := svc.Resend(inp)
+		assert.Nil(t, ex)
+		assert.Equal(t, 1, v.Total)
+	})
+
+	t.Run("should return not found when nothing is resendable", func(t *testing.T) {
+		inp := &model.NotificationResendRequest{Ids: []int64{2}}
+		dao.EXPECT().FindByIds(inp.Ids).Return(logs[1:], nil)
+		v, ex := svc.Resend(inp)
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeNotFound, ex.ErrorCode)
+	})
+
+	t.Run("should return submitted error when failed to queue", func(t *testing.T) {
+		inp := &model.NotificationResendRequest{Ids: []int64{1}}
+		dao.EXPECT().FindByIds(inp.Ids).Return(logs[:1], nil)
+		sqsAdapter.EXPECT().SendMessage("notification_email_otp", gomock.Any()).
+			Return(fmt.Errorf("something went wrong"))
+		v, ex := svc.Resend(inp)
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeSubmitted, ex.ErrorCode)
+	})
+
+	t.Run("should return something wrong on failed to find", func(t *testing.T) {
+		inp := &model.NotificationResendRequest{Ids: []int64{1}}
+		dao.EXPECT().FindByIds(inp.Ids).Return(nil, &model.TechnicalError{
+			Exception: "something went wrong",
+		})
+		v, ex := svc.Resend(inp)
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeSomethingWrong, ex.ErrorCode)
+	})
+}
@@ -0,0 +1,113 @@
+package notification
+
+import (
+	"crypto/hmac"
+	"database/sql"
+	"encoding/base64"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/internal/repository"
+	"go.uber.org/zap"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var categories = []string{apps.NotificationCategoryTransactional, apps.NotificationCategoryTier,
+	apps.NotificationCategoryMarketing}
+
+type Preference struct {
+	Dao            repository.PreferencePersister
+	UnsubscribeUrl string
+	Secret         string
+	Logger         *zap.Logger
+}
+
+type PreferenceProvider interface {
+	Allowed(msisdn string, email string, category string) bool
+	UnsubscribeLink(msisdn string, email string, category string) string
+	Confirm(inp *model.UnsubscribeRequest) (*model.UnsubscribeConfirmationResponse, *model.BusinessError)
+	Unsubscribe(inp *model.UnsubscribeRequest) (*model.UnsubscribeResponse, *model.BusinessError)
+}
+
+func NewPreference(p Preference) PreferenceProvider {
+	return &p
+}
+
+func (p *Preference) Allowed(msisdn string, email string, category string) bool {
+	v, ex := p.Dao.CountOptedOut(msisdn, email, category)
+	if ex != nil {
+		p.Logger.Warn("failed to check notification preference", zap.String("msisdn", msisdn),
+			zap.String("category", category))
+		return true
+	}
+	return *v == 0
+}
+
+func (p *Preference) token(msisdn string, email string, category string) string {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(strings.Join([]string{msisdn, email, category}, "|")))
+	return payload + "." + apps.HMAC(payload, p.Secret)
+}
+
+func (p *Preference) UnsubscribeLink(msisdn string, email string, category string) string {
+	return p.UnsubscribeUrl + "?token=" + url.QueryEscape(p.token(msisdn, email, category))
+}
+
+func (p *Preference) parse(token string) ([]string, bool) {
+	parts := strings.SplitN(token, ".", 2)
+	if len(parts) != 2 || !hmac.Equal([]byte(parts[1]), []byte(apps.HMAC(parts[0], p.Secret))) {
+		return nil, false
+	}
+	b, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, false
+	}
+	v := strings.Split(string(b), "|")
+	if len(v) != 3 || v[0] == "" || !apps.StringExists(v[2], categories) {
+		return nil, false
+	}
+	return v, true
+}
+
+func (p *Preference) Confirm(inp *model.UnsubscribeRequest) (*model.UnsubscribeConfirmationResponse, *model.BusinessError) {
+	v, ok := p.parse(inp.Token)
+	if !ok {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeBussUnsubscribeInvalid,
+			ErrorMessage: apps.ErrMsgBussUnsubscribeInvalid,
+		}
+	}
+	return &model.UnsubscribeConfirmationResponse{
+		Category: v[2],
+		Token:    inp.Token,
+	}, nil
+}
+
+func (p *Preference) Unsubscribe(inp *model.UnsubscribeRequest) (*model.UnsubscribeResponse, *model.BusinessError) {
+	v, ok := p.parse(inp.Token)
+	if !ok {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeBussUnsubscribeInvalid,
+			ErrorMessage: apps.ErrMsgBussUnsubscribeInvalid,
+		}
+	}
+	ex := p.Dao.Save(model.NotificationPreference{
+		Msisdn:   sql.NullString{String: v[0], Valid: true},
+		Email:    sql.NullString{String: v[1], Valid: true},
+		Category: sql.NullString{String: v[2], Valid: true},
+		OptedOut: true,
+	})
+	if ex != nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSubmitted,
+			ErrorMessage: apps.ErrMsgSubmitted,
+		}
+	}
+	return &model.UnsubscribeResponse{
+		Category: v[2],
+		TransactionResponse: model.TransactionResponse{
+			TransactionId:        apps.TransactionId(apps.DefaultTrxId + apps.DefaultTrxId),
+			TransactionTimestamp: time.Now().Unix(),
+		},
+	}, nil
+}
@@ -0,0 +1,114 @@
+package notification
+
+import (
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/mock/repository"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestPreference_Allowed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	dao := repository.NewMockPreferencePersister(ctrl)
+	svc := NewPreference(Preference{
+		Dao:    dao,
+		Logger: logger,
+	})
+	t.Run("should allow when not opted out", func(t *testing.T) {
+		count := 0
+		dao.EXPECT().CountOptedOut("628118770510", "someone@email.net", apps.NotificationCategoryTransactional).
+			Return(&count, nil)
+		assert.True(t, svc.Allowed("628118770510", "someone@email.net", apps.NotificationCategoryTransactional))
+	})
+
+	t.Run("should not allow when opted out", func(t *testing.T) {
+		count := 1
+		dao.EXPECT().CountOptedOut("628118770510", "", apps.NotificationCategoryTier).Return(&count, nil)
+		assert.False(t, svc.Allowed("628118770510", "", apps.NotificationCategoryTier))
+	})
+
+	t.Run("should allow on failed to check", func(t *testing.T) {
+		dao.EXPECT().CountOptedOut("628118770510", "", apps.NotificationCategoryTier).
+			Return(nil, &model.TechnicalError{Exception: "something went wrong"})
+		assert.True(t, svc.Allowed("628118770510", "", apps.NotificationCategoryTier))
+	})
+}
+
+func TestPreference_Unsubscribe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	dao := repository.NewMockPreferencePersister(ctrl)
+	svc := NewPreference(Preference{
+		Dao:            dao,
+		UnsubscribeUrl: "https://kezbek.id/api/v1/unsubscribe",
+		Secret:         "secret",
+		Logger:         logger,
+	})
+	link := svc.UnsubscribeLink("628118770510", "someone@email.net", apps.NotificationCategoryTransactional)
+	u, _ := url.Parse(link)
+	token := u.Query().Get("token")
+	t.Run("should success", func(t *testing.T) {
+		assert.True(t, strings.HasPrefix(link, "https://kezbek.id/api/v1/unsubscribe?token="))
+		dao.EXPECT().Save(gomock.Any()).DoAndReturn(func(m model.NotificationPreference) *model.TechnicalError {
+			assert.Equal(t, "628118770510", m.Msisdn.String)
+			assert.Equal(t, "someone@email.net", m.Email.String)
+			assert.Equal(t, apps.NotificationCategoryTransactional, m.Category.String)
+			assert.True(t, m.OptedOut)
+			return nil
+		})
+		v, ex := svc.Unsubscribe(&model.UnsubscribeRequest{Token: token})
+		assert.Nil(t, ex)
+		assert.Equal(t, apps.NotificationCategoryTransactional, v.Category)
+	})
+
+	t.Run("should return exception on tampered token", func(t *testing.T) {
+		v, ex := svc.Unsubscribe(&model.UnsubscribeRequest{Token: "X" + token})
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeBussUnsubscribeInvalid, ex.ErrorCode)
+	})
+
+	t.Run("should return exception on token signed by another secret", func(t *testing.T) {
+		other := NewPreference(Preference{UnsubscribeUrl: "https://kezbek.id", Secret: "another"})
+		u, _ := url.Parse(other.UnsubscribeLink("628118770510", "", apps.NotificationCategoryMarketing))
+		v, ex := svc.Unsubscribe(&model.UnsubscribeRequest{Token: u.Query().Get("token")})
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeBussUnsubscribeInvalid, ex.ErrorCode)
+	})
+
+	t.Run("should return exception on failed to save", func(t *testing.T) {
+		dao.EXPECT().Save(gomock.Any()).Return(&model.TechnicalError{Exception: "something went wrong"})
+		v, ex := svc.Unsubscribe(&model.UnsubscribeRequest{Token: token})
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeSubmitted, ex.ErrorCode)
+	})
+}
+
+func TestPreference_Confirm(t *testing.T) {
+	logger, _ := apps.NewLog(false)
+	svc := NewPreference(Preference{
+		UnsubscribeUrl: "https://kezbek.id/api/v1/unsubscribe",
+		Secret:         "secret",
+		Logger:         logger,
+	})
+	u, _ := url.Parse(svc.UnsubscribeLink("628118770510", "someone@email.net", apps.NotificationCategoryMarketing))
+	token := u.Query().Get("token")
+	t.Run("should success without opting out", func(t *testing.T) {
+		v, ex := svc.Confirm(&model.UnsubscribeRequest{Token: token})
+		assert.Nil(t, ex)
+		assert.Equal(t, apps.NotificationCategoryMarketing, v.Category)
+		assert.Equal(t, token, v.Token)
+	})
+
+	t.Run("should return exception on tampered token", func(t *testing.T) {
+		v, ex := svc.Confirm(&model.UnsubscribeRequest{Token: "X" + token})
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeBussUnsubscribeInvalid, ex.ErrorCode)
+	})
+}
@@ -0,0 +1,328 @@
+package notification
+
+import (
+	"bytes"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/internal/repository"
+	"github.com/adinandradrs/cezbek-engine/internal/storage"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+	htmltemplate "html/template"
+	"regexp"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+const (
+	groupTemplate = "EMAIL_TEMPLATE"
+	groupSubject  = "EMAIL_SUBJECT"
+)
+
+const TextSuffix = "_TEXT"
+
+var legacyPlaceholder = regexp.MustCompile(`\$\{(\w+)\}`)
+
+var months = map[string][]string{
+	apps.LocaleIndonesian: {"Jan", "Feb", "Mar", "Apr", "Mei", "Jun", "Jul", "Agu", "Sep", "Okt", "Nov", "Des"},
+}
+
+var samples = map[string]map[string]interface{}{
+	"OTP": {
+		"partner": "PT. Lajada Piranti Commerce",
+		"otp":     "123456",
+	},
+	"OTP_LOCKED": {
+		"partner":  "PT. Lajada Piranti Commerce",
+		"duration": "15m0s",
+	},
+	"OFFICER_INVITE": {
+		"partner":  "PT. Lajada Piranti Commerce",
+		"fullname": "John Doe",
+		"role":     apps.RoleOfficerFinance,
+	},
+	"INVOICE": {
+		"reference":         "C00223010112000062811",
+		"msisdn":            "628123456789",
+		"email":             "john.doe@email.net",
+		"walletCode":        "LSAJA",
+		"partner":           "PT. Lajada Piranti Commerce",
+		"qty":               2,
+		"transactionAmount": decimal.NewFromInt(1500000),
+		"cashbackAmount":    decimal.NewFromInt(15000),
+		"date":              time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+		"unsubscribeUrl":    "https://kezbek.id/api/v1/unsubscribe?token=sample",
+		"receiptUrl":        "https://cdn.kezbek.id/receipt/1/C00223010112000062811-8f2d0a6c1b9e4d57.pdf",
+	},
+	apps.NotificationEventTierUpgraded: {
+		"partner":              "PT. Lajada Piranti Commerce",
+		"msisdn":               "628123456789",
+		"tier":                 "SILVER",
+		"prevTier":             "BRONZE",
+		"reward":               decimal.NewFromInt(13000),
+		"nextTier":             "GOLD",
+		"nextTierTransactions": 4,
+		"expiredDate":          time.Date(2023, 2, 1, 12, 0, 0, 0, time.UTC),
+		"unsubscribeUrl":       "https://kezbek.id/api/v1/unsubscribe?token=sample",
+	},
+	apps.NotificationEventTierDowngraded: {
+		"partner":              "PT. Lajada Piranti Commerce",
+		"msisdn":               "628123456789",
+		"tier":                 "BRONZE",
+		"prevTier":             "SILVER",
+		"reward":               decimal.Zero,
+		"nextTier":             "SILVER",
+		"nextTierTransactions": 2,
+		"expiredDate":          time.Date(2023, 2, 1, 12, 0, 0, 0, time.UTC),
+		"unsubscribeUrl":       "https://kezbek.id/api/v1/unsubscribe?token=sample",
+	},
+	apps.NotificationEventBilling: {
+		"partner":    "PT. Lajada Piranti Commerce",
+		"invoiceNo":  "INV/LAJADA/202301",
+		"period":     "2023-01",
+		"total":      decimal.NewFromInt(2073830),
+		"dueDate":    time.Date(2023, 2, 15, 12, 0, 0, 0, time.UTC),
+		"invoiceUrl": "https://cdn.kezbek.id/invoice/1/INV-LAJADA-202301-8f2d0a6c1b9e4d57.pdf",
+	},
+	apps.NotificationEventDepositLow: {
+		"partner":   "PT. Lajada Piranti Commerce",
+		"available": decimal.NewFromInt(984000),
+		"threshold": decimal.NewFromInt(1000000),
+	},
+	apps.NotificationEventTransactionExport: {
+		"partner": "PT. Lajada Piranti Commerce",
+		"format":  "XLSX",
+		"url":     "https://kezbek.s3.amazonaws.com/export/1/transactions-20230101120000-sample.xlsx",
+		"expiry":  time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC),
+	},
+}
+
+type Template struct {
+	Dao           repository.ParamPersister
+	Cacher        storage.Cacher
+	DefaultLocale string
+	Logger        *zap.Logger
+}
+
+type TemplateProvider interface {
+	Render(name string, locale string, data map[string]interface{}) (*model.NotificationContent, *model.BusinessError)
+	Preview(inp *model.TemplatePreviewRequest) (*model.TemplatePreviewResponse, *model.BusinessError)
+}
+
+func NewTemplate(t Template) TemplateProvider {
+	return &t
+}
+
+func IDR(v interface{}) string {
+	var d decimal.Decimal
+	switch n := v.(type) {
+	case decimal.Decimal:
+		d = n
+	case int:
+		d = decimal.NewFromInt(int64(n))
+	case int64:
+		d = decimal.NewFromInt(n)
+	case float64:
+		d = decimal.NewFromFloat(n)
+	case string:
+		d, _ = decimal.NewFromString(n)
+	}
+	sign := ""
+	if d.IsNegative() {
+		sign, d = "-", d.Neg()
+	}
+	d = d.Round(2)
+	whole := d.Truncate(0).String()
+	var b strings.Builder
+	for i, c := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteRune('.')
+		}
+		b.WriteRune(c)
+	}
+	res := sign + "Rp " + b.String()
+	if frac := d.Sub(d.Truncate(0)); !frac.IsZero() {
+		res += "," + strings.TrimPrefix(frac.StringFixed(2), "0.")
+	}
+	return res
+}
+
+func epoch(v interface{}) time.Time {
+	switch t := v.(type) {
+	case time.Time:
+		return t
+	case int64:
+		return time.Unix(t, 0)
+	case string:
+		if n, err := strconv.ParseInt(t, 10, 64); err == nil {
+			return time.Unix(n, 0)
+		}
+		p, _ := time.Parse(time.RFC3339, t)
+		return p
+	}
+	return time.Time{}
+}
+
+func localizedFormat(v interface{}, layout string, locale string) string {
+	d := epoch(v)
+	res := d.Format(layout)
+	if m, ok := months[primaryLocale(locale)]; ok {
+		res = strings.Replace(res, d.Format("Jan"), m[d.Month()-1], 1)
+	}
+	return res
+}
+
+func LocalizedDate(v interface{}, locale string) string {
+	return localizedFormat(v, "02 Jan 2006", locale)
+}
+
+func Date(v interface{}) string {
+	return localizedFormat(v, "02 Jan 2006", "")
+}
+
+func DateTime(v interface{}) string {
+	return localizedFormat(v, "02 Jan 2006 15:04 MST", "")
+}
+
+func primaryLocale(l string) string {
+	return strings.SplitN(l, "-", 2)[0]
+}
+
+func funcs(locale string) map[string]interface{} {
+	return map[string]interface{}{
+		"idr": IDR,
+		"date": func(v interface{}) string {
+			return LocalizedDate(v, locale)
+		},
+		"datetime": func(v interface{}) string {
+			return localizedFormat(v, "02 Jan 2006 15:04 MST", locale)
+		},
+	}
+}
+
+func (t *Template) locales(locale string) []string {
+	res := []string{}
+	for _, l := range []string{apps.NormalizeLocale(locale), primaryLocale(apps.NormalizeLocale(locale)),
+		apps.NormalizeLocale(t.DefaultLocale), primaryLocale(apps.NormalizeLocale(t.DefaultLocale))} {
+		if l != "" && !apps.StringExists(l, res) {
+			res = append(res, l)
+		}
+	}
+	return append(res, "")
+}
+
+func (t *Template) lookup(name string, locale string) (content string, resolved string, ok bool) {
+	for _, l := range t.locales(locale) {
+		v, ex := t.Cacher.Hget(groupTemplate, apps.LocaleKey(name, l))
+		if ex == nil {
+			return v, l, true
+		}
+	}
+	return "", "", false
+}
+
+func (t *Template) subject(name string, locale string) string {
+	if v, ex := t.Cacher.Hget(groupSubject, apps.LocaleKey(name, locale)); ex == nil || locale == "" {
+		return v
+	}
+	v, _ := t.Cacher.Hget(groupSubject, name)
+	return v
+}
+
+func execute(w *bytes.Buffer, name string, locale string, content string, html bool, data map[string]interface{}) error {
+	content = legacyPlaceholder.ReplaceAllString(content, "{{.$1}}")
+	if html {
+		tmpl, err := htmltemplate.New(name).Option("missingkey=error").Funcs(funcs(locale)).Parse(content)
+		if err != nil {
+			return err
+		}
+		return tmpl.Execute(w, data)
+	}
+	tmpl, err := texttemplate.New(name).Option("missingkey=error").Funcs(funcs(locale)).Parse(content)
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(w, data)
+}
+
+func (t *Template) render(name string, locale string, sbj string, content string, data map[string]interface{}) (*model.NotificationContent, error) {
+	var c, s bytes.Buffer
+	if err := execute(&c, name, locale, content, !strings.HasSuffix(name, TextSuffix), data); err != nil {
+		return nil, err
+	}
+	if err := execute(&s, name, locale, sbj, false, data); err != nil {
+		return nil, err
+	}
+	return &model.NotificationContent{
+		Subject: s.String(),
+		Content: c.String(),
+	}, nil
+}
+
+func (t *Template) Render(name string, locale string, data map[string]interface{}) (*model.NotificationContent, *model.BusinessError) {
+	content, resolved, ok := t.lookup(name, locale)
+	if !ok {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeBussTemplateInvalid,
+			ErrorMessage: apps.ErrMsgBussTemplateInvalid,
+		}
+	}
+	v, err := t.render(name, resolved, t.subject(name, resolved), content, data)
+	if err != nil {
+		t.Logger.Error("failed to render notification template", zap.String("name", name),
+			zap.String("locale", resolved), zap.Error(err))
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeBussTemplateInvalid,
+			ErrorMessage: apps.ErrMsgBussTemplateInvalid,
+		}
+	}
+	return v, nil
+}
+
+func (t *Template) Preview(inp *model.TemplatePreviewRequest) (*model.TemplatePreviewResponse, *model.BusinessError) {
+	sample, ok := samples[strings.TrimSuffix(inp.Name, TextSuffix)]
+	if !ok {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeNotFound,
+			ErrorMessage: apps.ErrMsgNotFound,
+		}
+	}
+	data := map[string]interface{}{}
+	for k, v := range sample {
+		data[k] = v
+	}
+	for k, v := range inp.Data {
+		data[k] = v
+	}
+	locale := apps.NormalizeLocale(inp.Locale)
+	content, version := inp.Content, inp.Version
+	if content == "" {
+		p, ex := t.Dao.FindByParamGroupAndName(groupTemplate, inp.Name, locale, inp.Version)
+		if ex != nil {
+			return nil, &model.BusinessError{
+				ErrorCode:    apps.ErrCodeNotFound,
+				ErrorMessage: apps.ErrMsgNotFound,
+			}
+		}
+		content, version = p.ParamValue.String, p.ParamVersion
+	}
+	sbj := inp.Subject
+	if sbj == "" {
+		sbj = t.subject(inp.Name, locale)
+	}
+	v, err := t.render(inp.Name, locale, sbj, content, data)
+	if err != nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeBussTemplateInvalid,
+			ErrorMessage: apps.ErrMsgBussTemplateInvalid + ": " + err.Error(),
+		}
+	}
+	return &model.TemplatePreviewResponse{
+		Name:                inp.Name,
+		Version:             version,
+		Locale:              locale,
+		NotificationContent: *v,
+	}, nil
+}
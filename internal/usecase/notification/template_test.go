@@ -0,0 +1,218 @@
+package notification
+
+import (
+	"database/sql"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/mock/repository"
+	"github.com/adinandradrs/cezbek-engine/mock/storage"
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestIDR(t *testing.T) {
+	assert.Equal(t, "Rp 0", IDR(0))
+	assert.Equal(t, "Rp 999", IDR(int64(999)))
+	assert.Equal(t, "Rp 1.500.000", IDR(decimal.NewFromInt(1500000)))
+	assert.Equal(t, "Rp 1.234,50", IDR(1234.5))
+	assert.Equal(t, "-Rp 15.000", IDR("-15000"))
+}
+
+func TestDate(t *testing.T) {
+	d := time.Date(2023, 1, 2, 15, 4, 0, 0, time.UTC)
+	assert.Equal(t, "02 Jan 2023", Date(d))
+	assert.Equal(t, "02 Jan 2023", Date(d.Unix()))
+	assert.Equal(t, "02 Jan 2023 15:04 UTC", DateTime(d))
+	m := time.Date(2023, 8, 17, 10, 0, 0, 0, time.UTC)
+	assert.Equal(t, "17 Agu 2023", localizedFormat(m, "02 Jan 2006", "id"))
+	assert.Equal(t, "17 Aug 2023", localizedFormat(m, "02 Jan 2006", "en"))
+}
+
+func TestTemplate_Render(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	cacher := storage.NewMockCacher(ctrl)
+	svc := NewTemplate(Template{
+		Cacher: cacher,
+		Logger: logger,
+	})
+	t.Run("should success", func(t *testing.T) {
+		cacher.EXPECT().Hget("EMAIL_TEMPLATE", "INVOICE").
+			Return(`<p>{{.partner}}</p>{{if gt .qty 1}}<b>{{.qty}} items</b>{{end}}<i>{{idr .cashbackAmount}}</i>`, nil)
+		cacher.EXPECT().Hget("EMAIL_SUBJECT", "INVOICE").Return("Cashback {{.reference}} & more", nil)
+		v, ex := svc.Render("INVOICE", "", map[string]interface{}{
+			"partner":        "<Partner & Co>",
+			"qty":            2,
+			"cashbackAmount": decimal.NewFromInt(15000),
+			"reference":      "C001",
+		})
+		assert.Nil(t, ex)
+		assert.Equal(t, "<p>&lt;Partner &amp; Co&gt;</p><b>2 items</b><i>Rp 15.000</i>", v.Content)
+		assert.Equal(t, "Cashback C001 & more", v.Subject)
+	})
+	t.Run("should success with plain text template", func(t *testing.T) {
+		cacher.EXPECT().Hget("EMAIL_TEMPLATE", "INVOICE_TEXT").
+			Return(`{{.partner}} cashback {{idr .cashbackAmount}}`, nil)
+		cacher.EXPECT().Hget("EMAIL_SUBJECT", "INVOICE_TEXT").Return("", nil)
+		v, ex := svc.Render("INVOICE_TEXT", "", map[string]interface{}{
+			"partner":        "Partner & Co",
+			"cashbackAmount": decimal.NewFromInt(15000),
+		})
+		assert.Nil(t, ex)
+		assert.Equal(t, "Partner & Co cashback Rp 15.000", v.Content)
+	})
+	t.Run("should success with legacy placeholder", func(t *testing.T) {
+		cacher.EXPECT().Hget("EMAIL_TEMPLATE", "OTP").Return("<p>${partner} : ${otp}</p>", nil)
+		cacher.EXPECT().Hget("EMAIL_SUBJECT", "OTP").Return("OTP", nil)
+		v, ex := svc.Render("OTP", "", map[string]interface{}{
+			"partner": "Partner A",
+			"otp":     "123456",
+		})
+		assert.Nil(t, ex)
+		assert.Equal(t, "<p>Partner A : 123456</p>", v.Content)
+	})
+	t.Run("should success with primary language of locale", func(t *testing.T) {
+		miss := &model.TechnicalError{Exception: "redis: nil", Occurred: time.Now().Unix(), Ticket: "ERR-001"}
+		cacher.EXPECT().Hget("EMAIL_TEMPLATE", "OTP:en-us").Return("", miss)
+		cacher.EXPECT().Hget("EMAIL_TEMPLATE", "OTP:en").Return("<p>Your OTP is {{.otp}}</p>", nil)
+		cacher.EXPECT().Hget("EMAIL_SUBJECT", "OTP:en").Return("Your OTP", nil)
+		v, ex := svc.Render("OTP", "en_US", map[string]interface{}{"otp": "123456"})
+		assert.Nil(t, ex)
+		assert.Equal(t, "<p>Your OTP is 123456</p>", v.Content)
+		assert.Equal(t, "Your OTP", v.Subject)
+	})
+	t.Run("should return exception on missing key", func(t *testing.T) {
+		cacher.EXPECT().Hget("EMAIL_TEMPLATE", "OTP").Return("<p>{{.partner}} : {{.otp}}</p>", nil)
+		cacher.EXPECT().Hget("EMAIL_SUBJECT", "OTP").Return("OTP", nil)
+		v, ex := svc.Render("OTP", "", map[string]interface{}{
+			"partner": "Partner A",
+		})
+		assert.Equal(t, apps.ErrCodeBussTemplateInvalid, ex.ErrorCode)
+		assert.Nil(t, v)
+	})
+	t.Run("should return exception on malformed template", func(t *testing.T) {
+		cacher.EXPECT().Hget("EMAIL_TEMPLATE", "OTP").Return("<p>{{.partner</p>", nil)
+		cacher.EXPECT().Hget("EMAIL_SUBJECT", "OTP").Return("OTP", nil)
+		v, ex := svc.Render("OTP", "", map[string]interface{}{})
+		assert.Equal(t, apps.ErrCodeBussTemplateInvalid, ex.ErrorCode)
+		assert.Nil(t, v)
+	})
+	t.Run("should return exception on template not cached", func(t *testing.T) {
+		cacher.EXPECT().Hget("EMAIL_TEMPLATE", "OTP").Return("", &model.TechnicalError{
+			Exception: "redis: nil",
+			Occurred:  time.Now().Unix(),
+			Ticket:    "ERR-001",
+		})
+		v, ex := svc.Render("OTP", "", map[string]interface{}{})
+		assert.Equal(t, apps.ErrCodeBussTemplateInvalid, ex.ErrorCode)
+		assert.Nil(t, v)
+	})
+}
+
+func TestTemplate_RenderDefaultLocale(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	cacher := storage.NewMockCacher(ctrl)
+	svc := NewTemplate(Template{
+		Cacher:        cacher,
+		DefaultLocale: "id",
+		Logger:        logger,
+	})
+	miss := &model.TechnicalError{Exception: "redis: nil", Occurred: time.Now().Unix(), Ticket: "ERR-001"}
+	data := map[string]interface{}{"reference": "C001", "date": time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)}
+	t.Run("should success with default locale", func(t *testing.T) {
+		cacher.EXPECT().Hget("EMAIL_TEMPLATE", "INVOICE:fr").Return("", miss)
+		cacher.EXPECT().Hget("EMAIL_TEMPLATE", "INVOICE:id").Return("<p>Tanggal {{date .date}}</p>", nil)
+		cacher.EXPECT().Hget("EMAIL_SUBJECT", "INVOICE:id").Return("", miss)
+		cacher.EXPECT().Hget("EMAIL_SUBJECT", "INVOICE").Return("Invoice {{.reference}}", nil)
+		v, ex := svc.Render("INVOICE", "fr", data)
+		assert.Nil(t, ex)
+		assert.Equal(t, "<p>Tanggal 01 Mei 2023</p>", v.Content)
+		assert.Equal(t, "Invoice C001", v.Subject)
+	})
+	t.Run("should success with unlocalized template", func(t *testing.T) {
+		cacher.EXPECT().Hget("EMAIL_TEMPLATE", "INVOICE:id").Return("", miss)
+		cacher.EXPECT().Hget("EMAIL_TEMPLATE", "INVOICE").Return("<p>{{.reference}}</p>", nil)
+		cacher.EXPECT().Hget("EMAIL_SUBJECT", "INVOICE").Return("Invoice", nil)
+		v, ex := svc.Render("INVOICE", "", data)
+		assert.Nil(t, ex)
+		assert.Equal(t, "<p>C001</p>", v.Content)
+	})
+}
+
+func TestTemplate_Preview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	dao, cacher := repository.NewMockParamPersister(ctrl), storage.NewMockCacher(ctrl)
+	svc := NewTemplate(Template{
+		Dao:    dao,
+		Cacher: cacher,
+		Logger: logger,
+	})
+	t.Run("should success with stored version", func(t *testing.T) {
+		dao.EXPECT().FindByParamGroupAndName("EMAIL_TEMPLATE", "INVOICE", "", 2).Return(&model.Parameter{
+			ParamValue:   sql.NullString{String: "<p>{{idr .transactionAmount}} on {{date .date}}</p>", Valid: true},
+			ParamVersion: 2,
+		}, nil)
+		cacher.EXPECT().Hget("EMAIL_SUBJECT", "INVOICE").Return("Invoice {{.reference}}", nil)
+		v, ex := svc.Preview(&model.TemplatePreviewRequest{Name: "INVOICE", Version: 2})
+		assert.Nil(t, ex)
+		assert.Equal(t, 2, v.Version)
+		assert.Equal(t, "<p>Rp 1.500.000 on 01 Jan 2023</p>", v.Content)
+		assert.Equal(t, "Invoice C00223010112000062811", v.Subject)
+	})
+	t.Run("should success with stored locale", func(t *testing.T) {
+		dao.EXPECT().FindByParamGroupAndName("EMAIL_TEMPLATE", "INVOICE", "id", 0).Return(&model.Parameter{
+			ParamValue:   sql.NullString{String: "<p>Tanggal {{date .date}}</p>", Valid: true},
+			ParamVersion: 1,
+			ParamLocale:  sql.NullString{String: "id", Valid: true},
+		}, nil)
+		cacher.EXPECT().Hget("EMAIL_SUBJECT", "INVOICE:id").Return("Tagihan {{.reference}}", nil)
+		v, ex := svc.Preview(&model.TemplatePreviewRequest{Name: "INVOICE", Locale: "ID"})
+		assert.Nil(t, ex)
+		assert.Equal(t, "id", v.Locale)
+		assert.Equal(t, "<p>Tanggal 01 Jan 2023</p>", v.Content)
+		assert.Equal(t, "Tagihan C00223010112000062811", v.Subject)
+	})
+	t.Run("should success with draft content and data", func(t *testing.T) {
+		v, ex := svc.Preview(&model.TemplatePreviewRequest{
+			Name:    "OTP",
+			Subject: "Your OTP",
+			Content: "<p>{{.otp}}</p>",
+			Data:    map[string]interface{}{"otp": "654321"},
+		})
+		assert.Nil(t, ex)
+		assert.Equal(t, "<p>654321</p>", v.Content)
+	})
+	t.Run("should return exception on unknown template", func(t *testing.T) {
+		v, ex := svc.Preview(&model.TemplatePreviewRequest{Name: "UNKNOWN"})
+		assert.Equal(t, apps.ErrCodeNotFound, ex.ErrorCode)
+		assert.Nil(t, v)
+	})
+	t.Run("should return exception on version not found", func(t *testing.T) {
+		dao.EXPECT().FindByParamGroupAndName("EMAIL_TEMPLATE", "OTP", "", 9).Return(nil, &model.TechnicalError{
+			Exception: "no rows in result set",
+			Occurred:  time.Now().Unix(),
+			Ticket:    "ERR-001",
+		})
+		v, ex := svc.Preview(&model.TemplatePreviewRequest{Name: "OTP", Version: 9})
+		assert.Equal(t, apps.ErrCodeNotFound, ex.ErrorCode)
+		assert.Nil(t, v)
+	})
+	t.Run("should return exception on invalid draft", func(t *testing.T) {
+		v, ex := svc.Preview(&model.TemplatePreviewRequest{
+			Name:    "OTP",
+			Subject: "Your OTP",
+			Content: "<p>{{.unknown}}</p>",
+		})
+		assert.Equal(t, apps.ErrCodeBussTemplateInvalid, ex.ErrorCode)
+		assert.Contains(t, ex.ErrorMessage, "unknown")
+		assert.Nil(t, v)
+	})
+}
@@ -0,0 +1,33 @@
+package partner
+
+import (
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/internal/repository"
+	"go.uber.org/zap"
+)
+
+type Deposit struct {
+	Dao    repository.DepositPersister
+	Logger *zap.Logger
+}
+
+type DepositProvider interface {
+	Find(inp *model.SessionRequest) (*model.DepositResponse, *model.BusinessError)
+}
+
+func NewDeposit(d Deposit) DepositProvider {
+	return &d
+}
+
+func (d *Deposit) Find(inp *model.SessionRequest) (*model.DepositResponse, *model.BusinessError) {
+	v, ex := d.Dao.FindByPartner(inp.Id)
+	if ex != nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeNotFound,
+			ErrorMessage: apps.ErrMsgNotFound,
+		}
+	}
+	r := model.Deposit(*v)
+	return &r, nil
+}
@@ -0,0 +1,48 @@
+package partner
+
+import (
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/mock/repository"
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestDeposit_Find(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	dao := repository.NewMockDepositPersister(ctrl)
+	svc := NewDeposit(Deposit{
+		Dao:    dao,
+		Logger: logger,
+	})
+	inp := &model.SessionRequest{
+		Id: 1,
+	}
+	t.Run("should success", func(t *testing.T) {
+		dao.EXPECT().FindByPartner(int64(1)).Return(&model.DepositProjection{
+			Code:      "LAJADA",
+			Balance:   decimal.NewFromInt(5000000),
+			Available: decimal.NewFromInt(5000000),
+			Threshold: decimal.NewFromInt(1000000),
+		}, nil)
+		v, bx := svc.Find(inp)
+		assert.Nil(t, bx)
+		assert.False(t, v.LowBalance)
+	})
+
+	t.Run("should return exception on partner is not prepaid", func(t *testing.T) {
+		dao.EXPECT().FindByPartner(int64(1)).Return(nil, &model.TechnicalError{
+			Exception: "no rows in result set",
+			Occurred:  time.Now().Unix(),
+			Ticket:    "ERR-001",
+		})
+		v, bx := svc.Find(inp)
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeNotFound, bx.ErrorCode)
+	})
+}
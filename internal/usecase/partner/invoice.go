@@ -0,0 +1,43 @@
+package partner
+
+import (
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/internal/repository"
+	"go.uber.org/zap"
+)
+
+type Invoice struct {
+	Dao    repository.InvoicePersister
+	CDN    *string
+	Logger *zap.Logger
+}
+
+type InvoiceProvider interface {
+	Search(inp *model.SearchRequest) (*model.InvoiceSearchResponse, *model.BusinessError)
+}
+
+func NewInvoice(i Invoice) InvoiceProvider {
+	return &i
+}
+
+func (i *Invoice) Search(inp *model.SearchRequest) (*model.InvoiceSearchResponse, *model.BusinessError) {
+	model.Page(inp)
+	c, countEx := i.Dao.CountByPartner(inp)
+	v, searchEx := i.Dao.SearchByPartner(inp)
+	if countEx != nil || searchEx != nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeNotFound,
+			ErrorMessage: apps.ErrMsgNotFound,
+		}
+	}
+	for k := range v {
+		if v[k].Path != "" {
+			v[k].Url = *i.CDN + v[k].Path
+		}
+	}
+	return &model.InvoiceSearchResponse{
+		Invoices:           v,
+		PaginationResponse: model.Pagination(*c, inp.Limit, inp.Start),
+	}, nil
+}
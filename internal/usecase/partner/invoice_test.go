@@ -0,0 +1,64 @@
+package partner
+
+import (
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/mock/repository"
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestInvoice_Search(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	dao := repository.NewMockInvoicePersister(ctrl)
+	cdn := "https://cdn.kezbek.id/"
+	svc := NewInvoice(Invoice{
+		Dao:    dao,
+		CDN:    &cdn,
+		Logger: logger,
+	})
+	inp := &model.SearchRequest{
+		Limit: 10,
+		Start: 1,
+		SessionRequest: model.SessionRequest{
+			Id: 1,
+		},
+	}
+	t.Run("should success", func(t *testing.T) {
+		count := 1
+		dao.EXPECT().CountByPartner(inp).Return(&count, nil)
+		dao.EXPECT().SearchByPartner(inp).Return([]model.InvoiceProjection{
+			{
+				Id:         1,
+				InvoiceNo:  "INV/LAJADA/202301",
+				Period:     "2023-01",
+				Total:      decimal.NewFromInt(27775),
+				Status:     apps.InvoiceOverdue,
+				Path:       "invoice/1/INV-LAJADA-202301-8f2d0a6c1b9e4d57.pdf",
+				IssuedDate: time.Now().Unix(),
+			},
+		}, nil)
+		v, ex := svc.Search(inp)
+		assert.Nil(t, ex)
+		assert.Len(t, v.Invoices, 1)
+		assert.Equal(t, cdn+"invoice/1/INV-LAJADA-202301-8f2d0a6c1b9e4d57.pdf", v.Invoices[0].Url)
+		assert.Equal(t, 1, v.TotalElements)
+	})
+
+	t.Run("should return exception on failed in one of the query", func(t *testing.T) {
+		dao.EXPECT().CountByPartner(inp).Return(nil, &model.TechnicalError{
+			Exception: "something went wrong",
+			Occurred:  time.Now().Unix(),
+			Ticket:    "ERR-001",
+		})
+		dao.EXPECT().SearchByPartner(inp).Return(nil, nil)
+		v, ex := svc.Search(inp)
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeNotFound, ex.ErrorCode)
+	})
+}
@@ -0,0 +1,135 @@
+package partner
+
+import (
+	"database/sql"
+	"encoding/json"
+	"github.com/adinandradrs/cezbek-engine/internal/adaptor"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/internal/repository"
+	"github.com/adinandradrs/cezbek-engine/internal/storage"
+	"github.com/adinandradrs/cezbek-engine/internal/usecase/notification"
+	"go.uber.org/zap"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Officer struct {
+	Dao                           repository.OfficerPersister
+	Cacher                        storage.Cacher
+	SqsAdapter                    adaptor.SQSAdapter
+	TemplateProvider              notification.TemplateProvider
+	Logger                        *zap.Logger
+	QueueNotificationEmailOnboard *string
+}
+
+type OfficerProvider interface {
+	Invite(inp *model.AddOfficerRequest) (*model.TransactionResponse, *model.BusinessError)
+	Remove(inp *model.FindByIdRequest) (*model.TransactionResponse, *model.BusinessError)
+	Officers(inp *model.SessionRequest) ([]model.OfficerProjection, *model.BusinessError)
+}
+
+func NewOfficer(o Officer) OfficerProvider {
+	return &o
+}
+
+func (o *Officer) queueEmailInvite(inp *model.AddOfficerRequest) {
+	v, bx := o.TemplateProvider.Render("OFFICER_INVITE", inp.SessionRequest.Locale, map[string]interface{}{
+		"partner":  inp.SessionRequest.Fullname,
+		"fullname": inp.Fullname,
+		"role":     inp.Role,
+	})
+	if bx != nil {
+		o.Logger.Error("failed to render officer invitation email", zap.String("email", inp.Email))
+		return
+	}
+	msg, _ := json.Marshal(model.SendEmailRequest{
+		Content:     v.Content,
+		Subject:     v.Subject,
+		Destination: inp.Email,
+	})
+	err := o.SqsAdapter.SendMessage(*o.QueueNotificationEmailOnboard, string(msg))
+	if err != nil {
+		o.Logger.Error("failed to queue officer invitation email", zap.String("email", inp.Email), zap.Error(err))
+	}
+}
+
+func (o *Officer) Invite(inp *model.AddOfficerRequest) (*model.TransactionResponse, *model.BusinessError) {
+	inp.Email = strings.ToLower(inp.Email)
+	count, ex := o.Dao.CountByEmail(inp.Email)
+	if ex == nil && *count == 0 {
+		count, ex = o.Dao.CountPartnerByEmail(inp.SessionRequest.Id, inp.Email)
+	}
+	if ex != nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSomethingWrong,
+			ErrorMessage: apps.ErrMsgSomethingWrong,
+		}
+	}
+	if *count > 0 {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeBussOfficerExists,
+			ErrorMessage: apps.ErrMsgBussOfficerExists,
+		}
+	}
+	ex = o.Dao.Add(model.PartnerOfficer{
+		PartnerId: inp.SessionRequest.Id,
+		Email:     sql.NullString{String: inp.Email, Valid: true},
+		Fullname:  sql.NullString{String: inp.Fullname, Valid: true},
+		Role:      sql.NullString{String: inp.Role, Valid: true},
+		BaseEntity: model.BaseEntity{
+			CreatedBy: sql.NullInt64{Int64: inp.SessionRequest.Id, Valid: true},
+		},
+	})
+	if ex != nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSubmitted,
+			ErrorMessage: apps.ErrMsgSubmitted,
+		}
+	}
+	o.queueEmailInvite(inp)
+	return &model.TransactionResponse{
+		TransactionId:        apps.TransactionId(strconv.FormatInt(inp.SessionRequest.Id, 10) + apps.DefaultTrxId),
+		TransactionTimestamp: time.Now().Unix(),
+	}, nil
+}
+
+func (o *Officer) Remove(inp *model.FindByIdRequest) (*model.TransactionResponse, *model.BusinessError) {
+	v, ex := o.Dao.FindActiveById(inp.SessionRequest.Id, inp.Id)
+	if ex != nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeNotFound,
+			ErrorMessage: apps.ErrMsgNotFound,
+		}
+	}
+	if strings.EqualFold(v.Email.String, inp.SessionRequest.Email) {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeBussOfficerSelfRemoval,
+			ErrorMessage: apps.ErrMsgBussOfficerSelfRemoval,
+		}
+	}
+	v.UpdatedBy = sql.NullInt64{Int64: inp.SessionRequest.Id, Valid: true}
+	if ex = o.Dao.Remove(*v); ex != nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSubmitted,
+			ErrorMessage: apps.ErrMsgSubmitted,
+		}
+	}
+	_ = o.Cacher.Delete("B2BSESSION", strings.ToLower(v.Email.String))
+	return &model.TransactionResponse{
+		TransactionId:        apps.TransactionId(strconv.FormatInt(inp.SessionRequest.Id, 10) + apps.DefaultTrxId),
+		TransactionTimestamp: time.Now().Unix(),
+	}, nil
+}
+
+func (o *Officer) Officers(inp *model.SessionRequest) ([]model.OfficerProjection, *model.BusinessError) {
+	v, ex := o.Dao.FindActiveByPartner(inp.Id)
+	if ex != nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSomethingWrong,
+			ErrorMessage: apps.ErrMsgSomethingWrong,
+		}
+	}
+	return v, nil
+}
@@ -0,0 +1,224 @@
+package partner
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/mock/adaptor"
+	"github.com/adinandradrs/cezbek-engine/mock/repository"
+	"github.com/adinandradrs/cezbek-engine/mock/storage"
+	"github.com/adinandradrs/cezbek-engine/mock/usecase/notification"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestOfficer_Invite(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	dao, cacher, sqsAdapter, q := repository.NewMockOfficerPersister(ctrl),
+		storage.NewMockCacher(ctrl), adaptor.NewMockSQSAdapter(ctrl), "mock-queue"
+	templateProvider := notification.NewMockTemplateProvider(ctrl)
+	svc := NewOfficer(Officer{
+		Dao:                           dao,
+		Cacher:                        cacher,
+		SqsAdapter:                    sqsAdapter,
+		TemplateProvider:              templateProvider,
+		QueueNotificationEmailOnboard: &q,
+		Logger:                        logger,
+	})
+	inp := &model.AddOfficerRequest{
+		Email:    "Jane.Doe@email.net",
+		Fullname: "Jane Doe",
+		Role:     apps.RoleOfficerFinance,
+		SessionRequest: model.SessionRequest{
+			Id:       1,
+			Fullname: "PT. Partner A",
+			Locale:   "en",
+		},
+	}
+	t.Run("should success", func(t *testing.T) {
+		count := 0
+		dao.EXPECT().CountByEmail("jane.doe@email.net").Return(&count, nil)
+		dao.EXPECT().CountPartnerByEmail(int64(1), "jane.doe@email.net").Return(&count, nil)
+		dao.EXPECT().Add(gomock.Any()).Return(nil)
+		templateProvider.EXPECT().Render("OFFICER_INVITE", "en", map[string]interface{}{
+			"partner":  "PT. Partner A",
+			"fullname": "Jane Doe",
+			"role":     apps.RoleOfficerFinance,
+		}).Return(&model.NotificationContent{Subject: "subject", Content: "content"}, nil)
+		sqsAdapter.EXPECT().SendMessage(q, gomock.Any()).Return(nil)
+		v, ex := svc.Invite(inp)
+		assert.Nil(t, ex)
+		assert.NotNil(t, v)
+	})
+	t.Run("should success on failed to queue invitation", func(t *testing.T) {
+		count := 0
+		dao.EXPECT().CountByEmail("jane.doe@email.net").Return(&count, nil)
+		dao.EXPECT().CountPartnerByEmail(int64(1), "jane.doe@email.net").Return(&count, nil)
+		dao.EXPECT().Add(gomock.Any()).Return(nil)
+		templateProvider.EXPECT().Render("OFFICER_INVITE", gomock.Any(), gomock.Any()).
+			Return(&model.NotificationContent{Subject: "subject", Content: "content"}, nil)
+		sqsAdapter.EXPECT().SendMessage(q, gomock.Any()).Return(fmt.Errorf("something went wrong"))
+		v, ex := svc.Invite(inp)
+		assert.Nil(t, ex)
+		assert.NotNil(t, v)
+	})
+	t.Run("should success on failed to render invitation", func(t *testing.T) {
+		count := 0
+		dao.EXPECT().CountByEmail("jane.doe@email.net").Return(&count, nil)
+		dao.EXPECT().CountPartnerByEmail(int64(1), "jane.doe@email.net").Return(&count, nil)
+		dao.EXPECT().Add(gomock.Any()).Return(nil)
+		templateProvider.EXPECT().Render("OFFICER_INVITE", gomock.Any(), gomock.Any()).Return(nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeBussTemplateInvalid,
+			ErrorMessage: apps.ErrMsgBussTemplateInvalid,
+		})
+		v, ex := svc.Invite(inp)
+		assert.Nil(t, ex)
+		assert.NotNil(t, v)
+	})
+	t.Run("should return exception on officer exists", func(t *testing.T) {
+		count := 1
+		dao.EXPECT().CountByEmail("jane.doe@email.net").Return(&count, nil)
+		v, ex := svc.Invite(inp)
+		assert.Equal(t, apps.ErrCodeBussOfficerExists, ex.ErrorCode)
+		assert.Nil(t, v)
+	})
+	t.Run("should return exception on primary email of another partner", func(t *testing.T) {
+		count, partners := 0, 1
+		dao.EXPECT().CountByEmail("jane.doe@email.net").Return(&count, nil)
+		dao.EXPECT().CountPartnerByEmail(int64(1), "jane.doe@email.net").Return(&partners, nil)
+		v, ex := svc.Invite(inp)
+		assert.Equal(t, apps.ErrCodeBussOfficerExists, ex.ErrorCode)
+		assert.Nil(t, v)
+	})
+	t.Run("should return exception on failed to count partner", func(t *testing.T) {
+		count := 0
+		dao.EXPECT().CountByEmail("jane.doe@email.net").Return(&count, nil)
+		dao.EXPECT().CountPartnerByEmail(int64(1), "jane.doe@email.net").Return(nil, &model.TechnicalError{
+			Exception: "something went wrong",
+			Occurred:  time.Now().Unix(),
+			Ticket:    "ERR-001",
+		})
+		v, ex := svc.Invite(inp)
+		assert.Equal(t, apps.ErrCodeSomethingWrong, ex.ErrorCode)
+		assert.Nil(t, v)
+	})
+	t.Run("should return exception on failed to count", func(t *testing.T) {
+		dao.EXPECT().CountByEmail("jane.doe@email.net").Return(nil, &model.TechnicalError{
+			Exception: "something went wrong",
+			Occurred:  time.Now().Unix(),
+			Ticket:    "ERR-001",
+		})
+		v, ex := svc.Invite(inp)
+		assert.Equal(t, apps.ErrCodeSomethingWrong, ex.ErrorCode)
+		assert.Nil(t, v)
+	})
+	t.Run("should return exception on failed to add", func(t *testing.T) {
+		count := 0
+		dao.EXPECT().CountByEmail("jane.doe@email.net").Return(&count, nil)
+		dao.EXPECT().CountPartnerByEmail(int64(1), "jane.doe@email.net").Return(&count, nil)
+		dao.EXPECT().Add(gomock.Any()).Return(&model.TechnicalError{
+			Exception: "something went wrong",
+			Occurred:  time.Now().Unix(),
+			Ticket:    "ERR-001",
+		})
+		v, ex := svc.Invite(inp)
+		assert.Equal(t, apps.ErrCodeSubmitted, ex.ErrorCode)
+		assert.Nil(t, v)
+	})
+}
+
+func TestOfficer_Remove(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	dao, cacher := repository.NewMockOfficerPersister(ctrl), storage.NewMockCacher(ctrl)
+	svc := NewOfficer(Officer{
+		Dao:    dao,
+		Cacher: cacher,
+		Logger: logger,
+	})
+	inp := &model.FindByIdRequest{
+		Id: 2,
+		SessionRequest: model.SessionRequest{
+			Id:    1,
+			Email: "someone@email.net",
+		},
+	}
+	officer := &model.PartnerOfficer{
+		Id:        2,
+		PartnerId: 1,
+		Email:     sql.NullString{String: "Jane.Doe@email.net", Valid: true},
+	}
+	t.Run("should success", func(t *testing.T) {
+		dao.EXPECT().FindActiveById(int64(1), int64(2)).Return(officer, nil)
+		dao.EXPECT().Remove(gomock.Any()).Return(nil)
+		cacher.EXPECT().Delete("B2BSESSION", "jane.doe@email.net").Return(nil)
+		v, ex := svc.Remove(inp)
+		assert.Nil(t, ex)
+		assert.NotNil(t, v)
+	})
+	t.Run("should return exception on officer not found", func(t *testing.T) {
+		dao.EXPECT().FindActiveById(int64(1), int64(2)).Return(nil, &model.TechnicalError{
+			Exception: "no rows in result set",
+			Occurred:  time.Now().Unix(),
+			Ticket:    "ERR-001",
+		})
+		v, ex := svc.Remove(inp)
+		assert.Equal(t, apps.ErrCodeNotFound, ex.ErrorCode)
+		assert.Nil(t, v)
+	})
+	t.Run("should return exception on self removal", func(t *testing.T) {
+		self := *officer
+		self.Email = sql.NullString{String: "Someone@email.net", Valid: true}
+		dao.EXPECT().FindActiveById(int64(1), int64(2)).Return(&self, nil)
+		v, ex := svc.Remove(inp)
+		assert.Equal(t, apps.ErrCodeBussOfficerSelfRemoval, ex.ErrorCode)
+		assert.Nil(t, v)
+	})
+	t.Run("should return exception on failed to remove", func(t *testing.T) {
+		dao.EXPECT().FindActiveById(int64(1), int64(2)).Return(officer, nil)
+		dao.EXPECT().Remove(gomock.Any()).Return(&model.TechnicalError{
+			Exception: "something went wrong",
+			Occurred:  time.Now().Unix(),
+			Ticket:    "ERR-001",
+		})
+		v, ex := svc.Remove(inp)
+		assert.Equal(t, apps.ErrCodeSubmitted, ex.ErrorCode)
+		assert.Nil(t, v)
+	})
+}
+
+func TestOfficer_Officers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	dao := repository.NewMockOfficerPersister(ctrl)
+	svc := NewOfficer(Officer{
+		Dao:    dao,
+		Logger: logger,
+	})
+	inp := &model.SessionRequest{Id: 1}
+	t.Run("should success", func(t *testing.T) {
+		dao.EXPECT().FindActiveByPartner(int64(1)).Return([]model.OfficerProjection{
+			{Id: 1, Email: "someone@email.net", Fullname: "Someone", Role: apps.RoleOfficerAdmin},
+		}, nil)
+		v, ex := svc.Officers(inp)
+		assert.Nil(t, ex)
+		assert.Len(t, v, 1)
+	})
+	t.Run("should return exception on failed to query", func(t *testing.T) {
+		dao.EXPECT().FindActiveByPartner(int64(1)).Return(nil, &model.TechnicalError{
+			Exception: "something went wrong",
+			Occurred:  time.Now().Unix(),
+			Ticket:    "ERR-001",
+		})
+		v, ex := svc.Officers(inp)
+		assert.NotNil(t, ex)
+		assert.Nil(t, v)
+	})
+}
@@ -1,12 +1,15 @@
 package partner
 
 import (
+	"database/sql"
 	"encoding/json"
+	"fmt"
 	"github.com/adinandradrs/cezbek-engine/internal/adaptor"
 	"github.com/adinandradrs/cezbek-engine/internal/apps"
 	"github.com/adinandradrs/cezbek-engine/internal/model"
 	"github.com/adinandradrs/cezbek-engine/internal/repository"
 	"github.com/adinandradrs/cezbek-engine/internal/storage"
+	"github.com/adinandradrs/cezbek-engine/internal/usecase/notification"
 	"go.uber.org/zap"
 	"strings"
 	"time"
@@ -14,22 +17,35 @@ import (
 
 type Onboard struct {
 	Dao                       repository.PartnerPersister
+	AuditDao                  repository.AuditPersister
 	CiamWatcher               adaptor.CiamWatcher
 	SqsAdapter                adaptor.SQSAdapter
+	TemplateProvider          notification.TemplateProvider
 	Cacher                    storage.Cacher
 	Logger                    *zap.Logger
 	AuthTTL                   time.Duration
 	OtpTTL                    time.Duration
+	OtpLockTTL                time.Duration
+	OtpCooldown               time.Duration
+	OtpMaxAttempts            int64
+	OtpMaxLockAttempts        int64
 	QueueNotificationEmailOtp *string
 	CDN                       *string
 }
 
 const kotp = "OTPB2B:"
 const otpB2B = "OTPB2B"
+const otpTrx = "OTPB2BTRX"
+const otpFail = "OTPFAIL"
+const otpLock = "OTPLOCK"
+const otpResend = "OTPRESEND"
+const otpCooldown = "OTPCOOLDOWN"
 
 type OnboardProvider interface {
 	Authenticate(inp *model.OfficerAuthenticationRequest) (*model.OfficerAuthenticationResponse, *model.BusinessError)
 	Validate(inp *model.OfficerValidationRequest) (*model.OfficerValidationResponse, *model.BusinessError)
+	Refresh(inp *model.SessionRefreshRequest) (*model.SessionResponse, *model.BusinessError)
+	Logout(inp *model.SessionRequest) *model.BusinessError
 }
 
 func NewOnboard(o Onboard) OnboardProvider {
@@ -74,6 +90,12 @@ func (o *Onboard) Authenticate(inp *model.OfficerAuthenticationRequest) (*model.
 			ErrorMessage: apps.ErrMsgUnauthorized,
 		}
 	}
+	if o.locked(p.Email.String) {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeBussPartnerLocked,
+			ErrorMessage: apps.ErrMsgBussPartnerLocked,
+		}
+	}
 	otp, err := apps.RandomOtp(6)
 	if err != nil {
 		return nil, &model.BusinessError{
@@ -94,6 +116,12 @@ func (o *Onboard) Authenticate(inp *model.OfficerAuthenticationRequest) (*model.
 			},
 		}, nil
 	}
+	if cd, _ := o.Cacher.Ttl(otpCooldown, p.Email.String); cd.Seconds() > 0 {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeBussOTPThrottled,
+			ErrorMessage: apps.ErrMsgBussOTPThrottled,
+		}
+	}
 	cache, err := json.Marshal(p)
 	if err != nil {
 		return nil, &model.BusinessError{
@@ -103,10 +131,12 @@ func (o *Onboard) Authenticate(inp *model.OfficerAuthenticationRequest) (*model.
 	}
 	o.Cacher.Set(otpB2B, p.Email.String, otp+"#"+trx, o.OtpTTL)
 	o.Cacher.Set(kotp+trx, otp, cache, o.OtpTTL)
+	o.Cacher.Set(otpTrx, trx, p.Email.String, o.OtpTTL)
 	bx := o.queueEmailOtp(otp, p)
 	if bx != nil {
 		return nil, bx
 	}
+	o.cooldown(p.Email.String)
 	return &model.OfficerAuthenticationResponse{
 		RemainingSeconds: o.OtpTTL.Seconds(),
 		TransactionResponse: model.TransactionResponse{
@@ -116,21 +146,22 @@ func (o *Onboard) Authenticate(inp *model.OfficerAuthenticationRequest) (*model.
 	}, nil
 }
 
-func (o *Onboard) otpEmailContent(otp string, p string) string {
-	tmpl, _ := o.Cacher.Hget("EMAIL_TEMPLATE", "OTP")
-	tmpl = strings.ReplaceAll(tmpl, "${otp}", otp)
-	tmpl = strings.ReplaceAll(tmpl, "${partner}", p)
-	tmpl = strings.ReplaceAll(tmpl, "\n", "")
-	tmpl = strings.ReplaceAll(tmpl, "\t", "")
-	return tmpl
+func (o *Onboard) queueEmailOtp(otp string, p *model.Partner) *model.BusinessError {
+	return o.queueEmail("OTP", p.Locale.String, map[string]interface{}{
+		"otp":     otp,
+		"partner": p.Partner.String,
+	}, p.Email.String)
 }
 
-func (o *Onboard) queueEmailOtp(otp string, p *model.Partner) *model.BusinessError {
-	sbj, _ := o.Cacher.Hget("EMAIL_SUBJECT", "OTP")
+func (o *Onboard) queueEmail(tmpl string, locale string, data map[string]interface{}, dest string) *model.BusinessError {
+	v, bx := o.TemplateProvider.Render(tmpl, locale, data)
+	if bx != nil {
+		return bx
+	}
 	msg, err := json.Marshal(model.SendEmailRequest{
-		Content:     o.otpEmailContent(otp, p.Partner.String),
-		Subject:     sbj,
-		Destination: p.Email.String,
+		Content:     v.Content,
+		Subject:     v.Subject,
+		Destination: dest,
 	})
 	if err != nil {
 		return &model.BusinessError{
@@ -148,14 +179,104 @@ func (o *Onboard) queueEmailOtp(otp string, p *model.Partner) *model.BusinessErr
 	return nil
 }
 
+func (o *Onboard) locked(email string) bool {
+	ttl, _ := o.Cacher.Ttl(otpLock, email)
+	return ttl.Seconds() > 0
+}
+
+func (o *Onboard) cooldown(email string) {
+	n, ex := o.Cacher.Incr(otpResend, email, o.OtpLockTTL)
+	if ex != nil {
+		return
+	}
+	if n > 10 {
+		n = 10
+	}
+	d := o.OtpCooldown * time.Duration(int64(1)<<uint(n-1))
+	if o.OtpLockTTL > 0 && d > o.OtpLockTTL {
+		d = o.OtpLockTTL
+	}
+	if d > 0 {
+		o.Cacher.Set(otpCooldown, email, n, d)
+	}
+}
+
+func (o *Onboard) failedAttempt(trx string, email string) *model.BusinessError {
+	tn, _ := o.Cacher.Incr(otpFail, trx, o.OtpTTL)
+	en, _ := o.Cacher.Incr(otpFail, email, o.OtpLockTTL)
+	if o.OtpMaxLockAttempts > 0 && en >= o.OtpMaxLockAttempts {
+		o.lockout(trx, email, en)
+		return &model.BusinessError{
+			ErrorCode:    apps.ErrCodeBussPartnerLocked,
+			ErrorMessage: apps.ErrMsgBussPartnerLocked,
+		}
+	}
+	if o.OtpMaxAttempts > 0 && tn >= o.OtpMaxAttempts {
+		_ = o.Cacher.Delete(otpTrx, trx)
+		_ = o.Cacher.Delete(otpB2B, email)
+	}
+	return &model.BusinessError{
+		ErrorCode:    apps.ErrCodeBussPartnerOTPInvalid,
+		ErrorMessage: apps.ErrMsgBussPartnerOTPInvalid,
+	}
+}
+
+func (o *Onboard) lockout(trx string, email string, attempts int64) {
+	o.Cacher.Set(otpLock, email, trx, o.OtpLockTTL)
+	_ = o.Cacher.Delete(otpTrx, trx)
+	_ = o.Cacher.Delete(otpB2B, email)
+	_ = o.Cacher.Delete(otpFail, email)
+	o.Logger.Warn("officer is locked out on OTP validation", zap.String("email", email),
+		zap.String("trx", trx), zap.Int64("attempts", attempts))
+	if ex := o.AuditDao.Add(model.Audit{
+		Activity:  sql.NullString{String: "OTP_LOCKOUT", Valid: true},
+		Actor:     sql.NullString{String: email, Valid: true},
+		Reference: sql.NullString{String: trx, Valid: true},
+		Detail: sql.NullString{String: fmt.Sprintf("locked out for %s after %d failed OTP attempts",
+			o.OtpLockTTL, attempts), Valid: true},
+	}); ex != nil {
+		o.Logger.Error("failed to audit OTP lockout", zap.String("email", email))
+	}
+	p, ex := o.Dao.FindActiveByEmail(email)
+	if ex != nil {
+		return
+	}
+	if bx := o.queueEmail("OTP_LOCKED", p.Locale.String, map[string]interface{}{
+		"partner":  p.Partner.String,
+		"duration": o.OtpLockTTL.String(),
+	}, p.Email.String); bx != nil {
+		o.Logger.Error("failed to queue OTP lockout email", zap.String("email", email))
+	}
+}
+
+func (o *Onboard) clearAttempts(inp *model.OfficerValidationRequest, email string) {
+	_ = o.Cacher.Delete(kotp+inp.TransactionId, inp.Otp)
+	_ = o.Cacher.Delete(otpTrx, inp.TransactionId)
+	_ = o.Cacher.Delete(otpFail, inp.TransactionId)
+	_ = o.Cacher.Delete(otpFail, email)
+	_ = o.Cacher.Delete(otpResend, email)
+	_ = o.Cacher.Delete(otpCooldown, email)
+	_ = o.Cacher.Delete(otpB2B, email)
+}
+
 func (o *Onboard) Validate(inp *model.OfficerValidationRequest) (*model.OfficerValidationResponse, *model.BusinessError) {
-	cp, ex := o.Cacher.Get(kotp+inp.TransactionId, inp.Otp)
+	email, ex := o.Cacher.Get(otpTrx, inp.TransactionId)
 	if ex != nil {
 		return nil, &model.BusinessError{
 			ErrorCode:    apps.ErrCodeBussPartnerOTPInvalid,
 			ErrorMessage: apps.ErrMsgBussPartnerOTPInvalid,
 		}
 	}
+	if o.locked(email) {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeBussPartnerLocked,
+			ErrorMessage: apps.ErrMsgBussPartnerLocked,
+		}
+	}
+	cp, ex := o.Cacher.Get(kotp+inp.TransactionId, inp.Otp)
+	if ex != nil {
+		return nil, o.failedAttempt(inp.TransactionId, email)
+	}
 	var p model.Partner
 	err := json.Unmarshal([]byte(cp), &p)
 	if err != nil {
@@ -176,6 +297,8 @@ func (o *Onboard) Validate(inp *model.OfficerValidationRequest) (*model.OfficerV
 		Email:   p.Email.String,
 		Msisdn:  p.Msisdn.String,
 		Code:    p.Code.String,
+		Role:    p.Role.String,
+		Locale:  p.Locale.String,
 		SessionResponse: model.SessionResponse{
 			RefreshToken: auth.RefreshToken,
 			Token:        auth.Token,
@@ -191,9 +314,79 @@ func (o *Onboard) Validate(inp *model.OfficerValidationRequest) (*model.OfficerV
 			ErrorMessage: apps.ErrMsgSomethingWrong,
 		}
 	}
-	_ = o.Cacher.Delete(kotp+inp.TransactionId, inp.Otp)
-	_ = o.Cacher.Delete(otpB2B, p.Email.String)
+	o.clearAttempts(inp, p.Email.String)
 	o.Cacher.Set("B2BSESSION", p.Email.String, cache, o.AuthTTL)
+	o.Cacher.Set("B2BTOKEN", apps.Hash(auth.Token), p.Email.String, o.AuthTTL)
 	resp.Id = 0
 	return &resp, nil
 }
+
+func (o *Onboard) Refresh(inp *model.SessionRefreshRequest) (*model.SessionResponse, *model.BusinessError) {
+	v, ex := o.Cacher.Get("B2BSESSION", strings.ToLower(inp.Username))
+	if ex != nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeUnauthorized,
+			ErrorMessage: apps.ErrMsgUnauthorized,
+		}
+	}
+	var sess model.OfficerValidationResponse
+	err := json.Unmarshal([]byte(v), &sess)
+	if err != nil || sess.RefreshToken != inp.RefreshToken {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeUnauthorized,
+			ErrorMessage: apps.ErrMsgUnauthorized,
+		}
+	}
+
+	auth, ex := o.CiamWatcher.Refresh(model.CiamRefreshRequest{
+		Username:     sess.Code,
+		RefreshToken: inp.RefreshToken,
+	})
+	if ex != nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeUnauthorized,
+			ErrorMessage: apps.ErrMsgUnauthorized,
+		}
+	}
+	sess.SessionResponse = model.SessionResponse{
+		RefreshToken: auth.RefreshToken,
+		Token:        auth.Token,
+		AccessToken:  auth.AccessToken,
+		Expired:      &auth.ExpiresIn,
+	}
+	cache, err := json.Marshal(sess)
+	if err != nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSomethingWrong,
+			ErrorMessage: apps.ErrMsgSomethingWrong,
+		}
+	}
+	o.Cacher.Set("B2BSESSION", sess.Email, cache, o.AuthTTL)
+	o.Cacher.Set("B2BTOKEN", apps.Hash(auth.Token), sess.Email, o.AuthTTL)
+	return &sess.SessionResponse, nil
+}
+
+func (o *Onboard) Logout(inp *model.SessionRequest) *model.BusinessError {
+	v, ex := o.Cacher.Get("B2BSESSION", inp.Email)
+	if ex != nil {
+		return &model.BusinessError{
+			ErrorCode:    apps.ErrCodeUnauthorized,
+			ErrorMessage: apps.ErrMsgUnauthorized,
+		}
+	}
+	var sess model.OfficerValidationResponse
+	_ = json.Unmarshal([]byte(v), &sess)
+	if ex = o.CiamWatcher.SignOut(sess.AccessToken); ex != nil {
+		o.Logger.Error("failed to sign out officer from CIAM", zap.String("email", inp.Email))
+	}
+	if inp.TokenId != "" {
+		o.Cacher.Set("JWTDENY", inp.TokenId, inp.Email, apps.TokenTTL(inp.TokenExpiry, o.AuthTTL))
+	}
+	if ex = o.Cacher.Delete("B2BSESSION", inp.Email); ex != nil {
+		return &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSomethingWrong,
+			ErrorMessage: apps.ErrMsgSomethingWrong,
+		}
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/adinandradrs/cezbek-engine/mock/adaptor"
 	"github.com/adinandradrs/cezbek-engine/mock/repository"
 	"github.com/adinandradrs/cezbek-engine/mock/storage"
+	"github.com/adinandradrs/cezbek-engine/mock/usecase/notification"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -23,18 +24,25 @@ func TestOnboard_Authenticate(t *testing.T) {
 
 	authTTL, _ := time.ParseDuration("1s")
 	otpTTL, _ := time.ParseDuration("1s")
+	lockTTL, _ := time.ParseDuration("10s")
 	dao, ciamWatcher, sqsAdapter, cacher, q, cdn := repository.NewMockPartnerPersister(ctrl),
 		adaptor.NewMockCiamWatcher(ctrl), adaptor.NewMockSQSAdapter(ctrl), storage.NewMockCacher(ctrl),
 		"mock-queue", "https://cdn-mock.id"
+	templateProvider := notification.NewMockTemplateProvider(ctrl)
 	svc := NewOnboard(Onboard{
 		Logger:                    logger,
 		Cacher:                    cacher,
 		SqsAdapter:                sqsAdapter,
+		TemplateProvider:          templateProvider,
 		QueueNotificationEmailOtp: &q,
 		CDN:                       &cdn,
 		Dao:                       dao,
 		CiamWatcher:               ciamWatcher,
 		OtpTTL:                    otpTTL,
+		OtpLockTTL:                lockTTL,
+		OtpCooldown:               otpTTL,
+		OtpMaxAttempts:            3,
+		OtpMaxLockAttempts:        5,
 		AuthTTL:                   authTTL,
 	})
 	inp := &model.OfficerAuthenticationRequest{
@@ -52,14 +60,56 @@ func TestOnboard_Authenticate(t *testing.T) {
 		Secret:  secret,
 		Email:   sql.NullString{String: "someone@email.id", Valid: true},
 		Msisdn:  sql.NullString{String: "628123456789", Valid: true},
+		Locale:  sql.NullString{String: "en", Valid: true},
 	}
 	t.Run("should success with new generated OTP", func(t *testing.T) {
 		ttl, _ := time.ParseDuration("-5s")
 		dao.EXPECT().FindActiveByEmail(inp.Email).Return(p, nil)
+		cacher.EXPECT().Ttl("OTPLOCK", p.Email.String).Return(ttl, nil)
 		cacher.EXPECT().Ttl("OTPB2B", p.Email.String).Return(ttl, nil)
-		cacher.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
-		cacher.EXPECT().Hget(gomock.Any(), gomock.Any()).Times(2).Return("subject", nil)
+		cacher.EXPECT().Ttl("OTPCOOLDOWN", p.Email.String).Return(ttl, nil)
+		cacher.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(3)
+		templateProvider.EXPECT().Render("OTP", "en", gomock.Any()).DoAndReturn(
+			func(name string, locale string, data map[string]interface{}) (*model.NotificationContent, *model.BusinessError) {
+				assert.Equal(t, p.Partner.String, data["partner"])
+				assert.Len(t, data["otp"], 6)
+				return &model.NotificationContent{Subject: "subject", Content: "content"}, nil
+			})
+		sqsAdapter.EXPECT().SendMessage(gomock.Any(), gomock.Any()).Return(nil)
+		cacher.EXPECT().Incr("OTPRESEND", p.Email.String, lockTTL).Return(int64(3), nil)
+		cacher.EXPECT().Set("OTPCOOLDOWN", p.Email.String, int64(3), 4*otpTTL)
+		v, ex := svc.Authenticate(inp)
+		assert.Nil(t, ex)
+		assert.NotNil(t, v)
+	})
+	t.Run("should cool down the first resend by the cooldown ttl", func(t *testing.T) {
+		ttl, _ := time.ParseDuration("-5s")
+		dao.EXPECT().FindActiveByEmail(inp.Email).Return(p, nil)
+		cacher.EXPECT().Ttl("OTPLOCK", p.Email.String).Return(ttl, nil)
+		cacher.EXPECT().Ttl("OTPB2B", p.Email.String).Return(ttl, nil)
+		cacher.EXPECT().Ttl("OTPCOOLDOWN", p.Email.String).Return(ttl, nil)
+		cacher.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(3)
+		templateProvider.EXPECT().Render("OTP", "en", gomock.Any()).Return(
+			&model.NotificationContent{Subject: "subject", Content: "content"}, nil)
+		sqsAdapter.EXPECT().SendMessage(gomock.Any(), gomock.Any()).Return(nil)
+		cacher.EXPECT().Incr("OTPRESEND", p.Email.String, lockTTL).Return(int64(1), nil)
+		cacher.EXPECT().Set("OTPCOOLDOWN", p.Email.String, int64(1), otpTTL)
+		v, ex := svc.Authenticate(inp)
+		assert.Nil(t, ex)
+		assert.NotNil(t, v)
+	})
+	t.Run("should cap the resend cooldown at the lockout ttl", func(t *testing.T) {
+		ttl, _ := time.ParseDuration("-5s")
+		dao.EXPECT().FindActiveByEmail(inp.Email).Return(p, nil)
+		cacher.EXPECT().Ttl("OTPLOCK", p.Email.String).Return(ttl, nil)
+		cacher.EXPECT().Ttl("OTPB2B", p.Email.String).Return(ttl, nil)
+		cacher.EXPECT().Ttl("OTPCOOLDOWN", p.Email.String).Return(ttl, nil)
+		cacher.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(3)
+		templateProvider.EXPECT().Render("OTP", "en", gomock.Any()).Return(
+			&model.NotificationContent{Subject: "subject", Content: "content"}, nil)
 		sqsAdapter.EXPECT().SendMessage(gomock.Any(), gomock.Any()).Return(nil)
+		cacher.EXPECT().Incr("OTPRESEND", p.Email.String, lockTTL).Return(int64(6), nil)
+		cacher.EXPECT().Set("OTPCOOLDOWN", p.Email.String, int64(6), lockTTL)
 		v, ex := svc.Authenticate(inp)
 		assert.Nil(t, ex)
 		assert.NotNil(t, v)
@@ -67,6 +117,7 @@ func TestOnboard_Authenticate(t *testing.T) {
 	t.Run("should success with existing OTP", func(t *testing.T) {
 		ttl, _ := time.ParseDuration("5s")
 		dao.EXPECT().FindActiveByEmail(inp.Email).Return(p, nil)
+		cacher.EXPECT().Ttl("OTPLOCK", p.Email.String).Return(time.Duration(0), nil)
 		cacher.EXPECT().Ttl("OTPB2B", p.Email.String).Return(ttl, nil)
 		cacher.EXPECT().Get(gomock.Any(), gomock.Any()).Return("1#1", nil)
 		v, ex := svc.Authenticate(inp)
@@ -76,14 +127,53 @@ func TestOnboard_Authenticate(t *testing.T) {
 	t.Run("should return exception on send message", func(t *testing.T) {
 		ttl, _ := time.ParseDuration("-5s")
 		dao.EXPECT().FindActiveByEmail(inp.Email).Return(p, nil)
+		cacher.EXPECT().Ttl("OTPLOCK", p.Email.String).Return(ttl, nil)
 		cacher.EXPECT().Ttl("OTPB2B", p.Email.String).Return(ttl, nil)
-		cacher.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
-		cacher.EXPECT().Hget(gomock.Any(), gomock.Any()).Times(2).Return("subject", nil)
+		cacher.EXPECT().Ttl("OTPCOOLDOWN", p.Email.String).Return(ttl, nil)
+		cacher.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(3)
+		templateProvider.EXPECT().Render("OTP", "en", gomock.Any()).DoAndReturn(
+			func(name string, locale string, data map[string]interface{}) (*model.NotificationContent, *model.BusinessError) {
+				assert.Equal(t, p.Partner.String, data["partner"])
+				assert.Len(t, data["otp"], 6)
+				return &model.NotificationContent{Subject: "subject", Content: "content"}, nil
+			})
 		sqsAdapter.EXPECT().SendMessage(gomock.Any(), gomock.Any()).Return(fmt.Errorf("something went wrong"))
 		v, ex := svc.Authenticate(inp)
 		assert.NotNil(t, ex)
 		assert.Nil(t, v)
 	})
+	t.Run("should return exception on render template", func(t *testing.T) {
+		ttl, _ := time.ParseDuration("-5s")
+		dao.EXPECT().FindActiveByEmail(inp.Email).Return(p, nil)
+		cacher.EXPECT().Ttl("OTPLOCK", p.Email.String).Return(ttl, nil)
+		cacher.EXPECT().Ttl("OTPB2B", p.Email.String).Return(ttl, nil)
+		cacher.EXPECT().Ttl("OTPCOOLDOWN", p.Email.String).Return(ttl, nil)
+		cacher.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(3)
+		templateProvider.EXPECT().Render("OTP", gomock.Any(), gomock.Any()).Return(nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeBussTemplateInvalid,
+			ErrorMessage: apps.ErrMsgBussTemplateInvalid,
+		})
+		v, ex := svc.Authenticate(inp)
+		assert.Equal(t, apps.ErrCodeBussTemplateInvalid, ex.ErrorCode)
+		assert.Nil(t, v)
+	})
+	t.Run("should return exception on locked account", func(t *testing.T) {
+		dao.EXPECT().FindActiveByEmail(inp.Email).Return(p, nil)
+		cacher.EXPECT().Ttl("OTPLOCK", p.Email.String).Return(lockTTL, nil)
+		v, ex := svc.Authenticate(inp)
+		assert.Equal(t, apps.ErrCodeBussPartnerLocked, ex.ErrorCode)
+		assert.Nil(t, v)
+	})
+	t.Run("should return exception on resend cooldown", func(t *testing.T) {
+		ttl, _ := time.ParseDuration("-5s")
+		dao.EXPECT().FindActiveByEmail(inp.Email).Return(p, nil)
+		cacher.EXPECT().Ttl("OTPLOCK", p.Email.String).Return(ttl, nil)
+		cacher.EXPECT().Ttl("OTPB2B", p.Email.String).Return(ttl, nil)
+		cacher.EXPECT().Ttl("OTPCOOLDOWN", p.Email.String).Return(otpTTL, nil)
+		v, ex := svc.Authenticate(inp)
+		assert.Equal(t, apps.ErrCodeBussOTPThrottled, ex.ErrorCode)
+		assert.Nil(t, v)
+	})
 
 	t.Run("should return exception on email not found", func(t *testing.T) {
 		dao.EXPECT().FindActiveByEmail(inp.Email).Return(nil, &model.TechnicalError{
@@ -104,18 +194,26 @@ func TestOnboard_Validate(t *testing.T) {
 
 	authTTL, _ := time.ParseDuration("1s")
 	otpTTL, _ := time.ParseDuration("1s")
+	lockTTL, _ := time.ParseDuration("10s")
+	audit := repository.NewMockAuditPersister(ctrl)
 	dao, ciamWatcher, sqsAdapter, cacher, q, cdn := repository.NewMockPartnerPersister(ctrl),
 		adaptor.NewMockCiamWatcher(ctrl), adaptor.NewMockSQSAdapter(ctrl), storage.NewMockCacher(ctrl),
 		"mock-queue", "https://cdn-mock.id"
+	templateProvider := notification.NewMockTemplateProvider(ctrl)
 	svc := NewOnboard(Onboard{
 		Logger:                    logger,
 		Cacher:                    cacher,
 		SqsAdapter:                sqsAdapter,
+		TemplateProvider:          templateProvider,
 		QueueNotificationEmailOtp: &q,
 		CDN:                       &cdn,
 		Dao:                       dao,
+		AuditDao:                  audit,
 		CiamWatcher:               ciamWatcher,
 		OtpTTL:                    otpTTL,
+		OtpLockTTL:                lockTTL,
+		OtpMaxAttempts:            3,
+		OtpMaxLockAttempts:        5,
 		AuthTTL:                   authTTL,
 	})
 	inp := &model.OfficerValidationRequest{
@@ -125,6 +223,7 @@ func TestOnboard_Validate(t *testing.T) {
 	gpass, _ := apps.RandomPassword(12, 5, 3, logger)
 	salt := apps.Hash("CODE_A:" + uuid.NewString())
 	secret, _ := apps.Encrypt(gpass, salt, logger)
+	email := "someone@email.net"
 	t.Run("should success", func(t *testing.T) {
 		p := model.Partner{
 			Id:      int64(1),
@@ -136,10 +235,13 @@ func TestOnboard_Validate(t *testing.T) {
 			Salt:    sql.NullString{String: salt, Valid: true},
 		}
 		cache, _ := json.Marshal(p)
+		cacher.EXPECT().Get("OTPB2BTRX", inp.TransactionId).Return(email, nil)
+		cacher.EXPECT().Ttl("OTPLOCK", email).Return(time.Duration(0), nil)
 		cacher.EXPECT().Get("OTPB2B:"+inp.TransactionId, inp.Otp).
 			Return(string(cache), nil)
-		cacher.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(2)
+		cacher.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(7)
 		cacher.EXPECT().Set("B2BSESSION", p.Email.String, gomock.Any(), authTTL)
+		cacher.EXPECT().Set("B2BTOKEN", apps.Hash("token-abc"), p.Email.String, authTTL)
 		ciamWatcher.EXPECT().Authenticate(gomock.Any()).Return(&model.CiamAuthenticationResponse{
 			Token:        "token-abc",
 			ExpiresIn:    int64(1),
@@ -151,14 +253,80 @@ func TestOnboard_Validate(t *testing.T) {
 		assert.NotNil(t, v)
 	})
 	t.Run("should return exception on wrong OTP", func(t *testing.T) {
+		cacher.EXPECT().Get("OTPB2BTRX", inp.TransactionId).Return(email, nil)
+		cacher.EXPECT().Ttl("OTPLOCK", email).Return(time.Duration(0), nil)
 		cacher.EXPECT().Get("OTPB2B:"+inp.TransactionId, inp.Otp).
 			Return("", &model.TechnicalError{
 				Exception: "something went wrong",
 				Occurred:  time.Now().Unix(),
 				Ticket:    "ERR-001",
 			})
+		cacher.EXPECT().Incr("OTPFAIL", inp.TransactionId, otpTTL).Return(int64(1), nil)
+		cacher.EXPECT().Incr("OTPFAIL", email, lockTTL).Return(int64(1), nil)
 		v, ex := svc.Validate(inp)
-		assert.NotNil(t, ex)
+		assert.Equal(t, apps.ErrCodeBussPartnerOTPInvalid, ex.ErrorCode)
+		assert.Nil(t, v)
+	})
+	t.Run("should burn transaction on too many wrong OTP", func(t *testing.T) {
+		cacher.EXPECT().Get("OTPB2BTRX", inp.TransactionId).Return(email, nil)
+		cacher.EXPECT().Ttl("OTPLOCK", email).Return(time.Duration(0), nil)
+		cacher.EXPECT().Get("OTPB2B:"+inp.TransactionId, inp.Otp).
+			Return("", &model.TechnicalError{
+				Exception: "redis: nil",
+				Occurred:  time.Now().Unix(),
+				Ticket:    "ERR-001",
+			})
+		cacher.EXPECT().Incr("OTPFAIL", inp.TransactionId, otpTTL).Return(int64(3), nil)
+		cacher.EXPECT().Incr("OTPFAIL", email, lockTTL).Return(int64(3), nil)
+		cacher.EXPECT().Delete("OTPB2BTRX", inp.TransactionId).Return(nil)
+		cacher.EXPECT().Delete("OTPB2B", email).Return(nil)
+		v, ex := svc.Validate(inp)
+		assert.Equal(t, apps.ErrCodeBussPartnerOTPInvalid, ex.ErrorCode)
+		assert.Nil(t, v)
+	})
+	t.Run("should lock out on too many wrong OTP per email", func(t *testing.T) {
+		cacher.EXPECT().Get("OTPB2BTRX", inp.TransactionId).Return(email, nil)
+		cacher.EXPECT().Ttl("OTPLOCK", email).Return(time.Duration(0), nil)
+		cacher.EXPECT().Get("OTPB2B:"+inp.TransactionId, inp.Otp).
+			Return("", &model.TechnicalError{
+				Exception: "redis: nil",
+				Occurred:  time.Now().Unix(),
+				Ticket:    "ERR-001",
+			})
+		cacher.EXPECT().Incr("OTPFAIL", inp.TransactionId, otpTTL).Return(int64(1), nil)
+		cacher.EXPECT().Incr("OTPFAIL", email, lockTTL).Return(int64(5), nil)
+		cacher.EXPECT().Set("OTPLOCK", email, inp.TransactionId, lockTTL)
+		cacher.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(3)
+		audit.EXPECT().Add(gomock.Any()).Return(nil)
+		dao.EXPECT().FindActiveByEmail(email).Return(&model.Partner{
+			Partner: sql.NullString{String: "Partner A", Valid: true},
+			Email:   sql.NullString{String: email, Valid: true},
+			Locale:  sql.NullString{String: "id", Valid: true},
+		}, nil)
+		templateProvider.EXPECT().Render("OTP_LOCKED", "id", map[string]interface{}{
+			"partner":  "Partner A",
+			"duration": lockTTL.String(),
+		}).Return(&model.NotificationContent{Subject: "subject", Content: "content"}, nil)
+		sqsAdapter.EXPECT().SendMessage(q, gomock.Any()).Return(nil)
+		v, ex := svc.Validate(inp)
+		assert.Equal(t, apps.ErrCodeBussPartnerLocked, ex.ErrorCode)
+		assert.Nil(t, v)
+	})
+	t.Run("should return exception on locked account", func(t *testing.T) {
+		cacher.EXPECT().Get("OTPB2BTRX", inp.TransactionId).Return(email, nil)
+		cacher.EXPECT().Ttl("OTPLOCK", email).Return(lockTTL, nil)
+		v, ex := svc.Validate(inp)
+		assert.Equal(t, apps.ErrCodeBussPartnerLocked, ex.ErrorCode)
+		assert.Nil(t, v)
+	})
+	t.Run("should return exception on unknown transaction", func(t *testing.T) {
+		cacher.EXPECT().Get("OTPB2BTRX", inp.TransactionId).Return("", &model.TechnicalError{
+			Exception: "redis: nil",
+			Occurred:  time.Now().Unix(),
+			Ticket:    "ERR-001",
+		})
+		v, ex := svc.Validate(inp)
+		assert.Equal(t, apps.ErrCodeBussPartnerOTPInvalid, ex.ErrorCode)
 		assert.Nil(t, v)
 	})
 	t.Run("should return exception on CIAM failure", func(t *testing.T) {
@@ -172,6 +340,8 @@ func TestOnboard_Validate(t *testing.T) {
 			Salt:    sql.NullString{String: salt, Valid: true},
 		}
 		cache, _ := json.Marshal(p)
+		cacher.EXPECT().Get("OTPB2BTRX", inp.TransactionId).Return(email, nil)
+		cacher.EXPECT().Ttl("OTPLOCK", email).Return(time.Duration(0), nil)
 		cacher.EXPECT().Get("OTPB2B:"+inp.TransactionId, inp.Otp).
 			Return(string(cache), nil)
 		ciamWatcher.EXPECT().Authenticate(gomock.Any()).Return(nil, &model.TechnicalError{
@@ -184,3 +354,144 @@ func TestOnboard_Validate(t *testing.T) {
 		assert.Nil(t, v)
 	})
 }
+
+func TestOnboard_Refresh(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+
+	authTTL, _ := time.ParseDuration("1s")
+	otpTTL, _ := time.ParseDuration("1s")
+	dao, ciamWatcher, sqsAdapter, cacher, q, cdn := repository.NewMockPartnerPersister(ctrl),
+		adaptor.NewMockCiamWatcher(ctrl), adaptor.NewMockSQSAdapter(ctrl), storage.NewMockCacher(ctrl),
+		"mock-queue", "https://cdn-mock.id"
+	templateProvider := notification.NewMockTemplateProvider(ctrl)
+	svc := NewOnboard(Onboard{
+		Logger:                    logger,
+		Cacher:                    cacher,
+		SqsAdapter:                sqsAdapter,
+		TemplateProvider:          templateProvider,
+		QueueNotificationEmailOtp: &q,
+		CDN:                       &cdn,
+		Dao:                       dao,
+		CiamWatcher:               ciamWatcher,
+		OtpTTL:                    otpTTL,
+		AuthTTL:                   authTTL,
+	})
+	inp := &model.SessionRefreshRequest{
+		Username:     "Someone@Email.net",
+		RefreshToken: "ref-token-abc",
+	}
+	sess, _ := json.Marshal(model.OfficerValidationResponse{
+		Id:      1,
+		Code:    "CODE_A",
+		Email:   "someone@email.net",
+		Company: "Partner A",
+		SessionResponse: model.SessionResponse{
+			Token:        "token-abc",
+			AccessToken:  "access-token-abc",
+			RefreshToken: "ref-token-abc",
+		},
+	})
+	t.Run("should success", func(t *testing.T) {
+		cacher.EXPECT().Get("B2BSESSION", "someone@email.net").Return(string(sess), nil)
+		ciamWatcher.EXPECT().Refresh(model.CiamRefreshRequest{
+			Username:     "CODE_A",
+			RefreshToken: inp.RefreshToken,
+		}).Return(&model.CiamAuthenticationResponse{
+			Token:        "token-def",
+			ExpiresIn:    int64(1),
+			AccessToken:  "access-token-def",
+			RefreshToken: "ref-token-abc",
+		}, nil)
+		cacher.EXPECT().Set("B2BSESSION", "someone@email.net", gomock.Any(), authTTL)
+		cacher.EXPECT().Set("B2BTOKEN", apps.Hash("token-def"), "someone@email.net", authTTL)
+		v, ex := svc.Refresh(inp)
+		assert.Nil(t, ex)
+		assert.Equal(t, "token-def", v.Token)
+	})
+	t.Run("should return exception on mismatch refresh token", func(t *testing.T) {
+		cacher.EXPECT().Get("B2BSESSION", "someone@email.net").Return(string(sess), nil)
+		v, ex := svc.Refresh(&model.SessionRefreshRequest{
+			Username:     "someone@email.net",
+			RefreshToken: "ref-token-stolen",
+		})
+		assert.Equal(t, apps.ErrCodeUnauthorized, ex.ErrorCode)
+		assert.Nil(t, v)
+	})
+	t.Run("should return exception on ciam to refresh", func(t *testing.T) {
+		cacher.EXPECT().Get("B2BSESSION", "someone@email.net").Return(string(sess), nil)
+		ciamWatcher.EXPECT().Refresh(gomock.Any()).Return(nil, &model.TechnicalError{
+			Exception: "something went wrong",
+			Occurred:  time.Now().Unix(),
+			Ticket:    "ERR-001",
+		})
+		v, ex := svc.Refresh(inp)
+		assert.Equal(t, apps.ErrCodeUnauthorized, ex.ErrorCode)
+		assert.Nil(t, v)
+	})
+}
+
+func TestOnboard_Logout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+
+	authTTL, _ := time.ParseDuration("1s")
+	ciamWatcher, cacher := adaptor.NewMockCiamWatcher(ctrl), storage.NewMockCacher(ctrl)
+	svc := NewOnboard(Onboard{
+		Logger:      logger,
+		Cacher:      cacher,
+		CiamWatcher: ciamWatcher,
+		AuthTTL:     authTTL,
+	})
+	inp := &model.SessionRequest{
+		Email:   "someone@email.net",
+		TokenId: "jti-abc-123",
+	}
+	sess, _ := json.Marshal(model.SessionResponse{
+		Token:       "token-abc",
+		AccessToken: "access-token-abc",
+	})
+	t.Run("should success", func(t *testing.T) {
+		cacher.EXPECT().Get("B2BSESSION", "someone@email.net").Return(string(sess), nil)
+		ciamWatcher.EXPECT().SignOut("access-token-abc").Return(nil)
+		cacher.EXPECT().Set("JWTDENY", "jti-abc-123", "someone@email.net", authTTL)
+		cacher.EXPECT().Delete("B2BSESSION", "someone@email.net").Return(nil)
+		ex := svc.Logout(inp)
+		assert.Nil(t, ex)
+	})
+	t.Run("should success on ciam to sign-out failed", func(t *testing.T) {
+		cacher.EXPECT().Get("B2BSESSION", "someone@email.net").Return(string(sess), nil)
+		ciamWatcher.EXPECT().SignOut("access-token-abc").Return(&model.TechnicalError{
+			Exception: "something went wrong",
+			Occurred:  time.Now().Unix(),
+			Ticket:    "ERR-001",
+		})
+		cacher.EXPECT().Set("JWTDENY", "jti-abc-123", "someone@email.net", authTTL)
+		cacher.EXPECT().Delete("B2BSESSION", "someone@email.net").Return(nil)
+		ex := svc.Logout(inp)
+		assert.Nil(t, ex)
+	})
+	t.Run("should return exception on no active session", func(t *testing.T) {
+		cacher.EXPECT().Get("B2BSESSION", "someone@email.net").Return("", &model.TechnicalError{
+			Exception: "redis: nil",
+			Occurred:  time.Now().Unix(),
+			Ticket:    "ERR-001",
+		})
+		ex := svc.Logout(inp)
+		assert.Equal(t, apps.ErrCodeUnauthorized, ex.ErrorCode)
+	})
+	t.Run("should return exception on failed to delete session", func(t *testing.T) {
+		cacher.EXPECT().Get("B2BSESSION", "someone@email.net").Return(string(sess), nil)
+		ciamWatcher.EXPECT().SignOut("access-token-abc").Return(nil)
+		cacher.EXPECT().Set("JWTDENY", "jti-abc-123", "someone@email.net", authTTL)
+		cacher.EXPECT().Delete("B2BSESSION", "someone@email.net").Return(&model.TechnicalError{
+			Exception: "something went wrong",
+			Occurred:  time.Now().Unix(),
+			Ticket:    "ERR-001",
+		})
+		ex := svc.Logout(inp)
+		assert.Equal(t, apps.ErrCodeSomethingWrong, ex.ErrorCode)
+	})
+}
@@ -0,0 +1,37 @@
+package partner
+
+import (
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/internal/repository"
+	"go.uber.org/zap"
+)
+
+type Suppression struct {
+	Dao    repository.SuppressionPersister
+	Logger *zap.Logger
+}
+
+type SuppressionProvider interface {
+	Search(inp *model.SearchRequest) (*model.SuppressionSearchResponse, *model.BusinessError)
+}
+
+func NewSuppression(s Suppression) SuppressionProvider {
+	return &s
+}
+
+func (s *Suppression) Search(inp *model.SearchRequest) (*model.SuppressionSearchResponse, *model.BusinessError) {
+	model.Page(inp)
+	c, countEx := s.Dao.CountByPartner(inp)
+	v, searchEx := s.Dao.SearchByPartner(inp)
+	if countEx != nil || searchEx != nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeNotFound,
+			ErrorMessage: apps.ErrMsgNotFound,
+		}
+	}
+	return &model.SuppressionSearchResponse{
+		Suppressions:       v,
+		PaginationResponse: model.Pagination(*c, inp.Limit, inp.Start),
+	}, nil
+}
@@ -0,0 +1,58 @@
+package partner
+
+import (
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/mock/repository"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSuppression_Search(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	dao := repository.NewMockSuppressionPersister(ctrl)
+	svc := NewSuppression(Suppression{
+		Dao:    dao,
+		Logger: logger,
+	})
+	inp := &model.SearchRequest{
+		Limit: 10,
+		Start: 1,
+		SessionRequest: model.SessionRequest{
+			Id: 1,
+		},
+	}
+	t.Run("should success", func(t *testing.T) {
+		count := 1
+		dao.EXPECT().CountByPartner(inp).Return(&count, nil)
+		dao.EXPECT().SearchByPartner(inp).Return([]model.SuppressionProjection{
+			{
+				Email:          "someone@email.net",
+				Reason:         apps.SuppressionBounce,
+				Detail:         "smtp; 550 5.1.1 user unknown",
+				SuppressedDate: time.Now().Unix(),
+			},
+		}, nil)
+		v, ex := svc.Search(inp)
+		assert.Nil(t, ex)
+		assert.Len(t, v.Suppressions, 1)
+		assert.Equal(t, 1, v.TotalElements)
+	})
+
+	t.Run("should return exception on failed in one of the query", func(t *testing.T) {
+		count := 1
+		dao.EXPECT().CountByPartner(inp).Return(&count, nil)
+		dao.EXPECT().SearchByPartner(inp).Return(nil, &model.TechnicalError{
+			Exception: "something went wrong",
+			Occurred:  time.Now().Unix(),
+			Ticket:    "ERR-001",
+		})
+		v, ex := svc.Search(inp)
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeNotFound, ex.ErrorCode)
+	})
+}
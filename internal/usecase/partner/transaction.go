@@ -1,40 +1,96 @@
 package partner
 
 import (
+	"encoding/json"
+	"github.com/adinandradrs/cezbek-engine/internal/adaptor"
 	"github.com/adinandradrs/cezbek-engine/internal/apps"
 	"github.com/adinandradrs/cezbek-engine/internal/model"
 	"github.com/adinandradrs/cezbek-engine/internal/repository"
+	"github.com/adinandradrs/cezbek-engine/internal/usecase/workflow"
+	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type Transaction struct {
-	Dao    repository.TransactionPersister
-	Logger *zap.Logger
+	Dao repository.TransactionPersister
+	workflow.ReceiptProvider
+	SqsAdapter  adaptor.SQSAdapter
+	QueueExport *string
+	Logger      *zap.Logger
 }
 
 type TransactionProvider interface {
-	Search(inp *model.SearchRequest) (*model.PartnerTransactionSearchResponse, *model.BusinessError)
+	Search(inp *model.PartnerTransactionSearchRequest) (*model.PartnerTransactionSearchResponse, *model.BusinessError)
 	Detail(inp *model.FindByIdRequest) (*model.PartnerTransactionProjection, *model.BusinessError)
+	Receipt(inp *model.FindByIdRequest) (*model.ReceiptResponse, *model.BusinessError)
+	Export(inp *model.TransactionExportRequest) (*model.TransactionResponse, *model.BusinessError)
 }
 
 func NewTransaction(t Transaction) TransactionProvider {
 	return &t
 }
 
-func (t *Transaction) Search(inp *model.SearchRequest) (*model.PartnerTransactionSearchResponse, *model.BusinessError) {
-	model.Page(inp)
-	c, countEx := t.Dao.CountByPartner(inp)
-	v, searchEx := t.Dao.SearchByPartner(inp)
-	if countEx != nil || searchEx != nil {
+func validCursor(inp *model.PartnerTransactionSearchRequest) bool {
+	c, err := model.ParseCursor(inp.Cursor)
+	if err != nil || c.Id <= 0 {
+		return false
+	}
+	switch strings.ToUpper(inp.SortBy) {
+	case "DATE":
+		if _, err = time.Parse("2006-01-02 15:04:05.999999", c.Key); err != nil {
+			_, err = time.Parse("2006-01-02 15:04:05.999999-07", c.Key)
+		}
+	case "WALLET_CODE":
+	case "TRANSACTION", "AMOUNT", "CASHBACK", "REWARD":
+		_, err = decimal.NewFromString(c.Key)
+	default:
+		_, err = strconv.ParseInt(c.Key, 10, 64)
+	}
+	return err == nil
+}
+
+func (t *Transaction) Search(inp *model.PartnerTransactionSearchRequest) (*model.PartnerTransactionSearchResponse,
+	*model.BusinessError) {
+	if inp.Cursor != "" && !validCursor(inp) {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeBadPayload,
+			ErrorMessage: apps.ErrMsgBadPayload,
+		}
+	}
+	model.Page(&inp.SearchRequest)
+	v, ex := t.Dao.SearchByPartner(inp)
+	if ex != nil {
 		return nil, &model.BusinessError{
 			ErrorCode:    apps.ErrCodeNotFound,
 			ErrorMessage: apps.ErrMsgNotFound,
 		}
 	}
-	return &model.PartnerTransactionSearchResponse{
-		Transactions:       v,
-		PaginationResponse: model.Pagination(*c, inp.Limit, inp.Start),
-	}, nil
+	res := &model.PartnerTransactionSearchResponse{
+		Transactions: v,
+		PaginationResponse: model.PaginationResponse{
+			Size:   inp.Limit,
+			Number: inp.Start,
+		},
+	}
+	if len(v) == inp.Limit {
+		last := v[len(v)-1]
+		res.NextCursor = model.Cursor{Key: last.SortKey, Id: last.Id}.String()
+	}
+	if inp.SkipCount {
+		return res, nil
+	}
+	c, ex := t.Dao.CountByPartner(inp)
+	if ex != nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeNotFound,
+			ErrorMessage: apps.ErrMsgNotFound,
+		}
+	}
+	res.PaginationResponse = model.Pagination(*c, inp.Limit, inp.Start)
+	return res, nil
 }
 
 func (t *Transaction) Detail(inp *model.FindByIdRequest) (*model.PartnerTransactionProjection, *model.BusinessError) {
@@ -47,3 +103,47 @@ func (t *Transaction) Detail(inp *model.FindByIdRequest) (*model.PartnerTransact
 	}
 	return v, nil
 }
+
+func (t *Transaction) Receipt(inp *model.FindByIdRequest) (*model.ReceiptResponse, *model.BusinessError) {
+	v, bx := t.Detail(inp)
+	if bx != nil {
+		return nil, bx
+	}
+	return t.ReceiptProvider.Download(&model.ReceiptRequest{
+		PartnerId:       inp.SessionRequest.Id,
+		PartnerCode:     inp.SessionRequest.Username,
+		Partner:         inp.SessionRequest.Fullname,
+		KezbekRefCode:   v.KezbekRefCode,
+		WalletCode:      v.WalletCode,
+		Msisdn:          v.Msisdn,
+		Email:           v.Email,
+		Qty:             v.Qty,
+		Transaction:     v.Transaction,
+		Cashback:        v.Cashback,
+		Reward:          v.Reward,
+		TransactionDate: v.TransactionDate,
+		Locale:          inp.SessionRequest.Locale,
+	})
+}
+
+func (t *Transaction) Export(inp *model.TransactionExportRequest) (*model.TransactionResponse, *model.BusinessError) {
+	inp.SessionRequest = model.SessionRequest{
+		Id:       inp.SessionRequest.Id,
+		Username: inp.SessionRequest.Username,
+		Fullname: inp.SessionRequest.Fullname,
+		Email:    inp.SessionRequest.Email,
+		Locale:   inp.SessionRequest.Locale,
+	}
+	msg, _ := json.Marshal(inp)
+	err := t.SqsAdapter.SendMessage(*t.QueueExport, string(msg))
+	if err != nil {
+		t.Logger.Error("failed to queue transaction export", zap.Int64("partner_id", inp.SessionRequest.Id),
+			zap.Error(err))
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSomethingWrong,
+			ErrorMessage: apps.ErrMsgSomethingWrong,
+		}
+	}
+	v := apps.Transaction(inp.SessionRequest.Username)
+	return &v, nil
+}
@@ -1,9 +1,12 @@
 package partner
 
 import (
+	"fmt"
 	"github.com/adinandradrs/cezbek-engine/internal/apps"
 	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/mock/adaptor"
 	"github.com/adinandradrs/cezbek-engine/mock/repository"
+	"github.com/adinandradrs/cezbek-engine/mock/usecase/workflow"
 	"github.com/golang/mock/gomock"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
@@ -20,12 +23,14 @@ func TestTransaction_Search(t *testing.T) {
 		Dao:    dao,
 		Logger: logger,
 	})
-	inp := &model.SearchRequest{
-		Limit:  3,
-		Start:  1,
-		SortBy: "DESC",
-		SessionRequest: model.SessionRequest{
-			Id: 1,
+	inp := &model.PartnerTransactionSearchRequest{
+		SearchRequest: model.SearchRequest{
+			Limit:  3,
+			Start:  1,
+			SortBy: "DESC",
+			SessionRequest: model.SessionRequest{
+				Id: 1,
+			},
 		},
 	}
 	t.Run("should success", func(t *testing.T) {
@@ -60,24 +65,83 @@ func TestTransaction_Search(t *testing.T) {
 				Cashback:    decimal.NewFromInt(300),
 				Reward:      decimal.NewFromInt(400),
 				Msisdn:      "628118770511",
+				SortKey:     "3",
 			},
 		}, nil)
 		dao.EXPECT().CountByPartner(inp).Return(&count, nil)
 		v, ex := svc.Search(inp)
 		assert.Nil(t, ex)
-		assert.NotNil(t, v)
+		assert.Equal(t, 50, v.TotalElements)
+		assert.Equal(t, model.Cursor{Key: "3", Id: 3}.String(), v.NextCursor)
 	})
 
-	t.Run("should return exception on failed in one of the query", func(t *testing.T) {
-		count := 50
+	t.Run("should success without count on the last page", func(t *testing.T) {
+		inp.SkipCount = true
+		dao.EXPECT().SearchByPartner(inp).Return([]model.PartnerTransactionProjection{
+			{
+				Id:          4,
+				Transaction: decimal.NewFromInt(50000),
+				WalletCode:  "WALLET_A",
+				SortKey:     "4",
+			},
+		}, nil)
+		v, ex := svc.Search(inp)
+		assert.Nil(t, ex)
+		assert.Len(t, v.Transactions, 1)
+		assert.Empty(t, v.NextCursor)
+		assert.Equal(t, 0, v.TotalElements)
+		inp.SkipCount = false
+	})
+
+	t.Run("should return bad payload on malformed cursor", func(t *testing.T) {
+		for _, c := range []struct{ sortBy, cursor string }{
+			{"", "n0tacurs0r"},
+			{"", model.Cursor{Key: "abc", Id: 3}.String()},
+			{"", model.Cursor{Key: "3"}.String()},
+			{"DATE", model.Cursor{Key: "yesterday", Id: 3}.String()},
+			{"CASHBACK", model.Cursor{Key: "12,5", Id: 3}.String()},
+		} {
+			v, ex := svc.Search(&model.PartnerTransactionSearchRequest{
+				Cursor:        c.cursor,
+				SearchRequest: model.SearchRequest{SortBy: c.sortBy},
+			})
+			assert.Nil(t, v)
+			assert.Equal(t, apps.ErrCodeBadPayload, ex.ErrorCode)
+		}
+	})
+
+	t.Run("should success after the cursor sorted by date", func(t *testing.T) {
+		dinp := &model.PartnerTransactionSearchRequest{
+			Cursor:        model.Cursor{Key: "2023-01-02 15:04:05.123456", Id: 3}.String(),
+			SkipCount:     true,
+			SearchRequest: model.SearchRequest{Limit: 3, SortBy: "DATE"},
+		}
+		dao.EXPECT().SearchByPartner(dinp).Return([]model.PartnerTransactionProjection{}, nil)
+		v, ex := svc.Search(dinp)
+		assert.Nil(t, ex)
+		assert.Empty(t, v.Transactions)
+	})
+
+	t.Run("should return exception on failed to search", func(t *testing.T) {
 		dao.EXPECT().SearchByPartner(inp).Return(nil, &model.TechnicalError{
 			Exception: "something went wrong",
 			Occurred:  time.Now().Unix(),
 			Ticket:    "ERR-001",
 		})
-		dao.EXPECT().CountByPartner(inp).Return(&count, nil)
 		v, ex := svc.Search(inp)
-		assert.NotNil(t, ex)
+		assert.Equal(t, apps.ErrCodeNotFound, ex.ErrorCode)
+		assert.Nil(t, v)
+	})
+
+	t.Run("should return exception on failed to count", func(t *testing.T) {
+		dao.EXPECT().SearchByPartner(inp).Return([]model.PartnerTransactionProjection{}, nil)
+		dao.EXPECT().CountByPartner(inp).Return(nil, &model.TechnicalError{
+			Exception: "something went wrong",
+			Occurred:  time.Now().Unix(),
+			Ticket:    "ERR-001",
+		})
+		v, ex := svc.Search(inp)
+		assert.Equal(t, apps.ErrCodeNotFound, ex.ErrorCode)
 		assert.Nil(t, v)
 	})
 }
@@ -125,3 +189,103 @@ func TestTransaction_Detail(t *testing.T) {
 		assert.Nil(t, v)
 	})
 }
+
+func TestTransaction_Receipt(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	dao, receiptProvider := repository.NewMockTransactionPersister(ctrl), workflow.NewMockReceiptProvider(ctrl)
+	inp := &model.FindByIdRequest{
+		Id: 1,
+		SessionRequest: model.SessionRequest{
+			Id:       1,
+			Username: "CORP_A",
+			Fullname: "Company A",
+			Locale:   "id",
+		},
+	}
+	svc := NewTransaction(Transaction{
+		Dao:             dao,
+		ReceiptProvider: receiptProvider,
+		Logger:          logger,
+	})
+
+	t.Run("should success", func(t *testing.T) {
+		dao.EXPECT().DetailByPartner(inp).Return(&model.PartnerTransactionProjection{
+			Id:            1,
+			KezbekRefCode: "TRX0012345678",
+			Cashback:      decimal.NewFromInt(350),
+			Reward:        decimal.NewFromInt(500),
+		}, nil)
+		receiptProvider.EXPECT().Download(gomock.Any()).DoAndReturn(
+			func(r *model.ReceiptRequest) (*model.ReceiptResponse, *model.BusinessError) {
+				assert.Equal(t, int64(1), r.PartnerId)
+				assert.Equal(t, "CORP_A", r.PartnerCode)
+				assert.Equal(t, "TRX0012345678", r.KezbekRefCode)
+				assert.Equal(t, decimal.NewFromInt(500), r.Reward)
+				return &model.ReceiptResponse{Filename: "TRX0012345678.pdf"}, nil
+			})
+		v, ex := svc.Receipt(inp)
+		assert.Nil(t, ex)
+		assert.Equal(t, "TRX0012345678.pdf", v.Filename)
+	})
+
+	t.Run("should return exception on transaction not found", func(t *testing.T) {
+		dao.EXPECT().DetailByPartner(inp).Return(nil, &model.TechnicalError{
+			Exception: "something went wrong",
+			Occurred:  time.Now().Unix(),
+			Ticket:    "ERR-001",
+		})
+		v, ex := svc.Receipt(inp)
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeNotFound, ex.ErrorCode)
+	})
+}
+
+func TestTransaction_Export(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	sqsAdapter, q := adaptor.NewMockSQSAdapter(ctrl), "mock-queue"
+	svc := NewTransaction(Transaction{
+		SqsAdapter:  sqsAdapter,
+		QueueExport: &q,
+		Logger:      logger,
+	})
+	inp := &model.TransactionExportRequest{
+		Format: apps.ExportXLSX,
+		PartnerTransactionSearchRequest: model.PartnerTransactionSearchRequest{
+			WalletCode: "LSAJA",
+			SearchRequest: model.SearchRequest{
+				TextSearch: "someone",
+				SessionRequest: model.SessionRequest{
+					Id:       1,
+					Username: "CORP_A",
+					Email:    "finance@corp-a.id",
+					ContextRequest: model.ContextRequest{
+						Authorization: "Bearer token",
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("should success", func(t *testing.T) {
+		sqsAdapter.EXPECT().SendMessage(q, gomock.Any()).DoAndReturn(func(q string, msg string) error {
+			assert.Contains(t, msg, "finance@corp-a.id")
+			assert.Contains(t, msg, `"wallet_code":"LSAJA"`)
+			assert.NotContains(t, msg, "Bearer token")
+			return nil
+		})
+		v, ex := svc.Export(inp)
+		assert.Nil(t, ex)
+		assert.NotNil(t, v)
+	})
+
+	t.Run("should return exception on failed to queue", func(t *testing.T) {
+		sqsAdapter.EXPECT().SendMessage(q, gomock.Any()).Return(fmt.Errorf("something went wrong"))
+		v, ex := svc.Export(inp)
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeSomethingWrong, ex.ErrorCode)
+	})
+}
@@ -0,0 +1,177 @@
+package workflow
+
+import (
+	"bytes"
+	"github.com/adinandradrs/cezbek-engine/internal/adaptor"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/internal/repository"
+	"github.com/adinandradrs/cezbek-engine/internal/usecase/notification"
+	"go.uber.org/zap"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"strconv"
+	"strings"
+)
+
+type Receipt struct {
+	adaptor.S3Watcher
+	PartnerDao repository.PartnerPersister
+	CDN        *string
+	PathS3     *string
+	Secret     string
+	Logger     *zap.Logger
+}
+
+type ReceiptProvider interface {
+	Generate(inp *model.ReceiptRequest) (*model.ReceiptResponse, *model.BusinessError)
+	Download(inp *model.ReceiptRequest) (*model.ReceiptResponse, *model.BusinessError)
+}
+
+func NewReceipt(r Receipt) ReceiptProvider {
+	return &r
+}
+
+var receiptLabels = map[string]map[string]string{
+	apps.LocaleEnglish: {
+		"title":       "Cashback Receipt",
+		"reference":   "Kezbek Reference",
+		"date":        "Transaction Date",
+		"wallet":      "Wallet",
+		"msisdn":      "Mobile Number",
+		"email":       "Email",
+		"qty":         "Quantity",
+		"transaction": "Transaction Amount",
+		"cashback":    "Cashback",
+		"reward":      "Tier Reward",
+		"total":       "Total Disbursed",
+		"footer":      "This receipt is generated electronically and is valid without a signature.",
+	},
+	apps.LocaleIndonesian: {
+		"title":       "Kuitansi Cashback",
+		"reference":   "Referensi Kezbek",
+		"date":        "Tanggal Transaksi",
+		"wallet":      "Dompet",
+		"msisdn":      "Nomor Ponsel",
+		"email":       "Email",
+		"qty":         "Jumlah Barang",
+		"transaction": "Nilai Transaksi",
+		"cashback":    "Cashback",
+		"reward":      "Hadiah Tier",
+		"total":       "Total Dicairkan",
+		"footer":      "Kuitansi ini dibuat secara elektronik dan sah tanpa tanda tangan.",
+	},
+}
+
+func (r *Receipt) path(inp *model.ReceiptRequest) string {
+	return *r.PathS3 + "receipt/" + strconv.FormatInt(inp.PartnerId, 10) + "/" + inp.KezbekRefCode + "-" +
+		apps.HMAC(inp.KezbekRefCode, r.Secret)[:16] + ".pdf"
+}
+
+func (r *Receipt) logo(code string) (image.Image, []byte) {
+	p, ex := r.PartnerDao.FindActiveByCode(code)
+	if ex != nil || p.Logo.String == "" {
+		return nil, nil
+	}
+	v, ex := r.S3Watcher.Download(p.Logo.String)
+	if ex != nil {
+		return nil, nil
+	}
+	src, _, err := image.Decode(bytes.NewReader(v))
+	if err != nil {
+		r.Logger.Warn("failed to decode partner logo", zap.String("logo", p.Logo.String), zap.Error(err))
+		return nil, nil
+	}
+	img := image.NewRGBA(src.Bounds())
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: color.White}, image.Point{}, draw.Src)
+	draw.Draw(img, img.Bounds(), src, src.Bounds().Min, draw.Over)
+	var b bytes.Buffer
+	if err = jpeg.Encode(&b, img, &jpeg.Options{Quality: 90}); err != nil {
+		return nil, nil
+	}
+	return img, b.Bytes()
+}
+
+func (r *Receipt) render(inp *model.ReceiptRequest) []byte {
+	labels, ok := receiptLabels[strings.SplitN(apps.NormalizeLocale(inp.Locale), "-", 2)[0]]
+	if !ok {
+		labels = receiptLabels[apps.LocaleEnglish]
+	}
+	pdf := apps.NewPDF()
+	const left, right = 50.0, apps.PdfWidth - 50
+	y := apps.PdfHeight - 60
+	if img, v := r.logo(inp.PartnerCode); img != nil {
+		w, h := float64(img.Bounds().Dx()), float64(img.Bounds().Dy())
+		scale := 60 / h
+		if w*scale > 160 {
+			scale = 160 / w
+		}
+		pdf.Image(v, img.Bounds().Dx(), img.Bounds().Dy(), left, y-h*scale+20, w*scale, h*scale)
+		y -= 70
+	}
+	pdf.Text(left, y, 18, true, labels["title"])
+	pdf.Text(left, y-18, 11, false, inp.Partner)
+	y -= 32
+	pdf.Line(left, y, right, y)
+
+	row := func(label string, value string, bold bool) {
+		y -= 22
+		pdf.Text(left, y, 10, bold, label)
+		pdf.Text(left+200, y, 10, bold, value)
+	}
+	row(labels["reference"], inp.KezbekRefCode, false)
+	row(labels["date"], notification.LocalizedDate(inp.TransactionDate, inp.Locale), false)
+	row(labels["wallet"], inp.WalletCode, false)
+	row(labels["msisdn"], inp.Msisdn, false)
+	row(labels["email"], inp.Email, false)
+	row(labels["qty"], strconv.Itoa(inp.Qty), false)
+	y -= 14
+	pdf.Line(left, y, right, y)
+	row(labels["transaction"], notification.IDR(inp.Transaction), false)
+	row(labels["cashback"], notification.IDR(inp.Cashback), false)
+	row(labels["reward"], notification.IDR(inp.Reward), false)
+	y -= 14
+	pdf.Line(left, y, right, y)
+	row(labels["total"], notification.IDR(inp.Cashback.Add(inp.Reward)), true)
+	pdf.Text(left, 50, 8, false, labels["footer"])
+	return pdf.Bytes()
+}
+
+func (r *Receipt) Generate(inp *model.ReceiptRequest) (*model.ReceiptResponse, *model.BusinessError) {
+	content, path := r.render(inp), r.path(inp)
+	_, ex := r.S3Watcher.Upload(&model.S3UploadRequest{
+		Destination: path,
+		Source:      bytes.NewReader(content),
+		ContentType: "application/pdf",
+	})
+	if ex != nil {
+		return nil, &model.BusinessError{
+			ErrorCode:    apps.ErrCodeSomethingWrong,
+			ErrorMessage: apps.ErrMsgSomethingWrong,
+		}
+	}
+	return &model.ReceiptResponse{
+		Filename: inp.KezbekRefCode + ".pdf",
+		Path:     path,
+		Url:      *r.CDN + path,
+		Content:  content,
+	}, nil
+}
+
+func (r *Receipt) Download(inp *model.ReceiptRequest) (*model.ReceiptResponse, *model.BusinessError) {
+	path := r.path(inp)
+	v, ex := r.S3Watcher.Download(path)
+	if ex == nil {
+		return &model.ReceiptResponse{
+			Filename: inp.KezbekRefCode + ".pdf",
+			Path:     path,
+			Url:      *r.CDN + path,
+			Content:  v,
+		}, nil
+	}
+	return r.Generate(inp)
+}
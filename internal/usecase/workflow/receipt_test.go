@@ -0,0 +1,141 @@
+package workflow
+
+import (
+	"bytes"
+	"database/sql"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
+	"github.com/adinandradrs/cezbek-engine/internal/model"
+	"github.com/adinandradrs/cezbek-engine/mock/adaptor"
+	"github.com/adinandradrs/cezbek-engine/mock/repository"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"image"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReceipt_Generate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	s3Watcher, partnerDao := adaptor.NewMockS3Watcher(ctrl), repository.NewMockPartnerPersister(ctrl)
+	cdn, path := "https://cdn.kezbek.id/", "kezbek/"
+	svc := NewReceipt(Receipt{
+		S3Watcher:  s3Watcher,
+		PartnerDao: partnerDao,
+		CDN:        &cdn,
+		PathS3:     &path,
+		Secret:     "secret",
+		Logger:     logger,
+	})
+	inp := &model.ReceiptRequest{
+		PartnerId:       1,
+		PartnerCode:     "CORP_A",
+		Partner:         "PT. Corporate (A)",
+		KezbekRefCode:   "TRX0012345678",
+		WalletCode:      "LSAJA",
+		Msisdn:          "628118770510",
+		Email:           "someone@email.net",
+		Qty:             2,
+		Transaction:     decimal.NewFromInt(250000),
+		Cashback:        decimal.NewFromInt(2500),
+		Reward:          decimal.NewFromInt(13000),
+		TransactionDate: time.Date(2023, 8, 17, 10, 0, 0, 0, time.UTC).Unix(),
+		Locale:          "id",
+	}
+	var logo bytes.Buffer
+	_ = png.Encode(&logo, image.NewRGBA(image.Rect(0, 0, 40, 20)))
+
+	t.Run("should success with partner logo", func(t *testing.T) {
+		partnerDao.EXPECT().FindActiveByCode("CORP_A").Return(&model.Partner{
+			Logo: sql.NullString{String: "kezbek/logo/1.png", Valid: true},
+		}, nil)
+		s3Watcher.EXPECT().Download("kezbek/logo/1.png").Return(logo.Bytes(), nil)
+		s3Watcher.EXPECT().Upload(gomock.Any()).DoAndReturn(
+			func(req *model.S3UploadRequest) (*s3manager.UploadOutput, *model.TechnicalError) {
+				assert.Equal(t, "application/pdf", req.ContentType)
+				assert.True(t, strings.HasPrefix(req.Destination, "kezbek/receipt/1/TRX0012345678-"))
+				b, _ := io.ReadAll(req.Source)
+				assert.True(t, bytes.HasPrefix(b, []byte("%PDF-1.4")))
+				return nil, nil
+			})
+		v, ex := svc.Generate(inp)
+		assert.Nil(t, ex)
+		assert.Equal(t, "TRX0012345678.pdf", v.Filename)
+		assert.Equal(t, cdn+v.Path, v.Url)
+		assert.NotContains(t, v.Path, "TRX0012345678.pdf")
+		content := string(v.Content)
+		assert.Contains(t, content, "/DCTDecode")
+		assert.Contains(t, content, "(Kuitansi Cashback)")
+		assert.Contains(t, content, `(PT. Corporate \(A\))`)
+		assert.Contains(t, content, "(17 Agu 2023)")
+		assert.Contains(t, content, "(Rp 15.500)")
+	})
+
+	t.Run("should success without partner logo", func(t *testing.T) {
+		partnerDao.EXPECT().FindActiveByCode("CORP_A").Return(nil, &model.TechnicalError{
+			Exception: "something went wrong",
+		})
+		s3Watcher.EXPECT().Upload(gomock.Any()).Return(nil, nil)
+		req := *inp
+		req.Locale = "fr"
+		v, ex := svc.Generate(&req)
+		assert.Nil(t, ex)
+		assert.NotContains(t, string(v.Content), "/DCTDecode")
+		assert.Contains(t, string(v.Content), "(Cashback Receipt)")
+	})
+
+	t.Run("should return exception on failed to upload", func(t *testing.T) {
+		partnerDao.EXPECT().FindActiveByCode("CORP_A").Return(&model.Partner{}, nil)
+		s3Watcher.EXPECT().Upload(gomock.Any()).Return(nil, &model.TechnicalError{
+			Exception: "something went wrong",
+		})
+		v, ex := svc.Generate(inp)
+		assert.Nil(t, v)
+		assert.Equal(t, apps.ErrCodeSomethingWrong, ex.ErrorCode)
+	})
+}
+
+func TestReceipt_Download(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := apps.NewLog(false)
+	s3Watcher, partnerDao := adaptor.NewMockS3Watcher(ctrl), repository.NewMockPartnerPersister(ctrl)
+	cdn, path := "https://cdn.kezbek.id/", "kezbek/"
+	svc := NewReceipt(Receipt{
+		S3Watcher:  s3Watcher,
+		PartnerDao: partnerDao,
+		CDN:        &cdn,
+		PathS3:     &path,
+		Secret:     "secret",
+		Logger:     logger,
+	})
+	inp := &model.ReceiptRequest{
+		PartnerId:     1,
+		PartnerCode:   "CORP_A",
+		KezbekRefCode: "TRX0012345678",
+	}
+
+	t.Run("should success with stored receipt", func(t *testing.T) {
+		s3Watcher.EXPECT().Download(gomock.Any()).Return([]byte("%PDF-1.4 stored"), nil)
+		v, ex := svc.Download(inp)
+		assert.Nil(t, ex)
+		assert.Equal(t, []byte("%PDF-1.4 stored"), v.Content)
+	})
+
+	t.Run("should success to generate missing receipt", func(t *testing.T) {
+		s3Watcher.EXPECT().Download(gomock.Any()).Return(nil, &model.TechnicalError{
+			Exception: "NoSuchKey",
+		})
+		partnerDao.EXPECT().FindActiveByCode("CORP_A").Return(&model.Partner{}, nil)
+		s3Watcher.EXPECT().Upload(gomock.Any()).Return(nil, nil)
+		v, ex := svc.Download(inp)
+		assert.Nil(t, ex)
+		assert.True(t, bytes.HasPrefix(v.Content, []byte("%PDF-1.4")))
+	})
+}
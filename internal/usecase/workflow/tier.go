@@ -3,9 +3,12 @@ package workflow
 import (
 	"database/sql"
 	"encoding/json"
+	"github.com/adinandradrs/cezbek-engine/internal/apps"
 	"github.com/adinandradrs/cezbek-engine/internal/model"
 	"github.com/adinandradrs/cezbek-engine/internal/repository"
 	"github.com/adinandradrs/cezbek-engine/internal/storage"
+	"github.com/adinandradrs/cezbek-engine/internal/usecase/notification"
+	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
 	"strconv"
 	"time"
@@ -14,18 +17,48 @@ import (
 type Tier struct {
 	Dao            repository.TierPersister
 	Cacher         storage.Cacher
+	EventProvider  notification.EventProvider
 	ExpiryDuration time.Duration
 	Logger         *zap.Logger
 }
 
 type TierProvider interface {
-	Save(inp *model.TierRequest) (*model.WfRewardTierProjection, *model.TechnicalError)
+	Evaluate(inp *model.TierRequest) (*model.TierChange, *model.TechnicalError)
+	Save(inp *model.TierRequest, c *model.TierChange) *model.TechnicalError
+	NotifyUpgrade(inp *model.TierRequest, v *model.TierChange)
 }
 
 func NewTier(t Tier) TierProvider {
 	return &t
 }
 
+func TierEvent(c storage.Cacher, event string, v model.TierChangeProjection, reward decimal.Decimal) *model.NotificationEvent {
+	nextTier, nextTransactions := "", 0
+	cache, ex := c.Get("WFREWARD:"+v.CurrentTier, "1")
+	var m model.WfRewardTierProjection
+	if ex == nil && json.Unmarshal([]byte(cache), &m) == nil && m.NextTier.Tier != nil {
+		nextTier, nextTransactions = *m.NextTier.Tier, m.MaxRecurring-1
+	}
+	return &model.NotificationEvent{
+		Event:    event,
+		Category: apps.NotificationCategoryTier,
+		Partner:  v.PartnerCode,
+		Msisdn:   v.Msisdn,
+		Email:    v.Email,
+		Locale:   v.Locale,
+		Data: map[string]interface{}{
+			"partner":              v.Partner,
+			"msisdn":               v.Msisdn,
+			"tier":                 v.CurrentTier,
+			"prevTier":             v.PrevTier,
+			"reward":               reward,
+			"nextTier":             nextTier,
+			"nextTierTransactions": nextTransactions,
+			"expiredDate":          v.ExpiredDate,
+		},
+	}
+}
+
 func (t Tier) add(inp *model.TierRequest) *model.TechnicalError {
 	return t.Dao.Add(model.Tier{
 		PartnerId:            inp.PartnerId,
@@ -48,11 +81,11 @@ func (t Tier) add(inp *model.TierRequest) *model.TechnicalError {
 	})
 }
 
-func (t Tier) update(v *model.Tier, inp *model.TierRequest) (*model.WfRewardTierProjection, *model.TechnicalError) {
+func (t Tier) evaluate(v *model.Tier, inp *model.TierRequest) *model.TierChange {
 	v.TransactionRecurring = v.TransactionRecurring + 1
 	cacher, ex := t.Cacher.Get("WFREWARD:"+v.CurrentTier.String, strconv.Itoa(v.TransactionRecurring))
 	var m model.WfRewardTierProjection
-	currentRecurring := v.TransactionRecurring
+	currentRecurring, upgraded := v.TransactionRecurring, false
 	if ex == nil && cacher != "" {
 		_ = json.Unmarshal([]byte(cacher), &m)
 		t.Logger.Info("workflow tier found", zap.String("msisdn", inp.Msisdn), zap.Any("reward", m.Reward))
@@ -63,28 +96,61 @@ func (t Tier) update(v *model.Tier, inp *model.TierRequest) (*model.WfRewardTier
 			v.CurrentTier = sql.NullString{String: *m.NextTier.Tier}
 			v.TransactionRecurring = 1
 			v.ExpiredDate = sql.NullTime{Time: time.Now().Add(t.ExpiryDuration)}
+			upgraded = true
 		}
 	}
 	v.Journey = model.TierJourney{
-		CurrentTier:       v.CurrentTier,
-		CurrentGrade:      v.CurrentGrade,
-		LastTransactionId: inp.TransactionId,
+		CurrentTier:  v.CurrentTier,
+		CurrentGrade: v.CurrentGrade,
 	}
 	v.BaseEntity.UpdatedBy = sql.NullInt64{Int64: inp.PartnerId}
-	ex = t.Dao.Update(*v)
+	c := &model.TierChange{Tier: v, Upgraded: upgraded}
 	if m.Recurring == currentRecurring ||
 		m.MaxRecurring == currentRecurring {
-		return &m, nil
+		c.Reward = &m
+	}
+	return c
+}
+
+func (t Tier) NotifyUpgrade(inp *model.TierRequest, c *model.TierChange) {
+	if c == nil || !c.Upgraded {
+		return
+	}
+	v, reward := c.Tier, decimal.Zero
+	if c.Reward != nil {
+		reward = c.Reward.Reward
+	}
+	bx := t.EventProvider.Publish(TierEvent(t.Cacher, apps.NotificationEventTierUpgraded, model.TierChangeProjection{
+		PartnerId:    inp.PartnerId,
+		PartnerCode:  inp.PartnerCode,
+		Partner:      inp.Partner,
+		Locale:       inp.Locale,
+		Msisdn:       inp.Msisdn,
+		Email:        inp.Email,
+		CurrentGrade: v.CurrentGrade,
+		CurrentTier:  v.CurrentTier.String,
+		PrevGrade:    v.PrevGrade,
+		PrevTier:     v.PrevTier.String,
+		ExpiredDate:  v.ExpiredDate.Time,
+	}, reward))
+	if bx != nil {
+		t.Logger.Error("failed to notify tier upgrade", zap.String("msisdn", inp.Msisdn))
 	}
-	return nil, ex
 }
 
-func (t Tier) Save(inp *model.TierRequest) (*model.WfRewardTierProjection, *model.TechnicalError) {
+func (t Tier) Evaluate(inp *model.TierRequest) (*model.TierChange, *model.TechnicalError) {
 	v, ex := t.Dao.FindByPartnerMsisdn(inp.PartnerId, inp.Msisdn)
 	if v == nil && ex != nil {
-		ex = t.add(inp)
-		return nil, ex
-	} else {
-		return t.update(v, inp)
+		return &model.TierChange{}, nil
+	}
+	return t.evaluate(v, inp), nil
+}
+
+func (t Tier) Save(inp *model.TierRequest, c *model.TierChange) *model.TechnicalError {
+	if c.Tier == nil {
+		return t.add(inp)
 	}
+	v := *c.Tier
+	v.Journey.LastTransactionId = inp.TransactionId
+	return t.Dao.Update(v)
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/adinandradrs/cezbek-engine/internal/model"
 	"github.com/adinandradrs/cezbek-engine/mock/repository"
 	"github.com/adinandradrs/cezbek-engine/mock/storage"
+	"github.com/adinandradrs/cezbek-engine/mock/usecase/notification"
 	"github.com/golang/mock/gomock"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
@@ -19,8 +20,8 @@ func TestTier_Save(t *testing.T) {
 	defer ctrl.Finish()
 	logger, _ := apps.NewLog(false)
 
-	dao, cacher := repository.NewMockTierPersister(ctrl),
-		storage.NewMockCacher(ctrl)
+	dao, cacher, eventProvider := repository.NewMockTierPersister(ctrl),
+		storage.NewMockCacher(ctrl), notification.NewMockEventProvider(ctrl)
 	inp := &model.TierRequest{
 		PartnerId:     1,
 		Msisdn:        "628118770510",
@@ -32,6 +33,7 @@ func TestTier_Save(t *testing.T) {
 		Dao:            dao,
 		Logger:         logger,
 		Cacher:         cacher,
+		EventProvider:  eventProvider,
 		ExpiryDuration: exp,
 	})
 	t.Run("should success with add ops", func(t *testing.T) {
@@ -41,9 +43,14 @@ func TestTier_Save(t *testing.T) {
 				Occurred:  time.Now().Unix(),
 				Exception: "data is not found",
 			})
-		dao.EXPECT().Add(gomock.Any()).Return(nil)
-		_, ex := svc.Save(inp)
+		v, ex := svc.Evaluate(inp)
 		assert.Nil(t, ex)
+		assert.Nil(t, v.Tier)
+		assert.Nil(t, v.Reward)
+		dao.EXPECT().Add(gomock.Any()).Do(func(m model.Tier) {
+			assert.Equal(t, int64(1), m.Journey.LastTransactionId)
+		}).Return(nil)
+		assert.Nil(t, svc.Save(inp, v))
 	})
 
 	t.Run("should success with next tier on update ops", func(t *testing.T) {
@@ -61,7 +68,6 @@ func TestTier_Save(t *testing.T) {
 		}
 		dao.EXPECT().FindByPartnerMsisdn(inp.PartnerId, inp.Msisdn).
 			Return(d, nil)
-		dao.EXPECT().Update(gomock.Any()).Return(nil)
 		ngrade := 3
 		ntier := "GOLD"
 		cache, _ := json.Marshal(model.WfRewardTierProjection{
@@ -73,8 +79,78 @@ func TestTier_Save(t *testing.T) {
 			Reward:    decimal.NewFromInt(1000),
 			Recurring: 3,
 		})
-		cacher.EXPECT().Get(gomock.Any(), gomock.Any()).Return(string(cache), nil)
-		_, ex := svc.Save(inp)
+		cacher.EXPECT().Get("WFREWARD:SILVER", "3").Return(string(cache), nil)
+		next, _ := json.Marshal(model.WfRewardTierProjection{
+			MaxRecurring: 5,
+			NextTier: model.WfRewardTierGradeProjection{
+				Grade: &ngrade,
+				Tier:  &ntier,
+			},
+			Recurring: 1,
+		})
+		cacher.EXPECT().Get("WFREWARD:GOLD", "1").Return(string(next), nil)
+		eventProvider.EXPECT().Publish(gomock.Any()).DoAndReturn(func(e *model.NotificationEvent) *model.BusinessError {
+			assert.Equal(t, apps.NotificationEventTierUpgraded, e.Event)
+			assert.Equal(t, apps.NotificationCategoryTier, e.Category)
+			assert.Equal(t, "GOLD", e.Data["tier"])
+			assert.Equal(t, "SILVER", e.Data["prevTier"])
+			assert.Equal(t, decimal.NewFromInt(1000), e.Data["reward"])
+			assert.Equal(t, "GOLD", e.Data["nextTier"])
+			assert.Equal(t, 4, e.Data["nextTierTransactions"])
+			return nil
+		})
+		v, ex := svc.Evaluate(inp)
 		assert.Nil(t, ex)
+		assert.True(t, v.Upgraded)
+		assert.Equal(t, "GOLD", v.Tier.CurrentTier.String)
+		dao.EXPECT().Update(gomock.Any()).Do(func(m model.Tier) {
+			assert.Equal(t, "GOLD", m.Journey.CurrentTier.String)
+			assert.Equal(t, int64(1), m.Journey.LastTransactionId)
+		}).Return(nil)
+		assert.Nil(t, svc.Save(inp, v))
+		svc.NotifyUpgrade(inp, v)
+	})
+
+	t.Run("should success without notification on update ops in the same tier", func(t *testing.T) {
+		d := &model.Tier{
+			PartnerId:            1,
+			TransactionRecurring: 1,
+			CurrentTier:          sql.NullString{String: "SILVER"},
+			CurrentGrade:         2,
+		}
+		dao.EXPECT().FindByPartnerMsisdn(inp.PartnerId, inp.Msisdn).Return(d, nil)
+		cache, _ := json.Marshal(model.WfRewardTierProjection{
+			MaxRecurring: 3,
+			Reward:       decimal.NewFromInt(500),
+			Recurring:    2,
+		})
+		cacher.EXPECT().Get("WFREWARD:SILVER", "2").Return(string(cache), nil)
+		v, ex := svc.Evaluate(inp)
+		assert.Nil(t, ex)
+		assert.False(t, v.Upgraded)
+		assert.Equal(t, decimal.NewFromInt(500), v.Reward.Reward)
+		dao.EXPECT().Update(gomock.Any()).Return(nil)
+		assert.Nil(t, svc.Save(inp, v))
+		svc.NotifyUpgrade(inp, v)
+	})
+
+	t.Run("should return exception on failed update ops", func(t *testing.T) {
+		d := &model.Tier{
+			PartnerId:            1,
+			TransactionRecurring: 1,
+			CurrentTier:          sql.NullString{String: "SILVER"},
+			CurrentGrade:         2,
+		}
+		dao.EXPECT().FindByPartnerMsisdn(inp.PartnerId, inp.Msisdn).Return(d, nil)
+		dao.EXPECT().Update(gomock.Any()).Return(&model.TechnicalError{Exception: "something went wrong"})
+		cache, _ := json.Marshal(model.WfRewardTierProjection{
+			MaxRecurring: 3,
+			Reward:       decimal.NewFromInt(500),
+			Recurring:    2,
+		})
+		cacher.EXPECT().Get("WFREWARD:SILVER", "2").Return(string(cache), nil)
+		v, _ := svc.Evaluate(inp)
+		ex := svc.Save(inp, v)
+		assert.NotNil(t, ex)
 	})
 }
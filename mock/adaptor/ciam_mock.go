@@ -78,3 +78,46 @@ func (mr *MockCiamWatcherMockRecorder) OnboardPartner(m interface{}) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnboardPartner", reflect.TypeOf((*MockCiamWatcher)(nil).OnboardPartner), m)
 }
+
+// Refresh mocks base method.
+func (m_2 *MockCiamWatcher) Refresh(m model.CiamRefreshRequest) (*model.CiamAuthenticationResponse, *model.TechnicalError) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Refresh", m)
+	ret0, _ := ret[0].(*model.CiamAuthenticationResponse)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockCiamWatcherMockRecorder) Refresh(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockCiamWatcher)(nil).Refresh), m)
+}
+
+// RevokeUser mocks base method.
+func (m *MockCiamWatcher) RevokeUser(username string) *model.TechnicalError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUser", username)
+	ret0, _ := ret[0].(*model.TechnicalError)
+	return ret0
+}
+
+// RevokeUser indicates an expected call of RevokeUser.
+func (mr *MockCiamWatcherMockRecorder) RevokeUser(username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUser", reflect.TypeOf((*MockCiamWatcher)(nil).RevokeUser), username)
+}
+
+// SignOut mocks base method.
+func (m *MockCiamWatcher) SignOut(accessToken string) *model.TechnicalError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignOut", accessToken)
+	ret0, _ := ret[0].(*model.TechnicalError)
+	return ret0
+}
+
+// SignOut indicates an expected call of SignOut.
+func (mr *MockCiamWatcherMockRecorder) SignOut(accessToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignOut", reflect.TypeOf((*MockCiamWatcher)(nil).SignOut), accessToken)
+}
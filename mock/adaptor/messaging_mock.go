@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: messaging.go
+
+// Package mock_adaptor is a generated GoMock package.
+package adaptor
+
+import (
+	reflect "reflect"
+
+	model "github.com/adinandradrs/cezbek-engine/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMessagingAdapter is a mock of MessagingAdapter interface.
+type MockMessagingAdapter struct {
+	ctrl     *gomock.Controller
+	recorder *MockMessagingAdapterMockRecorder
+}
+
+// MockMessagingAdapterMockRecorder is the mock recorder for MockMessagingAdapter.
+type MockMessagingAdapterMockRecorder struct {
+	mock *MockMessagingAdapter
+}
+
+// NewMockMessagingAdapter creates a new mock instance.
+func NewMockMessagingAdapter(ctrl *gomock.Controller) *MockMessagingAdapter {
+	mock := &MockMessagingAdapter{ctrl: ctrl}
+	mock.recorder = &MockMessagingAdapterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMessagingAdapter) EXPECT() *MockMessagingAdapterMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m_2 *MockMessagingAdapter) Send(m model.SendMessageRequest) (*model.TransactionResponse, *model.TechnicalError) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Send", m)
+	ret0, _ := ret[0].(*model.TransactionResponse)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockMessagingAdapterMockRecorder) Send(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockMessagingAdapter)(nil).Send), m)
+}
@@ -6,6 +6,7 @@ package adaptor
 
 import (
 	reflect "reflect"
+	time "time"
 
 	model "github.com/adinandradrs/cezbek-engine/internal/model"
 	s3manager "github.com/aws/aws-sdk-go/service/s3/s3manager"
@@ -35,6 +36,36 @@ func (m *MockS3Watcher) EXPECT() *MockS3WatcherMockRecorder {
 	return m.recorder
 }
 
+// Download mocks base method.
+func (m *MockS3Watcher) Download(path string) ([]byte, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Download", path)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// Download indicates an expected call of Download.
+func (mr *MockS3WatcherMockRecorder) Download(path interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Download", reflect.TypeOf((*MockS3Watcher)(nil).Download), path)
+}
+
+// Presign mocks base method.
+func (m *MockS3Watcher) Presign(path string, ttl time.Duration) (*string, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Presign", path, ttl)
+	ret0, _ := ret[0].(*string)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// Presign indicates an expected call of Presign.
+func (mr *MockS3WatcherMockRecorder) Presign(path, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Presign", reflect.TypeOf((*MockS3Watcher)(nil).Presign), path, ttl)
+}
+
 // Upload mocks base method.
 func (m *MockS3Watcher) Upload(req *model.S3UploadRequest) (*s3manager.UploadOutput, *model.TechnicalError) {
 	m.ctrl.T.Helper()
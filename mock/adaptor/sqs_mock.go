@@ -34,32 +34,47 @@ func (m *MockSQSAdapter) EXPECT() *MockSQSAdapterMockRecorder {
 	return m.recorder
 }
 
-// DeleteMessages mocks base method.
-func (m *MockSQSAdapter) DeleteMessages(q, h string) *sqs.DeleteMessageOutput {
+// ChangeVisibility mocks base method.
+func (m *MockSQSAdapter) ChangeVisibility(q, h string, visibility int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteMessages", q, h)
-	ret0, _ := ret[0].(*sqs.DeleteMessageOutput)
+	ret := m.ctrl.Call(m, "ChangeVisibility", q, h, visibility)
+	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteMessages indicates an expected call of DeleteMessages.
-func (mr *MockSQSAdapterMockRecorder) DeleteMessages(q, h interface{}) *gomock.Call {
+// ChangeVisibility indicates an expected call of ChangeVisibility.
+func (mr *MockSQSAdapterMockRecorder) ChangeVisibility(q, h, visibility interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMessages", reflect.TypeOf((*MockSQSAdapter)(nil).DeleteMessages), q, h)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeVisibility", reflect.TypeOf((*MockSQSAdapter)(nil).ChangeVisibility), q, h, visibility)
 }
 
-// GetMessages mocks base method.
-func (m *MockSQSAdapter) GetMessages(q string) *sqs.Message {
+// DeleteMessageBatch mocks base method.
+func (m *MockSQSAdapter) DeleteMessageBatch(q string, hs []string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMessages", q)
-	ret0, _ := ret[0].(*sqs.Message)
+	ret := m.ctrl.Call(m, "DeleteMessageBatch", q, hs)
+	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// GetMessages indicates an expected call of GetMessages.
-func (mr *MockSQSAdapterMockRecorder) GetMessages(q interface{}) *gomock.Call {
+// DeleteMessageBatch indicates an expected call of DeleteMessageBatch.
+func (mr *MockSQSAdapterMockRecorder) DeleteMessageBatch(q, hs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMessageBatch", reflect.TypeOf((*MockSQSAdapter)(nil).DeleteMessageBatch), q, hs)
+}
+
+// ReceiveMessages mocks base method.
+func (m *MockSQSAdapter) ReceiveMessages(q string, max, wait, visibility int64) ([]*sqs.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReceiveMessages", q, max, wait, visibility)
+	ret0, _ := ret[0].([]*sqs.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReceiveMessages indicates an expected call of ReceiveMessages.
+func (mr *MockSQSAdapterMockRecorder) ReceiveMessages(q, max, wait, visibility interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessages", reflect.TypeOf((*MockSQSAdapter)(nil).GetMessages), q)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiveMessages", reflect.TypeOf((*MockSQSAdapter)(nil).ReceiveMessages), q, max, wait, visibility)
 }
 
 // SendMessage mocks base method.
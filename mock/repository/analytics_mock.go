@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: analytics.go
+
+// Package mock_repository is a generated GoMock package.
+package repository
+
+import (
+	reflect "reflect"
+
+	model "github.com/adinandradrs/cezbek-engine/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAnalyticsPersister is a mock of AnalyticsPersister interface.
+type MockAnalyticsPersister struct {
+	ctrl     *gomock.Controller
+	recorder *MockAnalyticsPersisterMockRecorder
+}
+
+// MockAnalyticsPersisterMockRecorder is the mock recorder for MockAnalyticsPersister.
+type MockAnalyticsPersisterMockRecorder struct {
+	mock *MockAnalyticsPersister
+}
+
+// NewMockAnalyticsPersister creates a new mock instance.
+func NewMockAnalyticsPersister(ctrl *gomock.Controller) *MockAnalyticsPersister {
+	mock := &MockAnalyticsPersister{ctrl: ctrl}
+	mock.recorder = &MockAnalyticsPersisterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAnalyticsPersister) EXPECT() *MockAnalyticsPersisterMockRecorder {
+	return m.recorder
+}
+
+// CashbackVolume mocks base method.
+func (m *MockAnalyticsPersister) CashbackVolume(inp *model.AnalyticsRequest) ([]model.CashbackVolumeProjection, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CashbackVolume", inp)
+	ret0, _ := ret[0].([]model.CashbackVolumeProjection)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// CashbackVolume indicates an expected call of CashbackVolume.
+func (mr *MockAnalyticsPersisterMockRecorder) CashbackVolume(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CashbackVolume", reflect.TypeOf((*MockAnalyticsPersister)(nil).CashbackVolume), inp)
+}
+
+// CustomerSummary mocks base method.
+func (m *MockAnalyticsPersister) CustomerSummary(inp *model.AnalyticsRequest) (*model.CustomerSummaryProjection, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CustomerSummary", inp)
+	ret0, _ := ret[0].(*model.CustomerSummaryProjection)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// CustomerSummary indicates an expected call of CustomerSummary.
+func (mr *MockAnalyticsPersisterMockRecorder) CustomerSummary(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CustomerSummary", reflect.TypeOf((*MockAnalyticsPersister)(nil).CustomerSummary), inp)
+}
+
+// DashboardCustomer mocks base method.
+func (m *MockAnalyticsPersister) DashboardCustomer(inp *model.AnalyticsRequest) (*model.DashboardCustomerProjection, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DashboardCustomer", inp)
+	ret0, _ := ret[0].(*model.DashboardCustomerProjection)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// DashboardCustomer indicates an expected call of DashboardCustomer.
+func (mr *MockAnalyticsPersisterMockRecorder) DashboardCustomer(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DashboardCustomer", reflect.TypeOf((*MockAnalyticsPersister)(nil).DashboardCustomer), inp)
+}
+
+// DashboardSnapshot mocks base method.
+func (m *MockAnalyticsPersister) DashboardSnapshot(inp *model.AnalyticsRequest) ([]model.DashboardSnapshotProjection, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DashboardSnapshot", inp)
+	ret0, _ := ret[0].([]model.DashboardSnapshotProjection)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// DashboardSnapshot indicates an expected call of DashboardSnapshot.
+func (mr *MockAnalyticsPersisterMockRecorder) DashboardSnapshot(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DashboardSnapshot", reflect.TypeOf((*MockAnalyticsPersister)(nil).DashboardSnapshot), inp)
+}
+
+// ProviderSavings mocks base method.
+func (m *MockAnalyticsPersister) ProviderSavings(inp *model.AnalyticsRequest, defaultProvider string) ([]model.ProviderSavingsProjection, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProviderSavings", inp, defaultProvider)
+	ret0, _ := ret[0].([]model.ProviderSavingsProjection)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// ProviderSavings indicates an expected call of ProviderSavings.
+func (mr *MockAnalyticsPersisterMockRecorder) ProviderSavings(inp, defaultProvider interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProviderSavings", reflect.TypeOf((*MockAnalyticsPersister)(nil).ProviderSavings), inp, defaultProvider)
+}
+
+// TierDistribution mocks base method.
+func (m *MockAnalyticsPersister) TierDistribution(inp *model.AnalyticsRequest) ([]model.TierDistributionProjection, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TierDistribution", inp)
+	ret0, _ := ret[0].([]model.TierDistributionProjection)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// TierDistribution indicates an expected call of TierDistribution.
+func (mr *MockAnalyticsPersisterMockRecorder) TierDistribution(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TierDistribution", reflect.TypeOf((*MockAnalyticsPersister)(nil).TierDistribution), inp)
+}
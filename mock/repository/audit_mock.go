@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: audit.go
+
+// Package mock_repository is a generated GoMock package.
+package repository
+
+import (
+	reflect "reflect"
+
+	model "github.com/adinandradrs/cezbek-engine/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAuditPersister is a mock of AuditPersister interface.
+type MockAuditPersister struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditPersisterMockRecorder
+}
+
+// MockAuditPersisterMockRecorder is the mock recorder for MockAuditPersister.
+type MockAuditPersisterMockRecorder struct {
+	mock *MockAuditPersister
+}
+
+// NewMockAuditPersister creates a new mock instance.
+func NewMockAuditPersister(ctrl *gomock.Controller) *MockAuditPersister {
+	mock := &MockAuditPersister{ctrl: ctrl}
+	mock.recorder = &MockAuditPersisterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditPersister) EXPECT() *MockAuditPersisterMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockAuditPersister) Add(audit model.Audit) *model.TechnicalError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", audit)
+	ret0, _ := ret[0].(*model.TechnicalError)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockAuditPersisterMockRecorder) Add(audit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockAuditPersister)(nil).Add), audit)
+}
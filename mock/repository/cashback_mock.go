@@ -35,15 +35,15 @@ func (m *MockCashbackPersister) EXPECT() *MockCashbackPersisterMockRecorder {
 }
 
 // Add mocks base method.
-func (m *MockCashbackPersister) Add(cashback model.Cashback) *model.TechnicalError {
+func (m *MockCashbackPersister) Add(cashback model.Cashback, journal model.LedgerJournal) *model.TechnicalError {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Add", cashback)
+	ret := m.ctrl.Call(m, "Add", cashback, journal)
 	ret0, _ := ret[0].(*model.TechnicalError)
 	return ret0
 }
 
 // Add indicates an expected call of Add.
-func (mr *MockCashbackPersisterMockRecorder) Add(cashback interface{}) *gomock.Call {
+func (mr *MockCashbackPersisterMockRecorder) Add(cashback, journal interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockCashbackPersister)(nil).Add), cashback)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockCashbackPersister)(nil).Add), cashback, journal)
 }
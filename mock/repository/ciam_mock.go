@@ -0,0 +1,78 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ciam.go
+
+// Package mock_repository is a generated GoMock package.
+package repository
+
+import (
+	reflect "reflect"
+
+	model "github.com/adinandradrs/cezbek-engine/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCiamUserPersister is a mock of CiamUserPersister interface.
+type MockCiamUserPersister struct {
+	ctrl     *gomock.Controller
+	recorder *MockCiamUserPersisterMockRecorder
+}
+
+// MockCiamUserPersisterMockRecorder is the mock recorder for MockCiamUserPersister.
+type MockCiamUserPersisterMockRecorder struct {
+	mock *MockCiamUserPersister
+}
+
+// NewMockCiamUserPersister creates a new mock instance.
+func NewMockCiamUserPersister(ctrl *gomock.Controller) *MockCiamUserPersister {
+	mock := &MockCiamUserPersister{ctrl: ctrl}
+	mock.recorder = &MockCiamUserPersisterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCiamUserPersister) EXPECT() *MockCiamUserPersisterMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockCiamUserPersister) Add(user model.CiamUser) *model.TechnicalError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", user)
+	ret0, _ := ret[0].(*model.TechnicalError)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockCiamUserPersisterMockRecorder) Add(user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockCiamUserPersister)(nil).Add), user)
+}
+
+// FindActiveByUsername mocks base method.
+func (m *MockCiamUserPersister) FindActiveByUsername(username string) (*model.CiamUser, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActiveByUsername", username)
+	ret0, _ := ret[0].(*model.CiamUser)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// FindActiveByUsername indicates an expected call of FindActiveByUsername.
+func (mr *MockCiamUserPersisterMockRecorder) FindActiveByUsername(username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActiveByUsername", reflect.TypeOf((*MockCiamUserPersister)(nil).FindActiveByUsername), username)
+}
+
+// RevokeTokens mocks base method.
+func (m *MockCiamUserPersister) RevokeTokens(username string) *model.TechnicalError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeTokens", username)
+	ret0, _ := ret[0].(*model.TechnicalError)
+	return ret0
+}
+
+// RevokeTokens indicates an expected call of RevokeTokens.
+func (mr *MockCiamUserPersisterMockRecorder) RevokeTokens(username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeTokens", reflect.TypeOf((*MockCiamUserPersister)(nil).RevokeTokens), username)
+}
@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: deposit.go
+
+// Package mock_repository is a generated GoMock package.
+package repository
+
+import (
+	reflect "reflect"
+
+	model "github.com/adinandradrs/cezbek-engine/internal/model"
+	gomock "github.com/golang/mock/gomock"
+	decimal "github.com/shopspring/decimal"
+)
+
+// MockDepositPersister is a mock of DepositPersister interface.
+type MockDepositPersister struct {
+	ctrl     *gomock.Controller
+	recorder *MockDepositPersisterMockRecorder
+}
+
+// MockDepositPersisterMockRecorder is the mock recorder for MockDepositPersister.
+type MockDepositPersisterMockRecorder struct {
+	mock *MockDepositPersister
+}
+
+// NewMockDepositPersister creates a new mock instance.
+func NewMockDepositPersister(ctrl *gomock.Controller) *MockDepositPersister {
+	mock := &MockDepositPersister{ctrl: ctrl}
+	mock.recorder = &MockDepositPersisterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDepositPersister) EXPECT() *MockDepositPersisterMockRecorder {
+	return m.recorder
+}
+
+// Capture mocks base method.
+func (m_2 *MockDepositPersister) Capture(m model.DepositMovement, held decimal.Decimal) *model.TechnicalError {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Capture", m, held)
+	ret0, _ := ret[0].(*model.TechnicalError)
+	return ret0
+}
+
+// Capture indicates an expected call of Capture.
+func (mr *MockDepositPersisterMockRecorder) Capture(m, held interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Capture", reflect.TypeOf((*MockDepositPersister)(nil).Capture), m, held)
+}
+
+// FindByPartner mocks base method.
+func (m *MockDepositPersister) FindByPartner(partnerId int64) (*model.DepositProjection, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByPartner", partnerId)
+	ret0, _ := ret[0].(*model.DepositProjection)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// FindByPartner indicates an expected call of FindByPartner.
+func (mr *MockDepositPersisterMockRecorder) FindByPartner(partnerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByPartner", reflect.TypeOf((*MockDepositPersister)(nil).FindByPartner), partnerId)
+}
+
+// Hold mocks base method.
+func (m_2 *MockDepositPersister) Hold(m model.DepositMovement) (*model.DepositProjection, *model.TechnicalError) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Hold", m)
+	ret0, _ := ret[0].(*model.DepositProjection)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// Hold indicates an expected call of Hold.
+func (mr *MockDepositPersisterMockRecorder) Hold(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hold", reflect.TypeOf((*MockDepositPersister)(nil).Hold), m)
+}
+
+// Release mocks base method.
+func (m_2 *MockDepositPersister) Release(m model.DepositMovement) *model.TechnicalError {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Release", m)
+	ret0, _ := ret[0].(*model.TechnicalError)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockDepositPersisterMockRecorder) Release(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockDepositPersister)(nil).Release), m)
+}
+
+// TopUp mocks base method.
+func (m_2 *MockDepositPersister) TopUp(m model.DepositMovement, threshold decimal.NullDecimal) *model.TechnicalError {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "TopUp", m, threshold)
+	ret0, _ := ret[0].(*model.TechnicalError)
+	return ret0
+}
+
+// TopUp indicates an expected call of TopUp.
+func (mr *MockDepositPersisterMockRecorder) TopUp(m, threshold interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopUp", reflect.TypeOf((*MockDepositPersister)(nil).TopUp), m, threshold)
+}
@@ -0,0 +1,140 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: invoice.go
+
+// Package mock_repository is a generated GoMock package.
+package repository
+
+import (
+	reflect "reflect"
+	time "time"
+
+	model "github.com/adinandradrs/cezbek-engine/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockInvoicePersister is a mock of InvoicePersister interface.
+type MockInvoicePersister struct {
+	ctrl     *gomock.Controller
+	recorder *MockInvoicePersisterMockRecorder
+}
+
+// MockInvoicePersisterMockRecorder is the mock recorder for MockInvoicePersister.
+type MockInvoicePersisterMockRecorder struct {
+	mock *MockInvoicePersister
+}
+
+// NewMockInvoicePersister creates a new mock instance.
+func NewMockInvoicePersister(ctrl *gomock.Controller) *MockInvoicePersister {
+	mock := &MockInvoicePersister{ctrl: ctrl}
+	mock.recorder = &MockInvoicePersisterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvoicePersister) EXPECT() *MockInvoicePersisterMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m_2 *MockInvoicePersister) Add(m model.Invoice) (*int64, *model.TechnicalError) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Add", m)
+	ret0, _ := ret[0].(*int64)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockInvoicePersisterMockRecorder) Add(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockInvoicePersister)(nil).Add), m)
+}
+
+// CountByPartner mocks base method.
+func (m *MockInvoicePersister) CountByPartner(inp *model.SearchRequest) (*int, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByPartner", inp)
+	ret0, _ := ret[0].(*int)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// CountByPartner indicates an expected call of CountByPartner.
+func (mr *MockInvoicePersisterMockRecorder) CountByPartner(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByPartner", reflect.TypeOf((*MockInvoicePersister)(nil).CountByPartner), inp)
+}
+
+// FindById mocks base method.
+func (m *MockInvoicePersister) FindById(id int64) (*model.Invoice, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", id)
+	ret0, _ := ret[0].(*model.Invoice)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockInvoicePersisterMockRecorder) FindById(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockInvoicePersister)(nil).FindById), id)
+}
+
+// Pay mocks base method.
+func (m_2 *MockInvoicePersister) Pay(m model.Invoice) *model.TechnicalError {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Pay", m)
+	ret0, _ := ret[0].(*model.TechnicalError)
+	return ret0
+}
+
+// Pay indicates an expected call of Pay.
+func (mr *MockInvoicePersisterMockRecorder) Pay(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pay", reflect.TypeOf((*MockInvoicePersister)(nil).Pay), m)
+}
+
+// SearchByPartner mocks base method.
+func (m *MockInvoicePersister) SearchByPartner(inp *model.SearchRequest) ([]model.InvoiceProjection, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchByPartner", inp)
+	ret0, _ := ret[0].([]model.InvoiceProjection)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// SearchByPartner indicates an expected call of SearchByPartner.
+func (mr *MockInvoicePersisterMockRecorder) SearchByPartner(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchByPartner", reflect.TypeOf((*MockInvoicePersister)(nil).SearchByPartner), inp)
+}
+
+// Summarize mocks base method.
+func (m *MockInvoicePersister) Summarize(period string, start, end time.Time) ([]model.BillingSummaryProjection, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Summarize", period, start, end)
+	ret0, _ := ret[0].([]model.BillingSummaryProjection)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// Summarize indicates an expected call of Summarize.
+func (mr *MockInvoicePersisterMockRecorder) Summarize(period, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Summarize", reflect.TypeOf((*MockInvoicePersister)(nil).Summarize), period, start, end)
+}
+
+// Unbilled mocks base method.
+func (m *MockInvoicePersister) Unbilled(before time.Time) ([]string, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unbilled", before)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// Unbilled indicates an expected call of Unbilled.
+func (mr *MockInvoicePersisterMockRecorder) Unbilled(before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unbilled", reflect.TypeOf((*MockInvoicePersister)(nil).Unbilled), before)
+}
@@ -0,0 +1,140 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ledger.go
+
+// Package mock_repository is a generated GoMock package.
+package repository
+
+import (
+	reflect "reflect"
+
+	model "github.com/adinandradrs/cezbek-engine/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockLedgerPersister is a mock of LedgerPersister interface.
+type MockLedgerPersister struct {
+	ctrl     *gomock.Controller
+	recorder *MockLedgerPersisterMockRecorder
+}
+
+// MockLedgerPersisterMockRecorder is the mock recorder for MockLedgerPersister.
+type MockLedgerPersisterMockRecorder struct {
+	mock *MockLedgerPersister
+}
+
+// NewMockLedgerPersister creates a new mock instance.
+func NewMockLedgerPersister(ctrl *gomock.Controller) *MockLedgerPersister {
+	mock := &MockLedgerPersister{ctrl: ctrl}
+	mock.recorder = &MockLedgerPersisterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLedgerPersister) EXPECT() *MockLedgerPersisterMockRecorder {
+	return m.recorder
+}
+
+// Balance mocks base method.
+func (m *MockLedgerPersister) Balance(inp *model.LedgerRequest) (*model.LedgerBalanceProjection, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Balance", inp)
+	ret0, _ := ret[0].(*model.LedgerBalanceProjection)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// Balance indicates an expected call of Balance.
+func (mr *MockLedgerPersisterMockRecorder) Balance(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Balance", reflect.TypeOf((*MockLedgerPersister)(nil).Balance), inp)
+}
+
+// CountStatement mocks base method.
+func (m *MockLedgerPersister) CountStatement(inp *model.LedgerRequest) (*int, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountStatement", inp)
+	ret0, _ := ret[0].(*int)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// CountStatement indicates an expected call of CountStatement.
+func (mr *MockLedgerPersisterMockRecorder) CountStatement(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountStatement", reflect.TypeOf((*MockLedgerPersister)(nil).CountStatement), inp)
+}
+
+// Entries mocks base method.
+func (m *MockLedgerPersister) Entries(journalId int64) ([]model.LedgerEntry, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Entries", journalId)
+	ret0, _ := ret[0].([]model.LedgerEntry)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// Entries indicates an expected call of Entries.
+func (mr *MockLedgerPersisterMockRecorder) Entries(journalId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Entries", reflect.TypeOf((*MockLedgerPersister)(nil).Entries), journalId)
+}
+
+// FindJournalById mocks base method.
+func (m *MockLedgerPersister) FindJournalById(id int64) (*model.LedgerJournalProjection, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindJournalById", id)
+	ret0, _ := ret[0].(*model.LedgerJournalProjection)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// FindJournalById indicates an expected call of FindJournalById.
+func (mr *MockLedgerPersisterMockRecorder) FindJournalById(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindJournalById", reflect.TypeOf((*MockLedgerPersister)(nil).FindJournalById), id)
+}
+
+// Post mocks base method.
+func (m *MockLedgerPersister) Post(j model.LedgerJournal) (*int64, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Post", j)
+	ret0, _ := ret[0].(*int64)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// Post indicates an expected call of Post.
+func (mr *MockLedgerPersisterMockRecorder) Post(j interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Post", reflect.TypeOf((*MockLedgerPersister)(nil).Post), j)
+}
+
+// Reverse mocks base method.
+func (m *MockLedgerPersister) Reverse(j model.LedgerJournal) (*int64, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reverse", j)
+	ret0, _ := ret[0].(*int64)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// Reverse indicates an expected call of Reverse.
+func (mr *MockLedgerPersisterMockRecorder) Reverse(j interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reverse", reflect.TypeOf((*MockLedgerPersister)(nil).Reverse), j)
+}
+
+// Statement mocks base method.
+func (m *MockLedgerPersister) Statement(inp *model.LedgerRequest) ([]model.LedgerEntryProjection, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Statement", inp)
+	ret0, _ := ret[0].([]model.LedgerEntryProjection)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// Statement indicates an expected call of Statement.
+func (mr *MockLedgerPersisterMockRecorder) Statement(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Statement", reflect.TypeOf((*MockLedgerPersister)(nil).Statement), inp)
+}
@@ -0,0 +1,123 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: notification.go
+
+// Package mock_repository is a generated GoMock package.
+package repository
+
+import (
+	reflect "reflect"
+
+	model "github.com/adinandradrs/cezbek-engine/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockNotificationPersister is a mock of NotificationPersister interface.
+type MockNotificationPersister struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationPersisterMockRecorder
+}
+
+// MockNotificationPersisterMockRecorder is the mock recorder for MockNotificationPersister.
+type MockNotificationPersisterMockRecorder struct {
+	mock *MockNotificationPersister
+}
+
+// NewMockNotificationPersister creates a new mock instance.
+func NewMockNotificationPersister(ctrl *gomock.Controller) *MockNotificationPersister {
+	mock := &MockNotificationPersister{ctrl: ctrl}
+	mock.recorder = &MockNotificationPersisterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationPersister) EXPECT() *MockNotificationPersisterMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockNotificationPersister) Count(inp *model.NotificationSearchRequest) (*int, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", inp)
+	ret0, _ := ret[0].(*int)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockNotificationPersisterMockRecorder) Count(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockNotificationPersister)(nil).Count), inp)
+}
+
+// FindByIds mocks base method.
+func (m *MockNotificationPersister) FindByIds(ids []int64) ([]model.NotificationLog, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIds", ids)
+	ret0, _ := ret[0].([]model.NotificationLog)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// FindByIds indicates an expected call of FindByIds.
+func (mr *MockNotificationPersisterMockRecorder) FindByIds(ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIds", reflect.TypeOf((*MockNotificationPersister)(nil).FindByIds), ids)
+}
+
+// FindByStatus mocks base method.
+func (m *MockNotificationPersister) FindByStatus(status string, limit int) ([]model.NotificationLog, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByStatus", status, limit)
+	ret0, _ := ret[0].([]model.NotificationLog)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// FindByStatus indicates an expected call of FindByStatus.
+func (mr *MockNotificationPersisterMockRecorder) FindByStatus(status, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByStatus", reflect.TypeOf((*MockNotificationPersister)(nil).FindByStatus), status, limit)
+}
+
+// Save mocks base method.
+func (m_2 *MockNotificationPersister) Save(m model.NotificationLog) *model.TechnicalError {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Save", m)
+	ret0, _ := ret[0].(*model.TechnicalError)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockNotificationPersisterMockRecorder) Save(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockNotificationPersister)(nil).Save), m)
+}
+
+// Search mocks base method.
+func (m *MockNotificationPersister) Search(inp *model.NotificationSearchRequest) ([]model.NotificationLogProjection, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", inp)
+	ret0, _ := ret[0].([]model.NotificationLogProjection)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockNotificationPersisterMockRecorder) Search(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockNotificationPersister)(nil).Search), inp)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockNotificationPersister) UpdateStatus(ids []int64, status string) *model.TechnicalError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ids, status)
+	ret0, _ := ret[0].(*model.TechnicalError)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockNotificationPersisterMockRecorder) UpdateStatus(ids, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockNotificationPersister)(nil).UpdateStatus), ids, status)
+}
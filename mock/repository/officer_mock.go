@@ -0,0 +1,123 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: officer.go
+
+// Package mock_repository is a generated GoMock package.
+package repository
+
+import (
+	reflect "reflect"
+
+	model "github.com/adinandradrs/cezbek-engine/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockOfficerPersister is a mock of OfficerPersister interface.
+type MockOfficerPersister struct {
+	ctrl     *gomock.Controller
+	recorder *MockOfficerPersisterMockRecorder
+}
+
+// MockOfficerPersisterMockRecorder is the mock recorder for MockOfficerPersister.
+type MockOfficerPersisterMockRecorder struct {
+	mock *MockOfficerPersister
+}
+
+// NewMockOfficerPersister creates a new mock instance.
+func NewMockOfficerPersister(ctrl *gomock.Controller) *MockOfficerPersister {
+	mock := &MockOfficerPersister{ctrl: ctrl}
+	mock.recorder = &MockOfficerPersisterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOfficerPersister) EXPECT() *MockOfficerPersisterMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockOfficerPersister) Add(officer model.PartnerOfficer) *model.TechnicalError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", officer)
+	ret0, _ := ret[0].(*model.TechnicalError)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockOfficerPersisterMockRecorder) Add(officer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockOfficerPersister)(nil).Add), officer)
+}
+
+// CountByEmail mocks base method.
+func (m *MockOfficerPersister) CountByEmail(email string) (*int, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByEmail", email)
+	ret0, _ := ret[0].(*int)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// CountByEmail indicates an expected call of CountByEmail.
+func (mr *MockOfficerPersisterMockRecorder) CountByEmail(email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByEmail", reflect.TypeOf((*MockOfficerPersister)(nil).CountByEmail), email)
+}
+
+// CountPartnerByEmail mocks base method.
+func (m *MockOfficerPersister) CountPartnerByEmail(pid int64, email string) (*int, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPartnerByEmail", pid, email)
+	ret0, _ := ret[0].(*int)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// CountPartnerByEmail indicates an expected call of CountPartnerByEmail.
+func (mr *MockOfficerPersisterMockRecorder) CountPartnerByEmail(pid, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPartnerByEmail", reflect.TypeOf((*MockOfficerPersister)(nil).CountPartnerByEmail), pid, email)
+}
+
+// FindActiveById mocks base method.
+func (m *MockOfficerPersister) FindActiveById(pid, id int64) (*model.PartnerOfficer, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActiveById", pid, id)
+	ret0, _ := ret[0].(*model.PartnerOfficer)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// FindActiveById indicates an expected call of FindActiveById.
+func (mr *MockOfficerPersisterMockRecorder) FindActiveById(pid, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActiveById", reflect.TypeOf((*MockOfficerPersister)(nil).FindActiveById), pid, id)
+}
+
+// FindActiveByPartner mocks base method.
+func (m *MockOfficerPersister) FindActiveByPartner(pid int64) ([]model.OfficerProjection, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActiveByPartner", pid)
+	ret0, _ := ret[0].([]model.OfficerProjection)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// FindActiveByPartner indicates an expected call of FindActiveByPartner.
+func (mr *MockOfficerPersisterMockRecorder) FindActiveByPartner(pid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActiveByPartner", reflect.TypeOf((*MockOfficerPersister)(nil).FindActiveByPartner), pid)
+}
+
+// Remove mocks base method.
+func (m *MockOfficerPersister) Remove(officer model.PartnerOfficer) *model.TechnicalError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", officer)
+	ret0, _ := ret[0].(*model.TechnicalError)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockOfficerPersisterMockRecorder) Remove(officer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockOfficerPersister)(nil).Remove), officer)
+}
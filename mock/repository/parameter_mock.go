@@ -48,3 +48,33 @@ func (mr *MockParamPersisterMockRecorder) FindByParamGroup(g interface{}) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByParamGroup", reflect.TypeOf((*MockParamPersister)(nil).FindByParamGroup), g)
 }
+
+// FindByParamGroupAndName mocks base method.
+func (m *MockParamPersister) FindByParamGroupAndName(g, n, l string, v int) (*model.Parameter, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByParamGroupAndName", g, n, l, v)
+	ret0, _ := ret[0].(*model.Parameter)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// FindByParamGroupAndName indicates an expected call of FindByParamGroupAndName.
+func (mr *MockParamPersisterMockRecorder) FindByParamGroupAndName(g, n, l, v interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByParamGroupAndName", reflect.TypeOf((*MockParamPersister)(nil).FindByParamGroupAndName), g, n, l, v)
+}
+
+// FindLatestByParamGroup mocks base method.
+func (m *MockParamPersister) FindLatestByParamGroup(g string) ([]*model.Parameter, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLatestByParamGroup", g)
+	ret0, _ := ret[0].([]*model.Parameter)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// FindLatestByParamGroup indicates an expected call of FindLatestByParamGroup.
+func (mr *MockParamPersisterMockRecorder) FindLatestByParamGroup(g interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLatestByParamGroup", reflect.TypeOf((*MockParamPersister)(nil).FindLatestByParamGroup), g)
+}
@@ -63,6 +63,21 @@ func (mr *MockPartnerPersisterMockRecorder) CountByIdentifier(m interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByIdentifier", reflect.TypeOf((*MockPartnerPersister)(nil).CountByIdentifier), m)
 }
 
+// FindActiveByCode mocks base method.
+func (m *MockPartnerPersister) FindActiveByCode(code string) (*model.Partner, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActiveByCode", code)
+	ret0, _ := ret[0].(*model.Partner)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// FindActiveByCode indicates an expected call of FindActiveByCode.
+func (mr *MockPartnerPersisterMockRecorder) FindActiveByCode(code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActiveByCode", reflect.TypeOf((*MockPartnerPersister)(nil).FindActiveByCode), code)
+}
+
 // FindActiveByCodeAndApiKey mocks base method.
 func (m *MockPartnerPersister) FindActiveByCodeAndApiKey(code, key string) (*model.Partner, *model.TechnicalError) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,64 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: preference.go
+
+// Package mock_repository is a generated GoMock package.
+package repository
+
+import (
+	reflect "reflect"
+
+	model "github.com/adinandradrs/cezbek-engine/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPreferencePersister is a mock of PreferencePersister interface.
+type MockPreferencePersister struct {
+	ctrl     *gomock.Controller
+	recorder *MockPreferencePersisterMockRecorder
+}
+
+// MockPreferencePersisterMockRecorder is the mock recorder for MockPreferencePersister.
+type MockPreferencePersisterMockRecorder struct {
+	mock *MockPreferencePersister
+}
+
+// NewMockPreferencePersister creates a new mock instance.
+func NewMockPreferencePersister(ctrl *gomock.Controller) *MockPreferencePersister {
+	mock := &MockPreferencePersister{ctrl: ctrl}
+	mock.recorder = &MockPreferencePersisterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPreferencePersister) EXPECT() *MockPreferencePersisterMockRecorder {
+	return m.recorder
+}
+
+// CountOptedOut mocks base method.
+func (m *MockPreferencePersister) CountOptedOut(msisdn, email, category string) (*int, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOptedOut", msisdn, email, category)
+	ret0, _ := ret[0].(*int)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// CountOptedOut indicates an expected call of CountOptedOut.
+func (mr *MockPreferencePersisterMockRecorder) CountOptedOut(msisdn, email, category interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOptedOut", reflect.TypeOf((*MockPreferencePersister)(nil).CountOptedOut), msisdn, email, category)
+}
+
+// Save mocks base method.
+func (m_2 *MockPreferencePersister) Save(m model.NotificationPreference) *model.TechnicalError {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Save", m)
+	ret0, _ := ret[0].(*model.TechnicalError)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockPreferencePersisterMockRecorder) Save(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockPreferencePersister)(nil).Save), m)
+}
@@ -0,0 +1,154 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: settlement.go
+
+// Package mock_repository is a generated GoMock package.
+package repository
+
+import (
+	reflect "reflect"
+
+	model "github.com/adinandradrs/cezbek-engine/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSettlementPersister is a mock of SettlementPersister interface.
+type MockSettlementPersister struct {
+	ctrl     *gomock.Controller
+	recorder *MockSettlementPersisterMockRecorder
+}
+
+// MockSettlementPersisterMockRecorder is the mock recorder for MockSettlementPersister.
+type MockSettlementPersisterMockRecorder struct {
+	mock *MockSettlementPersister
+}
+
+// NewMockSettlementPersister creates a new mock instance.
+func NewMockSettlementPersister(ctrl *gomock.Controller) *MockSettlementPersister {
+	mock := &MockSettlementPersister{ctrl: ctrl}
+	mock.recorder = &MockSettlementPersisterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSettlementPersister) EXPECT() *MockSettlementPersisterMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m_2 *MockSettlementPersister) Add(m model.Settlement, exceptions []model.SettlementException, settled []int64, journal model.LedgerJournal) (*int64, *model.TechnicalError) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Add", m, exceptions, settled, journal)
+	ret0, _ := ret[0].(*int64)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockSettlementPersisterMockRecorder) Add(m, exceptions, settled, journal interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockSettlementPersister)(nil).Add), m, exceptions, settled, journal)
+}
+
+// Cashbacks mocks base method.
+func (m *MockSettlementPersister) Cashbacks(h2hCode, date string, refs []string) ([]model.SettlementCashbackProjection, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cashbacks", h2hCode, date, refs)
+	ret0, _ := ret[0].([]model.SettlementCashbackProjection)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// Cashbacks indicates an expected call of Cashbacks.
+func (mr *MockSettlementPersisterMockRecorder) Cashbacks(h2hCode, date, refs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cashbacks", reflect.TypeOf((*MockSettlementPersister)(nil).Cashbacks), h2hCode, date, refs)
+}
+
+// Count mocks base method.
+func (m *MockSettlementPersister) Count() (*int, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count")
+	ret0, _ := ret[0].(*int)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockSettlementPersisterMockRecorder) Count() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockSettlementPersister)(nil).Count))
+}
+
+// Exceptions mocks base method.
+func (m *MockSettlementPersister) Exceptions(id int64) ([]model.SettlementExceptionProjection, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exceptions", id)
+	ret0, _ := ret[0].([]model.SettlementExceptionProjection)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// Exceptions indicates an expected call of Exceptions.
+func (mr *MockSettlementPersisterMockRecorder) Exceptions(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exceptions", reflect.TypeOf((*MockSettlementPersister)(nil).Exceptions), id)
+}
+
+// FindById mocks base method.
+func (m *MockSettlementPersister) FindById(id int64) (*model.SettlementProjection, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", id)
+	ret0, _ := ret[0].(*model.SettlementProjection)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockSettlementPersisterMockRecorder) FindById(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockSettlementPersister)(nil).FindById), id)
+}
+
+// FindExceptionById mocks base method.
+func (m *MockSettlementPersister) FindExceptionById(id int64) (*model.SettlementException, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindExceptionById", id)
+	ret0, _ := ret[0].(*model.SettlementException)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// FindExceptionById indicates an expected call of FindExceptionById.
+func (mr *MockSettlementPersisterMockRecorder) FindExceptionById(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindExceptionById", reflect.TypeOf((*MockSettlementPersister)(nil).FindExceptionById), id)
+}
+
+// Resolve mocks base method.
+func (m_2 *MockSettlementPersister) Resolve(m model.SettlementException) *model.TechnicalError {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Resolve", m)
+	ret0, _ := ret[0].(*model.TechnicalError)
+	return ret0
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockSettlementPersisterMockRecorder) Resolve(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockSettlementPersister)(nil).Resolve), m)
+}
+
+// Search mocks base method.
+func (m *MockSettlementPersister) Search(inp *model.SearchRequest) ([]model.SettlementProjection, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", inp)
+	ret0, _ := ret[0].([]model.SettlementProjection)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockSettlementPersisterMockRecorder) Search(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockSettlementPersister)(nil).Search), inp)
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: snapshot.go
+
+// Package mock_repository is a generated GoMock package.
+package repository
+
+import (
+	reflect "reflect"
+	time "time"
+
+	model "github.com/adinandradrs/cezbek-engine/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSnapshotPersister is a mock of SnapshotPersister interface.
+type MockSnapshotPersister struct {
+	ctrl     *gomock.Controller
+	recorder *MockSnapshotPersisterMockRecorder
+}
+
+// MockSnapshotPersisterMockRecorder is the mock recorder for MockSnapshotPersister.
+type MockSnapshotPersisterMockRecorder struct {
+	mock *MockSnapshotPersister
+}
+
+// NewMockSnapshotPersister creates a new mock instance.
+func NewMockSnapshotPersister(ctrl *gomock.Controller) *MockSnapshotPersister {
+	mock := &MockSnapshotPersister{ctrl: ctrl}
+	mock.recorder = &MockSnapshotPersisterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSnapshotPersister) EXPECT() *MockSnapshotPersisterMockRecorder {
+	return m.recorder
+}
+
+// Rebuild mocks base method.
+func (m *MockSnapshotPersister) Rebuild(date string) (*int64, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rebuild", date)
+	ret0, _ := ret[0].(*int64)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// Rebuild indicates an expected call of Rebuild.
+func (mr *MockSnapshotPersisterMockRecorder) Rebuild(date interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rebuild", reflect.TypeOf((*MockSnapshotPersister)(nil).Rebuild), date)
+}
+
+// Stale mocks base method.
+func (m *MockSnapshotPersister) Stale(since time.Time) ([]string, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stale", since)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// Stale indicates an expected call of Stale.
+func (mr *MockSnapshotPersisterMockRecorder) Stale(since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stale", reflect.TypeOf((*MockSnapshotPersister)(nil).Stale), since)
+}
@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: suppression.go
+
+// Package mock_repository is a generated GoMock package.
+package repository
+
+import (
+	reflect "reflect"
+
+	model "github.com/adinandradrs/cezbek-engine/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSuppressionPersister is a mock of SuppressionPersister interface.
+type MockSuppressionPersister struct {
+	ctrl     *gomock.Controller
+	recorder *MockSuppressionPersisterMockRecorder
+}
+
+// MockSuppressionPersisterMockRecorder is the mock recorder for MockSuppressionPersister.
+type MockSuppressionPersisterMockRecorder struct {
+	mock *MockSuppressionPersister
+}
+
+// NewMockSuppressionPersister creates a new mock instance.
+func NewMockSuppressionPersister(ctrl *gomock.Controller) *MockSuppressionPersister {
+	mock := &MockSuppressionPersister{ctrl: ctrl}
+	mock.recorder = &MockSuppressionPersisterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSuppressionPersister) EXPECT() *MockSuppressionPersisterMockRecorder {
+	return m.recorder
+}
+
+// CountByEmail mocks base method.
+func (m *MockSuppressionPersister) CountByEmail(email string) (*int, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByEmail", email)
+	ret0, _ := ret[0].(*int)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// CountByEmail indicates an expected call of CountByEmail.
+func (mr *MockSuppressionPersisterMockRecorder) CountByEmail(email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByEmail", reflect.TypeOf((*MockSuppressionPersister)(nil).CountByEmail), email)
+}
+
+// CountByPartner mocks base method.
+func (m *MockSuppressionPersister) CountByPartner(inp *model.SearchRequest) (*int, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByPartner", inp)
+	ret0, _ := ret[0].(*int)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// CountByPartner indicates an expected call of CountByPartner.
+func (mr *MockSuppressionPersisterMockRecorder) CountByPartner(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByPartner", reflect.TypeOf((*MockSuppressionPersister)(nil).CountByPartner), inp)
+}
+
+// Save mocks base method.
+func (m_2 *MockSuppressionPersister) Save(m model.EmailSuppression) *model.TechnicalError {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Save", m)
+	ret0, _ := ret[0].(*model.TechnicalError)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockSuppressionPersisterMockRecorder) Save(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockSuppressionPersister)(nil).Save), m)
+}
+
+// SearchByPartner mocks base method.
+func (m *MockSuppressionPersister) SearchByPartner(inp *model.SearchRequest) ([]model.SuppressionProjection, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchByPartner", inp)
+	ret0, _ := ret[0].([]model.SuppressionProjection)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// SearchByPartner indicates an expected call of SearchByPartner.
+func (mr *MockSuppressionPersisterMockRecorder) SearchByPartner(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchByPartner", reflect.TypeOf((*MockSuppressionPersister)(nil).SearchByPartner), inp)
+}
@@ -65,11 +65,12 @@ func (mr *MockTierPersisterMockRecorder) CountExpire() *gomock.Call {
 }
 
 // Expire mocks base method.
-func (m *MockTierPersister) Expire(expired time.Time) *model.TechnicalError {
+func (m *MockTierPersister) Expire(expired time.Time) ([]model.TierChangeProjection, *model.TechnicalError) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Expire", expired)
-	ret0, _ := ret[0].(*model.TechnicalError)
-	return ret0
+	ret0, _ := ret[0].([]model.TierChangeProjection)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
 }
 
 // Expire indicates an expected call of Expire.
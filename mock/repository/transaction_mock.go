@@ -50,7 +50,7 @@ func (mr *MockTransactionPersisterMockRecorder) Add(trx interface{}) *gomock.Cal
 }
 
 // CountByPartner mocks base method.
-func (m *MockTransactionPersister) CountByPartner(inp *model.SearchRequest) (*int, *model.TechnicalError) {
+func (m *MockTransactionPersister) CountByPartner(inp *model.PartnerTransactionSearchRequest) (*int, *model.TechnicalError) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CountByPartner", inp)
 	ret0, _ := ret[0].(*int)
@@ -80,7 +80,7 @@ func (mr *MockTransactionPersisterMockRecorder) DetailByPartner(inp interface{})
 }
 
 // SearchByPartner mocks base method.
-func (m *MockTransactionPersister) SearchByPartner(inp *model.SearchRequest) ([]model.PartnerTransactionProjection, *model.TechnicalError) {
+func (m *MockTransactionPersister) SearchByPartner(inp *model.PartnerTransactionSearchRequest) ([]model.PartnerTransactionProjection, *model.TechnicalError) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SearchByPartner", inp)
 	ret0, _ := ret[0].([]model.PartnerTransactionProjection)
@@ -93,3 +93,17 @@ func (mr *MockTransactionPersisterMockRecorder) SearchByPartner(inp interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchByPartner", reflect.TypeOf((*MockTransactionPersister)(nil).SearchByPartner), inp)
 }
+
+// StreamByPartner mocks base method.
+func (m *MockTransactionPersister) StreamByPartner(inp *model.PartnerTransactionSearchRequest, fn func(model.PartnerTransactionProjection) error) *model.TechnicalError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamByPartner", inp, fn)
+	ret0, _ := ret[0].(*model.TechnicalError)
+	return ret0
+}
+
+// StreamByPartner indicates an expected call of StreamByPartner.
+func (mr *MockTransactionPersisterMockRecorder) StreamByPartner(inp, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamByPartner", reflect.TypeOf((*MockTransactionPersister)(nil).StreamByPartner), inp, fn)
+}
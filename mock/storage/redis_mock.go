@@ -93,6 +93,21 @@ func (mr *MockCacherMockRecorder) Hset(k, p, v interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hset", reflect.TypeOf((*MockCacher)(nil).Hset), k, p, v)
 }
 
+// Incr mocks base method.
+func (m *MockCacher) Incr(k, p string, d time.Duration) (int64, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Incr", k, p, d)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// Incr indicates an expected call of Incr.
+func (mr *MockCacherMockRecorder) Incr(k, p, d interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Incr", reflect.TypeOf((*MockCacher)(nil).Incr), k, p, d)
+}
+
 // Set mocks base method.
 func (m *MockCacher) Set(k, p string, v interface{}, d time.Duration) *model.TechnicalError {
 	m.ctrl.T.Helper()
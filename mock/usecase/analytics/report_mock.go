@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: report.go
+
+// Package mock_analytics is a generated GoMock package.
+package analytics
+
+import (
+	reflect "reflect"
+
+	model "github.com/adinandradrs/cezbek-engine/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReportProvider is a mock of ReportProvider interface.
+type MockReportProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportProviderMockRecorder
+}
+
+// MockReportProviderMockRecorder is the mock recorder for MockReportProvider.
+type MockReportProviderMockRecorder struct {
+	mock *MockReportProvider
+}
+
+// NewMockReportProvider creates a new mock instance.
+func NewMockReportProvider(ctrl *gomock.Controller) *MockReportProvider {
+	mock := &MockReportProvider{ctrl: ctrl}
+	mock.recorder = &MockReportProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportProvider) EXPECT() *MockReportProviderMockRecorder {
+	return m.recorder
+}
+
+// CashbackVolume mocks base method.
+func (m *MockReportProvider) CashbackVolume(inp *model.AnalyticsRequest) (*model.CashbackVolumeResponse, *model.BusinessError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CashbackVolume", inp)
+	ret0, _ := ret[0].(*model.CashbackVolumeResponse)
+	ret1, _ := ret[1].(*model.BusinessError)
+	return ret0, ret1
+}
+
+// CashbackVolume indicates an expected call of CashbackVolume.
+func (mr *MockReportProviderMockRecorder) CashbackVolume(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CashbackVolume", reflect.TypeOf((*MockReportProvider)(nil).CashbackVolume), inp)
+}
+
+// CustomerSummary mocks base method.
+func (m *MockReportProvider) CustomerSummary(inp *model.AnalyticsRequest) (*model.CustomerSummaryResponse, *model.BusinessError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CustomerSummary", inp)
+	ret0, _ := ret[0].(*model.CustomerSummaryResponse)
+	ret1, _ := ret[1].(*model.BusinessError)
+	return ret0, ret1
+}
+
+// CustomerSummary indicates an expected call of CustomerSummary.
+func (mr *MockReportProviderMockRecorder) CustomerSummary(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CustomerSummary", reflect.TypeOf((*MockReportProvider)(nil).CustomerSummary), inp)
+}
+
+// Dashboard mocks base method.
+func (m *MockReportProvider) Dashboard(inp *model.AnalyticsRequest) (*model.DashboardResponse, *model.BusinessError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Dashboard", inp)
+	ret0, _ := ret[0].(*model.DashboardResponse)
+	ret1, _ := ret[1].(*model.BusinessError)
+	return ret0, ret1
+}
+
+// Dashboard indicates an expected call of Dashboard.
+func (mr *MockReportProviderMockRecorder) Dashboard(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Dashboard", reflect.TypeOf((*MockReportProvider)(nil).Dashboard), inp)
+}
+
+// ProviderSavings mocks base method.
+func (m *MockReportProvider) ProviderSavings(inp *model.AnalyticsRequest) (*model.ProviderSavingsResponse, *model.BusinessError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProviderSavings", inp)
+	ret0, _ := ret[0].(*model.ProviderSavingsResponse)
+	ret1, _ := ret[1].(*model.BusinessError)
+	return ret0, ret1
+}
+
+// ProviderSavings indicates an expected call of ProviderSavings.
+func (mr *MockReportProviderMockRecorder) ProviderSavings(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProviderSavings", reflect.TypeOf((*MockReportProvider)(nil).ProviderSavings), inp)
+}
+
+// TierDistribution mocks base method.
+func (m *MockReportProvider) TierDistribution(inp *model.AnalyticsRequest) (*model.TierDistributionResponse, *model.BusinessError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TierDistribution", inp)
+	ret0, _ := ret[0].(*model.TierDistributionResponse)
+	ret1, _ := ret[1].(*model.BusinessError)
+	return ret0, ret1
+}
+
+// TierDistribution indicates an expected call of TierDistribution.
+func (mr *MockReportProviderMockRecorder) TierDistribution(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TierDistribution", reflect.TypeOf((*MockReportProvider)(nil).TierDistribution), inp)
+}
@@ -48,3 +48,32 @@ func (mr *MockOnboardProviderMockRecorder) Authenticate(inp interface{}) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockOnboardProvider)(nil).Authenticate), inp)
 }
+
+// Logout mocks base method.
+func (m *MockOnboardProvider) Logout(inp *model.SessionRequest) *model.BusinessError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", inp)
+	ret0, _ := ret[0].(*model.BusinessError)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockOnboardProviderMockRecorder) Logout(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockOnboardProvider)(nil).Logout), inp)
+}
+
+// Refresh mocks base method.
+func (m *MockOnboardProvider) Refresh(inp *model.SessionRefreshRequest) (*model.SessionResponse, *model.BusinessError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", inp)
+	ret0, _ := ret[0].(*model.SessionResponse)
+	ret1, _ := ret[1].(*model.BusinessError)
+	return ret0, ret1
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockOnboardProviderMockRecorder) Refresh(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockOnboardProvider)(nil).Refresh), inp)
+}
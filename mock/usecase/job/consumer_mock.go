@@ -0,0 +1,64 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: consumer.go
+
+// Package mock_job is a generated GoMock package.
+package job
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/adinandradrs/cezbek-engine/internal/model"
+	job "github.com/adinandradrs/cezbek-engine/internal/usecase/job"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockQueueConsumer is a mock of QueueConsumer interface.
+type MockQueueConsumer struct {
+	ctrl     *gomock.Controller
+	recorder *MockQueueConsumerMockRecorder
+}
+
+// MockQueueConsumerMockRecorder is the mock recorder for MockQueueConsumer.
+type MockQueueConsumerMockRecorder struct {
+	mock *MockQueueConsumer
+}
+
+// NewMockQueueConsumer creates a new mock instance.
+func NewMockQueueConsumer(ctrl *gomock.Controller) *MockQueueConsumer {
+	mock := &MockQueueConsumer{ctrl: ctrl}
+	mock.recorder = &MockQueueConsumerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQueueConsumer) EXPECT() *MockQueueConsumerMockRecorder {
+	return m.recorder
+}
+
+// Poll mocks base method.
+func (m *MockQueueConsumer) Poll(h job.MessageHandler) (int, *model.TechnicalError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Poll", h)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(*model.TechnicalError)
+	return ret0, ret1
+}
+
+// Poll indicates an expected call of Poll.
+func (mr *MockQueueConsumerMockRecorder) Poll(h interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Poll", reflect.TypeOf((*MockQueueConsumer)(nil).Poll), h)
+}
+
+// Run mocks base method.
+func (m *MockQueueConsumer) Run(ctx context.Context, h job.MessageHandler) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx, h)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockQueueConsumerMockRecorder) Run(ctx, h interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockQueueConsumer)(nil).Run), ctx, h)
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: deposit.go
+
+// Package mock_management is a generated GoMock package.
+package management
+
+import (
+	reflect "reflect"
+
+	model "github.com/adinandradrs/cezbek-engine/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockDepositManager is a mock of DepositManager interface.
+type MockDepositManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockDepositManagerMockRecorder
+}
+
+// MockDepositManagerMockRecorder is the mock recorder for MockDepositManager.
+type MockDepositManagerMockRecorder struct {
+	mock *MockDepositManager
+}
+
+// NewMockDepositManager creates a new mock instance.
+func NewMockDepositManager(ctrl *gomock.Controller) *MockDepositManager {
+	mock := &MockDepositManager{ctrl: ctrl}
+	mock.recorder = &MockDepositManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDepositManager) EXPECT() *MockDepositManagerMockRecorder {
+	return m.recorder
+}
+
+// Find mocks base method.
+func (m *MockDepositManager) Find(code string) (*model.DepositResponse, *model.BusinessError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", code)
+	ret0, _ := ret[0].(*model.DepositResponse)
+	ret1, _ := ret[1].(*model.BusinessError)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockDepositManagerMockRecorder) Find(code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockDepositManager)(nil).Find), code)
+}
+
+// TopUp mocks base method.
+func (m *MockDepositManager) TopUp(inp *model.DepositTopUpRequest) (*model.TransactionResponse, *model.BusinessError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TopUp", inp)
+	ret0, _ := ret[0].(*model.TransactionResponse)
+	ret1, _ := ret[1].(*model.BusinessError)
+	return ret0, ret1
+}
+
+// TopUp indicates an expected call of TopUp.
+func (mr *MockDepositManagerMockRecorder) TopUp(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopUp", reflect.TypeOf((*MockDepositManager)(nil).TopUp), inp)
+}
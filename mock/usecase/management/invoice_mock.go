@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: invoice.go
+
+// Package mock_management is a generated GoMock package.
+package management
+
+import (
+	reflect "reflect"
+
+	model "github.com/adinandradrs/cezbek-engine/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockInvoiceManager is a mock of InvoiceManager interface.
+type MockInvoiceManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockInvoiceManagerMockRecorder
+}
+
+// MockInvoiceManagerMockRecorder is the mock recorder for MockInvoiceManager.
+type MockInvoiceManagerMockRecorder struct {
+	mock *MockInvoiceManager
+}
+
+// NewMockInvoiceManager creates a new mock instance.
+func NewMockInvoiceManager(ctrl *gomock.Controller) *MockInvoiceManager {
+	mock := &MockInvoiceManager{ctrl: ctrl}
+	mock.recorder = &MockInvoiceManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvoiceManager) EXPECT() *MockInvoiceManagerMockRecorder {
+	return m.recorder
+}
+
+// Pay mocks base method.
+func (m *MockInvoiceManager) Pay(inp *model.InvoicePaymentRequest) (*model.TransactionResponse, *model.BusinessError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pay", inp)
+	ret0, _ := ret[0].(*model.TransactionResponse)
+	ret1, _ := ret[1].(*model.BusinessError)
+	return ret0, ret1
+}
+
+// Pay indicates an expected call of Pay.
+func (mr *MockInvoiceManagerMockRecorder) Pay(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pay", reflect.TypeOf((*MockInvoiceManager)(nil).Pay), inp)
+}
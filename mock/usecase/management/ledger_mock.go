@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ledger.go
+
+// Package mock_management is a generated GoMock package.
+package management
+
+import (
+	reflect "reflect"
+
+	model "github.com/adinandradrs/cezbek-engine/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockLedgerManager is a mock of LedgerManager interface.
+type MockLedgerManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockLedgerManagerMockRecorder
+}
+
+// MockLedgerManagerMockRecorder is the mock recorder for MockLedgerManager.
+type MockLedgerManagerMockRecorder struct {
+	mock *MockLedgerManager
+}
+
+// NewMockLedgerManager creates a new mock instance.
+func NewMockLedgerManager(ctrl *gomock.Controller) *MockLedgerManager {
+	mock := &MockLedgerManager{ctrl: ctrl}
+	mock.recorder = &MockLedgerManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLedgerManager) EXPECT() *MockLedgerManagerMockRecorder {
+	return m.recorder
+}
+
+// Balance mocks base method.
+func (m *MockLedgerManager) Balance(inp *model.LedgerRequest) (*model.LedgerBalanceResponse, *model.BusinessError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Balance", inp)
+	ret0, _ := ret[0].(*model.LedgerBalanceResponse)
+	ret1, _ := ret[1].(*model.BusinessError)
+	return ret0, ret1
+}
+
+// Balance indicates an expected call of Balance.
+func (mr *MockLedgerManagerMockRecorder) Balance(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Balance", reflect.TypeOf((*MockLedgerManager)(nil).Balance), inp)
+}
+
+// Reverse mocks base method.
+func (m *MockLedgerManager) Reverse(inp *model.LedgerReversalRequest) (*model.TransactionResponse, *model.BusinessError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reverse", inp)
+	ret0, _ := ret[0].(*model.TransactionResponse)
+	ret1, _ := ret[1].(*model.BusinessError)
+	return ret0, ret1
+}
+
+// Reverse indicates an expected call of Reverse.
+func (mr *MockLedgerManagerMockRecorder) Reverse(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reverse", reflect.TypeOf((*MockLedgerManager)(nil).Reverse), inp)
+}
+
+// Statement mocks base method.
+func (m *MockLedgerManager) Statement(inp *model.LedgerRequest) (*model.LedgerStatementResponse, *model.BusinessError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Statement", inp)
+	ret0, _ := ret[0].(*model.LedgerStatementResponse)
+	ret1, _ := ret[1].(*model.BusinessError)
+	return ret0, ret1
+}
+
+// Statement indicates an expected call of Statement.
+func (mr *MockLedgerManagerMockRecorder) Statement(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Statement", reflect.TypeOf((*MockLedgerManager)(nil).Statement), inp)
+}
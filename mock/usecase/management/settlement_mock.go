@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: settlement.go
+
+// Package mock_management is a generated GoMock package.
+package management
+
+import (
+	reflect "reflect"
+
+	model "github.com/adinandradrs/cezbek-engine/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSettlementManager is a mock of SettlementManager interface.
+type MockSettlementManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockSettlementManagerMockRecorder
+}
+
+// MockSettlementManagerMockRecorder is the mock recorder for MockSettlementManager.
+type MockSettlementManagerMockRecorder struct {
+	mock *MockSettlementManager
+}
+
+// NewMockSettlementManager creates a new mock instance.
+func NewMockSettlementManager(ctrl *gomock.Controller) *MockSettlementManager {
+	mock := &MockSettlementManager{ctrl: ctrl}
+	mock.recorder = &MockSettlementManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSettlementManager) EXPECT() *MockSettlementManagerMockRecorder {
+	return m.recorder
+}
+
+// Reconcile mocks base method.
+func (m *MockSettlementManager) Reconcile(inp *model.SettlementRequest) (*model.SettlementReportResponse, *model.BusinessError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconcile", inp)
+	ret0, _ := ret[0].(*model.SettlementReportResponse)
+	ret1, _ := ret[1].(*model.BusinessError)
+	return ret0, ret1
+}
+
+// Reconcile indicates an expected call of Reconcile.
+func (mr *MockSettlementManagerMockRecorder) Reconcile(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockSettlementManager)(nil).Reconcile), inp)
+}
+
+// Report mocks base method.
+func (m *MockSettlementManager) Report(id int64) (*model.SettlementReportResponse, *model.BusinessError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Report", id)
+	ret0, _ := ret[0].(*model.SettlementReportResponse)
+	ret1, _ := ret[1].(*model.BusinessError)
+	return ret0, ret1
+}
+
+// Report indicates an expected call of Report.
+func (mr *MockSettlementManagerMockRecorder) Report(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Report", reflect.TypeOf((*MockSettlementManager)(nil).Report), id)
+}
+
+// Resolve mocks base method.
+func (m *MockSettlementManager) Resolve(inp *model.SettlementResolveRequest) (*model.TransactionResponse, *model.BusinessError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", inp)
+	ret0, _ := ret[0].(*model.TransactionResponse)
+	ret1, _ := ret[1].(*model.BusinessError)
+	return ret0, ret1
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockSettlementManagerMockRecorder) Resolve(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockSettlementManager)(nil).Resolve), inp)
+}
+
+// Search mocks base method.
+func (m *MockSettlementManager) Search(inp *model.SearchRequest) (*model.SettlementSearchResponse, *model.BusinessError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", inp)
+	ret0, _ := ret[0].(*model.SettlementSearchResponse)
+	ret1, _ := ret[1].(*model.BusinessError)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockSettlementManagerMockRecorder) Search(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockSettlementManager)(nil).Search), inp)
+}
@@ -0,0 +1,48 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: channel.go
+
+// Package mock_notification is a generated GoMock package.
+package notification
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockChannelProvider is a mock of ChannelProvider interface.
+type MockChannelProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockChannelProviderMockRecorder
+}
+
+// MockChannelProviderMockRecorder is the mock recorder for MockChannelProvider.
+type MockChannelProviderMockRecorder struct {
+	mock *MockChannelProvider
+}
+
+// NewMockChannelProvider creates a new mock instance.
+func NewMockChannelProvider(ctrl *gomock.Controller) *MockChannelProvider {
+	mock := &MockChannelProvider{ctrl: ctrl}
+	mock.recorder = &MockChannelProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChannelProvider) EXPECT() *MockChannelProviderMockRecorder {
+	return m.recorder
+}
+
+// Select mocks base method.
+func (m *MockChannelProvider) Select(event, partner, email string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Select", event, partner, email)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Select indicates an expected call of Select.
+func (mr *MockChannelProviderMockRecorder) Select(event, partner, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Select", reflect.TypeOf((*MockChannelProvider)(nil).Select), event, partner, email)
+}
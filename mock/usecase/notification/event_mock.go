@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: event.go
+
+// Package mock_notification is a generated GoMock package.
+package notification
+
+import (
+	reflect "reflect"
+
+	model "github.com/adinandradrs/cezbek-engine/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockEventProvider is a mock of EventProvider interface.
+type MockEventProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventProviderMockRecorder
+}
+
+// MockEventProviderMockRecorder is the mock recorder for MockEventProvider.
+type MockEventProviderMockRecorder struct {
+	mock *MockEventProvider
+}
+
+// NewMockEventProvider creates a new mock instance.
+func NewMockEventProvider(ctrl *gomock.Controller) *MockEventProvider {
+	mock := &MockEventProvider{ctrl: ctrl}
+	mock.recorder = &MockEventProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventProvider) EXPECT() *MockEventProviderMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockEventProvider) Publish(inp *model.NotificationEvent) *model.BusinessError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", inp)
+	ret0, _ := ret[0].(*model.BusinessError)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockEventProviderMockRecorder) Publish(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockEventProvider)(nil).Publish), inp)
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: log.go
+
+// Package mock_notification is a generated GoMock package.
+package notification
+
+import (
+	reflect "reflect"
+
+	model "github.com/adinandradrs/cezbek-engine/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockLogProvider is a mock of LogProvider interface.
+type MockLogProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockLogProviderMockRecorder
+}
+
+// MockLogProviderMockRecorder is the mock recorder for MockLogProvider.
+type MockLogProviderMockRecorder struct {
+	mock *MockLogProvider
+}
+
+// NewMockLogProvider creates a new mock instance.
+func NewMockLogProvider(ctrl *gomock.Controller) *MockLogProvider {
+	mock := &MockLogProvider{ctrl: ctrl}
+	mock.recorder = &MockLogProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLogProvider) EXPECT() *MockLogProviderMockRecorder {
+	return m.recorder
+}
+
+// Resend mocks base method.
+func (m *MockLogProvider) Resend(inp *model.NotificationResendRequest) (*model.NotificationResendResponse, *model.BusinessError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resend", inp)
+	ret0, _ := ret[0].(*model.NotificationResendResponse)
+	ret1, _ := ret[1].(*model.BusinessError)
+	return ret0, ret1
+}
+
+// Resend indicates an expected call of Resend.
+func (mr *MockLogProviderMockRecorder) Resend(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resend", reflect.TypeOf((*MockLogProvider)(nil).Resend), inp)
+}
+
+// Search mocks base method.
+func (m *MockLogProvider) Search(inp *model.NotificationSearchRequest) (*model.NotificationSearchResponse, *model.BusinessError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", inp)
+	ret0, _ := ret[0].(*model.NotificationSearchResponse)
+	ret1, _ := ret[1].(*model.BusinessError)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockLogProviderMockRecorder) Search(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockLogProvider)(nil).Search), inp)
+}
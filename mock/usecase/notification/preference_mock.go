@@ -0,0 +1,93 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: preference.go
+
+// Package mock_notification is a generated GoMock package.
+package notification
+
+import (
+	reflect "reflect"
+
+	model "github.com/adinandradrs/cezbek-engine/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPreferenceProvider is a mock of PreferenceProvider interface.
+type MockPreferenceProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockPreferenceProviderMockRecorder
+}
+
+// MockPreferenceProviderMockRecorder is the mock recorder for MockPreferenceProvider.
+type MockPreferenceProviderMockRecorder struct {
+	mock *MockPreferenceProvider
+}
+
+// NewMockPreferenceProvider creates a new mock instance.
+func NewMockPreferenceProvider(ctrl *gomock.Controller) *MockPreferenceProvider {
+	mock := &MockPreferenceProvider{ctrl: ctrl}
+	mock.recorder = &MockPreferenceProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPreferenceProvider) EXPECT() *MockPreferenceProviderMockRecorder {
+	return m.recorder
+}
+
+// Allowed mocks base method.
+func (m *MockPreferenceProvider) Allowed(msisdn, email, category string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Allowed", msisdn, email, category)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Allowed indicates an expected call of Allowed.
+func (mr *MockPreferenceProviderMockRecorder) Allowed(msisdn, email, category interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Allowed", reflect.TypeOf((*MockPreferenceProvider)(nil).Allowed), msisdn, email, category)
+}
+
+// Confirm mocks base method.
+func (m *MockPreferenceProvider) Confirm(inp *model.UnsubscribeRequest) (*model.UnsubscribeConfirmationResponse, *model.BusinessError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Confirm", inp)
+	ret0, _ := ret[0].(*model.UnsubscribeConfirmationResponse)
+	ret1, _ := ret[1].(*model.BusinessError)
+	return ret0, ret1
+}
+
+// Confirm indicates an expected call of Confirm.
+func (mr *MockPreferenceProviderMockRecorder) Confirm(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Confirm", reflect.TypeOf((*MockPreferenceProvider)(nil).Confirm), inp)
+}
+
+// Unsubscribe mocks base method.
+func (m *MockPreferenceProvider) Unsubscribe(inp *model.UnsubscribeRequest) (*model.UnsubscribeResponse, *model.BusinessError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unsubscribe", inp)
+	ret0, _ := ret[0].(*model.UnsubscribeResponse)
+	ret1, _ := ret[1].(*model.BusinessError)
+	return ret0, ret1
+}
+
+// Unsubscribe indicates an expected call of Unsubscribe.
+func (mr *MockPreferenceProviderMockRecorder) Unsubscribe(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsubscribe", reflect.TypeOf((*MockPreferenceProvider)(nil).Unsubscribe), inp)
+}
+
+// UnsubscribeLink mocks base method.
+func (m *MockPreferenceProvider) UnsubscribeLink(msisdn, email, category string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnsubscribeLink", msisdn, email, category)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// UnsubscribeLink indicates an expected call of UnsubscribeLink.
+func (mr *MockPreferenceProviderMockRecorder) UnsubscribeLink(msisdn, email, category interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnsubscribeLink", reflect.TypeOf((*MockPreferenceProvider)(nil).UnsubscribeLink), msisdn, email, category)
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: template.go
+
+// Package mock_notification is a generated GoMock package.
+package notification
+
+import (
+	reflect "reflect"
+
+	model "github.com/adinandradrs/cezbek-engine/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTemplateProvider is a mock of TemplateProvider interface.
+type MockTemplateProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockTemplateProviderMockRecorder
+}
+
+// MockTemplateProviderMockRecorder is the mock recorder for MockTemplateProvider.
+type MockTemplateProviderMockRecorder struct {
+	mock *MockTemplateProvider
+}
+
+// NewMockTemplateProvider creates a new mock instance.
+func NewMockTemplateProvider(ctrl *gomock.Controller) *MockTemplateProvider {
+	mock := &MockTemplateProvider{ctrl: ctrl}
+	mock.recorder = &MockTemplateProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTemplateProvider) EXPECT() *MockTemplateProviderMockRecorder {
+	return m.recorder
+}
+
+// Preview mocks base method.
+func (m *MockTemplateProvider) Preview(inp *model.TemplatePreviewRequest) (*model.TemplatePreviewResponse, *model.BusinessError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Preview", inp)
+	ret0, _ := ret[0].(*model.TemplatePreviewResponse)
+	ret1, _ := ret[1].(*model.BusinessError)
+	return ret0, ret1
+}
+
+// Preview indicates an expected call of Preview.
+func (mr *MockTemplateProviderMockRecorder) Preview(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Preview", reflect.TypeOf((*MockTemplateProvider)(nil).Preview), inp)
+}
+
+// Render mocks base method.
+func (m *MockTemplateProvider) Render(name, locale string, data map[string]interface{}) (*model.NotificationContent, *model.BusinessError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Render", name, locale, data)
+	ret0, _ := ret[0].(*model.NotificationContent)
+	ret1, _ := ret[1].(*model.BusinessError)
+	return ret0, ret1
+}
+
+// Render indicates an expected call of Render.
+func (mr *MockTemplateProviderMockRecorder) Render(name, locale, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Render", reflect.TypeOf((*MockTemplateProvider)(nil).Render), name, locale, data)
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: deposit.go
+
+// Package mock_partner is a generated GoMock package.
+package partner
+
+import (
+	reflect "reflect"
+
+	model "github.com/adinandradrs/cezbek-engine/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockDepositProvider is a mock of DepositProvider interface.
+type MockDepositProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockDepositProviderMockRecorder
+}
+
+// MockDepositProviderMockRecorder is the mock recorder for MockDepositProvider.
+type MockDepositProviderMockRecorder struct {
+	mock *MockDepositProvider
+}
+
+// NewMockDepositProvider creates a new mock instance.
+func NewMockDepositProvider(ctrl *gomock.Controller) *MockDepositProvider {
+	mock := &MockDepositProvider{ctrl: ctrl}
+	mock.recorder = &MockDepositProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDepositProvider) EXPECT() *MockDepositProviderMockRecorder {
+	return m.recorder
+}
+
+// Find mocks base method.
+func (m *MockDepositProvider) Find(inp *model.SessionRequest) (*model.DepositResponse, *model.BusinessError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", inp)
+	ret0, _ := ret[0].(*model.DepositResponse)
+	ret1, _ := ret[1].(*model.BusinessError)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockDepositProviderMockRecorder) Find(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockDepositProvider)(nil).Find), inp)
+}
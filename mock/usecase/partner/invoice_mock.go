@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: invoice.go
+
+// Package mock_partner is a generated GoMock package.
+package partner
+
+import (
+	reflect "reflect"
+
+	model "github.com/adinandradrs/cezbek-engine/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockInvoiceProvider is a mock of InvoiceProvider interface.
+type MockInvoiceProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockInvoiceProviderMockRecorder
+}
+
+// MockInvoiceProviderMockRecorder is the mock recorder for MockInvoiceProvider.
+type MockInvoiceProviderMockRecorder struct {
+	mock *MockInvoiceProvider
+}
+
+// NewMockInvoiceProvider creates a new mock instance.
+func NewMockInvoiceProvider(ctrl *gomock.Controller) *MockInvoiceProvider {
+	mock := &MockInvoiceProvider{ctrl: ctrl}
+	mock.recorder = &MockInvoiceProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvoiceProvider) EXPECT() *MockInvoiceProviderMockRecorder {
+	return m.recorder
+}
+
+// Search mocks base method.
+func (m *MockInvoiceProvider) Search(inp *model.SearchRequest) (*model.InvoiceSearchResponse, *model.BusinessError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", inp)
+	ret0, _ := ret[0].(*model.InvoiceSearchResponse)
+	ret1, _ := ret[1].(*model.BusinessError)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockInvoiceProviderMockRecorder) Search(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockInvoiceProvider)(nil).Search), inp)
+}
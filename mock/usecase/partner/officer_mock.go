@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: officer.go
+
+// Package mock_partner is a generated GoMock package.
+package partner
+
+import (
+	reflect "reflect"
+
+	model "github.com/adinandradrs/cezbek-engine/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockOfficerProvider is a mock of OfficerProvider interface.
+type MockOfficerProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockOfficerProviderMockRecorder
+}
+
+// MockOfficerProviderMockRecorder is the mock recorder for MockOfficerProvider.
+type MockOfficerProviderMockRecorder struct {
+	mock *MockOfficerProvider
+}
+
+// NewMockOfficerProvider creates a new mock instance.
+func NewMockOfficerProvider(ctrl *gomock.Controller) *MockOfficerProvider {
+	mock := &MockOfficerProvider{ctrl: ctrl}
+	mock.recorder = &MockOfficerProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOfficerProvider) EXPECT() *MockOfficerProviderMockRecorder {
+	return m.recorder
+}
+
+// Invite mocks base method.
+func (m *MockOfficerProvider) Invite(inp *model.AddOfficerRequest) (*model.TransactionResponse, *model.BusinessError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invite", inp)
+	ret0, _ := ret[0].(*model.TransactionResponse)
+	ret1, _ := ret[1].(*model.BusinessError)
+	return ret0, ret1
+}
+
+// Invite indicates an expected call of Invite.
+func (mr *MockOfficerProviderMockRecorder) Invite(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invite", reflect.TypeOf((*MockOfficerProvider)(nil).Invite), inp)
+}
+
+// Officers mocks base method.
+func (m *MockOfficerProvider) Officers(inp *model.SessionRequest) ([]model.OfficerProjection, *model.BusinessError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Officers", inp)
+	ret0, _ := ret[0].([]model.OfficerProjection)
+	ret1, _ := ret[1].(*model.BusinessError)
+	return ret0, ret1
+}
+
+// Officers indicates an expected call of Officers.
+func (mr *MockOfficerProviderMockRecorder) Officers(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Officers", reflect.TypeOf((*MockOfficerProvider)(nil).Officers), inp)
+}
+
+// Remove mocks base method.
+func (m *MockOfficerProvider) Remove(inp *model.FindByIdRequest) (*model.TransactionResponse, *model.BusinessError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", inp)
+	ret0, _ := ret[0].(*model.TransactionResponse)
+	ret1, _ := ret[1].(*model.BusinessError)
+	return ret0, ret1
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockOfficerProviderMockRecorder) Remove(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockOfficerProvider)(nil).Remove), inp)
+}
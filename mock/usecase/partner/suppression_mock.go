@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: suppression.go
+
+// Package mock_partner is a generated GoMock package.
+package partner
+
+import (
+	reflect "reflect"
+
+	model "github.com/adinandradrs/cezbek-engine/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSuppressionProvider is a mock of SuppressionProvider interface.
+type MockSuppressionProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockSuppressionProviderMockRecorder
+}
+
+// MockSuppressionProviderMockRecorder is the mock recorder for MockSuppressionProvider.
+type MockSuppressionProviderMockRecorder struct {
+	mock *MockSuppressionProvider
+}
+
+// NewMockSuppressionProvider creates a new mock instance.
+func NewMockSuppressionProvider(ctrl *gomock.Controller) *MockSuppressionProvider {
+	mock := &MockSuppressionProvider{ctrl: ctrl}
+	mock.recorder = &MockSuppressionProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSuppressionProvider) EXPECT() *MockSuppressionProviderMockRecorder {
+	return m.recorder
+}
+
+// Search mocks base method.
+func (m *MockSuppressionProvider) Search(inp *model.SearchRequest) (*model.SuppressionSearchResponse, *model.BusinessError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", inp)
+	ret0, _ := ret[0].(*model.SuppressionSearchResponse)
+	ret1, _ := ret[1].(*model.BusinessError)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockSuppressionProviderMockRecorder) Search(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockSuppressionProvider)(nil).Search), inp)
+}
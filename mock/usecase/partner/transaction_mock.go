@@ -49,8 +49,38 @@ func (mr *MockTransactionProviderMockRecorder) Detail(inp interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Detail", reflect.TypeOf((*MockTransactionProvider)(nil).Detail), inp)
 }
 
+// Export mocks base method.
+func (m *MockTransactionProvider) Export(inp *model.TransactionExportRequest) (*model.TransactionResponse, *model.BusinessError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", inp)
+	ret0, _ := ret[0].(*model.TransactionResponse)
+	ret1, _ := ret[1].(*model.BusinessError)
+	return ret0, ret1
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockTransactionProviderMockRecorder) Export(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockTransactionProvider)(nil).Export), inp)
+}
+
+// Receipt mocks base method.
+func (m *MockTransactionProvider) Receipt(inp *model.FindByIdRequest) (*model.ReceiptResponse, *model.BusinessError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Receipt", inp)
+	ret0, _ := ret[0].(*model.ReceiptResponse)
+	ret1, _ := ret[1].(*model.BusinessError)
+	return ret0, ret1
+}
+
+// Receipt indicates an expected call of Receipt.
+func (mr *MockTransactionProviderMockRecorder) Receipt(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Receipt", reflect.TypeOf((*MockTransactionProvider)(nil).Receipt), inp)
+}
+
 // Search mocks base method.
-func (m *MockTransactionProvider) Search(inp *model.SearchRequest) (*model.PartnerTransactionSearchResponse, *model.BusinessError) {
+func (m *MockTransactionProvider) Search(inp *model.PartnerTransactionSearchRequest) (*model.PartnerTransactionSearchResponse, *model.BusinessError) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Search", inp)
 	ret0, _ := ret[0].(*model.PartnerTransactionSearchResponse)
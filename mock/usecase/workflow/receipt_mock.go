@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: receipt.go
+
+// Package mock_workflow is a generated GoMock package.
+package workflow
+
+import (
+	reflect "reflect"
+
+	model "github.com/adinandradrs/cezbek-engine/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReceiptProvider is a mock of ReceiptProvider interface.
+type MockReceiptProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockReceiptProviderMockRecorder
+}
+
+// MockReceiptProviderMockRecorder is the mock recorder for MockReceiptProvider.
+type MockReceiptProviderMockRecorder struct {
+	mock *MockReceiptProvider
+}
+
+// NewMockReceiptProvider creates a new mock instance.
+func NewMockReceiptProvider(ctrl *gomock.Controller) *MockReceiptProvider {
+	mock := &MockReceiptProvider{ctrl: ctrl}
+	mock.recorder = &MockReceiptProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReceiptProvider) EXPECT() *MockReceiptProviderMockRecorder {
+	return m.recorder
+}
+
+// Download mocks base method.
+func (m *MockReceiptProvider) Download(inp *model.ReceiptRequest) (*model.ReceiptResponse, *model.BusinessError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Download", inp)
+	ret0, _ := ret[0].(*model.ReceiptResponse)
+	ret1, _ := ret[1].(*model.BusinessError)
+	return ret0, ret1
+}
+
+// Download indicates an expected call of Download.
+func (mr *MockReceiptProviderMockRecorder) Download(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Download", reflect.TypeOf((*MockReceiptProvider)(nil).Download), inp)
+}
+
+// Generate mocks base method.
+func (m *MockReceiptProvider) Generate(inp *model.ReceiptRequest) (*model.ReceiptResponse, *model.BusinessError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Generate", inp)
+	ret0, _ := ret[0].(*model.ReceiptResponse)
+	ret1, _ := ret[1].(*model.BusinessError)
+	return ret0, ret1
+}
+
+// Generate indicates an expected call of Generate.
+func (mr *MockReceiptProviderMockRecorder) Generate(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generate", reflect.TypeOf((*MockReceiptProvider)(nil).Generate), inp)
+}
@@ -34,17 +34,43 @@ func (m *MockTierProvider) EXPECT() *MockTierProviderMockRecorder {
 	return m.recorder
 }
 
-// Save mocks base method.
-func (m *MockTierProvider) Save(inp *model.TierRequest) (*model.WfRewardTierProjection, *model.TechnicalError) {
+// Evaluate mocks base method.
+func (m *MockTierProvider) Evaluate(inp *model.TierRequest) (*model.TierChange, *model.TechnicalError) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Save", inp)
-	ret0, _ := ret[0].(*model.WfRewardTierProjection)
+	ret := m.ctrl.Call(m, "Evaluate", inp)
+	ret0, _ := ret[0].(*model.TierChange)
 	ret1, _ := ret[1].(*model.TechnicalError)
 	return ret0, ret1
 }
 
+// Evaluate indicates an expected call of Evaluate.
+func (mr *MockTierProviderMockRecorder) Evaluate(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Evaluate", reflect.TypeOf((*MockTierProvider)(nil).Evaluate), inp)
+}
+
+// NotifyUpgrade mocks base method.
+func (m *MockTierProvider) NotifyUpgrade(inp *model.TierRequest, v *model.TierChange) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NotifyUpgrade", inp, v)
+}
+
+// NotifyUpgrade indicates an expected call of NotifyUpgrade.
+func (mr *MockTierProviderMockRecorder) NotifyUpgrade(inp, v interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyUpgrade", reflect.TypeOf((*MockTierProvider)(nil).NotifyUpgrade), inp, v)
+}
+
+// Save mocks base method.
+func (m *MockTierProvider) Save(inp *model.TierRequest, c *model.TierChange) *model.TechnicalError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", inp, c)
+	ret0, _ := ret[0].(*model.TechnicalError)
+	return ret0
+}
+
 // Save indicates an expected call of Save.
-func (mr *MockTierProviderMockRecorder) Save(inp interface{}) *gomock.Call {
+func (mr *MockTierProviderMockRecorder) Save(inp, c interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockTierProvider)(nil).Save), inp)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockTierProvider)(nil).Save), inp, c)
 }